@@ -198,6 +198,9 @@ Requirements:
 """
         
         if context:
+            language = context.get("language")
+            if language:
+                base_prompt += f"Write the scenario in {language}.\n"
             base_prompt += f"Additional context: {context}\n"
         
         base_prompt += "Generate only the door scenario text, no additional formatting or explanation."
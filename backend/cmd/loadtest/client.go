@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"dumdoors-backend/internal/services"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fasthttp/websocket"
+)
+
+// apiClient wraps the REST endpoints exercised by the load test
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+	recorder   *LatencyRecorder
+	// Secret simulated players' token requests are signed with, as the Devvit app would
+	devvitSecret string
+}
+
+// tokenEnvelope is the subset of the token response the load test needs
+type tokenEnvelope struct {
+	Auth struct {
+		Token string `json:"token"`
+	} `json:"auth"`
+}
+
+// sessionEnvelope is the subset of the session response the load test needs
+type sessionEnvelope struct {
+	Success bool `json:"success"`
+	Session struct {
+		SessionID string `json:"sessionId"`
+	} `json:"session"`
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// post sends a JSON body with the given headers and records the latency under op
+func (c *apiClient) post(op, path string, headers map[string]string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", op, err)
+	}
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build %s request: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.recorder.Fail(op)
+		return fmt.Errorf("%s request failed: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	elapsed := time.Since(start)
+	if err != nil {
+		c.recorder.Fail(op)
+		return fmt.Errorf("failed to read %s response: %w", op, err)
+	}
+
+	if resp.StatusCode >= 300 {
+		c.recorder.Fail(op)
+		return fmt.Errorf("%s returned %d: %s", op, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	c.recorder.Record(op, elapsed)
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode %s response: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// issueToken exchanges a simulated player's Reddit identity for an access token, signing the
+// request like the Devvit app when a secret is configured
+func (c *apiClient) issueToken(playerID, username string) (string, error) {
+	headers := map[string]string{
+		"X-Reddit-User-ID":  playerID,
+		"X-Reddit-Username": username,
+	}
+	if c.devvitSecret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		headers[services.DevvitTimestampHeader] = timestamp
+		headers[services.DevvitSignatureHeader] = services.SignDevvitRequest(c.devvitSecret, timestamp, playerID, username, "")
+	}
+
+	var resp tokenEnvelope
+	if err := c.post("auth", "/api/auth/token", headers, map[string]interface{}{}, &resp); err != nil {
+		return "", err
+	}
+	if resp.Auth.Token == "" {
+		return "", fmt.Errorf("auth returned no token")
+	}
+	return resp.Auth.Token, nil
+}
+
+// bearer returns the headers authenticating a request with a player's token
+func bearer(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
+// createSession creates a multiplayer session and returns its ID
+func (c *apiClient) createSession(token, playerID, username string) (string, error) {
+	var resp sessionEnvelope
+	err := c.post("create", "/api/game/create", bearer(token), map[string]interface{}{
+		"mode":     "multiplayer",
+		"playerId": playerID,
+		"username": username,
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.Session.SessionID == "" {
+		return "", fmt.Errorf("create returned no session ID")
+	}
+	return resp.Session.SessionID, nil
+}
+
+// joinSession joins a player to a session
+func (c *apiClient) joinSession(token, sessionID, playerID, username string) error {
+	return c.post("join", "/api/game/join/"+sessionID, bearer(token), map[string]interface{}{
+		"playerId": playerID,
+		"username": username,
+	}, nil)
+}
+
+// startSession starts the game and presents the first door
+func (c *apiClient) startSession(token, sessionID string) error {
+	return c.post("start", "/api/game/start-with-door/"+sessionID, bearer(token), map[string]interface{}{}, nil)
+}
+
+// submitResponse submits a player's response to the current door
+func (c *apiClient) submitResponse(token, sessionID, playerID, response string) error {
+	return c.post("submit", "/api/game/submit-response", bearer(token), map[string]interface{}{
+		"sessionId": sessionID,
+		"playerId":  playerID,
+		"response":  response,
+	}, nil)
+}
+
+// inboundEvent is the subset of a WebSocket event the load test tracks
+type inboundEvent struct {
+	Type      string `json:"type"`
+	SessionID string `json:"sessionId"`
+	PlayerID  string `json:"playerId"`
+}
+
+// playerSocket is a WebSocket connection that tallies received event types
+type playerSocket struct {
+	conn *websocket.Conn
+
+	mu      sync.Mutex
+	counts  map[string]int
+	waiters map[string][]chan struct{}
+	closed  chan struct{}
+}
+
+// dialPlayer opens the game WebSocket for a player, authenticated with their token
+func dialPlayer(baseURL, token, sessionID, playerID string, recorder *LatencyRecorder) (*playerSocket, error) {
+	wsURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if wsURL.Scheme == "https" {
+		wsURL.Scheme = "wss"
+	} else {
+		wsURL.Scheme = "ws"
+	}
+	wsURL.Path = "/api/ws/connect"
+	wsURL.RawQuery = url.Values{"sessionId": {sessionID}, "playerId": {playerID}, "token": {token}}.Encode()
+
+	start := time.Now()
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL.String(), nil)
+	if err != nil {
+		recorder.Fail("ws-connect")
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+	recorder.Record("ws-connect", time.Since(start))
+
+	socket := &playerSocket{
+		conn:    conn,
+		counts:  make(map[string]int),
+		waiters: make(map[string][]chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	go socket.readLoop()
+
+	return socket, nil
+}
+
+// readLoop consumes events until the connection closes
+func (s *playerSocket) readLoop() {
+	defer close(s.closed)
+
+	for {
+		var event inboundEvent
+		if err := s.conn.ReadJSON(&event); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.counts[event.Type]++
+		for _, waiter := range s.waiters[event.Type] {
+			close(waiter)
+		}
+		delete(s.waiters, event.Type)
+		s.mu.Unlock()
+	}
+}
+
+// waitFor blocks until the socket has seen at least n events of the given type
+func (s *playerSocket) waitFor(eventType string, n int, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+
+	for {
+		s.mu.Lock()
+		if s.counts[eventType] >= n {
+			s.mu.Unlock()
+			return true
+		}
+		waiter := make(chan struct{})
+		s.waiters[eventType] = append(s.waiters[eventType], waiter)
+		s.mu.Unlock()
+
+		select {
+		case <-waiter:
+		case <-s.closed:
+			return false
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// count returns how many events of a type were received
+func (s *playerSocket) count(eventType string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[eventType]
+}
+
+// close shuts the connection
+func (s *playerSocket) close() {
+	s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	s.conn.Close()
+}
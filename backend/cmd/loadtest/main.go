@@ -0,0 +1,294 @@
+// Command loadtest simulates many concurrent DumDoors sessions against a running
+// backend over REST and WebSocket, with each simulated player authenticated by its
+// own access token. It reports latency percentiles for auth, create, join, start,
+// submit and WebSocket connect, verifies that every player receives
+// the events it should, and exits non-zero when a configured gate is breached so
+// it can be used as a CI performance check.
+//
+// Example:
+//
+//	go run ./cmd/loadtest -url http://localhost:8080 -sessions 200 -players 4 -rounds 2 -max-p95-submit 250ms
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// loadTestConfig holds command line options
+type loadTestConfig struct {
+	baseURL      string
+	sessions     int
+	players      int
+	rounds       int
+	concurrency  int
+	eventTimeout time.Duration
+	maxP95Submit time.Duration
+	maxP95Create time.Duration
+	maxFailures  int
+	jsonOutput   bool
+	devvitSecret string
+}
+
+// eventLoss records an event a player should have received but did not
+type eventLoss struct {
+	SessionID string `json:"sessionId"`
+	PlayerID  string `json:"playerId"`
+	EventType string `json:"eventType"`
+	Expected  int    `json:"expected"`
+	Received  int    `json:"received"`
+}
+
+// report is the machine-readable result of a run
+type report struct {
+	Sessions         int                `json:"sessions"`
+	PlayersPerRoom   int                `json:"playersPerSession"`
+	Rounds           int                `json:"rounds"`
+	Duration         string             `json:"duration"`
+	CompletedSession int64              `json:"completedSessions"`
+	FailedSessions   int64              `json:"failedSessions"`
+	Operations       []OperationSummary `json:"operations"`
+	LostEvents       []eventLoss        `json:"lostEvents"`
+	GateFailures     []string           `json:"gateFailures"`
+}
+
+func main() {
+	cfg := parseFlags()
+
+	recorder := NewLatencyRecorder()
+	client := &apiClient{
+		baseURL:      cfg.baseURL,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		recorder:     recorder,
+		devvitSecret: cfg.devvitSecret,
+	}
+
+	var (
+		completed int64
+		failed    int64
+		lossMu    sync.Mutex
+		losses    []eventLoss
+	)
+
+	start := time.Now()
+	sem := make(chan struct{}, cfg.concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < cfg.sessions; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			sessionLosses, err := runSession(client, cfg, index)
+			if err != nil {
+				atomic.AddInt64(&failed, 1)
+				fmt.Fprintf(os.Stderr, "session %d failed: %v\n", index, err)
+			} else {
+				atomic.AddInt64(&completed, 1)
+			}
+
+			if len(sessionLosses) > 0 {
+				lossMu.Lock()
+				losses = append(losses, sessionLosses...)
+				lossMu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	result := report{
+		Sessions:         cfg.sessions,
+		PlayersPerRoom:   cfg.players,
+		Rounds:           cfg.rounds,
+		Duration:         time.Since(start).Round(time.Millisecond).String(),
+		CompletedSession: completed,
+		FailedSessions:   failed,
+		Operations:       recorder.Summaries(),
+		LostEvents:       losses,
+	}
+	result.GateFailures = evaluateGates(cfg, result)
+
+	if cfg.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(result)
+	} else {
+		fmt.Printf("Ran %d sessions x %d players x %d rounds in %s (%d completed, %d failed)\n\n",
+			cfg.sessions, cfg.players, cfg.rounds, result.Duration, completed, failed)
+		printSummaries(os.Stdout, result.Operations)
+		fmt.Printf("\nLost events: %d\n", len(losses))
+		for _, gate := range result.GateFailures {
+			fmt.Printf("GATE FAILED: %s\n", gate)
+		}
+	}
+
+	if len(result.GateFailures) > 0 {
+		os.Exit(1)
+	}
+}
+
+// parseFlags reads the load test options
+func parseFlags() loadTestConfig {
+	cfg := loadTestConfig{}
+	flag.StringVar(&cfg.baseURL, "url", "http://localhost:8080", "Base URL of the backend")
+	flag.IntVar(&cfg.sessions, "sessions", 100, "Number of sessions to simulate")
+	flag.IntVar(&cfg.players, "players", 4, "Players per session (2-8)")
+	flag.IntVar(&cfg.rounds, "rounds", 1, "Doors to play per session")
+	flag.IntVar(&cfg.concurrency, "concurrency", 50, "Sessions running at the same time")
+	flag.DurationVar(&cfg.eventTimeout, "event-timeout", 15*time.Second, "How long to wait for each expected WebSocket event")
+	flag.DurationVar(&cfg.maxP95Submit, "max-p95-submit", 0, "Fail if p95 submit latency exceeds this (0 disables)")
+	flag.DurationVar(&cfg.maxP95Create, "max-p95-create", 0, "Fail if p95 create latency exceeds this (0 disables)")
+	flag.IntVar(&cfg.maxFailures, "max-failures", 0, "Maximum failed sessions before the run fails")
+	flag.BoolVar(&cfg.jsonOutput, "json", false, "Print the report as JSON")
+	flag.StringVar(&cfg.devvitSecret, "devvit-secret", os.Getenv("DEVVIT_REQUEST_SECRET"), "Secret to sign token requests with (defaults to DEVVIT_REQUEST_SECRET)")
+	flag.Parse()
+
+	if cfg.players < 2 {
+		cfg.players = 2
+	}
+	if cfg.players > 8 {
+		cfg.players = 8
+	}
+	if cfg.concurrency < 1 {
+		cfg.concurrency = 1
+	}
+	if cfg.rounds < 1 {
+		cfg.rounds = 1
+	}
+
+	return cfg
+}
+
+// runSession plays one session end to end and returns any events that never arrived
+func runSession(client *apiClient, cfg loadTestConfig, index int) ([]eventLoss, error) {
+	runID := time.Now().UnixNano()
+	playerIDs := make([]string, cfg.players)
+	usernames := make([]string, cfg.players)
+	tokens := make(map[string]string, cfg.players)
+	for i := range playerIDs {
+		playerIDs[i] = fmt.Sprintf("lt_%d_%d_%d", runID, index, i)
+		usernames[i] = fmt.Sprintf("loadtest-%d", i)
+		if i == 0 {
+			usernames[i] = "loadtest-host"
+		}
+
+		token, err := client.issueToken(playerIDs[i], usernames[i])
+		if err != nil {
+			return nil, err
+		}
+		tokens[playerIDs[i]] = token
+	}
+	host := playerIDs[0]
+
+	sessionID, err := client.createSession(tokens[host], host, usernames[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(playerIDs); i++ {
+		if err := client.joinSession(tokens[playerIDs[i]], sessionID, playerIDs[i], usernames[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	sockets := make(map[string]*playerSocket, len(playerIDs))
+	defer func() {
+		for _, socket := range sockets {
+			socket.close()
+		}
+	}()
+
+	for _, playerID := range playerIDs {
+		socket, err := dialPlayer(cfg.baseURL, tokens[playerID], sessionID, playerID, client.recorder)
+		if err != nil {
+			return nil, err
+		}
+		sockets[playerID] = socket
+	}
+
+	if err := client.startSession(tokens[host], sessionID); err != nil {
+		return nil, err
+	}
+
+	var losses []eventLoss
+	expect := func(playerID, eventType string, n int) bool {
+		socket := sockets[playerID]
+		if socket.waitFor(eventType, n, cfg.eventTimeout) {
+			return true
+		}
+		losses = append(losses, eventLoss{
+			SessionID: sessionID,
+			PlayerID:  playerID,
+			EventType: eventType,
+			Expected:  n,
+			Received:  socket.count(eventType),
+		})
+		return false
+	}
+
+	for round := 1; round <= cfg.rounds; round++ {
+		for _, playerID := range playerIDs {
+			if !expect(playerID, "door-presented", round) {
+				return losses, fmt.Errorf("round %d: door was not presented to %s", round, playerID)
+			}
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, len(playerIDs))
+		for _, playerID := range playerIDs {
+			wg.Add(1)
+			go func(playerID string) {
+				defer wg.Done()
+				response := fmt.Sprintf("Load test response from %s for round %d: I would calmly improvise.", playerID, round)
+				if err := client.submitResponse(tokens[playerID], sessionID, playerID, response); err != nil {
+					errs <- err
+				}
+			}(playerID)
+		}
+		wg.Wait()
+		close(errs)
+		if err := <-errs; err != nil {
+			return losses, err
+		}
+
+		// Every player should see every submission and the end-of-round scores
+		for _, playerID := range playerIDs {
+			expect(playerID, "response-submitted", round*len(playerIDs))
+			expect(playerID, "scores-updated", round)
+		}
+	}
+
+	return losses, nil
+}
+
+// evaluateGates checks the run against the configured CI thresholds
+func evaluateGates(cfg loadTestConfig, result report) []string {
+	var failures []string
+
+	if int(result.FailedSessions) > cfg.maxFailures {
+		failures = append(failures, fmt.Sprintf("%d sessions failed (max %d)", result.FailedSessions, cfg.maxFailures))
+	}
+
+	if len(result.LostEvents) > 0 {
+		failures = append(failures, fmt.Sprintf("%d expected events were not received", len(result.LostEvents)))
+	}
+
+	for _, op := range result.Operations {
+		switch {
+		case op.Operation == "submit" && cfg.maxP95Submit > 0 && op.P95Ms > toMs(cfg.maxP95Submit):
+			failures = append(failures, fmt.Sprintf("p95 submit latency %.2fms exceeds %s", op.P95Ms, cfg.maxP95Submit))
+		case op.Operation == "create" && cfg.maxP95Create > 0 && op.P95Ms > toMs(cfg.maxP95Create):
+			failures = append(failures, fmt.Sprintf("p95 create latency %.2fms exceeds %s", op.P95Ms, cfg.maxP95Create))
+		}
+	}
+
+	return failures
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyRecorder collects latency samples per operation
+type LatencyRecorder struct {
+	mu       sync.Mutex
+	samples  map[string][]time.Duration
+	failures map[string]int
+}
+
+// NewLatencyRecorder creates an empty latency recorder
+func NewLatencyRecorder() *LatencyRecorder {
+	return &LatencyRecorder{
+		samples:  make(map[string][]time.Duration),
+		failures: make(map[string]int),
+	}
+}
+
+// Record stores a successful sample for an operation
+func (r *LatencyRecorder) Record(op string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples[op] = append(r.samples[op], d)
+}
+
+// Fail counts a failed attempt for an operation
+func (r *LatencyRecorder) Fail(op string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[op]++
+}
+
+// OperationSummary holds the percentile breakdown for one operation
+type OperationSummary struct {
+	Operation string  `json:"operation"`
+	Count     int     `json:"count"`
+	Failures  int     `json:"failures"`
+	P50Ms     float64 `json:"p50Ms"`
+	P90Ms     float64 `json:"p90Ms"`
+	P95Ms     float64 `json:"p95Ms"`
+	P99Ms     float64 `json:"p99Ms"`
+	MaxMs     float64 `json:"maxMs"`
+}
+
+// Summaries returns percentile summaries sorted by operation name
+func (r *LatencyRecorder) Summaries() []OperationSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ops := make(map[string]struct{})
+	for op := range r.samples {
+		ops[op] = struct{}{}
+	}
+	for op := range r.failures {
+		ops[op] = struct{}{}
+	}
+
+	var summaries []OperationSummary
+	for op := range ops {
+		samples := append([]time.Duration(nil), r.samples[op]...)
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+		summary := OperationSummary{
+			Operation: op,
+			Count:     len(samples),
+			Failures:  r.failures[op],
+		}
+		if len(samples) > 0 {
+			summary.P50Ms = toMs(percentile(samples, 50))
+			summary.P90Ms = toMs(percentile(samples, 90))
+			summary.P95Ms = toMs(percentile(samples, 95))
+			summary.P99Ms = toMs(percentile(samples, 99))
+			summary.MaxMs = toMs(samples[len(samples)-1])
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Operation < summaries[j].Operation })
+	return summaries
+}
+
+// percentile returns the nearest-rank percentile of sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+func toMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// printSummaries writes a human readable latency table
+func printSummaries(w io.Writer, summaries []OperationSummary) {
+	fmt.Fprintf(w, "%-16s %8s %8s %10s %10s %10s %10s %10s\n", "operation", "count", "failed", "p50(ms)", "p90(ms)", "p95(ms)", "p99(ms)", "max(ms)")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%-16s %8d %8d %10.2f %10.2f %10.2f %10.2f %10.2f\n", s.Operation, s.Count, s.Failures, s.P50Ms, s.P90Ms, s.P95Ms, s.P99Ms, s.MaxMs)
+	}
+}
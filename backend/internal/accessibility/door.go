@@ -0,0 +1,160 @@
+package accessibility
+
+import (
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Content warning categories
+const (
+	WarningViolence = "violence"
+	WarningDeath    = "death"
+	WarningAlcohol  = "alcohol"
+	WarningPhobia   = "phobia"
+	WarningMedical  = "medical"
+)
+
+// warningKeywords maps each content warning to the word stems that trigger it
+var warningKeywords = map[string][]string{
+	WarningViolence: {"attack", "blood", "fight", "gun", "kill", "knife", "murder", "weapon"},
+	WarningDeath:    {"dead", "death", "die", "dying", "funeral", "grave"},
+	WarningAlcohol:  {"alcohol", "beer", "drunk", "hangover", "wine"},
+	WarningPhobia:   {"claustrophob", "heights", "snake", "spider", "trapped"},
+	WarningMedical:  {"hospital", "injur", "surgery", "wound"},
+}
+
+// difficultyLabels names door difficulty levels in alt text
+var difficultyLabels = map[int]string{
+	1: "easy",
+	2: "medium",
+	3: "hard",
+}
+
+// Describe fills in any missing accessibility metadata for a door from its content
+func Describe(door *models.Door) {
+	if door == nil || door.Accessibility != nil {
+		return
+	}
+
+	metadata := &models.DoorAccessibility{
+		AltText:         altText(door),
+		ContentWarnings: contentWarnings(door.Content),
+	}
+	if i18n.IsDefault(door.Locale) {
+		metadata.ReadingLevel = readingLevel(door.Content)
+	}
+
+	door.Accessibility = metadata
+}
+
+// altText summarizes the door in one sentence for screen readers
+func altText(door *models.Door) string {
+	label, exists := difficultyLabels[door.Difficulty]
+	if !exists {
+		label = "unrated"
+	}
+
+	theme := door.Theme
+	if theme == "" {
+		theme = "general"
+	}
+
+	article := "A"
+	if strings.ContainsRune("aeiou", rune(label[0])) {
+		article = "An"
+	}
+
+	return fmt.Sprintf("%s %s %s door: %s", article, label, theme, firstSentence(door.Content))
+}
+
+// firstSentence returns the content up to and including its first sentence terminator
+func firstSentence(content string) string {
+	content = strings.TrimSpace(content)
+	if i := strings.IndexAny(content, ".!?"); i >= 0 {
+		return content[:i+1]
+	}
+	return content
+}
+
+// contentWarnings returns the sorted warning categories whose keywords appear in the content
+func contentWarnings(content string) []string {
+	words := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	warnings := []string{}
+	for warning, stems := range warningKeywords {
+		if containsStem(words, stems) {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	sort.Strings(warnings)
+	return warnings
+}
+
+// containsStem reports whether any word starts with any of the stems
+func containsStem(words, stems []string) bool {
+	for _, word := range words {
+		for _, stem := range stems {
+			if strings.HasPrefix(word, stem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readingLevel estimates the Flesch-Kincaid grade level of English text, rounded to one decimal
+func readingLevel(content string) float64 {
+	words := strings.FieldsFunc(content, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) == 0 {
+		return 0
+	}
+
+	sentences := strings.Count(content, ".") + strings.Count(content, "!") + strings.Count(content, "?")
+	if sentences == 0 {
+		sentences = 1
+	}
+
+	syllables := 0
+	for _, word := range words {
+		syllables += countSyllables(word)
+	}
+
+	grade := 0.39*float64(len(words))/float64(sentences) + 11.8*float64(syllables)/float64(len(words)) - 15.59
+	if grade < 0 {
+		grade = 0
+	}
+	return math.Round(grade*10) / 10
+}
+
+// countSyllables approximates syllables as vowel groups, ignoring a silent trailing "e"
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+
+	count := 0
+	previousVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !previousVowel {
+			count++
+		}
+		previousVowel = vowel
+	}
+
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
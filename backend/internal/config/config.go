@@ -1,33 +1,368 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Port        string
-	MongoURI    string
-	Neo4jURI    string
-	Neo4jUser   string
-	Neo4jPass   string
-	RedisURI    string
-	AIServiceURL string
-	Environment string
+	Port                       string
+	MongoURI                   string
+	Neo4jURI                   string
+	Neo4jUser                  string
+	Neo4jPass                  string
+	RedisURI                   string
+	AIServiceURL               string
+	AITransport                string
+	AIGRPCAddr                 string
+	AIGRPCPoolSize             int
+	AIGRPCDeadline             time.Duration
+	Environment                string
+	LogLevel                   string
+	WorkerPoolSize             int
+	WorkerPoolQueueSize        int
+	ProgressBroadcastInterval  time.Duration
+	AIScoringConcurrency       int
+	AIScoringRatePerSec        float64
+	AIScoringBatchSize         int
+	AIScoringBatchWindow       time.Duration
+	AIScoringStreaming         bool
+	ScoringStrategy            string
+	Experiments                []string
+	LeaderboardRefreshInterval time.Duration
+	WSSendQueueSize            int
+	WSOverflowPolicy           string
+	WSWriteTimeout             time.Duration
+	WSPingInterval             time.Duration
+	WSMaxMissedPongs           int
+	WSCompression              bool
+	WSCompressionThreshold     int
+	WSResyncBufferSize         int
+	WSTimerTickInterval        time.Duration
+	WSStickyRouting            bool
+	InstanceID                 string
+	RoutingSecret              string
+	SessionSnapshotCache       bool
+	BackgroundTaskTimeout      time.Duration
+	DeterministicSeed          int64
+	MatchmakingInterval        time.Duration
+	MatchmakingMaxWait         time.Duration
+	SchedulerPollInterval      time.Duration
+	RoundSnapshotInterval      time.Duration
+	TournamentPollInterval     time.Duration
+	JWTSecret                  string
+	JWTTTL                     time.Duration
+	AdminPlayerIDs             []string
+	ModeratorPlayerIDs         []string
+	ServiceAPIKeys             []string
+	StaffAPIKeys               []string
+	ModerationDenylist         []string
+	ModerationRejectPatterns   []string
+	ModerationMode             string
+	ModerationAIEnabled        bool
+	ResponseRevealEnabled      bool
+	DevvitCallbackURL          string
+	DevvitCallbackSecret       string
+	DevvitRequestSecret        string
+	ReplayBufferSize           int
+	ChatRateLimit              int
+	ChatRateWindow             time.Duration
+	IdempotencyTTL             time.Duration
+	SessionInactivityTimeout   time.Duration
+	SessionJanitorInterval     time.Duration
+	SessionMetricsInterval     time.Duration
+	DrainWindow                time.Duration
+	DoorCalibrationInterval    time.Duration
+	DoorCalibrationMinScores   int
+	DoorSimilarityThreshold    float64
+	DoorBankSize               int
+	DoorBankRefillInterval     time.Duration
+	DoorBankLocales            []string
+	AntiCheatMode              string
+	AntiCheatPenalty           float64
+	AntiCheatThreshold         float64
+	AnomalyFastThreshold       time.Duration
+	AnomalyFastLimit           int
+	AnomalyWindow              int
+	SessionLimitPerSubreddit   int
+	SessionLimitPerPlayer      int
+	MongoPool                  MongoPoolConfig
+	Neo4jPool                  Neo4jPoolConfig
+	RedisPool                  RedisPoolConfig
+	RateLimit                  RateLimitConfig
+	Tracing                    TracingConfig
+
+	// values that didn't parse and fell back to their defaults, reported by Validate
+	parseErrors []string
+}
+
+// Deterministic reports whether randomness is seeded and AI calls are frozen to the mock
+// provider, for reproducible end-to-end tests and demos. Enabled by a non-zero DETERMINISTIC_SEED.
+func (c *Config) Deterministic() bool {
+	return c.DeterministicSeed != 0
+}
+
+// MongoPoolConfig holds MongoDB connection pool settings
+type MongoPoolConfig struct {
+	MaxPoolSize     uint64
+	MinPoolSize     uint64
+	MaxConnIdleTime time.Duration
+	ConnectTimeout  time.Duration
+}
+
+// Neo4jPoolConfig holds Neo4j driver pool settings
+type Neo4jPoolConfig struct {
+	MaxConnections        int
+	AcquisitionTimeout    time.Duration
+	MaxConnectionLifetime time.Duration
 }
 
-// Load loads configuration from environment variables
+// RedisPoolConfig holds Redis client pool settings and timeouts
+type RedisPoolConfig struct {
+	PoolSize     int
+	MinIdleConns int
+	PoolTimeout  time.Duration
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// RateLimitConfig holds the request limits applied per route group
+type RateLimitConfig struct {
+	SubmitLimit       int
+	SubmitWindow      time.Duration
+	LeaderboardLimit  int
+	LeaderboardWindow time.Duration
+}
+
+// TracingConfig holds OpenTelemetry trace export settings; tracing is off without an endpoint
+type TracingConfig struct {
+	Endpoint    string
+	ServiceName string
+	SampleRatio float64
+}
+
+// Load loads configuration from environment variables. A value that doesn't parse falls back to
+// its default; Validate reports it.
 func Load() *Config {
-	return &Config{
-		Port:         getEnv("PORT", "8080"),
-		MongoURI:     getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		Neo4jURI:     getEnv("NEO4J_URI", "bolt://localhost:7687"),
-		Neo4jUser:    getEnv("NEO4J_USER", "neo4j"),
-		Neo4jPass:    getEnv("NEO4J_PASS", "password"),
-		RedisURI:     getEnv("REDIS_URI", "redis://localhost:6379"),
-		AIServiceURL: getEnv("AI_SERVICE_URL", "http://localhost:8000"),
-		Environment:  getEnv("ENVIRONMENT", "development"),
+	l := &loader{}
+	cfg := &Config{
+		Port:                       l.getEnv("PORT", "8080"),
+		MongoURI:                   l.getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		Neo4jURI:                   l.getEnv("NEO4J_URI", "bolt://localhost:7687"),
+		Neo4jUser:                  l.getEnv("NEO4J_USER", "neo4j"),
+		Neo4jPass:                  l.getEnv("NEO4J_PASS", "password"),
+		RedisURI:                   l.getEnv("REDIS_URI", "redis://localhost:6379"),
+		AIServiceURL:               l.getEnv("AI_SERVICE_URL", "http://localhost:8000"),
+		AITransport:                l.getEnv("AI_TRANSPORT", "http"),
+		AIGRPCAddr:                 l.getEnv("AI_GRPC_ADDR", "localhost:50051"),
+		AIGRPCPoolSize:             l.getEnvInt("AI_GRPC_POOL_SIZE", 4),
+		AIGRPCDeadline:             l.getEnvDuration("AI_GRPC_DEADLINE", 5*time.Second),
+		Environment:                l.getEnv("ENVIRONMENT", "development"),
+		LogLevel:                   l.getEnv("LOG_LEVEL", defaultLogLevel()),
+		WorkerPoolSize:             l.getEnvInt("WORKER_POOL_SIZE", 16),
+		WorkerPoolQueueSize:        l.getEnvInt("WORKER_POOL_QUEUE_SIZE", 1024),
+		ProgressBroadcastInterval:  l.getEnvDuration("PROGRESS_BROADCAST_INTERVAL", 500*time.Millisecond),
+		AIScoringConcurrency:       l.getEnvInt("AI_SCORING_CONCURRENCY", 4),
+		AIScoringRatePerSec:        l.getEnvFloat("AI_SCORING_RATE_PER_SEC", 20),
+		AIScoringBatchSize:         l.getEnvInt("AI_SCORING_BATCH_SIZE", 8),
+		AIScoringBatchWindow:       l.getEnvDuration("AI_SCORING_BATCH_WINDOW", 50*time.Millisecond),
+		AIScoringStreaming:         l.getEnvBool("AI_SCORING_STREAMING", false),
+		ScoringStrategy:            l.getEnv("SCORING_STRATEGY", "average"),
+		Experiments:                l.getEnvList("EXPERIMENTS"),
+		LeaderboardRefreshInterval: l.getEnvDuration("LEADERBOARD_REFRESH_INTERVAL", time.Minute),
+		WSSendQueueSize:            l.getEnvInt("WS_SEND_QUEUE_SIZE", 256),
+		WSOverflowPolicy:           l.getEnv("WS_OVERFLOW_POLICY", "disconnect"),
+		WSWriteTimeout:             l.getEnvDuration("WS_WRITE_TIMEOUT", 10*time.Second),
+		WSPingInterval:             l.getEnvDuration("WS_PING_INTERVAL", 30*time.Second),
+		WSMaxMissedPongs:           l.getEnvInt("WS_MAX_MISSED_PONGS", 3),
+		WSCompression:              l.getEnvBool("WS_COMPRESSION", false),
+		WSCompressionThreshold:     l.getEnvInt("WS_COMPRESSION_THRESHOLD", 1024),
+		WSResyncBufferSize:         l.getEnvInt("WS_RESYNC_BUFFER_SIZE", 100),
+		WSTimerTickInterval:        l.getEnvDuration("WS_TIMER_TICK_INTERVAL", 10*time.Second),
+		WSStickyRouting:            l.getEnvBool("WS_STICKY_ROUTING", false),
+		InstanceID:                 l.getEnv("INSTANCE_ID", ""),
+		RoutingSecret:              l.getEnv("ROUTING_SECRET", ""),
+		SessionSnapshotCache:       l.getEnvBool("SESSION_SNAPSHOT_CACHE", false),
+		BackgroundTaskTimeout:      l.getEnvDuration("BACKGROUND_TASK_TIMEOUT", 30*time.Second),
+		DeterministicSeed:          int64(l.getEnvInt("DETERMINISTIC_SEED", 0)),
+		MatchmakingInterval:        l.getEnvDuration("MATCHMAKING_INTERVAL", 2*time.Second),
+		MatchmakingMaxWait:         l.getEnvDuration("MATCHMAKING_MAX_WAIT", 15*time.Second),
+		SchedulerPollInterval:      l.getEnvDuration("SCHEDULER_POLL_INTERVAL", time.Second),
+		RoundSnapshotInterval:      l.getEnvDuration("ROUND_SNAPSHOT_INTERVAL", 5*time.Second),
+		TournamentPollInterval:     l.getEnvDuration("TOURNAMENT_POLL_INTERVAL", 5*time.Second),
+		JWTSecret:                  l.getEnv("JWT_SECRET", ""),
+		JWTTTL:                     l.getEnvDuration("JWT_TTL", 15*time.Minute),
+		AdminPlayerIDs:             l.getEnvList("ADMIN_PLAYER_IDS"),
+		ModeratorPlayerIDs:         l.getEnvList("MODERATOR_PLAYER_IDS"),
+		ServiceAPIKeys:             l.getSecretList("SERVICE_API_KEYS"),
+		StaffAPIKeys:               l.getSecretList("STAFF_API_KEYS"),
+		ModerationDenylist:         l.getEnvList("MODERATION_DENYLIST"),
+		ModerationRejectPatterns:   l.getEnvSplit("MODERATION_REJECT_PATTERNS", ";"),
+		ModerationMode:             l.getEnv("MODERATION_MODE", "mask"),
+		ModerationAIEnabled:        l.getEnvBool("MODERATION_AI_ENABLED", false),
+		ResponseRevealEnabled:      l.getEnvBool("RESPONSE_REVEAL_ENABLED", true),
+		DevvitCallbackURL:          l.getEnv("DEVVIT_CALLBACK_URL", ""),
+		DevvitCallbackSecret:       l.getEnv("DEVVIT_CALLBACK_SECRET", ""),
+		DevvitRequestSecret:        l.getEnv("DEVVIT_REQUEST_SECRET", ""),
+		ReplayBufferSize:           l.getEnvInt("REPLAY_BUFFER_SIZE", 1024),
+		ChatRateLimit:              l.getEnvInt("CHAT_RATE_LIMIT", 5),
+		ChatRateWindow:             l.getEnvDuration("CHAT_RATE_WINDOW", 10*time.Second),
+		IdempotencyTTL:             l.getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		SessionInactivityTimeout:   l.getEnvDuration("SESSION_INACTIVITY_TIMEOUT", 30*time.Minute),
+		SessionJanitorInterval:     l.getEnvDuration("SESSION_JANITOR_INTERVAL", 5*time.Minute),
+		SessionMetricsInterval:     l.getEnvDuration("SESSION_METRICS_INTERVAL", 30*time.Second),
+		DrainWindow:                l.getEnvDuration("DRAIN_WINDOW", 30*time.Second),
+		DoorCalibrationInterval:    l.getEnvDuration("DOOR_CALIBRATION_INTERVAL", time.Hour),
+		DoorCalibrationMinScores:   l.getEnvInt("DOOR_CALIBRATION_MIN_SCORES", 20),
+		DoorSimilarityThreshold:    l.getEnvFloat("DOOR_SIMILARITY_THRESHOLD", 0.8),
+		DoorBankSize:               l.getEnvInt("DOOR_BANK_SIZE", 5),
+		DoorBankRefillInterval:     l.getEnvDuration("DOOR_BANK_REFILL_INTERVAL", time.Minute),
+		DoorBankLocales:            l.getEnvList("DOOR_BANK_LOCALES"),
+		AntiCheatMode:              l.getEnv("ANTI_CHEAT_MODE", "penalize"),
+		AntiCheatPenalty:           l.getEnvFloat("ANTI_CHEAT_PENALTY", 0.5),
+		AntiCheatThreshold:         l.getEnvFloat("ANTI_CHEAT_SIMILARITY_THRESHOLD", 0.8),
+		AnomalyFastThreshold:       l.getEnvDuration("ANOMALY_FAST_THRESHOLD", 2*time.Second),
+		AnomalyFastLimit:           l.getEnvInt("ANOMALY_FAST_LIMIT", 5),
+		AnomalyWindow:              l.getEnvInt("ANOMALY_WINDOW", 20),
+		SessionLimitPerSubreddit:   l.getEnvInt("SESSION_LIMIT_PER_SUBREDDIT", 200),
+		SessionLimitPerPlayer:      l.getEnvInt("SESSION_LIMIT_PER_PLAYER", 3),
+		MongoPool: MongoPoolConfig{
+			MaxPoolSize:     uint64(l.getEnvInt("MONGO_MAX_POOL_SIZE", 100)),
+			MinPoolSize:     uint64(l.getEnvInt("MONGO_MIN_POOL_SIZE", 5)),
+			MaxConnIdleTime: l.getEnvDuration("MONGO_MAX_CONN_IDLE_TIME", 5*time.Minute),
+			ConnectTimeout:  l.getEnvDuration("MONGO_CONNECT_TIMEOUT", 10*time.Second),
+		},
+		Neo4jPool: Neo4jPoolConfig{
+			MaxConnections:        l.getEnvInt("NEO4J_MAX_CONNECTIONS", 50),
+			AcquisitionTimeout:    l.getEnvDuration("NEO4J_ACQUISITION_TIMEOUT", 30*time.Second),
+			MaxConnectionLifetime: l.getEnvDuration("NEO4J_MAX_CONNECTION_LIFETIME", time.Hour),
+		},
+		RedisPool: RedisPoolConfig{
+			PoolSize:     l.getEnvInt("REDIS_POOL_SIZE", 50),
+			MinIdleConns: l.getEnvInt("REDIS_MIN_IDLE_CONNS", 5),
+			PoolTimeout:  l.getEnvDuration("REDIS_POOL_TIMEOUT", 4*time.Second),
+			DialTimeout:  l.getEnvDuration("REDIS_DIAL_TIMEOUT", 5*time.Second),
+			ReadTimeout:  l.getEnvDuration("REDIS_READ_TIMEOUT", 3*time.Second),
+			WriteTimeout: l.getEnvDuration("REDIS_WRITE_TIMEOUT", 3*time.Second),
+		},
+		RateLimit: RateLimitConfig{
+			SubmitLimit:       l.getEnvInt("RATE_LIMIT_SUBMIT", 5),
+			SubmitWindow:      l.getEnvDuration("RATE_LIMIT_SUBMIT_WINDOW", time.Minute),
+			LeaderboardLimit:  l.getEnvInt("RATE_LIMIT_LEADERBOARD", 30),
+			LeaderboardWindow: l.getEnvDuration("RATE_LIMIT_LEADERBOARD_WINDOW", time.Minute),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    l.otlpTracesEndpoint(),
+			ServiceName: l.getEnv("OTEL_SERVICE_NAME", "dumdoors-backend"),
+			SampleRatio: l.getEnvFloat("OTEL_TRACES_SAMPLE_RATIO", 1),
+		},
+	}
+	cfg.parseErrors = l.problems
+	return cfg
+}
+
+// loader reads settings from the environment, noting each value that doesn't parse
+type loader struct {
+	problems []string
+}
+
+// invalid notes a value that doesn't parse as the expected kind
+func (l *loader) invalid(key, value, kind string) {
+	l.problems = append(l.problems, fmt.Sprintf("%s: %q is not a valid %s", key, value, kind))
+}
+
+// getEnv gets an environment variable with a fallback value
+func (l *loader) getEnv(key, fallback string) string {
+	return getEnv(key, fallback)
+}
+
+// getEnvInt gets an integer environment variable with a fallback value
+func (l *loader) getEnvInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err == nil {
+			return parsed
+		}
+		l.invalid(key, value, "integer")
+	}
+	return fallback
+}
+
+// getEnvDuration gets a duration environment variable (e.g. "500ms") with a fallback value
+func (l *loader) getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err == nil {
+			return parsed
+		}
+		l.invalid(key, value, "duration")
 	}
+	return fallback
+}
+
+// getEnvFloat gets a floating point environment variable with a fallback value
+func (l *loader) getEnvFloat(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			return parsed
+		}
+		l.invalid(key, value, "number")
+	}
+	return fallback
+}
+
+// getEnvBool gets a boolean environment variable (e.g. "true", "1") with a fallback value
+func (l *loader) getEnvBool(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err == nil {
+			return parsed
+		}
+		l.invalid(key, value, "boolean")
+	}
+	return fallback
+}
+
+// getEnvList gets a comma-separated environment variable, skipping empty entries
+func (l *loader) getEnvList(key string) []string {
+	return l.getEnvSplit(key, ",")
+}
+
+// getEnvSplit gets an environment variable split on sep, skipping empty entries
+func (l *loader) getEnvSplit(key, sep string) []string {
+	return splitList(os.Getenv(key), sep)
+}
+
+// getSecretList gets a comma-separated secret from the file named by <key>_FILE, as mounted
+// secrets are, falling back to the environment variable itself
+func (l *loader) getSecretList(key string) []string {
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			l.problems = append(l.problems, fmt.Sprintf("%s: %v", key+"_FILE", err))
+			return nil
+		}
+		return splitList(string(data), ",")
+	}
+	return l.getEnvList(key)
+}
+
+// otlpTracesEndpoint returns the OTLP/HTTP traces URL. As in the OpenTelemetry SDKs, the
+// generic endpoint is a base URL that the traces path is appended to.
+func (l *loader) otlpTracesEndpoint() string {
+	if endpoint := getEnv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", ""); endpoint != "" {
+		return endpoint
+	}
+	if base := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""); base != "" {
+		return strings.TrimSuffix(base, "/") + "/v1/traces"
+	}
+	return ""
 }
 
 // getEnv gets an environment variable with a fallback value
@@ -36,4 +371,23 @@ func getEnv(key, fallback string) string {
 		return value
 	}
 	return fallback
-}
\ No newline at end of file
+}
+
+// defaultLogLevel logs everything in development and from info up elsewhere
+func defaultLogLevel() string {
+	if getEnv("ENVIRONMENT", "development") == "development" {
+		return "debug"
+	}
+	return "info"
+}
+
+// splitList splits s on sep, trimming entries and skipping empty ones
+func splitList(s, sep string) []string {
+	var values []string
+	for _, value := range strings.Split(s, sep) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
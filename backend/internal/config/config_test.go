@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	t.Setenv("PORT", "70000")
+	t.Setenv("WORKER_POOL_SIZE", "lots")
+	t.Setenv("REDIS_URI", "http://localhost:6379")
+	t.Setenv("ADMIN_PLAYER_IDS", "t2_admin")
+	t.Setenv("WS_STICKY_ROUTING", "true")
+
+	err := Load().Validate()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+
+	report := err.Error()
+	for _, key := range []string{"PORT", "WORKER_POOL_SIZE", "REDIS_URI", "STAFF_API_KEYS", "ROUTING_SECRET", "INSTANCE_ID"} {
+		if !strings.Contains(report, key) {
+			t.Errorf("Expected %s in the report, got:\n%s", key, report)
+		}
+	}
+	if len(invalid.Problems) != 6 {
+		t.Errorf("Expected 6 problems, got %v", invalid.Problems)
+	}
+}
+
+func TestValidate_DefaultsAreValid(t *testing.T) {
+	if err := Load().Validate(); err != nil {
+		t.Errorf("Expected the defaults to validate, got %v", err)
+	}
+}
+
+func TestReloader_AppliesValidChangesOnly(t *testing.T) {
+	reloader := NewReloader(Load(), "")
+	var applied *Config
+	reloader.OnReload(func(cfg *Config) { applied = cfg })
+
+	t.Setenv("CHAT_RATE_LIMIT", "9")
+	t.Setenv("LOG_LEVEL", "warn")
+	if _, err := reloader.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if applied == nil || applied.ChatRateLimit != 9 || applied.LogLevel != "warn" {
+		t.Fatalf("Expected the new chat limit and log level to be applied, got %+v", applied)
+	}
+
+	// A bad value keeps the running configuration
+	t.Setenv("SESSION_INACTIVITY_TIMEOUT", "soon")
+	if _, err := reloader.Reload(); err == nil {
+		t.Fatal("Expected an invalid reload to be rejected")
+	}
+	if current := reloader.Current(); current != applied || current.SessionInactivityTimeout != 30*time.Minute {
+		t.Errorf("Expected the running configuration to be kept, got %+v", current)
+	}
+}
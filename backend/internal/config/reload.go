@@ -0,0 +1,125 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/joho/godotenv"
+)
+
+// Reloader re-reads the configuration on SIGHUP and hands it to the components whose settings
+// can change while the server runs, such as timers, rate limits and the log level. Connection
+// settings and pool sizes still need a restart.
+type Reloader struct {
+	mu       sync.Mutex
+	current  *Config
+	envFile  string
+	handlers []func(cfg *Config)
+}
+
+// NewReloader creates a reloader starting from cfg that re-reads envFile, if it exists, on
+// every reload so edits to it override the process environment
+func NewReloader(cfg *Config, envFile string) *Reloader {
+	return &Reloader{
+		current: cfg,
+		envFile: envFile,
+	}
+}
+
+// OnReload registers a function that applies reloaded settings
+func (r *Reloader) OnReload(apply func(cfg *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, apply)
+}
+
+// Current returns the configuration last loaded
+func (r *Reloader) Current() *Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// Reload reads the configuration again and applies it. A configuration that fails validation
+// is rejected and the running one kept.
+func (r *Reloader) Reload() (*Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.envFile != "" {
+		if err := godotenv.Overload(r.envFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", r.envFile, err)
+		}
+	}
+
+	cfg := Load()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if changed := restartOnlyChanges(r.current, cfg); len(changed) > 0 {
+		fmt.Printf("Warning: %s changed but only takes effect after a restart\n", strings.Join(changed, ", "))
+	}
+
+	r.current = cfg
+	for _, apply := range r.handlers {
+		apply(cfg)
+	}
+	return cfg, nil
+}
+
+// Watch reloads the configuration on every SIGHUP until the context is cancelled
+func (r *Reloader) Watch(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			if _, err := r.Reload(); err != nil {
+				fmt.Printf("Warning: configuration reload rejected: %v\n", err)
+			}
+		}
+	}
+}
+
+// restartOnlyChanges names the settings that differ between old and updated but are only read
+// at startup
+func restartOnlyChanges(old, updated *Config) []string {
+	if old == nil {
+		return nil
+	}
+
+	settings := []struct {
+		key     string
+		changed bool
+	}{
+		{"PORT", old.Port != updated.Port},
+		{"MONGO_URI", old.MongoURI != updated.MongoURI},
+		{"NEO4J_URI", old.Neo4jURI != updated.Neo4jURI},
+		{"REDIS_URI", old.RedisURI != updated.RedisURI},
+		{"AI_SERVICE_URL", old.AIServiceURL != updated.AIServiceURL},
+		{"AI_TRANSPORT", old.AITransport != updated.AITransport},
+		{"AI_GRPC_ADDR", old.AIGRPCAddr != updated.AIGRPCAddr},
+		{"ENVIRONMENT", old.Environment != updated.Environment},
+		{"WORKER_POOL_SIZE", old.WorkerPoolSize != updated.WorkerPoolSize},
+		{"DETERMINISTIC_SEED", old.DeterministicSeed != updated.DeterministicSeed},
+	}
+
+	var changed []string
+	for _, setting := range settings {
+		if setting.changed {
+			changed = append(changed, setting.key)
+		}
+	}
+	return changed
+}
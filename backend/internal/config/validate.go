@@ -0,0 +1,151 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every problem found in the configuration, so they can all be fixed at once
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Log levels accepted in LOG_LEVEL
+var validLogLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+
+// Scoring strategies accepted in SCORING_STRATEGY
+var validScoringStrategies = map[string]bool{"average": true, "weighted": true, "max-metric": true, "theme-weighted": true, "rubric": true}
+
+// URI schemes accepted for each dependency
+var (
+	mongoSchemes = []string{"mongodb", "mongodb+srv"}
+	neo4jSchemes = []string{"bolt", "bolt+s", "bolt+ssc", "neo4j", "neo4j+s", "neo4j+ssc"}
+	redisSchemes = []string{"redis", "rediss"}
+	httpSchemes  = []string{"http", "https"}
+)
+
+// Validate checks the configuration, returning a ValidationError listing every problem found
+func (c *Config) Validate() error {
+	problems := append([]string(nil), c.parseErrors...)
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	port, err := strconv.Atoi(c.Port)
+	check(err == nil && port >= 1 && port <= 65535, "PORT: %q is not a port between 1 and 65535", c.Port)
+
+	problems = append(problems, checkURI("MONGO_URI", c.MongoURI, mongoSchemes)...)
+	problems = append(problems, checkURI("NEO4J_URI", c.Neo4jURI, neo4jSchemes)...)
+	problems = append(problems, checkURI("REDIS_URI", c.RedisURI, redisSchemes)...)
+	problems = append(problems, checkURI("AI_SERVICE_URL", c.AIServiceURL, httpSchemes)...)
+	if c.DevvitCallbackURL != "" {
+		problems = append(problems, checkURI("DEVVIT_CALLBACK_URL", c.DevvitCallbackURL, httpSchemes)...)
+	}
+
+	check(validLogLevels[c.LogLevel], "LOG_LEVEL: %q is not one of debug, info, warn or error", c.LogLevel)
+	check(c.RoutingSecret != "" || (c.Environment == "development" && !c.WSStickyRouting), "ROUTING_SECRET: must be set outside development and whenever WS_STICKY_ROUTING is on")
+	check(c.InstanceID != "" || !c.WSStickyRouting, "INSTANCE_ID: must be set when WS_STICKY_ROUTING is on, so routes survive restarts")
+	check(len(c.StaffAPIKeys) > 0 || len(c.AdminPlayerIDs)+len(c.ModeratorPlayerIDs) == 0, "STAFF_API_KEYS: must be set when ADMIN_PLAYER_IDS or MODERATOR_PLAYER_IDS are")
+	check(c.AITransport == "http" || c.AITransport == "grpc", "AI_TRANSPORT: %q is not http or grpc", c.AITransport)
+	if c.AITransport == "grpc" {
+		check(c.AIGRPCAddr != "", "AI_GRPC_ADDR: must be set when AI_TRANSPORT is grpc")
+		check(c.AIGRPCPoolSize > 0, "AI_GRPC_POOL_SIZE: must be positive, got %d", c.AIGRPCPoolSize)
+		check(c.AIGRPCDeadline > 0, "AI_GRPC_DEADLINE: must be positive, got %s", c.AIGRPCDeadline)
+	}
+	check(validScoringStrategies[c.ScoringStrategy], "SCORING_STRATEGY: %q is not one of average, weighted, max-metric, theme-weighted or rubric", c.ScoringStrategy)
+	check(c.ModerationMode == "mask" || c.ModerationMode == "reject", "MODERATION_MODE: %q is not mask or reject", c.ModerationMode)
+	check(c.AntiCheatMode == "off" || c.AntiCheatMode == "penalize" || c.AntiCheatMode == "reject", "ANTI_CHEAT_MODE: %q is not off, penalize or reject", c.AntiCheatMode)
+	check(c.AntiCheatPenalty >= 0 && c.AntiCheatPenalty <= 1, "ANTI_CHEAT_PENALTY: %v is not between 0 and 1", c.AntiCheatPenalty)
+	check(c.AntiCheatThreshold > 0 && c.AntiCheatThreshold <= 1, "ANTI_CHEAT_SIMILARITY_THRESHOLD: %v is not above 0 and at most 1", c.AntiCheatThreshold)
+	check(c.AnomalyFastThreshold > 0, "ANOMALY_FAST_THRESHOLD: must be positive, got %s", c.AnomalyFastThreshold)
+	check(c.AnomalyWindow > 0, "ANOMALY_WINDOW: must be positive, got %d", c.AnomalyWindow)
+	check(c.AnomalyFastLimit > 0 && c.AnomalyFastLimit <= c.AnomalyWindow, "ANOMALY_FAST_LIMIT: %d is not between 1 and ANOMALY_WINDOW", c.AnomalyFastLimit)
+	check(c.SessionLimitPerSubreddit >= 0, "SESSION_LIMIT_PER_SUBREDDIT: must not be negative, got %d", c.SessionLimitPerSubreddit)
+	check(c.SessionLimitPerPlayer >= 0, "SESSION_LIMIT_PER_PLAYER: must not be negative, got %d", c.SessionLimitPerPlayer)
+	check(c.WorkerPoolSize > 0, "WORKER_POOL_SIZE: must be positive, got %d", c.WorkerPoolSize)
+	check(c.WSSendQueueSize > 0, "WS_SEND_QUEUE_SIZE: must be positive, got %d", c.WSSendQueueSize)
+	check(c.WSOverflowPolicy == "disconnect" || c.WSOverflowPolicy == "drop-oldest", "WS_OVERFLOW_POLICY: %q is not disconnect or drop-oldest", c.WSOverflowPolicy)
+	check(c.WSCompressionThreshold > 0, "WS_COMPRESSION_THRESHOLD: must be positive, got %d", c.WSCompressionThreshold)
+	check(c.WSResyncBufferSize >= 0, "WS_RESYNC_BUFFER_SIZE: must not be negative, got %d", c.WSResyncBufferSize)
+	check(c.WSTimerTickInterval > 0, "WS_TIMER_TICK_INTERVAL: must be positive, got %s", c.WSTimerTickInterval)
+	check(c.RoundSnapshotInterval > 0, "ROUND_SNAPSHOT_INTERVAL: must be positive, got %s", c.RoundSnapshotInterval)
+	check(c.AIScoringConcurrency > 0, "AI_SCORING_CONCURRENCY: must be positive, got %d", c.AIScoringConcurrency)
+	check(c.ChatRateWindow > 0, "CHAT_RATE_WINDOW: must be positive, got %s", c.ChatRateWindow)
+	check(c.DoorBankSize >= 0, "DOOR_BANK_SIZE: must not be negative, got %d", c.DoorBankSize)
+	check(c.DoorBankRefillInterval > 0, "DOOR_BANK_REFILL_INTERVAL: must be positive, got %s", c.DoorBankRefillInterval)
+	check(c.SessionInactivityTimeout > 0, "SESSION_INACTIVITY_TIMEOUT: must be positive, got %s", c.SessionInactivityTimeout)
+	check(c.RateLimit.SubmitWindow > 0, "RATE_LIMIT_SUBMIT_WINDOW: must be positive, got %s", c.RateLimit.SubmitWindow)
+	check(c.RateLimit.LeaderboardWindow > 0, "RATE_LIMIT_LEADERBOARD_WINDOW: must be positive, got %s", c.RateLimit.LeaderboardWindow)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "OTEL_TRACES_SAMPLE_RATIO: %v is not between 0 and 1", c.Tracing.SampleRatio)
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// CheckAIServiceReachable dials the AI service's host, so a wrong AI_SERVICE_URL is caught at
+// startup rather than on the first door
+func (c *Config) CheckAIServiceReachable(ctx context.Context, timeout time.Duration) error {
+	parsed, err := url.Parse(c.AIServiceURL)
+	if err != nil {
+		return fmt.Errorf("AI_SERVICE_URL: %w", err)
+	}
+
+	address := parsed.Host
+	if parsed.Port() == "" {
+		port := "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+		address = net.JoinHostPort(parsed.Hostname(), port)
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return &ValidationError{Problems: []string{fmt.Sprintf("AI_SERVICE_URL: %s is not reachable: %v", c.AIServiceURL, err)}}
+	}
+	return conn.Close()
+}
+
+// checkURI reports a URI that doesn't parse, has no host or uses a scheme other than those given
+func checkURI(key, value string, schemes []string) []string {
+	if value == "" {
+		return []string{key + ": is required"}
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", key, err)}
+	}
+
+	var problems []string
+	if !containsScheme(schemes, parsed.Scheme) {
+		problems = append(problems, fmt.Sprintf("%s: scheme %q is not one of %s", key, parsed.Scheme, strings.Join(schemes, ", ")))
+	}
+	if parsed.Host == "" {
+		problems = append(problems, fmt.Sprintf("%s: %q has no host", key, value))
+	}
+	return problems
+}
+
+// containsScheme reports whether scheme is one of schemes
+func containsScheme(schemes []string, scheme string) bool {
+	for _, candidate := range schemes {
+		if candidate == scheme {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Health check settings
+const (
+	DefaultProbeTimeout = 2 * time.Second
+	// SlowProbeThreshold is the latency above which a dependency that answers is reported degraded
+	SlowProbeThreshold = 500 * time.Millisecond
+)
+
+// Health statuses reported per dependency and for the instance as a whole
+const (
+	HealthHealthy   = "healthy"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+// DependencyProbe checks that one dependency is reachable
+type DependencyProbe struct {
+	Name  string
+	Check func(ctx context.Context) error
+	// Optional dependencies have a fallback, so their failure degrades the instance instead
+	// of taking it out of rotation
+	Optional bool
+}
+
+// DependencyHealth is the result of probing one dependency. LastError is the most recent
+// failure seen, kept after the dependency recovers so flapping shows up in the report.
+type DependencyHealth struct {
+	Status      string     `json:"status"`
+	LatencyMs   float64    `json:"latency_ms"`
+	Optional    bool       `json:"optional,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// HealthReport is the readiness document built from probing every dependency
+type HealthReport struct {
+	Status       string                      `json:"status"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
+
+// Ready reports whether every required dependency answered
+func (r *HealthReport) Ready() bool {
+	return r.Status != HealthUnhealthy
+}
+
+// recordedError is the last failure of a dependency
+type recordedError struct {
+	message string
+	at      time.Time
+}
+
+// healthTracker holds the probes added to a database manager and the last error of each dependency
+type healthTracker struct {
+	mu         sync.Mutex
+	probes     []DependencyProbe
+	lastErrors map[string]recordedError
+}
+
+// AddHealthProbe adds a dependency other than the databases to the health check
+func (dm *DatabaseManager) AddHealthProbe(probe DependencyProbe) {
+	dm.health.mu.Lock()
+	defer dm.health.mu.Unlock()
+	dm.health.probes = append(dm.health.probes, probe)
+}
+
+// HealthCheck pings MongoDB, Neo4j, Redis and any added dependencies in parallel, each under
+// DefaultProbeTimeout. The instance is unhealthy if a required dependency fails, and degraded
+// if one is slow or an optional one fails.
+func (dm *DatabaseManager) HealthCheck(ctx context.Context) *HealthReport {
+	probes := dm.healthProbes()
+	results := make([]DependencyHealth, len(probes))
+
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func(i int, probe DependencyProbe) {
+			defer wg.Done()
+			results[i] = runProbe(ctx, probe)
+		}(i, probe)
+	}
+	wg.Wait()
+
+	report := &HealthReport{
+		Status:       HealthHealthy,
+		CheckedAt:    time.Now().UTC(),
+		Dependencies: make(map[string]DependencyHealth, len(probes)),
+	}
+
+	dm.health.mu.Lock()
+	defer dm.health.mu.Unlock()
+	if dm.health.lastErrors == nil {
+		dm.health.lastErrors = make(map[string]recordedError)
+	}
+
+	for i, probe := range probes {
+		result := results[i]
+		if result.Error != "" {
+			dm.health.lastErrors[probe.Name] = recordedError{message: result.Error, at: report.CheckedAt}
+		}
+		if last, ok := dm.health.lastErrors[probe.Name]; ok {
+			at := last.at
+			result.LastError, result.LastErrorAt = last.message, &at
+		}
+		report.Dependencies[probe.Name] = result
+
+		switch {
+		case result.Status == HealthUnhealthy && !probe.Optional:
+			report.Status = HealthUnhealthy
+		case result.Status != HealthHealthy && report.Status == HealthHealthy:
+			report.Status = HealthDegraded
+		}
+	}
+	return report
+}
+
+// healthProbes lists a ping for each connected database followed by the added probes
+func (dm *DatabaseManager) healthProbes() []DependencyProbe {
+	var probes []DependencyProbe
+	if dm.MongoDB != nil {
+		probes = append(probes, DependencyProbe{Name: "mongodb", Check: func(ctx context.Context) error {
+			return dm.MongoDB.Client.Ping(ctx, nil)
+		}})
+	}
+	if dm.Neo4j != nil {
+		probes = append(probes, DependencyProbe{Name: "neo4j", Check: func(ctx context.Context) error {
+			return dm.Neo4j.Driver.VerifyConnectivity(ctx)
+		}})
+	}
+	if dm.Redis != nil {
+		probes = append(probes, DependencyProbe{Name: "redis", Check: func(ctx context.Context) error {
+			return dm.Redis.Client.Ping(ctx).Err()
+		}})
+	}
+
+	dm.health.mu.Lock()
+	defer dm.health.mu.Unlock()
+	return append(probes, dm.health.probes...)
+}
+
+// runProbe times one probe under DefaultProbeTimeout
+func runProbe(ctx context.Context, probe DependencyProbe) DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, DefaultProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := probe.Check(ctx)
+	latency := time.Since(start)
+
+	result := DependencyHealth{
+		Status:    HealthHealthy,
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+		Optional:  probe.Optional,
+	}
+	switch {
+	case err != nil:
+		result.Status = HealthUnhealthy
+		result.Error = err.Error()
+	case latency > SlowProbeThreshold:
+		result.Status = HealthDegraded
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestHealthCheck_OptionalFailureDegrades(t *testing.T) {
+	manager := &DatabaseManager{}
+	manager.AddHealthProbe(DependencyProbe{Name: "store", Check: func(ctx context.Context) error { return nil }})
+	manager.AddHealthProbe(DependencyProbe{Name: "ai_service", Optional: true, Check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+
+	report := manager.HealthCheck(context.Background())
+	if report.Status != HealthDegraded || !report.Ready() {
+		t.Fatalf("Expected a degraded but ready instance, got %s", report.Status)
+	}
+	if ai := report.Dependencies["ai_service"]; ai.Status != HealthUnhealthy || ai.LastError != "connection refused" {
+		t.Errorf("Expected the AI service to be reported unhealthy with its error, got %+v", ai)
+	}
+}
+
+func TestHealthCheck_RequiredFailureKeepsLastError(t *testing.T) {
+	manager := &DatabaseManager{}
+	down := true
+	manager.AddHealthProbe(DependencyProbe{Name: "store", Check: func(ctx context.Context) error {
+		if down {
+			return errors.New("no reachable servers")
+		}
+		return nil
+	}})
+
+	if report := manager.HealthCheck(context.Background()); report.Ready() {
+		t.Fatalf("Expected a failed required dependency to make the instance unready, got %s", report.Status)
+	}
+
+	down = false
+	report := manager.HealthCheck(context.Background())
+	store := report.Dependencies["store"]
+	if report.Status != HealthHealthy || store.Error != "" {
+		t.Fatalf("Expected the instance to recover, got %s with %+v", report.Status, store)
+	}
+	if store.LastError != "no reachable servers" || store.LastErrorAt == nil {
+		t.Errorf("Expected the last error to be kept after recovering, got %+v", store)
+	}
+}
@@ -12,25 +12,27 @@ type DatabaseManager struct {
 	MongoDB *MongoClient
 	Neo4j   *Neo4jClient
 	Redis   *RedisClient
+
+	health healthTracker
 }
 
 // NewDatabaseManager creates a new database manager with all connections
 func NewDatabaseManager(cfg *config.Config) (*DatabaseManager, error) {
 	// Initialize MongoDB
-	mongodb, err := NewMongoClient(cfg.MongoURI, "dumdoors")
+	mongodb, err := NewMongoClient(cfg.MongoURI, "dumdoors", cfg.MongoPool)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
 	}
 
 	// Initialize Neo4j
-	neo4j, err := NewNeo4jClient(cfg.Neo4jURI, cfg.Neo4jUser, cfg.Neo4jPass)
+	neo4j, err := NewNeo4jClient(cfg.Neo4jURI, cfg.Neo4jUser, cfg.Neo4jPass, cfg.Neo4jPool)
 	if err != nil {
 		mongodb.Close() // Clean up MongoDB connection
 		return nil, fmt.Errorf("failed to initialize Neo4j: %w", err)
 	}
 
 	// Initialize Redis
-	redis, err := NewRedisClient(cfg.RedisURI)
+	redis, err := NewRedisClient(cfg.RedisURI, cfg.RedisPool)
 	if err != nil {
 		mongodb.Close() // Clean up MongoDB connection
 		neo4j.Close(context.Background()) // Clean up Neo4j connection
@@ -98,24 +100,4 @@ func (dm *DatabaseManager) Close() {
 	}
 
 	log.Println("All database connections closed")
-}
-
-// HealthCheck performs health checks on all database connections
-func (dm *DatabaseManager) HealthCheck(ctx context.Context) error {
-	// Check MongoDB
-	if err := dm.MongoDB.Client.Ping(ctx, nil); err != nil {
-		return fmt.Errorf("MongoDB health check failed: %w", err)
-	}
-
-	// Check Neo4j
-	if err := dm.Neo4j.Driver.VerifyConnectivity(ctx); err != nil {
-		return fmt.Errorf("Neo4j health check failed: %w", err)
-	}
-
-	// Check Redis
-	if err := dm.Redis.Client.Ping(ctx).Err(); err != nil {
-		return fmt.Errorf("Redis health check failed: %w", err)
-	}
-
-	return nil
 }
\ No newline at end of file
@@ -2,10 +2,14 @@ package database
 
 import (
 	"context"
+	"dumdoors-backend/internal/config"
 	"fmt"
 	"log"
+	"sync/atomic"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -14,15 +18,29 @@ import (
 type MongoClient struct {
 	Client   *mongo.Client
 	Database *mongo.Database
+	
+	// Pool utilization counters maintained by the pool monitor
+	maxPoolSize       uint64
+	openConnections   int64
+	inUseConnections  int64
+	checkoutFailures  int64
 }
 
 // NewMongoClient creates a new MongoDB client connection
-func NewMongoClient(uri, dbName string) (*MongoClient, error) {
+func NewMongoClient(uri, dbName string, poolCfg config.MongoPoolConfig) (*MongoClient, error) {
+	mc := &MongoClient{maxPoolSize: poolCfg.MaxPoolSize}
+	
 	// Set client options
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions := options.Client().
+		ApplyURI(uri).
+		SetMaxPoolSize(poolCfg.MaxPoolSize).
+		SetMinPoolSize(poolCfg.MinPoolSize).
+		SetMaxConnIdleTime(poolCfg.MaxConnIdleTime).
+		SetPoolMonitor(mc.poolMonitor()).
+		SetMonitor((&mongoCommandTracer{}).monitor())
 	
 	// Set connection timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), poolCfg.ConnectTimeout)
 	defer cancel()
 
 	// Connect to MongoDB
@@ -40,10 +58,40 @@ func NewMongoClient(uri, dbName string) (*MongoClient, error) {
 	
 	log.Printf("Successfully connected to MongoDB database: %s", dbName)
 	
-	return &MongoClient{
-		Client:   client,
-		Database: database,
-	}, nil
+	mc.Client = client
+	mc.Database = database
+	
+	return mc, nil
+}
+
+// poolMonitor tracks connection pool events for utilization metrics
+func (mc *MongoClient) poolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.ConnectionCreated:
+				atomic.AddInt64(&mc.openConnections, 1)
+			case event.ConnectionClosed:
+				atomic.AddInt64(&mc.openConnections, -1)
+			case event.GetSucceeded:
+				atomic.AddInt64(&mc.inUseConnections, 1)
+			case event.ConnectionReturned:
+				atomic.AddInt64(&mc.inUseConnections, -1)
+			case event.GetFailed:
+				atomic.AddInt64(&mc.checkoutFailures, 1)
+			}
+		},
+	}
+}
+
+// PoolStats returns the current MongoDB connection pool utilization
+func (mc *MongoClient) PoolStats() PoolStats {
+	return PoolStats{
+		MaxConnections:   int64(mc.maxPoolSize),
+		OpenConnections:  atomic.LoadInt64(&mc.openConnections),
+		InUseConnections: atomic.LoadInt64(&mc.inUseConnections),
+		Failures:         atomic.LoadInt64(&mc.checkoutFailures),
+	}
 }
 
 // Close closes the MongoDB connection
@@ -77,6 +125,10 @@ func (mc *MongoClient) CreateIndexes() error {
 		{
 			Keys: map[string]int{"createdAt": 1},
 		},
+		{
+			// Serves the open session browser
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "mode", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
 	}
 	
 	if _, err := sessionsCollection.Indexes().CreateMany(ctx, sessionIndexes); err != nil {
@@ -124,6 +176,117 @@ func (mc *MongoClient) CreateIndexes() error {
 		return fmt.Errorf("failed to create response indexes: %w", err)
 	}
 
+	// Player profiles collection indexes
+	profilesCollection := mc.GetCollection("player_profiles")
+	profileIndexes := []mongo.IndexModel{
+		{
+			Keys: map[string]int{"playerId": 1},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	
+	if _, err := profilesCollection.Indexes().CreateMany(ctx, profileIndexes); err != nil {
+		return fmt.Errorf("failed to create profile indexes: %w", err)
+	}
+
+	// Player achievements collection indexes; each badge is unlocked once per player
+	achievementsCollection := mc.GetCollection("player_achievements")
+	achievementIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "playerId", Value: 1}, {Key: "achievementId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	
+	if _, err := achievementsCollection.Indexes().CreateMany(ctx, achievementIndexes); err != nil {
+		return fmt.Errorf("failed to create achievement indexes: %w", err)
+	}
+
+	// Friends collection indexes
+	friendsCollection := mc.GetCollection("friends")
+	friendIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "playerId", Value: 1}, {Key: "friendId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	
+	if _, err := friendsCollection.Indexes().CreateMany(ctx, friendIndexes); err != nil {
+		return fmt.Errorf("failed to create friend indexes: %w", err)
+	}
+
+	// Door scores collection indexes; the calibration job reads recently scored doors
+	doorScoresCollection := mc.GetCollection("door_scores")
+	doorScoreIndexes := []mongo.IndexModel{
+		{
+			Keys: map[string]int{"doorId": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: map[string]int{"updatedAt": 1},
+		},
+	}
+	
+	if _, err := doorScoresCollection.Indexes().CreateMany(ctx, doorScoreIndexes); err != nil {
+		return fmt.Errorf("failed to create door score indexes: %w", err)
+	}
+
+	// Themes collection indexes
+	themesCollection := mc.GetCollection("themes")
+	themeIndexes := []mongo.IndexModel{
+		{
+			Keys: map[string]int{"themeId": 1},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	
+	if _, err := themesCollection.Indexes().CreateMany(ctx, themeIndexes); err != nil {
+		return fmt.Errorf("failed to create theme indexes: %w", err)
+	}
+
+	// Audit events collection indexes; the audit log is listed by actor and time
+	auditCollection := mc.GetCollection("audit_events")
+	auditIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "actor.id", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+		{
+			Keys: map[string]int{"createdAt": -1},
+		},
+	}
+	
+	if _, err := auditCollection.Indexes().CreateMany(ctx, auditIndexes); err != nil {
+		return fmt.Errorf("failed to create audit indexes: %w", err)
+	}
+
+	// Session events collection indexes; a session's event log is read in order
+	sessionEventsCollection := mc.GetCollection("session_events")
+	sessionEventIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "sessionId", Value: 1}, {Key: "at", Value: 1}},
+		},
+	}
+	
+	if _, err := sessionEventsCollection.Indexes().CreateMany(ctx, sessionEventIndexes); err != nil {
+		return fmt.Errorf("failed to create session event indexes: %w", err)
+	}
+
+	// Player activity collection indexes; admins list flagged players, most recent first
+	activityCollection := mc.GetCollection("player_activity")
+	activityIndexes := []mongo.IndexModel{
+		{
+			Keys: map[string]int{"playerId": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "flagged", Value: 1}, {Key: "flaggedAt", Value: -1}},
+		},
+	}
+	
+	if _, err := activityCollection.Indexes().CreateMany(ctx, activityIndexes); err != nil {
+		return fmt.Errorf("failed to create player activity indexes: %w", err)
+	}
+
 	log.Println("Successfully created MongoDB indexes")
 	return nil
 }
\ No newline at end of file
@@ -2,21 +2,31 @@ package database
 
 import (
 	"context"
+	"dumdoors-backend/internal/config"
+	"dumdoors-backend/internal/tracing"
 	"fmt"
 	"log"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // Neo4jClient wraps the Neo4j driver with additional functionality
 type Neo4jClient struct {
-	Driver neo4j.DriverWithContext
+	Driver         neo4j.DriverWithContext
+	maxConnections int
 }
 
 // NewNeo4jClient creates a new Neo4j client connection
-func NewNeo4jClient(uri, username, password string) (*Neo4jClient, error) {
+func NewNeo4jClient(uri, username, password string, poolCfg config.Neo4jPoolConfig) (*Neo4jClient, error) {
 	// Create driver
-	driver, err := neo4j.NewDriverWithContext(uri, neo4j.BasicAuth(username, password, ""))
+	driver, err := neo4j.NewDriverWithContext(uri, neo4j.BasicAuth(username, password, ""), func(c *neo4jconfig.Config) {
+		c.MaxConnectionPoolSize = poolCfg.MaxConnections
+		c.ConnectionAcquisitionTimeout = poolCfg.AcquisitionTimeout
+		c.MaxConnectionLifetime = poolCfg.MaxConnectionLifetime
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
 	}
@@ -30,7 +40,8 @@ func NewNeo4jClient(uri, username, password string) (*Neo4jClient, error) {
 	log.Printf("Successfully connected to Neo4j at: %s", uri)
 	
 	return &Neo4jClient{
-		Driver: driver,
+		Driver:         driver,
+		maxConnections: poolCfg.MaxConnections,
 	}, nil
 }
 
@@ -41,7 +52,16 @@ func (nc *Neo4jClient) Close(ctx context.Context) error {
 
 // ExecuteQuery executes a Cypher query and returns the result
 func (nc *Neo4jClient) ExecuteQuery(ctx context.Context, query string, params map[string]interface{}) (*neo4j.EagerResult, error) {
-	return neo4j.ExecuteQuery(ctx, nc.Driver, query, params, neo4j.EagerResultTransformer)
+	ctx, span := tracing.Tracer().Start(ctx, "neo4j.query",
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			attribute.String("db.system", "neo4j"),
+			attribute.String("db.statement", query),
+		),
+	)
+	result, err := neo4j.ExecuteQuery(ctx, nc.Driver, query, params, neo4j.EagerResultTransformer)
+	tracing.EndSpan(span, err)
+	return result, err
 }
 
 // CreateSession creates a new Neo4j session
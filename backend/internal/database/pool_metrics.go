@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"dumdoors-backend/internal/monitoring"
+	"time"
+)
+
+// PoolStats describes the utilization of a database connection pool
+type PoolStats struct {
+	MaxConnections   int64 `json:"maxConnections"`
+	OpenConnections  int64 `json:"openConnections"`
+	InUseConnections int64 `json:"inUseConnections"`
+	Failures         int64 `json:"failures"`
+}
+
+// Utilization returns the fraction of the pool currently in use
+func (s PoolStats) Utilization() float64 {
+	if s.MaxConnections <= 0 {
+		return 0
+	}
+	return float64(s.InUseConnections) / float64(s.MaxConnections)
+}
+
+// PoolStats returns the configured Neo4j pool size. The Go driver does not
+// expose live pool counters, so only the limit is reported.
+func (nc *Neo4jClient) PoolStats() PoolStats {
+	return PoolStats{
+		MaxConnections: int64(nc.maxConnections),
+	}
+}
+
+// GetPoolStats returns pool utilization for every database
+func (dm *DatabaseManager) GetPoolStats() map[string]PoolStats {
+	stats := make(map[string]PoolStats)
+	if dm.MongoDB != nil {
+		stats["mongodb"] = dm.MongoDB.PoolStats()
+	}
+	if dm.Neo4j != nil {
+		stats["neo4j"] = dm.Neo4j.PoolStats()
+	}
+	if dm.Redis != nil {
+		stats["redis"] = dm.Redis.PoolStats()
+	}
+	return stats
+}
+
+// StartPoolMetricsCollection periodically exports pool utilization as gauges
+func (dm *DatabaseManager) StartPoolMetricsCollection(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			dm.collectPoolMetrics()
+		}
+	}
+}
+
+// collectPoolMetrics records the current pool stats in the global metrics collector
+func (dm *DatabaseManager) collectPoolMetrics() {
+	collector := monitoring.GetGlobalMetricsCollector()
+
+	for name, stats := range dm.GetPoolStats() {
+		labels := map[string]string{"database": name}
+		collector.NewGauge("db_pool_"+name+"_max_connections", "Configured maximum pool connections", labels).Set(float64(stats.MaxConnections))
+		collector.NewGauge("db_pool_"+name+"_open_connections", "Open pool connections", labels).Set(float64(stats.OpenConnections))
+		collector.NewGauge("db_pool_"+name+"_in_use_connections", "Pool connections currently checked out", labels).Set(float64(stats.InUseConnections))
+		collector.NewGauge("db_pool_"+name+"_utilization", "Fraction of the pool in use", labels).Set(stats.Utilization())
+		collector.NewGauge("db_pool_"+name+"_failures", "Pool checkout failures or timeouts", labels).Set(float64(stats.Failures))
+	}
+}
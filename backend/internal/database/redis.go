@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"dumdoors-backend/internal/config"
 	"fmt"
 	"log"
 	"time"
@@ -15,15 +16,24 @@ type RedisClient struct {
 }
 
 // NewRedisClient creates a new Redis client connection
-func NewRedisClient(uri string) (*RedisClient, error) {
+func NewRedisClient(uri string, poolCfg config.RedisPoolConfig) (*RedisClient, error) {
 	// Parse Redis URI
 	opt, err := redis.ParseURL(uri)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse Redis URI: %w", err)
 	}
+	
+	// Apply pool sizing and timeouts
+	opt.PoolSize = poolCfg.PoolSize
+	opt.MinIdleConns = poolCfg.MinIdleConns
+	opt.PoolTimeout = poolCfg.PoolTimeout
+	opt.DialTimeout = poolCfg.DialTimeout
+	opt.ReadTimeout = poolCfg.ReadTimeout
+	opt.WriteTimeout = poolCfg.WriteTimeout
 
 	// Create Redis client
 	client := redis.NewClient(opt)
+	client.AddHook(redisTracingHook{})
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -45,6 +55,17 @@ func (rc *RedisClient) Close() error {
 	return rc.Client.Close()
 }
 
+// PoolStats returns the current Redis connection pool utilization
+func (rc *RedisClient) PoolStats() PoolStats {
+	stats := rc.Client.PoolStats()
+	return PoolStats{
+		MaxConnections:   int64(rc.Client.Options().PoolSize),
+		OpenConnections:  int64(stats.TotalConns),
+		InUseConnections: int64(stats.TotalConns) - int64(stats.IdleConns),
+		Failures:         int64(stats.Timeouts),
+	}
+}
+
 // SetGameSession stores a game session in Redis with expiration
 func (rc *RedisClient) SetGameSession(ctx context.Context, sessionID string, data interface{}, expiration time.Duration) error {
 	key := fmt.Sprintf("session:%s", sessionID)
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// mongoCommandTracer records a client span for every MongoDB command
+type mongoCommandTracer struct {
+	spans sync.Map // request ID -> span
+}
+
+// monitor returns the command monitor that starts and ends the spans
+func (t *mongoCommandTracer) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", evt.DatabaseName),
+				attribute.String("db.operation", evt.CommandName),
+			}
+			if collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK(); ok {
+				attrs = append(attrs, attribute.String("db.mongodb.collection", collection))
+			}
+
+			_, span := tracing.Tracer().Start(ctx, "mongodb."+evt.CommandName,
+				oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+				oteltrace.WithAttributes(attrs...),
+			)
+			t.spans.Store(evt.RequestID, span)
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			t.end(evt.RequestID, nil)
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			t.end(evt.RequestID, errors.New(evt.Failure))
+		},
+	}
+}
+
+// end finishes the span started for a command
+func (t *mongoCommandTracer) end(requestID int64, err error) {
+	if span, ok := t.spans.LoadAndDelete(requestID); ok {
+		tracing.EndSpan(span.(oteltrace.Span), err)
+	}
+}
+
+// redisTracingHook records a client span for every Redis command and pipeline
+type redisTracingHook struct{}
+
+func (redisTracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (redisTracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := tracing.Tracer().Start(ctx, "redis."+cmd.Name(),
+			oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+			oteltrace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", cmd.Name()),
+			),
+		)
+		err := next(ctx, cmd)
+		tracing.EndSpan(span, redisFailure(err))
+		return err
+	}
+}
+
+func (redisTracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := tracing.Tracer().Start(ctx, "redis.pipeline",
+			oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+			oteltrace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.Int("db.redis.num_cmd", len(cmds)),
+			),
+		)
+		err := next(ctx, cmds)
+		tracing.EndSpan(span, redisFailure(err))
+		return err
+	}
+}
+
+// redisFailure drops redis.Nil, which reports a missing key rather than a failed command
+func redisFailure(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,539 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MaxDepth bounds how deeply a query may nest selections
+const MaxDepth = 12
+
+// ResolveFunc resolves a field's value from its parent object and arguments
+type ResolveFunc func(ctx context.Context, source interface{}, args Args) (interface{}, error)
+
+// Object is an object type whose fields have resolvers. Fields without one are read from the
+// source value by their JSON names.
+type Object struct {
+	Name   string
+	Fields map[string]*FieldResolver
+	// Unwrap, when set, returns the value that fields without a resolver are read from
+	Unwrap func(source interface{}) interface{}
+}
+
+// FieldResolver resolves a field of an object type. When Type is set the resolved value, or each
+// element of a resolved slice, is an instance of that type; otherwise it is read by its JSON
+// names.
+type FieldResolver struct {
+	Type    *Object
+	Resolve ResolveFunc
+}
+
+// Schema is a read-only GraphQL schema
+type Schema struct {
+	Query *Object
+	// Extensions, when set, returns the extensions reported with a resolver's error, such as
+	// its error code
+	Extensions func(err error) map[string]interface{}
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of executing a request. Data is omitted when the request could not be
+// executed at all.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error reports a request or field error, with the path to the field that failed
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Args holds a field's arguments with variables substituted
+type Args map[string]interface{}
+
+// String returns a string argument, or "" if it is missing
+func (a Args) String(name string) string {
+	switch value := a[name].(type) {
+	case string:
+		return value
+	case EnumValue:
+		return string(value)
+	}
+	return ""
+}
+
+// Int returns an integer argument, or fallback if it is missing
+func (a Args) Int(name string, fallback int) int {
+	switch value := a[name].(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return fallback
+}
+
+// Bool returns a boolean argument, or false if it is missing
+func (a Args) Bool(name string) bool {
+	value, _ := a[name].(bool)
+	return value
+}
+
+// Execute runs the request's query operation against the schema. Field errors leave the field
+// null and are reported alongside the rest of the data.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	operation, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	if operation.Type != "query" {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("%s operations are not supported", operation.Type)}}}
+	}
+	if err := validateSelections(doc, operation.SelectionSet, 1, map[string]bool{}); err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	variables := make(map[string]interface{}, len(operation.Variables))
+	for _, definition := range operation.Variables {
+		if value, ok := req.Variables[definition.Name]; ok {
+			variables[definition.Name] = value
+		} else {
+			variables[definition.Name] = definition.Default
+		}
+	}
+
+	e := &execution{schema: s, doc: doc, variables: variables}
+	data := e.executeObject(ctx, s.Query, nil, operation.SelectionSet, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+// selectOperation picks the operation to run, which must be named when there are several
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document has several operations")
+		}
+		return doc.Operations[0], nil
+	}
+	for _, operation := range doc.Operations {
+		if operation.Name == name {
+			return operation, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// validateSelections rejects undefined or cyclic fragments and queries nested beyond MaxDepth
+func validateSelections(doc *Document, selections []Selection, depth int, spreading map[string]bool) error {
+	if depth > MaxDepth {
+		return fmt.Errorf("query is nested more than %d levels deep", MaxDepth)
+	}
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *Field:
+			if err := validateSelections(doc, selection.SelectionSet, depth+1, spreading); err != nil {
+				return err
+			}
+		case *InlineFragment:
+			if err := validateSelections(doc, selection.SelectionSet, depth, spreading); err != nil {
+				return err
+			}
+		case *FragmentSpread:
+			fragment, ok := doc.Fragments[selection.Name]
+			if !ok {
+				return fmt.Errorf("unknown fragment %q", selection.Name)
+			}
+			if spreading[selection.Name] {
+				return fmt.Errorf("fragment %q spreads itself", selection.Name)
+			}
+			spreading[selection.Name] = true
+			err := validateSelections(doc, fragment.SelectionSet, depth, spreading)
+			delete(spreading, selection.Name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// execution holds the state of one request's execution
+type execution struct {
+	schema    *Schema
+	doc       *Document
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+func (e *execution) fail(path []interface{}, format string, args ...interface{}) {
+	e.errors = append(e.errors, &Error{Message: fmt.Sprintf(format, args...), Path: path})
+}
+
+func (e *execution) resolverFailed(path []interface{}, err error) {
+	failure := &Error{Message: err.Error(), Path: path}
+	if e.schema.Extensions != nil {
+		failure.Extensions = e.schema.Extensions(err)
+	}
+	e.errors = append(e.errors, failure)
+}
+
+// executeObject resolves a selection set against a value of a schema type
+func (e *execution) executeObject(ctx context.Context, object *Object, source interface{}, selections []Selection, path []interface{}) *OrderedMap {
+	result := &OrderedMap{}
+	for _, field := range e.collectFields(selections, object.Name) {
+		key := field.ResponseKey()
+		if result.Has(key) {
+			continue
+		}
+		fieldPath := appendPath(path, key)
+
+		if field.Name == "__typename" {
+			result.Set(key, object.Name)
+			continue
+		}
+
+		resolver, ok := object.Fields[field.Name]
+		if !ok && source == nil {
+			e.fail(fieldPath, "cannot query field %q on %s", field.Name, object.Name)
+			result.Set(key, nil)
+			continue
+		}
+		if !ok {
+			value := source
+			if object.Unwrap != nil {
+				value = object.Unwrap(source)
+			}
+			result.Set(key, e.completeField(field, value, fieldPath))
+			continue
+		}
+
+		value, err := resolver.Resolve(ctx, source, e.arguments(field.Arguments))
+		if err != nil {
+			e.resolverFailed(fieldPath, err)
+			result.Set(key, nil)
+			continue
+		}
+		result.Set(key, e.completeValue(ctx, resolver.Type, field, value, fieldPath))
+	}
+	return result
+}
+
+// completeValue shapes a resolved value according to the field's selections
+func (e *execution) completeValue(ctx context.Context, object *Object, field *Field, value interface{}, path []interface{}) interface{} {
+	if object == nil {
+		return e.completeGeneric(field, reflect.ValueOf(value), path)
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || (isNillable(v) && v.IsNil()) {
+		return nil
+	}
+	if len(field.SelectionSet) == 0 {
+		e.fail(path, "field %q of type %s must have a selection of subfields", field.Name, object.Name)
+		return nil
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = e.completeValue(ctx, object, field, v.Index(i).Interface(), appendPath(path, i))
+		}
+		return list
+	}
+	return e.executeObject(ctx, object, value, field.SelectionSet, path)
+}
+
+// completeField reads a field without a resolver from a plain Go value
+func (e *execution) completeField(field *Field, source interface{}, path []interface{}) interface{} {
+	v := indirect(reflect.ValueOf(source))
+	if !v.IsValid() {
+		return nil
+	}
+
+	value, ok := lookup(v, field.Name)
+	if !ok {
+		e.fail(path, "cannot query field %q on %s", field.Name, typeName(v))
+		return nil
+	}
+	return e.completeGeneric(field, value, path)
+}
+
+// completeGeneric shapes a plain Go value: structs and maps need selections, lists are mapped
+// element by element and anything else is returned as is
+func (e *execution) completeGeneric(field *Field, v reflect.Value, path []interface{}) interface{} {
+	v = indirect(v)
+	if !v.IsValid() || (isNillable(v) && v.IsNil()) {
+		return nil
+	}
+
+	if isLeaf(v) {
+		if len(field.SelectionSet) > 0 {
+			e.fail(path, "field %q must not have a selection since %s has no subfields", field.Name, typeName(v))
+			return nil
+		}
+		return v.Interface()
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = e.completeGeneric(field, v.Index(i), appendPath(path, i))
+		}
+		return list
+	}
+
+	if len(field.SelectionSet) == 0 {
+		e.fail(path, "field %q of type %s must have a selection of subfields", field.Name, typeName(v))
+		return nil
+	}
+	result := &OrderedMap{}
+	for _, subfield := range e.collectFields(field.SelectionSet, "") {
+		key := subfield.ResponseKey()
+		if result.Has(key) {
+			continue
+		}
+		if subfield.Name == "__typename" {
+			result.Set(key, typeName(v))
+			continue
+		}
+		result.Set(key, e.completeField(subfield, v.Interface(), appendPath(path, key)))
+	}
+	return result
+}
+
+// collectFields flattens fragments and drops selections skipped by @skip or @include. Type
+// conditions are only checked against schema types; plain values match any condition.
+func (e *execution) collectFields(selections []Selection, typeName string) []*Field {
+	var fields []*Field
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *Field:
+			if e.included(selection.Directives) {
+				fields = append(fields, selection)
+			}
+		case *InlineFragment:
+			if e.included(selection.Directives) && matchesType(selection.TypeCondition, typeName) {
+				fields = append(fields, e.collectFields(selection.SelectionSet, typeName)...)
+			}
+		case *FragmentSpread:
+			fragment := e.doc.Fragments[selection.Name]
+			if e.included(selection.Directives) && matchesType(fragment.TypeCondition, typeName) {
+				fields = append(fields, e.collectFields(fragment.SelectionSet, typeName)...)
+			}
+		}
+	}
+	return fields
+}
+
+func matchesType(condition, typeName string) bool {
+	return condition == "" || typeName == "" || condition == typeName
+}
+
+func (e *execution) included(directives []*Directive) bool {
+	for _, directive := range directives {
+		condition, _ := e.value(directive.Arguments["if"]).(bool)
+		switch directive.Name {
+		case "skip":
+			if condition {
+				return false
+			}
+		case "include":
+			if !condition {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// arguments substitutes variables into a field's arguments
+func (e *execution) arguments(arguments map[string]interface{}) Args {
+	args := make(Args, len(arguments))
+	for name, value := range arguments {
+		args[name] = e.value(value)
+	}
+	return args
+}
+
+func (e *execution) value(value interface{}) interface{} {
+	switch value := value.(type) {
+	case Variable:
+		return e.variables[value.Name]
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, item := range value {
+			list[i] = e.value(item)
+		}
+		return list
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for name, item := range value {
+			object[name] = e.value(item)
+		}
+		return object
+	}
+	return value
+}
+
+// lookup finds a struct field by its JSON name, or a map entry by its key
+func lookup(v reflect.Value, name string) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		return value, value.IsValid()
+	case reflect.Struct:
+		return lookupStructField(v, name)
+	}
+	return reflect.Value{}, false
+}
+
+func lookupStructField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && jsonName == "" {
+			if embedded := indirect(v.Field(i)); embedded.Kind() == reflect.Struct {
+				if value, ok := lookupStructField(embedded, name); ok {
+					return value, true
+				}
+			}
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		if jsonName == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// isLeaf reports whether a value is a scalar: anything that isn't a struct, map or list, plus
+// types such as time.Time that marshal themselves and byte slices
+func isLeaf(v reflect.Value) bool {
+	if v.Type().Implements(jsonMarshalerType) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		return false
+	case reflect.Slice, reflect.Array:
+		return v.Type().Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
+
+func isNillable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// indirect follows pointers and interfaces to the value they hold
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func typeName(v reflect.Value) string {
+	if name := v.Type().Name(); name != "" {
+		return name
+	}
+	return v.Type().String()
+}
+
+func appendPath(path []interface{}, element interface{}) []interface{} {
+	extended := make([]interface{}, len(path), len(path)+1)
+	copy(extended, path)
+	return append(extended, element)
+}
+
+// OrderedMap is a JSON object that keeps its keys in the order the query selected them
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// Set adds or replaces a key
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if m.values == nil {
+		m.values = make(map[string]interface{})
+	}
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Has reports whether a key has been set
+func (m *OrderedMap) Has(key string) bool {
+	_, exists := m.values[key]
+	return exists
+}
+
+// Get returns a key's value
+func (m *OrderedMap) Get(key string) interface{} {
+	return m.values[key]
+}
+
+// MarshalJSON writes the object with its keys in selection order
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
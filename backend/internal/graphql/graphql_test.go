@@ -0,0 +1,218 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPlayer struct {
+	ID         string    `json:"playerId"`
+	Name       string    `json:"username"`
+	Score      int       `json:"totalScore"`
+	Secret     string    `json:"-"`
+	JoinedAt   time.Time `json:"joinedAt"`
+	Highlights []string  `json:"highlights"`
+}
+
+type testSession struct {
+	ID      string        `json:"sessionId"`
+	Players []*testPlayer `json:"players"`
+}
+
+func testSchema() *Schema {
+	session := &Object{
+		Name: "Session",
+		Fields: map[string]*FieldResolver{
+			"playerCount": {Resolve: func(ctx context.Context, source interface{}, args Args) (interface{}, error) {
+				return len(source.(*testSession).Players), nil
+			}},
+			"broken": {Resolve: func(ctx context.Context, source interface{}, args Args) (interface{}, error) {
+				return nil, errors.New("progress is unavailable")
+			}},
+		},
+	}
+
+	return &Schema{
+		Query: &Object{
+			Name: "Query",
+			Fields: map[string]*FieldResolver{
+				"session": {Type: session, Resolve: func(ctx context.Context, source interface{}, args Args) (interface{}, error) {
+					if args.String("id") != "s1" {
+						return nil, nil
+					}
+					return &testSession{ID: "s1", Players: []*testPlayer{
+						{ID: "p1", Name: "alice", Score: 150, Secret: "hunter2", Highlights: []string{"wall"}},
+						{ID: "p2", Name: "bob", Score: 100},
+					}}, nil
+				}},
+				"echo": {Resolve: func(ctx context.Context, source interface{}, args Args) (interface{}, error) {
+					return map[string]interface{}{"text": args.String("text"), "times": args.Int("times", 1)}, nil
+				}},
+			},
+		},
+		Extensions: func(err error) map[string]interface{} {
+			return map[string]interface{}{"code": "TEST"}
+		},
+	}
+}
+
+// execute runs a query and returns its response as JSON
+func execute(t *testing.T, req Request) string {
+	t.Helper()
+	body, err := json.Marshal(testSchema().Execute(context.Background(), req))
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	return string(body)
+}
+
+func TestExecute_ResolvesNestedSelections(t *testing.T) {
+	got := execute(t, Request{Query: `
+		query Results($id: String!) {
+			session(id: $id) {
+				__typename
+				sessionId
+				count: playerCount
+				players { username totalScore highlights }
+			}
+		}`, Variables: map[string]interface{}{"id": "s1"}})
+
+	want := `{"data":{"session":{"__typename":"Session","sessionId":"s1","count":2,` +
+		`"players":[{"username":"alice","totalScore":150,"highlights":["wall"]},{"username":"bob","totalScore":100,"highlights":null}]}}}`
+	if got != want {
+		t.Errorf("Unexpected response\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestExecute_Fragments(t *testing.T) {
+	got := execute(t, Request{Query: `
+		{
+			session(id: "s1") {
+				...Summary
+				players { ... on testPlayer { playerId } }
+				playerCount @skip(if: true)
+			}
+		}
+		fragment Summary on Session { sessionId }`})
+
+	want := `{"data":{"session":{"sessionId":"s1","players":[{"playerId":"p1"},{"playerId":"p2"}]}}}`
+	if got != want {
+		t.Errorf("Unexpected response\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestExecute_Arguments(t *testing.T) {
+	got := execute(t, Request{
+		Query:     `query Echo($times: Int = 3) { echo(text: "hi\n", times: $times) { text times } }`,
+		Variables: map[string]interface{}{},
+	})
+
+	want := `{"data":{"echo":{"text":"hi\n","times":3}}}`
+	if got != want {
+		t.Errorf("Unexpected response\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "resolver errors null the field",
+			query: `{ session(id: "s1") { sessionId broken } }`,
+			want:  `{"data":{"session":{"sessionId":"s1","broken":null}},"errors":[{"message":"progress is unavailable","path":["session","broken"],"extensions":{"code":"TEST"}}]}`,
+		},
+		{
+			name:  "hidden fields cannot be queried",
+			query: `{ session(id: "s1") { players { secret } } }`,
+			want:  `{"data":{"session":{"players":[{"secret":null},{"secret":null}]}},"errors":[{"message":"cannot query field \"secret\" on testPlayer","path":["session","players",0,"secret"]},{"message":"cannot query field \"secret\" on testPlayer","path":["session","players",1,"secret"]}]}`,
+		},
+		{
+			name:  "objects need a selection",
+			query: `{ session(id: "s1") }`,
+			want:  `{"data":{"session":null},"errors":[{"message":"field \"session\" of type Session must have a selection of subfields","path":["session"]}]}`,
+		},
+		{
+			name:  "scalars cannot have a selection",
+			query: `{ session(id: "s1") { sessionId { length } } }`,
+			want:  `{"data":{"session":{"sessionId":null}},"errors":[{"message":"field \"sessionId\" must not have a selection since string has no subfields","path":["session","sessionId"]}]}`,
+		},
+		{
+			name:  "unknown root fields",
+			query: `{ sessions { sessionId } }`,
+			want:  `{"data":{"sessions":null},"errors":[{"message":"cannot query field \"sessions\" on Query","path":["sessions"]}]}`,
+		},
+		{
+			name:  "missing objects are null",
+			query: `{ session(id: "missing") { sessionId } }`,
+			want:  `{"data":{"session":null}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execute(t, Request{Query: tt.query}); got != tt.want {
+				t.Errorf("Unexpected response\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_RejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     Request
+		message string
+	}{
+		{"syntax errors", Request{Query: `{ session(id: "s1" { sessionId } }`}, "expected a name"},
+		{"mutations", Request{Query: `mutation { session(id: "s1") { sessionId } }`}, "mutation operations are not supported"},
+		{"ambiguous operations", Request{Query: `query A { echo { text } } query B { echo { text } }`}, "operationName is required"},
+		{"unknown operations", Request{Query: `query A { echo { text } }`, OperationName: "B"}, `unknown operation "B"`},
+		{"unknown fragments", Request{Query: `{ ...Missing }`}, `unknown fragment "Missing"`},
+		{"fragment cycles", Request{Query: `{ session(id: "s1") { ...A } } fragment A on Session { ...B } fragment B on Session { ...A }`}, "spreads itself"},
+		{"deep queries", Request{Query: strings.Repeat("{ echo ", MaxDepth+1) + "{ text }" + strings.Repeat(" }", MaxDepth+1)}, "nested more than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := testSchema().Execute(context.Background(), tt.req)
+			if resp.Data != nil {
+				t.Errorf("Expected no data for a rejected request, got %v", resp.Data)
+			}
+			if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, tt.message) {
+				t.Errorf("Expected an error containing %q, got %+v", tt.message, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestParse_Values(t *testing.T) {
+	doc, err := Parse(`# comment
+		query Q($limit: [Int!]! = [1, 2]) {
+			field(s: """block "quoted" text""", f: -1.5e2, b: false, n: null, e: WEEK, l: [$limit], o: {k: "v"})
+		}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	operation := doc.Operations[0]
+	if operation.Name != "Q" || len(operation.Variables) != 1 || len(operation.Variables[0].Default.([]interface{})) != 2 {
+		t.Fatalf("Unexpected operation %+v", operation)
+	}
+	args := operation.SelectionSet[0].(*Field).Arguments
+	if args["s"] != `block "quoted" text` || args["f"] != -150.0 || args["b"] != false || args["n"] != nil || args["e"] != EnumValue("WEEK") {
+		t.Errorf("Unexpected scalar arguments %+v", args)
+	}
+	if list := args["l"].([]interface{}); len(list) != 1 || list[0] != (Variable{Name: "limit"}) {
+		t.Errorf("Expected a list holding the $limit variable, got %+v", args["l"])
+	}
+	if object := args["o"].(map[string]interface{}); object["k"] != "v" {
+		t.Errorf("Expected an object argument, got %+v", args["o"])
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AchievementHandler serves the achievements players have unlocked
+type AchievementHandler struct {
+	achievementService services.AchievementService
+}
+
+// NewAchievementHandler creates a new achievement handler
+func NewAchievementHandler(achievementService services.AchievementService) *AchievementHandler {
+	return &AchievementHandler{
+		achievementService: achievementService,
+	}
+}
+
+// GetAchievements returns a player's unlocked achievements, oldest first
+func (h *AchievementHandler) GetAchievements(c *fiber.Ctx) error {
+	playerID := c.Params("playerId")
+	if playerID == "" {
+		return missingParameter("Player ID must be provided in the URL path")
+	}
+
+	achievements, err := h.achievementService.ListAchievements(c.UserContext(), playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get achievements"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success":      true,
+		"achievements": achievements,
+		"count":        len(achievements),
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminAnomalyHandler lets staff review players flagged for scripted play
+type AdminAnomalyHandler struct {
+	anomalyService services.AnomalyService
+	auditService   services.AuditService
+}
+
+// NewAdminAnomalyHandler creates a new admin anomaly handler
+func NewAdminAnomalyHandler(anomalyService services.AnomalyService, auditService services.AuditService) *AdminAnomalyHandler {
+	return &AdminAnomalyHandler{
+		anomalyService: anomalyService,
+		auditService:   auditService,
+	}
+}
+
+// ListFlaggedPlayers returns a page of flagged players with the cadence that got them flagged
+func (h *AdminAnomalyHandler) ListFlaggedPlayers(c *fiber.Ctx) error {
+	page, err := h.anomalyService.ListFlagged(c.UserContext(), models.PlayerActivityFilter{
+		Page:     c.QueryInt("page", 1),
+		PageSize: c.QueryInt("pageSize", services.DefaultAnomalyPageSize),
+	})
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list flagged players"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"page":    page,
+	})
+}
+
+// ClearPlayer lifts a player's flag after review, listing their games on the leaderboards again
+func (h *AdminAnomalyHandler) ClearPlayer(c *fiber.Ctx) error {
+	playerID := c.Params("playerId")
+	activity, err := h.anomalyService.Clear(c.UserContext(), playerID, auditActor(c).ID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to clear player"))
+	}
+	recordAudit(c, h.auditService, models.AuditAnomalyCleared, models.AuditTarget{Type: "player", ID: playerID}, nil, fiber.Map{"flagged": false})
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"activity": activity,
+	})
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"bytes"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminDoorHandler handles door curation requests from admins
+type AdminDoorHandler struct {
+	doorAdminService services.DoorAdminService
+	auditService     services.AuditService
+}
+
+// NewAdminDoorHandler creates a new admin door handler that records door changes in the audit log
+func NewAdminDoorHandler(doorAdminService services.DoorAdminService, auditService services.AuditService) *AdminDoorHandler {
+	return &AdminDoorHandler{
+		doorAdminService: doorAdminService,
+		auditService:     auditService,
+	}
+}
+
+// CreateDoorRequest represents the request body for creating a door
+type CreateDoorRequest struct {
+	Content               string            `json:"content" validate:"required"`
+	Theme                 string            `json:"theme" validate:"required"`
+	Difficulty            int               `json:"difficulty" validate:"required,min=1,max=3"`
+	Locale                string            `json:"locale,omitempty"`
+	Translations          map[string]string `json:"translations,omitempty"`
+	ExpectedSolutionTypes []string          `json:"expectedSolutionTypes,omitempty"`
+}
+
+// UpdateDoorStatusRequest represents the request body for moving a door through moderation
+type UpdateDoorStatusRequest struct {
+	Status models.DoorStatus `json:"status" validate:"required,oneof=draft approved published"`
+}
+
+// ListDoors returns a page of doors filtered by theme, status and difficulty
+func (h *AdminDoorHandler) ListDoors(c *fiber.Ctx) error {
+	page, err := h.doorAdminService.ListDoors(c.UserContext(), models.DoorFilter{
+		Theme:      c.Query("theme"),
+		Status:     models.DoorStatus(c.Query("status")),
+		Difficulty: c.QueryInt("difficulty", 0),
+		Page:       c.QueryInt("page", 1),
+		PageSize:   c.QueryInt("pageSize", services.DefaultDoorPageSize),
+	})
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list doors"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"page":    page,
+	})
+}
+
+// GetDoor returns a single door in any status
+func (h *AdminDoorHandler) GetDoor(c *fiber.Ctx) error {
+	door, err := h.doorAdminService.GetDoor(c.UserContext(), c.Params("doorId"))
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to get door"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"door":    door,
+	})
+}
+
+// CreateDoor adds a curated door as a draft
+func (h *AdminDoorHandler) CreateDoor(c *fiber.Ctx) error {
+	var req CreateDoorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	door, err := h.doorAdminService.CreateDoor(c.UserContext(), &models.Door{
+		Content:               req.Content,
+		Theme:                 req.Theme,
+		Difficulty:            req.Difficulty,
+		Locale:                req.Locale,
+		Translations:          req.Translations,
+		ExpectedSolutionTypes: req.ExpectedSolutionTypes,
+	})
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to create door"))
+	}
+	recordAudit(c, h.auditService, models.AuditDoorCreated, doorTarget(door.DoorID), nil, door)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"door":    door,
+	})
+}
+
+// ImportDoors stores a JSON or CSV file of doors as drafts, all of them or none. The file is sent
+// as the multipart field file or as the request body; its format comes from the format query,
+// else the file's extension or content type. With dryRun=true the file is only checked.
+func (h *AdminDoorHandler) ImportDoors(c *fiber.Ctx) error {
+	data, format, err := importFile(c)
+	if err != nil {
+		return err
+	}
+	if query := c.Query("format"); query != "" {
+		format = services.DoorImportFormat(strings.ToLower(query))
+	}
+
+	result, err := h.doorAdminService.ImportDoors(c.UserContext(), data, format, c.QueryBool("dryRun"))
+	if err != nil {
+		appErr := serviceError(err, middleware.ValidationError("Failed to import doors"))
+		if result != nil {
+			appErr.WithDetails("rows", result.Rows).WithDetails("errors", result.Errors)
+		}
+		return appErr
+	}
+
+	status := fiber.StatusOK
+	if !result.DryRun {
+		doorIDs := make([]string, len(result.Doors))
+		for i, door := range result.Doors {
+			doorIDs[i] = door.DoorID
+		}
+		recordAudit(c, h.auditService, models.AuditDoorsImported, models.AuditTarget{Type: "door"}, nil,
+			fiber.Map{"imported": result.Imported, "doorIds": doorIDs})
+		status = fiber.StatusCreated
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": true,
+		"import":  result,
+	})
+}
+
+// UpdateDoor edits a door's fields
+func (h *AdminDoorHandler) UpdateDoor(c *fiber.Ctx) error {
+	var req services.DoorUpdate
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	before, err := h.currentDoor(c)
+	if err != nil {
+		return err
+	}
+	door, err := h.doorAdminService.UpdateDoor(c.UserContext(), c.Params("doorId"), req)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to update door"))
+	}
+	recordAudit(c, h.auditService, models.AuditDoorUpdated, doorTarget(door.DoorID), before, door)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"door":    door,
+	})
+}
+
+// UpdateDoorStatus approves, publishes or returns a door to draft
+func (h *AdminDoorHandler) UpdateDoorStatus(c *fiber.Ctx) error {
+	var req UpdateDoorStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	before, err := h.currentDoor(c)
+	if err != nil {
+		return err
+	}
+	door, err := h.doorAdminService.SetDoorStatus(c.UserContext(), c.Params("doorId"), req.Status)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to update door status"))
+	}
+	recordAudit(c, h.auditService, models.AuditDoorStatusChanged, doorTarget(door.DoorID),
+		fiber.Map{"status": before.Status}, fiber.Map{"status": door.Status})
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"door":    door,
+	})
+}
+
+// DeleteDoor removes a door
+func (h *AdminDoorHandler) DeleteDoor(c *fiber.Ctx) error {
+	before, err := h.currentDoor(c)
+	if err != nil {
+		return err
+	}
+	if err := h.doorAdminService.DeleteDoor(c.UserContext(), c.Params("doorId")); err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to delete door"))
+	}
+	recordAudit(c, h.auditService, models.AuditDoorDeleted, doorTarget(before.DoorID), before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Door deleted",
+	})
+}
+
+// currentDoor returns a copy of the door named in the path, as it was before the request changed it
+func (h *AdminDoorHandler) currentDoor(c *fiber.Ctx) (*models.Door, error) {
+	door, err := h.doorAdminService.GetDoor(c.UserContext(), c.Params("doorId"))
+	if err != nil {
+		return nil, serviceError(err, middleware.ValidationError("Failed to get door"))
+	}
+	before := *door
+	before.Translations = make(map[string]string, len(door.Translations))
+	for locale, content := range door.Translations {
+		before.Translations[locale] = content
+	}
+	return &before, nil
+}
+
+// importFile returns the uploaded door file and the format its name or content type suggests
+func importFile(c *fiber.Ctx) (io.Reader, services.DoorImportFormat, error) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		if len(c.Body()) == 0 {
+			return nil, "", missingParameter("A JSON or CSV file of doors is required")
+		}
+		return bytes.NewReader(c.Body()), importFormat("", c.Get(fiber.HeaderContentType)), nil
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, "", missingParameter("A JSON or CSV file of doors is required in the file field")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, "", invalidBody(err)
+	}
+	defer file.Close()
+
+	// The multipart file may be backed by a temporary file removed when the request ends
+	var data bytes.Buffer
+	if _, err := data.ReadFrom(file); err != nil {
+		return nil, "", invalidBody(err)
+	}
+	return &data, importFormat(header.Filename, header.Header.Get(fiber.HeaderContentType)), nil
+}
+
+// importFormat guesses a door file's format from its extension, else its content type
+func importFormat(filename, contentType string) services.DoorImportFormat {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return services.DoorImportCSV
+	case ".json":
+		return services.DoorImportJSON
+	}
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return services.DoorImportCSV
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+		return services.DoorImportJSON
+	}
+	return ""
+}
+
+// doorTarget names a door as the target of an audited action
+func doorTarget(doorID string) models.AuditTarget {
+	return models.AuditTarget{Type: "door", ID: doorID}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminExportHandler streams leaderboard and session data to staff for their own analysis
+type AdminExportHandler struct {
+	exportService services.ExportService
+	auditService  services.AuditService
+}
+
+// NewAdminExportHandler creates a new admin export handler
+func NewAdminExportHandler(exportService services.ExportService, auditService services.AuditService) *AdminExportHandler {
+	return &AdminExportHandler{
+		exportService: exportService,
+		auditService:  auditService,
+	}
+}
+
+// ExportLeaderboard streams leaderboard entries completed since the since query (RFC 3339) as
+// CSV or NDJSON, chosen by the format query. An interrupted export is resumed by passing the
+// last cursor received as after.
+func (h *AdminExportHandler) ExportLeaderboard(c *fiber.Ctx) error {
+	filter, err := exportFilter(c)
+	if err != nil {
+		return err
+	}
+
+	export, err := h.exportService.ExportLeaderboard(c.UserContext(), filter)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to export the leaderboard"))
+	}
+	recordAudit(c, h.auditService, models.AuditDataExported, models.AuditTarget{Type: "leaderboard"}, nil, filter)
+
+	return streamExport(c, export, "leaderboard", filter.Format)
+}
+
+// ExportSessions streams session summaries, one row per player in CSV, filtered like
+// ExportLeaderboard and optionally by status
+func (h *AdminExportHandler) ExportSessions(c *fiber.Ctx) error {
+	filter, err := exportFilter(c)
+	if err != nil {
+		return err
+	}
+	if status := models.GameStatus(c.Query("status")); status != "" {
+		if !validSessionStatus(status) {
+			return invalidParameter("Unknown session status: " + string(status))
+		}
+		filter.Status = status
+	}
+
+	export, err := h.exportService.ExportSessions(c.UserContext(), filter)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to export sessions"))
+	}
+	recordAudit(c, h.auditService, models.AuditDataExported, models.AuditTarget{Type: "session"}, nil, filter)
+
+	return streamExport(c, export, "sessions", filter.Format)
+}
+
+// exportFilter reads the format, since and after queries shared by the exports
+func exportFilter(c *fiber.Ctx) (models.ExportFilter, error) {
+	filter := models.ExportFilter{
+		Format: models.ExportFormat(c.Query("format", string(models.ExportCSV))),
+		After:  c.Query("after"),
+	}
+	if since := c.Query("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return filter, invalidParameter("since must be an RFC 3339 time, e.g. 2024-01-02T15:04:05Z")
+		}
+		filter.Since = &parsed
+	}
+	return filter, nil
+}
+
+// streamExport writes the export as a downloadable file, one chunk at a time. Once streaming
+// has started the status can no longer change, so later failures end the download early.
+func streamExport(c *fiber.Ctx, export *services.Export, name string, format models.ExportFormat) error {
+	c.Set(fiber.HeaderContentType, export.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().UTC().Format("20060102T150405Z"), format))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	// The request context ends with the handler, and the stream outlives it
+	ctx := context.WithoutCancel(c.UserContext())
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := export.Stream(ctx, w); err != nil {
+			log.Printf("%s export ended early: %v", name, err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminModerationHandler handles moderation review requests from admins
+type AdminModerationHandler struct {
+	moderationService services.ModerationService
+}
+
+// NewAdminModerationHandler creates a new admin moderation handler
+func NewAdminModerationHandler(moderationService services.ModerationService) *AdminModerationHandler {
+	return &AdminModerationHandler{
+		moderationService: moderationService,
+	}
+}
+
+// ListEvents returns a page of masked and rejected responses filtered by player and action
+func (h *AdminModerationHandler) ListEvents(c *fiber.Ctx) error {
+	page, err := h.moderationService.ListEvents(c.UserContext(), models.ModerationEventFilter{
+		PlayerID: c.Query("playerId"),
+		Action:   models.ModerationAction(c.Query("action")),
+		Page:     c.QueryInt("page", 1),
+		PageSize: c.QueryInt("pageSize", services.DefaultModerationPageSize),
+	})
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list moderation events"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"page":    page,
+	})
+}
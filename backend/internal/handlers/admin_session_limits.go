@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminSessionLimitsHandler lets staff adjust how many sessions subreddits and players may run at once
+type AdminSessionLimitsHandler struct {
+	quotaService services.SessionQuotaService
+	auditService services.AuditService
+}
+
+// NewAdminSessionLimitsHandler creates a new admin session limits handler
+func NewAdminSessionLimitsHandler(quotaService services.SessionQuotaService, auditService services.AuditService) *AdminSessionLimitsHandler {
+	return &AdminSessionLimitsHandler{
+		quotaService: quotaService,
+		auditService: auditService,
+	}
+}
+
+// GetLimits returns the limits in force and, for the subreddit or playerId query, how much of
+// that quota is in use
+func (h *AdminSessionLimitsHandler) GetLimits(c *fiber.Ctx) error {
+	limits, err := h.quotaService.Limits(c.UserContext())
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get session limits"))
+	}
+
+	response := fiber.Map{
+		"success": true,
+		"limits":  limits,
+	}
+	scope, id := models.QuotaScopeSubreddit, c.Query("subreddit")
+	if id == "" {
+		scope, id = models.QuotaScopePlayer, c.Query("playerId")
+	}
+	if id != "" {
+		usage, err := h.quotaService.Usage(c.UserContext(), scope, id)
+		if err != nil {
+			return serviceError(err, middleware.InternalError("Failed to get session quota usage"))
+		}
+		response["usage"] = usage
+	}
+
+	return c.JSON(response)
+}
+
+// UpdateLimits replaces the limits in force; running sessions are not affected
+func (h *AdminSessionLimitsHandler) UpdateLimits(c *fiber.Ctx) error {
+	var req models.SessionLimits
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	before, err := h.quotaService.Limits(c.UserContext())
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get session limits"))
+	}
+	limits, err := h.quotaService.SetLimits(c.UserContext(), req)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to update session limits"))
+	}
+	recordAudit(c, h.auditService, models.AuditSessionLimitsSet, models.AuditTarget{Type: "session_limits"}, before, limits)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"limits":  limits,
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminSessionHandler lets staff inspect sessions, such as those abandoned by the janitor, and expire them
+type AdminSessionHandler struct {
+	janitor      services.SessionJanitor
+	auditService services.AuditService
+}
+
+// NewAdminSessionHandler creates a new admin session handler
+func NewAdminSessionHandler(janitor services.SessionJanitor, auditService services.AuditService) *AdminSessionHandler {
+	return &AdminSessionHandler{
+		janitor:      janitor,
+		auditService: auditService,
+	}
+}
+
+// ListSessions returns the sessions in the state given by the status query, abandoned by default
+func (h *AdminSessionHandler) ListSessions(c *fiber.Ctx) error {
+	status := models.GameStatus(c.Query("status", string(models.GameStatusAbandoned)))
+	if !validSessionStatus(status) {
+		return invalidParameter("Unknown session status: " + string(status))
+	}
+
+	sessions, err := h.janitor.ListSessions(c.UserContext(), status)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list sessions"))
+	}
+	if sessions == nil {
+		sessions = []*models.GameSession{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"status":   status,
+		"count":    len(sessions),
+		"sessions": sessions,
+	})
+}
+
+// ExpireInactiveSessions abandons every session idle past the inactivity window now, instead
+// of waiting for the janitor's next sweep
+func (h *AdminSessionHandler) ExpireInactiveSessions(c *fiber.Ctx) error {
+	abandoned, err := h.janitor.Sweep(c.UserContext())
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to expire inactive sessions"))
+	}
+	recordAudit(c, h.auditService, models.AuditSessionsSwept, models.AuditTarget{Type: "session"}, nil, fiber.Map{"abandoned": abandoned})
+
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"abandoned": abandoned,
+	})
+}
+
+// ExpireSession abandons a single session, closing its players' connections
+func (h *AdminSessionHandler) ExpireSession(c *fiber.Ctx) error {
+	change, err := h.janitor.Expire(c.UserContext(), c.Params("sessionId"))
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to expire session"))
+	}
+	recordAudit(c, h.auditService, models.AuditSessionExpired, models.AuditTarget{Type: "session", ID: change.SessionID},
+		fiber.Map{"status": change.From}, fiber.Map{"status": change.To})
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"transition": change,
+	})
+}
+
+// validSessionStatus reports whether status names a session state
+func validSessionStatus(status models.GameStatus) bool {
+	switch status {
+	case models.GameStatusWaiting, models.GameStatusStarting, models.GameStatusActive, models.GameStatusScoring,
+		models.GameStatusRevealing, models.GameStatusPaused, models.GameStatusCompleting, models.GameStatusCompleted,
+		models.GameStatusAbandoned:
+		return true
+	}
+	return false
+}
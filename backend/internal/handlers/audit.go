@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// serviceActorID identifies services in the audit log; their API keys are never recorded
+const serviceActorID = "service"
+
+// AuditHandler serves the audit log of privileged actions to admins
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListEvents returns a page of audit events filtered by actor, action and a since time (RFC 3339)
+func (h *AuditHandler) ListEvents(c *fiber.Ctx) error {
+	filter := models.AuditEventFilter{
+		ActorID:  c.Query("actor"),
+		Action:   models.AuditAction(c.Query("action")),
+		Page:     c.QueryInt("page", 1),
+		PageSize: c.QueryInt("pageSize", services.DefaultAuditPageSize),
+	}
+	if since := c.Query("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return invalidParameter("since must be an RFC 3339 time, e.g. 2024-01-02T15:04:05Z")
+		}
+		filter.Since = &parsed
+	}
+
+	page, err := h.auditService.ListEvents(c.UserContext(), filter)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list audit events"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"page":    page,
+	})
+}
+
+// recordAudit records a privileged action taken by the caller. The action has already
+// happened, so a failure to record it is logged rather than returned.
+func recordAudit(c *fiber.Ctx, auditService services.AuditService, action models.AuditAction, target models.AuditTarget, before, after interface{}) {
+	if auditService == nil {
+		return
+	}
+
+	event := &models.AuditEvent{
+		Action: action,
+		Actor:  auditActor(c),
+		Target: target,
+		Before: before,
+		After:  after,
+	}
+	if err := auditService.Record(c.UserContext(), event); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// auditActor describes the caller: the authenticated player, or a service
+func auditActor(c *fiber.Ctx) models.AuditActor {
+	var actor models.AuditActor
+	for _, role := range middleware.CallerRoles(c) {
+		actor.Roles = append(actor.Roles, string(role))
+	}
+
+	if player, ok := middleware.AuthenticatedPlayer(c); ok {
+		actor.ID = player.PlayerID
+		actor.Name = player.Username
+	} else {
+		actor.ID = serviceActorID
+	}
+	return actor
+}
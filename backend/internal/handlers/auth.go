@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuthHandler exchanges Devvit/Reddit identities for player access tokens
+type AuthHandler struct {
+	authService    services.AuthService
+	devvitService  services.DevvitIntegration
+	allowAnonymous bool
+}
+
+// NewAuthHandler creates a new auth handler. Anonymous identities are only exchanged when
+// allowAnonymous is set, which is meant for local development.
+func NewAuthHandler(authService services.AuthService, devvitService services.DevvitIntegration, allowAnonymous bool) *AuthHandler {
+	return &AuthHandler{
+		authService:    authService,
+		devvitService:  devvitService,
+		allowAnonymous: allowAnonymous,
+	}
+}
+
+// IssueToken issues a short-lived access token for the Reddit user making the request
+func (h *AuthHandler) IssueToken(c *fiber.Ctx) error {
+	if err := h.devvitService.ValidateDevvitRequest(c); err != nil {
+		log.Printf("Devvit validation error: %v", err)
+		return middleware.UnauthorizedError("Invalid Devvit request").WithCause(err)
+	}
+
+	user, err := h.devvitService.GetCurrentUser(c)
+	if err != nil {
+		return middleware.UnauthorizedError("Failed to identify user").WithCause(err)
+	}
+
+	if user.ID == "anonymous" && !h.allowAnonymous {
+		return middleware.UnauthorizedError("A Reddit identity is required").WithCode(middleware.CodeAnonymousUser)
+	}
+
+	token, err := h.authService.IssueToken(user)
+	if err != nil {
+		return middleware.InternalError("Failed to issue access token").WithCause(err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"auth":    token,
+	})
+}
+
+// authorizePlayer checks that a player ID named in a request belongs to the authenticated
+// caller, defaulting to the caller when none is given. Routes without authentication keep
+// the claimed ID.
+func authorizePlayer(c *fiber.Ctx, claimed string) (string, error) {
+	player, ok := middleware.AuthenticatedPlayer(c)
+	if !ok {
+		return claimed, nil
+	}
+
+	if claimed != "" && claimed != player.PlayerID {
+		return "", middleware.ForbiddenError("Player ID does not match the authenticated player").WithCode(middleware.CodePlayerMismatch)
+	}
+	return player.PlayerID, nil
+}
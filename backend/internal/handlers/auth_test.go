@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newAuthApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler()})
+	handler := NewAuthHandler(
+		services.NewAuthService([]byte("token secret"), time.Minute),
+		services.NewDevvitIntegration(services.WithRequestSigning("devvit secret")),
+		false,
+	)
+	app.Post("/auth/token", handler.IssueToken)
+	return app
+}
+
+func TestIssueToken_RequiresSignedDevvitRequest(t *testing.T) {
+	app := newAuthApp()
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	cases := []struct {
+		name      string
+		timestamp string
+		signature string
+		status    int
+	}{
+		{"forged identity headers", "", "", fiber.StatusUnauthorized},
+		{"signed with another secret", now, services.SignDevvitRequest("guess", now, "t2_alice", "alice", ""), fiber.StatusUnauthorized},
+		{"signed for another user", now, services.SignDevvitRequest("devvit secret", now, "t2_bob", "bob", ""), fiber.StatusUnauthorized},
+		{"replayed", stale, services.SignDevvitRequest("devvit secret", stale, "t2_alice", "alice", ""), fiber.StatusUnauthorized},
+		{"signed by Devvit", now, services.SignDevvitRequest("devvit secret", now, "t2_alice", "alice", ""), fiber.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("POST", "/auth/token", nil)
+		req.Header.Set("X-Reddit-User-ID", "t2_alice")
+		req.Header.Set("X-Reddit-Username", "alice")
+		if tc.timestamp != "" {
+			req.Header.Set(services.DevvitTimestampHeader, tc.timestamp)
+			req.Header.Set(services.DevvitSignatureHeader, tc.signature)
+		}
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", tc.name, err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d, got %d", tc.name, tc.status, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ChatHandler serves session chat history; live messages travel over the game socket
+type ChatHandler struct {
+	chatService services.ChatService
+}
+
+// NewChatHandler creates a new chat handler
+func NewChatHandler(chatService services.ChatService) *ChatHandler {
+	return &ChatHandler{
+		chatService: chatService,
+	}
+}
+
+// GetHistory returns the session's recent chat messages so reconnecting players can catch up
+func (h *ChatHandler) GetHistory(c *fiber.Ctx) error {
+	playerID, err := authorizePlayer(c, c.Query("playerId"))
+	if err != nil {
+		return err
+	}
+
+	sessionID := c.Params("sessionId")
+	messages, err := h.chatService.GetHistory(c.UserContext(), sessionID, playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get chat history"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"sessionId": sessionID,
+		"messages":  messages,
+	})
+}
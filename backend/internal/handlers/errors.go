@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// serviceErrorMapping describes the AppError a service error is reported as
+type serviceErrorMapping struct {
+	err       error
+	errorType middleware.ErrorType
+	status    int
+	code      string
+	message   string // shown instead of the service's own message when set
+}
+
+// serviceErrors maps the errors services return to the status and code clients receive. The
+// first match wins, so errors that wrap others come before them.
+var serviceErrors = []serviceErrorMapping{
+	{err: services.ErrSessionNotFound, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeSessionNotFound},
+	{err: services.ErrSessionFull, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeSessionFull},
+	{err: services.ErrNotAcceptingPlayers, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeNotAcceptingPlayers},
+	{err: services.ErrAlreadyInSession, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeAlreadyInSession},
+	{err: services.ErrPlayerNotInSession, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodePlayerNotInSession},
+	{err: services.ErrNoActiveDoor, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeNoActiveDoor},
+	{err: services.ErrAlreadyResponded, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeAlreadyResponded},
+	{err: services.ErrSessionQuotaExceeded, errorType: middleware.ErrorTypeRateLimit, status: fiber.StatusTooManyRequests, code: middleware.CodeSessionQuotaExceeded},
+	{err: services.ErrInvalidSessionLimits, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidSessionLimits},
+	{err: services.ErrInvalidSessionSettings, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidSessionSettings},
+	{err: services.ErrInvalidPlayerOptions, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidPlayerOptions},
+	{err: services.ErrResponseWindowClosed, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeResponseWindowClosed},
+	{err: services.ErrRouteNotFound, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeRouteNotFound},
+	{err: services.ErrInvalidConnectionToken, errorType: middleware.ErrorTypeUnauthorized, status: fiber.StatusUnauthorized, code: middleware.CodeInvalidRouteToken},
+	{err: services.ErrWrongInstance, errorType: middleware.ErrorTypeConflict, status: fiber.StatusMisdirectedRequest, code: middleware.CodeWrongInstance},
+	{err: services.ErrJoinCodeNotFound, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeSessionNotFound},
+	{err: services.ErrIncorrectPassword, errorType: middleware.ErrorTypeUnauthorized, status: fiber.StatusUnauthorized, code: middleware.CodeIncorrectPassword},
+	{err: services.ErrLobbyLocked, errorType: middleware.ErrorTypeForbidden, status: fiber.StatusForbidden, code: middleware.CodeLobbyLocked},
+	{err: services.ErrPlayerKicked, errorType: middleware.ErrorTypeForbidden, status: fiber.StatusForbidden, code: middleware.CodePlayerKicked},
+	{err: services.ErrNotHost, errorType: middleware.ErrorTypeForbidden, status: fiber.StatusForbidden, code: middleware.CodeNotHost},
+	{err: services.ErrGamePaused, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeGamePaused},
+	{err: services.ErrIllegalOperation, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeIllegalOperation},
+	{err: services.ErrInvalidTransition, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeIllegalOperation},
+	{err: services.ErrPlayersNotReady, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodePlayersNotReady},
+	{err: services.ErrTeamFull, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeTeamFull},
+	{err: services.ErrTeamsIncomplete, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeTeamsIncomplete},
+	{err: services.ErrNotTeamSession, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeNotTeamSession},
+	{err: services.ErrSpectatorReadOnly, errorType: middleware.ErrorTypeForbidden, status: fiber.StatusForbidden, code: middleware.CodeSpectatorReadOnly},
+	{err: services.ErrResponseRejected, errorType: middleware.ErrorTypeValidation, status: fiber.StatusUnprocessableEntity, code: middleware.CodeResponseRejected,
+		message: "Your response contains content that isn't allowed. Please rephrase it."},
+	{err: services.ErrDuplicateResponse, errorType: middleware.ErrorTypeValidation, status: fiber.StatusUnprocessableEntity, code: middleware.CodeDuplicateResponse,
+		message: "Your response repeats an earlier answer. Please write something new."},
+	{err: services.ErrPlayerActivityNotFound, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeActivityNotFound},
+	{err: services.ErrInvalidVote, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidVote},
+	{err: services.ErrScoringBacklogFull, errorType: middleware.ErrorTypeServiceUnavailable, status: fiber.StatusServiceUnavailable, code: middleware.CodeScoringBacklogFull},
+	{err: services.ErrWorkerPoolFull, errorType: middleware.ErrorTypeServiceUnavailable, status: fiber.StatusServiceUnavailable, code: middleware.CodeServerBusy},
+	{err: services.ErrDoorNotFound, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeDoorNotFound},
+	{err: services.ErrInvalidDoorTransition, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeInvalidDoorTransition},
+	{err: services.ErrInvalidDoorImport, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidDoorImport},
+	{err: services.ErrDoorImportRejected, errorType: middleware.ErrorTypeValidation, status: fiber.StatusUnprocessableEntity, code: middleware.CodeDoorImportRejected},
+	{err: services.ErrPathGraphUnavailable, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodePathGraphUnavailable},
+	{err: services.ErrThemeNotFound, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeThemeNotFound},
+	{err: services.ErrThemeUnavailable, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeThemeUnavailable},
+	{err: services.ErrInvalidTheme, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidTheme},
+	{err: services.ErrChatNotMember, errorType: middleware.ErrorTypeForbidden, status: fiber.StatusForbidden, code: middleware.CodeNotSessionMember},
+	{err: services.ErrInvalidChatMessage, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidChatMessage},
+	{err: services.ErrChatRateLimited, errorType: middleware.ErrorTypeRateLimit, status: fiber.StatusTooManyRequests, code: middleware.CodeRateLimited},
+	{err: services.ErrInvalidFriend, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidFriend},
+	{err: services.ErrNotFriends, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeNotFriends},
+	{err: services.ErrAlreadyFriends, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeAlreadyFriends},
+	{err: services.ErrFriendListFull, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeFriendListFull},
+	{err: services.ErrFriendsNotEnabled, errorType: middleware.ErrorTypeServiceUnavailable, status: fiber.StatusServiceUnavailable, code: middleware.CodeFriendsDisabled},
+	{err: services.ErrProfileNotFound, errorType: middleware.ErrorTypeNotFound, status: fiber.StatusNotFound, code: middleware.CodeProfileNotFound,
+		message: "This player has not completed a game yet"},
+	{err: services.ErrReplayUnavailable, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeReplayUnavailable},
+	{err: services.ErrSummaryUnavailable, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeSummaryUnavailable},
+	{err: services.ErrInvalidExportFormat, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidParameter},
+	{err: repositories.ErrInvalidExportCursor, errorType: middleware.ErrorTypeValidation, status: fiber.StatusBadRequest, code: middleware.CodeInvalidParameter},
+	{err: repositories.ErrSessionConflict, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeSessionConflict},
+	{err: repositories.ErrTournamentConflict, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeTournamentConflict},
+	{err: repositories.ErrTournamentClosed, errorType: middleware.ErrorTypeConflict, status: fiber.StatusConflict, code: middleware.CodeTournamentClosed},
+}
+
+// serviceError turns a failed service call into the AppError the central error handler
+// reports. Known service errors get their own status and code; anything else is reported
+// as the fallback, with the service's message attached to client errors.
+func serviceError(err error, fallback *middleware.AppError) *middleware.AppError {
+	var appErr *middleware.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	for _, mapping := range serviceErrors {
+		if errors.Is(err, mapping.err) {
+			message := mapping.message
+			if message == "" {
+				message = err.Error()
+			}
+			return middleware.NewAppError(mapping.errorType, message, mapping.status).WithCode(mapping.code).WithCause(err)
+		}
+	}
+
+	if fallback.StatusCode < fiber.StatusInternalServerError {
+		fallback.WithDetails("reason", err.Error())
+	}
+	return fallback.WithCause(err)
+}
+
+// invalidBody reports a request body that could not be parsed
+func invalidBody(err error) *middleware.AppError {
+	return middleware.ValidationError("Invalid request body").
+		WithCode(middleware.CodeInvalidRequestBody).
+		WithDetails("reason", err.Error()).
+		WithCause(err)
+}
+
+// missingParameter reports a required path, query or body parameter the request left out
+func missingParameter(message string) *middleware.AppError {
+	return middleware.ValidationError(message).WithCode(middleware.CodeMissingParameter)
+}
+
+// invalidParameter reports a parameter whose value the handler cannot use
+func invalidParameter(message string) *middleware.AppError {
+	return middleware.ValidationError(message).WithCode(middleware.CodeInvalidParameter)
+}
+
+// unavailable reports an optional service this deployment runs without
+func unavailable(message string) *middleware.AppError {
+	return middleware.ServiceUnavailableError(message)
+}
+
+// upgradeRequired reports a plain HTTP request to a WebSocket-only endpoint
+func upgradeRequired() *middleware.AppError {
+	return middleware.NewAppError(middleware.ErrorTypeValidation, "This endpoint requires a WebSocket connection", fiber.StatusUpgradeRequired).
+		WithCode(middleware.CodeUpgradeRequired)
+}
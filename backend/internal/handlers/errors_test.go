@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestServiceError_MapsKnownErrors(t *testing.T) {
+	err := fmt.Errorf("failed to join session: %w", services.ErrLobbyLocked)
+
+	appErr := serviceError(err, middleware.ValidationError("Failed to join session"))
+	if appErr.StatusCode != fiber.StatusForbidden || appErr.Code != middleware.CodeLobbyLocked {
+		t.Errorf("Expected a forbidden LOBBY_LOCKED error, got %d %s", appErr.StatusCode, appErr.Code)
+	}
+	if appErr.Message != err.Error() || !errors.Is(appErr.Cause, services.ErrLobbyLocked) {
+		t.Errorf("Expected the service's message and cause, got %q (%v)", appErr.Message, appErr.Cause)
+	}
+
+	// Mappings can replace messages players shouldn't see
+	if appErr = serviceError(services.ErrResponseRejected, middleware.ValidationError("Failed to submit response")); appErr.Message == services.ErrResponseRejected.Error() {
+		t.Errorf("Expected a player-facing message for rejected responses, got %q", appErr.Message)
+	}
+}
+
+func TestServiceError_GameErrors(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{services.ErrSessionNotFound, fiber.StatusNotFound, middleware.CodeSessionNotFound},
+		{services.ErrChatSessionNotFound, fiber.StatusNotFound, middleware.CodeSessionNotFound},
+		{services.ErrReplayNotFound, fiber.StatusNotFound, middleware.CodeSessionNotFound},
+		{services.ErrSessionFull, fiber.StatusConflict, middleware.CodeSessionFull},
+		{services.ErrNotAcceptingPlayers, fiber.StatusConflict, middleware.CodeNotAcceptingPlayers},
+		{services.ErrAlreadyInSession, fiber.StatusConflict, middleware.CodeAlreadyInSession},
+		{services.ErrPlayerNotInSession, fiber.StatusNotFound, middleware.CodePlayerNotInSession},
+		{services.ErrNoActiveDoor, fiber.StatusConflict, middleware.CodeNoActiveDoor},
+		{services.ErrAlreadyResponded, fiber.StatusConflict, middleware.CodeAlreadyResponded},
+		{services.ErrGamePaused, fiber.StatusConflict, middleware.CodeGamePaused},
+		{services.ErrIllegalOperation, fiber.StatusConflict, middleware.CodeIllegalOperation},
+		{services.ErrInvalidTransition, fiber.StatusConflict, middleware.CodeIllegalOperation},
+		{services.ErrIncorrectPassword, fiber.StatusUnauthorized, middleware.CodeIncorrectPassword},
+		{services.ErrSpectatorReadOnly, fiber.StatusForbidden, middleware.CodeSpectatorReadOnly},
+		{services.ErrResponseRejected, fiber.StatusUnprocessableEntity, middleware.CodeResponseRejected},
+		{services.ErrScoringBacklogFull, fiber.StatusServiceUnavailable, middleware.CodeScoringBacklogFull},
+
+		// A join refused by the state machine wraps both errors and reports the more specific one
+		{fmt.Errorf("%w: %w", services.ErrNotAcceptingPlayers, services.ErrIllegalOperation), fiber.StatusConflict, middleware.CodeNotAcceptingPlayers},
+	}
+	for _, tc := range cases {
+		appErr := serviceError(fmt.Errorf("failed: %w", tc.err), middleware.InternalError("Failed"))
+		if appErr.StatusCode != tc.status || appErr.Code != tc.code {
+			t.Errorf("%v: expected %d %s, got %d %s", tc.err, tc.status, tc.code, appErr.StatusCode, appErr.Code)
+		}
+	}
+}
+
+func TestServiceError_EveryMappingIsReachable(t *testing.T) {
+	for _, mapping := range serviceErrors {
+		if appErr := serviceError(mapping.err, middleware.InternalError("Failed")); appErr.Code != mapping.code {
+			t.Errorf("%v is reported as %s by an earlier mapping instead of %s", mapping.err, appErr.Code, mapping.code)
+		}
+	}
+}
+
+func TestServiceError_FallsBack(t *testing.T) {
+	err := errors.New("player is already the host")
+
+	appErr := serviceError(err, middleware.ValidationError("Failed to transfer host"))
+	if appErr.StatusCode != fiber.StatusBadRequest || appErr.Message != "Failed to transfer host" || appErr.Details["reason"] != err.Error() {
+		t.Errorf("Expected the fallback with the service's reason, got %d %q %v", appErr.StatusCode, appErr.Message, appErr.Details)
+	}
+
+	// Internal failures don't leak their cause to clients
+	appErr = serviceError(errors.New("connection refused"), middleware.InternalError("Failed to create session"))
+	if _, leaked := appErr.Details["reason"]; leaked || appErr.Cause == nil {
+		t.Errorf("Expected the cause to be kept for logging only, got %v", appErr.Details)
+	}
+
+	// Errors that already are AppErrors pass through
+	forbidden := middleware.ForbiddenError("nope")
+	if serviceError(fmt.Errorf("wrapped: %w", forbidden), middleware.InternalError("Failed")) != forbidden {
+		t.Error("Expected an AppError to be returned as is")
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FriendHandler manages players' friend lists
+type FriendHandler struct {
+	friendService services.FriendService
+}
+
+// NewFriendHandler creates a new friend handler
+func NewFriendHandler(friendService services.FriendService) *FriendHandler {
+	return &FriendHandler{
+		friendService: friendService,
+	}
+}
+
+// AddFriendRequest represents the request body for adding a friend
+type AddFriendRequest struct {
+	FriendID string `json:"friendId" validate:"required"`
+}
+
+// ListFriends returns the authenticated player's friend list
+func (h *FriendHandler) ListFriends(c *fiber.Ctx) error {
+	playerID, err := authorizePlayer(c, c.Params("playerId"))
+	if err != nil {
+		return err
+	}
+
+	friends, err := h.friendService.ListFriends(c.UserContext(), playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list friends"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"friends": friends,
+		"count":   len(friends),
+	})
+}
+
+// AddFriend adds a player to the authenticated player's friend list
+func (h *FriendHandler) AddFriend(c *fiber.Ctx) error {
+	var req AddFriendRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	playerID, err := authorizePlayer(c, c.Params("playerId"))
+	if err != nil {
+		return err
+	}
+
+	if err := h.friendService.AddFriend(c.UserContext(), playerID, req.FriendID); err != nil {
+		return serviceError(err, middleware.InternalError("Failed to add friend"))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success":  true,
+		"friendId": req.FriendID,
+	})
+}
+
+// RemoveFriend removes a player from the authenticated player's friend list
+func (h *FriendHandler) RemoveFriend(c *fiber.Ctx) error {
+	playerID, err := authorizePlayer(c, c.Params("playerId"))
+	if err != nil {
+		return err
+	}
+
+	if err := h.friendService.RemoveFriend(c.UserContext(), playerID, c.Params("friendId")); err != nil {
+		return serviceError(err, middleware.InternalError("Failed to remove friend"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Friend removed",
+	})
+}
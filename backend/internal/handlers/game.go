@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/middleware"
 	"dumdoors-backend/internal/models"
 	"dumdoors-backend/internal/services"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -12,34 +17,62 @@ type GameHandler struct {
 	gameService        services.GameService
 	progressService    services.ProgressService
 	leaderboardService services.LeaderboardService
+	draftService       services.DraftService
+	auditService       services.AuditService
+	routingService     services.RoutingService
 }
 
-// NewGameHandler creates a new game handler
-func NewGameHandler(gameService services.GameService, progressService services.ProgressService, leaderboardService services.LeaderboardService) *GameHandler {
+// NewGameHandler creates a new game handler; host actions are recorded in the audit log and
+// players creating or joining a session get a connection token routing them to its instance
+func NewGameHandler(gameService services.GameService, progressService services.ProgressService, leaderboardService services.LeaderboardService, draftService services.DraftService, auditService services.AuditService, routingService services.RoutingService) *GameHandler {
 	return &GameHandler{
 		gameService:        gameService,
 		progressService:    progressService,
 		leaderboardService: leaderboardService,
+		draftService:       draftService,
+		auditService:       auditService,
+		routingService:     routingService,
 	}
 }
 
 // CreateSessionRequest represents the request body for creating a session
 type CreateSessionRequest struct {
-	Mode     string  `json:"mode" validate:"required,oneof=multiplayer single-player"`
-	Theme    *string `json:"theme,omitempty"`
-	PlayerID string  `json:"playerId" validate:"required"`
-	Username string  `json:"username" validate:"required"`
+	Mode            string                 `json:"mode" validate:"required,oneof=multiplayer single-player"`
+	Theme           *string                `json:"theme,omitempty"`
+	Locale          string                 `json:"locale,omitempty"`
+	ScoringMode     models.ScoringMode     `json:"scoringMode,omitempty" validate:"omitempty,oneof=ai peer-vote"`
+	ReadyPercent    int                    `json:"readyPercent,omitempty" validate:"omitempty,min=0,max=100"` // share of players that must be ready before the game starts
+	IsPrivate       bool                   `json:"isPrivate,omitempty"`                                       // joined only by code, never listed
+	Password        string                 `json:"password,omitempty" validate:"omitempty,max=72"`            // optional, private sessions only
+	BotOpponents    int                    `json:"botOpponents,omitempty" validate:"omitempty,min=0,max=3"`   // AI opponents, single-player only
+	RevealMode      models.RevealMode      `json:"revealMode,omitempty" validate:"omitempty,oneof=anonymous attributed off"`
+	ScoringStrategy models.ScoringStrategy `json:"scoringStrategy,omitempty" validate:"omitempty,oneof=average weighted max-metric theme-weighted rubric"` // how AI metrics are combined; the server default if empty
+	Format          models.MatchFormat     `json:"format,omitempty" validate:"omitempty,oneof=path best-of"`                                          // best-of plays a fixed number of doors for everyone
+	Rounds          int                    `json:"rounds,omitempty" validate:"omitempty,min=1,max=15"`                                                // doors in a best-of match; 5 if empty
+	TeamSize        int                    `json:"teamSize,omitempty" validate:"omitempty,oneof=2 4"`                                                 // players per team for 2v2 or 4v4, multiplayer only
+	MaxLength       int                    `json:"maxLength,omitempty" validate:"omitempty,min=100,max=2000"`                                         // character limit of responses; 500 if empty
+	Profanity       models.ProfanityFilter `json:"profanity,omitempty" validate:"omitempty,oneof=mask reject allow"`                                  // what happens to responses with profanity; masked if empty
+	PlayerID        string                 `json:"playerId" validate:"required"`
+	Username        string                 `json:"username" validate:"required"`
 }
 
 // JoinSessionRequest represents the request body for joining a session
 type JoinSessionRequest struct {
 	PlayerID string `json:"playerId" validate:"required"`
 	Username string `json:"username" validate:"required"`
+	Password string `json:"password,omitempty"` // required by password-protected sessions
+}
+
+// SpectateRequest represents the request body for watching a session
+type SpectateRequest struct {
+	SpectatorID string `json:"spectatorId" validate:"required"`
+	Username    string `json:"username" validate:"required"`
 }
 
 // StartGameRequest represents the request body for starting a game
 type StartGameRequest struct {
 	SessionID string `json:"sessionId" validate:"required"`
+	PlayerID  string `json:"playerId,omitempty"` // the host, defaults to the authenticated player
 }
 
 // GetAPIInfo returns basic API information
@@ -55,11 +88,15 @@ func (h *GameHandler) GetAPIInfo(c *fiber.Ctx) error {
 func (h *GameHandler) CreateSession(c *fiber.Ctx) error {
 	var req CreateSessionRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
-			"message": err.Error(),
-		})
+		return invalidBody(err)
+	}
+	
+	// Players may only act as themselves
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
 	}
+	req.PlayerID = playerID
 	
 	// Validate mode
 	var mode models.GameMode
@@ -69,24 +106,34 @@ func (h *GameHandler) CreateSession(c *fiber.Ctx) error {
 	case "single-player":
 		mode = models.GameModeSinglePlayer
 	default:
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid game mode",
-			"message": "Mode must be 'multiplayer' or 'single-player'",
-		})
+		return invalidParameter("Mode must be 'multiplayer' or 'single-player'")
 	}
 	
 	// Create session
-	session, err := h.gameService.CreateSession(c.Context(), mode, req.PlayerID, req.Username, req.Theme)
+	settings := models.SessionSettings{
+		ScoringMode:     req.ScoringMode,
+		ReadyPercent:    req.ReadyPercent,
+		IsPrivate:       req.IsPrivate,
+		Password:        req.Password,
+		BotOpponents:    req.BotOpponents,
+		RevealMode:      req.RevealMode,
+		ScoringStrategy: req.ScoringStrategy,
+		Format:          req.Format,
+		Rounds:          req.Rounds,
+		TeamSize:        req.TeamSize,
+		MaxLength:       req.MaxLength,
+		Profanity:       req.Profanity,
+		Post:            requestPost(c),
+	}
+	session, err := h.gameService.CreateSession(c.UserContext(), mode, req.PlayerID, req.Username, req.Theme, requestLocale(c, req.Locale), settings)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to create session",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to create session"))
 	}
 	
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"session": session,
+		"success":         true,
+		"session":         session.ForPlayer(req.PlayerID),
+		"connectionToken": h.connectionToken(c, session.SessionID),
 	})
 }
 
@@ -94,32 +141,114 @@ func (h *GameHandler) CreateSession(c *fiber.Ctx) error {
 func (h *GameHandler) JoinSession(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	var req JoinSessionRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
-			"message": err.Error(),
-		})
+		return invalidBody(err)
 	}
 	
+	// Players may only act as themselves
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	req.PlayerID = playerID
+	
 	// Join session
-	session, err := h.gameService.JoinSession(c.Context(), sessionID, req.PlayerID, req.Username)
+	session, err := h.gameService.JoinSession(c.UserContext(), sessionID, req.PlayerID, req.Username, req.Password)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to join session"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success":         true,
+		"session":         session.ForPlayer(req.PlayerID),
+		"connectionToken": h.connectionToken(c, session.SessionID),
+	})
+}
+
+// connectionToken pins a session to the instance holding it and returns the token routing the
+// player's connection there. Routing is an optimisation, so failures leave the token empty.
+func (h *GameHandler) connectionToken(c *fiber.Ctx, sessionID string) string {
+	if h.routingService == nil {
+		return ""
+	}
+	
+	route, err := h.routingService.Claim(c.UserContext(), sessionID)
+	if err != nil {
+		fmt.Printf("Warning: failed to route session %s: %v\n", sessionID, err)
+		return ""
+	}
+	return route.ConnectionToken
+}
+
+// LookupJoinCode resolves a join code to the session it was issued for
+func (h *GameHandler) LookupJoinCode(c *fiber.Ctx) error {
+	code := c.Params("code")
+	if code == "" {
+		return missingParameter("Join code must be provided in the URL path")
+	}
+	
+	lookup, err := h.gameService.LookupJoinCode(c.UserContext(), code)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to look up join code"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": lookup,
+	})
+}
+
+// ListOpenSessions lists public multiplayer sessions that are waiting for players
+func (h *GameHandler) ListOpenSessions(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", services.DefaultOpenSessionsLimit)
+	
+	sessions, err := h.gameService.ListOpenSessions(c.UserContext(), limit)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list open sessions"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"sessions": sessions,
+		"count":    len(sessions),
+	})
+}
+
+// Spectate joins a session as a read-only spectator
+func (h *GameHandler) Spectate(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req SpectateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	// Spectators are identified by their own player identity
+	spectatorID, err := authorizePlayer(c, req.SpectatorID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Failed to join session",
-			"message": err.Error(),
-		})
+		return err
+	}
+	req.SpectatorID = spectatorID
+	
+	if req.SpectatorID == "" {
+		return missingParameter("spectatorId must be provided in the request body")
+	}
+	
+	session, err := h.gameService.JoinAsSpectator(c.UserContext(), sessionID, req.SpectatorID, req.Username)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to spectate session"))
 	}
 	
 	return c.JSON(fiber.Map{
 		"success": true,
-		"session": session,
+		"session": session.ForPlayer(req.SpectatorID),
 	})
 }
 
@@ -127,42 +256,99 @@ func (h *GameHandler) JoinSession(c *fiber.Ctx) error {
 func (h *GameHandler) GetSessionStatus(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	// Only the caller's own responses are shown
+	playerID, err := authorizePlayer(c, "")
+	if err != nil {
+		return err
 	}
 	
-	session, err := h.gameService.GetSessionStatus(c.Context(), sessionID)
+	session, err := h.gameService.GetSessionStatus(c.UserContext(), sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   "Session not found",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get session"))
 	}
 	
 	return c.JSON(fiber.Map{
 		"success": true,
-		"session": session,
+		"session": session.ForPlayer(playerID),
 	})
 }
 
-// StartGame starts a game session
+// ResumeSession returns everything a reconnecting player needs to restore their game,
+// including any draft they were typing
+func (h *GameHandler) ResumeSession(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	playerID := c.Params("playerId")
+	if sessionID == "" || playerID == "" {
+		return missingParameter("Session ID and player ID must be provided in the URL path")
+	}
+	
+	playerID, err := authorizePlayer(c, playerID)
+	if err != nil {
+		return err
+	}
+	
+	resume, err := h.gameService.ResumeSession(c.UserContext(), sessionID, playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to resume session"))
+	}
+	
+	response := fiber.Map{
+		"success": true,
+		"resume":  resume,
+	}
+	
+	if h.draftService != nil && resume.CurrentDoor != nil && !resume.HasResponded {
+		if draft, err := h.draftService.GetDraft(c.UserContext(), sessionID, playerID); err != nil {
+			fmt.Printf("Warning: failed to load draft for resume: %v\n", err)
+		} else if draft != nil {
+			response["draft"] = draft
+		}
+	}
+	
+	return c.JSON(response)
+}
+
+// requireHost refuses a request to start the session from anyone but its host
+func (h *GameHandler) requireHost(c *fiber.Ctx, sessionID string) error {
+	var req StartGameRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return invalidBody(err)
+		}
+	}
+	
+	hostID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	session, err := h.gameService.GetSessionStatus(c.UserContext(), sessionID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get session"))
+	}
+	if hostID == "" || hostID != session.Host() {
+		return serviceError(services.ErrNotHost, middleware.ForbiddenError("Only the session host can start the game"))
+	}
+	return nil
+}
+
+// StartGame starts a game session for its host
 func (h *GameHandler) StartGame(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	if err := h.requireHost(c, sessionID); err != nil {
+		return err
 	}
 	
-	err := h.gameService.StartGame(c.Context(), sessionID)
+	err := h.gameService.StartGame(c.UserContext(), sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Failed to start game",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.ValidationError("Failed to start game"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -171,22 +357,371 @@ func (h *GameHandler) StartGame(c *fiber.Ctx) error {
 	})
 }
 
-// StartGameWithDoor starts a game session and presents the first door
+// ReadyRequest represents the request body for answering a session's ready check
+type ReadyRequest struct {
+	PlayerID string `json:"playerId" validate:"required"`
+	Ready    *bool  `json:"ready,omitempty"` // defaults to true
+}
+
+// SetReady marks a player as ready, or no longer ready, to start the game
+func (h *GameHandler) SetReady(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req ReadyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	// Players may only ready themselves
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	ready := req.Ready == nil || *req.Ready
+	status, err := h.gameService.SetPlayerReady(c.UserContext(), sessionID, playerID, ready)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to update ready state"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"ready":   status,
+	})
+}
+
+// PlayerOptionsRequest represents the request body for choosing handicap and accessibility options
+type PlayerOptionsRequest struct {
+	PlayerID          string  `json:"playerId" validate:"required"`
+	TimeMultiplier    float64 `json:"timeMultiplier,omitempty" validate:"omitempty,min=1,max=3"`         // e.g. 2 for twice the time to answer
+	MaxResponseLength int     `json:"maxResponseLength,omitempty" validate:"omitempty,min=500,max=1000"` // a raised character limit
+	TextOnly          bool    `json:"textOnly,omitempty"`
+}
+
+// SetPlayerOptions sets the player's handicap and accessibility options before the game starts
+func (h *GameHandler) SetPlayerOptions(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req PlayerOptionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	// Players may only choose their own options
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	options := models.PlayerOptions{
+		TimeMultiplier:    req.TimeMultiplier,
+		MaxResponseLength: req.MaxResponseLength,
+		TextOnly:          req.TextOnly,
+	}
+	session, err := h.gameService.SetPlayerOptions(c.UserContext(), sessionID, playerID, options)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to update player options"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session.ForPlayer(playerID),
+	})
+}
+
+// LeaveSessionRequest represents the request body for leaving a session
+type LeaveSessionRequest struct {
+	PlayerID string `json:"playerId" validate:"required"`
+}
+
+// LeaveSession takes the player out of the session; leaving a game in progress forfeits it
+func (h *GameHandler) LeaveSession(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req LeaveSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	// Players may only take themselves out
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	session, err := h.gameService.LeaveSession(c.UserContext(), sessionID, playerID)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to leave session"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session.ForPlayer(playerID),
+	})
+}
+
+// KickPlayerRequest represents the request body for removing a player from a lobby
+type KickPlayerRequest struct {
+	PlayerID       string `json:"playerId" validate:"required"` // the host
+	TargetPlayerID string `json:"targetPlayerId" validate:"required"`
+}
+
+// TransferHostRequest represents the request body for handing the host role to another player
+type TransferHostRequest struct {
+	PlayerID  string `json:"playerId" validate:"required"` // the current host
+	NewHostID string `json:"newHostId" validate:"required"`
+}
+
+// LockLobbyRequest represents the request body for locking or unlocking a lobby
+type LockLobbyRequest struct {
+	PlayerID string `json:"playerId" validate:"required"` // the host
+	Locked   *bool  `json:"locked,omitempty"`             // defaults to true
+}
+
+// AssignTeamRequest represents the request body for moving a player to another team
+type AssignTeamRequest struct {
+	PlayerID       string `json:"playerId" validate:"required"` // the host
+	TargetPlayerID string `json:"targetPlayerId" validate:"required"`
+	TeamID         string `json:"teamId" validate:"required,oneof=red blue"`
+}
+
+// BalanceTeamsRequest represents the request body for balancing a lobby's teams
+type BalanceTeamsRequest struct {
+	PlayerID string `json:"playerId" validate:"required"` // the host
+}
+
+// KickPlayer removes a player from the session at the host's request
+func (h *GameHandler) KickPlayer(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req KickPlayerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	if req.TargetPlayerID == "" {
+		return missingParameter("targetPlayerId must name the player to remove")
+	}
+	
+	// Only the host may act for the host
+	hostID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	session, err := h.gameService.KickPlayer(c.UserContext(), sessionID, hostID, req.TargetPlayerID)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to kick player"))
+	}
+	recordAudit(c, h.auditService, models.AuditPlayerKicked, models.AuditTarget{Type: "player", ID: req.TargetPlayerID, SessionID: sessionID}, nil, nil)
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session.ForPlayer(hostID),
+	})
+}
+
+// TransferHost hands the host role to another player in the session
+func (h *GameHandler) TransferHost(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req TransferHostRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	if req.NewHostID == "" {
+		return missingParameter("newHostId must name the player to hand the session to")
+	}
+	
+	hostID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	session, err := h.gameService.TransferHost(c.UserContext(), sessionID, hostID, req.NewHostID)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to transfer host"))
+	}
+	recordAudit(c, h.auditService, models.AuditHostTransferred, models.AuditTarget{Type: "session", ID: sessionID},
+		fiber.Map{"hostId": hostID}, fiber.Map{"hostId": req.NewHostID})
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session.ForPlayer(hostID),
+	})
+}
+
+// LockLobby locks the session against new joins, or unlocks it
+func (h *GameHandler) LockLobby(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req LockLobbyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	hostID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	locked := req.Locked == nil || *req.Locked
+	session, err := h.gameService.SetLobbyLocked(c.UserContext(), sessionID, hostID, locked)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to update lobby lock"))
+	}
+	recordAudit(c, h.auditService, models.AuditLobbyLocked, models.AuditTarget{Type: "session", ID: sessionID}, nil, fiber.Map{"locked": locked})
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session.ForPlayer(hostID),
+	})
+}
+
+// AssignTeam moves a player in the lobby to another team
+func (h *GameHandler) AssignTeam(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req AssignTeamRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	if req.TargetPlayerID == "" || req.TeamID == "" {
+		return missingParameter("targetPlayerId and teamId must name the player and the team to move them to")
+	}
+	
+	hostID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	session, err := h.gameService.AssignTeam(c.UserContext(), sessionID, hostID, req.TargetPlayerID, req.TeamID)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to assign team"))
+	}
+	recordAudit(c, h.auditService, models.AuditTeamsChanged, models.AuditTarget{Type: "player", ID: req.TargetPlayerID, SessionID: sessionID}, nil, fiber.Map{"teamId": req.TeamID})
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session.ForPlayer(hostID),
+	})
+}
+
+// BalanceTeams splits the lobby into two teams of similar strength
+func (h *GameHandler) BalanceTeams(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req BalanceTeamsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	hostID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	session, err := h.gameService.BalanceTeams(c.UserContext(), sessionID, hostID)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to balance teams"))
+	}
+	recordAudit(c, h.auditService, models.AuditTeamsChanged, models.AuditTarget{Type: "session", ID: sessionID}, nil, fiber.Map{"balanced": true})
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session.ForPlayer(hostID),
+	})
+}
+
+// PauseGameRequest represents the request body for pausing or resuming a game
+type PauseGameRequest struct {
+	PlayerID string `json:"playerId" validate:"required"` // the host
+}
+
+// PauseGame freezes the game's response timer at the host's request
+func (h *GameHandler) PauseGame(c *fiber.Ctx) error {
+	return h.setPaused(c, true)
+}
+
+// ResumeGame restarts a paused game with the time players had left
+func (h *GameHandler) ResumeGame(c *fiber.Ctx) error {
+	return h.setPaused(c, false)
+}
+
+// setPaused pauses or resumes the session named in the path for its host
+func (h *GameHandler) setPaused(c *fiber.Ctx, paused bool) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	
+	var req PauseGameRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	hostID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	var session *models.GameSession
+	action, fallback := models.AuditGameResumed, "Failed to resume game"
+	if paused {
+		action, fallback = models.AuditGamePaused, "Failed to pause game"
+		session, err = h.gameService.PauseGame(c.UserContext(), sessionID, hostID)
+	} else {
+		session, err = h.gameService.ResumeGame(c.UserContext(), sessionID, hostID)
+	}
+	if err != nil {
+		return serviceError(err, middleware.ValidationError(fallback))
+	}
+	recordAudit(c, h.auditService, action, models.AuditTarget{Type: "session", ID: sessionID}, nil, nil)
+	
+	return c.JSON(fiber.Map{
+		"success":              true,
+		"session":              session.ForPlayer(hostID),
+		"timeRemainingSeconds": int(session.TimeRemaining(time.Now()).Round(time.Second).Seconds()),
+	})
+}
+
+// StartGameWithDoor starts a game session for its host and presents the first door
 func (h *GameHandler) StartGameWithDoor(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
-	err := h.gameService.StartGameWithFirstDoor(c.Context(), sessionID)
+	if err := h.requireHost(c, sessionID); err != nil {
+		return err
+	}
+	
+	err := h.gameService.StartGameWithFirstDoor(c.UserContext(), sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Failed to start game with door",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.ValidationError("Failed to start game with door"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -199,57 +734,157 @@ func (h *GameHandler) StartGameWithDoor(c *fiber.Ctx) error {
 type SubmitResponseRequest struct {
 	SessionID string `json:"sessionId" validate:"required"`
 	PlayerID  string `json:"playerId" validate:"required"`
-	Response  string `json:"response" validate:"required,max=500"`
+	Response  string `json:"response" validate:"required,max=2000"` // at most the session's character limit, 500 unless set, or the player's raised limit
 }
 
 // SubmitResponse handles player response submission
 func (h *GameHandler) SubmitResponse(c *fiber.Ctx) error {
 	var req SubmitResponseRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
-			"message": err.Error(),
-		})
+		return invalidBody(err)
 	}
 	
-	// Validate response length (500 character limit as per requirements)
-	if len(req.Response) > 500 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Response too long",
-			"message": "Response must be 500 characters or less",
-		})
+	// Players may only act as themselves
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
 	}
+	req.PlayerID = playerID
 	
-	if len(req.Response) == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Response is required",
-			"message": "Response cannot be empty",
-		})
+	if req.Response == "" {
+		return missingParameter("Response cannot be empty")
+	}
+	
+	// Responses longer than any session allows are refused before the session is loaded, then
+	// the player's own limit is checked, counted in characters rather than bytes. The service
+	// checks it again against the session it updates.
+	if remaining, err := services.ValidateResponseLimit(req.Response, services.MaxSessionResponseLength); err != nil {
+		return responseTooLong(services.MaxSessionResponseLength, remaining)
+	}
+	if session, err := h.gameService.GetSessionStatus(c.UserContext(), req.SessionID); err == nil && session != nil {
+		limit := services.ResponseLimit(session, req.PlayerID)
+		if remaining, err := services.ValidateResponseLimit(req.Response, limit); err != nil {
+			return responseTooLong(limit, remaining)
+		}
 	}
 	
 	// Submit the response
-	err := h.gameService.SubmitResponse(c.Context(), req.SessionID, req.PlayerID, req.Response)
+	submitted, err := h.gameService.SubmitResponse(c.UserContext(), req.SessionID, req.PlayerID, req.Response)
+	if err != nil {
+		var tooLong *services.ResponseLengthError
+		if errors.As(err, &tooLong) {
+			return responseTooLong(tooLong.Limit, tooLong.Remaining)
+		}
+		return serviceError(err, middleware.ValidationError("Failed to submit response"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"message":   "Response submitted successfully",
+		"response":  submitted,
+		"remaining": submitted.CharacterLimit - services.ResponseLength(req.Response),
+	})
+}
+
+// responseTooLong reports a response over the player's character limit
+func responseTooLong(limit, remaining int) error {
+	return middleware.ValidationError(fmt.Sprintf("Response must be %d characters or less", limit)).
+		WithCode(middleware.CodeResponseTooLong).
+		WithDetails("maxLength", limit).
+		WithDetails("remaining", remaining)
+}
+
+// CastVoteRequest represents the request body for rating another player's response
+type CastVoteRequest struct {
+	SessionID  string `json:"sessionId" validate:"required"`
+	PlayerID   string `json:"playerId" validate:"required"`
+	ResponseID string `json:"responseId" validate:"required"`
+	Stars      int    `json:"stars" validate:"required,min=1,max=5"`
+}
+
+// CastVote records a star rating for another player's response in a peer-vote session
+func (h *GameHandler) CastVote(c *fiber.Ctx) error {
+	var req CastVoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	// Players may only vote as themselves
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	
+	err = h.gameService.CastVote(c.UserContext(), req.SessionID, playerID, req.ResponseID, req.Stars)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Failed to submit response",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.ValidationError("Failed to cast vote"))
 	}
 	
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Response submitted successfully",
+		"message": "Vote recorded",
+	})
+}
+
+// SaveDraftRequest represents the request body for autosaving a response draft
+type SaveDraftRequest struct {
+	SessionID string `json:"sessionId" validate:"required"`
+	PlayerID  string `json:"playerId" validate:"required"`
+	Response  string `json:"response"`
+}
+
+// SaveDraft autosaves a player's in-progress response to the current door
+func (h *GameHandler) SaveDraft(c *fiber.Ctx) error {
+	if h.draftService == nil {
+		return unavailable("Draft autosave is not available")
+	}
+	
+	var req SaveDraftRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+	
+	// Players may only act as themselves
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	req.PlayerID = playerID
+	
+	if req.SessionID == "" || req.PlayerID == "" {
+		return missingParameter("sessionId and playerId must be provided")
+	}
+	
+	draft, err := h.draftService.SaveDraft(c.UserContext(), req.SessionID, req.PlayerID, req.Response)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to save draft"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"draft":     draft,
+		"remaining": draftRemaining(draft, req.Response),
 	})
 }
 
+// draftRemaining returns how many characters the player has left after their draft. Cleared
+// drafts leave the standard limit.
+func draftRemaining(draft *models.ResponseDraft, content string) int {
+	limit := services.MaxResponseLength
+	if draft != nil && draft.Limit > 0 {
+		limit = draft.Limit
+	}
+	return limit - services.ResponseLength(content)
+}
+
 // GetNextDoor retrieves the next door for a specific player
 func (h *GameHandler) GetNextDoor(c *fiber.Ctx) error {
-	playerID := c.Query("playerId")
+	playerID, err := authorizePlayer(c, c.Query("playerId"))
+	if err != nil {
+		return err
+	}
 	if playerID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Player ID is required",
-			"message": "Player ID must be provided as a query parameter",
-		})
+		return missingParameter("Player ID must be provided as a query parameter")
 	}
 	
 	// Get current score from query params (default to 50 if not provided)
@@ -260,19 +895,13 @@ func (h *GameHandler) GetNextDoor(c *fiber.Ctx) error {
 		}
 	}
 	
-	door, err := h.gameService.GetNextDoor(playerID, currentScore)
+	door, err := h.gameService.GetNextDoor(c.UserContext(), playerID, currentScore, requestLocale(c, c.Query("locale")))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get next door",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get next door"))
 	}
 	
 	if door == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   "No door available",
-			"message": "No next door found for player",
-		})
+		return middleware.NotFoundError("No next door found for player").WithCode(middleware.CodeNoDoorAvailable)
 	}
 	
 	return c.JSON(fiber.Map{
@@ -285,25 +914,16 @@ func (h *GameHandler) GetNextDoor(c *fiber.Ctx) error {
 func (h *GameHandler) GetSessionProgress(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	if h.progressService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Progress service unavailable",
-			"message": "Progress tracking service is not available",
-		})
+		return unavailable("Progress tracking service is not available")
 	}
 	
-	progress, err := h.progressService.CalculateSessionProgress(c.Context(), sessionID)
+	progress, err := h.progressService.CalculateSessionProgress(c.UserContext(), sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get session progress",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get session progress"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -318,32 +938,20 @@ func (h *GameHandler) GetPlayerProgress(c *fiber.Ctx) error {
 	playerID := c.Params("playerId")
 	
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	if playerID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Player ID is required",
-			"message": "Player ID must be provided in the URL path",
-		})
+		return missingParameter("Player ID must be provided in the URL path")
 	}
 	
 	if h.progressService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Progress service unavailable",
-			"message": "Progress tracking service is not available",
-		})
+		return unavailable("Progress tracking service is not available")
 	}
 	
-	progress, err := h.progressService.CalculatePlayerProgress(c.Context(), sessionID, playerID)
+	progress, err := h.progressService.CalculatePlayerProgress(c.UserContext(), sessionID, playerID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get player progress",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get player progress"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -356,25 +964,16 @@ func (h *GameHandler) GetPlayerProgress(c *fiber.Ctx) error {
 func (h *GameHandler) GetLeaderboard(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	if h.progressService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Progress service unavailable",
-			"message": "Progress tracking service is not available",
-		})
+		return unavailable("Progress tracking service is not available")
 	}
 	
-	leaderboard, err := h.progressService.GetLeaderboard(c.Context(), sessionID)
+	leaderboard, err := h.progressService.GetLeaderboard(c.UserContext(), sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get leaderboard",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get leaderboard"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -387,25 +986,16 @@ func (h *GameHandler) GetLeaderboard(c *fiber.Ctx) error {
 func (h *GameHandler) GetRealTimeProgress(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	if h.progressService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Progress service unavailable",
-			"message": "Progress tracking service is not available",
-		})
+		return unavailable("Progress tracking service is not available")
 	}
 	
-	progress, err := h.progressService.GetRealTimeSessionStatus(c.Context(), sessionID)
+	progress, err := h.progressService.GetRealTimeSessionStatus(c.UserContext(), sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get real-time progress",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get real-time progress"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -419,25 +1009,16 @@ func (h *GameHandler) GetRealTimeProgress(c *fiber.Ctx) error {
 func (h *GameHandler) BroadcastProgressUpdate(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	if h.progressService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Progress service unavailable",
-			"message": "Progress tracking service is not available",
-		})
+		return unavailable("Progress tracking service is not available")
 	}
 	
-	err := h.progressService.BroadcastProgressUpdates(c.Context(), sessionID)
+	err := h.progressService.BroadcastProgressUpdates(c.UserContext(), sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to broadcast progress update",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to broadcast progress update"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -451,10 +1032,7 @@ func (h *GameHandler) BroadcastProgressUpdate(c *fiber.Ctx) error {
 // GetGlobalLeaderboard retrieves the global leaderboard with all categories
 func (h *GameHandler) GetGlobalLeaderboard(c *fiber.Ctx) error {
 	if h.leaderboardService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Leaderboard service unavailable",
-			"message": "Leaderboard service is not available",
-		})
+		return unavailable("Leaderboard service is not available")
 	}
 	
 	// Parse query parameters for filtering
@@ -475,12 +1053,20 @@ func (h *GameHandler) GetGlobalLeaderboard(c *fiber.Ctx) error {
 		filter.TimeRange = &timeRange
 	}
 	
-	leaderboard, err := h.leaderboardService.GetGlobalLeaderboard(c.Context(), filter)
+	if timezone := c.Query("timezone"); timezone != "" {
+		if _, err := models.ParseTimezone(timezone); err != nil {
+			return invalidParameter("Invalid timezone").WithDetails("reason", err.Error())
+		}
+		filter.Timezone = &timezone
+	}
+	
+	if friendsOf := c.Query("friendsOf"); friendsOf != "" {
+		filter.FriendsOf = &friendsOf
+	}
+	
+	leaderboard, err := h.leaderboardService.GetGlobalLeaderboard(c.UserContext(), filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get global leaderboard",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get global leaderboard"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -493,18 +1079,12 @@ func (h *GameHandler) GetGlobalLeaderboard(c *fiber.Ctx) error {
 // GetLeaderboardStats retrieves aggregated leaderboard statistics
 func (h *GameHandler) GetLeaderboardStats(c *fiber.Ctx) error {
 	if h.leaderboardService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Leaderboard service unavailable",
-			"message": "Leaderboard service is not available",
-		})
+		return unavailable("Leaderboard service is not available")
 	}
 	
-	stats, err := h.leaderboardService.GetLeaderboardStats(c.Context())
+	stats, err := h.leaderboardService.GetLeaderboardStats(c.UserContext())
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get leaderboard stats",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get leaderboard stats"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -516,10 +1096,7 @@ func (h *GameHandler) GetLeaderboardStats(c *fiber.Ctx) error {
 // GetFastestCompletions retrieves the fastest completion times leaderboard
 func (h *GameHandler) GetFastestCompletions(c *fiber.Ctx) error {
 	if h.leaderboardService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Leaderboard service unavailable",
-			"message": "Leaderboard service is not available",
-		})
+		return unavailable("Leaderboard service is not available")
 	}
 	
 	// Parse query parameters for filtering
@@ -540,12 +1117,20 @@ func (h *GameHandler) GetFastestCompletions(c *fiber.Ctx) error {
 		filter.TimeRange = &timeRange
 	}
 	
-	entries, err := h.leaderboardService.GetFastestCompletions(c.Context(), filter)
+	if timezone := c.Query("timezone"); timezone != "" {
+		if _, err := models.ParseTimezone(timezone); err != nil {
+			return invalidParameter("Invalid timezone").WithDetails("reason", err.Error())
+		}
+		filter.Timezone = &timezone
+	}
+	
+	if friendsOf := c.Query("friendsOf"); friendsOf != "" {
+		filter.FriendsOf = &friendsOf
+	}
+	
+	entries, err := h.leaderboardService.GetFastestCompletions(c.UserContext(), filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get fastest completions",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get fastest completions"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -558,10 +1143,7 @@ func (h *GameHandler) GetFastestCompletions(c *fiber.Ctx) error {
 // GetHighestAverageScores retrieves the highest average scores leaderboard
 func (h *GameHandler) GetHighestAverageScores(c *fiber.Ctx) error {
 	if h.leaderboardService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Leaderboard service unavailable",
-			"message": "Leaderboard service is not available",
-		})
+		return unavailable("Leaderboard service is not available")
 	}
 	
 	// Parse query parameters for filtering
@@ -582,12 +1164,20 @@ func (h *GameHandler) GetHighestAverageScores(c *fiber.Ctx) error {
 		filter.TimeRange = &timeRange
 	}
 	
-	entries, err := h.leaderboardService.GetHighestAverageScores(c.Context(), filter)
+	if timezone := c.Query("timezone"); timezone != "" {
+		if _, err := models.ParseTimezone(timezone); err != nil {
+			return invalidParameter("Invalid timezone").WithDetails("reason", err.Error())
+		}
+		filter.Timezone = &timezone
+	}
+	
+	if friendsOf := c.Query("friendsOf"); friendsOf != "" {
+		filter.FriendsOf = &friendsOf
+	}
+	
+	entries, err := h.leaderboardService.GetHighestAverageScores(c.UserContext(), filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get highest average scores",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get highest average scores"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -603,32 +1193,20 @@ func (h *GameHandler) GetPlayerRank(c *fiber.Ctx) error {
 	category := c.Params("category")
 	
 	if playerID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Player ID is required",
-			"message": "Player ID must be provided in the URL path",
-		})
+		return missingParameter("Player ID must be provided in the URL path")
 	}
 	
 	if category == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Category is required",
-			"message": "Category must be provided in the URL path",
-		})
+		return missingParameter("Category must be provided in the URL path")
 	}
 	
 	if h.leaderboardService == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
-			"error":   "Leaderboard service unavailable",
-			"message": "Leaderboard service is not available",
-		})
+		return unavailable("Leaderboard service is not available")
 	}
 	
-	rank, err := h.leaderboardService.GetPlayerRank(c.Context(), playerID, category)
+	rank, err := h.leaderboardService.GetPlayerRank(c.UserContext(), playerID, category)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to get player rank",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to get player rank"))
 	}
 	
 	return c.JSON(fiber.Map{
@@ -637,4 +1215,25 @@ func (h *GameHandler) GetPlayerRank(c *fiber.Ctx) error {
 		"category": category,
 		"rank":     rank,
 	})
-}
\ No newline at end of file
+}
+
+// requestLocale is the locale a request asked for explicitly, or else the one its Accept-Language header prefers
+func requestLocale(c *fiber.Ctx, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	return i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// requestPost is the Reddit post a request came from, as set by the Devvit app, or nil for
+// requests from outside Reddit
+func requestPost(c *fiber.Ctx) *models.PostContext {
+	postID := c.Get("X-Reddit-Post-ID")
+	if postID == "" {
+		return nil
+	}
+	return &models.PostContext{
+		PostID:        postID,
+		SubredditName: c.Get("X-Reddit-Subreddit"),
+	}
+}
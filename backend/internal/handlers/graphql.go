@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"dumdoors-backend/internal/graphql"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxGraphQLQueryLength bounds the size of a query document
+const maxGraphQLQueryLength = 8 * 1024
+
+// viewerKey carries the authenticated player a query runs for, whose view of sessions it sees
+type viewerKey struct{}
+
+// GraphQLHandler answers read-only GraphQL queries over sessions, players, progress,
+// leaderboards and profiles, so a results screen can be fetched in one request
+type GraphQLHandler struct {
+	gameService        services.GameService
+	progressService    services.ProgressService
+	leaderboardService services.LeaderboardService
+	profileService     services.ProfileService
+	schema             *graphql.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(gameService services.GameService, progressService services.ProgressService, leaderboardService services.LeaderboardService, profileService services.ProfileService) *GraphQLHandler {
+	h := &GraphQLHandler{
+		gameService:        gameService,
+		progressService:    progressService,
+		leaderboardService: leaderboardService,
+		profileService:     profileService,
+	}
+	h.schema = h.buildSchema()
+	return h
+}
+
+// Query executes a GraphQL query, sent as a JSON body by POST or as query parameters by GET
+func (h *GraphQLHandler) Query(c *fiber.Ctx) error {
+	var req graphql.Request
+	if c.Method() == fiber.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return invalidParameter("Invalid variables").WithDetails("reason", err.Error())
+			}
+		}
+	} else if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	if req.Query == "" {
+		return missingParameter("A GraphQL query must be provided")
+	}
+	if len(req.Query) > maxGraphQLQueryLength {
+		return invalidParameter("GraphQL query is too long")
+	}
+
+	ctx := c.UserContext()
+	if player, ok := middleware.AuthenticatedPlayer(c); ok {
+		ctx = context.WithValue(ctx, viewerKey{}, player.PlayerID)
+	}
+	resp := h.schema.Execute(ctx, req)
+	if resp.Data == nil {
+		c.Status(fiber.StatusBadRequest)
+	}
+	return c.JSON(resp)
+}
+
+// playerNode is a player resolved within the session they played in
+type playerNode struct {
+	sessionID string
+	player    *models.PlayerInfo
+}
+
+// buildSchema describes the types clients can query. Fields not listed are read straight from
+// the models by their JSON names.
+func (h *GraphQLHandler) buildSchema() *graphql.Schema {
+	player := &graphql.Object{
+		Name: "Player",
+		Fields: map[string]*graphql.FieldResolver{
+			"progress": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				node := source.(playerNode)
+				progress, err := h.progressService.CalculatePlayerProgress(ctx, node.sessionID, node.player.PlayerID)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get player progress"))
+				}
+				return progress, nil
+			}},
+			"profile": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return h.profile(ctx, source.(playerNode).player.PlayerID)
+			}},
+		},
+		Unwrap: func(source interface{}) interface{} {
+			return source.(playerNode).player
+		},
+	}
+
+	session := &graphql.Object{
+		Name: "GameSession",
+		Fields: map[string]*graphql.FieldResolver{
+			"players": {Type: player, Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				session := source.(*models.GameSession)
+				players := make([]playerNode, len(session.Players))
+				for i := range session.Players {
+					players[i] = playerNode{sessionID: session.SessionID, player: &session.Players[i]}
+				}
+				return players, nil
+			}},
+			"progress": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				progress, err := h.progressService.CalculateSessionProgress(ctx, source.(*models.GameSession).SessionID)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get session progress"))
+				}
+				return progress, nil
+			}},
+			"leaderboard": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				leaderboard, err := h.progressService.GetLeaderboard(ctx, source.(*models.GameSession).SessionID)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get session leaderboard"))
+				}
+				return leaderboard, nil
+			}},
+			"rankings": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				rankings, err := h.progressService.GetFinalRankings(ctx, source.(*models.GameSession).SessionID)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get final rankings"))
+				}
+				return rankings, nil
+			}},
+			"stats": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				stats, err := h.progressService.GetPerformanceStatistics(ctx, source.(*models.GameSession).SessionID)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get performance statistics"))
+				}
+				return stats, nil
+			}},
+		},
+	}
+
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: map[string]*graphql.FieldResolver{
+			"session": {Type: session, Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				sessionID := args.String("id")
+				if sessionID == "" {
+					return nil, missingParameter("session requires an id argument")
+				}
+				session, err := h.gameService.GetSessionStatus(ctx, sessionID)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get session"))
+				}
+				viewer, _ := ctx.Value(viewerKey{}).(string)
+				return session.ForPlayer(viewer), nil
+			}},
+			"profile": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				playerID := args.String("playerId")
+				if playerID == "" {
+					return nil, missingParameter("profile requires a playerId argument")
+				}
+				return h.profile(ctx, playerID)
+			}},
+			"leaderboard": {Resolve: h.resolveGlobalLeaderboard},
+			"leaderboardStats": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				if h.leaderboardService == nil {
+					return nil, unavailable("Leaderboard service is not available")
+				}
+				stats, err := h.leaderboardService.GetLeaderboardStats(ctx)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get leaderboard stats"))
+				}
+				return stats, nil
+			}},
+			"playerRank": {Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				if h.leaderboardService == nil {
+					return nil, unavailable("Leaderboard service is not available")
+				}
+				playerID, category := args.String("playerId"), args.String("category")
+				if playerID == "" || category == "" {
+					return nil, missingParameter("playerRank requires playerId and category arguments")
+				}
+				rank, err := h.leaderboardService.GetPlayerRank(ctx, playerID, category)
+				if err != nil {
+					return nil, serviceError(err, middleware.InternalError("Failed to get player rank"))
+				}
+				return rank, nil
+			}},
+		},
+	}
+
+	return &graphql.Schema{Query: query, Extensions: graphqlErrorExtensions}
+}
+
+// resolveGlobalLeaderboard takes the same filters as the REST leaderboard endpoint
+func (h *GraphQLHandler) resolveGlobalLeaderboard(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+	if h.leaderboardService == nil {
+		return nil, unavailable("Leaderboard service is not available")
+	}
+
+	filter := models.LeaderboardFilter{
+		Limit: args.Int("limit", 10),
+	}
+	if gameMode := args.String("gameMode"); gameMode != "" {
+		mode := models.GameMode(gameMode)
+		filter.GameMode = &mode
+	}
+	if theme := args.String("theme"); theme != "" {
+		filter.Theme = &theme
+	}
+	if timeRange := args.String("timeRange"); timeRange != "" {
+		filter.TimeRange = &timeRange
+	}
+	if timezone := args.String("timezone"); timezone != "" {
+		if _, err := models.ParseTimezone(timezone); err != nil {
+			return nil, invalidParameter("Invalid timezone").WithDetails("reason", err.Error())
+		}
+		filter.Timezone = &timezone
+	}
+	if friendsOf := args.String("friendsOf"); friendsOf != "" {
+		filter.FriendsOf = &friendsOf
+	}
+
+	leaderboard, err := h.leaderboardService.GetGlobalLeaderboard(ctx, filter)
+	if err != nil {
+		return nil, serviceError(err, middleware.InternalError("Failed to get global leaderboard"))
+	}
+	return leaderboard, nil
+}
+
+func (h *GraphQLHandler) profile(ctx context.Context, playerID string) (interface{}, error) {
+	if h.profileService == nil {
+		return nil, unavailable("Profile service is not available")
+	}
+	profile, err := h.profileService.GetProfile(ctx, playerID)
+	if err != nil {
+		return nil, serviceError(err, middleware.InternalError("Failed to get profile"))
+	}
+	return profile, nil
+}
+
+// graphqlErrorExtensions reports a failed field's error code, as REST responses do
+func graphqlErrorExtensions(err error) map[string]interface{} {
+	var appErr *middleware.AppError
+	if errors.As(err, &appErr) && appErr.Code != "" {
+		return map[string]interface{}{"code": appErr.Code}
+	}
+	return nil
+}
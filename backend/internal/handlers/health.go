@@ -1,19 +1,32 @@
 package handlers
 
 import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/services"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// DependencyChecker probes the dependencies the instance needs to serve games
+type DependencyChecker interface {
+	HealthCheck(ctx context.Context) *database.HealthReport
+}
+
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	// Simplified - no dependencies for now
+	drainService services.DrainService
+	checker      DependencyChecker
 }
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler; the instance reports not ready while it drains
+// or while a required dependency is unreachable
+func NewHealthHandler(drainService services.DrainService, checker DependencyChecker) *HealthHandler {
+	return &HealthHandler{
+		drainService: drainService,
+		checker:      checker,
+	}
 }
 
 // CheckHealth returns the overall health status
@@ -35,12 +48,34 @@ func (h *HealthHandler) CheckHealth(c *fiber.Ctx) error {
 
 // CheckReadiness returns readiness status for Kubernetes readiness probes
 func (h *HealthHandler) CheckReadiness(c *fiber.Ctx) error {
+	// Draining instances leave the load balancer's rotation before they shut down
+	if h.drainService != nil && h.drainService.Draining() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status":    "draining",
+			"timestamp": time.Now().UTC(),
+			"service":   "dumdoors-backend",
+		})
+	}
+
 	readiness := fiber.Map{
 		"status":    "ready",
 		"timestamp": time.Now().UTC(),
 		"service":   "dumdoors-backend",
 	}
+	if h.checker == nil {
+		return c.JSON(readiness)
+	}
 
+	// Slow or optional dependencies leave the instance in rotation as degraded
+	report := h.checker.HealthCheck(c.UserContext())
+	readiness["dependencies"] = report.Dependencies
+	switch report.Status {
+	case database.HealthUnhealthy:
+		readiness["status"] = "not_ready"
+		return c.Status(fiber.StatusServiceUnavailable).JSON(readiness)
+	case database.HealthDegraded:
+		readiness["status"] = "degraded"
+	}
 	return c.JSON(readiness)
 }
 
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MatchmakingHandler handles matchmaking queue requests
+type MatchmakingHandler struct {
+	matchmakingService services.MatchmakingService
+}
+
+// NewMatchmakingHandler creates a new matchmaking handler
+func NewMatchmakingHandler(matchmakingService services.MatchmakingService) *MatchmakingHandler {
+	return &MatchmakingHandler{
+		matchmakingService: matchmakingService,
+	}
+}
+
+// EnqueueRequest represents the request body for joining the matchmaking queue
+type EnqueueRequest struct {
+	PlayerID    string `json:"playerId" validate:"required"`
+	Username    string `json:"username" validate:"required"`
+	Theme       string `json:"theme,omitempty"`
+	SkillBucket int    `json:"skillBucket" validate:"required,min=1,max=3"`
+}
+
+// Enqueue puts a player in the matchmaking queue
+func (h *MatchmakingHandler) Enqueue(c *fiber.Ctx) error {
+	var req EnqueueRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	req.PlayerID = playerID
+
+	ticket, err := h.matchmakingService.Enqueue(c.UserContext(), req.PlayerID, req.Username, req.Theme, req.SkillBucket)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to join matchmaking"))
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"ticket":  ticket,
+	})
+}
+
+// Cancel removes a player from the matchmaking queue
+func (h *MatchmakingHandler) Cancel(c *fiber.Ctx) error {
+	playerID := c.Params("playerId")
+	if playerID == "" {
+		return missingParameter("Player ID must be provided in the URL path")
+	}
+
+	playerID, err := authorizePlayer(c, playerID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.matchmakingService.Cancel(c.UserContext(), playerID); err != nil {
+		return serviceError(err, middleware.InternalError("Failed to cancel matchmaking"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Left matchmaking",
+	})
+}
+
+// GetStatus reports whether a player is still waiting or has been matched into a session
+func (h *MatchmakingHandler) GetStatus(c *fiber.Ctx) error {
+	playerID := c.Params("playerId")
+	if playerID == "" {
+		return missingParameter("Player ID must be provided in the URL path")
+	}
+
+	playerID, err := authorizePlayer(c, playerID)
+	if err != nil {
+		return err
+	}
+
+	ticket, err := h.matchmakingService.GetTicket(c.UserContext(), playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get matchmaking status"))
+	}
+
+	if ticket == nil {
+		return middleware.NotFoundError("Player has no matchmaking ticket").WithCode(middleware.CodeNotInMatchmaking)
+	}
+
+	status := "waiting"
+	if ticket.Matched() {
+		status = "matched"
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"status":  status,
+		"ticket":  ticket,
+	})
+}
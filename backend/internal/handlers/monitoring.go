@@ -2,9 +2,14 @@ package handlers
 
 import (
 	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
 	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/services"
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,12 +18,14 @@ import (
 // MonitoringHandler handles monitoring and observability endpoints
 type MonitoringHandler struct {
 	metricsCollector *monitoring.MetricsCollector
+	auditService     services.AuditService
 }
 
-// NewMonitoringHandler creates a new monitoring handler
-func NewMonitoringHandler() *MonitoringHandler {
+// NewMonitoringHandler creates a new monitoring handler that records metrics resets in the audit log
+func NewMonitoringHandler(auditService services.AuditService) *MonitoringHandler {
 	return &MonitoringHandler{
 		metricsCollector: monitoring.GetGlobalMetricsCollector(),
+		auditService:     auditService,
 	}
 }
 
@@ -34,39 +41,38 @@ func (h *MonitoringHandler) GetMetrics(c *fiber.Ctx) error {
 	})
 }
 
-// GetPrometheusMetrics returns metrics in Prometheus format
+// GetPrometheusMetrics returns metrics in the Prometheus text exposition format
 func (h *MonitoringHandler) GetPrometheusMetrics(c *fiber.Ctx) error {
-	metrics := h.metricsCollector.GetMetrics()
-	
-	// Convert to Prometheus format
-	var prometheusOutput string
-	for name, metric := range metrics {
+	var out strings.Builder
+	for _, family := range h.metricsCollector.GetMetricFamilies() {
+		name := family.Name
+		
 		// Add help text
-		if metric.Help != "" {
-			prometheusOutput += "# HELP " + name + " " + metric.Help + "\n"
+		if family.Help != "" {
+			out.WriteString("# HELP " + name + " " + family.Help + "\n")
 		}
 		
 		// Add type
-		prometheusOutput += "# TYPE " + name + " " + string(metric.Type) + "\n"
+		out.WriteString("# TYPE " + name + " " + string(family.Type) + "\n")
 		
-		// Add metric value with labels
-		if len(metric.Labels) > 0 {
-			labelStr := ""
-			for k, v := range metric.Labels {
-				if labelStr != "" {
-					labelStr += ","
+		for _, metric := range family.Series {
+			if family.Type == monitoring.MetricTypeHistogram {
+				// Histograms are exported as cumulative buckets plus the sum and count of observations
+				for _, bucket := range metric.Buckets {
+					labels := monitoring.FormatLabels(metric.Labels, "le", monitoring.FormatBucketBound(bucket.UpperBound))
+					out.WriteString(name + "_bucket" + labels + " " + strconv.FormatUint(bucket.Count, 10) + "\n")
 				}
-				labelStr += k + "=\"" + v + "\""
+				out.WriteString(name + "_sum" + monitoring.FormatLabels(metric.Labels) + " " + formatFloat(metric.Sum) + "\n")
+				out.WriteString(name + "_count" + monitoring.FormatLabels(metric.Labels) + " " + strconv.FormatUint(metric.Count, 10) + "\n")
+			} else {
+				out.WriteString(name + monitoring.FormatLabels(metric.Labels) + " " + formatFloat(metric.Value) + "\n")
 			}
-			prometheusOutput += name + "{" + labelStr + "} " + formatFloat(metric.Value) + "\n"
-		} else {
-			prometheusOutput += name + " " + formatFloat(metric.Value) + "\n"
 		}
-		prometheusOutput += "\n"
+		out.WriteString("\n")
 	}
 	
 	c.Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
-	return c.SendString(prometheusOutput)
+	return c.SendString(out.String())
 }
 
 // GetSystemInfo returns system information
@@ -93,14 +99,24 @@ func (h *MonitoringHandler) GetSystemInfo(c *fiber.Ctx) error {
 			},
 		},
 		"circuit_breakers": middleware.GetAllCircuitBreakerStats(),
+		"rate_limiters":    middleware.GetAllRateLimiterStats(),
 	}
 	
 	return c.JSON(systemInfo)
 }
 
+// GetRateLimitStats returns how often each rate limiter allowed and rejected requests
+func (h *MonitoringHandler) GetRateLimitStats(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"timestamp":     time.Now().UTC(),
+		"service":       "dumdoors-backend",
+		"rate_limiters": middleware.GetAllRateLimiterStats(),
+	})
+}
+
 // GetPerformanceStats returns performance statistics
 func (h *MonitoringHandler) GetPerformanceStats(c *fiber.Ctx) error {
-	metrics := h.metricsCollector.GetMetrics()
+	metrics := h.metricsCollector
 	
 	// Calculate performance statistics
 	stats := fiber.Map{
@@ -108,22 +124,22 @@ func (h *MonitoringHandler) GetPerformanceStats(c *fiber.Ctx) error {
 		"service":   "dumdoors-backend",
 		"performance": fiber.Map{
 			"requests": fiber.Map{
-				"total":        getMetricValue(metrics, "http_requests_total"),
-				"errors":       getMetricValue(metrics, "errors_total"),
-				"avg_duration": getMetricValue(metrics, "http_request_duration_seconds"),
+				"total":        metrics.Total("http_requests_total"),
+				"errors":       metrics.Total("errors_total"),
+				"avg_duration": metrics.Mean("http_request_duration_seconds"),
 			},
 			"game": fiber.Map{
-				"active_sessions":    getMetricValue(metrics, "game_sessions_active"),
-				"active_players":     getMetricValue(metrics, "players_active"),
-				"active_connections": getMetricValue(metrics, "websocket_connections_active"),
+				"active_sessions":    metrics.Total("game_sessions_active"),
+				"active_players":     metrics.Total("players_active"),
+				"active_connections": metrics.Total("websocket_connections_active"),
 			},
 			"ai_service": fiber.Map{
-				"total_calls":    getMetricValue(metrics, "ai_service_calls_total"),
-				"avg_duration":   getMetricValue(metrics, "ai_service_call_duration_seconds"),
+				"total_calls":    metrics.Total("ai_service_calls_total"),
+				"avg_duration":   metrics.Mean("ai_service_call_duration_seconds"),
 			},
 			"database": fiber.Map{
-				"total_operations": getMetricValue(metrics, "database_operations_total"),
-				"avg_duration":     getMetricValue(metrics, "database_operation_duration_seconds"),
+				"total_operations": metrics.Total("database_operations_total"),
+				"avg_duration":     metrics.Mean("database_operation_duration_seconds"),
 			},
 		},
 	}
@@ -136,7 +152,7 @@ func (h *MonitoringHandler) GetHealthDashboard(c *fiber.Ctx) error {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 	
-	metrics := h.metricsCollector.GetMetrics()
+	metrics := h.metricsCollector
 	circuitBreakers := middleware.GetAllCircuitBreakerStats()
 	
 	// Determine overall health status
@@ -153,6 +169,19 @@ func (h *MonitoringHandler) GetHealthDashboard(c *fiber.Ctx) error {
 		}
 	}
 	
+	// Report the breaker guarding each database; a breaker not yet used counts as closed
+	databases := make(map[string]interface{})
+	for _, name := range repositories.DatabaseBreakers {
+		state := "closed"
+		if cbMap, ok := circuitBreakers[name].(map[string]interface{}); ok {
+			state = cbMap["state"].(string)
+		}
+		databases[name] = fiber.Map{
+			"circuit_breaker": state,
+			"available":       state != "open",
+		}
+	}
+	
 	// Check memory usage (warn if > 1GB)
 	if m.Alloc > 1024*1024*1024 {
 		overallHealth = "degraded"
@@ -169,8 +198,8 @@ func (h *MonitoringHandler) GetHealthDashboard(c *fiber.Ctx) error {
 	}
 	
 	// Check error rate
-	totalRequests := getMetricValue(metrics, "http_requests_total")
-	totalErrors := getMetricValue(metrics, "errors_total")
+	totalRequests := metrics.Total("http_requests_total")
+	totalErrors := metrics.Total("errors_total")
 	errorRate := float64(0)
 	if totalRequests > 0 {
 		errorRate = totalErrors / totalRequests * 100
@@ -201,13 +230,14 @@ func (h *MonitoringHandler) GetHealthDashboard(c *fiber.Ctx) error {
 			"total_requests":      totalRequests,
 			"total_errors":        totalErrors,
 			"error_rate_percent":  errorRate,
-			"active_sessions":     getMetricValue(metrics, "game_sessions_active"),
-			"active_players":      getMetricValue(metrics, "players_active"),
-			"active_connections":  getMetricValue(metrics, "websocket_connections_active"),
+			"active_sessions":     metrics.Total("game_sessions_active"),
+			"active_players":      metrics.Total("players_active"),
+			"active_connections":  metrics.Total("websocket_connections_active"),
 			"memory_alloc_mb":     float64(m.Alloc) / 1024 / 1024,
 			"goroutines":          runtime.NumGoroutine(),
 		},
 		"circuit_breakers": circuitBreakers,
+		"databases":        databases,
 	}
 	
 	// Set appropriate HTTP status based on health
@@ -220,10 +250,8 @@ func (h *MonitoringHandler) GetHealthDashboard(c *fiber.Ctx) error {
 
 // ResetMetrics resets all metrics (for testing/debugging)
 func (h *MonitoringHandler) ResetMetrics(c *fiber.Ctx) error {
-	// In a real implementation, you might want to restrict this endpoint
-	// or require authentication
-	
 	h.metricsCollector = monitoring.NewMetricsCollector()
+	recordAudit(c, h.auditService, models.AuditMetricsReset, models.AuditTarget{Type: "metrics"}, nil, nil)
 	
 	return c.JSON(fiber.Map{
 		"success":   true,
@@ -233,13 +261,6 @@ func (h *MonitoringHandler) ResetMetrics(c *fiber.Ctx) error {
 }
 
 // Helper functions
-func getMetricValue(metrics map[string]*monitoring.Metric, name string) float64 {
-	if metric, exists := metrics[name]; exists {
-		return metric.Value
-	}
-	return 0
-}
-
 func formatFloat(f float64) string {
 	return fmt.Sprintf("%.6f", f)
 }
\ No newline at end of file
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/monitoring"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestGetPrometheusMetrics_ExportsHistogramBuckets(t *testing.T) {
+	collector := monitoring.NewMetricsCollector()
+	histogram := collector.NewHistogramWithBuckets("door_latency_seconds", "Door latency", map[string]string{"route": "/next"}, []float64{1, 2.5})
+	for _, value := range []float64{0.5, 1, 2.5, 4} {
+		histogram.Observe(value)
+	}
+
+	app := fiber.New()
+	handler := &MonitoringHandler{metricsCollector: collector}
+	app.Get("/metrics", handler.GetPrometheusMetrics)
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	body := string(raw)
+
+	// Buckets are cumulative and include observations exactly on their bound
+	want := strings.Join([]string{
+		"# HELP door_latency_seconds Door latency",
+		"# TYPE door_latency_seconds histogram",
+		`door_latency_seconds_bucket{route="/next",le="1"} 2`,
+		`door_latency_seconds_bucket{route="/next",le="2.5"} 3`,
+		`door_latency_seconds_bucket{route="/next",le="+Inf"} 4`,
+		`door_latency_seconds_sum{route="/next"} 8.000000`,
+		`door_latency_seconds_count{route="/next"} 4`,
+	}, "\n") + "\n"
+	if !strings.Contains(body, want) {
+		t.Errorf("Expected the histogram exported as\n%s\ngot\n%s", want, body)
+	}
+}
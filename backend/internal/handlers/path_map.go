@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PathMapHandler serves the map of each player's journey through the door graph
+type PathMapHandler struct {
+	pathMapService services.PathMapService
+}
+
+// NewPathMapHandler creates a new path map handler
+func NewPathMapHandler(pathMapService services.PathMapService) *PathMapHandler {
+	return &PathMapHandler{
+		pathMapService: pathMapService,
+	}
+}
+
+// GetPlayerGraph returns the doors of the session's graph marked with the ones the player has
+// visited and the branches ahead of them, and the edges between doors with the score each needs
+func (h *PathMapHandler) GetPlayerGraph(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" || c.Params("playerId") == "" {
+		return missingParameter("Session ID and player ID must be provided in the URL path")
+	}
+
+	playerID, err := authorizePlayer(c, c.Params("playerId"))
+	if err != nil {
+		return err
+	}
+
+	pathMap, err := h.pathMapService.GetPlayerMap(c.UserContext(), sessionID, playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get the player's path"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"path":    pathMap,
+	})
+}
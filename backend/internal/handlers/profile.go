@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProfileHandler serves players' career statistics
+type ProfileHandler struct {
+	profileService services.ProfileService
+}
+
+// NewProfileHandler creates a new profile handler
+func NewProfileHandler(profileService services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+	}
+}
+
+// GetProfile returns a player's profile
+func (h *ProfileHandler) GetProfile(c *fiber.Ctx) error {
+	playerID := c.Params("playerId")
+	if playerID == "" {
+		return missingParameter("Player ID must be provided in the URL path")
+	}
+
+	profile, err := h.profileService.GetProfile(c.UserContext(), playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get profile"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"profile": profile,
+		"winRate": profile.WinRate(),
+	})
+}
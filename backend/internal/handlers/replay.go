@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReplayHandler serves the recorded event log of completed games
+type ReplayHandler struct {
+	replayService services.ReplayService
+}
+
+// NewReplayHandler creates a new replay handler
+func NewReplayHandler(replayService services.ReplayService) *ReplayHandler {
+	return &ReplayHandler{
+		replayService: replayService,
+	}
+}
+
+// GetReplay returns a page of a completed session's events, oldest first
+func (h *ReplayHandler) GetReplay(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	page, err := h.replayService.GetReplay(c.UserContext(), sessionID, c.QueryInt("page", 1), c.QueryInt("pageSize", services.DefaultReplayPageSize))
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get replay"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"replay":  page,
+	})
+}
+
+// StreamReplay upgrades to a WebSocket that plays a completed session back in real time,
+// sped up by the optional speed query parameter
+func (h *ReplayHandler) StreamReplay(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return upgradeRequired()
+	}
+
+	return websocket.New(h.handleReplayStream)(c)
+}
+
+// handleReplayStream sends each replay event over the socket, then a completion or error event
+func (h *ReplayHandler) handleReplayStream(c *websocket.Conn) {
+	defer c.Close()
+
+	sessionID := c.Params("sessionId")
+	speed, _ := strconv.ParseFloat(c.Query("speed"), 64)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Stop playback as soon as the viewer disconnects
+	go func() {
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	err := h.replayService.StreamReplay(ctx, sessionID, speed, func(event *models.ReplayEvent) error {
+		return c.WriteJSON(services.WebSocketEvent{
+			Type:      "replay-event",
+			SessionID: sessionID,
+			Data:      event,
+			Timestamp: time.Now(),
+		})
+	})
+
+	final := services.WebSocketEvent{
+		Type:      "replay-completed",
+		SessionID: sessionID,
+		Data:      map[string]interface{}{"message": "Replay finished"},
+		Timestamp: time.Now(),
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return // Viewer left
+		}
+		log.Printf("Replay stream for session %s failed: %v", sessionID, err)
+		final.Type = "replay-error"
+		final.Data = map[string]interface{}{"message": err.Error()}
+	}
+
+	if err := c.WriteJSON(final); err != nil {
+		log.Printf("Failed to finish replay stream for session %s: %v", sessionID, err)
+	}
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResultCardHandler serves the shareable summaries of completed games
+type ResultCardHandler struct {
+	resultCardService services.ResultCardService
+}
+
+// NewResultCardHandler creates a new result card handler
+func NewResultCardHandler(resultCardService services.ResultCardService) *ResultCardHandler {
+	return &ResultCardHandler{
+		resultCardService: resultCardService,
+	}
+}
+
+// GetSummary returns a completed game's result card: the winner, the best answer to each door,
+// the funniest answer and the hardest door, ready for the Devvit client to post
+func (h *ResultCardHandler) GetSummary(c *fiber.Ctx) error {
+	card, err := h.resultCardService.GetResultCard(c.UserContext(), c.Params("sessionId"))
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get game summary"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"summary": card,
+	})
+}
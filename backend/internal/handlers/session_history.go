@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SessionHistoryHandler serves each session's event log to the players in it
+type SessionHistoryHandler struct {
+	historyService services.SessionHistoryService
+}
+
+// NewSessionHistoryHandler creates a new session history handler
+func NewSessionHistoryHandler(historyService services.SessionHistoryService) *SessionHistoryHandler {
+	return &SessionHistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// GetHistory returns when each door was presented, when each response reached the server and
+// how it was scored, so hosts can settle disputes over the timer or a score
+func (h *SessionHistoryHandler) GetHistory(c *fiber.Ctx) error {
+	playerID, err := authorizePlayer(c, c.Query("playerId"))
+	if err != nil {
+		return err
+	}
+
+	history, err := h.historyService.GetHistory(c.UserContext(), c.Params("sessionId"), playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get session history"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"history": history,
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sessionStatusStub serves a fixed session to GetSessionStatus
+type sessionStatusStub struct {
+	services.GameService
+	session *models.GameSession
+}
+
+func (s *sessionStatusStub) GetSessionStatus(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	return s.session, nil
+}
+
+func newAnonymousSession() *models.GameSession {
+	return &models.GameSession{
+		SessionID: "s1",
+		Status:    models.GameStatusActive,
+		Settings:  models.SessionSettings{RevealMode: models.RevealAnonymous},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "alice", Responses: []models.PlayerResponse{{ResponseID: "r1", PlayerID: "p1", Content: "climb out the window", Feedback: "bold but risky"}}},
+			{PlayerID: "p2", Username: "bob", Responses: []models.PlayerResponse{{ResponseID: "r2", PlayerID: "p2", Content: "bribe the guard", Feedback: "clever use of the guard"}}},
+		},
+	}
+}
+
+// responsesByPlayer returns the responses each player's entry carries in a session payload
+func responsesByPlayer(t *testing.T, session *models.GameSession) map[string]int {
+	t.Helper()
+	responses := make(map[string]int)
+	for _, player := range session.Players {
+		responses[player.PlayerID] = len(player.Responses)
+	}
+	return responses
+}
+
+func TestSessionPayloads_HideOtherPlayersResponses(t *testing.T) {
+	session := newAnonymousSession()
+	authService := services.NewAuthService([]byte("token secret"), time.Minute)
+	token, err := authService.IssueToken(&models.RedditUser{ID: "p1", Username: "alice"})
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler()})
+	handler := NewGameHandler(&sessionStatusStub{session: session}, nil, nil, nil, nil, nil)
+	app.Get("/status/:sessionId", middleware.Authenticate(authService), handler.GetSessionStatus)
+
+	req := httptest.NewRequest("GET", "/status/s1", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token.Token)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	var body struct {
+		Session *models.GameSession `json:"session"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !strings.Contains(string(raw), "bold but risky") || strings.Contains(string(raw), "clever use of the guard") {
+		t.Errorf("Expected the caller to get their own feedback and nobody else's, got %s", raw)
+	}
+	if got := responsesByPlayer(t, body.Session); got["p1"] != 1 || got["p2"] != 0 {
+		t.Errorf("Expected the status to show only the caller's response, got %v", got)
+	}
+
+	welcome := (&WebSocketHandler{}).welcomeEvent(context.Background(), session, "p2", "hello")
+	welcomed := welcome.Data.(map[string]interface{})["session"].(*models.GameSession)
+	if got := responsesByPlayer(t, welcomed); got["p1"] != 0 || got["p2"] != 1 {
+		t.Errorf("Expected the welcome to show only the player's own response, got %v", got)
+	}
+
+	if got := responsesByPlayer(t, session); got["p1"] != 1 || got["p2"] != 1 {
+		t.Errorf("Expected the stored session to keep every response, got %v", got)
+	}
+}
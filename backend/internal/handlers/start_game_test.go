@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// startGameStub records which sessions were started
+type startGameStub struct {
+	sessionStatusStub
+	started []string
+}
+
+func (s *startGameStub) StartGame(ctx context.Context, sessionID string) error {
+	s.started = append(s.started, sessionID)
+	return nil
+}
+
+func (s *startGameStub) StartGameWithFirstDoor(ctx context.Context, sessionID string) error {
+	s.started = append(s.started, sessionID)
+	return nil
+}
+
+func TestStartGame_OnlyHostCanStart(t *testing.T) {
+	session := newAnonymousSession()
+	session.Status = models.GameStatusWaiting
+	session.HostID = "p1"
+	gameService := &startGameStub{sessionStatusStub: sessionStatusStub{session: session}}
+	authService := services.NewAuthService([]byte("token secret"), time.Minute)
+
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler()})
+	handler := NewGameHandler(gameService, nil, nil, nil, nil, nil)
+	app.Post("/start/:sessionId", middleware.Authenticate(authService), handler.StartGame)
+	app.Post("/start-with-door/:sessionId", middleware.Authenticate(authService), handler.StartGameWithDoor)
+
+	start := func(path string, user *models.RedditUser) int {
+		token, err := authService.IssueToken(user)
+		if err != nil {
+			t.Fatalf("IssueToken failed: %v", err)
+		}
+		req := httptest.NewRequest("POST", path, nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token.Token)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	bob := &models.RedditUser{ID: "p2", Username: "bob"}
+	for _, path := range []string{"/start/s1", "/start-with-door/s1"} {
+		if status := start(path, bob); status != fiber.StatusForbidden {
+			t.Errorf("Expected 403 for a non-host on %s, got %d", path, status)
+		}
+	}
+	if len(gameService.started) != 0 {
+		t.Fatalf("Expected no game to start, got %v", gameService.started)
+	}
+
+	if status := start("/start/s1", &models.RedditUser{ID: "p1", Username: "alice"}); status != fiber.StatusOK {
+		t.Errorf("Expected the host to start the game, got %d", status)
+	}
+	if len(gameService.started) != 1 {
+		t.Errorf("Expected the game to start once, got %v", gameService.started)
+	}
+}
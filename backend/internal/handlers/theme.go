@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ThemeHandler serves the theme catalog to players and lets admins manage it
+type ThemeHandler struct {
+	themeService services.ThemeService
+	auditService services.AuditService
+}
+
+// NewThemeHandler creates a new theme handler that records catalog changes in the audit log
+func NewThemeHandler(themeService services.ThemeService, auditService services.AuditService) *ThemeHandler {
+	return &ThemeHandler{
+		themeService: themeService,
+		auditService: auditService,
+	}
+}
+
+// CreateThemeRequest represents the request body for adding a theme
+type CreateThemeRequest struct {
+	ThemeID        string                `json:"themeId" validate:"required"`
+	Name           string                `json:"name" validate:"required"`
+	Description    string                `json:"description,omitempty"`
+	Icon           string                `json:"icon,omitempty"`
+	MaturityRating models.MaturityRating `json:"maturityRating,omitempty" validate:"omitempty,oneof=everyone teen mature"`
+	Enabled        bool                  `json:"enabled"`
+}
+
+// ListThemes returns the enabled themes players can choose from
+func (h *ThemeHandler) ListThemes(c *fiber.Ctx) error {
+	return h.listThemes(c, false)
+}
+
+// ListAllThemes returns the whole catalog, including disabled themes
+func (h *ThemeHandler) ListAllThemes(c *fiber.Ctx) error {
+	return h.listThemes(c, true)
+}
+
+// GetTheme returns a single theme in any state
+func (h *ThemeHandler) GetTheme(c *fiber.Ctx) error {
+	theme, err := h.themeService.GetTheme(c.UserContext(), c.Params("themeId"))
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get theme"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"theme":   theme,
+	})
+}
+
+// CreateTheme adds a theme to the catalog
+func (h *ThemeHandler) CreateTheme(c *fiber.Ctx) error {
+	var req CreateThemeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	theme, err := h.themeService.CreateTheme(c.UserContext(), &models.Theme{
+		ThemeID:        req.ThemeID,
+		Name:           req.Name,
+		Description:    req.Description,
+		Icon:           req.Icon,
+		MaturityRating: req.MaturityRating,
+		Enabled:        req.Enabled,
+	})
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to create theme"))
+	}
+	recordAudit(c, h.auditService, models.AuditThemeCreated, themeTarget(theme.ThemeID), nil, theme)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"theme":   theme,
+	})
+}
+
+// UpdateTheme edits a theme's metadata or enables and disables it
+func (h *ThemeHandler) UpdateTheme(c *fiber.Ctx) error {
+	var req services.ThemeUpdate
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	before, err := h.currentTheme(c)
+	if err != nil {
+		return err
+	}
+	theme, err := h.themeService.UpdateTheme(c.UserContext(), c.Params("themeId"), req)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to update theme"))
+	}
+	recordAudit(c, h.auditService, models.AuditThemeUpdated, themeTarget(theme.ThemeID), before, theme)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"theme":   theme,
+	})
+}
+
+// DeleteTheme removes a theme from the catalog
+func (h *ThemeHandler) DeleteTheme(c *fiber.Ctx) error {
+	before, err := h.currentTheme(c)
+	if err != nil {
+		return err
+	}
+	if err := h.themeService.DeleteTheme(c.UserContext(), c.Params("themeId")); err != nil {
+		return serviceError(err, middleware.InternalError("Failed to delete theme"))
+	}
+	recordAudit(c, h.auditService, models.AuditThemeDeleted, themeTarget(before.ThemeID), before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Theme deleted",
+	})
+}
+
+// listThemes returns the catalog, optionally with disabled themes
+func (h *ThemeHandler) listThemes(c *fiber.Ctx, includeDisabled bool) error {
+	themes, err := h.themeService.ListThemes(c.UserContext(), includeDisabled)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to list themes"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"themes":  themes,
+	})
+}
+
+// currentTheme returns a copy of the theme named in the path, as it was before the request changed it
+func (h *ThemeHandler) currentTheme(c *fiber.Ctx) (*models.Theme, error) {
+	theme, err := h.themeService.GetTheme(c.UserContext(), c.Params("themeId"))
+	if err != nil {
+		return nil, serviceError(err, middleware.InternalError("Failed to get theme"))
+	}
+	before := *theme
+	return &before, nil
+}
+
+// themeTarget names a theme as the target of an audited action
+func themeTarget(themeID string) models.AuditTarget {
+	return models.AuditTarget{Type: "theme", ID: themeID}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TournamentHandler handles tournament requests
+type TournamentHandler struct {
+	tournamentService services.TournamentService
+}
+
+// NewTournamentHandler creates a new tournament handler
+func NewTournamentHandler(tournamentService services.TournamentService) *TournamentHandler {
+	return &TournamentHandler{
+		tournamentService: tournamentService,
+	}
+}
+
+// CreateTournamentRequest represents the request body for creating a tournament
+type CreateTournamentRequest struct {
+	Name     string  `json:"name,omitempty"`
+	Size     int     `json:"size" validate:"required,oneof=8 16"`
+	Theme    *string `json:"theme,omitempty"`
+	PlayerID string  `json:"playerId" validate:"required"`
+	Username string  `json:"username" validate:"required"`
+}
+
+// JoinTournamentRequest represents the request body for joining a tournament
+type JoinTournamentRequest struct {
+	TournamentID string `json:"tournamentId" validate:"required"`
+	PlayerID     string `json:"playerId" validate:"required"`
+	Username     string `json:"username" validate:"required"`
+}
+
+// CreateTournament opens a new tournament bracket
+func (h *TournamentHandler) CreateTournament(c *fiber.Ctx) error {
+	var req CreateTournamentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	tournament, err := h.tournamentService.CreateTournament(c.UserContext(), playerID, req.Username, req.Name, req.Size, req.Theme)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to create tournament"))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success":    true,
+		"tournament": tournament,
+	})
+}
+
+// JoinTournament registers a player for a tournament
+func (h *TournamentHandler) JoinTournament(c *fiber.Ctx) error {
+	var req JoinTournamentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(err)
+	}
+
+	if req.TournamentID == "" {
+		return missingParameter("tournamentId must be provided in the request body")
+	}
+
+	playerID, err := authorizePlayer(c, req.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	tournament, err := h.tournamentService.JoinTournament(c.UserContext(), req.TournamentID, playerID, req.Username)
+	if err != nil {
+		return serviceError(err, middleware.ValidationError("Failed to join tournament"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"tournament": tournament,
+	})
+}
+
+// GetTournamentStatus returns a tournament's bracket and progress
+func (h *TournamentHandler) GetTournamentStatus(c *fiber.Ctx) error {
+	tournamentID := c.Params("id")
+	if tournamentID == "" {
+		return missingParameter("Tournament ID must be provided in the URL path")
+	}
+
+	tournament, err := h.tournamentService.GetTournament(c.UserContext(), tournamentID)
+	if err != nil {
+		return serviceError(err, middleware.NotFoundError("Tournament not found").WithCode(middleware.CodeTournamentNotFound))
+	}
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"tournament": tournament,
+	})
+}
@@ -1,7 +1,13 @@
 package handlers
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
 	"dumdoors-backend/internal/services"
 	"log"
 
@@ -11,15 +17,25 @@ import (
 
 // WebSocketHandler handles WebSocket connections
 type WebSocketHandler struct {
-	wsManager   services.WebSocketManager
-	gameService services.GameService
+	wsManager    services.WebSocketManager
+	gameService  services.GameService
+	draftService services.DraftService
+	auditService services.AuditService
+	timerSync    services.TimerSyncService
+	routing      services.RoutingService
+	compression  bool // offer permessage-deflate when upgrading
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
-func NewWebSocketHandler(wsManager services.WebSocketManager, gameService services.GameService) *WebSocketHandler {
+func NewWebSocketHandler(wsManager services.WebSocketManager, gameService services.GameService, draftService services.DraftService, auditService services.AuditService, timerSync services.TimerSyncService, routing services.RoutingService, compression bool) *WebSocketHandler {
 	return &WebSocketHandler{
-		wsManager:   wsManager,
-		gameService: gameService,
+		wsManager:    wsManager,
+		gameService:  gameService,
+		draftService: draftService,
+		auditService: auditService,
+		timerSync:    timerSync,
+		routing:      routing,
+		compression:  compression,
 	}
 }
 
@@ -27,13 +43,21 @@ func NewWebSocketHandler(wsManager services.WebSocketManager, gameService servic
 func (h *WebSocketHandler) UpgradeConnection(c *fiber.Ctx) error {
 	// Check if the request is a WebSocket upgrade
 	if websocket.IsWebSocketUpgrade(c) {
-		return websocket.New(h.handleWebSocketConnection)(c)
+		// Connection tokens are checked before upgrading, so load balancers and clients see
+		// where a misdirected connection belongs
+		if token := c.Query("connectionToken"); token != "" && h.routing != nil {
+			route, err := h.routing.CheckToken(token, c.Query("sessionId"))
+			if errors.Is(err, services.ErrWrongInstance) {
+				return serviceError(err, middleware.UnauthorizedError("Invalid connection token")).WithDetails("instanceId", route.InstanceID).WithDetails("connectionToken", route.ConnectionToken)
+			}
+			if err != nil {
+				return serviceError(err, middleware.UnauthorizedError("Invalid connection token"))
+			}
+		}
+		return websocket.New(h.handleWebSocketConnection, websocket.Config{EnableCompression: h.compression})(c)
 	}
 	
-	return c.Status(fiber.StatusUpgradeRequired).JSON(fiber.Map{
-		"error":   "WebSocket upgrade required",
-		"message": "This endpoint requires a WebSocket connection",
-	})
+	return upgradeRequired()
 }
 
 // handleWebSocketConnection handles individual WebSocket connections
@@ -42,6 +66,22 @@ func (h *WebSocketHandler) handleWebSocketConnection(c *websocket.Conn) {
 	sessionID := c.Query("sessionId")
 	playerID := c.Query("playerId")
 	
+	// Sockets may only connect as the authenticated player
+	claimed := playerID
+	if claimed == "" {
+		claimed = c.Query("spectatorId")
+	}
+	if !authorizedConnection(c, claimed) {
+		rejectUnauthorized(c, claimed)
+		return
+	}
+	
+	// Spectators connect with a spectatorId instead of a playerId
+	if spectatorID := c.Query("spectatorId"); sessionID != "" && playerID == "" && spectatorID != "" {
+		h.handleSpectatorConnection(c, sessionID, spectatorID)
+		return
+	}
+	
 	if sessionID == "" || playerID == "" {
 		log.Printf("WebSocket connection rejected: missing sessionId or playerId")
 		c.WriteMessage(websocket.TextMessage, []byte(`{"error": "sessionId and playerId are required"}`))
@@ -77,14 +117,181 @@ func (h *WebSocketHandler) handleWebSocketConnection(c *websocket.Conn) {
 	
 	log.Printf("WebSocket connection established for player %s in session %s", playerID, sessionID)
 	
-	// Send welcome message
+	// Send welcome message, restoring any draft the player was typing before the connection dropped
+	welcomeEvent := h.welcomeEvent(ctx, session, playerID, "WebSocket connection established")
+	
+	if err := c.WriteJSON(welcomeEvent); err != nil {
+		log.Printf("Failed to send welcome message: %v", err)
+		c.Close()
+		return
+	}
+	
+	// Reconnecting players pick the countdown up from the server's deadline
+	if syncEvent := h.timerSyncEvent(ctx, session, playerID); syncEvent != nil {
+		if err := c.WriteJSON(syncEvent); err != nil {
+			log.Printf("Failed to send timer sync: %v", err)
+			c.Close()
+			return
+		}
+	}
+	
+	// Handle the connection using the WebSocket manager
+	h.wsManager.HandleWebSocketConnection(c, sessionID, playerID)
+}
+
+// welcomeEvent greets a newly connected player with the session and any draft they were typing
+func (h *WebSocketHandler) welcomeEvent(ctx context.Context, session *models.GameSession, playerID, message string) services.WebSocketEvent {
+	welcomeData := map[string]interface{}{
+		"message": message,
+		"session": session.ForPlayer(playerID),
+	}
+	if h.draftService != nil {
+		if draft, err := h.draftService.GetDraft(ctx, session.SessionID, playerID); err != nil {
+			log.Printf("Failed to load draft for player %s: %v", playerID, err)
+		} else if draft != nil {
+			welcomeData["draft"] = draft
+		}
+	}
+	
+	return services.WebSocketEvent{
+		Type:      "connection-established",
+		SessionID: session.SessionID,
+		PlayerID:  playerID,
+		Data:      welcomeData,
+	}
+}
+
+// timerSyncEvent returns the round deadline to send a newly connected player, if a round is
+// counting down
+func (h *WebSocketHandler) timerSyncEvent(ctx context.Context, session *models.GameSession, playerID string) *services.WebSocketEvent {
+	if h.timerSync == nil {
+		return nil
+	}
+	return h.timerSync.SyncEvent(ctx, session, playerID)
+}
+
+// StreamEvents streams a player's session events as Server-Sent Events, for clients such as
+// Devvit webviews that can't hold a WebSocket open. Each message carries the same JSON event a
+// socket would receive, since the stream joins the session through the WebSocket manager.
+func (h *WebSocketHandler) StreamEvents(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	playerID, err := authorizePlayer(c, c.Params("playerId"))
+	if err != nil {
+		return err
+	}
+	
+	// Validate that the session exists and player is part of it
+	ctx := c.UserContext()
+	session, err := h.gameService.GetSessionStatus(ctx, sessionID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get session"))
+	}
+	
+	playerFound := false
+	for _, player := range session.Players {
+		if player.PlayerID == playerID {
+			playerFound = true
+			break
+		}
+	}
+	if !playerFound {
+		return middleware.ForbiddenError(fmt.Sprintf("Player %s is not part of session %s", playerID, sessionID)).WithCode(middleware.CodePlayerNotInSession)
+	}
+	
+	stream, err := h.wsManager.RegisterStream(sessionID, playerID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to open event stream"))
+	}
+	welcomeEvent := h.welcomeEvent(ctx, session, playerID, "Event stream established")
+	syncEvent := h.timerSyncEvent(ctx, session, playerID)
+	
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // stop proxies buffering the stream
+	
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer stream.Close()
+		
+		if err := writeStreamEvent(w, welcomeEvent); err != nil {
+			log.Printf("Failed to send welcome message: %v", err)
+			return
+		}
+		if syncEvent != nil {
+			if err := writeStreamEvent(w, *syncEvent); err != nil {
+				log.Printf("Failed to send timer sync: %v", err)
+				return
+			}
+		}
+		
+		for {
+			event, err := stream.Next(services.DefaultStreamKeepAlive)
+			if err != nil {
+				return // Closed by the session ending or another connection
+			}
+			
+			if event == nil {
+				// Comments keep idle streams open, and fail to flush once the client has gone
+				fmt.Fprint(w, ": keep-alive\n\n")
+				err = w.Flush()
+			} else {
+				err = writeStreamEvent(w, *event)
+			}
+			if err != nil {
+				log.Printf("Event stream closed for player %s in session %s: %v", playerID, sessionID, err)
+				return
+			}
+		}
+	})
+	
+	return nil
+}
+
+// writeStreamEvent writes an event as a Server-Sent Events message and flushes it to the client
+func writeStreamEvent(w *bufio.Writer, event services.WebSocketEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	return w.Flush()
+}
+
+// handleSpectatorConnection serves a read-only connection for a spectator who joined the session
+func (h *WebSocketHandler) handleSpectatorConnection(c *websocket.Conn, sessionID, spectatorID string) {
+	session, err := h.gameService.GetSessionStatus(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("WebSocket connection rejected: invalid session %s", sessionID)
+		c.WriteMessage(websocket.TextMessage, []byte(`{"error": "Invalid session"}`))
+		c.Close()
+		return
+	}
+	
+	spectating := false
+	for _, spectator := range session.Spectators {
+		if spectator.SpectatorID == spectatorID {
+			spectating = true
+			break
+		}
+	}
+	
+	if !spectating {
+		log.Printf("WebSocket connection rejected: spectator %s not watching session %s", spectatorID, sessionID)
+		c.WriteMessage(websocket.TextMessage, []byte(`{"error": "Spectator not in session"}`))
+		c.Close()
+		return
+	}
+	
+	log.Printf("WebSocket spectator connection established for %s in session %s", spectatorID, sessionID)
+	
 	welcomeEvent := services.WebSocketEvent{
 		Type:      "connection-established",
 		SessionID: sessionID,
-		PlayerID:  playerID,
 		Data: map[string]interface{}{
-			"message": "WebSocket connection established",
-			"session": session,
+			"message":   "WebSocket connection established",
+			"session":   session.ForPlayer(""),
+			"spectator": true,
 		},
 	}
 	
@@ -94,18 +301,49 @@ func (h *WebSocketHandler) handleWebSocketConnection(c *websocket.Conn) {
 		return
 	}
 	
-	// Handle the connection using the WebSocket manager
-	h.wsManager.HandleWebSocketConnection(c, sessionID, playerID)
+	h.wsManager.HandleSpectatorConnection(c, sessionID, spectatorID)
+}
+
+// authorizedConnection reports whether the socket authenticated as the given player.
+// Connections on routes without authentication are not checked.
+func authorizedConnection(c *websocket.Conn, playerID string) bool {
+	claims, ok := c.Locals(middleware.PlayerLocalsKey).(*models.PlayerClaims)
+	return !ok || claims == nil || claims.PlayerID == playerID
+}
+
+// rejectUnauthorized closes a socket that tried to connect as another player
+func rejectUnauthorized(c *websocket.Conn, playerID string) {
+	log.Printf("WebSocket connection rejected: token does not belong to player %s", playerID)
+	c.WriteMessage(websocket.TextMessage, []byte(`{"error": "Player ID does not match the authenticated player"}`))
+	c.Close()
+}
+
+// GetSessionRoute returns the instance holding a session and a connection token routing to it
+func (h *WebSocketHandler) GetSessionRoute(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return missingParameter("Session ID must be provided in the URL path")
+	}
+	if h.routing == nil {
+		return unavailable("Session routing is not enabled")
+	}
+	
+	route, err := h.routing.Route(c.UserContext(), sessionID)
+	if err != nil {
+		return serviceError(err, middleware.InternalError("Failed to get session route"))
+	}
+	
+	return c.JSON(fiber.Map{
+		"success": true,
+		"route":   route,
+	})
 }
 
 // GetConnectionStatus returns the status of WebSocket connections for a session
 func (h *WebSocketHandler) GetConnectionStatus(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	// Get active connections
@@ -113,8 +351,17 @@ func (h *WebSocketHandler) GetConnectionStatus(c *fiber.Ctx) error {
 	
 	// Build response
 	var activePlayerIDs []string
+	sendQueues := make(map[string]fiber.Map, len(connections))
+	latency := make(map[string]services.HeartbeatStats, len(connections))
 	for _, conn := range connections {
 		activePlayerIDs = append(activePlayerIDs, conn.PlayerID)
+		
+		depth, dropped := conn.SendQueueStats()
+		sendQueues[conn.PlayerID] = fiber.Map{
+			"depth":   depth,
+			"dropped": dropped,
+		}
+		latency[conn.PlayerID] = conn.HeartbeatStats()
 	}
 	
 	return c.JSON(fiber.Map{
@@ -122,17 +369,17 @@ func (h *WebSocketHandler) GetConnectionStatus(c *fiber.Ctx) error {
 		"sessionId":         sessionID,
 		"activeConnections": len(connections),
 		"activePlayers":     activePlayerIDs,
+		"spectators":        h.wsManager.GetSpectatorCount(sessionID),
+		"sendQueues":        sendQueues,
+		"latency":           latency,
 	})
 }
 
-// BroadcastMessage broadcasts a message to all players in a session (for testing/admin purposes)
+// BroadcastMessage broadcasts a staff or service message to all players in a session
 func (h *WebSocketHandler) BroadcastMessage(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	if sessionID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Session ID is required",
-			"message": "Session ID must be provided in the URL path",
-		})
+		return missingParameter("Session ID must be provided in the URL path")
 	}
 	
 	var req struct {
@@ -141,10 +388,7 @@ func (h *WebSocketHandler) BroadcastMessage(c *fiber.Ctx) error {
 	}
 	
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
-			"message": err.Error(),
-		})
+		return invalidBody(err)
 	}
 	
 	// Create event
@@ -156,11 +400,9 @@ func (h *WebSocketHandler) BroadcastMessage(c *fiber.Ctx) error {
 	
 	// Broadcast to session
 	if err := h.wsManager.BroadcastToSession(sessionID, event); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to broadcast message",
-			"message": err.Error(),
-		})
+		return serviceError(err, middleware.InternalError("Failed to broadcast message"))
 	}
+	recordAudit(c, h.auditService, models.AuditSessionBroadcast, models.AuditTarget{Type: "session", ID: sessionID}, nil, event)
 	
 	return c.JSON(fiber.Map{
 		"success": true,
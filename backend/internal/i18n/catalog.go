@@ -0,0 +1,245 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when a session has no locale or asks for one without a catalog
+const DefaultLocale = "en"
+
+// MessageKey identifies a player-facing system message
+type MessageKey string
+
+// System message keys
+const (
+	MsgGameStarted     MessageKey = "game.started"
+	MsgDoorPresented   MessageKey = "door.presented"
+	MsgScoresUpdated   MessageKey = "scores.updated"
+	MsgResponseTimeout MessageKey = "response.timeout"
+	MsgVotingStarted   MessageKey = "voting.started"
+	MsgPlayerJoined    MessageKey = "player.joined"
+	MsgPlayerConnected MessageKey = "player.connected"
+	MsgPlayerLeft      MessageKey = "player.disconnected"
+	MsgPlayerBack      MessageKey = "player.reconnected"
+	MsgPlayerKicked    MessageKey = "player.kicked"
+	MsgPlayerQuit      MessageKey = "player.left"
+	MsgResponseIn      MessageKey = "response.submitted"
+	MsgGameWon         MessageKey = "game.won"
+	MsgServerDraining  MessageKey = "server.draining"
+	MsgGamePaused      MessageKey = "game.paused"
+	MsgGameResumed     MessageKey = "game.resumed"
+	MsgAnswerProgress  MessageKey = "answers.progress"
+	MsgRevealed        MessageKey = "responses.revealed"
+	MsgRoundResults    MessageKey = "round.results"
+	MsgPlayerTimedOut  MessageKey = "player.timed_out"
+)
+
+// catalogs holds the system messages for every supported locale. Each catalog must
+// define every key; messages may take fmt arguments.
+var catalogs = map[string]map[MessageKey]string{
+	"en": {
+		MsgGameStarted:     "Game has started!",
+		MsgDoorPresented:   "New door presented! You have %d seconds to respond.",
+		MsgScoresUpdated:   "All players have responded! Scores updated.",
+		MsgResponseTimeout: "Time's up! Processing responses from players who submitted.",
+		MsgVotingStarted:   "All responses are in! Rate the other answers from 1 to 5 stars within %d seconds.",
+		MsgPlayerJoined:    "%s joined the game",
+		MsgPlayerConnected: "Player connected",
+		MsgPlayerLeft:      "Player disconnected",
+		MsgPlayerBack:      "Player reconnected",
+		MsgPlayerKicked:    "%s was removed from the game by the host",
+		MsgPlayerQuit:      "%s left the game",
+		MsgResponseIn:      "%s submitted their response",
+		MsgGameWon:         "%s has won the game!",
+		MsgServerDraining:  "The server is restarting. You'll be reconnected in a moment.",
+		MsgGamePaused:      "The host paused the game.",
+		MsgGameResumed:     "The game is back on! You have %d seconds left to respond.",
+		MsgAnswerProgress:  "%d of %d players have answered.",
+		MsgRevealed:        "Here's what everyone answered.",
+		MsgRoundResults:    "Round %d of %d is over. %s leads with %d points.",
+		MsgPlayerTimedOut:  "%s didn't reconnect in time and forfeits this door",
+	},
+	"es": {
+		MsgGameStarted:     "¡La partida ha comenzado!",
+		MsgDoorPresented:   "¡Nueva puerta! Tienes %d segundos para responder.",
+		MsgScoresUpdated:   "¡Todos los jugadores han respondido! Puntuaciones actualizadas.",
+		MsgResponseTimeout: "¡Se acabó el tiempo! Procesando las respuestas enviadas.",
+		MsgVotingStarted:   "¡Ya están todas las respuestas! Puntúa las demás de 1 a 5 estrellas en %d segundos.",
+		MsgPlayerJoined:    "%s se ha unido a la partida",
+		MsgPlayerConnected: "Jugador conectado",
+		MsgPlayerLeft:      "Jugador desconectado",
+		MsgPlayerBack:      "Jugador reconectado",
+		MsgPlayerKicked:    "El anfitrión ha expulsado a %s de la partida",
+		MsgPlayerQuit:      "%s ha abandonado la partida",
+		MsgResponseIn:      "%s ha enviado su respuesta",
+		MsgGameWon:         "¡%s ha ganado la partida!",
+		MsgServerDraining:  "El servidor se está reiniciando. Te volverás a conectar en un momento.",
+		MsgGamePaused:      "El anfitrión ha pausado la partida.",
+		MsgGameResumed:     "¡La partida continúa! Te quedan %d segundos para responder.",
+		MsgAnswerProgress:  "%d de %d jugadores han respondido.",
+		MsgRevealed:        "Esto es lo que respondió cada uno.",
+		MsgRoundResults:    "Ronda %d de %d terminada. %s va en cabeza con %d puntos.",
+		MsgPlayerTimedOut:  "%s no se reconectó a tiempo y pierde esta puerta",
+	},
+	"fr": {
+		MsgGameStarted:     "La partie a commencé !",
+		MsgDoorPresented:   "Nouvelle porte ! Vous avez %d secondes pour répondre.",
+		MsgScoresUpdated:   "Tous les joueurs ont répondu ! Scores mis à jour.",
+		MsgResponseTimeout: "Temps écoulé ! Traitement des réponses envoyées.",
+		MsgVotingStarted:   "Toutes les réponses sont là ! Notez les autres de 1 à 5 étoiles en %d secondes.",
+		MsgPlayerJoined:    "%s a rejoint la partie",
+		MsgPlayerConnected: "Joueur connecté",
+		MsgPlayerLeft:      "Joueur déconnecté",
+		MsgPlayerBack:      "Joueur reconnecté",
+		MsgPlayerKicked:    "%s a été retiré de la partie par l'hôte",
+		MsgPlayerQuit:      "%s a quitté la partie",
+		MsgResponseIn:      "%s a envoyé sa réponse",
+		MsgGameWon:         "%s a gagné la partie !",
+		MsgServerDraining:  "Le serveur redémarre. Vous serez reconnecté dans un instant.",
+		MsgGamePaused:      "L'hôte a mis la partie en pause.",
+		MsgGameResumed:     "La partie reprend ! Il vous reste %d secondes pour répondre.",
+		MsgAnswerProgress:  "%d joueurs sur %d ont répondu.",
+		MsgRevealed:        "Voici ce que tout le monde a répondu.",
+		MsgRoundResults:    "Manche %d sur %d terminée. %s mène avec %d points.",
+		MsgPlayerTimedOut:  "%s ne s'est pas reconnecté à temps et abandonne cette porte",
+	},
+	"de": {
+		MsgGameStarted:     "Das Spiel hat begonnen!",
+		MsgDoorPresented:   "Neue Tür! Du hast %d Sekunden zum Antworten.",
+		MsgScoresUpdated:   "Alle Spieler haben geantwortet! Punkte aktualisiert.",
+		MsgResponseTimeout: "Die Zeit ist um! Eingereichte Antworten werden ausgewertet.",
+		MsgVotingStarted:   "Alle Antworten sind da! Bewerte die anderen in %d Sekunden mit 1 bis 5 Sternen.",
+		MsgPlayerJoined:    "%s ist dem Spiel beigetreten",
+		MsgPlayerConnected: "Spieler verbunden",
+		MsgPlayerLeft:      "Spieler getrennt",
+		MsgPlayerBack:      "Spieler wieder verbunden",
+		MsgPlayerKicked:    "%s wurde vom Gastgeber aus dem Spiel entfernt",
+		MsgPlayerQuit:      "%s hat das Spiel verlassen",
+		MsgResponseIn:      "%s hat eine Antwort abgegeben",
+		MsgGameWon:         "%s hat das Spiel gewonnen!",
+		MsgServerDraining:  "Der Server startet neu. Du wirst gleich wieder verbunden.",
+		MsgGamePaused:      "Der Gastgeber hat das Spiel pausiert.",
+		MsgGameResumed:     "Weiter geht's! Du hast noch %d Sekunden zum Antworten.",
+		MsgAnswerProgress:  "%d von %d Spielern haben geantwortet.",
+		MsgRevealed:        "Das haben alle geantwortet.",
+		MsgRoundResults:    "Runde %d von %d ist vorbei. %s führt mit %d Punkten.",
+		MsgPlayerTimedOut:  "%s hat sich nicht rechtzeitig wieder verbunden und verliert diese Tür",
+	},
+	"pt": {
+		MsgGameStarted:     "O jogo começou!",
+		MsgDoorPresented:   "Nova porta! Você tem %d segundos para responder.",
+		MsgScoresUpdated:   "Todos os jogadores responderam! Pontuações atualizadas.",
+		MsgResponseTimeout: "O tempo acabou! Processando as respostas enviadas.",
+		MsgVotingStarted:   "Todas as respostas chegaram! Avalie as outras de 1 a 5 estrelas em %d segundos.",
+		MsgPlayerJoined:    "%s entrou no jogo",
+		MsgPlayerConnected: "Jogador conectado",
+		MsgPlayerLeft:      "Jogador desconectado",
+		MsgPlayerBack:      "Jogador reconectado",
+		MsgPlayerKicked:    "%s foi removido do jogo pelo anfitrião",
+		MsgPlayerQuit:      "%s saiu do jogo",
+		MsgResponseIn:      "%s enviou a resposta",
+		MsgGameWon:         "%s venceu o jogo!",
+		MsgServerDraining:  "O servidor está reiniciando. Você será reconectado em instantes.",
+		MsgGamePaused:      "O anfitrião pausou o jogo.",
+		MsgGameResumed:     "O jogo voltou! Você tem %d segundos restantes para responder.",
+		MsgAnswerProgress:  "%d de %d jogadores responderam.",
+		MsgRevealed:        "Veja o que todos responderam.",
+		MsgRoundResults:    "Rodada %d de %d encerrada. %s lidera com %d pontos.",
+		MsgPlayerTimedOut:  "%s não se reconectou a tempo e perde esta porta",
+	},
+}
+
+// languageNames gives the English name of each supported language, used when asking the AI
+// service to write content in that language
+var languageNames = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"pt": "Portuguese",
+}
+
+// Normalize reduces a locale such as "es-MX" or "pt_BR" to a supported language code,
+// falling back to DefaultLocale
+func Normalize(locale string) string {
+	language := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+
+	if _, exists := catalogs[language]; exists {
+		return language
+	}
+	return DefaultLocale
+}
+
+// IsDefault reports whether a locale resolves to the default locale
+func IsDefault(locale string) bool {
+	return Normalize(locale) == DefaultLocale
+}
+
+// LanguageName returns the English name of the locale's language
+func LanguageName(locale string) string {
+	return languageNames[Normalize(locale)]
+}
+
+// Message returns the system message for key in the given locale, formatted with args.
+// Keys missing from a catalog fall back to the default locale.
+func Message(locale string, key MessageKey, args ...interface{}) string {
+	format, exists := catalogs[Normalize(locale)][key]
+	if !exists {
+		format, exists = catalogs[DefaultLocale][key]
+		if !exists {
+			return string(key)
+		}
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Supported reports whether the locale's language has a message catalog
+func Supported(locale string) bool {
+	language := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	_, exists := catalogs[language]
+	return exists
+}
+
+// Negotiate picks the supported locale a client prefers from an Accept-Language header such
+// as "es-MX,es;q=0.9,en;q=0.8", falling back to DefaultLocale
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		locale string
+		weight float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		weight := 1.0
+		for _, param := range fields[1:] {
+			if value, found := strings.CutPrefix(strings.TrimSpace(param), "q="); found {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if weight > 0 && Supported(fields[0]) {
+			preferences = append(preferences, preference{locale: Normalize(fields[0]), weight: weight})
+		}
+	}
+	if len(preferences) == 0 {
+		return DefaultLocale
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].weight > preferences[j].weight })
+	return preferences[0].locale
+}
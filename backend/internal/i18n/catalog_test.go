@@ -0,0 +1,29 @@
+package i18n
+
+import "testing"
+
+func TestCatalogsDefineEveryKey(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for key := range catalogs[DefaultLocale] {
+			if _, exists := catalog[key]; !exists {
+				t.Errorf("Catalog %s is missing %s", locale, key)
+			}
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                          "en",
+		"es-MX,es;q=0.9,en;q=0.8":   "es",
+		"ja,fr;q=0.5,de;q=0.7":      "de",
+		"de;q=0,pt-BR;q=0.4":        "pt",
+		"zh-CN, ja;q=0.9":           "en",
+		"en-GB;q=0.2, FR-fr;q=0.3 ": "fr",
+	}
+	for header, expected := range cases {
+		if locale := Negotiate(header); locale != expected {
+			t.Errorf("Negotiate(%q) = %q, expected %q", header, locale, expected)
+		}
+	}
+}
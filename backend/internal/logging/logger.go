@@ -8,6 +8,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -46,6 +47,7 @@ type Logger struct {
 	service     string
 	version     string
 	level       LogLevel
+	levelMu     sync.RWMutex
 	output      *log.Logger
 	enableCaller bool
 }
@@ -61,8 +63,10 @@ func NewLogger(service, version string) *Logger {
 	}
 }
 
-// SetLevel sets the minimum log level
+// SetLevel sets the minimum log level; it is safe to call while the logger is in use
 func (l *Logger) SetLevel(level LogLevel) {
+	l.levelMu.Lock()
+	defer l.levelMu.Unlock()
 	l.level = level
 }
 
@@ -170,6 +174,8 @@ func (l *Logger) shouldLog(level LogLevel) bool {
 		LevelFatal: 4,
 	}
 
+	l.levelMu.RLock()
+	defer l.levelMu.RUnlock()
 	return levels[level] >= levels[l.level]
 }
 
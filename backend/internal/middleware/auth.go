@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"dumdoors-backend/internal/models"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PlayerLocalsKey is the c.Locals key holding the authenticated player's claims
+const PlayerLocalsKey = "player"
+
+// TokenVerifier verifies player access tokens
+type TokenVerifier interface {
+	VerifyToken(token string) (*models.PlayerClaims, error)
+}
+
+// Authenticate middleware requires a valid player access token and injects its claims into
+// c.Locals. Browsers cannot set headers on WebSocket upgrades, so a token query parameter is
+// accepted as well.
+func Authenticate(verifier TokenVerifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, err := verifyPlayer(c, verifier)
+		if err != nil {
+			return err
+		}
+
+		c.Locals(PlayerLocalsKey, claims)
+		return c.Next()
+	}
+}
+
+// AuthenticatedPlayer returns the claims injected by Authenticate, if any
+func AuthenticatedPlayer(c *fiber.Ctx) (*models.PlayerClaims, bool) {
+	claims, ok := c.Locals(PlayerLocalsKey).(*models.PlayerClaims)
+	return claims, ok && claims != nil
+}
+
+// verifyPlayer verifies the request's player access token
+func verifyPlayer(c *fiber.Ctx, verifier TokenVerifier) (*models.PlayerClaims, error) {
+	token := bearerToken(c.Get(fiber.HeaderAuthorization))
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token == "" {
+		return nil, UnauthorizedError("Authentication required").WithCode(CodeMissingToken)
+	}
+
+	claims, err := verifier.VerifyToken(token)
+	if err != nil {
+		return nil, UnauthorizedError("Invalid or expired access token").WithCode(CodeInvalidToken).WithCause(err)
+	}
+	return claims, nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header value
+func bearerToken(header string) string {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// drainRetryAfter is the Retry-After, in seconds, sent with requests refused while draining;
+// by then the load balancer should be routing to another instance
+const drainRetryAfter = 2
+
+// DrainState reports whether the instance is draining before a shutdown
+type DrainState interface {
+	Draining() bool
+}
+
+// RejectWhileDraining middleware refuses requests that would start new games on an instance
+// that is shutting down, with 503 and a Retry-After so clients try again elsewhere
+func RejectWhileDraining(state DrainState) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !state.Draining() {
+			return c.Next()
+		}
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(drainRetryAfter))
+		return ServiceUnavailableError("Server is restarting, please try again shortly").
+			WithCode(CodeServerDraining).
+			WithDetails("retryAfter", drainRetryAfter)
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type drainFlag bool
+
+func (d *drainFlag) Draining() bool { return bool(*d) }
+
+func TestRejectWhileDraining(t *testing.T) {
+	draining := drainFlag(false)
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler()})
+	app.Post("/create", RejectWhileDraining(&draining), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("POST", "/create", nil))
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("Expected requests through before draining, got %d", resp.StatusCode)
+	}
+
+	draining = true
+	resp, _ = app.Test(httptest.NewRequest("POST", "/create", nil))
+	if resp.StatusCode != fiber.StatusServiceUnavailable || resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+		t.Errorf("Expected 503 with Retry-After while draining, got %d %q", resp.StatusCode, resp.Header.Get(fiber.HeaderRetryAfter))
+	}
+}
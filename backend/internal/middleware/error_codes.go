@@ -0,0 +1,89 @@
+package middleware
+
+// Error codes carried by AppErrors so clients can tell failures apart without parsing
+// messages. Errors without a code of their own report their type, upper-cased.
+const (
+	// Request errors
+	CodeInvalidRequestBody = "INVALID_REQUEST_BODY"
+	CodeMissingParameter   = "MISSING_PARAMETER"
+	CodeInvalidParameter   = "INVALID_PARAMETER"
+	CodeUpgradeRequired    = "UPGRADE_REQUIRED"
+	CodeWrongInstance      = "WRONG_INSTANCE"
+
+	// Authentication and rate limiting
+	CodeMissingToken          = "MISSING_TOKEN"
+	CodeInvalidToken          = "INVALID_TOKEN"
+	CodeNotAdmin              = "NOT_ADMIN"
+	CodeMissingRole           = "MISSING_ROLE"
+	CodeInvalidServiceKey     = "INVALID_SERVICE_KEY"
+	CodeInvalidStaffKey       = "INVALID_STAFF_KEY"
+	CodeAnonymousUser         = "ANONYMOUS_USER"
+	CodePlayerMismatch        = "PLAYER_MISMATCH"
+	CodeRateLimited           = "RATE_LIMITED"
+	CodeInvalidIdempotencyKey = "INVALID_IDEMPOTENCY_KEY"
+	CodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+	CodeInvalidRouteToken     = "INVALID_ROUTE_TOKEN"
+
+	// Sessions and gameplay
+	CodeSessionNotFound        = "SESSION_NOT_FOUND"
+	CodeSessionFull            = "SESSION_FULL"
+	CodeNotAcceptingPlayers    = "NOT_ACCEPTING_PLAYERS"
+	CodeAlreadyInSession       = "ALREADY_IN_SESSION"
+	CodeNoActiveDoor           = "NO_ACTIVE_DOOR"
+	CodeAlreadyResponded       = "ALREADY_RESPONDED"
+	CodeInvalidSessionSettings = "INVALID_SESSION_SETTINGS"
+	CodeIncorrectPassword      = "INCORRECT_PASSWORD"
+	CodeLobbyLocked            = "LOBBY_LOCKED"
+	CodeGamePaused             = "GAME_PAUSED"
+	CodePlayerKicked           = "PLAYER_KICKED"
+	CodePlayerNotInSession     = "PLAYER_NOT_IN_SESSION"
+	CodeNotHost                = "NOT_HOST"
+	CodeIllegalOperation       = "ILLEGAL_OPERATION"
+	CodePlayersNotReady        = "PLAYERS_NOT_READY"
+	CodeTeamFull               = "TEAM_FULL"
+	CodeTeamsIncomplete        = "TEAMS_INCOMPLETE"
+	CodeNotTeamSession         = "NOT_TEAM_SESSION"
+	CodeSpectatorReadOnly      = "SPECTATOR_READ_ONLY"
+	CodeResponseTooLong        = "RESPONSE_TOO_LONG"
+	CodeResponseWindowClosed   = "RESPONSE_WINDOW_CLOSED"
+	CodeInvalidPlayerOptions   = "INVALID_PLAYER_OPTIONS"
+	CodeResponseRejected       = "RESPONSE_REJECTED"
+	CodeDuplicateResponse      = "DUPLICATE_RESPONSE"
+	CodeActivityNotFound       = "ACTIVITY_NOT_FOUND"
+	CodeInvalidVote            = "INVALID_VOTE"
+	CodeScoringBacklogFull     = "SCORING_BACKLOG_FULL"
+	CodeServerBusy             = "SERVER_BUSY"
+	CodeServerDraining         = "SERVER_DRAINING"
+	CodeSessionQuotaExceeded   = "SESSION_QUOTA_EXCEEDED"
+	CodeInvalidSessionLimits   = "INVALID_SESSION_LIMITS"
+	CodeRouteNotFound          = "ROUTE_NOT_FOUND"
+
+	// Doors and themes
+	CodeDoorNotFound          = "DOOR_NOT_FOUND"
+	CodeNoDoorAvailable       = "NO_DOOR_AVAILABLE"
+	CodeInvalidDoorTransition = "INVALID_DOOR_TRANSITION"
+	CodeInvalidDoorImport     = "INVALID_DOOR_IMPORT"
+	CodeDoorImportRejected    = "DOOR_IMPORT_REJECTED"
+	CodeThemeNotFound         = "THEME_NOT_FOUND"
+	CodeThemeUnavailable      = "THEME_UNAVAILABLE"
+	CodeInvalidTheme          = "INVALID_THEME"
+	CodePathGraphUnavailable  = "PATH_GRAPH_UNAVAILABLE"
+
+	// Social features
+	CodeNotSessionMember   = "NOT_SESSION_MEMBER"
+	CodeInvalidChatMessage = "INVALID_CHAT_MESSAGE"
+	CodeInvalidFriend      = "INVALID_FRIEND"
+	CodeAlreadyFriends     = "ALREADY_FRIENDS"
+	CodeNotFriends         = "NOT_FRIENDS"
+	CodeFriendListFull     = "FRIEND_LIST_FULL"
+	CodeFriendsDisabled    = "FRIENDS_DISABLED"
+	CodeProfileNotFound    = "PROFILE_NOT_FOUND"
+	CodeReplayUnavailable  = "REPLAY_UNAVAILABLE"
+	CodeSummaryUnavailable = "SUMMARY_UNAVAILABLE"
+	CodeTournamentNotFound = "TOURNAMENT_NOT_FOUND"
+	CodeTournamentConflict = "TOURNAMENT_CONFLICT"
+	CodeTournamentClosed   = "TOURNAMENT_CLOSED"
+	CodeNotInMatchmaking   = "NOT_IN_MATCHMAKING"
+	CodeSessionConflict    = "SESSION_CONFLICT"
+)
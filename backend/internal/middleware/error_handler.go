@@ -2,10 +2,13 @@ package middleware
 
 import (
 	"context"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/tracing"
 	"encoding/json"
 	"fmt"
 	"log"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -119,6 +122,10 @@ func NetworkError(message string) *AppError {
 	return NewAppError(ErrorTypeNetwork, message, fiber.StatusBadGateway)
 }
 
+func RateLimitError(message string) *AppError {
+	return NewAppError(ErrorTypeRateLimit, message, fiber.StatusTooManyRequests)
+}
+
 // ErrorHandler middleware for centralized error handling
 func ErrorHandler() fiber.ErrorHandler {
 	return func(c *fiber.Ctx, err error) error {
@@ -132,7 +139,7 @@ func ErrorHandler() fiber.ErrorHandler {
 		case *AppError:
 			appErr = e
 		case *fiber.Error:
-			appErr = NewAppError(ErrorTypeInternal, e.Message, e.Code)
+			appErr = NewAppError(errorTypeForStatus(e.Code), e.Message, e.Code)
 		default:
 			// Handle context errors
 			if err == context.DeadlineExceeded {
@@ -147,6 +154,11 @@ func ErrorHandler() fiber.ErrorHandler {
 		// Add request ID for tracing
 		appErr.WithRequestID(requestID)
 		
+		// Every error carries a code; errors without one of their own report their type
+		if appErr.Code == "" {
+			appErr.Code = strings.ToUpper(string(appErr.Type))
+		}
+		
 		// Log error with appropriate level
 		logError(appErr, c)
 		
@@ -163,6 +175,30 @@ func ErrorHandler() fiber.ErrorHandler {
 	}
 }
 
+// errorTypeForStatus classifies errors raised by fiber itself, such as unknown routes
+func errorTypeForStatus(status int) ErrorType {
+	switch status {
+	case fiber.StatusNotFound:
+		return ErrorTypeNotFound
+	case fiber.StatusUnauthorized:
+		return ErrorTypeUnauthorized
+	case fiber.StatusForbidden:
+		return ErrorTypeForbidden
+	case fiber.StatusConflict:
+		return ErrorTypeConflict
+	case fiber.StatusTooManyRequests:
+		return ErrorTypeRateLimit
+	case fiber.StatusServiceUnavailable:
+		return ErrorTypeServiceUnavailable
+	case fiber.StatusRequestTimeout:
+		return ErrorTypeTimeout
+	}
+	if status >= fiber.StatusBadRequest && status < fiber.StatusInternalServerError {
+		return ErrorTypeValidation
+	}
+	return ErrorTypeInternal
+}
+
 // logError logs the error with appropriate level and context
 func logError(err *AppError, c *fiber.Ctx) {
 	// Prepare log context
@@ -194,7 +230,7 @@ func logError(err *AppError, c *fiber.Ctx) {
 	
 	// Log with appropriate level
 	switch err.Type {
-	case ErrorTypeValidation, ErrorTypeNotFound, ErrorTypeUnauthorized, ErrorTypeForbidden:
+	case ErrorTypeValidation, ErrorTypeNotFound, ErrorTypeUnauthorized, ErrorTypeForbidden, ErrorTypeRateLimit:
 		// Client errors - log as info
 		log.Printf("CLIENT_ERROR: %s", logJSON)
 	case ErrorTypeTimeout, ErrorTypeNetwork, ErrorTypeServiceUnavailable:
@@ -247,14 +283,21 @@ func RequestID() fiber.Handler {
 		// Check if request ID already exists
 		requestID := c.Get("X-Request-ID")
 		if requestID == "" {
-			// Generate new request ID
-			requestID = fmt.Sprintf("%d-%s", time.Now().UnixNano(), generateRandomString(8))
+			// Generate new request ID; timestamps would make seeded runs irreproducible
+			if random.Seeded() {
+				requestID = random.ID()
+			} else {
+				requestID = fmt.Sprintf("%d-%s", time.Now().UnixNano(), generateRandomString(8))
+			}
 		}
 		
 		// Set request ID in context and response header
 		c.Set("X-Request-ID", requestID)
 		c.Locals("request_id", requestID)
 		
+		// Carry the request ID into service calls and any background work they start
+		c.SetUserContext(tracing.WithRequestID(c.UserContext(), requestID))
+		
 		return c.Next()
 	}
 }
@@ -264,7 +307,7 @@ func generateRandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[time.Now().UnixNano()%int64(len(charset))]
+		b[i] = charset[random.Intn(len(charset))]
 	}
 	return string(b)
 }
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"dumdoors-backend/internal/models"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyKeyHeader carries the client-chosen key identifying a request across retries
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyLockTTL bounds how long a crashed request can hold its key
+const idempotencyLockTTL = time.Minute
+
+// IdempotencyStore records the first outcome of each idempotent request
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key, requestHash string, lockTTL time.Duration) (*models.IdempotentResponse, bool, error)
+	Complete(ctx context.Context, key string, response *models.IdempotentResponse, ttl time.Duration) error
+	Release(ctx context.Context, key string) error
+}
+
+// Idempotent middleware executes a request carrying an Idempotency-Key once and replays its
+// response to duplicates for ttl. Keys are scoped to the player and route, so it must run after
+// Authenticate, and before any rate limiter so a throttled request isn't what gets stored.
+// Responses a retry could turn out differently, such as server errors, conflicts and
+// throttling, are not stored either, letting the client retry them.
+func Idempotent(store IdempotencyStore, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		idempotencyKey := c.Get(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			return c.Next()
+		}
+		if len(idempotencyKey) > 255 {
+			return ValidationError("Idempotency-Key must be at most 255 characters").WithCode(CodeInvalidIdempotencyKey)
+		}
+
+		playerID := ""
+		if player, ok := AuthenticatedPlayer(c); ok {
+			playerID = player.PlayerID
+		}
+		key := fmt.Sprintf("%s:%s:%s:%s", playerID, c.Method(), c.Path(), idempotencyKey)
+		requestHash := hashRequestBody(c.Body())
+
+		ctx := c.UserContext()
+		existing, reserved, err := store.Reserve(ctx, key, requestHash, idempotencyLockTTL)
+		if err != nil {
+			fmt.Printf("Warning: idempotency unavailable, executing request: %v\n", err)
+			return c.Next()
+		}
+		if !reserved {
+			return replayIdempotent(c, existing, requestHash)
+		}
+
+		if err := c.Next(); err != nil {
+			// Render the error now so its response can be stored like any other
+			if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+				releaseIdempotencyKey(store, key)
+				return handlerErr
+			}
+		}
+
+		status := c.Response().StatusCode()
+		if retryableStatus(status) {
+			releaseIdempotencyKey(store, key)
+			return nil
+		}
+
+		record := &models.IdempotentResponse{
+			RequestHash: requestHash,
+			StatusCode:  status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+			CreatedAt:   time.Now(),
+		}
+		if err := store.Complete(ctx, key, record, ttl); err != nil {
+			fmt.Printf("Warning: failed to store idempotent response: %v\n", err)
+			releaseIdempotencyKey(store, key)
+		}
+		return nil
+	}
+}
+
+// retryableStatus reports whether a response reflects a passing condition rather than the
+// request's outcome
+func retryableStatus(status int) bool {
+	switch status {
+	case fiber.StatusRequestTimeout, fiber.StatusConflict, fiber.StatusTooEarly, fiber.StatusTooManyRequests:
+		return true
+	}
+	return status >= fiber.StatusInternalServerError
+}
+
+// replayIdempotent answers a duplicate request from the stored outcome of the first one
+func replayIdempotent(c *fiber.Ctx, existing *models.IdempotentResponse, requestHash string) error {
+	if existing.RequestHash != requestHash {
+		return NewAppError(ErrorTypeValidation, "Idempotency-Key was already used for a different request", fiber.StatusUnprocessableEntity).
+			WithCode(CodeIdempotencyKeyReused)
+	}
+	if existing.Pending {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(1))
+		return ConflictError("A request with this Idempotency-Key is still in progress").WithCode(CodeIdempotencyInProgress)
+	}
+
+	c.Set("Idempotent-Replayed", "true")
+	if existing.ContentType != "" {
+		c.Set(fiber.HeaderContentType, existing.ContentType)
+	}
+	return c.Status(existing.StatusCode).Send(existing.Body)
+}
+
+// releaseIdempotencyKey frees a key whose request could not be stored
+func releaseIdempotencyKey(store IdempotencyStore, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := store.Release(ctx, key); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// hashRequestBody fingerprints a request so a reused key with a different payload is caught
+func hashRequestBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
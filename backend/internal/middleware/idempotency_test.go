@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// memoryIdempotencyStore is an in-memory IdempotencyStore
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*models.IdempotentResponse
+}
+
+func (m *memoryIdempotencyStore) Reserve(ctx context.Context, key, requestHash string, lockTTL time.Duration) (*models.IdempotentResponse, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.entries[key]; ok {
+		return existing, false, nil
+	}
+	m.entries[key] = &models.IdempotentResponse{Pending: true, RequestHash: requestHash}
+	return nil, true, nil
+}
+
+func (m *memoryIdempotencyStore) Complete(ctx context.Context, key string, response *models.IdempotentResponse, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = response
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func TestIdempotent_ReplaysOutcomesButNotThrottling(t *testing.T) {
+	statuses := []int{fiber.StatusTooManyRequests, fiber.StatusCreated, fiber.StatusBadRequest}
+	calls := 0
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler()})
+	app.Post("/submit", Idempotent(&memoryIdempotencyStore{entries: make(map[string]*models.IdempotentResponse)}, time.Hour), func(c *fiber.Ctx) error {
+		status := statuses[calls]
+		calls++
+		return c.SendStatus(status)
+	})
+
+	send := func(key string) int {
+		req := httptest.NewRequest("POST", "/submit", nil)
+		req.Header.Set(IdempotencyKeyHeader, key)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	if status := send("k1"); status != fiber.StatusTooManyRequests {
+		t.Fatalf("Expected the first attempt to be throttled, got %d", status)
+	}
+	if status := send("k1"); status != fiber.StatusCreated {
+		t.Errorf("Expected the retry to run rather than replay the 429, got %d", status)
+	}
+	if status := send("k1"); status != fiber.StatusCreated || calls != 2 {
+		t.Errorf("Expected the stored 201 to be replayed, got %d after %d calls", status, calls)
+	}
+	if status := send("k2"); status != fiber.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", status)
+	}
+	if status := send("k2"); status != fiber.StatusBadRequest || calls != 3 {
+		t.Errorf("Expected the stored 400 to be replayed, got %d after %d calls", status, calls)
+	}
+}
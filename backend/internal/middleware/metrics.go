@@ -111,7 +111,7 @@ func (gmm *GameMetricsMiddleware) TrackResponseScoring(playerID string, score in
 	counter.Inc()
 	
 	if success {
-		histogram := gmm.collector.NewHistogram("response_scores", "Distribution of response scores", labels)
+		histogram := gmm.collector.NewHistogramWithBuckets("response_scores", "Distribution of response scores", labels, []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100})
 		histogram.Observe(float64(score))
 	}
 }
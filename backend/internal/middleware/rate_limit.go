@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimitStore holds the token buckets behind RateLimit
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit int, window time.Duration) (*models.RateLimitDecision, error)
+}
+
+// RateLimitKeyFunc picks the bucket a request is counted against; an empty key skips limiting
+type RateLimitKeyFunc func(c *fiber.Ctx) string
+
+// RateLimitConfig describes one route group's limit
+type RateLimitConfig struct {
+	Name   string           // Identifies the limiter in bucket keys and stats
+	Limit  int              // Requests allowed per window
+	Window time.Duration    // Time for an empty bucket to refill
+	Key    RateLimitKeyFunc // Bucket selection, e.g. PerPlayer or PerIP
+}
+
+// PerPlayer counts requests against the authenticated player; it must run after Authenticate
+func PerPlayer(c *fiber.Ctx) string {
+	player, ok := AuthenticatedPlayer(c)
+	if !ok {
+		return ""
+	}
+	return "player:" + player.PlayerID
+}
+
+// PerIP counts requests against the client IP
+func PerIP(c *fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// RateLimiter tracks how a route group's limit is being applied
+type RateLimiter struct {
+	config      RateLimitConfig
+	mu          sync.RWMutex
+	allowed     int64
+	limited     int64
+	storeErrors int64
+}
+
+// limits returns the limiter's current limit and window
+func (rl *RateLimiter) limits() (int, time.Duration) {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return rl.config.Limit, rl.config.Window
+}
+
+// SetLimits changes the limit and window applied from the next request on
+func (rl *RateLimiter) SetLimits(limit int, window time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.config.Limit, rl.config.Window = limit, window
+}
+
+// GetStats returns rate limiter statistics
+func (rl *RateLimiter) GetStats() map[string]interface{} {
+	limit, window := rl.limits()
+	return map[string]interface{}{
+		"name":         rl.config.Name,
+		"limit":        limit,
+		"window":       window.String(),
+		"allowed":      atomic.LoadInt64(&rl.allowed),
+		"limited":      atomic.LoadInt64(&rl.limited),
+		"store_errors": atomic.LoadInt64(&rl.storeErrors),
+	}
+}
+
+// RateLimit middleware takes a token from the request's bucket and rejects the request with
+// 429 and Retry-After once the bucket is empty. Limiting fails open if the store is unavailable.
+func RateLimit(store RateLimitStore, config RateLimitConfig) fiber.Handler {
+	if config.Key == nil {
+		config.Key = PerIP
+	}
+	limiter := registerRateLimiter(config)
+
+	return func(c *fiber.Ctx) error {
+		key := config.Key(c)
+		limit, window := limiter.limits()
+		if key == "" || limit <= 0 {
+			return c.Next()
+		}
+
+		decision, err := store.Take(c.UserContext(), config.Name+":"+key, limit, window)
+		if err != nil {
+			atomic.AddInt64(&limiter.storeErrors, 1)
+			fmt.Printf("Warning: rate limiting %s unavailable: %v\n", config.Name, err)
+			return c.Next()
+		}
+
+		c.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		if decision.Allowed {
+			atomic.AddInt64(&limiter.allowed, 1)
+			return c.Next()
+		}
+
+		atomic.AddInt64(&limiter.limited, 1)
+		retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+		return RateLimitError("Too many requests, please slow down").
+			WithCode(CodeRateLimited).
+			WithDetails("retryAfter", retryAfter)
+	}
+}
+
+// rateLimiters holds every limiter created by RateLimit, for the monitoring endpoints
+var (
+	rateLimiters      = make(map[string]*RateLimiter)
+	rateLimitersMutex sync.RWMutex
+)
+
+// registerRateLimiter records a limiter's stats under its name
+func registerRateLimiter(config RateLimitConfig) *RateLimiter {
+	rateLimitersMutex.Lock()
+	defer rateLimitersMutex.Unlock()
+
+	limiter := &RateLimiter{config: config}
+	rateLimiters[config.Name] = limiter
+	return limiter
+}
+
+// SetRateLimit changes the limits of the named rate limiter, reporting whether it exists
+func SetRateLimit(name string, limit int, window time.Duration) bool {
+	rateLimitersMutex.RLock()
+	limiter, exists := rateLimiters[name]
+	rateLimitersMutex.RUnlock()
+
+	if exists {
+		limiter.SetLimits(limit, window)
+	}
+	return exists
+}
+
+// GetAllRateLimiterStats returns stats for all rate limiters
+func GetAllRateLimiterStats() map[string]interface{} {
+	rateLimitersMutex.RLock()
+	defer rateLimitersMutex.RUnlock()
+
+	stats := make(map[string]interface{})
+	for name, limiter := range rateLimiters {
+		stats[name] = limiter.GetStats()
+	}
+	return stats
+}
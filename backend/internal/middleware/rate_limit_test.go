@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"math"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// memoryRateLimitStore is an in-memory token bucket store on a manual clock, refilling the way
+// the Redis store's script does
+type memoryRateLimitStore struct {
+	mu      sync.Mutex
+	now     time.Time
+	buckets map[string]*memoryBucket
+	keys    []string
+	err     error
+}
+
+type memoryBucket struct {
+	tokens float64
+	at     time.Time
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{now: time.Unix(0, 0), buckets: make(map[string]*memoryBucket)}
+}
+
+func (m *memoryRateLimitStore) Take(ctx context.Context, key string, limit int, window time.Duration) (*models.RateLimitDecision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.keys = append(m.keys, key)
+
+	refillPerSec := float64(limit) / window.Seconds()
+	bucket, exists := m.buckets[key]
+	if !exists {
+		bucket = &memoryBucket{tokens: float64(limit), at: m.now}
+		m.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(float64(limit), bucket.tokens+m.now.Sub(bucket.at).Seconds()*refillPerSec)
+	bucket.at = m.now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / refillPerSec * float64(time.Second))
+		return &models.RateLimitDecision{Remaining: 0, RetryAfter: wait}, nil
+	}
+	bucket.tokens--
+	return &models.RateLimitDecision{Allowed: true, Remaining: int(bucket.tokens)}, nil
+}
+
+// advance moves the store's clock forward
+func (m *memoryRateLimitStore) advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+}
+
+func newRateLimitApp(store RateLimitStore, config RateLimitConfig) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler()})
+	app.Post("/submit", Authenticate(stubVerifier{}), RateLimit(store, config), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	return app
+}
+
+// sendAs makes a request as the given player
+func sendAs(t *testing.T, app *fiber.App, playerID string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/submit", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+playerID)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp.StatusCode, resp.Header.Get(fiber.HeaderRetryAfter)
+}
+
+func TestRateLimit_RejectsEmptyBucketUntilItRefills(t *testing.T) {
+	store := newMemoryRateLimitStore()
+	app := newRateLimitApp(store, RateLimitConfig{Name: "test-refill", Limit: 2, Window: 10 * time.Second, Key: PerPlayer})
+
+	for i := 0; i < 2; i++ {
+		if status, _ := sendAs(t, app, "p1"); status != fiber.StatusNoContent {
+			t.Fatalf("Expected request %d within the limit to pass, got %d", i+1, status)
+		}
+	}
+
+	// One token takes half the window to come back
+	status, retryAfter := sendAs(t, app, "p1")
+	if status != fiber.StatusTooManyRequests || retryAfter != "5" {
+		t.Fatalf("Expected 429 with Retry-After 5, got %d %q", status, retryAfter)
+	}
+
+	store.advance(4 * time.Second)
+	if status, retryAfter := sendAs(t, app, "p1"); status != fiber.StatusTooManyRequests || retryAfter != "1" {
+		t.Errorf("Expected 429 with the remaining second rounded up, got %d %q", status, retryAfter)
+	}
+
+	store.advance(time.Second)
+	if status, _ := sendAs(t, app, "p1"); status != fiber.StatusNoContent {
+		t.Errorf("Expected the refilled token to be taken, got %d", status)
+	}
+}
+
+func TestRateLimit_KeysBucketsByPlayerOrIP(t *testing.T) {
+	store := newMemoryRateLimitStore()
+	perPlayer := newRateLimitApp(store, RateLimitConfig{Name: "test-player", Limit: 1, Window: time.Minute, Key: PerPlayer})
+
+	sendAs(t, perPlayer, "p1")
+	if status, _ := sendAs(t, perPlayer, "p2"); status != fiber.StatusNoContent {
+		t.Errorf("Expected each player to have their own bucket, got %d", status)
+	}
+	if status, _ := sendAs(t, perPlayer, "p1"); status != fiber.StatusTooManyRequests {
+		t.Errorf("Expected p1's bucket to be empty, got %d", status)
+	}
+
+	perIP := newRateLimitApp(store, RateLimitConfig{Name: "test-ip", Limit: 1, Window: time.Minute, Key: PerIP})
+	sendAs(t, perIP, "p1")
+	if status, _ := sendAs(t, perIP, "p2"); status != fiber.StatusTooManyRequests {
+		t.Errorf("Expected players behind one IP to share a bucket, got %d", status)
+	}
+
+	want := []string{"test-player:player:p1", "test-player:player:p2", "test-player:player:p1", "test-ip:ip:0.0.0.0", "test-ip:ip:0.0.0.0"}
+	if len(store.keys) != len(want) {
+		t.Fatalf("Expected keys %v, got %v", want, store.keys)
+	}
+	for i := range want {
+		if store.keys[i] != want[i] {
+			t.Errorf("Expected key %q, got %q", want[i], store.keys[i])
+		}
+	}
+}
+
+func TestRateLimit_FailsOpenWhenStoreErrors(t *testing.T) {
+	store := newMemoryRateLimitStore()
+	store.err = errors.New("redis: connection refused")
+	app := newRateLimitApp(store, RateLimitConfig{Name: "test-fail-open", Limit: 1, Window: time.Minute, Key: PerPlayer})
+
+	for i := 0; i < 3; i++ {
+		if status, _ := sendAs(t, app, "p1"); status != fiber.StatusNoContent {
+			t.Fatalf("Expected requests through while the store is down, got %d", status)
+		}
+	}
+	stats := GetAllRateLimiterStats()["test-fail-open"].(map[string]interface{})
+	if stats["store_errors"] != int64(3) {
+		t.Errorf("Expected 3 store errors counted, got %v", stats["store_errors"])
+	}
+}
+
+func TestSetRateLimit_ReloadsLimits(t *testing.T) {
+	store := newMemoryRateLimitStore()
+	app := newRateLimitApp(store, RateLimitConfig{Name: "test-reload", Limit: 1, Window: time.Minute, Key: PerPlayer})
+
+	sendAs(t, app, "p1")
+	if status, _ := sendAs(t, app, "p1"); status != fiber.StatusTooManyRequests {
+		t.Fatalf("Expected the second request to be limited, got %d", status)
+	}
+
+	if !SetRateLimit("test-reload", 0, time.Minute) {
+		t.Fatal("Expected the limiter to be found")
+	}
+	if status, _ := sendAs(t, app, "p1"); status != fiber.StatusNoContent {
+		t.Errorf("Expected a zero limit to turn limiting off, got %d", status)
+	}
+
+	SetRateLimit("test-reload", 3, time.Minute)
+	req := httptest.NewRequest("POST", "/submit", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer p2")
+	resp, _ := app.Test(req)
+	if resp.Header.Get("X-RateLimit-Limit") != "3" || resp.Header.Get("X-RateLimit-Remaining") != "2" {
+		t.Errorf("Expected the reloaded limit of 3 to apply, got limit %q remaining %q",
+			resp.Header.Get("X-RateLimit-Limit"), resp.Header.Get("X-RateLimit-Remaining"))
+	}
+
+	if SetRateLimit("test-missing", 1, time.Minute) {
+		t.Error("Expected an unknown limiter to be reported missing")
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"dumdoors-backend/internal/models"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// stubVerifier accepts tokens that are player IDs
+type stubVerifier struct{}
+
+func (stubVerifier) VerifyToken(token string) (*models.PlayerClaims, error) {
+	if token == "" || token == "forged" {
+		return nil, errors.New("invalid token")
+	}
+	return &models.PlayerClaims{PlayerID: token}, nil
+}
+
+func newRBACApp() *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *AppError
+			if errors.As(err, &appErr) {
+				return c.Status(appErr.StatusCode).SendString(appErr.Code)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+
+	admin := app.Group("/admin", Authorize(stubVerifier{}, RoleConfig{
+		AdminPlayerIDs:     []string{"alice"},
+		ModeratorPlayerIDs: []string{"mo"},
+		ServiceKeys:        []string{"s3cret"},
+		StaffKeys:          []string{"st4ff"},
+	}))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	admin.Put("/doors", RequireRole(RoleModerator), ok)
+	admin.Post("/broadcast", RequireRole(RoleService), ok)
+	admin.Post("/metrics/reset", RequireRole(RoleAdmin), ok)
+	return app
+}
+
+func TestAuthorize_EnforcesRoles(t *testing.T) {
+	app := newRBACApp()
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		key    string
+		staff  string
+		status int
+		code   string
+	}{
+		{"anonymous", "PUT", "/admin/doors", "", "", "", fiber.StatusUnauthorized, CodeMissingToken},
+		{"forged token", "PUT", "/admin/doors", "forged", "", "st4ff", fiber.StatusUnauthorized, CodeInvalidToken},
+		{"player without a role", "PUT", "/admin/doors", "bob", "", "st4ff", fiber.StatusForbidden, CodeNotAdmin},
+		{"admin token without a staff key", "POST", "/admin/metrics/reset", "alice", "", "", fiber.StatusUnauthorized, CodeInvalidStaffKey},
+		{"admin token with a service key as staff key", "POST", "/admin/metrics/reset", "alice", "", "s3cret", fiber.StatusUnauthorized, CodeInvalidStaffKey},
+		{"moderator editing doors", "PUT", "/admin/doors", "mo", "", "st4ff", fiber.StatusNoContent, ""},
+		{"moderator resetting metrics", "POST", "/admin/metrics/reset", "mo", "", "st4ff", fiber.StatusForbidden, CodeMissingRole},
+		{"service broadcasting", "POST", "/admin/broadcast", "", "s3cret", "", fiber.StatusNoContent, ""},
+		{"service editing doors", "PUT", "/admin/doors", "", "s3cret", "", fiber.StatusForbidden, CodeMissingRole},
+		{"staff key as service key", "POST", "/admin/broadcast", "", "st4ff", "", fiber.StatusUnauthorized, CodeInvalidServiceKey},
+		{"wrong service key", "POST", "/admin/broadcast", "alice", "guess", "st4ff", fiber.StatusUnauthorized, CodeInvalidServiceKey},
+		{"admin resetting metrics", "POST", "/admin/metrics/reset", "alice", "", "st4ff", fiber.StatusNoContent, ""},
+		{"admin broadcasting", "POST", "/admin/broadcast", "alice", "", "st4ff", fiber.StatusNoContent, ""},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.token != "" {
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tc.token)
+		}
+		if tc.key != "" {
+			req.Header.Set(ServiceKeyHeader, tc.key)
+		}
+		if tc.staff != "" {
+			req.Header.Set(StaffKeyHeader, tc.staff)
+		}
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", tc.name, err)
+		}
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+		if resp.StatusCode != tc.status || string(body[:n]) != tc.code {
+			t.Errorf("%s: expected %d %s, got %d %s", tc.name, tc.status, tc.code, resp.StatusCode, body[:n])
+		}
+	}
+}
@@ -2,9 +2,9 @@ package middleware
 
 import (
 	"context"
+	"dumdoors-backend/internal/random"
 	"fmt"
 	"math"
-	"math/rand"
 	"time"
 )
 
@@ -81,7 +81,7 @@ func (rc *RetryConfig) CalculateDelay(attempt int) time.Duration {
 
 	// Add jitter if enabled
 	if rc.Jitter {
-		jitter := rand.Float64() * 0.1 * delay // 10% jitter
+		jitter := random.Float64() * 0.1 * delay // 10% jitter
 		delay += jitter
 	}
 
@@ -182,7 +182,7 @@ func (eb *ExponentialBackoff) CalculateDelay(attempt int) time.Duration {
 	}
 
 	if eb.Jitter {
-		jitter := rand.Float64() * 0.1 * delay
+		jitter := random.Float64() * 0.1 * delay
 		delay += jitter
 	}
 
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Tracing middleware starts a server span for each request, continuing any trace the caller
+// propagated in its headers. It must run after RequestID so the span is tagged with the request ID.
+func Tracing() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), fiberHeaderCarrier{c: c})
+		ctx, span := tracing.Tracer().Start(ctx, c.Method()+" "+c.Path(),
+			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+			oteltrace.WithAttributes(append(tracing.Attributes(ctx),
+				attribute.String("http.request.method", c.Method()),
+				attribute.String("url.path", c.Path()),
+			)...),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+		err := c.Next()
+
+		// Name the span after the matched route so requests for different sessions group together
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(attribute.String("http.route", route))
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = statusForError(err)
+			span.RecordError(err)
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+
+		return err
+	}
+}
+
+// statusForError returns the status the error handler will respond with
+func statusForError(err error) int {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.StatusCode
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// fiberHeaderCarrier reads and writes trace propagation headers on a Fiber request
+type fiberHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+func (f fiberHeaderCarrier) Get(key string) string {
+	return f.c.Get(key)
+}
+
+func (f fiberHeaderCarrier) Set(key, value string) {
+	f.c.Request().Header.Set(key, value)
+}
+
+func (f fiberHeaderCarrier) Keys() []string {
+	keys := make([]string, 0)
+	f.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
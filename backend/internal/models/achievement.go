@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// AchievementID identifies a badge players can unlock
+type AchievementID string
+
+const (
+	AchievementFirstWin   AchievementID = "first-win"
+	AchievementPerfectRun AchievementID = "perfect-run"
+	AchievementHighScore  AchievementID = "high-score"
+	AchievementSpeedWin   AchievementID = "speed-win"
+	AchievementVeteran    AchievementID = "veteran"
+)
+
+// Achievement describes a badge
+type Achievement struct {
+	ID          AchievementID `bson:"achievementId" json:"id"`
+	Name        string        `bson:"name" json:"name"`
+	Description string        `bson:"description" json:"description"`
+}
+
+// PlayerAchievement records a badge a player has unlocked and the game they unlocked it in
+type PlayerAchievement struct {
+	PlayerID    string `bson:"playerId" json:"playerId"`
+	Achievement `bson:",inline"`
+	SessionID   string    `bson:"sessionId" json:"sessionId"`
+	UnlockedAt  time.Time `bson:"unlockedAt" json:"unlockedAt"`
+}
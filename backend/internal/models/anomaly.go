@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Play anomaly reasons
+const (
+	AnomalyFastResponses = "fast_responses" // answered faster than a person can read the door, again and again
+	AnomalyUniformLength = "uniform_length" // responses are all about the same length
+)
+
+// PlaySample is one submitted response as seen by anomaly detection: how long after the door
+// opened it arrived and how long it was
+type PlaySample struct {
+	SessionID string        `bson:"sessionId" json:"sessionId"`
+	Latency   time.Duration `bson:"latency" json:"latency"`
+	Length    int           `bson:"length" json:"length"`
+	At        time.Time     `bson:"at" json:"at"`
+}
+
+// PlayerActivity tracks a player's recent submission cadence and whether it looks scripted.
+// Flagged players are shadow-excluded from the global leaderboards until an admin clears them.
+type PlayerActivity struct {
+	PlayerID        string        `bson:"playerId" json:"playerId"`
+	Username        string        `bson:"username,omitempty" json:"username,omitempty"`
+	Samples         []PlaySample  `bson:"samples" json:"samples"` // most recent submissions, oldest first
+	Submissions     int64         `bson:"submissions" json:"submissions"`
+	FastSubmissions int64         `bson:"fastSubmissions" json:"fastSubmissions"`
+	MedianLatency   time.Duration `bson:"-" json:"medianLatency"` // of the recent samples
+	LengthEntropy   float64       `bson:"-" json:"lengthEntropy"` // bits over the recent samples; near zero when every response is the same length
+	Flagged         bool          `bson:"flagged" json:"flagged"`
+	Reasons         []string      `bson:"reasons,omitempty" json:"reasons,omitempty"`
+	FlaggedAt       *time.Time    `bson:"flaggedAt,omitempty" json:"flaggedAt,omitempty"`
+	ClearedAt       *time.Time    `bson:"clearedAt,omitempty" json:"clearedAt,omitempty"`
+	ClearedBy       string        `bson:"clearedBy,omitempty" json:"clearedBy,omitempty"`
+	UpdatedAt       time.Time     `bson:"updatedAt" json:"updatedAt"`
+}
+
+// PlayerActivityFilter pages through flagged players, most recently flagged first
+type PlayerActivityFilter struct {
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
+}
+
+// PlayerActivityPage is one page of flagged players with the total flagged count
+type PlayerActivityPage struct {
+	Players  []*PlayerActivity `json:"players"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"pageSize"`
+}
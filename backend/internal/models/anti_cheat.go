@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// CheatKind names why a response was suspected of being copied
+type CheatKind string
+
+const (
+	CheatRepeat     CheatKind = "repeat"     // the player reused one of their own earlier answers
+	CheatPlagiarism CheatKind = "plagiarism" // the player copied another player's answer
+)
+
+// CheatSuspicion records the earlier response a submitted response nearly duplicates and the
+// share of its score withheld for it
+type CheatSuspicion struct {
+	Kind              CheatKind `bson:"kind" json:"kind"`
+	MatchedResponseID string    `bson:"matchedResponseId,omitempty" json:"matchedResponseId,omitempty"`
+	MatchedPlayerID   string    `bson:"matchedPlayerId,omitempty" json:"matchedPlayerId,omitempty"`
+	Similarity        float64   `bson:"similarity" json:"similarity"`
+	Exact             bool      `bson:"exact,omitempty" json:"exact,omitempty"` // the normalized texts are identical
+	Penalty           float64   `bson:"penalty,omitempty" json:"penalty,omitempty"`
+}
+
+// ResponseFingerprint identifies a response's text without keeping the text itself: Hash matches
+// identical answers once case, punctuation and spacing are ignored, and Signature estimates how
+// much two answers overlap
+type ResponseFingerprint struct {
+	ResponseID  string    `json:"responseId"`
+	SessionID   string    `json:"sessionId"`
+	Hash        string    `json:"hash"`
+	Signature   []uint64  `json:"signature"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
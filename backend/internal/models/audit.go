@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// AuditAction names a privileged action recorded in the audit log
+type AuditAction string
+
+const (
+	AuditPlayerKicked      AuditAction = "player.kick"
+	AuditHostTransferred   AuditAction = "host.transfer"
+	AuditLobbyLocked       AuditAction = "lobby.lock"
+	AuditTeamsChanged      AuditAction = "teams.update"
+	AuditGamePaused        AuditAction = "game.pause"
+	AuditGameResumed       AuditAction = "game.resume"
+	AuditDoorCreated       AuditAction = "door.create"
+	AuditDoorUpdated       AuditAction = "door.update"
+	AuditDoorStatusChanged AuditAction = "door.status"
+	AuditDoorDeleted       AuditAction = "door.delete"
+	AuditDoorsImported     AuditAction = "doors.import"
+	AuditThemeCreated      AuditAction = "theme.create"
+	AuditThemeUpdated      AuditAction = "theme.update"
+	AuditThemeDeleted      AuditAction = "theme.delete"
+	AuditSessionExpired    AuditAction = "session.expire"
+	AuditSessionsSwept     AuditAction = "sessions.sweep"
+	AuditSessionBroadcast  AuditAction = "session.broadcast"
+	AuditMetricsReset      AuditAction = "metrics.reset"
+	AuditDataExported      AuditAction = "data.export"
+	AuditCheatSuspected    AuditAction = "response.cheat"
+	AuditAnomalyCleared    AuditAction = "anomaly.clear"
+	AuditSessionLimitsSet  AuditAction = "session_limits.update"
+)
+
+// AuditActor identifies who performed an audited action: a player, or a service
+// authenticated by API key
+type AuditActor struct {
+	ID    string   `bson:"id" json:"id"`
+	Name  string   `bson:"name,omitempty" json:"name,omitempty"`
+	Roles []string `bson:"roles,omitempty" json:"roles,omitempty"`
+}
+
+// AuditTarget identifies what an audited action was performed on
+type AuditTarget struct {
+	Type      string `bson:"type" json:"type"` // e.g. "door", "player" or "session"
+	ID        string `bson:"id,omitempty" json:"id,omitempty"`
+	SessionID string `bson:"sessionId,omitempty" json:"sessionId,omitempty"`
+}
+
+// AuditEvent records a privileged action with the state it changed. Before and After hold
+// whatever the action changed, e.g. the door before and after an edit.
+type AuditEvent struct {
+	EventID   string      `bson:"eventId" json:"eventId"`
+	Action    AuditAction `bson:"action" json:"action"`
+	Actor     AuditActor  `bson:"actor" json:"actor"`
+	Target    AuditTarget `bson:"target" json:"target"`
+	Before    interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After     interface{} `bson:"after,omitempty" json:"after,omitempty"`
+	RequestID string      `bson:"requestId,omitempty" json:"requestId,omitempty"`
+	CreatedAt time.Time   `bson:"createdAt" json:"createdAt"`
+}
+
+// AuditEventFilter narrows audit log listings; empty fields match every event
+type AuditEventFilter struct {
+	ActorID  string      `json:"actor,omitempty"`
+	Action   AuditAction `json:"action,omitempty"`
+	Since    *time.Time  `json:"since,omitempty"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"pageSize"`
+}
+
+// AuditEventPage is one page of audit events with the total match count
+type AuditEventPage struct {
+	Events   []*AuditEvent `json:"events"`
+	Total    int64         `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"pageSize"`
+}
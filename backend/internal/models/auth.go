@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PlayerClaims are the verified identity claims carried by a player access token
+type PlayerClaims struct {
+	PlayerID  string `json:"sub"`
+	Username  string `json:"name"`
+	Issuer    string `json:"iss"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// AuthToken is a signed access token issued to a player
+type AuthToken struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"tokenType"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	PlayerID  string    `json:"playerId"`
+	Username  string    `json:"username"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ChatMessage is one message in a session's player chat
+type ChatMessage struct {
+	MessageID string    `json:"messageId"`
+	SessionID string    `json:"sessionId"`
+	PlayerID  string    `json:"playerId"`
+	Username  string    `json:"username"`
+	Text      string    `json:"text"`
+	SentAt    time.Time `json:"sentAt"`
+}
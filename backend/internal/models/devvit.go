@@ -10,8 +10,8 @@ type RedditUser struct {
 
 // PostContext represents the Devvit post context
 type PostContext struct {
-	PostID        string `json:"postId"`
-	SubredditName string `json:"subredditName"`
+	PostID        string `bson:"postId" json:"postId"`
+	SubredditName string `bson:"subredditName" json:"subredditName"`
 }
 
 // GameState represents the current state stored in Devvit
@@ -32,4 +32,26 @@ type InitResponse struct {
 	PostID   string `json:"postId"`
 	Username string `json:"username"`
 	GameData *GameState `json:"gameData,omitempty"`
-}
\ No newline at end of file
+}
+
+// PostUpdate is sent to Devvit when a game started from a Reddit post finishes, so the post
+// can show the final rankings and the winning response
+type PostUpdate struct {
+	PostID          string             `bson:"postId" json:"postId"`
+	SubredditName   string             `bson:"subredditName,omitempty" json:"subredditName,omitempty"`
+	SessionID       string             `bson:"sessionId" json:"sessionId"`
+	WinnerID        string             `bson:"winnerId,omitempty" json:"winnerId,omitempty"`
+	WinnerUsername  string             `bson:"winnerUsername,omitempty" json:"winnerUsername,omitempty"`
+	WinningResponse *ResponseHighlight `bson:"winningResponse,omitempty" json:"winningResponse,omitempty"` // the winner's best answer; its text is left out when the session keeps answers private
+	FinalRankings   []PlayerRanking    `bson:"finalRankings" json:"finalRankings"`
+	CompletedAt     *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+}
+
+// PostUpdateDeadLetter records a post update Devvit never accepted, so it can be inspected
+// and replayed by hand
+type PostUpdateDeadLetter struct {
+	ID       string     `bson:"_id" json:"id"`
+	Update   PostUpdate `bson:"update" json:"update"`
+	Error    string     `bson:"error" json:"error"`
+	FailedAt time.Time  `bson:"failedAt" json:"failedAt"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "fmt"
+
+// DoorPool identifies one pool of pre-generated doors in the door bank
+type DoorPool struct {
+	Theme      string `json:"theme"`
+	Difficulty int    `json:"difficulty"`
+	Locale     string `json:"locale"`
+}
+
+// String names the pool as theme:difficulty:locale
+func (p DoorPool) String() string {
+	return fmt.Sprintf("%s:%d:%s", p.Theme, p.Difficulty, p.Locale)
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// MaxDoorScores is how many of a door's most recent scores are kept for calibration
+const MaxDoorScores = 500
+
+// DoorScores holds the scores a door's responses have received, from which its
+// empirical difficulty is calibrated
+type DoorScores struct {
+	DoorID    string    `bson:"doorId" json:"doorId"`
+	Scores    []int     `bson:"scores" json:"scores"` // the most recent MaxDoorScores scores
+	Count     int       `bson:"count" json:"count"`   // every score ever recorded
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
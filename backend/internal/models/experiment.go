@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ExperimentUnit selects what an experiment buckets into its variants
+type ExperimentUnit string
+
+const (
+	ExperimentUnitSession ExperimentUnit = "session" // every session is bucketed on its own
+	ExperimentUnitPlayer  ExperimentUnit = "player"  // sessions are bucketed by their creator, who sees the same variant every time
+)
+
+// Experiment splits sessions between variants that tune the game differently
+type Experiment struct {
+	ID       string              `json:"id"`
+	Unit     ExperimentUnit      `json:"unit"`
+	Variants []ExperimentVariant `json:"variants"`
+}
+
+// ExperimentVariant is one arm of an experiment. Weight is its share of the traffic relative to
+// the other variants.
+type ExperimentVariant struct {
+	Name   string     `json:"name"`
+	Weight int        `json:"weight"`
+	Tuning GameTuning `json:"tuning"`
+}
+
+// GameTuning holds the game parameters an experiment variant may change; zero values keep the
+// game's defaults
+type GameTuning struct {
+	ResponseTimeLimit time.Duration `json:"responseTimeLimit,omitempty"`
+	ShortcutThreshold int           `json:"shortcutThreshold,omitempty"` // scores above this shorten a player's path
+}
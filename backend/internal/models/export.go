@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// ExportFormat selects how exported records are encoded
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson" // one JSON object per line
+)
+
+// ExportFilter narrows a data export. After is the cursor of the last record already received,
+// so an interrupted export can be resumed where it stopped.
+type ExportFilter struct {
+	Format ExportFormat `json:"format"`
+	Since  *time.Time   `json:"since,omitempty"`
+	After  string       `json:"after,omitempty"`
+	Status GameStatus   `json:"status,omitempty"` // sessions only
+}
+
+// SessionExport is the exported summary of a game session. Response text is left out; only
+// the scores are exported.
+type SessionExport struct {
+	Cursor      string                `json:"cursor"`
+	SessionID   string                `json:"sessionId"`
+	Mode        GameMode              `json:"mode"`
+	Theme       string                `json:"theme,omitempty"`
+	Locale      string                `json:"locale,omitempty"`
+	Status      GameStatus            `json:"status"`
+	WinnerID    string                `json:"winnerId,omitempty"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	StartedAt   *time.Time            `json:"startedAt,omitempty"`
+	CompletedAt *time.Time            `json:"completedAt,omitempty"`
+	Experiments map[string]string     `json:"experiments,omitempty"`
+	Players     []SessionExportPlayer `json:"players"`
+}
+
+// SessionExportPlayer is how one player did in an exported session
+type SessionExportPlayer struct {
+	PlayerID       string  `json:"playerId"`
+	Username       string  `json:"username"`
+	IsBot          bool    `json:"isBot,omitempty"`
+	TotalScore     int     `json:"totalScore"`
+	DoorsCompleted int     `json:"doorsCompleted"`
+	AverageScore   float64 `json:"averageScore"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Friendship records that a player has added another player to their friend list
+type Friendship struct {
+	PlayerID  string    `bson:"playerId" json:"playerId"`
+	FriendID  string    `bson:"friendId" json:"friendId"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"dumdoors-backend/internal/i18n"
+	"math"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,27 +16,218 @@ const (
 	GameModeSinglePlayer GameMode = "single-player"
 )
 
+// MaxMultiplayerPlayers is how many players a multiplayer session holds
+const MaxMultiplayerPlayers = 8
+
 // GameStatus represents the current state of a game session
 type GameStatus string
 
 const (
-	GameStatusWaiting   GameStatus = "waiting"
-	GameStatusActive    GameStatus = "active"
-	GameStatusCompleted GameStatus = "completed"
+	GameStatusWaiting    GameStatus = "waiting"
+	GameStatusStarting   GameStatus = "starting" // ready countdown running or the first door being set up
+	GameStatusActive     GameStatus = "active"
+	GameStatusScoring    GameStatus = "scoring"
+	GameStatusRevealing  GameStatus = "revealing" // between doors: scores are shown before the next door
+	GameStatusPaused     GameStatus = "paused"
+	GameStatusCompleting GameStatus = "completing" // game over while results are recorded
+	GameStatusCompleted  GameStatus = "completed"
+	GameStatusAbandoned  GameStatus = "abandoned"
+)
+
+// StatusChange records when a session moved from one state to another
+type StatusChange struct {
+	From GameStatus `bson:"from" json:"from"`
+	To   GameStatus `bson:"to" json:"to"`
+	At   time.Time  `bson:"at" json:"at"`
+}
+
+// ScoringMode selects how responses are scored
+type ScoringMode string
+
+const (
+	ScoringModeAI       ScoringMode = "ai"
+	ScoringModePeerVote ScoringMode = "peer-vote"
+)
+
+// ScoringStrategy names how a response's AI metrics are combined into its score
+type ScoringStrategy string
+
+const (
+	ScoringStrategyAverage       ScoringStrategy = "average"
+	ScoringStrategyWeighted      ScoringStrategy = "weighted"
+	ScoringStrategyMaxMetric     ScoringStrategy = "max-metric"
+	ScoringStrategyThemeWeighted ScoringStrategy = "theme-weighted"
+	ScoringStrategyRubric        ScoringStrategy = "rubric"
 )
 
+// RevealMode selects how a round's responses are shown to the players once they are scored
+type RevealMode string
+
+const (
+	RevealAnonymous  RevealMode = "anonymous"
+	RevealAttributed RevealMode = "attributed"
+	RevealOff        RevealMode = "off"
+)
+
+// MatchFormat selects how a session's doors are chosen and how its winner is decided
+type MatchFormat string
+
+const (
+	MatchFormatPath   MatchFormat = "path"    // each player follows their own path; the first to its end wins
+	MatchFormatBestOf MatchFormat = "best-of" // everyone answers the same fixed doors; the highest total wins
+)
+
+// SessionSettings holds the options chosen when a session is created
+type SessionSettings struct {
+	ScoringMode     ScoringMode     `bson:"scoringMode,omitempty" json:"scoringMode,omitempty"`         // empty means AI scoring
+	ReadyPercent    int             `bson:"readyPercent,omitempty" json:"readyPercent,omitempty"`       // share of players that must be ready to start; 0 skips the ready check
+	IsPrivate       bool            `bson:"isPrivate,omitempty" json:"isPrivate,omitempty"`             // joined only by code, never listed
+	Password        string          `bson:"-" json:"-"`                                                 // plaintext join password, hashed into the session on creation
+	BotOpponents    int             `bson:"botOpponents,omitempty" json:"botOpponents,omitempty"`       // AI opponents added to a single-player session
+	RevealMode      RevealMode      `bson:"revealMode,omitempty" json:"revealMode,omitempty"`           // empty means anonymous reveal
+	ScoringStrategy ScoringStrategy `bson:"scoringStrategy,omitempty" json:"scoringStrategy,omitempty"` // empty means the server's default strategy
+	Post            *PostContext    `bson:"post,omitempty" json:"post,omitempty"`                       // the Reddit post the session was started from, updated when the game ends
+	Format          MatchFormat     `bson:"format,omitempty" json:"format,omitempty"`                   // empty means the path format
+	Rounds          int             `bson:"rounds,omitempty" json:"rounds,omitempty"`                   // doors in a best-of match
+	TeamSize        int             `bson:"teamSize,omitempty" json:"teamSize,omitempty"`               // players on each of the two teams; 0 means everyone plays for themselves
+	MaxLength       int             `bson:"maxLength,omitempty" json:"maxLength,omitempty"`             // the session's character limit of responses; 0 means the standard 500
+	Profanity       ProfanityFilter `bson:"profanity,omitempty" json:"profanity,omitempty"`             // empty means the server's moderation setting
+}
+
+// PeerVoting reports whether responses are scored by the other players' votes
+func (s SessionSettings) PeerVoting() bool {
+	return s.ScoringMode == ScoringModePeerVote
+}
+
+// BestOf reports whether the session is a best-of match of fixed doors
+func (s SessionSettings) BestOf() bool {
+	return s.Format == MatchFormatBestOf
+}
+
+// TeamPlay reports whether the players are split into two teams that win or lose together
+func (s SessionSettings) TeamPlay() bool {
+	return s.TeamSize > 0
+}
+
+// RevealsResponses reports whether a round's responses are shown to the players after scoring
+func (s SessionSettings) RevealsResponses() bool {
+	return s.RevealMode != RevealOff
+}
+
 // GameSession represents a game session in the database
 type GameSession struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	SessionID   string             `bson:"sessionId" json:"sessionId"`
-	Mode        GameMode           `bson:"mode" json:"mode"`
-	Theme       *string            `bson:"theme,omitempty" json:"theme,omitempty"`
-	Players     []PlayerInfo       `bson:"players" json:"players"`
-	Status      GameStatus         `bson:"status" json:"status"`
-	CurrentDoor *Door              `bson:"currentDoor,omitempty" json:"currentDoor,omitempty"`
-	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
-	StartedAt   *time.Time         `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
-	CompletedAt *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SessionID     string             `bson:"sessionId" json:"sessionId"`
+	Mode          GameMode           `bson:"mode" json:"mode"`
+	Theme         *string            `bson:"theme,omitempty" json:"theme,omitempty"`
+	Locale        string             `bson:"locale,omitempty" json:"locale,omitempty"`
+	HostID        string             `bson:"hostId,omitempty" json:"hostId,omitempty"` // moderates the session; the creator until they hand it on
+	Locked        bool               `bson:"locked,omitempty" json:"locked,omitempty"` // set by the host to refuse new joins
+	JoinCode      string             `bson:"joinCode,omitempty" json:"joinCode,omitempty"` // short code players share to find the session
+	PasswordHash  string             `bson:"passwordHash,omitempty" json:"-"`
+	Players       []PlayerInfo       `bson:"players" json:"players"`
+	KickedPlayers []string           `bson:"kickedPlayers,omitempty" json:"kickedPlayers,omitempty"` // removed by the host and not allowed back
+	Spectators    []SpectatorInfo    `bson:"spectators,omitempty" json:"spectators,omitempty"`
+	Status        GameStatus         `bson:"status" json:"status"`
+	CurrentDoor   *Door              `bson:"currentDoor,omitempty" json:"currentDoor,omitempty"`
+	RoundDeadline *time.Time         `bson:"roundDeadline,omitempty" json:"roundDeadline,omitempty"`
+	RoundOpenedAt *time.Time         `bson:"roundOpenedAt,omitempty" json:"roundOpenedAt,omitempty"` // when the open round's doors were presented
+	RoundLimit    time.Duration      `bson:"roundLimit,omitempty" json:"roundLimit,omitempty"`       // the open round's standard time to answer, stretched for players with extra time
+	Settings      SessionSettings    `bson:"settings" json:"settings"`
+	VoteDeadline  *time.Time         `bson:"voteDeadline,omitempty" json:"voteDeadline,omitempty"` // set while players vote on a round
+	StartsAt      *time.Time         `bson:"startsAt,omitempty" json:"startsAt,omitempty"`         // set while the ready countdown runs
+	PausedAt      *time.Time         `bson:"pausedAt,omitempty" json:"pausedAt,omitempty"`         // set while the host has paused the game
+	WinnerID      string             `bson:"winnerId,omitempty" json:"winnerId,omitempty"`
+	WinnerTeamID  string             `bson:"winnerTeamId,omitempty" json:"winnerTeamId,omitempty"` // the winning team of a team session
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+	StartedAt     *time.Time         `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
+	CompletedAt   *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	AbandonedAt   *time.Time         `bson:"abandonedAt,omitempty" json:"abandonedAt,omitempty"`
+	LastActiveAt  time.Time          `bson:"lastActiveAt,omitempty" json:"lastActiveAt,omitempty"` // stamped on every write
+	StatusHistory []StatusChange     `bson:"statusHistory,omitempty" json:"statusHistory,omitempty"`
+	Experiments   map[string]string  `bson:"experiments,omitempty" json:"experiments,omitempty"` // variant of each running experiment, by experiment ID
+	MatchDoors    []*Door            `bson:"matchDoors,omitempty" json:"-"`                       // a best-of match's doors in play order, hidden so players can't read ahead
+	Version       int64              `bson:"version,omitempty" json:"-"`                          // bumped by every write, so whole-session updates can detect concurrent ones
+	
+	loaded *loadedState // as last read or written, see MarkLoaded
+}
+
+// MatchRound returns the 1-based number of the best-of round being played, or 0 if the
+// session is not playing one of its match doors
+func (s *GameSession) MatchRound() int {
+	if s.CurrentDoor == nil {
+		return 0
+	}
+	for i, door := range s.MatchDoors {
+		if door.DoorID == s.CurrentDoor.DoorID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LastActivity returns when the session was last written, falling back to its creation time
+func (s *GameSession) LastActivity() time.Time {
+	if s.LastActiveAt.IsZero() {
+		return s.CreatedAt
+	}
+	return s.LastActiveAt
+}
+
+// TimeRemaining returns how long players have left to answer the open round. The clock is
+// frozen while the game is paused.
+func (s *GameSession) TimeRemaining(now time.Time) time.Duration {
+	if s.RoundDeadline == nil {
+		return 0
+	}
+	if s.PausedAt != nil {
+		now = *s.PausedAt
+	}
+	if remaining := s.RoundDeadline.Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Host returns the player who moderates the session. Sessions created before hosts were
+// recorded are moderated by their first player.
+func (s *GameSession) Host() string {
+	if s.HostID != "" {
+		return s.HostID
+	}
+	if len(s.Players) > 0 {
+		return s.Players[0].PlayerID
+	}
+	return ""
+}
+
+// PasswordProtected reports whether joining the session requires a password
+func (s *GameSession) PasswordProtected() bool {
+	return s.PasswordHash != ""
+}
+
+// WasKicked reports whether the host removed the player from the session
+func (s *GameSession) WasKicked(playerID string) bool {
+	for _, kicked := range s.KickedPlayers {
+		if kicked == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenSession summarizes a public multiplayer session that is waiting for players
+type OpenSession struct {
+	SessionID    string      `json:"sessionId"`
+	JoinCode     string      `json:"joinCode,omitempty"`
+	Theme        *string     `json:"theme,omitempty"`
+	Locale       string      `json:"locale,omitempty"`
+	ScoringMode  ScoringMode `json:"scoringMode,omitempty"`
+	HostUsername string      `json:"hostUsername,omitempty"`
+	PlayerCount  int         `json:"playerCount"`
+	MaxPlayers   int         `json:"maxPlayers"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	AgeSeconds   int         `json:"ageSeconds"` // how long ago the session was created, as of the response
 }
 
 // PlayerInfo represents a player within a game session
@@ -47,6 +240,90 @@ type PlayerInfo struct {
 	TotalScore      int              `bson:"totalScore" json:"totalScore"`
 	Responses       []PlayerResponse `bson:"responses" json:"responses"`
 	IsActive        bool             `bson:"isActive" json:"isActive"`
+	IsReady         bool             `bson:"isReady,omitempty" json:"isReady,omitempty"`         // set by the ready check before the game starts
+	CurrentDoor     *Door            `bson:"currentDoor,omitempty" json:"currentDoor,omitempty"` // set when players are on divergent paths
+	IsBot           bool             `bson:"isBot,omitempty" json:"isBot,omitempty"`             // an AI opponent whose responses are generated
+	TeamID          string           `bson:"teamId,omitempty" json:"teamId,omitempty"`           // the player's team in team sessions
+	Options         *PlayerOptions   `bson:"options,omitempty" json:"options,omitempty"`         // handicap and accessibility options chosen in the lobby
+	DisconnectedAt  *time.Time       `bson:"disconnectedAt,omitempty" json:"disconnectedAt,omitempty"` // when the player's connection dropped; nil while connected
+	TimedOut        bool             `bson:"timedOut,omitempty" json:"timedOut,omitempty"`       // stayed away past the disconnect grace; rounds don't wait for them until they reconnect
+}
+
+// AwaitedInRound reports whether rounds wait for the player's response: they are still playing
+// and haven't stayed disconnected past the grace period
+func (p PlayerInfo) AwaitedInRound() bool {
+	return p.IsActive && !p.TimedOut
+}
+
+// ReadyCount returns how many of the session's active players are ready and how many there are
+func (s *GameSession) ReadyCount() (ready, total int) {
+	for _, player := range s.Players {
+		if !player.IsActive {
+			continue
+		}
+		total++
+		if player.IsReady {
+			ready++
+		}
+	}
+	return ready, total
+}
+
+// ReadyThresholdMet reports whether enough players are ready to start. Sessions without a
+// ready check are always ready.
+func (s *GameSession) ReadyThresholdMet() bool {
+	if s.Settings.ReadyPercent <= 0 {
+		return true
+	}
+	ready, total := s.ReadyCount()
+	return total > 0 && ready*100 >= s.Settings.ReadyPercent*total
+}
+
+// DoorForPlayer returns the door the player is currently answering: their own door when
+// players are on divergent paths, otherwise the session's shared door
+func (s *GameSession) DoorForPlayer(playerID string) *Door {
+	for _, player := range s.Players {
+		if player.PlayerID == playerID && player.CurrentDoor != nil {
+			return player.CurrentDoor
+		}
+	}
+	return s.CurrentDoor
+}
+
+// CurrentDoors returns every door open in the current round, without duplicates
+func (s *GameSession) CurrentDoors() []*Door {
+	var doors []*Door
+	seen := make(map[string]bool)
+
+	add := func(door *Door) {
+		if door != nil && !seen[door.DoorID] {
+			seen[door.DoorID] = true
+			doors = append(doors, door)
+		}
+	}
+
+	add(s.CurrentDoor)
+	for _, player := range s.Players {
+		add(player.CurrentDoor)
+	}
+	return doors
+}
+
+// IsCurrentDoor reports whether the door is open in the current round for any player
+func (s *GameSession) IsCurrentDoor(doorID string) bool {
+	for _, door := range s.CurrentDoors() {
+		if door.DoorID == doorID {
+			return true
+		}
+	}
+	return false
+}
+
+// SpectatorInfo represents a read-only viewer of a game session
+type SpectatorInfo struct {
+	SpectatorID string    `bson:"spectatorId" json:"spectatorId"`
+	Username    string    `bson:"username" json:"username"`
+	JoinedAt    time.Time `bson:"joinedAt" json:"joinedAt"`
 }
 
 // Door represents a game scenario/situation
@@ -56,8 +333,100 @@ type Door struct {
 	Content               string             `bson:"content" json:"content"`
 	Theme                 string             `bson:"theme" json:"theme"`
 	Difficulty            int                `bson:"difficulty" json:"difficulty"`
+	Locale                string             `bson:"locale,omitempty" json:"locale,omitempty"`
+	Translations          map[string]string  `bson:"translations,omitempty" json:"translations,omitempty"` // content keyed by locale, for locales other than the door's own
 	ExpectedSolutionTypes []string           `bson:"expectedSolutionTypes" json:"expectedSolutionTypes"`
+	Accessibility         *DoorAccessibility `bson:"accessibility,omitempty" json:"accessibility,omitempty"`
+	Status                DoorStatus         `bson:"status,omitempty" json:"status,omitempty"`
+	EmpiricalDifficulty   float64            `bson:"empiricalDifficulty,omitempty" json:"empiricalDifficulty,omitempty"` // calibrated from the scores the door has received
+	ResponseCount         int                `bson:"responseCount,omitempty" json:"responseCount,omitempty"`             // scores seen at the last calibration
+	CalibratedAt          *time.Time         `bson:"calibratedAt,omitempty" json:"calibratedAt,omitempty"`
 	CreatedAt             time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt             *time.Time         `bson:"updatedAt,omitempty" json:"updatedAt,omitempty"`
+}
+
+// DoorStatus represents where a door is in the moderation workflow
+type DoorStatus string
+
+const (
+	DoorStatusDraft     DoorStatus = "draft"
+	DoorStatusApproved  DoorStatus = "approved"
+	DoorStatusPublished DoorStatus = "published"
+)
+
+// EffectiveDifficulty is the door's calibrated difficulty once it has one, otherwise its authored difficulty
+func (d *Door) EffectiveDifficulty() int {
+	if d.EmpiricalDifficulty > 0 {
+		return int(math.Round(d.EmpiricalDifficulty))
+	}
+	return d.Difficulty
+}
+
+// InLocale returns the door as players of the locale should see it: the door itself when it is
+// written in that locale, otherwise a copy carrying its translation. ok is false when the door has neither.
+func (d *Door) InLocale(locale string) (door *Door, ok bool) {
+	if i18n.Normalize(d.Locale) == locale {
+		return d, true
+	}
+	content, exists := d.Translations[locale]
+	if !exists {
+		return nil, false
+	}
+
+	translated := *d
+	translated.Content = content
+	translated.Locale = locale
+	translated.Translations = nil
+	translated.Accessibility = nil
+	return &translated, true
+}
+
+// Servable reports whether the door has been approved for play
+func (d *Door) Servable() bool {
+	return d.Status == DoorStatusApproved || d.Status == DoorStatusPublished
+}
+
+// CanTransitionDoor reports whether moderation may move a door between statuses. Doors move
+// forward one step at a time and can always be sent back to draft. Doors stored before
+// moderation existed count as drafts.
+func CanTransitionDoor(from, to DoorStatus) bool {
+	if from == "" {
+		from = DoorStatusDraft
+	}
+	
+	switch to {
+	case DoorStatusDraft:
+		return true
+	case DoorStatusApproved:
+		return from == DoorStatusDraft || from == DoorStatusApproved
+	case DoorStatusPublished:
+		return from == DoorStatusApproved || from == DoorStatusPublished
+	}
+	return false
+}
+
+// DoorFilter narrows door listings; empty fields match every door
+type DoorFilter struct {
+	Theme      string     `json:"theme,omitempty"`
+	Status     DoorStatus `json:"status,omitempty"`
+	Difficulty int        `json:"difficulty,omitempty"`
+	Page       int        `json:"page"`
+	PageSize   int        `json:"pageSize"`
+}
+
+// DoorPage is one page of a door listing
+type DoorPage struct {
+	Doors    []*Door `json:"doors"`
+	Total    int64   `json:"total"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"pageSize"`
+}
+
+// DoorAccessibility describes a door for assistive technology and content filtering
+type DoorAccessibility struct {
+	AltText         string   `bson:"altText" json:"altText"`
+	ReadingLevel    float64  `bson:"readingLevel,omitempty" json:"readingLevel,omitempty"` // Flesch-Kincaid grade, English doors only
+	ContentWarnings []string `bson:"contentWarnings" json:"contentWarnings"`
 }
 
 // PlayerResponse represents a player's response to a door
@@ -69,6 +438,48 @@ type PlayerResponse struct {
 	AIScore         int             `bson:"aiScore" json:"aiScore"`
 	SubmittedAt     time.Time       `bson:"submittedAt" json:"submittedAt"`
 	ScoringMetrics  ScoringMetrics  `bson:"scoringMetrics" json:"scoringMetrics"`
+	Feedback        string          `bson:"feedback,omitempty" json:"feedback,omitempty"` // AI explanation of the score, only sent to the author (see GameSession.ForPlayer)
+	Votes           []ResponseVote  `bson:"votes,omitempty" json:"votes,omitempty"`
+	ScoringPending  bool            `bson:"scoringPending,omitempty" json:"scoringPending,omitempty"` // AI scores not delivered yet
+	ScoringStrategy ScoringStrategy `bson:"scoringStrategy,omitempty" json:"scoringStrategy,omitempty"` // how the metrics were combined into AIScore
+	CheatSuspicion  *CheatSuspicion `bson:"cheatSuspicion,omitempty" json:"cheatSuspicion,omitempty"`   // set when the response nearly repeats an earlier one
+	CharacterLimit  int             `bson:"characterLimit,omitempty" json:"characterLimit,omitempty"`   // the limit the response was written under
+	Forfeited       bool            `bson:"forfeited,omitempty" json:"forfeited,omitempty"`             // submitted for a player who stayed disconnected past the grace period
+}
+
+// ResponseVote is one player's star rating of another player's response in peer-vote sessions
+type ResponseVote struct {
+	VoterID string    `bson:"voterId" json:"voterId"`
+	Stars   int       `bson:"stars" json:"stars"`
+	CastAt  time.Time `bson:"castAt" json:"castAt"`
+}
+
+// ResponseDraft is a player's unsubmitted answer to the current door, autosaved while typing
+type ResponseDraft struct {
+	SessionID string    `json:"sessionId"`
+	DoorID    string    `json:"doorId"`
+	PlayerID  string    `json:"playerId"`
+	Content   string    `json:"content"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Limit     int       `json:"limit,omitempty"` // the player's character limit
+}
+
+// DoorDeadline is the moment a session's current door stops accepting responses
+type DoorDeadline struct {
+	SessionID string    `json:"sessionId"`
+	DoorID    string    `json:"doorId"`
+	At        time.Time `json:"at"`
+}
+
+// RoundSnapshot is the transient state of a session's round in play, saved periodically so
+// another instance can rebuild it if the instance running the round dies
+type RoundSnapshot struct {
+	SessionID   string               `json:"sessionId"`
+	InstanceID  string               `json:"instanceId"`
+	Status      GameStatus           `json:"status"`      // the round's stage: answering, scoring or revealing
+	PlayerDoors map[string]string    `json:"playerDoors"` // player ID -> door they are answering
+	Deadlines   map[string]time.Time `json:"deadlines"`   // scheduler key -> when it falls due
+	TakenAt     time.Time            `json:"takenAt"`
 }
 
 // ScoringMetrics represents the detailed scoring breakdown
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// IdempotentResponse is the stored outcome of a request made with an Idempotency-Key. A pending
+// record marks a request that is still executing.
+type IdempotentResponse struct {
+	Pending     bool      `json:"pending"`
+	RequestHash string    `json:"requestHash"`
+	StatusCode  int       `json:"statusCode,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
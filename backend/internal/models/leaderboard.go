@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,6 +24,9 @@ type LeaderboardEntry struct {
 	SessionID        string             `bson:"sessionId" json:"sessionId"`
 	CompletedAt      time.Time          `bson:"completedAt" json:"completedAt"`
 	CreatedAt        time.Time          `bson:"createdAt" json:"createdAt"`
+	Experiments      map[string]string  `bson:"experiments,omitempty" json:"experiments,omitempty"` // the session's experiment variants, for offline analysis
+	Format           MatchFormat        `bson:"format,omitempty" json:"format,omitempty"`           // best-of entries are ranked apart from path games
+	Shadowed         bool               `bson:"shadowed,omitempty" json:"-"`                         // hidden from leaderboards while the player is flagged for scripted play
 }
 
 // GlobalLeaderboard represents different leaderboard categories
@@ -29,6 +35,7 @@ type GlobalLeaderboard struct {
 	HighestAverages    []LeaderboardEntry `json:"highestAverages"`
 	MostCompleted      []LeaderboardEntry `json:"mostCompleted"`
 	RecentWinners      []LeaderboardEntry `json:"recentWinners"`
+	BestOfTotals       []LeaderboardEntry `json:"bestOfTotals"` // highest totals in best-of matches, which the other categories leave out
 }
 
 // LeaderboardStats represents aggregated statistics for leaderboards
@@ -46,5 +53,62 @@ type LeaderboardFilter struct {
 	GameMode  *GameMode `json:"gameMode,omitempty"`
 	Theme     *string   `json:"theme,omitempty"`
 	TimeRange *string   `json:"timeRange,omitempty"` // "day", "week", "month", "all"
+	Timezone  *string   `json:"timezone,omitempty"`  // IANA name or UTC offset; makes time ranges calendar-based
+	FriendsOf *string   `json:"friendsOf,omitempty"` // restricts entries to this player and their friends
+	PlayerIDs []string  `json:"-"`                   // players FriendsOf resolves to; nil means everyone
 	Limit     int       `json:"limit"`
+}
+
+// ParseTimezone resolves an IANA zone name ("America/Sao_Paulo") or a UTC offset
+// ("+05:30", "-0800", "UTC+2", "GMT-3") to a location
+func ParseTimezone(value string) (*time.Location, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("timezone is empty")
+	}
+	
+	offset := value
+	for _, prefix := range []string{"UTC", "GMT"} {
+		if strings.HasPrefix(strings.ToUpper(offset), prefix) {
+			offset = offset[len(prefix):]
+			break
+		}
+	}
+	if offset == "" {
+		return time.UTC, nil
+	}
+	
+	if offset[0] == '+' || offset[0] == '-' {
+		sign := 1
+		if offset[0] == '-' {
+			sign = -1
+		}
+		
+		digits := strings.Replace(offset[1:], ":", "", 1)
+		var hours, minutes int
+		var err error
+		switch len(digits) {
+		case 1, 2:
+			hours, err = strconv.Atoi(digits)
+		case 4:
+			hours, err = strconv.Atoi(digits[:2])
+			if err == nil {
+				minutes, err = strconv.Atoi(digits[2:])
+			}
+		default:
+			err = fmt.Errorf("unrecognized offset")
+		}
+		if err != nil || hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid UTC offset %q", value)
+		}
+		
+		seconds := sign * (hours*3600 + minutes*60)
+		return time.FixedZone(value, seconds), nil
+	}
+	
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", value, err)
+	}
+	return location, nil
 }
\ No newline at end of file
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Skill buckets group players of similar ability; they match door difficulty levels
+const (
+	MinSkillBucket = 1
+	MaxSkillBucket = 3
+)
+
+// MatchmakingTicket is a player's place in the matchmaking queue
+type MatchmakingTicket struct {
+	PlayerID    string     `json:"playerId"`
+	Username    string     `json:"username"`
+	Theme       string     `json:"theme"`
+	SkillBucket int        `json:"skillBucket"`
+	EnqueuedAt  time.Time  `json:"enqueuedAt"`
+	SessionID   string     `json:"sessionId,omitempty"`
+	MatchedAt   *time.Time `json:"matchedAt,omitempty"`
+}
+
+// Pool returns the key of the queue the ticket waits in; only players in the same pool are matched
+func (t *MatchmakingTicket) Pool() string {
+	return fmt.Sprintf("%s:%d", t.Theme, t.SkillBucket)
+}
+
+// Matched reports whether the ticket has been placed in a session
+func (t *MatchmakingTicket) Matched() bool {
+	return t.SessionID != ""
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// ModerationAction is what moderation decided to do with a piece of player text
+type ModerationAction string
+
+const (
+	ModerationAllow  ModerationAction = "allow"
+	ModerationMask   ModerationAction = "mask"
+	ModerationReject ModerationAction = "reject"
+)
+
+// ProfanityFilter sets how a session treats denylisted words in player text. Text refused
+// by reject patterns or the AI check is refused whatever the filter.
+type ProfanityFilter string
+
+const (
+	ProfanityMask   ProfanityFilter = "mask"   // denylisted words are starred out; the server default
+	ProfanityReject ProfanityFilter = "reject" // text with denylisted words is refused
+	ProfanityAllow  ProfanityFilter = "allow"  // denylisted words are kept as written
+)
+
+// ModerationResult is the outcome of screening player text; Text is what may be used in play
+type ModerationResult struct {
+	Action  ModerationAction `json:"action"`
+	Text    string           `json:"text"`
+	Reasons []string         `json:"reasons,omitempty"`
+}
+
+// ModerationSubject identifies where moderated text was submitted
+type ModerationSubject struct {
+	SessionID string `bson:"sessionId" json:"sessionId"`
+	PlayerID  string `bson:"playerId" json:"playerId"`
+	DoorID    string `bson:"doorId,omitempty" json:"doorId,omitempty"`
+}
+
+// ModerationEvent records masked or rejected text for admin review
+type ModerationEvent struct {
+	EventID   string            `bson:"eventId" json:"eventId"`
+	Subject   ModerationSubject `bson:"subject" json:"subject"`
+	Original  string            `bson:"original" json:"original"`
+	Result    string            `bson:"result,omitempty" json:"result,omitempty"`
+	Action    ModerationAction  `bson:"action" json:"action"`
+	Reasons   []string          `bson:"reasons" json:"reasons"`
+	CreatedAt time.Time         `bson:"createdAt" json:"createdAt"`
+}
+
+// ModerationEventFilter narrows moderation event listings; empty fields match every event
+type ModerationEventFilter struct {
+	PlayerID string           `json:"playerId,omitempty"`
+	Action   ModerationAction `json:"action,omitempty"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"pageSize"`
+}
+
+// ModerationEventPage is one page of moderation events with the total match count
+type ModerationEventPage struct {
+	Events   []*ModerationEvent `json:"events"`
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"pageSize"`
+}
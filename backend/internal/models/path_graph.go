@@ -0,0 +1,61 @@
+package models
+
+// PathEdge is a LEADS_TO relationship in a door graph. A player at From moves to To when their
+// latest score reaches ScoreThreshold; the highest threshold reached wins.
+type PathEdge struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	ScoreThreshold int    `json:"scoreThreshold"`
+}
+
+// PathGraph is the door graph for one theme. Players enter at EntryID and walk the long path
+// one door at a time, cutting across to the next door of the short path when they score well.
+type PathGraph struct {
+	Theme     string     `json:"theme"`
+	EntryID   string     `json:"entryId"`
+	LongPath  []string   `json:"longPath"`
+	ShortPath []string   `json:"shortPath"`
+	Edges     []PathEdge `json:"edges"`
+	Doors     []*Door    `json:"-"`
+	Signature string     `json:"signature"` // changes whenever the doors behind the graph change
+}
+
+// PathNodeState is where a node of a player's path map stands in their journey
+type PathNodeState string
+
+const (
+	PathNodeVisited  PathNodeState = "visited"
+	PathNodeCurrent  PathNodeState = "current"
+	PathNodeNext     PathNodeState = "next"     // one step from the player's position
+	PathNodeUpcoming PathNodeState = "upcoming" // further along the graph
+)
+
+// PathMapNode is the entry or a door of a player's path map
+type PathMapNode struct {
+	ID         string        `json:"id"`
+	Entry      bool          `json:"entry,omitempty"`
+	Content    string        `json:"content,omitempty"`
+	Difficulty int           `json:"difficulty,omitempty"`
+	Step       int           `json:"step"` // position along the long path; the entry is 0
+	ShortPath  bool          `json:"shortPath,omitempty"`
+	State      PathNodeState `json:"state"`
+	Score      *int          `json:"score,omitempty"` // the player's score on a visited door, once scored
+}
+
+// PathMapEdge is a branch of a player's path map. Edges out of the player's position are the
+// branches they can take next, chosen by their score.
+type PathMapEdge struct {
+	PathEdge
+	Shortcut  bool `json:"shortcut,omitempty"`
+	Traversed bool `json:"traversed,omitempty"`
+}
+
+// PathMap is a player's journey through their session's door graph, for drawing as a map
+type PathMap struct {
+	SessionID string        `json:"sessionId"`
+	PlayerID  string        `json:"playerId"`
+	Theme     string        `json:"theme"`
+	Position  string        `json:"position,omitempty"` // the node the player is at
+	Nodes     []PathMapNode `json:"nodes"`
+	Edges     []PathMapEdge `json:"edges"`
+}
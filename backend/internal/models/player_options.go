@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// PlayerOptions are a player's handicap and accessibility options for a session. They are
+// kept on the player so scoring and rankings can tell what conditions a response was written under.
+type PlayerOptions struct {
+	TimeMultiplier    float64 `bson:"timeMultiplier,omitempty" json:"timeMultiplier,omitempty"`       // stretches the player's response timer, e.g. 2 for twice the time; 0 means the normal timer
+	MaxResponseLength int     `bson:"maxResponseLength,omitempty" json:"maxResponseLength,omitempty"` // the player's character limit; 0 means the normal limit
+	TextOnly          bool    `bson:"textOnly,omitempty" json:"textOnly,omitempty"`                   // the player's client shows doors as plain text, without visuals
+}
+
+// ExtraTime returns how much longer than the round's time limit the player has to answer
+func (p PlayerInfo) ExtraTime(limit time.Duration) time.Duration {
+	if p.Options == nil || p.Options.TimeMultiplier <= 1 {
+		return 0
+	}
+	return time.Duration(float64(limit) * (p.Options.TimeMultiplier - 1))
+}
+
+// PlayerDeadline returns when the player's response window for the open round closes: the
+// round deadline plus any extra time the player has. It is nil when no round is open.
+func (s *GameSession) PlayerDeadline(playerID string) *time.Time {
+	if s.RoundDeadline == nil {
+		return nil
+	}
+	deadline := *s.RoundDeadline
+	for _, player := range s.Players {
+		if player.PlayerID == playerID {
+			deadline = deadline.Add(player.ExtraTime(s.RoundLimit))
+			break
+		}
+	}
+	return &deadline
+}
+
+// RoundClosesAt returns when the open round stops taking responses: the latest deadline of
+// its active players. It is nil when no round is open.
+func (s *GameSession) RoundClosesAt() *time.Time {
+	if s.RoundDeadline == nil {
+		return nil
+	}
+	var extra time.Duration
+	for _, player := range s.Players {
+		if player.IsActive && player.ExtraTime(s.RoundLimit) > extra {
+			extra = player.ExtraTime(s.RoundLimit)
+		}
+	}
+	closesAt := s.RoundDeadline.Add(extra)
+	return &closesAt
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PlayerProfile holds a player's career statistics across every completed game
+type PlayerProfile struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	PlayerID           string             `bson:"playerId" json:"playerId"`
+	Username           string             `bson:"username" json:"username"`
+	GamesPlayed        int                `bson:"gamesPlayed" json:"gamesPlayed"`
+	Wins               int                `bson:"wins" json:"wins"`
+	DoorsAnswered      int                `bson:"doorsAnswered" json:"doorsAnswered"`
+	TotalScore         int                `bson:"totalScore" json:"totalScore"`
+	BestScore          int                `bson:"bestScore" json:"bestScore"` // highest score for a single response
+	AverageScore       float64            `bson:"averageScore" json:"averageScore"`
+	ScoredResponses    int                `bson:"scoredResponses" json:"scoredResponses"` // responses with AI metrics behind the averages
+	MetricTotals       ScoringMetrics     `bson:"metricTotals" json:"-"`
+	AverageMetrics     MetricAverages     `bson:"averageMetrics" json:"averageMetrics"`
+	ThemeCounts        map[string]int     `bson:"themeCounts,omitempty" json:"themeCounts,omitempty"`
+	FavoriteThemes     []string           `bson:"favoriteThemes,omitempty" json:"favoriteThemes,omitempty"`
+	CurrentWinStreak   int                `bson:"currentWinStreak" json:"currentWinStreak"`
+	LongestWinStreak   int                `bson:"longestWinStreak" json:"longestWinStreak"`
+	CurrentDailyStreak int                `bson:"currentDailyStreak" json:"currentDailyStreak"` // consecutive days with a completed game
+	LongestDailyStreak int                `bson:"longestDailyStreak" json:"longestDailyStreak"`
+	RecentSessionIDs   []string           `bson:"recentSessionIds,omitempty" json:"-"` // guards against counting a game twice
+	LastPlayedAt       *time.Time         `bson:"lastPlayedAt,omitempty" json:"lastPlayedAt,omitempty"`
+	CreatedAt          time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt          time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// MetricAverages is the mean of each AI scoring metric over a player's responses
+type MetricAverages struct {
+	Creativity  float64 `bson:"creativity" json:"creativity"`
+	Feasibility float64 `bson:"feasibility" json:"feasibility"`
+	Humor       float64 `bson:"humor" json:"humor"`
+	Originality float64 `bson:"originality" json:"originality"`
+}
+
+// WinRate returns the share of the player's games they won
+func (p *PlayerProfile) WinRate() float64 {
+	if p.GamesPlayed == 0 {
+		return 0
+	}
+	return float64(p.Wins) / float64(p.GamesPlayed)
+}
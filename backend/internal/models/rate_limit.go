@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// RateLimitDecision is the outcome of taking a token from a rate limit bucket
+type RateLimitDecision struct {
+	Allowed    bool          `json:"allowed"`
+	Remaining  int           `json:"remaining"`
+	RetryAfter time.Duration `json:"retryAfter"`
+}
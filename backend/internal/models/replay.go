@@ -0,0 +1,25 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReplayEvent is one entry in a session's replay log, in the order it was broadcast
+type ReplayEvent struct {
+	SessionID string          `bson:"sessionId" json:"sessionId"`
+	Sequence  int64           `bson:"sequence" json:"sequence"`
+	Type      string          `bson:"type" json:"type"`
+	PlayerID  string          `bson:"playerId,omitempty" json:"playerId,omitempty"`
+	Data      json.RawMessage `bson:"data" json:"data"`
+	At        time.Time       `bson:"at" json:"at"`
+}
+
+// ReplayPage is one page of a session's replay log with the total event count
+type ReplayPage struct {
+	SessionID string         `json:"sessionId"`
+	Events    []*ReplayEvent `json:"events"`
+	Total     int64          `json:"total"`
+	Page      int            `json:"page"`
+	PageSize  int            `json:"pageSize"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ResultCard summarizes a completed game in a form the Devvit client can post back to the
+// subreddit: who won, the best answer and the door that stumped everyone
+type ResultCard struct {
+	SessionID      string              `json:"sessionId"`
+	Mode           GameMode            `json:"mode"`
+	Theme          *string             `json:"theme,omitempty"`
+	PlayerCount    int                 `json:"playerCount"`
+	DoorCount      int                 `json:"doorCount"`
+	CompletedAt    *time.Time          `json:"completedAt,omitempty"`
+	Winner         *ResultCardPlayer   `json:"winner,omitempty"`
+	FunniestAnswer *ResponseHighlight  `json:"funniestAnswer,omitempty"` // the highest-scoring response of the game
+	HardestDoor    *DoorHighlight      `json:"hardestDoor,omitempty"`    // the door with the lowest average score
+	BestResponses  []ResponseHighlight `json:"bestResponses"`            // the highest-scoring response to each door, in the order the doors were played
+}
+
+// ResultCardPlayer names a player on a result card
+type ResultCardPlayer struct {
+	PlayerID   string `json:"playerId"`
+	Username   string `json:"username"`
+	TotalScore int    `json:"totalScore"`
+}
+
+// ResponseHighlight is a response singled out on a result card. Content is left out when the
+// session keeps answers private, and the author when answers are revealed anonymously.
+type ResponseHighlight struct {
+	DoorID      string `json:"doorId"`
+	DoorContent string `json:"doorContent,omitempty"`
+	ResponseID  string `json:"responseId"`
+	Content     string `json:"content,omitempty"`
+	Score       int    `json:"score"`
+	PlayerID    string `json:"playerId,omitempty"`
+	Username    string `json:"username,omitempty"`
+}
+
+// DoorHighlight is a door singled out on a result card
+type DoorHighlight struct {
+	DoorID        string  `json:"doorId"`
+	Content       string  `json:"content,omitempty"`
+	AverageScore  float64 `json:"averageScore"`
+	ResponseCount int     `json:"responseCount"`
+}
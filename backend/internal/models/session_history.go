@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// SessionEventType names an entry in a session's event log
+type SessionEventType string
+
+const (
+	SessionEventDoorPresented     SessionEventType = "door.presented"
+	SessionEventResponseSubmitted SessionEventType = "response.submitted"
+	SessionEventResponseScored    SessionEventType = "response.scored"
+	SessionEventResponseMissed    SessionEventType = "response.missed"
+	SessionEventResponseLate      SessionEventType = "response.late"
+)
+
+// SessionEvent is one entry in a session's event log, recorded by the server as it happens so
+// disputes over timing and scores can be settled afterwards. Deadline is the response deadline
+// in force when the event happened.
+type SessionEvent struct {
+	EventID    string           `bson:"eventId" json:"eventId"`
+	SessionID  string           `bson:"sessionId" json:"sessionId"`
+	Type       SessionEventType `bson:"type" json:"type"`
+	PlayerID   string           `bson:"playerId,omitempty" json:"playerId,omitempty"`
+	DoorID     string           `bson:"doorId,omitempty" json:"doorId,omitempty"`
+	ResponseID string           `bson:"responseId,omitempty" json:"responseId,omitempty"`
+	Deadline   *time.Time       `bson:"deadline,omitempty" json:"deadline,omitempty"`
+	Score      *int             `bson:"score,omitempty" json:"score,omitempty"`
+	Metrics    *ScoringMetrics  `bson:"metrics,omitempty" json:"metrics,omitempty"`
+	Fallback   bool             `bson:"fallback,omitempty" json:"fallback,omitempty"` // scored without the AI service
+	At         time.Time        `bson:"at" json:"at"`
+}
+
+// SessionHistory is a session's event log, oldest first
+type SessionHistory struct {
+	SessionID string          `json:"sessionId"`
+	Events    []*SessionEvent `json:"events"`
+}
@@ -0,0 +1,117 @@
+package models
+
+// loadedState is what a copy of a session looked like when it was last read or written, so a
+// whole-session update can tell the writes made by others since from its own changes
+type loadedState struct {
+	status  GameStatus
+	players map[string]bool
+}
+
+// MarkLoaded records the session as read from or written to storage. Repositories call it on
+// every copy they hand out, so MergeConcurrentWrites can tell what changed since.
+func (s *GameSession) MarkLoaded() {
+	s.loaded = &loadedState{status: s.Status, players: make(map[string]bool, len(s.Players))}
+	for _, player := range s.Players {
+		s.loaded.players[player.PlayerID] = true
+	}
+}
+
+// MergeConcurrentWrites carries over what another writer saved to stored since this copy of the
+// session was loaded, so retrying a whole-session update never drops it:
+//   - players who joined meanwhile are added, and players kicked meanwhile are removed
+//   - responses and scores are merged, as in MergeConcurrentResponses
+//
+// It reports false if the other writer changed the session's state, such as pausing or ending
+// it, to something other than this copy's, as neither change can be kept without undoing the other.
+func (s *GameSession) MergeConcurrentWrites(stored *GameSession) bool {
+	if s.loaded != nil && stored.Status != s.loaded.status && stored.Status != s.Status {
+		return false
+	}
+
+	for _, playerID := range stored.KickedPlayers {
+		if !s.WasKicked(playerID) {
+			s.KickedPlayers = append(s.KickedPlayers, playerID)
+		}
+	}
+
+	storedPlayers := make(map[string]bool, len(stored.Players))
+	for _, player := range stored.Players {
+		storedPlayers[player.PlayerID] = true
+	}
+	players := s.Players[:0]
+	for _, player := range s.Players {
+		// Players this copy loaded but who are gone from storage were removed by the other writer
+		removed := s.loaded != nil && s.loaded.players[player.PlayerID] && !storedPlayers[player.PlayerID]
+		if !removed && !stored.WasKicked(player.PlayerID) {
+			players = append(players, player)
+		}
+	}
+	s.Players = players
+
+	for _, player := range stored.Players {
+		// Players stored but never loaded here joined meanwhile; loaded ones were removed by this copy
+		if s.WasKicked(player.PlayerID) || (s.loaded != nil && s.loaded.players[player.PlayerID]) || s.hasPlayer(player.PlayerID) {
+			continue
+		}
+		s.Players = append(s.Players, player)
+	}
+
+	s.MergeConcurrentResponses(stored)
+
+	// What's stored now is what the retried write has to account for
+	stored.MarkLoaded()
+	s.loaded = stored.loaded
+	return true
+}
+
+// hasPlayer reports whether the player is in the session
+func (s *GameSession) hasPlayer(playerID string) bool {
+	for _, player := range s.Players {
+		if player.PlayerID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeConcurrentResponses carries over the responses and scores another writer saved to stored
+// since this copy of the session was read: responses this copy doesn't have are added, and
+// responses still pending here take the score stored since. Players' total scores follow.
+func (s *GameSession) MergeConcurrentResponses(stored *GameSession) {
+	for i := range s.Players {
+		player := &s.Players[i]
+		var storedPlayer *PlayerInfo
+		for j := range stored.Players {
+			if stored.Players[j].PlayerID == player.PlayerID {
+				storedPlayer = &stored.Players[j]
+				break
+			}
+		}
+		if storedPlayer == nil {
+			continue
+		}
+
+		for _, response := range storedPlayer.Responses {
+			existing := -1
+			for j := range player.Responses {
+				if player.Responses[j].ResponseID == response.ResponseID || player.Responses[j].DoorID == response.DoorID {
+					existing = j
+					break
+				}
+			}
+
+			switch {
+			case existing < 0:
+				player.Responses = append(player.Responses, response)
+				player.TotalScore += response.AIScore
+			case player.Responses[existing].ResponseID != response.ResponseID:
+				// Both answered the same door; the response saved first stands
+				player.TotalScore += response.AIScore - player.Responses[existing].AIScore
+				player.Responses[existing] = response
+			case player.Responses[existing].ScoringPending && !response.ScoringPending:
+				player.TotalScore += response.AIScore - player.Responses[existing].AIScore
+				player.Responses[existing] = response
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+// Session quota scopes
+const (
+	QuotaScopeSubreddit = "subreddit" // unfinished sessions started from one subreddit's app install
+	QuotaScopePlayer    = "player"    // unfinished sessions one player has created
+)
+
+// SessionLimits caps how many unfinished sessions may run at once. A limit of zero is unlimited.
+type SessionLimits struct {
+	MaxActivePerSubreddit int            `json:"maxActivePerSubreddit"`
+	MaxActivePerPlayer    int            `json:"maxActivePerPlayer"`
+	Subreddits            map[string]int `json:"subreddits,omitempty"` // per-subreddit overrides of MaxActivePerSubreddit
+}
+
+// SubredditLimit returns the limit for a subreddit, its override if it has one
+func (l SessionLimits) SubredditLimit(subreddit string) int {
+	if limit, ok := l.Subreddits[subreddit]; ok {
+		return limit
+	}
+	return l.MaxActivePerSubreddit
+}
+
+// SessionQuota is one limit a new session counts against, with how much of it is in use
+type SessionQuota struct {
+	Scope  string `json:"scope"`
+	ID     string `json:"id"`
+	Limit  int    `json:"limit"`
+	Active int    `json:"active"`
+}
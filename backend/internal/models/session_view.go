@@ -0,0 +1,17 @@
+package models
+
+// ForPlayer returns a copy of the session as sent to one player: other players' responses are
+// left out, so nobody reads them before the reveal, learns who wrote an anonymous one or sees
+// the AI feedback meant for their author. Pass an empty player ID for spectators, who see no
+// responses.
+func (s *GameSession) ForPlayer(playerID string) *GameSession {
+	view := *s
+	view.Players = make([]PlayerInfo, len(s.Players))
+	for i, player := range s.Players {
+		if player.PlayerID != playerID {
+			player.Responses = []PlayerResponse{}
+		}
+		view.Players[i] = player
+	}
+	return &view
+}
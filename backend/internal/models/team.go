@@ -0,0 +1,37 @@
+package models
+
+// Team sessions always have two teams
+const (
+	TeamRed  = "red"
+	TeamBlue = "blue"
+)
+
+// TeamIDs lists the teams of a team session in the order players are assigned to them
+var TeamIDs = []string{TeamRed, TeamBlue}
+
+// ValidTeamID reports whether teamID names one of the two teams
+func ValidTeamID(teamID string) bool {
+	return teamID == TeamRed || teamID == TeamBlue
+}
+
+// TeamPlayers returns the players on the given team
+func (s *GameSession) TeamPlayers(teamID string) []PlayerInfo {
+	var players []PlayerInfo
+	for _, player := range s.Players {
+		if player.TeamID == teamID {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// TeamRanking represents a team's final ranking in a team session
+type TeamRanking struct {
+	Rank           int      `json:"rank"`
+	TeamID         string   `json:"teamId"`
+	PlayerIDs      []string `json:"playerIds"`
+	TotalScore     int      `json:"totalScore"`     // the team's players' scores added together
+	AverageScore   float64  `json:"averageScore"`   // the average of the team's players' total scores
+	CompletionRate float64  `json:"completionRate"` // the average of the team's players' completion rates
+	IsWinner       bool     `json:"isWinner"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaturityRating describes the audience a theme's doors are written for
+type MaturityRating string
+
+const (
+	MaturityEveryone MaturityRating = "everyone"
+	MaturityTeen     MaturityRating = "teen"
+	MaturityMature   MaturityRating = "mature"
+)
+
+// Valid reports whether the rating is one of the known ratings
+func (r MaturityRating) Valid() bool {
+	return r == MaturityEveryone || r == MaturityTeen || r == MaturityMature
+}
+
+// Theme is a content pack sessions can be played in. Disabled themes stay in the catalog
+// for admins but cannot be chosen for new sessions.
+type Theme struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	ThemeID        string             `bson:"themeId" json:"themeId"` // the name sessions and doors refer to, e.g. "workplace"
+	Name           string             `bson:"name" json:"name"`
+	Description    string             `bson:"description" json:"description"`
+	Icon           string             `bson:"icon,omitempty" json:"icon,omitempty"`
+	MaturityRating MaturityRating     `bson:"maturityRating" json:"maturityRating"`
+	Enabled        bool               `bson:"enabled" json:"enabled"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      *time.Time         `bson:"updatedAt,omitempty" json:"updatedAt,omitempty"`
+}
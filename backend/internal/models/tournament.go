@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TournamentStatus represents the current state of a tournament
+type TournamentStatus string
+
+const (
+	TournamentStatusRegistering TournamentStatus = "registering"
+	TournamentStatusInProgress  TournamentStatus = "in_progress"
+	TournamentStatusCompleted   TournamentStatus = "completed"
+)
+
+// ValidTournamentSize reports whether a bracket can be built for the given number of players
+func ValidTournamentSize(size int) bool {
+	return size == 8 || size == 16
+}
+
+// Tournament is a single-elimination bracket where every match is its own game session
+type Tournament struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TournamentID string             `bson:"tournamentId" json:"tournamentId"`
+	Name         string             `bson:"name" json:"name"`
+	Theme        *string            `bson:"theme,omitempty" json:"theme,omitempty"`
+	Size         int                `bson:"size" json:"size"`
+	Status       TournamentStatus   `bson:"status" json:"status"`
+	CreatedBy    string             `bson:"createdBy" json:"createdBy"`
+	Players      []TournamentPlayer `bson:"players" json:"players"`
+	Rounds       []TournamentRound  `bson:"rounds" json:"rounds"`
+	CurrentRound int                `bson:"currentRound" json:"currentRound"` // 1-based; 0 until the bracket starts
+	WinnerID     string             `bson:"winnerId,omitempty" json:"winnerId,omitempty"`
+	Version      int                `bson:"version" json:"-"` // guards concurrent updates from several instances
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+	StartedAt    *time.Time         `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
+	CompletedAt  *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+}
+
+// TournamentPlayer represents a registered player and how far they got
+type TournamentPlayer struct {
+	PlayerID          string    `bson:"playerId" json:"playerId"`
+	Username          string    `bson:"username" json:"username"`
+	Seed              int       `bson:"seed" json:"seed"`
+	JoinedAt          time.Time `bson:"joinedAt" json:"joinedAt"`
+	EliminatedInRound int       `bson:"eliminatedInRound,omitempty" json:"eliminatedInRound,omitempty"`
+}
+
+// TournamentRound is one elimination round of the bracket
+type TournamentRound struct {
+	Number  int               `bson:"number" json:"number"`
+	Matches []TournamentMatch `bson:"matches" json:"matches"`
+}
+
+// TournamentMatch pairs two players in a game session; the winner advances
+type TournamentMatch struct {
+	MatchID     string     `bson:"matchId" json:"matchId"`
+	PlayerIDs   []string   `bson:"playerIds" json:"playerIds"`
+	SessionID   string     `bson:"sessionId,omitempty" json:"sessionId,omitempty"`
+	WinnerID    string     `bson:"winnerId,omitempty" json:"winnerId,omitempty"`
+	CompletedAt *time.Time `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+}
+
+// Completed reports whether the match has been decided
+func (m *TournamentMatch) Completed() bool {
+	return m.WinnerID != ""
+}
+
+// HasPlayer reports whether the player is registered for the tournament
+func (t *Tournament) HasPlayer(playerID string) bool {
+	return t.Player(playerID) != nil
+}
+
+// Player returns the registered player with the given ID, or nil
+func (t *Tournament) Player(playerID string) *TournamentPlayer {
+	for i := range t.Players {
+		if t.Players[i].PlayerID == playerID {
+			return &t.Players[i]
+		}
+	}
+	return nil
+}
+
+// Round returns the current round, or nil before the bracket starts
+func (t *Tournament) Round() *TournamentRound {
+	if t.CurrentRound < 1 || t.CurrentRound > len(t.Rounds) {
+		return nil
+	}
+	return &t.Rounds[t.CurrentRound-1]
+}
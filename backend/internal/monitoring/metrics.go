@@ -3,7 +3,12 @@ package monitoring
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -18,7 +23,7 @@ const (
 	MetricTypeTiming    MetricType = "timing"
 )
 
-// Metric represents a single metric
+// Metric represents a single metric series
 type Metric struct {
 	Name      string                 `json:"name"`
 	Type      MetricType             `json:"type"`
@@ -27,17 +32,64 @@ type Metric struct {
 	Timestamp time.Time              `json:"timestamp"`
 	Help      string                 `json:"help,omitempty"`
 	Unit      string                 `json:"unit,omitempty"`
+	
+	// Histogram state; Value holds the mean for consumers that only read a single number
+	Buckets []BucketCount `json:"buckets,omitempty"`
+	Sum     float64       `json:"sum,omitempty"`
+	Count   uint64        `json:"count,omitempty"`
+}
+
+// BucketCount is the cumulative number of observations less than or equal to UpperBound
+type BucketCount struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// MarshalJSON writes the upper bound the way Prometheus labels it, since JSON has no +Inf
+func (b BucketCount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		UpperBound string `json:"le"`
+		Count      uint64 `json:"count"`
+	}{FormatBucketBound(b.UpperBound), b.Count})
+}
+
+// FormatBucketBound formats a bucket upper bound as a Prometheus le label value
+func FormatBucketBound(bound float64) string {
+	if math.IsInf(bound, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(bound, 'g', -1, 64)
+}
+
+// MetricFamily groups the series that share a metric name
+type MetricFamily struct {
+	Name   string     `json:"name"`
+	Type   MetricType `json:"type"`
+	Help   string     `json:"help,omitempty"`
+	Series []*Metric  `json:"series"`
+}
+
+// series is a single labeled metric tracked by a family
+type series interface {
+	snapshot() *Metric
 }
 
-// MetricsCollector collects and manages application metrics
+// family holds every series registered under one metric name, keyed by their labels
+type family struct {
+	name       string
+	help       string
+	metricType MetricType
+	series     map[string]series
+}
+
+// MetricsCollector collects and manages application metrics. Metrics are registered once per
+// name and label set: asking for a metric that already exists returns the existing series, so
+// callers may look metrics up on every event.
 type MetricsCollector struct {
-	metrics map[string]*Metric
-	mutex   sync.RWMutex
+	families map[string]*family
+	mutex    sync.RWMutex
 	
 	// Built-in metrics
-	requestCount    *Counter
-	requestDuration *Histogram
-	errorCount      *Counter
 	activeConnections *Gauge
 	gameSessionCount  *Gauge
 	playerCount       *Gauge
@@ -46,41 +98,31 @@ type MetricsCollector struct {
 // NewMetricsCollector creates a new metrics collector
 func NewMetricsCollector() *MetricsCollector {
 	mc := &MetricsCollector{
-		metrics: make(map[string]*Metric),
+		families: make(map[string]*family),
 	}
 	
 	// Initialize built-in metrics
-	mc.requestCount = mc.NewCounter("http_requests_total", "Total number of HTTP requests", map[string]string{})
-	mc.requestDuration = mc.NewHistogram("http_request_duration_seconds", "HTTP request duration in seconds", map[string]string{})
-	mc.errorCount = mc.NewCounter("errors_total", "Total number of errors", map[string]string{})
-	mc.activeConnections = mc.NewGauge("websocket_connections_active", "Number of active WebSocket connections", map[string]string{})
-	mc.gameSessionCount = mc.NewGauge("game_sessions_active", "Number of active game sessions", map[string]string{})
-	mc.playerCount = mc.NewGauge("players_active", "Number of active players", map[string]string{})
+	mc.activeConnections = mc.NewGauge("websocket_connections_active", "Number of active WebSocket connections", nil)
+	mc.gameSessionCount = mc.NewGauge("game_sessions_active", "Number of active game sessions", nil)
+	mc.playerCount = mc.NewGauge("players_active", "Number of active players", nil)
 	
 	return mc
 }
 
 // Counter represents a counter metric
 type Counter struct {
-	collector *MetricsCollector
-	name      string
-	help      string
-	labels    map[string]string
-	value     float64
-	mutex     sync.Mutex
+	name    string
+	labels  map[string]string
+	value   float64
+	updated time.Time
+	mutex   sync.Mutex
 }
 
-// NewCounter creates a new counter metric
+// NewCounter returns the counter registered under name and labels, creating it on first use
 func (mc *MetricsCollector) NewCounter(name, help string, labels map[string]string) *Counter {
-	counter := &Counter{
-		collector: mc,
-		name:      name,
-		help:      help,
-		labels:    labels,
-	}
-	
-	mc.registerMetric(name, MetricTypeCounter, help, labels)
-	return counter
+	return mc.getOrCreate(name, help, MetricTypeCounter, labels, func(labels map[string]string) series {
+		return &Counter{name: name, labels: labels, updated: time.Now()}
+	}).(*Counter)
 }
 
 // Inc increments the counter by 1
@@ -94,7 +136,7 @@ func (c *Counter) Add(value float64) {
 	defer c.mutex.Unlock()
 	
 	c.value += value
-	c.collector.updateMetric(c.name, c.value, c.labels)
+	c.updated = time.Now()
 }
 
 // Get returns the current counter value
@@ -104,27 +146,26 @@ func (c *Counter) Get() float64 {
 	return c.value
 }
 
+func (c *Counter) snapshot() *Metric {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return &Metric{Name: c.name, Type: MetricTypeCounter, Value: c.value, Labels: c.labels, Timestamp: c.updated}
+}
+
 // Gauge represents a gauge metric
 type Gauge struct {
-	collector *MetricsCollector
-	name      string
-	help      string
-	labels    map[string]string
-	value     float64
-	mutex     sync.Mutex
+	name    string
+	labels  map[string]string
+	value   float64
+	updated time.Time
+	mutex   sync.Mutex
 }
 
-// NewGauge creates a new gauge metric
+// NewGauge returns the gauge registered under name and labels, creating it on first use
 func (mc *MetricsCollector) NewGauge(name, help string, labels map[string]string) *Gauge {
-	gauge := &Gauge{
-		collector: mc,
-		name:      name,
-		help:      help,
-		labels:    labels,
-	}
-	
-	mc.registerMetric(name, MetricTypeGauge, help, labels)
-	return gauge
+	return mc.getOrCreate(name, help, MetricTypeGauge, labels, func(labels map[string]string) series {
+		return &Gauge{name: name, labels: labels, updated: time.Now()}
+	}).(*Gauge)
 }
 
 // Set sets the gauge to the given value
@@ -133,7 +174,7 @@ func (g *Gauge) Set(value float64) {
 	defer g.mutex.Unlock()
 	
 	g.value = value
-	g.collector.updateMetric(g.name, g.value, g.labels)
+	g.updated = time.Now()
 }
 
 // Inc increments the gauge by 1
@@ -152,7 +193,7 @@ func (g *Gauge) Add(value float64) {
 	defer g.mutex.Unlock()
 	
 	g.value += value
-	g.collector.updateMetric(g.name, g.value, g.labels)
+	g.updated = time.Now()
 }
 
 // Get returns the current gauge value
@@ -162,35 +203,46 @@ func (g *Gauge) Get() float64 {
 	return g.value
 }
 
+func (g *Gauge) snapshot() *Metric {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return &Metric{Name: g.name, Type: MetricTypeGauge, Value: g.value, Labels: g.labels, Timestamp: g.updated}
+}
+
 // Histogram represents a histogram metric
 type Histogram struct {
-	collector *MetricsCollector
-	name      string
-	help      string
-	labels    map[string]string
-	buckets   []float64
-	counts    []uint64
-	sum       float64
-	count     uint64
-	mutex     sync.Mutex
+	name    string
+	labels  map[string]string
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+	updated time.Time
+	mutex   sync.Mutex
 }
 
-// NewHistogram creates a new histogram metric
+// DefaultBuckets are the histogram bucket upper bounds, in seconds, used for durations
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// NewHistogram returns the histogram registered under name and labels, creating it with the
+// default duration buckets on first use
 func (mc *MetricsCollector) NewHistogram(name, help string, labels map[string]string) *Histogram {
-	// Default buckets for HTTP request durations
-	buckets := []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
-	
-	histogram := &Histogram{
-		collector: mc,
-		name:      name,
-		help:      help,
-		labels:    labels,
-		buckets:   buckets,
-		counts:    make([]uint64, len(buckets)+1), // +1 for +Inf bucket
-	}
-	
-	mc.registerMetric(name, MetricTypeHistogram, help, labels)
-	return histogram
+	return mc.NewHistogramWithBuckets(name, help, labels, DefaultBuckets)
+}
+
+// NewHistogramWithBuckets returns the histogram registered under name and labels, creating it
+// with the given bucket upper bounds on first use. Bounds must be sorted in increasing order;
+// the +Inf bucket is always added.
+func (mc *MetricsCollector) NewHistogramWithBuckets(name, help string, labels map[string]string, buckets []float64) *Histogram {
+	return mc.getOrCreate(name, help, MetricTypeHistogram, labels, func(labels map[string]string) series {
+		return &Histogram{
+			name:    name,
+			labels:  labels,
+			buckets: buckets,
+			counts:  make([]uint64, len(buckets)+1), // +1 for +Inf bucket
+			updated: time.Now(),
+		}
+	}).(*Histogram)
 }
 
 // Observe adds an observation to the histogram
@@ -200,6 +252,7 @@ func (h *Histogram) Observe(value float64) {
 	
 	h.sum += value
 	h.count++
+	h.updated = time.Now()
 	
 	// Find the appropriate bucket
 	for i, bucket := range h.buckets {
@@ -209,10 +262,33 @@ func (h *Histogram) Observe(value float64) {
 	}
 	// Always increment the +Inf bucket
 	h.counts[len(h.buckets)]++
+}
+
+func (h *Histogram) snapshot() *Metric {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
 	
-	// Update the metric with average value
-	average := h.sum / float64(h.count)
-	h.collector.updateMetric(h.name, average, h.labels)
+	buckets := make([]BucketCount, 0, len(h.counts))
+	for i, bound := range h.buckets {
+		buckets = append(buckets, BucketCount{UpperBound: bound, Count: h.counts[i]})
+	}
+	buckets = append(buckets, BucketCount{UpperBound: math.Inf(1), Count: h.counts[len(h.buckets)]})
+	
+	average := 0.0
+	if h.count > 0 {
+		average = h.sum / float64(h.count)
+	}
+	
+	return &Metric{
+		Name:      h.name,
+		Type:      MetricTypeHistogram,
+		Value:     average,
+		Labels:    h.labels,
+		Timestamp: h.updated,
+		Buckets:   buckets,
+		Sum:       h.sum,
+		Count:     h.count,
+	}
 }
 
 // Timer provides a convenient way to time operations
@@ -235,43 +311,80 @@ func (t *Timer) ObserveDuration() {
 	t.histogram.Observe(duration)
 }
 
-// registerMetric registers a new metric
-func (mc *MetricsCollector) registerMetric(name string, metricType MetricType, help string, labels map[string]string) {
+// getOrCreate returns the series registered under name and labels, registering the one built by
+// create if there is none. A name already registered with another type cannot be shared, so the
+// new metric still works but is not exported.
+func (mc *MetricsCollector) getOrCreate(name, help string, metricType MetricType, labels map[string]string, create func(labels map[string]string) series) series {
+	key := FormatLabels(labels)
+	
 	mc.mutex.Lock()
 	defer mc.mutex.Unlock()
 	
-	mc.metrics[name] = &Metric{
-		Name:      name,
-		Type:      metricType,
-		Value:     0,
-		Labels:    labels,
-		Timestamp: time.Now(),
-		Help:      help,
+	fam, exists := mc.families[name]
+	if !exists {
+		fam = &family{
+			name:       name,
+			help:       help,
+			metricType: metricType,
+			series:     make(map[string]series),
+		}
+		mc.families[name] = fam
 	}
-}
-
-// updateMetric updates an existing metric
-func (mc *MetricsCollector) updateMetric(name string, value float64, labels map[string]string) {
-	mc.mutex.Lock()
-	defer mc.mutex.Unlock()
 	
-	if metric, exists := mc.metrics[name]; exists {
-		metric.Value = value
-		metric.Labels = labels
-		metric.Timestamp = time.Now()
+	if fam.metricType != metricType {
+		fmt.Printf("Warning: metric %s is registered as a %s, not exporting it as a %s\n", name, fam.metricType, metricType)
+		return create(copyLabels(labels))
 	}
+	
+	if existing, exists := fam.series[key]; exists {
+		return existing
+	}
+	
+	created := create(copyLabels(labels))
+	fam.series[key] = created
+	return created
 }
 
-// GetMetrics returns all current metrics
-func (mc *MetricsCollector) GetMetrics() map[string]*Metric {
+// GetMetricFamilies returns every metric family, sorted by name, with its series sorted by labels
+func (mc *MetricsCollector) GetMetricFamilies() []*MetricFamily {
 	mc.mutex.RLock()
 	defer mc.mutex.RUnlock()
 	
-	// Create a copy to avoid race conditions
+	families := make([]*MetricFamily, 0, len(mc.families))
+	for _, fam := range mc.families {
+		keys := make([]string, 0, len(fam.series))
+		for key := range fam.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		
+		result := &MetricFamily{
+			Name:   fam.name,
+			Type:   fam.metricType,
+			Help:   fam.help,
+			Series: make([]*Metric, 0, len(keys)),
+		}
+		for _, key := range keys {
+			metric := fam.series[key].snapshot()
+			metric.Help = fam.help
+			result.Series = append(result.Series, metric)
+		}
+		families = append(families, result)
+	}
+	
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].Name < families[j].Name
+	})
+	return families
+}
+
+// GetMetrics returns all current series keyed by name and labels, e.g. http_requests_total{method="GET"}
+func (mc *MetricsCollector) GetMetrics() map[string]*Metric {
 	result := make(map[string]*Metric)
-	for name, metric := range mc.metrics {
-		metricCopy := *metric
-		result[name] = &metricCopy
+	for _, fam := range mc.GetMetricFamilies() {
+		for _, metric := range fam.Series {
+			result[metric.Name+FormatLabels(metric.Labels)] = metric
+		}
 	}
 	
 	return result
@@ -283,15 +396,91 @@ func (mc *MetricsCollector) GetMetricsJSON() ([]byte, error) {
 	return json.Marshal(metrics)
 }
 
+// Total returns the sum of every series of a counter or gauge, or the number of observations
+// of a histogram
+func (mc *MetricsCollector) Total(name string) float64 {
+	total := 0.0
+	for _, metric := range mc.snapshotSeries(name) {
+		if metric.Type == MetricTypeHistogram {
+			total += float64(metric.Count)
+		} else {
+			total += metric.Value
+		}
+	}
+	return total
+}
+
+// Mean returns the mean observation across every series of a histogram
+func (mc *MetricsCollector) Mean(name string) float64 {
+	sum, count := 0.0, uint64(0)
+	for _, metric := range mc.snapshotSeries(name) {
+		sum += metric.Sum
+		count += metric.Count
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// snapshotSeries snapshots every series registered under name
+func (mc *MetricsCollector) snapshotSeries(name string) []*Metric {
+	mc.mutex.RLock()
+	defer mc.mutex.RUnlock()
+	
+	fam, exists := mc.families[name]
+	if !exists {
+		return nil
+	}
+	
+	metrics := make([]*Metric, 0, len(fam.series))
+	for _, s := range fam.series {
+		metrics = append(metrics, s.snapshot())
+	}
+	return metrics
+}
+
+// FormatLabels renders labels, plus any extra name/value pairs, as a Prometheus label set with
+// the labels sorted by name. It returns an empty string when there are no labels.
+func FormatLabels(labels map[string]string, extra ...string) string {
+	pairs := make([]string, 0, len(labels)+len(extra)/2)
+	for k, v := range labels {
+		pairs = append(pairs, k+"=\""+labelValueEscaper.Replace(v)+"\"")
+	}
+	sort.Strings(pairs)
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+"=\""+labelValueEscaper.Replace(extra[i+1])+"\"")
+	}
+	
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labelValueEscaper escapes the characters Prometheus requires escaping in label values
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// copyLabels copies labels so later changes to the caller's map don't alter a registered series
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(labels))
+	for k, v := range labels {
+		copied[k] = v
+	}
+	return copied
+}
+
 // Built-in metric accessors
 func (mc *MetricsCollector) IncrementRequests(method, path string, statusCode int) {
 	labels := map[string]string{
 		"method": method,
 		"path":   path,
-		"status": string(rune(statusCode)),
+		"status": strconv.Itoa(statusCode),
 	}
-	counter := mc.NewCounter("http_requests_total", "Total HTTP requests", labels)
-	counter.Inc()
+	mc.NewCounter("http_requests_total", "Total HTTP requests", labels).Inc()
 }
 
 func (mc *MetricsCollector) ObserveRequestDuration(method, path string, duration time.Duration) {
@@ -299,8 +488,7 @@ func (mc *MetricsCollector) ObserveRequestDuration(method, path string, duration
 		"method": method,
 		"path":   path,
 	}
-	histogram := mc.NewHistogram("http_request_duration_seconds", "HTTP request duration", labels)
-	histogram.Observe(duration.Seconds())
+	mc.NewHistogram("http_request_duration_seconds", "HTTP request duration in seconds", labels).Observe(duration.Seconds())
 }
 
 func (mc *MetricsCollector) IncrementErrors(errorType, component string) {
@@ -308,8 +496,7 @@ func (mc *MetricsCollector) IncrementErrors(errorType, component string) {
 		"type":      errorType,
 		"component": component,
 	}
-	counter := mc.NewCounter("errors_total", "Total errors", labels)
-	counter.Inc()
+	mc.NewCounter("errors_total", "Total errors", labels).Inc()
 }
 
 func (mc *MetricsCollector) SetActiveConnections(count int) {
@@ -327,6 +514,7 @@ func (mc *MetricsCollector) SetActivePlayers(count int) {
 // SystemMetrics collects system-level metrics
 type SystemMetrics struct {
 	collector *MetricsCollector
+	lastNumGC uint32
 }
 
 // NewSystemMetrics creates a new system metrics collector
@@ -348,8 +536,9 @@ func (sm *SystemMetrics) CollectSystemMetrics() {
 	sm.collector.NewGauge("memory_heap_alloc_bytes", "Heap allocated memory in bytes", nil).Set(float64(m.HeapAlloc))
 	sm.collector.NewGauge("memory_heap_sys_bytes", "Heap system memory in bytes", nil).Set(float64(m.HeapSys))
 	
-	// Garbage collection metrics
-	sm.collector.NewCounter("gc_runs_total", "Total number of GC runs", nil).Add(float64(m.NumGC))
+	// Garbage collection metrics; the counter persists between collections, so only add the new runs
+	sm.collector.NewCounter("gc_runs_total", "Total number of GC runs", nil).Add(float64(m.NumGC - sm.lastNumGC))
+	sm.lastNumGC = m.NumGC
 	sm.collector.NewGauge("gc_pause_ns", "GC pause time in nanoseconds", nil).Set(float64(m.PauseTotalNs))
 	
 	// Goroutine metrics
@@ -406,4 +595,4 @@ func SetActiveGameSessions(count int) {
 
 func SetActivePlayers(count int) {
 	GetGlobalMetricsCollector().SetActivePlayers(count)
-}
\ No newline at end of file
+}
@@ -0,0 +1,94 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestHistogram_CountsObservationsCumulatively(t *testing.T) {
+	collector := NewMetricsCollector()
+	histogram := collector.NewHistogramWithBuckets("scoring_seconds", "", nil, []float64{0.1, 1})
+	for _, value := range []float64{0.05, 0.1, 0.5, 1, 7} {
+		histogram.Observe(value)
+	}
+
+	metric := collector.GetMetrics()["scoring_seconds"]
+	if metric == nil {
+		t.Fatal("Expected the histogram to be exported")
+	}
+	want := []BucketCount{{0.1, 2}, {1, 4}, {math.Inf(1), 5}}
+	if len(metric.Buckets) != len(want) {
+		t.Fatalf("Expected buckets %v, got %v", want, metric.Buckets)
+	}
+	for i, bucket := range want {
+		if metric.Buckets[i] != bucket {
+			t.Errorf("Expected bucket %d to be %v, got %v", i, bucket, metric.Buckets[i])
+		}
+	}
+	if metric.Sum != 8.65 || metric.Count != 5 || metric.Value != 8.65/5 {
+		t.Errorf("Expected sum 8.65 over 5 observations, got sum %v count %d mean %v", metric.Sum, metric.Count, metric.Value)
+	}
+
+	// JSON has no infinity, so the last bound is written the way Prometheus labels it
+	data, err := json.Marshal(metric.Buckets)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `{"le":"+Inf","count":5}`) {
+		t.Errorf("Expected the +Inf bucket in the JSON, got %s", data)
+	}
+}
+
+func TestFormatLabels_EscapesValuesAndSortsNames(t *testing.T) {
+	labels := map[string]string{
+		"path":   `/doors/"best"`,
+		"error":  "line one\nline two",
+		"method": `C:\doors`,
+	}
+
+	want := `{error="line one\nline two",method="C:\\doors",path="/doors/\"best\""}`
+	for i := 0; i < 10; i++ {
+		if got := FormatLabels(labels); got != want {
+			t.Fatalf("Expected %s, got %s", want, got)
+		}
+	}
+	if got := FormatLabels(labels, "le", "+Inf"); got != strings.TrimSuffix(want, "}")+`,le="+Inf"}` {
+		t.Errorf("Expected le after the sorted labels, got %s", got)
+	}
+	if got := FormatLabels(nil); got != "" {
+		t.Errorf("Expected no label set without labels, got %s", got)
+	}
+}
+
+func TestMetricsCollector_SharesSeriesAcrossConcurrentLookups(t *testing.T) {
+	collector := NewMetricsCollector()
+	labels := map[string]string{"method": "GET", "path": "/doors"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			collector.NewCounter("requests_total", "Requests", labels).Inc()
+			collector.NewHistogram("request_seconds", "Latency", labels).Observe(0.2)
+		}()
+	}
+	wg.Wait()
+
+	// Label order doesn't matter, so either spelling finds the same series
+	reordered := map[string]string{"path": "/doors", "method": "GET"}
+	if got := collector.NewCounter("requests_total", "Requests", reordered).Get(); got != 50 {
+		t.Errorf("Expected all 50 increments on one series, got %v", got)
+	}
+	if got := collector.Total("request_seconds"); got != 50 {
+		t.Errorf("Expected all 50 observations on one series, got %v", got)
+	}
+	for _, family := range collector.GetMetricFamilies() {
+		if (family.Name == "requests_total" || family.Name == "request_seconds") && len(family.Series) != 1 {
+			t.Errorf("Expected one %s series, got %d", family.Name, len(family.Series))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package random
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// source is the process-wide random source; nil until Seed is called
+var (
+	mu     sync.Mutex
+	source *rand.Rand
+)
+
+// Seed switches the process into deterministic mode: every ID and random value drawn
+// through this package afterwards is reproducible from the seed
+func Seed(seed int64) {
+	mu.Lock()
+	defer mu.Unlock()
+	source = rand.New(rand.NewSource(seed))
+}
+
+// Reset leaves deterministic mode
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	source = nil
+}
+
+// Seeded reports whether deterministic mode is on
+func Seeded() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return source != nil
+}
+
+// ID returns a new random UUID string, drawn from the seed in deterministic mode
+func ID() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if source == nil {
+		return uuid.New().String()
+	}
+
+	id, err := uuid.NewRandomFromReader(source)
+	if err != nil {
+		return uuid.New().String()
+	}
+	return id.String()
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0)
+func Float64() float64 {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if source == nil {
+		return rand.Float64()
+	}
+	return source.Float64()
+}
+
+// Intn returns a pseudo-random number in [0, n)
+func Intn(n int) int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if source == nil {
+		return rand.Intn(n)
+	}
+	return source.Intn(n)
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AchievementRepository stores the badges each player has unlocked
+type AchievementRepository interface {
+	// Award records the achievement, reporting false if the player already had it
+	Award(ctx context.Context, achievement *models.PlayerAchievement) (bool, error)
+	ListByPlayer(ctx context.Context, playerID string) ([]models.PlayerAchievement, error)
+}
+
+// AchievementRepositoryImpl implements the AchievementRepository interface
+type AchievementRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewAchievementRepository creates a new achievement repository
+func NewAchievementRepository(mongodb *database.MongoClient) AchievementRepository {
+	return &AchievementRepositoryImpl{
+		collection: mongodb.GetCollection("player_achievements"),
+	}
+}
+
+// Award inserts the achievement unless the player already has it, so concurrent awards unlock it once
+func (r *AchievementRepositoryImpl) Award(ctx context.Context, achievement *models.PlayerAchievement) (bool, error) {
+	filter := bson.M{"playerId": achievement.PlayerID, "achievementId": achievement.ID}
+	update := bson.M{"$setOnInsert": achievement}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to award achievement: %w", err)
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// ListByPlayer returns the player's achievements in the order they were unlocked
+func (r *AchievementRepositoryImpl) ListByPlayer(ctx context.Context, playerID string) ([]models.PlayerAchievement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "unlockedAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"playerId": playerID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list achievements: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	achievements := []models.PlayerAchievement{}
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, fmt.Errorf("failed to decode achievements: %w", err)
+	}
+	return achievements, nil
+}
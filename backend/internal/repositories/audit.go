@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditEventRepository stores the audit log of privileged actions
+type AuditEventRepository interface {
+	Record(ctx context.Context, event *models.AuditEvent) error
+	List(ctx context.Context, filter models.AuditEventFilter) ([]*models.AuditEvent, int64, error)
+}
+
+// AuditEventRepositoryImpl implements the AuditEventRepository interface
+type AuditEventRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewAuditEventRepository creates a new audit event repository
+func NewAuditEventRepository(mongodb *database.MongoClient) AuditEventRepository {
+	return &AuditEventRepositoryImpl{
+		collection: mongodb.GetCollection("audit_events"),
+	}
+}
+
+// Record stores an audit event
+func (r *AuditEventRepositoryImpl) Record(ctx context.Context, event *models.AuditEvent) error {
+	if _, err := r.collection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+// List returns one page of audit events matching the filter, newest first, with the total match count
+func (r *AuditEventRepositoryImpl) List(ctx context.Context, filter models.AuditEventFilter) ([]*models.AuditEvent, int64, error) {
+	query := bson.M{}
+	if filter.ActorID != "" {
+		query["actor.id"] = filter.ActorID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.Since != nil {
+		query["createdAt"] = bson.M{"$gte": *filter.Since}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.PageSize)).
+		SetLimit(int64(filter.PageSize))
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []*models.AuditEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode audit events: %w", err)
+	}
+	return events, total, nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Chat history settings
+const (
+	ChatHistoryLimit      = 100
+	DefaultChatHistoryTTL = 24 * time.Hour
+)
+
+// ChatStore keeps the most recent chat messages of each session
+type ChatStore interface {
+	Append(ctx context.Context, message *models.ChatMessage) error
+	Recent(ctx context.Context, sessionID string) ([]*models.ChatMessage, error)
+}
+
+// RedisChatStore keeps each session's chat as a capped Redis list of JSON messages
+type RedisChatStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewChatStore creates a Redis-backed chat store; a session's history expires ttl after its last message
+func NewChatStore(redis *database.RedisClient, ttl time.Duration) ChatStore {
+	if ttl <= 0 {
+		ttl = DefaultChatHistoryTTL
+	}
+	return &RedisChatStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Append adds a message to the session's history, dropping the oldest beyond ChatHistoryLimit
+func (s *RedisChatStore) Append(ctx context.Context, message *models.ChatMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	key := chatKey(message.SessionID)
+	_, err = s.redis.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, data)
+		pipe.LTrim(ctx, key, -ChatHistoryLimit, -1)
+		pipe.Expire(ctx, key, s.ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save chat message: %w", err)
+	}
+	return nil
+}
+
+// Recent returns the session's stored messages, oldest first
+func (s *RedisChatStore) Recent(ctx context.Context, sessionID string) ([]*models.ChatMessage, error) {
+	entries, err := s.redis.Client.LRange(ctx, chatKey(sessionID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat history: %w", err)
+	}
+
+	messages := make([]*models.ChatMessage, 0, len(entries))
+	for _, entry := range entries {
+		var message models.ChatMessage
+		if err := json.Unmarshal([]byte(entry), &message); err != nil {
+			fmt.Printf("Warning: skipping unreadable chat message in session %s: %v\n", sessionID, err)
+			continue
+		}
+		messages = append(messages, &message)
+	}
+	return messages, nil
+}
+
+// chatKey returns the Redis key for a session's chat history
+func chatKey(sessionID string) string {
+	return fmt.Sprintf("chat:%s", sessionID)
+}
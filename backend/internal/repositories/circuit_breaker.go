@@ -0,0 +1,295 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"time"
+)
+
+// Names of the circuit breakers guarding each database
+const (
+	MongoDBBreaker = "mongodb"
+	Neo4jBreaker   = "neo4j"
+)
+
+// DatabaseBreakers lists the database circuit breakers reported on the health dashboard
+var DatabaseBreakers = []string{MongoDBBreaker, Neo4jBreaker}
+
+// CircuitBreakingSessionRepository guards a MongoDB-backed game session repository with a
+// circuit breaker. While the breaker is open, sessions are still served from the Redis cache
+// where it holds a current copy; everything else fails fast instead of waiting on MongoDB.
+type CircuitBreakingSessionRepository struct {
+	inner   GameSessionRepository
+	breaker *middleware.CircuitBreaker
+	cache   *SessionCache
+}
+
+// NewCircuitBreakingSessionRepository wraps a game session repository with the given breaker,
+// falling back to the cache for reads while it is open
+func NewCircuitBreakingSessionRepository(inner GameSessionRepository, breaker *middleware.CircuitBreaker, cache *SessionCache) GameSessionRepository {
+	return &CircuitBreakingSessionRepository{
+		inner:   inner,
+		breaker: breaker,
+		cache:   cache,
+	}
+}
+
+// Create creates a session through the breaker
+func (r *CircuitBreakingSessionRepository) Create(ctx context.Context, session *models.GameSession) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.Create(ctx, session)
+	})
+}
+
+// GetByID loads a session through the breaker, serving the cached copy while it is open
+func (r *CircuitBreakingSessionRepository) GetByID(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	var session *models.GameSession
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		session, err = r.inner.GetByID(ctx, sessionID)
+		return err
+	})
+	if err == nil || !middleware.IsCircuitBreakerError(err) || r.cache == nil {
+		return session, err
+	}
+
+	cached, _, cacheErr := r.cache.Get(ctx, sessionID)
+	if cacheErr != nil || cached == nil {
+		return nil, err
+	}
+	fmt.Printf("Warning: serving cached session %s while MongoDB is unavailable\n", sessionID)
+	return cached, nil
+}
+
+// Update updates a session through the breaker
+func (r *CircuitBreakingSessionRepository) Update(ctx context.Context, session *models.GameSession) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.Update(ctx, session)
+	})
+}
+
+// Delete deletes a session through the breaker
+func (r *CircuitBreakingSessionRepository) Delete(ctx context.Context, sessionID string) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.Delete(ctx, sessionID)
+	})
+}
+
+// GetActiveSessionsByStatus lists sessions in a status through the breaker
+func (r *CircuitBreakingSessionRepository) GetActiveSessionsByStatus(ctx context.Context, status models.GameStatus) ([]*models.GameSession, error) {
+	var sessions []*models.GameSession
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		sessions, err = r.inner.GetActiveSessionsByStatus(ctx, status)
+		return err
+	})
+	return sessions, err
+}
+
+// FindOpenSessions lists joinable lobbies through the breaker
+func (r *CircuitBreakingSessionRepository) FindOpenSessions(ctx context.Context, limit int) ([]*models.GameSession, error) {
+	var sessions []*models.GameSession
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		sessions, err = r.inner.FindOpenSessions(ctx, limit)
+		return err
+	})
+	return sessions, err
+}
+
+// AddPlayerToSession adds a player through the breaker
+func (r *CircuitBreakingSessionRepository) AddPlayerToSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.AddPlayerToSession(ctx, sessionID, player)
+	})
+}
+
+// UpdatePlayerInSession updates a player through the breaker
+func (r *CircuitBreakingSessionRepository) UpdatePlayerInSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.UpdatePlayerInSession(ctx, sessionID, player)
+	})
+}
+
+// AppendPlayerResponse records a response through the breaker
+func (r *CircuitBreakingSessionRepository) AppendPlayerResponse(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	var session *models.GameSession
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		session, err = r.inner.AppendPlayerResponse(ctx, sessionID, response)
+		return err
+	})
+	return session, err
+}
+
+// SetResponseScore records a score through the breaker
+func (r *CircuitBreakingSessionRepository) SetResponseScore(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	var session *models.GameSession
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		session, err = r.inner.SetResponseScore(ctx, sessionID, response)
+		return err
+	})
+	return session, err
+}
+
+// CircuitBreakingPlayerPathRepository guards a Neo4j-backed player path repository with a
+// circuit breaker. While the breaker is open, reads fall back to the default path so games
+// carry on with theme doors, and writes fail fast instead of waiting on Neo4j.
+type CircuitBreakingPlayerPathRepository struct {
+	inner   PlayerPathRepository
+	breaker *middleware.CircuitBreaker
+}
+
+// NewCircuitBreakingPlayerPathRepository wraps a player path repository with the given breaker
+func NewCircuitBreakingPlayerPathRepository(inner PlayerPathRepository, breaker *middleware.CircuitBreaker) PlayerPathRepository {
+	return &CircuitBreakingPlayerPathRepository{
+		inner:   inner,
+		breaker: breaker,
+	}
+}
+
+// CreatePlayer creates a player node through the breaker
+func (r *CircuitBreakingPlayerPathRepository) CreatePlayer(ctx context.Context, playerID, username string) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.CreatePlayer(ctx, playerID, username)
+	})
+}
+
+// GetNextDoor follows the player's door graph through the breaker. While it is open no graph
+// door is returned, so the next door is picked by theme instead.
+func (r *CircuitBreakingPlayerPathRepository) GetNextDoor(ctx context.Context, playerID string, currentScore int) (string, error) {
+	var doorID string
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		doorID, err = r.inner.GetNextDoor(ctx, playerID, currentScore)
+		return err
+	})
+	if middleware.IsCircuitBreakerError(err) {
+		return "", nil
+	}
+	return doorID, err
+}
+
+// UpdatePlayerPosition moves the player through the breaker
+func (r *CircuitBreakingPlayerPathRepository) UpdatePlayerPosition(ctx context.Context, playerID, doorID string) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.UpdatePlayerPosition(ctx, playerID, doorID)
+	})
+}
+
+// ReleasePlayer releases the player's path through the breaker
+func (r *CircuitBreakingPlayerPathRepository) ReleasePlayer(ctx context.Context, playerID string) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.ReleasePlayer(ctx, playerID)
+	})
+}
+
+// GetPlayerPath loads the player's path through the breaker, returning the default path while
+// it is open
+func (r *CircuitBreakingPlayerPathRepository) GetPlayerPath(ctx context.Context, playerID string) (*models.PlayerPath, error) {
+	var path *models.PlayerPath
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		path, err = r.inner.GetPlayerPath(ctx, playerID)
+		return err
+	})
+	if middleware.IsCircuitBreakerError(err) {
+		return defaultPlayerPath(playerID), nil
+	}
+	return path, err
+}
+
+// UpdatePlayerPath saves the player's path through the breaker
+func (r *CircuitBreakingPlayerPathRepository) UpdatePlayerPath(ctx context.Context, playerPath *models.PlayerPath) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.UpdatePlayerPath(ctx, playerPath)
+	})
+}
+
+// CalculateOptimalPath calculates the player's optimal path through the breaker
+func (r *CircuitBreakingPlayerPathRepository) CalculateOptimalPath(ctx context.Context, playerID string, scores []int) ([]string, error) {
+	var path []string
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		path, err = r.inner.CalculateOptimalPath(ctx, playerID, scores)
+		return err
+	})
+	return path, err
+}
+
+// CircuitBreakingDoorGraphRepository guards a Neo4j-backed door graph repository with a
+// circuit breaker, so seeding graphs fails fast while Neo4j is unavailable
+type CircuitBreakingDoorGraphRepository struct {
+	inner   DoorGraphRepository
+	breaker *middleware.CircuitBreaker
+}
+
+// NewCircuitBreakingDoorGraphRepository wraps a door graph repository with the given breaker
+func NewCircuitBreakingDoorGraphRepository(inner DoorGraphRepository, breaker *middleware.CircuitBreaker) DoorGraphRepository {
+	return &CircuitBreakingDoorGraphRepository{
+		inner:   inner,
+		breaker: breaker,
+	}
+}
+
+// GetSignature reads the theme's graph signature through the breaker
+func (r *CircuitBreakingDoorGraphRepository) GetSignature(ctx context.Context, theme string) (string, error) {
+	var signature string
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		signature, err = r.inner.GetSignature(ctx, theme)
+		return err
+	})
+	return signature, err
+}
+
+// SaveGraph saves the theme's graph through the breaker
+func (r *CircuitBreakingDoorGraphRepository) SaveGraph(ctx context.Context, graph *models.PathGraph) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.SaveGraph(ctx, graph)
+	})
+}
+
+// PlacePlayer places the player on the theme's graph through the breaker
+func (r *CircuitBreakingDoorGraphRepository) PlacePlayer(ctx context.Context, playerID, theme string) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.PlacePlayer(ctx, playerID, theme)
+	})
+}
+
+// GetGraph reads the theme's graph through the breaker
+func (r *CircuitBreakingDoorGraphRepository) GetGraph(ctx context.Context, theme string) (*models.PathGraph, error) {
+	var graph *models.PathGraph
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		graph, err = r.inner.GetGraph(ctx, theme)
+		return err
+	})
+	return graph, err
+}
+
+// GetPlayerPosition reads the player's position through the breaker
+func (r *CircuitBreakingDoorGraphRepository) GetPlayerPosition(ctx context.Context, playerID string) (string, error) {
+	var position string
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		position, err = r.inner.GetPlayerPosition(ctx, playerID)
+		return err
+	})
+	return position, err
+}
+
+// defaultPlayerPath is the path of a player Neo4j has no record of
+func defaultPlayerPath(playerID string) *models.PlayerPath {
+	return &models.PlayerPath{
+		PlayerID:          playerID,
+		Theme:             "general",
+		CurrentDifficulty: 1,
+		DoorsVisited:      []string{},
+		TotalDoors:        10,
+		CreatedAt:         time.Now(),
+	}
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/middleware"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(name string) *middleware.CircuitBreaker {
+	return middleware.NewCircuitBreaker(name, middleware.CircuitBreakerConfig{
+		MaxFailures:      1,
+		ResetTimeout:     time.Minute,
+		SuccessThreshold: 1,
+		Timeout:          time.Second,
+	})
+}
+
+func TestCircuitBreakingSessionRepository_ServesCacheWhileOpen(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingSessionRepository()
+	inner.sessions["s1"] = newTestSession("s1")
+	cache := NewSessionCache(newMemorySessionCacheStore(), time.Minute)
+	repo := NewCircuitBreakingSessionRepository(inner, newTestBreaker(MongoDBBreaker), cache)
+
+	session, _ := repo.GetByID(ctx, "s1")
+	if err := cache.WriteThrough(ctx, session); err != nil {
+		t.Fatalf("WriteThrough failed: %v", err)
+	}
+
+	// One failed write is enough to open this breaker
+	inner.updateErr = errors.New("mongodb down")
+	if err := repo.Update(ctx, session); err == nil {
+		t.Fatal("Expected the update to fail")
+	}
+	if err := repo.Update(ctx, session); !middleware.IsCircuitBreakerError(err) {
+		t.Fatalf("Expected the open breaker to reject the update, got %v", err)
+	}
+
+	reads := inner.reads
+	cached, err := repo.GetByID(ctx, "s1")
+	if err != nil || cached == nil || cached.SessionID != "s1" {
+		t.Fatalf("Expected the cached session while the breaker is open, got %v, %v", cached, err)
+	}
+	if inner.reads != reads {
+		t.Error("Expected MongoDB not to be read while the breaker is open")
+	}
+
+	if _, err := repo.GetByID(ctx, "uncached"); !middleware.IsCircuitBreakerError(err) {
+		t.Errorf("Expected the breaker error for a session the cache doesn't hold, got %v", err)
+	}
+}
+
+func TestCircuitBreakingPlayerPathRepository_DefaultPathWhileOpen(t *testing.T) {
+	ctx := context.Background()
+	breaker := newTestBreaker(Neo4jBreaker)
+	breaker.Execute(ctx, func(ctx context.Context) error { return errors.New("neo4j down") })
+	repo := NewCircuitBreakingPlayerPathRepository(nil, breaker)
+
+	path, err := repo.GetPlayerPath(ctx, "p1")
+	if err != nil || path == nil || path.PlayerID != "p1" || path.CurrentPosition != 0 {
+		t.Errorf("Expected the default path while the breaker is open, got %+v, %v", path, err)
+	}
+	if doorID, err := repo.GetNextDoor(ctx, "p1", 50); err != nil || doorID != "" {
+		t.Errorf("Expected no graph door while the breaker is open, got %q, %v", doorID, err)
+	}
+	if err := repo.UpdatePlayerPosition(ctx, "p1", "door-1"); !middleware.IsCircuitBreakerError(err) {
+		t.Errorf("Expected writes to fail fast while the breaker is open, got %v", err)
+	}
+}
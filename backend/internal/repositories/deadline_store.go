@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// doorDeadlinesKey is the sorted set of pending door deadlines, scored by due time in milliseconds
+const doorDeadlinesKey = "scheduler:door-deadlines"
+
+// DeadlineStore persists door deadlines so they survive restarts and are shared by every
+// backend instance
+type DeadlineStore interface {
+	Schedule(ctx context.Context, deadline models.DoorDeadline) error
+	Due(ctx context.Context, now time.Time, limit int) ([]models.DoorDeadline, error)
+	Claim(ctx context.Context, deadline models.DoorDeadline) (bool, error)
+	Cancel(ctx context.Context, sessionID, doorID string) error
+	Deadline(ctx context.Context, sessionID, doorID string) (time.Time, bool, error)
+}
+
+// RedisDeadlineStore keeps deadlines in a single Redis sorted set
+type RedisDeadlineStore struct {
+	redis *database.RedisClient
+}
+
+// NewDeadlineStore creates a Redis-backed deadline store
+func NewDeadlineStore(redis *database.RedisClient) DeadlineStore {
+	return &RedisDeadlineStore{redis: redis}
+}
+
+// Schedule records a deadline, replacing any earlier one for the same door
+func (s *RedisDeadlineStore) Schedule(ctx context.Context, deadline models.DoorDeadline) error {
+	err := s.redis.Client.ZAdd(ctx, doorDeadlinesKey, redis.Z{
+		Score:  float64(deadline.At.UnixMilli()),
+		Member: deadlineMember(deadline.SessionID, deadline.DoorID),
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to schedule door deadline: %w", err)
+	}
+	return nil
+}
+
+// Due returns up to limit deadlines that have passed, earliest first
+func (s *RedisDeadlineStore) Due(ctx context.Context, now time.Time, limit int) ([]models.DoorDeadline, error) {
+	results, err := s.redis.Client.ZRangeByScoreWithScores(ctx, doorDeadlinesKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due door deadlines: %w", err)
+	}
+
+	deadlines := make([]models.DoorDeadline, 0, len(results))
+	for _, result := range results {
+		member, _ := result.Member.(string)
+		sessionID, doorID, ok := strings.Cut(member, "/")
+		if !ok {
+			fmt.Printf("Warning: dropping malformed door deadline %q\n", member)
+			s.redis.Client.ZRem(ctx, doorDeadlinesKey, member)
+			continue
+		}
+
+		deadlines = append(deadlines, models.DoorDeadline{
+			SessionID: sessionID,
+			DoorID:    doorID,
+			At:        time.UnixMilli(int64(result.Score)),
+		})
+	}
+
+	return deadlines, nil
+}
+
+// Claim removes a due deadline and reports whether this caller removed it, so exactly one
+// backend instance fires each deadline
+func (s *RedisDeadlineStore) Claim(ctx context.Context, deadline models.DoorDeadline) (bool, error) {
+	removed, err := s.redis.Client.ZRem(ctx, doorDeadlinesKey, deadlineMember(deadline.SessionID, deadline.DoorID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim door deadline: %w", err)
+	}
+	return removed == 1, nil
+}
+
+// Cancel drops a pending deadline
+func (s *RedisDeadlineStore) Cancel(ctx context.Context, sessionID, doorID string) error {
+	if err := s.redis.Client.ZRem(ctx, doorDeadlinesKey, deadlineMember(sessionID, doorID)).Err(); err != nil {
+		return fmt.Errorf("failed to cancel door deadline: %w", err)
+	}
+	return nil
+}
+
+// Deadline returns when the door's pending deadline falls due, and false if it has none
+func (s *RedisDeadlineStore) Deadline(ctx context.Context, sessionID, doorID string) (time.Time, bool, error) {
+	score, err := s.redis.Client.ZScore(ctx, doorDeadlinesKey, deadlineMember(sessionID, doorID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get door deadline: %w", err)
+	}
+	return time.UnixMilli(int64(score)), true, nil
+}
+
+// deadlineMember encodes a door deadline as a sorted set member; session IDs never contain "/"
+func deadlineMember(sessionID, doorID string) string {
+	return sessionID + "/" + doorID
+}
@@ -2,24 +2,31 @@ package repositories
 
 import (
 	"context"
+	"dumdoors-backend/internal/accessibility"
 	"dumdoors-backend/internal/database"
 	"dumdoors-backend/internal/models"
+	"errors"
 	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // DoorRepository interface defines operations for doors
 type DoorRepository interface {
 	Create(ctx context.Context, door *models.Door) error
+	// CreateMany stores all of the doors or, if any insert fails, none of them
+	CreateMany(ctx context.Context, doors []*models.Door) error
 	GetByID(ctx context.Context, doorID string) (*models.Door, error)
 	GetByTheme(ctx context.Context, theme string) ([]*models.Door, error)
 	GetByDifficulty(ctx context.Context, difficulty int) ([]*models.Door, error)
 	Update(ctx context.Context, door *models.Door) error
 	Delete(ctx context.Context, doorID string) error
+	List(ctx context.Context, filter models.DoorFilter) ([]*models.Door, int64, error)
+	UpdateCalibration(ctx context.Context, doorID string, empiricalDifficulty float64, responseCount int) error
 }
 
 // DoorRepositoryImpl implements the DoorRepository interface
@@ -39,6 +46,7 @@ func NewDoorRepository(mongodb *database.MongoClient, redis *database.RedisClien
 // Create creates a new door
 func (r *DoorRepositoryImpl) Create(ctx context.Context, door *models.Door) error {
 	door.CreatedAt = time.Now()
+	accessibility.Describe(door)
 	
 	result, err := r.collection.InsertOne(ctx, door)
 	if err != nil {
@@ -55,6 +63,74 @@ func (r *DoorRepositoryImpl) Create(ctx context.Context, door *models.Door) erro
 	return nil
 }
 
+// CreateMany inserts the doors in a single transaction. Deployments without transaction support
+// (a standalone MongoDB) insert them in order and delete any inserted before a failure.
+func (r *DoorRepositoryImpl) CreateMany(ctx context.Context, doors []*models.Door) error {
+	if len(doors) == 0 {
+		return nil
+	}
+	
+	now := time.Now()
+	documents := make([]interface{}, len(doors))
+	for i, door := range doors {
+		door.CreatedAt = now
+		accessibility.Describe(door)
+		documents[i] = door
+	}
+	
+	session, err := r.collection.Database().Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start door import session: %w", err)
+	}
+	defer session.EndSession(ctx)
+	
+	var inserted []interface{}
+	_, err = session.WithTransaction(ctx, func(txCtx mongo.SessionContext) (interface{}, error) {
+		result, err := r.collection.InsertMany(txCtx, documents)
+		if err != nil {
+			return nil, err
+		}
+		inserted = result.InsertedIDs
+		return nil, nil
+	})
+	if transactionsUnsupported(err) {
+		inserted, err = r.insertAllOrNone(ctx, documents)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to import doors: %w", err)
+	}
+	
+	for i, door := range doors {
+		door.ID = inserted[i].(primitive.ObjectID)
+		if err := r.cacheDoor(ctx, door); err != nil {
+			fmt.Printf("Warning: failed to cache door in Redis: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// insertAllOrNone inserts the documents in order, removing those already inserted if one fails
+func (r *DoorRepositoryImpl) insertAllOrNone(ctx context.Context, documents []interface{}) ([]interface{}, error) {
+	result, err := r.collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(true))
+	if err == nil {
+		return result.InsertedIDs, nil
+	}
+	
+	if result != nil && len(result.InsertedIDs) > 0 {
+		if _, cleanupErr := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": result.InsertedIDs}}); cleanupErr != nil {
+			fmt.Printf("Warning: failed to remove partially imported doors: %v\n", cleanupErr)
+		}
+	}
+	return nil, err
+}
+
+// transactionsUnsupported reports whether err is MongoDB refusing a transaction because it isn't
+// running as a replica set
+func transactionsUnsupported(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 20 // IllegalOperation
+}
+
 // GetByID retrieves a door by ID
 func (r *DoorRepositoryImpl) GetByID(ctx context.Context, doorID string) (*models.Door, error) {
 	// Try to get from Redis cache first
@@ -161,6 +237,64 @@ func (r *DoorRepositoryImpl) Delete(ctx context.Context, doorID string) error {
 	return nil
 }
 
+// UpdateCalibration records a door's empirical difficulty, leaving the rest of the door untouched
+func (r *DoorRepositoryImpl) UpdateCalibration(ctx context.Context, doorID string, empiricalDifficulty float64, responseCount int) error {
+	filter := bson.M{"doorId": doorID}
+	update := bson.M{"$set": bson.M{
+		"empiricalDifficulty": empiricalDifficulty,
+		"responseCount":       responseCount,
+		"calibratedAt":        time.Now(),
+	}}
+	
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to update door calibration: %w", err)
+	}
+	
+	// The cached copy is stale now
+	if err := r.redis.Delete(ctx, fmt.Sprintf("door:%s", doorID)); err != nil {
+		fmt.Printf("Warning: failed to remove door from cache: %v\n", err)
+	}
+	
+	return nil
+}
+
+// List returns one page of doors matching the filter, newest first, with the total match count
+func (r *DoorRepositoryImpl) List(ctx context.Context, filter models.DoorFilter) ([]*models.Door, int64, error) {
+	query := bson.M{}
+	if filter.Theme != "" {
+		query["theme"] = filter.Theme
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Difficulty != 0 {
+		query["difficulty"] = filter.Difficulty
+	}
+	
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count doors: %w", err)
+	}
+	
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.PageSize)).
+		SetLimit(int64(filter.PageSize))
+	
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list doors: %w", err)
+	}
+	defer cursor.Close(ctx)
+	
+	doors := []*models.Door{}
+	if err := cursor.All(ctx, &doors); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode doors: %w", err)
+	}
+	
+	return doors, total, nil
+}
+
 // Helper methods for Redis caching
 func (r *DoorRepositoryImpl) cacheDoor(ctx context.Context, door *models.Door) error {
 	// Cache for 24 hours since doors don't change frequently
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DoorBank holds pre-generated doors that have not been served yet, in one first-in, first-out
+// pool per theme, difficulty and locale
+type DoorBank interface {
+	Push(ctx context.Context, pool models.DoorPool, door *models.Door) error
+	Pop(ctx context.Context, pool models.DoorPool) (*models.Door, error)
+	List(ctx context.Context, pool models.DoorPool) ([]*models.Door, error)
+}
+
+// RedisDoorBank keeps each pool as a Redis list of JSON doors
+type RedisDoorBank struct {
+	redis *database.RedisClient
+}
+
+// NewDoorBank creates a Redis-backed door bank
+func NewDoorBank(redis *database.RedisClient) DoorBank {
+	return &RedisDoorBank{redis: redis}
+}
+
+// Push adds a door to the back of its pool
+func (b *RedisDoorBank) Push(ctx context.Context, pool models.DoorPool, door *models.Door) error {
+	data, err := json.Marshal(door)
+	if err != nil {
+		return fmt.Errorf("failed to marshal banked door: %w", err)
+	}
+
+	if err := b.redis.Client.RPush(ctx, doorBankKey(pool), data).Err(); err != nil {
+		return fmt.Errorf("failed to bank door: %w", err)
+	}
+	return nil
+}
+
+// Pop removes and returns the oldest door in the pool, or nil if the pool is empty. Each door
+// is handed out once, however many instances share the bank.
+func (b *RedisDoorBank) Pop(ctx context.Context, pool models.DoorPool) (*models.Door, error) {
+	data, err := b.redis.Client.LPop(ctx, doorBankKey(pool)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to take banked door: %w", err)
+	}
+
+	var door models.Door
+	if err := json.Unmarshal([]byte(data), &door); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal banked door: %w", err)
+	}
+	return &door, nil
+}
+
+// List returns the doors waiting in the pool, oldest first
+func (b *RedisDoorBank) List(ctx context.Context, pool models.DoorPool) ([]*models.Door, error) {
+	entries, err := b.redis.Client.LRange(ctx, doorBankKey(pool), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list banked doors: %w", err)
+	}
+
+	doors := make([]*models.Door, 0, len(entries))
+	for _, entry := range entries {
+		var door models.Door
+		if err := json.Unmarshal([]byte(entry), &door); err != nil {
+			fmt.Printf("Warning: skipping unreadable banked door in pool %s: %v\n", pool, err)
+			continue
+		}
+		doors = append(doors, &door)
+	}
+	return doors, nil
+}
+
+// doorBankKey returns the Redis key for a pool of banked doors
+func doorBankKey(pool models.DoorPool) string {
+	return "door_bank:" + pool.String()
+}
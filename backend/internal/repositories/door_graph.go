@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// DoorGraphRepository persists the door graphs players traverse in Neo4j
+type DoorGraphRepository interface {
+	// GetSignature returns the signature of the theme's stored graph, or "" if it has none
+	GetSignature(ctx context.Context, theme string) (string, error)
+	// SaveGraph replaces the theme's graph with the one given
+	SaveGraph(ctx context.Context, graph *models.PathGraph) error
+	// PlacePlayer puts the player at the entry of the theme's graph
+	PlacePlayer(ctx context.Context, playerID, theme string) error
+	// GetGraph reads the theme's stored graph, or nil if it has none
+	GetGraph(ctx context.Context, theme string) (*models.PathGraph, error)
+	// GetPlayerPosition returns the ID of the node the player is at, or "" if they aren't in a graph
+	GetPlayerPosition(ctx context.Context, playerID string) (string, error)
+}
+
+// DoorGraphRepositoryImpl implements the DoorGraphRepository interface
+type DoorGraphRepositoryImpl struct {
+	neo4j *database.Neo4jClient
+}
+
+// NewDoorGraphRepository creates a new door graph repository
+func NewDoorGraphRepository(neo4j *database.Neo4jClient) DoorGraphRepository {
+	return &DoorGraphRepositoryImpl{
+		neo4j: neo4j,
+	}
+}
+
+// GetSignature reads the signature stored on the theme's entry node
+func (r *DoorGraphRepositoryImpl) GetSignature(ctx context.Context, theme string) (string, error) {
+	query := `
+		MATCH (entry:PathStart {theme: $theme})
+		RETURN entry.signature as signature
+	`
+
+	result, err := r.neo4j.ExecuteQuery(ctx, query, map[string]interface{}{"theme": theme})
+	if err != nil {
+		return "", fmt.Errorf("failed to get door graph signature: %w", err)
+	}
+	if len(result.Records) == 0 {
+		return "", nil
+	}
+
+	signature, _ := result.Records[0].Get("signature")
+	value, _ := signature.(string)
+	return value, nil
+}
+
+// SaveGraph writes the theme's doors, LEADS_TO relationships and paths in one transaction,
+// dropping the relationships of the graph it replaces
+func (r *DoorGraphRepositoryImpl) SaveGraph(ctx context.Context, graph *models.PathGraph) error {
+	doors := make([]map[string]interface{}, 0, len(graph.Doors))
+	for _, door := range graph.Doors {
+		doors = append(doors, map[string]interface{}{
+			"id":         door.DoorID,
+			"content":    door.Content,
+			"difficulty": door.Difficulty,
+		})
+	}
+
+	edges := make([]map[string]interface{}, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		edges = append(edges, map[string]interface{}{
+			"from":           edge.From,
+			"to":             edge.To,
+			"scoreThreshold": edge.ScoreThreshold,
+		})
+	}
+
+	params := map[string]interface{}{
+		"theme":     graph.Theme,
+		"entryId":   graph.EntryID,
+		"signature": graph.Signature,
+		"doors":     doors,
+		"edges":     edges,
+		"shortId":   graph.Theme + ":short",
+		"longId":    graph.Theme + ":long",
+		"shortPath": graph.ShortPath,
+		"longPath":  graph.LongPath,
+	}
+
+	statements := []string{
+		// The entry node carries the signature, so it is written last once everything else is in place
+		`MERGE (entry:PathStart {theme: $theme})
+		 SET entry.id = $entryId`,
+		`MATCH ()-[old:LEADS_TO {theme: $theme}]->()
+		 DELETE old`,
+		`MATCH (path:Path)-[old:CONTAINS]->()
+		 WHERE path.id IN [$shortId, $longId]
+		 DELETE old`,
+		`UNWIND $doors as door
+		 MERGE (d:Door {id: door.id})
+		 SET d.theme = $theme, d.content = door.content, d.difficulty = door.difficulty`,
+		`UNWIND $edges as edge
+		 MATCH (from) WHERE (from:Door OR from:PathStart) AND from.id = edge.from
+		 MATCH (to:Door {id: edge.to})
+		 CREATE (from)-[:LEADS_TO {scoreThreshold: edge.scoreThreshold, theme: $theme}]->(to)`,
+		`MERGE (path:Path {id: $shortId})
+		 SET path.theme = $theme, path.totalDoors = size($shortPath), path.difficulty = 'easy'
+		 WITH path
+		 UNWIND $shortPath as doorId
+		 MATCH (d:Door {id: doorId})
+		 MERGE (path)-[:CONTAINS]->(d)`,
+		`MERGE (path:Path {id: $longId})
+		 SET path.theme = $theme, path.totalDoors = size($longPath), path.difficulty = 'hard'
+		 WITH path
+		 UNWIND $longPath as doorId
+		 MATCH (d:Door {id: doorId})
+		 MERGE (path)-[:CONTAINS]->(d)`,
+		`MATCH (entry:PathStart {theme: $theme})
+		 SET entry.signature = $signature, entry.updatedAt = datetime()`,
+	}
+
+	session := r.neo4j.CreateSession(ctx)
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		for _, statement := range statements {
+			if _, err := tx.Run(ctx, statement, params); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save door graph: %w", err)
+	}
+	return nil
+}
+
+// PlacePlayer moves the player to the theme's entry node, restarting their walk through the graph
+func (r *DoorGraphRepositoryImpl) PlacePlayer(ctx context.Context, playerID, theme string) error {
+	query := `
+		MATCH (entry:PathStart {theme: $theme})
+		MERGE (p:Player {id: $playerId})
+		WITH p, entry
+		OPTIONAL MATCH (p)-[r:CURRENTLY_AT]->()
+		DELETE r
+		WITH DISTINCT p, entry
+		CREATE (p)-[:CURRENTLY_AT]->(entry)
+		SET p.currentPosition = 0
+		RETURN p
+	`
+
+	params := map[string]interface{}{
+		"playerId": playerID,
+		"theme":    theme,
+	}
+
+	if _, err := r.neo4j.ExecuteQuery(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to place player in door graph: %w", err)
+	}
+	return nil
+}
+
+// GetGraph reads the theme's entry, doors and LEADS_TO relationships, with the doors of its
+// short path. The long path is the chain of doors joined without a score threshold.
+func (r *DoorGraphRepositoryImpl) GetGraph(ctx context.Context, theme string) (*models.PathGraph, error) {
+	query := `
+		MATCH (entry:PathStart {theme: $theme})
+		OPTIONAL MATCH (from)-[r:LEADS_TO {theme: $theme}]->(to:Door)
+		WITH entry, collect({from: from.id, to: to.id, scoreThreshold: r.scoreThreshold}) as edges,
+		     collect(DISTINCT to) as doors
+		OPTIONAL MATCH (:Path {id: $shortId})-[:CONTAINS]->(short:Door)
+		RETURN entry.id as entryId, entry.signature as signature, edges,
+		       [door IN doors | {id: door.id, content: door.content, difficulty: door.difficulty}] as doors,
+		       collect(short.id) as shortPath
+	`
+
+	result, err := r.neo4j.ExecuteQuery(ctx, query, map[string]interface{}{
+		"theme":   theme,
+		"shortId": theme + ":short",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get door graph: %w", err)
+	}
+	if len(result.Records) == 0 {
+		return nil, nil
+	}
+
+	record := result.Records[0]
+	graph := &models.PathGraph{Theme: theme}
+	if value, ok := record.Get("entryId"); ok {
+		graph.EntryID, _ = value.(string)
+	}
+	if value, ok := record.Get("signature"); ok {
+		graph.Signature, _ = value.(string)
+	}
+
+	edges, _ := record.Get("edges")
+	for _, item := range graphMaps(edges) {
+		to, _ := item["to"].(string)
+		if to == "" {
+			continue // the entry of a graph without doors
+		}
+		from, _ := item["from"].(string)
+		threshold, _ := item["scoreThreshold"].(int64)
+		graph.Edges = append(graph.Edges, models.PathEdge{From: from, To: to, ScoreThreshold: int(threshold)})
+	}
+
+	doors, _ := record.Get("doors")
+	for _, item := range graphMaps(doors) {
+		id, _ := item["id"].(string)
+		content, _ := item["content"].(string)
+		difficulty, _ := item["difficulty"].(int64)
+		graph.Doors = append(graph.Doors, &models.Door{DoorID: id, Content: content, Theme: theme, Difficulty: int(difficulty)})
+	}
+
+	shortPath, _ := record.Get("shortPath")
+	if ids, ok := shortPath.([]interface{}); ok {
+		for _, id := range ids {
+			if doorID, ok := id.(string); ok {
+				graph.ShortPath = append(graph.ShortPath, doorID)
+			}
+		}
+	}
+
+	graph.LongPath = longPath(graph)
+	return graph, nil
+}
+
+// GetPlayerPosition reads the node the player is CURRENTLY_AT
+func (r *DoorGraphRepositoryImpl) GetPlayerPosition(ctx context.Context, playerID string) (string, error) {
+	query := `
+		MATCH (p:Player {id: $playerId})-[:CURRENTLY_AT]->(current)
+		RETURN current.id as position
+	`
+
+	result, err := r.neo4j.ExecuteQuery(ctx, query, map[string]interface{}{"playerId": playerID})
+	if err != nil {
+		return "", fmt.Errorf("failed to get player position: %w", err)
+	}
+	if len(result.Records) == 0 {
+		return "", nil
+	}
+
+	position, _ := result.Records[0].Get("position")
+	value, _ := position.(string)
+	return value, nil
+}
+
+// graphMaps reads a list of Cypher maps
+func graphMaps(value interface{}) []map[string]interface{} {
+	items, _ := value.([]interface{})
+	maps := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			maps = append(maps, m)
+		}
+	}
+	return maps
+}
+
+// longPath follows the graph's unconditional edges from its entry, which is how the long path
+// was laid out
+func longPath(graph *models.PathGraph) []string {
+	next := make(map[string]string, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if edge.ScoreThreshold == 0 {
+			next[edge.From] = edge.To
+		}
+	}
+
+	var path []string
+	seen := make(map[string]bool)
+	for id := next[graph.EntryID]; id != "" && !seen[id]; id = next[id] {
+		seen[id] = true
+		path = append(path, id)
+	}
+	return path
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DoorScoreRepository stores the scores each door's responses receive
+type DoorScoreRepository interface {
+	RecordScore(ctx context.Context, doorID string, score int) error
+	ListUpdatedSince(ctx context.Context, since time.Time) ([]*models.DoorScores, error)
+}
+
+// DoorScoreRepositoryImpl implements the DoorScoreRepository interface
+type DoorScoreRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewDoorScoreRepository creates a new door score repository
+func NewDoorScoreRepository(mongodb *database.MongoClient) DoorScoreRepository {
+	return &DoorScoreRepositoryImpl{
+		collection: mongodb.GetCollection("door_scores"),
+	}
+}
+
+// RecordScore adds a score to the door's distribution, keeping only the most recent ones
+func (r *DoorScoreRepositoryImpl) RecordScore(ctx context.Context, doorID string, score int) error {
+	update := bson.M{
+		"$push": bson.M{"scores": bson.M{"$each": []int{score}, "$slice": -models.MaxDoorScores}},
+		"$inc":  bson.M{"count": 1},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"doorId": doorID}, update, opts); err != nil {
+		return fmt.Errorf("failed to record door score: %w", err)
+	}
+	return nil
+}
+
+// ListUpdatedSince returns the distributions of doors scored since the given time
+func (r *DoorScoreRepositoryImpl) ListUpdatedSince(ctx context.Context, since time.Time) ([]*models.DoorScores, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"updatedAt": bson.M{"$gt": since}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find door scores: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var scores []*models.DoorScores
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, fmt.Errorf("failed to decode door scores: %w", err)
+	}
+	return scores, nil
+}
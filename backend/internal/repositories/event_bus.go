@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"fmt"
+)
+
+// websocketEventsChannel is the Redis pub/sub channel carrying WebSocket events between instances
+const websocketEventsChannel = "websocket:events"
+
+// EventBus fans WebSocket events out to every backend instance
+type EventBus interface {
+	Publish(ctx context.Context, payload []byte) error
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
+
+// RedisEventBus implements EventBus with Redis pub/sub
+type RedisEventBus struct {
+	redis *database.RedisClient
+}
+
+// NewEventBus creates a Redis-backed event bus
+func NewEventBus(redis *database.RedisClient) EventBus {
+	return &RedisEventBus{redis: redis}
+}
+
+// Publish sends a payload to every subscribed instance
+func (b *RedisEventBus) Publish(ctx context.Context, payload []byte) error {
+	if err := b.redis.Client.Publish(ctx, websocketEventsChannel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish websocket event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe delivers published payloads to handler until the context is cancelled
+func (b *RedisEventBus) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	pubsub := b.redis.Client.Subscribe(ctx, websocketEventsChannel)
+	defer pubsub.Close()
+
+	// Wait for the subscription to be confirmed so no events are missed after startup
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to websocket events: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handler([]byte(message.Payload))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidExportCursor is returned when an export is resumed from a cursor it never issued
+var ErrInvalidExportCursor = errors.New("invalid export cursor")
+
+// ExportRepository reads leaderboard entries and sessions in insertion order, one chunk at a
+// time, so exports never hold a whole collection in memory
+type ExportRepository interface {
+	// LeaderboardChunk returns up to limit entries completed since filter.Since, after the filter's cursor
+	LeaderboardChunk(ctx context.Context, filter models.ExportFilter, limit int) ([]*models.LeaderboardEntry, error)
+	// SessionChunk returns up to limit sessions created since filter.Since, after the filter's cursor
+	SessionChunk(ctx context.Context, filter models.ExportFilter, limit int) ([]*models.GameSession, error)
+}
+
+// ExportRepositoryImpl implements the ExportRepository interface
+type ExportRepositoryImpl struct {
+	leaderboard *mongo.Collection
+	sessions    *mongo.Collection
+}
+
+// NewExportRepository creates a new export repository
+func NewExportRepository(mongodb *database.MongoClient) ExportRepository {
+	return &ExportRepositoryImpl{
+		leaderboard: mongodb.GetCollection("leaderboard_entries"),
+		sessions:    mongodb.GetCollection("game_sessions"),
+	}
+}
+
+// LeaderboardChunk returns the next chunk of leaderboard entries
+func (r *ExportRepositoryImpl) LeaderboardChunk(ctx context.Context, filter models.ExportFilter, limit int) ([]*models.LeaderboardEntry, error) {
+	query, err := exportQuery(filter, "completedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*models.LeaderboardEntry{}
+	if err := findChunk(ctx, r.leaderboard, query, limit, &entries); err != nil {
+		return nil, fmt.Errorf("failed to export leaderboard entries: %w", err)
+	}
+	return entries, nil
+}
+
+// SessionChunk returns the next chunk of sessions
+func (r *ExportRepositoryImpl) SessionChunk(ctx context.Context, filter models.ExportFilter, limit int) ([]*models.GameSession, error) {
+	query, err := exportQuery(filter, "createdAt")
+	if err != nil {
+		return nil, err
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	sessions := []*models.GameSession{}
+	if err := findChunk(ctx, r.sessions, query, limit, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to export sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// exportQuery matches the documents after the cursor whose timeField is at or after filter.Since.
+// Cursors are document IDs, which grow with insertion order.
+func exportQuery(filter models.ExportFilter, timeField string) (bson.M, error) {
+	query := bson.M{}
+	if filter.After != "" {
+		after, err := primitive.ObjectIDFromHex(filter.After)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidExportCursor, filter.After)
+		}
+		query["_id"] = bson.M{"$gt": after}
+	}
+	if filter.Since != nil {
+		query[timeField] = bson.M{"$gte": *filter.Since}
+	}
+	return query, nil
+}
+
+// findChunk decodes up to limit documents matching query, in ID order, into results
+func findChunk(ctx context.Context, collection *mongo.Collection, query bson.M, limit int, results interface{}) error {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	return cursor.All(ctx, results)
+}
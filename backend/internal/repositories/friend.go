@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FriendRepository stores each player's friend list
+type FriendRepository interface {
+	// Add puts friendID on the player's friend list, reporting false if it was already there
+	Add(ctx context.Context, playerID, friendID string) (bool, error)
+	// Remove takes friendID off the player's friend list, reporting false if it was not there
+	Remove(ctx context.Context, playerID, friendID string) (bool, error)
+	List(ctx context.Context, playerID string) ([]models.Friendship, error)
+	Count(ctx context.Context, playerID string) (int64, error)
+}
+
+// FriendRepositoryImpl implements the FriendRepository interface
+type FriendRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewFriendRepository creates a new friend repository
+func NewFriendRepository(mongodb *database.MongoClient) FriendRepository {
+	return &FriendRepositoryImpl{
+		collection: mongodb.GetCollection("friends"),
+	}
+}
+
+// Add inserts the friendship unless it already exists
+func (r *FriendRepositoryImpl) Add(ctx context.Context, playerID, friendID string) (bool, error) {
+	friendship := models.Friendship{PlayerID: playerID, FriendID: friendID, CreatedAt: time.Now()}
+	filter := bson.M{"playerId": playerID, "friendId": friendID}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": friendship}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to add friend: %w", err)
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// Remove deletes the friendship
+func (r *FriendRepositoryImpl) Remove(ctx context.Context, playerID, friendID string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"playerId": playerID, "friendId": friendID})
+	if err != nil {
+		return false, fmt.Errorf("failed to remove friend: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// List returns the player's friends in the order they were added
+func (r *FriendRepositoryImpl) List(ctx context.Context, playerID string) ([]models.Friendship, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"playerId": playerID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list friends: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	friends := []models.Friendship{}
+	if err := cursor.All(ctx, &friends); err != nil {
+		return nil, fmt.Errorf("failed to decode friends: %w", err)
+	}
+	return friends, nil
+}
+
+// Count returns how many friends the player has
+func (r *FriendRepositoryImpl) Count(ctx context.Context, playerID string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"playerId": playerID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count friends: %w", err)
+	}
+	return count, nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"dumdoors-backend/internal/database"
 	"dumdoors-backend/internal/models"
+	"errors"
 	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // GameSessionRepository interface defines operations for game sessions
@@ -19,27 +21,42 @@ type GameSessionRepository interface {
 	Update(ctx context.Context, session *models.GameSession) error
 	Delete(ctx context.Context, sessionID string) error
 	GetActiveSessionsByStatus(ctx context.Context, status models.GameStatus) ([]*models.GameSession, error)
+	FindOpenSessions(ctx context.Context, limit int) ([]*models.GameSession, error)
 	AddPlayerToSession(ctx context.Context, sessionID string, player models.PlayerInfo) error
 	UpdatePlayerInSession(ctx context.Context, sessionID string, player models.PlayerInfo) error
+	// AppendPlayerResponse atomically adds a response to its player's record and total score, returning
+	// the updated session, or nil if the player isn't in the session or has already answered the door
+	AppendPlayerResponse(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error)
+	// SetResponseScore atomically replaces a response still waiting for its score and adds the score to
+	// its player's total, returning the updated session, or nil if the response is no longer pending
+	SetResponseScore(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error)
 }
 
+// ErrSessionConflict is returned by Update when another write changed the session's state since
+// it was read, to something other than the update's own
+var ErrSessionConflict = errors.New("game session was modified concurrently")
+
+// maxUpdateAttempts bounds how often Update retries after losing a race with another write
+const maxUpdateAttempts = 5
+
 // GameSessionRepositoryImpl implements the GameSessionRepository interface
 type GameSessionRepositoryImpl struct {
 	collection *mongo.Collection
-	redis      *database.RedisClient
+	cache      *SessionCache
 }
 
 // NewGameSessionRepository creates a new game session repository
 func NewGameSessionRepository(mongodb *database.MongoClient, redis *database.RedisClient) GameSessionRepository {
 	return &GameSessionRepositoryImpl{
 		collection: mongodb.GetCollection("game_sessions"),
-		redis:      redis,
+		cache:      NewSessionCache(NewRedisSessionCacheStore(redis), DefaultSessionCacheTTL),
 	}
 }
 
 // Create creates a new game session
 func (r *GameSessionRepositoryImpl) Create(ctx context.Context, session *models.GameSession) error {
 	session.CreatedAt = time.Now()
+	session.LastActiveAt = session.CreatedAt
 	
 	result, err := r.collection.InsertOne(ctx, session)
 	if err != nil {
@@ -47,9 +64,10 @@ func (r *GameSessionRepositoryImpl) Create(ctx context.Context, session *models.
 	}
 	
 	session.ID = result.InsertedID.(primitive.ObjectID)
+	session.MarkLoaded()
 	
 	// Cache session in Redis for quick access
-	if err := r.cacheSession(ctx, session); err != nil {
+	if err := r.cache.WriteThrough(ctx, session); err != nil {
 		// Log error but don't fail the operation
 		fmt.Printf("Warning: failed to cache session in Redis: %v\n", err)
 	}
@@ -60,8 +78,12 @@ func (r *GameSessionRepositoryImpl) Create(ctx context.Context, session *models.
 // GetByID retrieves a game session by ID
 func (r *GameSessionRepositoryImpl) GetByID(ctx context.Context, sessionID string) (*models.GameSession, error) {
 	// Try to get from Redis cache first
-	if session, err := r.getCachedSession(ctx, sessionID); err == nil && session != nil {
-		return session, nil
+	cached, revision, cacheErr := r.cache.Get(ctx, sessionID)
+	if cacheErr != nil {
+		fmt.Printf("Warning: failed to read session cache: %v\n", cacheErr)
+	} else if cached != nil {
+		cached.MarkLoaded()
+		return cached, nil
 	}
 	
 	// If not in cache, get from MongoDB
@@ -75,27 +97,60 @@ func (r *GameSessionRepositoryImpl) GetByID(ctx context.Context, sessionID strin
 		}
 		return nil, fmt.Errorf("failed to get game session: %w", err)
 	}
+	session.MarkLoaded()
 	
-	// Cache the session for future requests
-	if err := r.cacheSession(ctx, &session); err != nil {
-		fmt.Printf("Warning: failed to cache session in Redis: %v\n", err)
+	// Cache the session for future requests, tagged with the revision seen before reading so a
+	// write that lands in between leaves this copy stale
+	if cacheErr == nil {
+		if err := r.cache.Put(ctx, &session, revision); err != nil {
+			fmt.Printf("Warning: failed to cache session in Redis: %v\n", err)
+		}
 	}
 	
 	return &session, nil
 }
 
-// Update updates an existing game session
+// Update replaces a game session, as long as nobody wrote it since it was read. Otherwise the
+// players, kicks, responses and scores saved meanwhile are merged into the session and the write
+// is retried, so a transition never drops a concurrent join or submission. A concurrent change
+// to the session's state can't be merged and fails the update with ErrSessionConflict.
 func (r *GameSessionRepositoryImpl) Update(ctx context.Context, session *models.GameSession) error {
-	filter := bson.M{"sessionId": session.SessionID}
-	update := bson.M{"$set": session}
+	session.LastActiveAt = time.Now()
 	
-	_, err := r.collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return fmt.Errorf("failed to update game session: %w", err)
+	for attempt := 1; ; attempt++ {
+		read := session.Version
+		session.Version = read + 1
+		result, err := r.collection.UpdateOne(ctx, versionFilter(session.SessionID, read), bson.M{"$set": session})
+		if err != nil {
+			session.Version = read
+			return fmt.Errorf("failed to update game session: %w", err)
+		}
+		if result.MatchedCount > 0 {
+			break
+		}
+		
+		var stored models.GameSession
+		if err := r.collection.FindOne(ctx, bson.M{"sessionId": session.SessionID}).Decode(&stored); err != nil {
+			session.Version = read
+			if err == mongo.ErrNoDocuments {
+				return nil // Deleted meanwhile
+			}
+			return fmt.Errorf("failed to reload game session: %w", err)
+		}
+		if attempt == maxUpdateAttempts {
+			session.Version = read
+			return fmt.Errorf("failed to update game session: still contended after %d attempts", attempt)
+		}
+		if !session.MergeConcurrentWrites(&stored) {
+			session.Version = read
+			return ErrSessionConflict
+		}
+		session.Version = stored.Version
 	}
+	session.MarkLoaded()
 	
-	// Update cache
-	if err := r.cacheSession(ctx, session); err != nil {
+	// Write through to the cache, invalidating any older copy
+	if err := r.cache.WriteThrough(ctx, session); err != nil {
 		fmt.Printf("Warning: failed to update session cache: %v\n", err)
 	}
 	
@@ -112,7 +167,7 @@ func (r *GameSessionRepositoryImpl) Delete(ctx context.Context, sessionID string
 	}
 	
 	// Remove from cache
-	if err := r.redis.DeleteGameSession(ctx, sessionID); err != nil {
+	if _, err := r.cache.Invalidate(ctx, sessionID); err != nil {
 		fmt.Printf("Warning: failed to remove session from cache: %v\n", err)
 	}
 	
@@ -135,16 +190,53 @@ func (r *GameSessionRepositoryImpl) GetActiveSessionsByStatus(ctx context.Contex
 		if err := cursor.Decode(&session); err != nil {
 			return nil, fmt.Errorf("failed to decode session: %w", err)
 		}
+		session.MarkLoaded()
 		sessions = append(sessions, &session)
 	}
 	
 	return sessions, nil
 }
 
+// FindOpenSessions returns public multiplayer sessions that are waiting for players and have
+// room for more, newest first
+func (r *GameSessionRepositoryImpl) FindOpenSessions(ctx context.Context, limit int) ([]*models.GameSession, error) {
+	filter := bson.M{
+		"status":             models.GameStatusWaiting,
+		"mode":               models.GameModeMultiplayer,
+		"settings.isPrivate": bson.M{"$ne": true},
+		"locked":             bson.M{"$ne": true},
+		// A session is full once the player at the last index exists
+		fmt.Sprintf("players.%d", models.MaxMultiplayerPlayers-1): bson.M{"$exists": false},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(int64(limit))
+	
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open sessions: %w", err)
+	}
+	defer cursor.Close(ctx)
+	
+	var sessions []*models.GameSession
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to decode open sessions: %w", err)
+	}
+	for _, session := range sessions {
+		session.MarkLoaded()
+	}
+	
+	return sessions, nil
+}
+
 // AddPlayerToSession adds a player to an existing session
 func (r *GameSessionRepositoryImpl) AddPlayerToSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
 	filter := bson.M{"sessionId": sessionID}
-	update := bson.M{"$push": bson.M{"players": player}}
+	update := bson.M{
+		"$push": bson.M{"players": player},
+		"$set":  bson.M{"lastActiveAt": time.Now()},
+		"$inc":  bson.M{"version": 1},
+	}
 	
 	_, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -152,7 +244,7 @@ func (r *GameSessionRepositoryImpl) AddPlayerToSession(ctx context.Context, sess
 	}
 	
 	// Invalidate cache to force refresh
-	if err := r.redis.DeleteGameSession(ctx, sessionID); err != nil {
+	if _, err := r.cache.Invalidate(ctx, sessionID); err != nil {
 		fmt.Printf("Warning: failed to invalidate session cache: %v\n", err)
 	}
 	
@@ -165,7 +257,10 @@ func (r *GameSessionRepositoryImpl) UpdatePlayerInSession(ctx context.Context, s
 		"sessionId":       sessionID,
 		"players.playerId": player.PlayerID,
 	}
-	update := bson.M{"$set": bson.M{"players.$": player}}
+	update := bson.M{
+		"$set": bson.M{"players.$": player, "lastActiveAt": time.Now()},
+		"$inc": bson.M{"version": 1},
+	}
 	
 	_, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -173,27 +268,92 @@ func (r *GameSessionRepositoryImpl) UpdatePlayerInSession(ctx context.Context, s
 	}
 	
 	// Invalidate cache to force refresh
-	if err := r.redis.DeleteGameSession(ctx, sessionID); err != nil {
+	if _, err := r.cache.Invalidate(ctx, sessionID); err != nil {
 		fmt.Printf("Warning: failed to invalidate session cache: %v\n", err)
 	}
 	
 	return nil
 }
 
-// Helper methods for Redis caching
-func (r *GameSessionRepositoryImpl) cacheSession(ctx context.Context, session *models.GameSession) error {
-	// Cache for 1 hour
-	return r.redis.SetGameSession(ctx, session.SessionID, session, time.Hour)
+// AppendPlayerResponse pushes the response onto the player's responses and increments their total
+// score in a single update, so concurrent submissions never overwrite each other
+func (r *GameSessionRepositoryImpl) AppendPlayerResponse(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	// Players stored without responses hold null, which $push can't extend
+	_, err := r.collection.UpdateOne(ctx, bson.M{
+		"sessionId": sessionID,
+		"players":   bson.M{"$elemMatch": bson.M{"playerId": response.PlayerID, "responses": nil}},
+	}, bson.M{"$set": bson.M{"players.$.responses": []models.PlayerResponse{}}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare player responses: %w", err)
+	}
+	
+	filter := bson.M{
+		"sessionId": sessionID,
+		"players": bson.M{"$elemMatch": bson.M{
+			"playerId":         response.PlayerID,
+			"responses.doorId": bson.M{"$ne": response.DoorID},
+		}},
+	}
+	update := bson.M{
+		"$push": bson.M{"players.$.responses": response},
+		"$inc":  bson.M{"players.$.totalScore": response.AIScore, "version": 1},
+		"$set":  bson.M{"lastActiveAt": time.Now()},
+	}
+	
+	session, err := r.findAndUpdate(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add response to session: %w", err)
+	}
+	return session, nil
 }
 
-func (r *GameSessionRepositoryImpl) getCachedSession(ctx context.Context, sessionID string) (*models.GameSession, error) {
-	data, err := r.redis.GetGameSession(ctx, sessionID)
+// SetResponseScore replaces a pending response and increments its player's total score in a single update
+func (r *GameSessionRepositoryImpl) SetResponseScore(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	filter := bson.M{
+		"sessionId": sessionID,
+		"players": bson.M{"$elemMatch": bson.M{
+			"playerId":  response.PlayerID,
+			"responses": bson.M{"$elemMatch": bson.M{"responseId": response.ResponseID, "scoringPending": true}},
+		}},
+	}
+	update := bson.M{
+		"$set": bson.M{"players.$.responses.$[scored]": response, "lastActiveAt": time.Now()},
+		"$inc": bson.M{"players.$.totalScore": response.AIScore, "version": 1},
+	}
+	arrayFilters := options.ArrayFilters{Filters: []interface{}{bson.M{"scored.responseId": response.ResponseID}}}
+	
+	session, err := r.findAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetArrayFilters(arrayFilters))
 	if err != nil {
+		return nil, fmt.Errorf("failed to save response score: %w", err)
+	}
+	return session, nil
+}
+
+// versionFilter matches a session still at the version it was read at. Sessions saved before
+// versions were tracked have none.
+func versionFilter(sessionID string, version int64) bson.M {
+	if version == 0 {
+		return bson.M{"sessionId": sessionID, "version": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"sessionId": sessionID, "version": version}
+}
+
+// findAndUpdate applies an update to the matching session and writes the result through to the
+// cache, returning nil if no session matched
+func (r *GameSessionRepositoryImpl) findAndUpdate(ctx context.Context, filter, update bson.M, opts ...*options.FindOneAndUpdateOptions) (*models.GameSession, error) {
+	opts = append(opts, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	
+	var session models.GameSession
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts...).Decode(&session); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
 		return nil, err
 	}
+	session.MarkLoaded()
 	
-	// In a real implementation, you would unmarshal the JSON data
-	// For now, return nil to indicate cache miss
-	_ = data
-	return nil, fmt.Errorf("cache implementation pending")
-}
\ No newline at end of file
+	if err := r.cache.WriteThrough(ctx, &session); err != nil {
+		fmt.Printf("Warning: failed to update session cache: %v\n", err)
+	}
+	return &session, nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultIdempotencyTTL is how long a completed request is replayed for
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// RedisIdempotencyStore keeps idempotent request outcomes in Redis, shared by every instance
+type RedisIdempotencyStore struct {
+	redis *database.RedisClient
+}
+
+// NewIdempotencyStore creates a Redis-backed idempotency store
+func NewIdempotencyStore(redis *database.RedisClient) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{redis: redis}
+}
+
+// Reserve claims key for a new request. If the key is already taken, the existing record is
+// returned instead: either a pending request or the completed response to replay.
+func (s *RedisIdempotencyStore) Reserve(ctx context.Context, key, requestHash string, lockTTL time.Duration) (*models.IdempotentResponse, bool, error) {
+	pending, err := json.Marshal(&models.IdempotentResponse{
+		Pending:     true,
+		RequestHash: requestHash,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	reserved, err := s.redis.Client.SetNX(ctx, idempotencyKey(key), pending, lockTTL).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if reserved {
+		return nil, true, nil
+	}
+
+	data, err := s.redis.Client.Get(ctx, idempotencyKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// The previous holder released the key between the two calls; let the caller retry
+		return &models.IdempotentResponse{Pending: true, RequestHash: requestHash}, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+
+	var record models.IdempotentResponse
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+	return &record, false, nil
+}
+
+// Complete stores the response of a reserved request for replay
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, key string, response *models.IdempotentResponse, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+	if err := s.redis.Client.Set(ctx, idempotencyKey(key), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save idempotency record: %w", err)
+	}
+	return nil
+}
+
+// Release frees a reserved key without storing a response, so the request can be retried
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key string) error {
+	if err := s.redis.Client.Del(ctx, idempotencyKey(key)).Err(); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// idempotencyKey namespaces an idempotency key in Redis
+func idempotencyKey(key string) string {
+	return "idempotency:" + key
+}
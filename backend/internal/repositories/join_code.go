@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultJoinCodeTTL keeps a join code reserved for longer than any session stays open
+const DefaultJoinCodeTTL = 24 * time.Hour
+
+// JoinCodeStore maps short join codes to the sessions they were issued for
+type JoinCodeStore interface {
+	Reserve(ctx context.Context, code, sessionID string) (bool, error)
+	Resolve(ctx context.Context, code string) (string, error)
+	Release(ctx context.Context, code string) error
+}
+
+// RedisJoinCodeStore keeps join codes in Redis so codes are unique across instances
+type RedisJoinCodeStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewJoinCodeStore creates a Redis-backed join code store; codes expire after ttl
+func NewJoinCodeStore(redis *database.RedisClient, ttl time.Duration) JoinCodeStore {
+	if ttl <= 0 {
+		ttl = DefaultJoinCodeTTL
+	}
+	return &RedisJoinCodeStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Reserve claims a code for a session, reporting false if another session already holds it
+func (s *RedisJoinCodeStore) Reserve(ctx context.Context, code, sessionID string) (bool, error) {
+	reserved, err := s.redis.Client.SetNX(ctx, joinCodeKey(code), sessionID, s.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve join code: %w", err)
+	}
+	return reserved, nil
+}
+
+// Resolve returns the session a code was issued for, or an empty string for unknown codes
+func (s *RedisJoinCodeStore) Resolve(ctx context.Context, code string) (string, error) {
+	sessionID, err := s.redis.Get(ctx, joinCodeKey(code))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to resolve join code: %w", err)
+	}
+	return sessionID, nil
+}
+
+// Release frees a code so it can be issued again
+func (s *RedisJoinCodeStore) Release(ctx context.Context, code string) error {
+	if err := s.redis.Delete(ctx, joinCodeKey(code)); err != nil {
+		return fmt.Errorf("failed to release join code: %w", err)
+	}
+	return nil
+}
+
+// joinCodeKey returns the Redis key for a join code
+func joinCodeKey(code string) string {
+	return fmt.Sprintf("joincode:%s", code)
+}
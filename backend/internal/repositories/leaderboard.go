@@ -19,15 +19,18 @@ type LeaderboardRepository interface {
 	GetHighestAverageScores(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error)
 	GetMostCompleted(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error)
 	GetRecentWinners(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error)
+	GetBestOfTotals(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error)
 	GetGlobalLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (*models.GlobalLeaderboard, error)
 	GetLeaderboardStats(ctx context.Context) (*models.LeaderboardStats, error)
 	GetPlayerRank(ctx context.Context, playerID string, category string) (int, error)
+	SetShadowed(ctx context.Context, playerID string, shadowed bool) (int64, error)
 }
 
 // LeaderboardRepositoryImpl implements the LeaderboardRepository interface
 type LeaderboardRepositoryImpl struct {
 	collection *mongo.Collection
 	redis      *database.RedisClient
+	cache      *LeaderboardCache
 }
 
 // NewLeaderboardRepository creates a new leaderboard repository
@@ -35,6 +38,7 @@ func NewLeaderboardRepository(mongodb *database.MongoClient, redis *database.Red
 	return &LeaderboardRepositoryImpl{
 		collection: mongodb.GetCollection("leaderboard_entries"),
 		redis:      redis,
+		cache:      NewLeaderboardCache(NewRedisLeaderboardCacheStore(redis), DefaultLeaderboardCacheTTL),
 	}
 }
 
@@ -47,10 +51,17 @@ func (r *LeaderboardRepositoryImpl) AddEntry(ctx context.Context, entry *models.
 		return fmt.Errorf("failed to add leaderboard entry: %w", err)
 	}
 	
-	// Update Redis leaderboards for fast access
-	if err := r.updateRedisLeaderboards(ctx, entry); err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to update Redis leaderboards: %v\n", err)
+	// Update Redis leaderboards for fast access; shadowed players are never listed
+	if !entry.Shadowed {
+		if err := r.updateRedisLeaderboards(ctx, entry); err != nil {
+			// Log error but don't fail the operation
+			fmt.Printf("Warning: failed to update Redis leaderboards: %v\n", err)
+		}
+	}
+	
+	// Every cached leaderboard may now be out of date
+	if err := r.cache.Invalidate(ctx); err != nil {
+		fmt.Printf("Warning: %v\n", err)
 	}
 	
 	return nil
@@ -59,7 +70,8 @@ func (r *LeaderboardRepositoryImpl) AddEntry(ctx context.Context, entry *models.
 // GetFastestCompletions retrieves the fastest completion times
 func (r *LeaderboardRepositoryImpl) GetFastestCompletions(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
 	// Try Redis cache first
-	if entries, err := r.getCachedLeaderboard(ctx, "fastest", filter); err == nil && len(entries) > 0 {
+	entries, generation, hit := r.getCachedLeaderboard(ctx, "fastest", filter)
+	if hit {
 		return entries, nil
 	}
 	
@@ -77,13 +89,12 @@ func (r *LeaderboardRepositoryImpl) GetFastestCompletions(ctx context.Context, f
 	}
 	defer cursor.Close(ctx)
 	
-	var entries []models.LeaderboardEntry
 	if err := cursor.All(ctx, &entries); err != nil {
 		return nil, fmt.Errorf("failed to decode fastest completions: %w", err)
 	}
 	
 	// Cache results
-	if err := r.cacheLeaderboard(ctx, "fastest", filter, entries); err != nil {
+	if err := r.cacheLeaderboard(ctx, "fastest", filter, generation, entries); err != nil {
 		fmt.Printf("Warning: failed to cache fastest completions: %v\n", err)
 	}
 	
@@ -93,7 +104,8 @@ func (r *LeaderboardRepositoryImpl) GetFastestCompletions(ctx context.Context, f
 // GetHighestAverageScores retrieves the highest average scores
 func (r *LeaderboardRepositoryImpl) GetHighestAverageScores(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
 	// Try Redis cache first
-	if entries, err := r.getCachedLeaderboard(ctx, "highest_avg", filter); err == nil && len(entries) > 0 {
+	entries, generation, hit := r.getCachedLeaderboard(ctx, "highest_avg", filter)
+	if hit {
 		return entries, nil
 	}
 	
@@ -111,13 +123,12 @@ func (r *LeaderboardRepositoryImpl) GetHighestAverageScores(ctx context.Context,
 	}
 	defer cursor.Close(ctx)
 	
-	var entries []models.LeaderboardEntry
 	if err := cursor.All(ctx, &entries); err != nil {
 		return nil, fmt.Errorf("failed to decode highest average scores: %w", err)
 	}
 	
 	// Cache results
-	if err := r.cacheLeaderboard(ctx, "highest_avg", filter, entries); err != nil {
+	if err := r.cacheLeaderboard(ctx, "highest_avg", filter, generation, entries); err != nil {
 		fmt.Printf("Warning: failed to cache highest average scores: %v\n", err)
 	}
 	
@@ -127,7 +138,8 @@ func (r *LeaderboardRepositoryImpl) GetHighestAverageScores(ctx context.Context,
 // GetMostCompleted retrieves players with most completed games
 func (r *LeaderboardRepositoryImpl) GetMostCompleted(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
 	// Try Redis cache first
-	if entries, err := r.getCachedLeaderboard(ctx, "most_completed", filter); err == nil && len(entries) > 0 {
+	entries, generation, hit := r.getCachedLeaderboard(ctx, "most_completed", filter)
+	if hit {
 		return entries, nil
 	}
 	
@@ -145,13 +157,12 @@ func (r *LeaderboardRepositoryImpl) GetMostCompleted(ctx context.Context, filter
 	}
 	defer cursor.Close(ctx)
 	
-	var entries []models.LeaderboardEntry
 	if err := cursor.All(ctx, &entries); err != nil {
 		return nil, fmt.Errorf("failed to decode most completed: %w", err)
 	}
 	
 	// Cache results
-	if err := r.cacheLeaderboard(ctx, "most_completed", filter, entries); err != nil {
+	if err := r.cacheLeaderboard(ctx, "most_completed", filter, generation, entries); err != nil {
 		fmt.Printf("Warning: failed to cache most completed: %v\n", err)
 	}
 	
@@ -161,7 +172,8 @@ func (r *LeaderboardRepositoryImpl) GetMostCompleted(ctx context.Context, filter
 // GetRecentWinners retrieves recent game winners
 func (r *LeaderboardRepositoryImpl) GetRecentWinners(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
 	// Try Redis cache first
-	if entries, err := r.getCachedLeaderboard(ctx, "recent_winners", filter); err == nil && len(entries) > 0 {
+	entries, generation, hit := r.getCachedLeaderboard(ctx, "recent_winners", filter)
+	if hit {
 		return entries, nil
 	}
 	
@@ -179,19 +191,54 @@ func (r *LeaderboardRepositoryImpl) GetRecentWinners(ctx context.Context, filter
 	}
 	defer cursor.Close(ctx)
 	
-	var entries []models.LeaderboardEntry
 	if err := cursor.All(ctx, &entries); err != nil {
 		return nil, fmt.Errorf("failed to decode recent winners: %w", err)
 	}
 	
 	// Cache results
-	if err := r.cacheLeaderboard(ctx, "recent_winners", filter, entries); err != nil {
+	if err := r.cacheLeaderboard(ctx, "recent_winners", filter, generation, entries); err != nil {
 		fmt.Printf("Warning: failed to cache recent winners: %v\n", err)
 	}
 	
 	return entries, nil
 }
 
+// GetBestOfTotals retrieves the highest total scores in best-of matches. Every player in a
+// match answers the same number of doors, so totals are comparable between matches of a length.
+func (r *LeaderboardRepositoryImpl) GetBestOfTotals(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
+	// Try Redis cache first
+	entries, generation, hit := r.getCachedLeaderboard(ctx, "best_of", filter)
+	if hit {
+		return entries, nil
+	}
+	
+	// Build MongoDB filter, keeping only best-of matches
+	mongoFilter := r.buildMongoFilter(filter)
+	mongoFilter["format"] = models.MatchFormatBestOf
+	
+	// Sort by total score (descending - highest first)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "totalScore", Value: -1}}).
+		SetLimit(int64(filter.Limit))
+	
+	cursor, err := r.collection.Find(ctx, mongoFilter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get best-of totals: %w", err)
+	}
+	defer cursor.Close(ctx)
+	
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode best-of totals: %w", err)
+	}
+	
+	// Cache results
+	if err := r.cacheLeaderboard(ctx, "best_of", filter, generation, entries); err != nil {
+		fmt.Printf("Warning: failed to cache best-of totals: %v\n", err)
+	}
+	
+	return entries, nil
+}
+
 // GetGlobalLeaderboard retrieves all leaderboard categories
 func (r *LeaderboardRepositoryImpl) GetGlobalLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (*models.GlobalLeaderboard, error) {
 	// Get all categories concurrently
@@ -199,8 +246,9 @@ func (r *LeaderboardRepositoryImpl) GetGlobalLeaderboard(ctx context.Context, fi
 	highestCh := make(chan []models.LeaderboardEntry, 1)
 	mostCompletedCh := make(chan []models.LeaderboardEntry, 1)
 	recentCh := make(chan []models.LeaderboardEntry, 1)
+	bestOfCh := make(chan []models.LeaderboardEntry, 1)
 	
-	errCh := make(chan error, 4)
+	errCh := make(chan error, 5)
 	
 	// Fetch fastest completions
 	go func() {
@@ -242,9 +290,19 @@ func (r *LeaderboardRepositoryImpl) GetGlobalLeaderboard(ctx context.Context, fi
 		recentCh <- entries
 	}()
 	
+	// Fetch best-of totals
+	go func() {
+		entries, err := r.GetBestOfTotals(ctx, filter)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		bestOfCh <- entries
+	}()
+	
 	// Collect results
 	leaderboard := &models.GlobalLeaderboard{}
-	for i := 0; i < 4; i++ {
+	for i := 0; i < 5; i++ {
 		select {
 		case fastest := <-fastestCh:
 			leaderboard.FastestCompletions = fastest
@@ -254,6 +312,8 @@ func (r *LeaderboardRepositoryImpl) GetGlobalLeaderboard(ctx context.Context, fi
 			leaderboard.MostCompleted = mostCompleted
 		case recent := <-recentCh:
 			leaderboard.RecentWinners = recent
+		case bestOf := <-bestOfCh:
+			leaderboard.BestOfTotals = bestOf
 		case err := <-errCh:
 			return nil, fmt.Errorf("failed to get global leaderboard: %w", err)
 		}
@@ -265,12 +325,16 @@ func (r *LeaderboardRepositoryImpl) GetGlobalLeaderboard(ctx context.Context, fi
 // GetLeaderboardStats retrieves aggregated leaderboard statistics
 func (r *LeaderboardRepositoryImpl) GetLeaderboardStats(ctx context.Context) (*models.LeaderboardStats, error) {
 	// Try Redis cache first
-	if stats, err := r.getCachedStats(ctx); err == nil && stats != nil {
-		return stats, nil
+	cachedStats, generation := r.getCachedStats(ctx)
+	if cachedStats != nil {
+		return cachedStats, nil
 	}
 	
 	// Aggregate statistics from MongoDB
 	pipeline := []bson.M{
+		{
+			"$match": notShadowed(),
+		},
 		{
 			"$group": bson.M{
 				"_id":                   nil,
@@ -314,8 +378,8 @@ func (r *LeaderboardRepositoryImpl) GetLeaderboardStats(ctx context.Context) (*m
 		stats.MostActivePlayer = mostActivePlayer
 	}
 	
-	// Cache stats for 5 minutes
-	if err := r.cacheStats(ctx, stats); err != nil {
+	// Cache stats until the next entry is added, or for 5 minutes at most
+	if err := r.cacheStats(ctx, generation, stats); err != nil {
 		fmt.Printf("Warning: failed to cache leaderboard stats: %v\n", err)
 	}
 	
@@ -337,15 +401,25 @@ func (r *LeaderboardRepositoryImpl) GetPlayerRank(ctx context.Context, playerID
 	case "most_completed":
 		sortField = "doorsCompleted"
 		sortOrder = -1 // descending
+	case "best_of":
+		sortField = "totalScore"
+		sortOrder = -1 // descending
 	default:
 		return 0, fmt.Errorf("invalid category: %s", category)
 	}
 	
+	// Best-of matches are ranked only against each other, path games only against path games
+	format := bson.M{"$ne": models.MatchFormatBestOf}
+	if category == "best_of" {
+		format = bson.M{"$eq": models.MatchFormatBestOf}
+	}
+	
 	// Count entries better than this player
 	pipeline := []bson.M{
 		{
 			"$match": bson.M{
 				"playerId": playerID,
+				"format":   format,
 			},
 		},
 		{
@@ -355,6 +429,8 @@ func (r *LeaderboardRepositoryImpl) GetPlayerRank(ctx context.Context, playerID
 				"pipeline": []bson.M{
 					{
 						"$match": bson.M{
+							"shadowed": bson.M{"$ne": true},
+							"format":   format,
 							"$expr": bson.M{
 								"$cond": bson.M{
 									"if": bson.M{"$eq": []interface{}{sortOrder, 1}},
@@ -393,10 +469,44 @@ func (r *LeaderboardRepositoryImpl) GetPlayerRank(ctx context.Context, playerID
 	return int(result[0]["rank"].(int32)), nil
 }
 
+// SetShadowed hides every entry of the player from the leaderboards, or shows them again, and
+// returns how many entries changed. The player's own rank is still counted as if they were listed.
+func (r *LeaderboardRepositoryImpl) SetShadowed(ctx context.Context, playerID string, shadowed bool) (int64, error) {
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"playerId": playerID},
+		bson.M{"$set": bson.M{"shadowed": shadowed}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update shadowed leaderboard entries: %w", err)
+	}
+	
+	if shadowed {
+		for _, name := range []string{"fastest_completions", "highest_averages", "most_completed", "best_of_totals"} {
+			if err := r.redis.Client.ZRem(ctx, name, playerID).Err(); err != nil {
+				fmt.Printf("Warning: failed to remove shadowed player from Redis leaderboard %s: %v\n", name, err)
+			}
+		}
+	}
+	
+	if err := r.cache.Invalidate(ctx); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	
+	return result.ModifiedCount, nil
+}
+
 // Helper methods
 
+// notShadowed matches the entries of players who are not shadow-excluded
+func notShadowed() bson.M {
+	return bson.M{"shadowed": bson.M{"$ne": true}}
+}
+
+// buildMongoFilter matches the listed path-game entries the filter selects; best-of entries
+// are only listed in their own category
 func (r *LeaderboardRepositoryImpl) buildMongoFilter(filter models.LeaderboardFilter) bson.M {
-	mongoFilter := bson.M{}
+	mongoFilter := notShadowed()
+	mongoFilter["format"] = bson.M{"$ne": models.MatchFormatBestOf}
 	
 	if filter.GameMode != nil {
 		mongoFilter["gameMode"] = *filter.GameMode
@@ -406,21 +516,19 @@ func (r *LeaderboardRepositoryImpl) buildMongoFilter(filter models.LeaderboardFi
 		mongoFilter["theme"] = *filter.Theme
 	}
 	
+	if filter.PlayerIDs != nil {
+		mongoFilter["playerId"] = bson.M{"$in": filter.PlayerIDs}
+	}
+	
 	if filter.TimeRange != nil {
-		var timeFilter time.Time
-		now := time.Now()
-		
-		switch *filter.TimeRange {
-		case "day":
-			timeFilter = now.AddDate(0, 0, -1)
-		case "week":
-			timeFilter = now.AddDate(0, 0, -7)
-		case "month":
-			timeFilter = now.AddDate(0, -1, 0)
-		default:
-			// "all" or invalid - no time filter
+		var location *time.Location
+		if filter.Timezone != nil {
+			if parsed, err := models.ParseTimezone(*filter.Timezone); err == nil {
+				location = parsed
+			}
 		}
 		
+		timeFilter := timeRangeStart(*filter.TimeRange, time.Now(), location)
 		if !timeFilter.IsZero() {
 			mongoFilter["completedAt"] = bson.M{"$gte": timeFilter}
 		}
@@ -429,7 +537,43 @@ func (r *LeaderboardRepositoryImpl) buildMongoFilter(filter models.LeaderboardFi
 	return mongoFilter
 }
 
+// timeRangeStart returns the earliest completion time included in a time range. Without a
+// location the ranges are rolling windows ending now; with one they are calendar periods in
+// that zone (today, this week from Monday, this month). "all" and unknown ranges return zero.
+func timeRangeStart(timeRange string, now time.Time, location *time.Location) time.Time {
+	if location == nil {
+		switch timeRange {
+		case "day":
+			return now.AddDate(0, 0, -1)
+		case "week":
+			return now.AddDate(0, 0, -7)
+		case "month":
+			return now.AddDate(0, -1, 0)
+		}
+		return time.Time{}
+	}
+	
+	local := now.In(location)
+	startOfDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	
+	switch timeRange {
+	case "day":
+		return startOfDay
+	case "week":
+		daysSinceMonday := (int(local.Weekday()) + 6) % 7
+		return startOfDay.AddDate(0, 0, -daysSinceMonday)
+	case "month":
+		return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, location)
+	}
+	return time.Time{}
+}
+
 func (r *LeaderboardRepositoryImpl) updateRedisLeaderboards(ctx context.Context, entry *models.LeaderboardEntry) error {
+	// Best-of matches have a leaderboard of their own
+	if entry.Format == models.MatchFormatBestOf {
+		return r.redis.AddToLeaderboard(ctx, "best_of_totals", entry.PlayerID, float64(entry.TotalScore))
+	}
+	
 	// Update fastest completions leaderboard
 	if err := r.redis.AddToLeaderboard(ctx, "fastest_completions", entry.PlayerID, float64(entry.CompletionTime.Nanoseconds())); err != nil {
 		return err
@@ -448,31 +592,43 @@ func (r *LeaderboardRepositoryImpl) updateRedisLeaderboards(ctx context.Context,
 	return nil
 }
 
-func (r *LeaderboardRepositoryImpl) getCachedLeaderboard(ctx context.Context, category string, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
-	// Implementation would deserialize cached leaderboard data
-	// For now, return empty to force MongoDB query
-	return nil, fmt.Errorf("cache miss")
+// getCachedLeaderboard reads a leaderboard from the cache, returning the cache generation to
+// tag the result with on a miss. Cache errors are treated as misses.
+func (r *LeaderboardRepositoryImpl) getCachedLeaderboard(ctx context.Context, category string, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, int64, bool) {
+	entries, generation, hit, err := r.cache.GetEntries(ctx, category, filter)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return nil, 0, false
+	}
+	return entries, generation, hit
 }
 
-func (r *LeaderboardRepositoryImpl) cacheLeaderboard(ctx context.Context, category string, filter models.LeaderboardFilter, entries []models.LeaderboardEntry) error {
-	// Implementation would serialize and cache leaderboard data
-	// Cache for 5 minutes
-	return nil
+// cacheLeaderboard caches a leaderboard read from MongoDB at the given cache generation
+func (r *LeaderboardRepositoryImpl) cacheLeaderboard(ctx context.Context, category string, filter models.LeaderboardFilter, generation int64, entries []models.LeaderboardEntry) error {
+	return r.cache.PutEntries(ctx, category, filter, generation, entries)
 }
 
-func (r *LeaderboardRepositoryImpl) getCachedStats(ctx context.Context) (*models.LeaderboardStats, error) {
-	// Implementation would deserialize cached stats
-	return nil, fmt.Errorf("cache miss")
+// getCachedStats reads the leaderboard stats from the cache, returning nil on a miss
+func (r *LeaderboardRepositoryImpl) getCachedStats(ctx context.Context) (*models.LeaderboardStats, int64) {
+	stats, generation, err := r.cache.GetStats(ctx)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return nil, 0
+	}
+	return stats, generation
 }
 
-func (r *LeaderboardRepositoryImpl) cacheStats(ctx context.Context, stats *models.LeaderboardStats) error {
-	// Implementation would serialize and cache stats
-	return nil
+// cacheStats caches leaderboard stats aggregated at the given cache generation
+func (r *LeaderboardRepositoryImpl) cacheStats(ctx context.Context, generation int64, stats *models.LeaderboardStats) error {
+	return r.cache.PutStats(ctx, generation, stats)
 }
 
 func (r *LeaderboardRepositoryImpl) getMostActivePlayer(ctx context.Context) (string, error) {
 	// Find player with most games completed
 	pipeline := []bson.M{
+		{
+			"$match": notShadowed(),
+		},
 		{
 			"$group": bson.M{
 				"_id":   "$playerId",
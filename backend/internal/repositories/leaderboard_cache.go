@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Leaderboard cache settings
+const (
+	DefaultLeaderboardCacheTTL = 5 * time.Minute
+	leaderboardCacheFormat     = 1 // bump when the cached entry or stats shape changes incompatibly
+	leaderboardGenerationKey   = "leaderboard:cache:generation"
+	leaderboardStatsCacheKey   = "leaderboard:cache:stats"
+)
+
+// LeaderboardCacheStore is the key-value store behind the leaderboard cache. The store keeps
+// one generation for every cached leaderboard; Bump advances it when a new entry is added.
+type LeaderboardCacheStore interface {
+	Load(ctx context.Context, key string) (entry string, generation int64, err error)
+	Save(ctx context.Context, key, entry string, ttl time.Duration) error
+	Bump(ctx context.Context) (int64, error)
+}
+
+// cachedLeaderboard is the versioned envelope stored for each leaderboard query and for the stats
+type cachedLeaderboard struct {
+	Format     int                       `json:"format"`
+	Generation int64                     `json:"generation"`
+	Entries    []models.LeaderboardEntry `json:"entries"`
+	Stats      *models.LeaderboardStats  `json:"stats,omitempty"`
+}
+
+// LeaderboardCache caches leaderboard queries and stats as JSON, keyed by category and filter.
+// Entries are tagged with the generation they were read at, and adding a leaderboard entry
+// moves the generation on, so every cached query is dropped at once and a reader that loaded
+// a leaderboard just before the write can never cache the old result.
+type LeaderboardCache struct {
+	store LeaderboardCacheStore
+	ttl   time.Duration
+}
+
+// NewLeaderboardCache creates a leaderboard cache keeping entries for ttl
+func NewLeaderboardCache(store LeaderboardCacheStore, ttl time.Duration) *LeaderboardCache {
+	if ttl <= 0 {
+		ttl = DefaultLeaderboardCacheTTL
+	}
+	return &LeaderboardCache{store: store, ttl: ttl}
+}
+
+// GetEntries returns the cached leaderboard for the category and filter, reporting whether it
+// was found, along with the current generation. Pass the generation to PutEntries after
+// loading the leaderboard from the database.
+func (c *LeaderboardCache) GetEntries(ctx context.Context, category string, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, int64, bool, error) {
+	cached, generation, err := c.load(ctx, leaderboardCacheKey(category, filter))
+	if err != nil || cached == nil || cached.Entries == nil {
+		return nil, generation, false, err
+	}
+	return cached.Entries, generation, true, nil
+}
+
+// PutEntries caches a leaderboard as of the given generation
+func (c *LeaderboardCache) PutEntries(ctx context.Context, category string, filter models.LeaderboardFilter, generation int64, entries []models.LeaderboardEntry) error {
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+	return c.save(ctx, leaderboardCacheKey(category, filter), cachedLeaderboard{Entries: entries}, generation)
+}
+
+// GetStats returns the cached leaderboard stats, or nil on a miss, along with the current generation
+func (c *LeaderboardCache) GetStats(ctx context.Context) (*models.LeaderboardStats, int64, error) {
+	cached, generation, err := c.load(ctx, leaderboardStatsCacheKey)
+	if err != nil || cached == nil {
+		return nil, generation, err
+	}
+	return cached.Stats, generation, nil
+}
+
+// PutStats caches leaderboard stats as of the given generation
+func (c *LeaderboardCache) PutStats(ctx context.Context, generation int64, stats *models.LeaderboardStats) error {
+	return c.save(ctx, leaderboardStatsCacheKey, cachedLeaderboard{Stats: stats}, generation)
+}
+
+// Invalidate marks every cached leaderboard and the stats stale
+func (c *LeaderboardCache) Invalidate(ctx context.Context) error {
+	if _, err := c.store.Bump(ctx); err != nil {
+		return fmt.Errorf("failed to invalidate leaderboard cache: %w", err)
+	}
+	return nil
+}
+
+// load reads an envelope, returning nil if it is missing, unreadable or from an older generation
+func (c *LeaderboardCache) load(ctx context.Context, key string) (*cachedLeaderboard, int64, error) {
+	entry, generation, err := c.store.Load(ctx, key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read leaderboard cache: %w", err)
+	}
+	if entry == "" {
+		return nil, generation, nil
+	}
+
+	var cached cachedLeaderboard
+	if err := json.Unmarshal([]byte(entry), &cached); err != nil {
+		fmt.Printf("Warning: discarding unreadable cached leaderboard %s: %v\n", key, err)
+		return nil, generation, nil
+	}
+	if cached.Format != leaderboardCacheFormat || cached.Generation != generation {
+		return nil, generation, nil
+	}
+	return &cached, generation, nil
+}
+
+// save writes an envelope tagged with the generation it was read at
+func (c *LeaderboardCache) save(ctx context.Context, key string, cached cachedLeaderboard, generation int64) error {
+	cached.Format = leaderboardCacheFormat
+	cached.Generation = generation
+
+	entry, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to encode cached leaderboard: %w", err)
+	}
+	if err := c.store.Save(ctx, key, string(entry), c.ttl); err != nil {
+		return fmt.Errorf("failed to write leaderboard cache: %w", err)
+	}
+	return nil
+}
+
+// leaderboardCacheKey is the cache key for a category queried with a filter. Every filter field
+// is part of the key, so differently filtered leaderboards never share an entry.
+func leaderboardCacheKey(category string, filter models.LeaderboardFilter) string {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(filter.Limit))
+	if filter.GameMode != nil {
+		params.Set("mode", string(*filter.GameMode))
+	}
+	if filter.Theme != nil {
+		params.Set("theme", *filter.Theme)
+	}
+	if filter.TimeRange != nil {
+		params.Set("range", *filter.TimeRange)
+	}
+	if filter.Timezone != nil {
+		params.Set("tz", *filter.Timezone)
+	}
+	if filter.PlayerIDs != nil {
+		// Friend lists change, so the key follows the players themselves rather than whose friends they are
+		params.Set("players", playerSetDigest(filter.PlayerIDs))
+	}
+	return fmt.Sprintf("leaderboard:cache:%s:%s", category, params.Encode())
+}
+
+// playerSetDigest is a short digest of a set of player IDs that ignores their order
+func playerSetDigest(playerIDs []string) string {
+	sorted := append([]string(nil), playerIDs...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:12])
+}
+
+// RedisLeaderboardCacheStore keeps cached leaderboards and their generation in Redis
+type RedisLeaderboardCacheStore struct {
+	redis *database.RedisClient
+}
+
+// NewRedisLeaderboardCacheStore creates a Redis-backed leaderboard cache store
+func NewRedisLeaderboardCacheStore(redis *database.RedisClient) LeaderboardCacheStore {
+	return &RedisLeaderboardCacheStore{redis: redis}
+}
+
+// Load reads a cached entry and the current generation in one round trip
+func (s *RedisLeaderboardCacheStore) Load(ctx context.Context, key string) (string, int64, error) {
+	values, err := s.redis.Client.MGet(ctx, key, leaderboardGenerationKey).Result()
+	if err != nil {
+		return "", 0, err
+	}
+
+	entry, _ := values[0].(string)
+
+	var generation int64
+	if raw, ok := values[1].(string); ok {
+		if generation, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return "", 0, fmt.Errorf("invalid leaderboard cache generation %q: %w", raw, err)
+		}
+	}
+	return entry, generation, nil
+}
+
+// Save stores a cached entry; entries from older generations are left to expire
+func (s *RedisLeaderboardCacheStore) Save(ctx context.Context, key, entry string, ttl time.Duration) error {
+	return s.redis.Client.Set(ctx, key, entry, ttl).Err()
+}
+
+// Bump advances the generation, dropping the cached stats along with it
+func (s *RedisLeaderboardCacheStore) Bump(ctx context.Context) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := s.redis.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, leaderboardGenerationKey)
+		pipe.Del(ctx, leaderboardStatsCacheKey)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
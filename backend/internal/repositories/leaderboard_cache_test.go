@@ -0,0 +1,249 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryLeaderboardCacheStore is an in-memory LeaderboardCacheStore safe for concurrent use
+type memoryLeaderboardCacheStore struct {
+	mu         sync.Mutex
+	entries    map[string]string
+	generation int64
+	loadErr    error
+}
+
+func newMemoryLeaderboardCacheStore() *memoryLeaderboardCacheStore {
+	return &memoryLeaderboardCacheStore{entries: make(map[string]string)}
+}
+
+func (m *memoryLeaderboardCacheStore) Load(ctx context.Context, key string) (string, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loadErr != nil {
+		return "", 0, m.loadErr
+	}
+	return m.entries[key], m.generation, nil
+}
+
+func (m *memoryLeaderboardCacheStore) Save(ctx context.Context, key, entry string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry
+	return nil
+}
+
+func (m *memoryLeaderboardCacheStore) Bump(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.generation++
+	delete(m.entries, leaderboardStatsCacheKey)
+	return m.generation, nil
+}
+
+func TestLeaderboardCache_MissThenHit(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLeaderboardCache(newMemoryLeaderboardCacheStore(), time.Minute)
+	filter := models.LeaderboardFilter{Limit: 10}
+
+	_, generation, hit, err := cache.GetEntries(ctx, "fastest", filter)
+	if err != nil || hit {
+		t.Fatalf("Expected a miss, got hit %v (err %v)", hit, err)
+	}
+
+	entries := []models.LeaderboardEntry{
+		{PlayerID: "p1", Username: "alice", CompletionTime: 90 * time.Second, AverageScore: 81.5},
+	}
+	if err := cache.PutEntries(ctx, "fastest", filter, generation, entries); err != nil {
+		t.Fatalf("PutEntries failed: %v", err)
+	}
+
+	cached, _, hit, err := cache.GetEntries(ctx, "fastest", filter)
+	if err != nil || !hit {
+		t.Fatalf("Expected a hit, got hit %v (err %v)", hit, err)
+	}
+	if len(cached) != 1 || cached[0].Username != "alice" || cached[0].CompletionTime != 90*time.Second || cached[0].AverageScore != 81.5 {
+		t.Errorf("Cached entries did not round-trip: %+v", cached)
+	}
+
+	// Other categories are cached separately
+	if _, _, hit, _ := cache.GetEntries(ctx, "highest_avg", filter); hit {
+		t.Error("Expected another category to miss")
+	}
+}
+
+func TestLeaderboardCache_EmptyLeaderboardIsAHit(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLeaderboardCache(newMemoryLeaderboardCacheStore(), time.Minute)
+	filter := models.LeaderboardFilter{Limit: 10}
+
+	if err := cache.PutEntries(ctx, "recent_winners", filter, 0, nil); err != nil {
+		t.Fatalf("PutEntries failed: %v", err)
+	}
+
+	cached, _, hit, err := cache.GetEntries(ctx, "recent_winners", filter)
+	if err != nil || !hit || len(cached) != 0 {
+		t.Errorf("Expected a cached empty leaderboard, got %v (hit %v, err %v)", cached, hit, err)
+	}
+}
+
+func TestLeaderboardCacheKey_IncludesEveryFilterField(t *testing.T) {
+	mode := models.GameModeMultiplayer
+	theme := "space:station"
+	day := "day"
+	timezone := "+05:30"
+
+	filters := []models.LeaderboardFilter{
+		{Limit: 10},
+		{Limit: 20},
+		{Limit: 10, GameMode: &mode},
+		{Limit: 10, Theme: &theme},
+		{Limit: 10, TimeRange: &day},
+		{Limit: 10, TimeRange: &day, Timezone: &timezone},
+		{Limit: 10, PlayerIDs: []string{"p1", "p2"}},
+		{Limit: 10, PlayerIDs: []string{"p1", "p3"}},
+	}
+
+	seen := make(map[string]int)
+	for i, filter := range filters {
+		key := leaderboardCacheKey("fastest", filter)
+		if previous, exists := seen[key]; exists {
+			t.Errorf("Filters %d and %d share the key %s", previous, i, key)
+		}
+		seen[key] = i
+	}
+
+	if leaderboardCacheKey("fastest", filters[3]) != leaderboardCacheKey("fastest", models.LeaderboardFilter{Limit: 10, Theme: &theme}) {
+		t.Error("Expected equal filters to share a key")
+	}
+	if leaderboardCacheKey("fastest", models.LeaderboardFilter{Limit: 10, PlayerIDs: []string{"p2", "p1"}}) != leaderboardCacheKey("fastest", filters[6]) {
+		t.Error("Expected the same players in another order to share a key")
+	}
+}
+
+func TestLeaderboardCache_InvalidateDropsEntriesAndStats(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLeaderboardCache(newMemoryLeaderboardCacheStore(), time.Minute)
+	filter := models.LeaderboardFilter{Limit: 10}
+
+	if err := cache.PutEntries(ctx, "most_completed", filter, 0, []models.LeaderboardEntry{{PlayerID: "p1"}}); err != nil {
+		t.Fatalf("PutEntries failed: %v", err)
+	}
+	if err := cache.PutStats(ctx, 0, &models.LeaderboardStats{TotalGamesCompleted: 3}); err != nil {
+		t.Fatalf("PutStats failed: %v", err)
+	}
+	if stats, _, err := cache.GetStats(ctx); err != nil || stats == nil || stats.TotalGamesCompleted != 3 {
+		t.Fatalf("Expected cached stats, got %+v (err %v)", stats, err)
+	}
+
+	if err := cache.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+
+	if _, _, hit, _ := cache.GetEntries(ctx, "most_completed", filter); hit {
+		t.Error("Expected cached entries to miss after invalidation")
+	}
+	if stats, _, _ := cache.GetStats(ctx); stats != nil {
+		t.Errorf("Expected cached stats to miss after invalidation, got %+v", stats)
+	}
+}
+
+func TestLeaderboardCache_IgnoresResultReadBeforeConcurrentWrite(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLeaderboardCache(newMemoryLeaderboardCacheStore(), time.Minute)
+	filter := models.LeaderboardFilter{Limit: 10}
+
+	// A reader misses and queries MongoDB...
+	_, generation, _, err := cache.GetEntries(ctx, "fastest", filter)
+	if err != nil {
+		t.Fatalf("GetEntries failed: %v", err)
+	}
+
+	// ...a faster completion is added before the reader caches what it read...
+	if err := cache.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+
+	// ...so the stale result is tagged with an old generation and never served
+	if err := cache.PutEntries(ctx, "fastest", filter, generation, []models.LeaderboardEntry{{PlayerID: "p1"}}); err != nil {
+		t.Fatalf("PutEntries failed: %v", err)
+	}
+	if _, _, hit, _ := cache.GetEntries(ctx, "fastest", filter); hit {
+		t.Error("Expected the stale result to be ignored")
+	}
+}
+
+func TestLeaderboardCache_ConcurrentWritesNeverLeaveStaleEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLeaderboardCache(newMemoryLeaderboardCacheStore(), time.Minute)
+	filter := models.LeaderboardFilter{Limit: 10}
+
+	// The database is a single counter; the cached leaderboard records the count it was read at
+	var database int64
+	readThrough := func() (int, error) {
+		entries, generation, hit, err := cache.GetEntries(ctx, "most_completed", filter)
+		if err != nil {
+			return 0, err
+		}
+		if hit {
+			return entries[0].DoorsCompleted, nil
+		}
+		count := int(atomic.LoadInt64(&database))
+		return count, cache.PutEntries(ctx, "most_completed", filter, generation, []models.LeaderboardEntry{{DoorsCompleted: count}})
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				// AddEntry writes to MongoDB, then invalidates
+				atomic.AddInt64(&database, 1)
+				if err := cache.Invalidate(ctx); err != nil {
+					errs <- err
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if _, err := readThrough(); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Concurrent cache access failed: %v", err)
+	}
+
+	// Once the writes settle, readers see every entry, whether or not a stale copy was written late
+	for i := 0; i < 2; i++ {
+		count, err := readThrough()
+		if err != nil {
+			t.Fatalf("readThrough failed: %v", err)
+		}
+		if count != 100 {
+			t.Errorf("Expected the leaderboard to reflect all 100 entries, got %d", count)
+		}
+	}
+}
+
+func TestLeaderboardCache_ReportsStoreErrors(t *testing.T) {
+	store := newMemoryLeaderboardCacheStore()
+	store.loadErr = errors.New("connection refused")
+	cache := NewLeaderboardCache(store, time.Minute)
+
+	if _, _, _, err := cache.GetEntries(context.Background(), "fastest", models.LeaderboardFilter{}); err == nil {
+		t.Fatal("Expected an error when the store is unavailable")
+	}
+}
@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrLeaderboardSnapshotMiss is returned when no materialized leaderboard is available
+var ErrLeaderboardSnapshotMiss = errors.New("leaderboard snapshot not materialized")
+
+// Hash fields used for materialized leaderboard categories
+const (
+	snapshotFieldFastest        = "fastest"
+	snapshotFieldHighestAvg     = "highest_avg"
+	snapshotFieldMostCompleted  = "most_completed"
+	snapshotFieldRecentWinners  = "recent_winners"
+	snapshotFieldBestOf         = "best_of"
+	snapshotFieldStats          = "stats"
+	snapshotFieldMaterializedAt = "materialized_at"
+)
+
+// LeaderboardSnapshotStore holds precomputed leaderboards so reads never touch MongoDB
+type LeaderboardSnapshotStore interface {
+	SaveLeaderboard(ctx context.Context, timeRange string, leaderboard *models.GlobalLeaderboard) error
+	GetLeaderboard(ctx context.Context, timeRange string) (*models.GlobalLeaderboard, error)
+	SaveStats(ctx context.Context, stats *models.LeaderboardStats) error
+	GetStats(ctx context.Context) (*models.LeaderboardStats, error)
+	Invalidate(ctx context.Context) error
+}
+
+// RedisLeaderboardSnapshotStore stores each time range as a Redis hash with one field per category
+type RedisLeaderboardSnapshotStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewLeaderboardSnapshotStore creates a Redis-backed snapshot store. Snapshots expire after ttl
+// so a stalled materialization job cannot serve stale rankings forever.
+func NewLeaderboardSnapshotStore(redis *database.RedisClient, ttl time.Duration) LeaderboardSnapshotStore {
+	return &RedisLeaderboardSnapshotStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// SaveLeaderboard replaces the materialized leaderboard for a time range
+func (s *RedisLeaderboardSnapshotStore) SaveLeaderboard(ctx context.Context, timeRange string, leaderboard *models.GlobalLeaderboard) error {
+	fields := map[string]interface{}{
+		snapshotFieldMaterializedAt: time.Now().Format(time.RFC3339Nano),
+	}
+
+	categories := map[string][]models.LeaderboardEntry{
+		snapshotFieldFastest:       leaderboard.FastestCompletions,
+		snapshotFieldHighestAvg:    leaderboard.HighestAverages,
+		snapshotFieldMostCompleted: leaderboard.MostCompleted,
+		snapshotFieldRecentWinners: leaderboard.RecentWinners,
+		snapshotFieldBestOf:        leaderboard.BestOfTotals,
+	}
+	for field, entries := range categories {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s leaderboard: %w", field, err)
+		}
+		fields[field] = data
+	}
+
+	return s.writeHash(ctx, leaderboardSnapshotKey(timeRange), fields)
+}
+
+// GetLeaderboard returns the materialized leaderboard for a time range
+func (s *RedisLeaderboardSnapshotStore) GetLeaderboard(ctx context.Context, timeRange string) (*models.GlobalLeaderboard, error) {
+	fields, err := s.redis.Client.HGetAll(ctx, leaderboardSnapshotKey(timeRange)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read leaderboard snapshot: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, ErrLeaderboardSnapshotMiss
+	}
+
+	leaderboard := &models.GlobalLeaderboard{}
+	targets := map[string]*[]models.LeaderboardEntry{
+		snapshotFieldFastest:       &leaderboard.FastestCompletions,
+		snapshotFieldHighestAvg:    &leaderboard.HighestAverages,
+		snapshotFieldMostCompleted: &leaderboard.MostCompleted,
+		snapshotFieldRecentWinners: &leaderboard.RecentWinners,
+		snapshotFieldBestOf:        &leaderboard.BestOfTotals,
+	}
+	for field, target := range targets {
+		data, ok := fields[field]
+		if !ok {
+			return nil, ErrLeaderboardSnapshotMiss
+		}
+		if err := json.Unmarshal([]byte(data), target); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s leaderboard: %w", field, err)
+		}
+	}
+
+	return leaderboard, nil
+}
+
+// SaveStats replaces the materialized leaderboard statistics
+func (s *RedisLeaderboardSnapshotStore) SaveStats(ctx context.Context, stats *models.LeaderboardStats) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal leaderboard stats: %w", err)
+	}
+
+	return s.writeHash(ctx, leaderboardStatsSnapshotKey, map[string]interface{}{
+		snapshotFieldStats:          data,
+		snapshotFieldMaterializedAt: time.Now().Format(time.RFC3339Nano),
+	})
+}
+
+// GetStats returns the materialized leaderboard statistics
+func (s *RedisLeaderboardSnapshotStore) GetStats(ctx context.Context) (*models.LeaderboardStats, error) {
+	data, err := s.redis.Client.HGet(ctx, leaderboardStatsSnapshotKey, snapshotFieldStats).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrLeaderboardSnapshotMiss
+		}
+		return nil, fmt.Errorf("failed to read leaderboard stats snapshot: %w", err)
+	}
+
+	var stats models.LeaderboardStats
+	if err := json.Unmarshal([]byte(data), &stats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal leaderboard stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// Invalidate removes every materialized leaderboard so readers fall back to MongoDB until the next run
+func (s *RedisLeaderboardSnapshotStore) Invalidate(ctx context.Context) error {
+	keys := []string{leaderboardStatsSnapshotKey}
+	for _, timeRange := range LeaderboardSnapshotTimeRanges {
+		keys = append(keys, leaderboardSnapshotKey(timeRange))
+	}
+
+	if err := s.redis.Client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate leaderboard snapshots: %w", err)
+	}
+
+	return nil
+}
+
+// writeHash atomically replaces a snapshot hash and refreshes its expiry
+func (s *RedisLeaderboardSnapshotStore) writeHash(ctx context.Context, key string, fields map[string]interface{}) error {
+	pipe := s.redis.Client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, fields)
+	if s.ttl > 0 {
+		pipe.Expire(ctx, key, s.ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to write leaderboard snapshot %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// LeaderboardSnapshotTimeRanges lists the time ranges that are materialized
+var LeaderboardSnapshotTimeRanges = []string{"all", "day", "week", "month"}
+
+const leaderboardStatsSnapshotKey = "leaderboard:materialized:stats"
+
+func leaderboardSnapshotKey(timeRange string) string {
+	return fmt.Sprintf("leaderboard:materialized:%s", timeRange)
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestTimeRangeStart_RollingWithoutTimezone(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	if start := timeRangeStart("day", now, nil); !start.Equal(now.Add(-24 * time.Hour)) {
+		t.Errorf("Expected a rolling 24h window, got %v", start)
+	}
+	if start := timeRangeStart("all", now, nil); !start.IsZero() {
+		t.Errorf("Expected no bound for all, got %v", start)
+	}
+}
+
+func TestTimeRangeStart_CalendarInTimezone(t *testing.T) {
+	location, err := models.ParseTimezone("+09:00")
+	if err != nil {
+		t.Fatalf("ParseTimezone failed: %v", err)
+	}
+
+	// 20:00 UTC on Friday the 15th is already Saturday the 16th in UTC+9
+	now := time.Date(2024, 3, 15, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		timeRange string
+		expected  time.Time
+	}{
+		{"day", time.Date(2024, 3, 16, 0, 0, 0, 0, location)},
+		{"week", time.Date(2024, 3, 11, 0, 0, 0, 0, location)},
+		{"month", time.Date(2024, 3, 1, 0, 0, 0, 0, location)},
+	}
+
+	for _, tt := range tests {
+		if start := timeRangeStart(tt.timeRange, now, location); !start.Equal(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.timeRange, tt.expected, start)
+		}
+	}
+}
+
+func TestParseTimezone(t *testing.T) {
+	valid := map[string]int{
+		"UTC":    0,
+		"+05:30": 5*3600 + 30*60,
+		"-0800":  -8 * 3600,
+		"UTC+2":  2 * 3600,
+		"GMT-3":  -3 * 3600,
+	}
+	reference := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for value, expectedOffset := range valid {
+		location, err := models.ParseTimezone(value)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", value, err)
+			continue
+		}
+		if _, offset := reference.In(location).Zone(); offset != expectedOffset {
+			t.Errorf("%s: expected offset %d, got %d", value, expectedOffset, offset)
+		}
+	}
+
+	for _, value := range []string{"", "+25:00", "Mars/Olympus_Mons", "+5:3"} {
+		if _, err := models.ParseTimezone(value); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultTicketTTL bounds how long an abandoned ticket, or a match result waiting to be
+// picked up, stays in Redis
+const DefaultTicketTTL = 15 * time.Minute
+
+// Matchmaking Redis key prefixes
+const (
+	matchmakingPoolPrefix   = "matchmaking:pool:"
+	matchmakingTicketPrefix = "matchmaking:ticket:"
+)
+
+// MatchmakingQueue holds players waiting for a match, grouped into pools by theme and skill bucket
+type MatchmakingQueue interface {
+	Enqueue(ctx context.Context, ticket *models.MatchmakingTicket) error
+	Remove(ctx context.Context, playerID string) error
+	GetTicket(ctx context.Context, playerID string) (*models.MatchmakingTicket, error)
+	SaveTicket(ctx context.Context, ticket *models.MatchmakingTicket) error
+	Pools(ctx context.Context) ([]string, error)
+	Waiting(ctx context.Context, pool string, limit int) ([]*models.MatchmakingTicket, error)
+	Claim(ctx context.Context, pool string, playerIDs []string) ([]string, error)
+}
+
+// RedisMatchmakingQueue keeps each pool as a sorted set of player IDs scored by enqueue time,
+// with ticket details stored alongside as JSON strings
+type RedisMatchmakingQueue struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewMatchmakingQueue creates a Redis-backed matchmaking queue; tickets expire after ttl
+func NewMatchmakingQueue(redis *database.RedisClient, ttl time.Duration) MatchmakingQueue {
+	if ttl <= 0 {
+		ttl = DefaultTicketTTL
+	}
+	return &RedisMatchmakingQueue{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Enqueue adds the player to their ticket's pool, moving them out of any pool they were waiting in
+func (q *RedisMatchmakingQueue) Enqueue(ctx context.Context, ticket *models.MatchmakingTicket) error {
+	previous, err := q.GetTicket(ctx, ticket.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return fmt.Errorf("failed to marshal matchmaking ticket: %w", err)
+	}
+
+	pipe := q.redis.Client.TxPipeline()
+	if previous != nil && previous.Pool() != ticket.Pool() {
+		pipe.ZRem(ctx, poolKey(previous.Pool()), ticket.PlayerID)
+	}
+	pipe.Set(ctx, ticketKey(ticket.PlayerID), data, q.ttl)
+	pipe.ZAdd(ctx, poolKey(ticket.Pool()), redis.Z{
+		Score:  float64(ticket.EnqueuedAt.UnixNano()),
+		Member: ticket.PlayerID,
+	})
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to enqueue player: %w", err)
+	}
+	return nil
+}
+
+// Remove takes the player out of the queue and forgets their ticket
+func (q *RedisMatchmakingQueue) Remove(ctx context.Context, playerID string) error {
+	ticket, err := q.GetTicket(ctx, playerID)
+	if err != nil {
+		return err
+	}
+	if ticket == nil {
+		return nil
+	}
+
+	pipe := q.redis.Client.TxPipeline()
+	pipe.ZRem(ctx, poolKey(ticket.Pool()), playerID)
+	pipe.Del(ctx, ticketKey(playerID))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove player from matchmaking: %w", err)
+	}
+	return nil
+}
+
+// GetTicket returns the player's ticket, or nil if they are not queued or recently matched
+func (q *RedisMatchmakingQueue) GetTicket(ctx context.Context, playerID string) (*models.MatchmakingTicket, error) {
+	data, err := q.redis.Get(ctx, ticketKey(playerID))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get matchmaking ticket: %w", err)
+	}
+
+	var ticket models.MatchmakingTicket
+	if err := json.Unmarshal([]byte(data), &ticket); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal matchmaking ticket: %w", err)
+	}
+	return &ticket, nil
+}
+
+// SaveTicket stores an updated ticket without changing the player's place in the queue
+func (q *RedisMatchmakingQueue) SaveTicket(ctx context.Context, ticket *models.MatchmakingTicket) error {
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return fmt.Errorf("failed to marshal matchmaking ticket: %w", err)
+	}
+
+	if err := q.redis.SetWithExpiration(ctx, ticketKey(ticket.PlayerID), data, q.ttl); err != nil {
+		return fmt.Errorf("failed to save matchmaking ticket: %w", err)
+	}
+	return nil
+}
+
+// Pools returns every pool with at least one waiting player. Redis drops empty sorted sets,
+// so scanning for pool keys never finds stale pools.
+func (q *RedisMatchmakingQueue) Pools(ctx context.Context) ([]string, error) {
+	var pools []string
+
+	iter := q.redis.Client.Scan(ctx, 0, matchmakingPoolPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		pools = append(pools, strings.TrimPrefix(iter.Val(), matchmakingPoolPrefix))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list matchmaking pools: %w", err)
+	}
+
+	return pools, nil
+}
+
+// Waiting returns up to limit tickets from a pool, longest-waiting first. Players whose
+// ticket has expired are dropped from the pool.
+func (q *RedisMatchmakingQueue) Waiting(ctx context.Context, pool string, limit int) ([]*models.MatchmakingTicket, error) {
+	playerIDs, err := q.redis.Client.ZRange(ctx, poolKey(pool), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waiting players: %w", err)
+	}
+
+	tickets := make([]*models.MatchmakingTicket, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		ticket, err := q.GetTicket(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if ticket == nil {
+			if err := q.redis.Client.ZRem(ctx, poolKey(pool), playerID).Err(); err != nil {
+				fmt.Printf("Warning: failed to drop expired matchmaking ticket: %v\n", err)
+			}
+			continue
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
+}
+
+// Claim removes the players from the pool and returns those this caller removed. Another
+// backend instance matching the same pool concurrently can never claim the same player.
+func (q *RedisMatchmakingQueue) Claim(ctx context.Context, pool string, playerIDs []string) ([]string, error) {
+	claimed := make([]string, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		removed, err := q.redis.Client.ZRem(ctx, poolKey(pool), playerID).Result()
+		if err != nil {
+			return claimed, fmt.Errorf("failed to claim player %s: %w", playerID, err)
+		}
+		if removed == 1 {
+			claimed = append(claimed, playerID)
+		}
+	}
+	return claimed, nil
+}
+
+// poolKey returns the Redis key of a pool's sorted set
+func poolKey(pool string) string {
+	return matchmakingPoolPrefix + pool
+}
+
+// ticketKey returns the Redis key of a player's ticket
+func ticketKey(playerID string) string {
+	return matchmakingTicketPrefix + playerID
+}
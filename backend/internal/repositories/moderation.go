@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ModerationEventRepository stores moderation decisions for admin review
+type ModerationEventRepository interface {
+	Record(ctx context.Context, event *models.ModerationEvent) error
+	List(ctx context.Context, filter models.ModerationEventFilter) ([]*models.ModerationEvent, int64, error)
+}
+
+// ModerationEventRepositoryImpl implements the ModerationEventRepository interface
+type ModerationEventRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewModerationEventRepository creates a new moderation event repository
+func NewModerationEventRepository(mongodb *database.MongoClient) ModerationEventRepository {
+	return &ModerationEventRepositoryImpl{
+		collection: mongodb.GetCollection("moderation_events"),
+	}
+}
+
+// Record stores a moderation event
+func (r *ModerationEventRepositoryImpl) Record(ctx context.Context, event *models.ModerationEvent) error {
+	if _, err := r.collection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to record moderation event: %w", err)
+	}
+	return nil
+}
+
+// List returns one page of moderation events matching the filter, newest first, with the total match count
+func (r *ModerationEventRepositoryImpl) List(ctx context.Context, filter models.ModerationEventFilter) ([]*models.ModerationEvent, int64, error) {
+	query := bson.M{}
+	if filter.PlayerID != "" {
+		query["subject.playerId"] = filter.PlayerID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count moderation events: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.PageSize)).
+		SetLimit(int64(filter.PageSize))
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list moderation events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []*models.ModerationEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode moderation events: %w", err)
+	}
+	return events, total, nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultOpenSessionsTTL is how long a listing of open sessions is served before it is rebuilt
+const DefaultOpenSessionsTTL = 3 * time.Second
+
+// OpenSessionCache briefly keeps the open session listing so busy lobbies don't query the
+// database on every refresh
+type OpenSessionCache interface {
+	Get(ctx context.Context, limit int) ([]models.OpenSession, error)
+	Put(ctx context.Context, limit int, sessions []models.OpenSession) error
+}
+
+// RedisOpenSessionCache stores each listing as a JSON string with a short TTL
+type RedisOpenSessionCache struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewOpenSessionCache creates a Redis-backed open session cache; listings expire after ttl
+func NewOpenSessionCache(redis *database.RedisClient, ttl time.Duration) OpenSessionCache {
+	if ttl <= 0 {
+		ttl = DefaultOpenSessionsTTL
+	}
+	return &RedisOpenSessionCache{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Get returns the cached listing for the limit, or nil on a miss
+func (c *RedisOpenSessionCache) Get(ctx context.Context, limit int) ([]models.OpenSession, error) {
+	data, err := c.redis.Get(ctx, openSessionsKey(limit))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get open sessions: %w", err)
+	}
+
+	sessions := []models.OpenSession{}
+	if err := json.Unmarshal([]byte(data), &sessions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal open sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// Put caches the listing for the limit
+func (c *RedisOpenSessionCache) Put(ctx context.Context, limit int, sessions []models.OpenSession) error {
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal open sessions: %w", err)
+	}
+
+	if err := c.redis.SetWithExpiration(ctx, openSessionsKey(limit), data, c.ttl); err != nil {
+		return fmt.Errorf("failed to cache open sessions: %w", err)
+	}
+	return nil
+}
+
+// openSessionsKey returns the Redis key for a listing of up to limit sessions
+func openSessionsKey(limit int) string {
+	return fmt.Sprintf("open-sessions:%d", limit)
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PlayerActivityRepository stores each player's recent submission cadence for anomaly detection
+type PlayerActivityRepository interface {
+	Record(ctx context.Context, playerID, username string, sample models.PlaySample, fast bool, window int) (*models.PlayerActivity, error)
+	Flag(ctx context.Context, playerID string, reasons []string) (bool, error)
+	Clear(ctx context.Context, playerID, clearedBy string) (*models.PlayerActivity, error)
+	IsFlagged(ctx context.Context, playerID string) (bool, error)
+	ListFlagged(ctx context.Context, filter models.PlayerActivityFilter) ([]*models.PlayerActivity, int64, error)
+}
+
+// PlayerActivityRepositoryImpl implements the PlayerActivityRepository interface
+type PlayerActivityRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewPlayerActivityRepository creates a new player activity repository
+func NewPlayerActivityRepository(mongodb *database.MongoClient) PlayerActivityRepository {
+	return &PlayerActivityRepositoryImpl{
+		collection: mongodb.GetCollection("player_activity"),
+	}
+}
+
+// Record adds a sample to the player's activity, keeping only the last window samples, and
+// returns the updated activity
+func (r *PlayerActivityRepositoryImpl) Record(ctx context.Context, playerID, username string, sample models.PlaySample, fast bool, window int) (*models.PlayerActivity, error) {
+	inc := bson.M{"submissions": 1}
+	if fast {
+		inc["fastSubmissions"] = 1
+	}
+	update := bson.M{
+		"$push":        bson.M{"samples": bson.M{"$each": []models.PlaySample{sample}, "$slice": -window}},
+		"$inc":         inc,
+		"$set":         bson.M{"username": username, "updatedAt": time.Now()},
+		"$setOnInsert": bson.M{"flagged": false},
+	}
+
+	var activity models.PlayerActivity
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"playerId": playerID}, update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&activity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record player activity: %w", err)
+	}
+	return &activity, nil
+}
+
+// Flag marks the player as suspected of scripted play. It reports false if they were already
+// flagged, so the caller acts on each flag once.
+func (r *PlayerActivityRepositoryImpl) Flag(ctx context.Context, playerID string, reasons []string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"playerId": playerID, "flagged": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{"flagged": true, "reasons": reasons, "flaggedAt": time.Now()}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to flag player: %w", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// Clear lifts the player's flag and forgets their samples, so they are judged afresh. It
+// returns nil if the player has no recorded activity.
+func (r *PlayerActivityRepositoryImpl) Clear(ctx context.Context, playerID, clearedBy string) (*models.PlayerActivity, error) {
+	update := bson.M{
+		"$set":   bson.M{"flagged": false, "samples": []models.PlaySample{}, "clearedAt": time.Now(), "clearedBy": clearedBy},
+		"$unset": bson.M{"reasons": "", "flaggedAt": ""},
+	}
+
+	var activity models.PlayerActivity
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"playerId": playerID}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&activity)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to clear player flag: %w", err)
+	}
+	return &activity, nil
+}
+
+// IsFlagged reports whether the player is currently flagged
+func (r *PlayerActivityRepositoryImpl) IsFlagged(ctx context.Context, playerID string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"playerId": playerID, "flagged": true}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check player flag: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListFlagged returns one page of flagged players, most recently flagged first, with the total flagged count
+func (r *PlayerActivityRepositoryImpl) ListFlagged(ctx context.Context, filter models.PlayerActivityFilter) ([]*models.PlayerActivity, int64, error) {
+	query := bson.M{"flagged": true}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count flagged players: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "flaggedAt", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.PageSize)).
+		SetLimit(int64(filter.PageSize))
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list flagged players: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	players := []*models.PlayerActivity{}
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode flagged players: %w", err)
+	}
+	return players, total, nil
+}
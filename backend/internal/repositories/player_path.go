@@ -13,6 +13,7 @@ type PlayerPathRepository interface {
 	CreatePlayer(ctx context.Context, playerID, username string) error
 	GetNextDoor(ctx context.Context, playerID string, currentScore int) (string, error)
 	UpdatePlayerPosition(ctx context.Context, playerID, doorID string) error
+	ReleasePlayer(ctx context.Context, playerID string) error
 	GetPlayerPath(ctx context.Context, playerID string) (*models.PlayerPath, error)
 	UpdatePlayerPath(ctx context.Context, playerPath *models.PlayerPath) error
 	CalculateOptimalPath(ctx context.Context, playerID string, scores []int) ([]string, error)
@@ -54,7 +55,7 @@ func (r *PlayerPathRepositoryImpl) CreatePlayer(ctx context.Context, playerID, u
 // GetNextDoor determines the next door for a player based on their current score
 func (r *PlayerPathRepositoryImpl) GetNextDoor(ctx context.Context, playerID string, currentScore int) (string, error) {
 	query := `
-		MATCH (p:Player {id: $playerId})-[:CURRENTLY_AT]->(current:Door)
+		MATCH (p:Player {id: $playerId})-[:CURRENTLY_AT]->(current)
 		MATCH (current)-[r:LEADS_TO]->(next:Door)
 		WHERE $score >= r.scoreThreshold
 		RETURN next.id as doorId
@@ -81,14 +82,14 @@ func (r *PlayerPathRepositoryImpl) GetNextDoor(ctx context.Context, playerID str
 	return doorID.(string), nil
 }
 
-// UpdatePlayerPosition updates the player's current position in the graph
+// UpdatePlayerPosition moves the player along a LEADS_TO relationship to the given door. Doors
+// that don't lead on from the player's current position leave them where they are.
 func (r *PlayerPathRepositoryImpl) UpdatePlayerPosition(ctx context.Context, playerID, doorID string) error {
 	query := `
-		MATCH (p:Player {id: $playerId})
-		OPTIONAL MATCH (p)-[r:CURRENTLY_AT]->()
+		MATCH (p:Player {id: $playerId})-[r:CURRENTLY_AT]->(current)
+		MATCH (current)-[:LEADS_TO]->(door:Door {id: $doorId})
+		WITH DISTINCT p, r, door
 		DELETE r
-		WITH p
-		MATCH (door:Door {id: $doorId})
 		CREATE (p)-[:CURRENTLY_AT]->(door)
 		SET p.currentPosition = p.currentPosition + 1
 		RETURN p
@@ -107,6 +108,28 @@ func (r *PlayerPathRepositoryImpl) UpdatePlayerPosition(ctx context.Context, pla
 	return nil
 }
 
+// ReleasePlayer clears the player's in-progress position, keeping the doors they visited
+func (r *PlayerPathRepositoryImpl) ReleasePlayer(ctx context.Context, playerID string) error {
+	query := `
+		MATCH (p:Player {id: $playerId})
+		OPTIONAL MATCH (p)-[r:CURRENTLY_AT]->()
+		DELETE r
+		SET p.currentPosition = 0
+		RETURN p
+	`
+	
+	params := map[string]interface{}{
+		"playerId": playerID,
+	}
+	
+	_, err := r.neo4j.ExecuteQuery(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to release player: %w", err)
+	}
+	
+	return nil
+}
+
 // GetPlayerPath retrieves the complete path information for a player
 func (r *PlayerPathRepositoryImpl) GetPlayerPath(ctx context.Context, playerID string) (*models.PlayerPath, error) {
 	// Get player information and visited doors
@@ -130,15 +153,7 @@ func (r *PlayerPathRepositoryImpl) GetPlayerPath(ctx context.Context, playerID s
 	
 	if len(result.Records) == 0 {
 		// Return a default path if player not found
-		return &models.PlayerPath{
-			PlayerID:          playerID,
-			Theme:             "general",
-			CurrentDifficulty: 1,
-			DoorsVisited:      []string{},
-			CurrentPosition:   0,
-			TotalDoors:        10, // Default total doors
-			CreatedAt:         time.Now(),
-		}, nil
+		return defaultPlayerPath(playerID), nil
 	}
 	
 	record := result.Records[0]
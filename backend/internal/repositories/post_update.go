@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PostUpdateDeadLetterRepository keeps the Devvit post updates that could not be delivered
+type PostUpdateDeadLetterRepository interface {
+	Save(ctx context.Context, letter *models.PostUpdateDeadLetter) error
+}
+
+// PostUpdateDeadLetterRepositoryImpl implements the PostUpdateDeadLetterRepository interface
+type PostUpdateDeadLetterRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewPostUpdateDeadLetterRepository creates a new dead-letter repository for Devvit post updates
+func NewPostUpdateDeadLetterRepository(mongodb *database.MongoClient) PostUpdateDeadLetterRepository {
+	return &PostUpdateDeadLetterRepositoryImpl{
+		collection: mongodb.GetCollection("devvit_dead_letters"),
+	}
+}
+
+// Save stores an undelivered post update
+func (r *PostUpdateDeadLetterRepositoryImpl) Save(ctx context.Context, letter *models.PostUpdateDeadLetter) error {
+	if _, err := r.collection.InsertOne(ctx, letter); err != nil {
+		return fmt.Errorf("failed to save post update dead letter: %w", err)
+	}
+	return nil
+}
+
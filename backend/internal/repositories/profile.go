@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PlayerProfileRepository stores players' career statistics
+type PlayerProfileRepository interface {
+	Get(ctx context.Context, playerID string) (*models.PlayerProfile, error)
+	Save(ctx context.Context, profile *models.PlayerProfile) error
+}
+
+// PlayerProfileRepositoryImpl implements the PlayerProfileRepository interface
+type PlayerProfileRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewPlayerProfileRepository creates a new player profile repository
+func NewPlayerProfileRepository(mongodb *database.MongoClient) PlayerProfileRepository {
+	return &PlayerProfileRepositoryImpl{
+		collection: mongodb.GetCollection("player_profiles"),
+	}
+}
+
+// Get returns the player's profile, or nil if they have not completed a game yet
+func (r *PlayerProfileRepositoryImpl) Get(ctx context.Context, playerID string) (*models.PlayerProfile, error) {
+	var profile models.PlayerProfile
+	err := r.collection.FindOne(ctx, bson.M{"playerId": playerID}).Decode(&profile)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// Save creates or replaces the player's profile
+func (r *PlayerProfileRepositoryImpl) Save(ctx context.Context, profile *models.PlayerProfile) error {
+	profile.UpdatedAt = time.Now()
+	if profile.CreatedAt.IsZero() {
+		profile.CreatedAt = profile.UpdatedAt
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.collection.ReplaceOne(ctx, bson.M{"playerId": profile.PlayerID}, profile, opts); err != nil {
+		return fmt.Errorf("failed to save player profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeTokenScript refills a token bucket for the time elapsed since it was last touched, then
+// takes one token if available. Buckets are hashes of their token count and last refill time,
+// and expire once they would have refilled completely.
+var takeTokenScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local refill_per_ms = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * refill_per_ms)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / refill_per_ms)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, math.floor(tokens), wait}
+`)
+
+// RedisRateLimitStore keeps token buckets in Redis so limits hold across every backend instance
+type RedisRateLimitStore struct {
+	redis *database.RedisClient
+}
+
+// NewRateLimitStore creates a Redis-backed token bucket store
+func NewRateLimitStore(redis *database.RedisClient) *RedisRateLimitStore {
+	return &RedisRateLimitStore{redis: redis}
+}
+
+// Take takes a token from the bucket at key, which holds up to limit tokens and refills
+// completely over window
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit int, window time.Duration) (*models.RateLimitDecision, error) {
+	if limit <= 0 || window <= 0 {
+		return &models.RateLimitDecision{Allowed: true}, nil
+	}
+
+	refillPerMs := float64(limit) / float64(window.Milliseconds())
+	result, err := takeTokenScript.Run(ctx, s.redis.Client, []string{rateLimitKey(key)},
+		limit, refillPerMs, time.Now().UnixMilli(), window.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(result) != 3 {
+		return nil, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+
+	return &models.RateLimitDecision{
+		Allowed:    result[0] == 1,
+		Remaining:  int(result[1]),
+		RetryAfter: time.Duration(result[2]) * time.Millisecond,
+	}, nil
+}
+
+// rateLimitKey namespaces a bucket key in Redis
+func rateLimitKey(key string) string {
+	return "ratelimit:" + key
+}
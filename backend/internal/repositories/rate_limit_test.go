@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRateLimitStore connects to the Redis at TEST_REDIS_URI, skipping the test when none
+// is configured, as the token bucket script needs a real Redis to run
+func newTestRateLimitStore(t *testing.T) *RedisRateLimitStore {
+	t.Helper()
+	uri := os.Getenv("TEST_REDIS_URI")
+	if uri == "" {
+		t.Skip("TEST_REDIS_URI not set")
+	}
+	opt, err := redis.ParseURL(uri)
+	if err != nil {
+		t.Fatalf("invalid TEST_REDIS_URI: %v", err)
+	}
+	client := redis.NewClient(opt)
+	t.Cleanup(func() { client.Close() })
+	return NewRateLimitStore(&database.RedisClient{Client: client})
+}
+
+func TestRedisRateLimitStore_RefillsOverWindow(t *testing.T) {
+	store := newTestRateLimitStore(t)
+	ctx := context.Background()
+	key := "test:" + t.Name() + ":" + time.Now().Format(time.RFC3339Nano)
+	window := 400 * time.Millisecond
+
+	for i := 0; i < 2; i++ {
+		decision, err := store.Take(ctx, key, 2, window)
+		if err != nil {
+			t.Fatalf("Take failed: %v", err)
+		}
+		if !decision.Allowed || decision.Remaining != 1-i {
+			t.Fatalf("Expected token %d to be taken, got %+v", i+1, decision)
+		}
+	}
+
+	// One token takes half the window to come back
+	decision, err := store.Take(ctx, key, 2, window)
+	if err != nil {
+		t.Fatalf("Take failed: %v", err)
+	}
+	if decision.Allowed || decision.RetryAfter <= 0 || decision.RetryAfter > window/2 {
+		t.Fatalf("Expected a rejection retrying within %v, got %+v", window/2, decision)
+	}
+
+	time.Sleep(decision.RetryAfter + 20*time.Millisecond)
+	if decision, err := store.Take(ctx, key, 2, window); err != nil || !decision.Allowed {
+		t.Errorf("Expected the refilled token to be taken, got %+v, %v", decision, err)
+	}
+}
+
+func TestRedisRateLimitStore_ReportsUnavailableRedis(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+	defer client.Close()
+	store := NewRateLimitStore(&database.RedisClient{Client: client})
+
+	// The middleware fails open on this error, so it must not come back as a decision
+	if decision, err := store.Take(context.Background(), "p1", 1, time.Minute); err == nil {
+		t.Errorf("Expected an error from an unreachable Redis, got %+v", decision)
+	}
+	if decision, err := store.Take(context.Background(), "p1", 0, time.Minute); err != nil || !decision.Allowed {
+		t.Errorf("Expected a zero limit to allow without touching Redis, got %+v, %v", decision, err)
+	}
+}
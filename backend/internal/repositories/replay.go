@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReplayRepository stores each session's ordered replay log
+type ReplayRepository interface {
+	Append(ctx context.Context, event *models.ReplayEvent) error
+	List(ctx context.Context, sessionID string, offset, limit int) ([]*models.ReplayEvent, int64, error)
+}
+
+// ReplayRepositoryImpl implements the ReplayRepository interface
+type ReplayRepositoryImpl struct {
+	events   *mongo.Collection
+	counters *mongo.Collection
+}
+
+// NewReplayRepository creates a new replay repository
+func NewReplayRepository(mongodb *database.MongoClient) ReplayRepository {
+	return &ReplayRepositoryImpl{
+		events:   mongodb.GetCollection("replay_events"),
+		counters: mongodb.GetCollection("replay_counters"),
+	}
+}
+
+// Append stores an event at the end of its session's log. Sequence numbers come from a
+// per-session counter so events recorded by different instances stay in order.
+func (r *ReplayRepositoryImpl) Append(ctx context.Context, event *models.ReplayEvent) error {
+	var counter struct {
+		Sequence int64 `bson:"sequence"`
+	}
+	err := r.counters.FindOneAndUpdate(ctx,
+		bson.M{"_id": event.SessionID},
+		bson.M{"$inc": bson.M{"sequence": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return fmt.Errorf("failed to assign replay sequence: %w", err)
+	}
+
+	event.Sequence = counter.Sequence
+	if _, err := r.events.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to append replay event: %w", err)
+	}
+	return nil
+}
+
+// List returns up to limit events of a session's log starting at offset, with the total event count
+func (r *ReplayRepositoryImpl) List(ctx context.Context, sessionID string, offset, limit int) ([]*models.ReplayEvent, int64, error) {
+	query := bson.M{"sessionId": sessionID}
+
+	total, err := r.events.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count replay events: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "sequence", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.events.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list replay events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []*models.ReplayEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode replay events: %w", err)
+	}
+	return events, total, nil
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultDraftTTL keeps a draft long enough to survive a reconnect but not past its door
+const DefaultDraftTTL = 10 * time.Minute
+
+// ResponseDraftStore keeps players' in-progress responses per session and door
+type ResponseDraftStore interface {
+	SaveDraft(ctx context.Context, draft *models.ResponseDraft) error
+	GetDraft(ctx context.Context, sessionID, doorID, playerID string) (*models.ResponseDraft, error)
+	DeleteDraft(ctx context.Context, sessionID, doorID, playerID string) error
+}
+
+// RedisResponseDraftStore stores each draft as a JSON string with a short TTL
+type RedisResponseDraftStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewResponseDraftStore creates a Redis-backed draft store; drafts expire after ttl
+func NewResponseDraftStore(redis *database.RedisClient, ttl time.Duration) ResponseDraftStore {
+	if ttl <= 0 {
+		ttl = DefaultDraftTTL
+	}
+	return &RedisResponseDraftStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// SaveDraft replaces the player's draft for the door and refreshes its TTL
+func (s *RedisResponseDraftStore) SaveDraft(ctx context.Context, draft *models.ResponseDraft) error {
+	data, err := json.Marshal(draft)
+	if err != nil {
+		return fmt.Errorf("failed to marshal draft: %w", err)
+	}
+
+	if err := s.redis.SetWithExpiration(ctx, draftKey(draft.SessionID, draft.DoorID, draft.PlayerID), data, s.ttl); err != nil {
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+	return nil
+}
+
+// GetDraft returns the player's draft for the door, or nil if there is none
+func (s *RedisResponseDraftStore) GetDraft(ctx context.Context, sessionID, doorID, playerID string) (*models.ResponseDraft, error) {
+	data, err := s.redis.Get(ctx, draftKey(sessionID, doorID, playerID))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get draft: %w", err)
+	}
+
+	var draft models.ResponseDraft
+	if err := json.Unmarshal([]byte(data), &draft); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal draft: %w", err)
+	}
+	return &draft, nil
+}
+
+// DeleteDraft removes the player's draft for the door
+func (s *RedisResponseDraftStore) DeleteDraft(ctx context.Context, sessionID, doorID, playerID string) error {
+	if err := s.redis.Delete(ctx, draftKey(sessionID, doorID, playerID)); err != nil {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+	return nil
+}
+
+// draftKey returns the Redis key for a player's draft on a door
+func draftKey(sessionID, doorID, playerID string) string {
+	return fmt.Sprintf("draft:%s:%s:%s", sessionID, doorID, playerID)
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Response fingerprint history settings
+const (
+	FingerprintHistoryLimit      = 200
+	DefaultFingerprintHistoryTTL = 30 * 24 * time.Hour
+)
+
+// FingerprintStore keeps fingerprints of each player's most recent responses across games
+type FingerprintStore interface {
+	Append(ctx context.Context, playerID string, fingerprint *models.ResponseFingerprint) error
+	Recent(ctx context.Context, playerID string) ([]*models.ResponseFingerprint, error)
+}
+
+// RedisFingerprintStore keeps each player's fingerprints as a capped Redis list of JSON entries
+type RedisFingerprintStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewFingerprintStore creates a Redis-backed fingerprint store; a player's history expires ttl
+// after their last response
+func NewFingerprintStore(redis *database.RedisClient, ttl time.Duration) FingerprintStore {
+	if ttl <= 0 {
+		ttl = DefaultFingerprintHistoryTTL
+	}
+	return &RedisFingerprintStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Append adds a fingerprint to the player's history, dropping the oldest beyond FingerprintHistoryLimit
+func (s *RedisFingerprintStore) Append(ctx context.Context, playerID string, fingerprint *models.ResponseFingerprint) error {
+	data, err := json.Marshal(fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response fingerprint: %w", err)
+	}
+
+	key := fingerprintKey(playerID)
+	_, err = s.redis.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, data)
+		pipe.LTrim(ctx, key, -FingerprintHistoryLimit, -1)
+		pipe.Expire(ctx, key, s.ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save response fingerprint: %w", err)
+	}
+	return nil
+}
+
+// Recent returns the player's stored fingerprints, oldest first
+func (s *RedisFingerprintStore) Recent(ctx context.Context, playerID string) ([]*models.ResponseFingerprint, error) {
+	entries, err := s.redis.Client.LRange(ctx, fingerprintKey(playerID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get response fingerprints: %w", err)
+	}
+
+	fingerprints := make([]*models.ResponseFingerprint, 0, len(entries))
+	for _, entry := range entries {
+		var fingerprint models.ResponseFingerprint
+		if err := json.Unmarshal([]byte(entry), &fingerprint); err != nil {
+			fmt.Printf("Warning: skipping unreadable response fingerprint of player %s: %v\n", playerID, err)
+			continue
+		}
+		fingerprints = append(fingerprints, &fingerprint)
+	}
+	return fingerprints, nil
+}
+
+// fingerprintKey returns the Redis key for a player's response fingerprints
+func fingerprintKey(playerID string) string {
+	return fmt.Sprintf("response_fingerprints:%s", playerID)
+}
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRoundSnapshotTTL drops snapshots nobody recovered long after their round would have ended
+const DefaultRoundSnapshotTTL = time.Hour
+
+// roundSnapshotsKey is the sorted set of snapshotted sessions, scored by when they were last
+// snapshotted in milliseconds
+const roundSnapshotsKey = "roundsnapshots"
+
+// deleteRoundSnapshotScript drops a snapshot unless another instance has saved it since, so an
+// instance whose players left never drops the snapshot of the instance they moved to
+//
+// KEYS: the snapshot key, the snapshot index. ARGV: instance ID, session ID.
+var deleteRoundSnapshotScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if data and cjson.decode(data).instanceId ~= ARGV[1] then
+	return 0
+end
+redis.call('ZREM', KEYS[2], ARGV[2])
+redis.call('DEL', KEYS[1])
+return 1
+`)
+
+// RoundSnapshotStore keeps the latest snapshot of each round in play, so the round can be
+// rebuilt by another instance when the one running it stops refreshing it
+type RoundSnapshotStore interface {
+	Save(ctx context.Context, snapshot *models.RoundSnapshot) error
+	Delete(ctx context.Context, sessionID, instanceID string) error
+	Stale(ctx context.Context, before time.Time, limit int) ([]*models.RoundSnapshot, error)
+	Claim(ctx context.Context, sessionID string) (bool, error)
+}
+
+// RedisRoundSnapshotStore stores each snapshot as a JSON string, indexed by age in a sorted set
+type RedisRoundSnapshotStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewRoundSnapshotStore creates a Redis-backed round snapshot store; snapshots expire after ttl
+func NewRoundSnapshotStore(redis *database.RedisClient, ttl time.Duration) RoundSnapshotStore {
+	if ttl <= 0 {
+		ttl = DefaultRoundSnapshotTTL
+	}
+	return &RedisRoundSnapshotStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Save replaces the session's snapshot and marks it fresh
+func (s *RedisRoundSnapshotStore) Save(ctx context.Context, snapshot *models.RoundSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal round snapshot: %w", err)
+	}
+
+	pipe := s.redis.Client.TxPipeline()
+	pipe.Set(ctx, roundSnapshotKey(snapshot.SessionID), data, s.ttl)
+	pipe.ZAdd(ctx, roundSnapshotsKey, redis.Z{
+		Score:  float64(snapshot.TakenAt.UnixMilli()),
+		Member: snapshot.SessionID,
+	})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save round snapshot: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the session's snapshot once its round is no longer in play on the instance,
+// unless another instance has saved it since
+func (s *RedisRoundSnapshotStore) Delete(ctx context.Context, sessionID, instanceID string) error {
+	keys := []string{roundSnapshotKey(sessionID), roundSnapshotsKey}
+	if err := deleteRoundSnapshotScript.Run(ctx, s.redis.Client, keys, instanceID, sessionID).Err(); err != nil {
+		return fmt.Errorf("failed to delete round snapshot: %w", err)
+	}
+	return nil
+}
+
+// drop removes the session's snapshot, whichever instance saved it
+func (s *RedisRoundSnapshotStore) drop(ctx context.Context, sessionID string) error {
+	pipe := s.redis.Client.TxPipeline()
+	pipe.ZRem(ctx, roundSnapshotsKey, sessionID)
+	pipe.Del(ctx, roundSnapshotKey(sessionID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete round snapshot: %w", err)
+	}
+	return nil
+}
+
+// Stale returns up to limit snapshots last saved before the given time, oldest first.
+// Snapshots that expired are dropped from the index.
+func (s *RedisRoundSnapshotStore) Stale(ctx context.Context, before time.Time, limit int) ([]*models.RoundSnapshot, error) {
+	sessionIDs, err := s.redis.Client.ZRangeByScore(ctx, roundSnapshotsKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale round snapshots: %w", err)
+	}
+
+	snapshots := make([]*models.RoundSnapshot, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		data, err := s.redis.Get(ctx, roundSnapshotKey(sessionID))
+		if errors.Is(err, redis.Nil) {
+			s.redis.Client.ZRem(ctx, roundSnapshotsKey, sessionID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get round snapshot: %w", err)
+		}
+
+		var snapshot models.RoundSnapshot
+		if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+			fmt.Printf("Warning: dropping malformed round snapshot for session %s: %v\n", sessionID, err)
+			s.drop(ctx, sessionID)
+			continue
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// Claim removes a stale snapshot from the index and reports whether this caller removed it, so
+// exactly one instance rebuilds each round
+func (s *RedisRoundSnapshotStore) Claim(ctx context.Context, sessionID string) (bool, error) {
+	removed, err := s.redis.Client.ZRem(ctx, roundSnapshotsKey, sessionID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim round snapshot: %w", err)
+	}
+	if removed == 0 {
+		return false, nil
+	}
+
+	if err := s.redis.Delete(ctx, roundSnapshotKey(sessionID)); err != nil {
+		fmt.Printf("Warning: failed to delete claimed round snapshot for session %s: %v\n", sessionID, err)
+	}
+	return true, nil
+}
+
+// roundSnapshotKey returns the Redis key for a session's round snapshot
+func roundSnapshotKey(sessionID string) string {
+	return fmt.Sprintf("roundsnapshot:%s", sessionID)
+}
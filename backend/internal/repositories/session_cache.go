@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Session cache settings
+const (
+	DefaultSessionCacheTTL = time.Hour
+	sessionCacheFormat     = 1 // bump when the cached GameSession shape changes incompatibly
+)
+
+// SessionCacheStore is the key-value store behind the session cache. Every session has a
+// revision that only moves forward; Bump advances it and drops the cached entry.
+type SessionCacheStore interface {
+	Load(ctx context.Context, sessionID string) (entry string, revision int64, err error)
+	Save(ctx context.Context, sessionID, entry string, ttl time.Duration) error
+	Bump(ctx context.Context, sessionID string, ttl time.Duration) (int64, error)
+}
+
+// cachedSession is the versioned envelope stored for each session. The password hash, a best-of
+// match's doors and the session's write version are kept out of the session's JSON, so the
+// envelope carries them alongside.
+type cachedSession struct {
+	Format       int                 `json:"format"`
+	Revision     int64               `json:"revision"`
+	Session      *models.GameSession `json:"session"`
+	PasswordHash string              `json:"passwordHash,omitempty"`
+	MatchDoors   []*models.Door      `json:"matchDoors,omitempty"`
+	Version      int64               `json:"version,omitempty"`
+}
+
+// SessionCache caches game sessions as JSON. Entries are tagged with the session's revision
+// when they are written; an entry whose revision no longer matches is stale and ignored, so
+// a reader that cached a session just before a write can never serve the old copy.
+type SessionCache struct {
+	store SessionCacheStore
+	ttl   time.Duration
+}
+
+// NewSessionCache creates a session cache keeping entries for ttl
+func NewSessionCache(store SessionCacheStore, ttl time.Duration) *SessionCache {
+	if ttl <= 0 {
+		ttl = DefaultSessionCacheTTL
+	}
+	return &SessionCache{store: store, ttl: ttl}
+}
+
+// Get returns the cached session, or nil on a miss, along with the session's current revision.
+// Pass the revision to Put after loading the session from the database.
+func (c *SessionCache) Get(ctx context.Context, sessionID string) (*models.GameSession, int64, error) {
+	entry, revision, err := c.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read session cache: %w", err)
+	}
+	if entry == "" {
+		return nil, revision, nil
+	}
+
+	var cached cachedSession
+	if err := json.Unmarshal([]byte(entry), &cached); err != nil {
+		fmt.Printf("Warning: discarding unreadable cached session %s: %v\n", sessionID, err)
+		return nil, revision, nil
+	}
+	if cached.Format != sessionCacheFormat || cached.Revision != revision || cached.Session == nil {
+		return nil, revision, nil
+	}
+
+	cached.Session.PasswordHash = cached.PasswordHash
+	cached.Session.MatchDoors = cached.MatchDoors
+	cached.Session.Version = cached.Version
+	return cached.Session, revision, nil
+}
+
+// Put caches a session as of the given revision
+func (c *SessionCache) Put(ctx context.Context, session *models.GameSession, revision int64) error {
+	entry, err := json.Marshal(cachedSession{
+		Format:       sessionCacheFormat,
+		Revision:     revision,
+		Session:      session,
+		PasswordHash: session.PasswordHash,
+		MatchDoors:   session.MatchDoors,
+		Version:      session.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode cached session: %w", err)
+	}
+
+	if err := c.store.Save(ctx, session.SessionID, string(entry), c.ttl); err != nil {
+		return fmt.Errorf("failed to write session cache: %w", err)
+	}
+	return nil
+}
+
+// Invalidate marks every cached copy of the session stale and returns the new revision
+func (c *SessionCache) Invalidate(ctx context.Context, sessionID string) (int64, error) {
+	revision, err := c.store.Bump(ctx, sessionID, c.ttl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to invalidate session cache: %w", err)
+	}
+	return revision, nil
+}
+
+// WriteThrough invalidates older copies of the session and caches the one just written
+func (c *SessionCache) WriteThrough(ctx context.Context, session *models.GameSession) error {
+	revision, err := c.Invalidate(ctx, session.SessionID)
+	if err != nil {
+		return err
+	}
+	return c.Put(ctx, session, revision)
+}
+
+// RedisSessionCacheStore keeps cached sessions and their revisions in Redis
+type RedisSessionCacheStore struct {
+	redis *database.RedisClient
+}
+
+// NewRedisSessionCacheStore creates a Redis-backed session cache store
+func NewRedisSessionCacheStore(redis *database.RedisClient) SessionCacheStore {
+	return &RedisSessionCacheStore{redis: redis}
+}
+
+// Load reads a session's cached entry and current revision in one round trip
+func (s *RedisSessionCacheStore) Load(ctx context.Context, sessionID string) (string, int64, error) {
+	values, err := s.redis.Client.MGet(ctx, sessionEntryKey(sessionID), sessionRevisionKey(sessionID)).Result()
+	if err != nil {
+		return "", 0, err
+	}
+
+	entry, _ := values[0].(string)
+
+	var revision int64
+	if raw, ok := values[1].(string); ok {
+		if revision, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return "", 0, fmt.Errorf("invalid session revision %q: %w", raw, err)
+		}
+	}
+	return entry, revision, nil
+}
+
+// Save stores a session's entry, keeping its revision alive at least as long as the entry
+func (s *RedisSessionCacheStore) Save(ctx context.Context, sessionID, entry string, ttl time.Duration) error {
+	_, err := s.redis.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionEntryKey(sessionID), entry, ttl)
+		pipe.Expire(ctx, sessionRevisionKey(sessionID), 2*ttl)
+		return nil
+	})
+	return err
+}
+
+// Bump advances a session's revision and drops its cached entry atomically
+func (s *RedisSessionCacheStore) Bump(ctx context.Context, sessionID string, ttl time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := s.redis.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, sessionRevisionKey(sessionID))
+		pipe.Expire(ctx, sessionRevisionKey(sessionID), 2*ttl)
+		pipe.Del(ctx, sessionEntryKey(sessionID))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// sessionEntryKey is the Redis key holding a cached session
+func sessionEntryKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
+
+// sessionRevisionKey is the Redis key holding a session's cache revision
+func sessionRevisionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s:rev", sessionID)
+}
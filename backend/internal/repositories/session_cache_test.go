@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// memorySessionCacheStore is an in-memory SessionCacheStore
+type memorySessionCacheStore struct {
+	entries   map[string]string
+	revisions map[string]int64
+	loadErr   error
+}
+
+func newMemorySessionCacheStore() *memorySessionCacheStore {
+	return &memorySessionCacheStore{
+		entries:   make(map[string]string),
+		revisions: make(map[string]int64),
+	}
+}
+
+func (m *memorySessionCacheStore) Load(ctx context.Context, sessionID string) (string, int64, error) {
+	if m.loadErr != nil {
+		return "", 0, m.loadErr
+	}
+	return m.entries[sessionID], m.revisions[sessionID], nil
+}
+
+func (m *memorySessionCacheStore) Save(ctx context.Context, sessionID, entry string, ttl time.Duration) error {
+	m.entries[sessionID] = entry
+	return nil
+}
+
+func (m *memorySessionCacheStore) Bump(ctx context.Context, sessionID string, ttl time.Duration) (int64, error) {
+	m.revisions[sessionID]++
+	delete(m.entries, sessionID)
+	return m.revisions[sessionID], nil
+}
+
+func TestSessionCache_MissThenHit(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSessionCache(newMemorySessionCacheStore(), time.Minute)
+
+	cached, revision, err := cache.Get(ctx, "session1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if cached != nil {
+		t.Fatal("Expected a miss for an uncached session")
+	}
+
+	session := newTestSession("session1")
+	session.Players[0].Responses = []models.PlayerResponse{
+		{ResponseID: "r1", DoorID: "door1", PlayerID: "player1", Content: "Climb out", AIScore: 72},
+	}
+	if err := cache.Put(ctx, session, revision); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	cached, _, err = cache.Get(ctx, "session1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if cached == nil {
+		t.Fatal("Expected a cache hit after Put")
+	}
+	if cached.Status != models.GameStatusActive || len(cached.Players) != 1 {
+		t.Fatalf("Cached session did not round-trip: %+v", cached)
+	}
+	if got := cached.Players[0].Responses[0]; got.Content != "Climb out" || got.AIScore != 72 {
+		t.Errorf("Expected response to round-trip, got %+v", got)
+	}
+	if !cached.CreatedAt.Equal(session.CreatedAt) {
+		t.Errorf("Expected CreatedAt %v, got %v", session.CreatedAt, cached.CreatedAt)
+	}
+}
+
+func TestSessionCache_WriteThroughReplacesOlderCopy(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSessionCache(newMemorySessionCacheStore(), time.Minute)
+
+	session := newTestSession("session1")
+	if err := cache.WriteThrough(ctx, session); err != nil {
+		t.Fatalf("WriteThrough failed: %v", err)
+	}
+
+	updated := newTestSession("session1")
+	updated.Status = models.GameStatusCompleted
+	if err := cache.WriteThrough(ctx, updated); err != nil {
+		t.Fatalf("WriteThrough failed: %v", err)
+	}
+
+	cached, _, err := cache.Get(ctx, "session1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if cached == nil || cached.Status != models.GameStatusCompleted {
+		t.Fatalf("Expected the updated session, got %+v", cached)
+	}
+}
+
+func TestSessionCache_InvalidateMakesReadersMiss(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSessionCache(newMemorySessionCacheStore(), time.Minute)
+
+	if err := cache.WriteThrough(ctx, newTestSession("session1")); err != nil {
+		t.Fatalf("WriteThrough failed: %v", err)
+	}
+	if _, err := cache.Invalidate(ctx, "session1"); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+
+	cached, _, err := cache.Get(ctx, "session1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if cached != nil {
+		t.Fatal("Expected a miss after invalidation")
+	}
+}
+
+func TestSessionCache_IgnoresCopyFromBeforeConcurrentWrite(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSessionCache(newMemorySessionCacheStore(), time.Minute)
+
+	// A reader misses and loads the session from the database...
+	_, revision, err := cache.Get(ctx, "session1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	stale := newTestSession("session1")
+
+	// ...a player joins before the reader gets to cache what it read...
+	if _, err := cache.Invalidate(ctx, "session1"); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+
+	// ...so the copy it caches is tagged with an old revision and never served
+	if err := cache.Put(ctx, stale, revision); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	cached, _, err := cache.Get(ctx, "session1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if cached != nil {
+		t.Fatal("Expected the stale copy to be ignored")
+	}
+}
+
+func TestSessionCache_IgnoresOtherFormatsAndCorruptEntries(t *testing.T) {
+	ctx := context.Background()
+	store := newMemorySessionCacheStore()
+	cache := NewSessionCache(store, time.Minute)
+
+	oldFormat, err := json.Marshal(cachedSession{Format: sessionCacheFormat + 1, Session: newTestSession("session1")})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	store.entries["session1"] = string(oldFormat)
+	store.entries["session2"] = "{not json"
+
+	for _, sessionID := range []string{"session1", "session2"} {
+		cached, _, err := cache.Get(ctx, sessionID)
+		if err != nil {
+			t.Fatalf("Get %s failed: %v", sessionID, err)
+		}
+		if cached != nil {
+			t.Errorf("Expected %s to be treated as a miss", sessionID)
+		}
+	}
+}
+
+func TestSessionCache_ReportsStoreErrors(t *testing.T) {
+	store := newMemorySessionCacheStore()
+	store.loadErr = errors.New("connection refused")
+	cache := NewSessionCache(store, time.Minute)
+
+	if _, _, err := cache.Get(context.Background(), "session1"); err == nil {
+		t.Fatal("Expected an error when the store is unavailable")
+	}
+}
+
+func TestSessionCache_KeepsPasswordHashOutOfSessionJSON(t *testing.T) {
+	ctx := context.Background()
+	store := newMemorySessionCacheStore()
+	cache := NewSessionCache(store, time.Minute)
+
+	session := newTestSession("session1")
+	session.PasswordHash = "hash"
+	session.Version = 7
+	session.MatchDoors = []*models.Door{{DoorID: "door1"}, {DoorID: "door2"}}
+	if err := cache.WriteThrough(ctx, session); err != nil {
+		t.Fatalf("WriteThrough failed: %v", err)
+	}
+
+	var entry struct {
+		Session map[string]interface{} `json:"session"`
+	}
+	if err := json.Unmarshal([]byte(store.entries["session1"]), &entry); err != nil {
+		t.Fatalf("failed to decode entry: %v", err)
+	}
+	if _, leaked := entry.Session["passwordHash"]; leaked {
+		t.Error("The password hash should not be part of the session's JSON")
+	}
+
+	cached, _, err := cache.Get(ctx, "session1")
+	if err != nil || cached == nil {
+		t.Fatalf("Expected a cache hit, got %v (err %v)", cached, err)
+	}
+	if cached.PasswordHash != "hash" {
+		t.Errorf("Expected the password hash to survive caching, got %q", cached.PasswordHash)
+	}
+	if cached.Version != 7 {
+		t.Errorf("Expected the write version to survive caching, got %d", cached.Version)
+	}
+	if len(cached.MatchDoors) != 2 || cached.MatchDoors[1].DoorID != "door2" {
+		t.Errorf("Expected the match doors to survive caching, got %+v", cached.MatchDoors)
+	}
+}
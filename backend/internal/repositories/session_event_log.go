@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Session event log defaults
+const (
+	DefaultSessionEventLogSize = 100
+	DefaultSessionEventLogTTL  = 24 * time.Hour
+)
+
+// appendEventScript numbers an event with the session's next sequence number and adds it to the
+// log, keeping only the newest events. Numbering and appending together keeps the log in
+// sequence order however many instances broadcast to the session. Members are prefixed with
+// their sequence number, so identical payloads are still kept apart.
+//
+// KEYS: sequence key, log key. ARGV: payload, events kept, TTL in seconds.
+var appendEventScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+redis.call('ZADD', KEYS[2], seq, seq .. ':' .. ARGV[1])
+redis.call('ZREMRANGEBYRANK', KEYS[2], 0, -tonumber(ARGV[2]) - 1)
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+return seq
+`)
+
+// SequencedEvent is an encoded session event and its sequence number
+type SequencedEvent struct {
+	Sequence int64
+	Payload  []byte
+}
+
+// SessionEventLog numbers each session's broadcast events and keeps the most recent ones, so
+// clients that briefly lost their connection can catch up on what they missed
+type SessionEventLog interface {
+	Append(ctx context.Context, sessionID string, payload []byte) (int64, error)
+	Since(ctx context.Context, sessionID string, after int64) ([]SequencedEvent, int64, error)
+}
+
+// RedisSessionEventLog keeps each session's recent events in a sorted set scored by sequence
+// number, next to a counter holding the last number issued
+type RedisSessionEventLog struct {
+	redis *database.RedisClient
+	size  int
+	ttl   time.Duration
+}
+
+// NewSessionEventLog creates a Redis-backed session event log keeping the last size events of
+// each session; logs of sessions without events for ttl expire
+func NewSessionEventLog(redis *database.RedisClient, size int, ttl time.Duration) SessionEventLog {
+	if size <= 0 {
+		size = DefaultSessionEventLogSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultSessionEventLogTTL
+	}
+	return &RedisSessionEventLog{
+		redis: redis,
+		size:  size,
+		ttl:   ttl,
+	}
+}
+
+// Append adds an event to the session's log and returns the sequence number it was given
+func (l *RedisSessionEventLog) Append(ctx context.Context, sessionID string, payload []byte) (int64, error) {
+	seq, err := appendEventScript.Run(ctx, l.redis.Client,
+		[]string{sessionSequenceKey(sessionID), sessionEventLogKey(sessionID)},
+		payload, l.size, int64(l.ttl.Seconds()),
+	).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to append session event: %w", err)
+	}
+	return seq, nil
+}
+
+// Since returns the logged events numbered after the given sequence number, oldest first, and
+// the last number issued. Events that have already left the log are not returned.
+func (l *RedisSessionEventLog) Since(ctx context.Context, sessionID string, after int64) ([]SequencedEvent, int64, error) {
+	latest, err := l.redis.Client.Get(ctx, sessionSequenceKey(sessionID)).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, fmt.Errorf("failed to get session sequence: %w", err)
+	}
+	if latest <= after {
+		return nil, latest, nil
+	}
+
+	entries, err := l.redis.Client.ZRangeByScoreWithScores(ctx, sessionEventLogKey(sessionID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(after, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get session events: %w", err)
+	}
+
+	events := make([]SequencedEvent, 0, len(entries))
+	for _, entry := range entries {
+		member, _ := entry.Member.(string)
+		_, payload, _ := strings.Cut(member, ":")
+		events = append(events, SequencedEvent{Sequence: int64(entry.Score), Payload: []byte(payload)})
+	}
+	return events, latest, nil
+}
+
+// sessionSequenceKey returns the Redis key holding a session's last sequence number
+func sessionSequenceKey(sessionID string) string {
+	return fmt.Sprintf("session_seq:%s", sessionID)
+}
+
+// sessionEventLogKey returns the Redis key holding a session's recent events
+func sessionEventLogKey(sessionID string) string {
+	return fmt.Sprintf("session_events:%s", sessionID)
+}
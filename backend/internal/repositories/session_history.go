@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SessionEventRepository stores each session's event log
+type SessionEventRepository interface {
+	Append(ctx context.Context, event *models.SessionEvent) error
+	List(ctx context.Context, sessionID string) ([]*models.SessionEvent, error)
+}
+
+// SessionEventRepositoryImpl implements the SessionEventRepository interface
+type SessionEventRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewSessionEventRepository creates a new session event repository
+func NewSessionEventRepository(mongodb *database.MongoClient) SessionEventRepository {
+	return &SessionEventRepositoryImpl{
+		collection: mongodb.GetCollection("session_events"),
+	}
+}
+
+// Append stores an event in its session's log
+func (r *SessionEventRepositoryImpl) Append(ctx context.Context, event *models.SessionEvent) error {
+	if _, err := r.collection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to append session event: %w", err)
+	}
+	return nil
+}
+
+// List returns a session's events in the order they happened
+func (r *SessionEventRepositoryImpl) List(ctx context.Context, sessionID string) ([]*models.SessionEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "eventId", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"sessionId": sessionID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []*models.SessionEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode session events: %w", err)
+	}
+	return events, nil
+}
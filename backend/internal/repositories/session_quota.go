@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultSessionQuotaTTL is how long a session counts against its quotas if it is never released,
+// longer than any session stays open
+const DefaultSessionQuotaTTL = 24 * time.Hour
+
+// sessionLimitsKey holds the limits set through the admin API
+const sessionLimitsKey = "session_limits"
+
+// acquireQuotaScript counts a session against each quota key unless one of them is full. Entries
+// older than the cutoff are dropped first, so sessions that were never released stop counting.
+// It returns the 1-based index of the first full quota, or 0 once the session is counted.
+//
+// KEYS: the quota keys, then the session's held key. ARGV: session ID, now and cutoff in
+// milliseconds, TTL in seconds, then one limit per quota key.
+var acquireQuotaScript = redis.NewScript(`
+local held = KEYS[#KEYS]
+for i = 1, #KEYS - 1 do
+	redis.call('ZREMRANGEBYSCORE', KEYS[i], '-inf', ARGV[3])
+	local limit = tonumber(ARGV[4 + i])
+	if limit > 0 and not redis.call('ZSCORE', KEYS[i], ARGV[1]) and redis.call('ZCARD', KEYS[i]) >= limit then
+		return i
+	end
+end
+for i = 1, #KEYS - 1 do
+	redis.call('ZADD', KEYS[i], ARGV[2], ARGV[1])
+	redis.call('EXPIRE', KEYS[i], ARGV[4])
+	redis.call('SADD', held, KEYS[i])
+end
+redis.call('EXPIRE', held, ARGV[4])
+return 0
+`)
+
+// releaseQuotaScript stops a session counting against every quota it acquired
+var releaseQuotaScript = redis.NewScript(`
+local keys = redis.call('SMEMBERS', KEYS[1])
+for _, key in ipairs(keys) do
+	redis.call('ZREM', key, ARGV[1])
+end
+redis.call('DEL', KEYS[1])
+return #keys
+`)
+
+// SessionQuotaStore counts unfinished sessions per subreddit and per player, and keeps the limits
+// admins have set
+type SessionQuotaStore interface {
+	Acquire(ctx context.Context, sessionID string, quotas []models.SessionQuota) (*models.SessionQuota, error)
+	Release(ctx context.Context, sessionID string) error
+	Active(ctx context.Context, scope, id string) (int, error)
+	GetLimits(ctx context.Context) (*models.SessionLimits, error)
+	SetLimits(ctx context.Context, limits models.SessionLimits) error
+}
+
+// RedisSessionQuotaStore keeps each quota as a sorted set of session IDs scored by when they
+// started, so the count is shared by every instance
+type RedisSessionQuotaStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewSessionQuotaStore creates a Redis-backed session quota store; unreleased sessions stop
+// counting after ttl
+func NewSessionQuotaStore(redis *database.RedisClient, ttl time.Duration) SessionQuotaStore {
+	if ttl <= 0 {
+		ttl = DefaultSessionQuotaTTL
+	}
+	return &RedisSessionQuotaStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Acquire counts the session against every quota, or against none if one of them is full, in
+// which case that quota is returned
+func (s *RedisSessionQuotaStore) Acquire(ctx context.Context, sessionID string, quotas []models.SessionQuota) (*models.SessionQuota, error) {
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	keys := make([]string, 0, len(quotas)+1)
+	args := []interface{}{sessionID, now.UnixMilli(), now.Add(-s.ttl).UnixMilli(), int64(s.ttl.Seconds())}
+	for _, quota := range quotas {
+		keys = append(keys, sessionQuotaKey(quota.Scope, quota.ID))
+		args = append(args, quota.Limit)
+	}
+	keys = append(keys, sessionQuotaHeldKey(sessionID))
+
+	full, err := acquireQuotaScript.Run(ctx, s.redis.Client, keys, args...).Int()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire session quota: %w", err)
+	}
+	if full == 0 {
+		return nil, nil
+	}
+
+	return &quotas[full-1], nil
+}
+
+// Release stops the session counting against its quotas. Releasing a session twice is harmless.
+func (s *RedisSessionQuotaStore) Release(ctx context.Context, sessionID string) error {
+	if err := releaseQuotaScript.Run(ctx, s.redis.Client, []string{sessionQuotaHeldKey(sessionID)}, sessionID).Err(); err != nil {
+		return fmt.Errorf("failed to release session quota: %w", err)
+	}
+	return nil
+}
+
+// Active returns how many unfinished sessions count against a quota
+func (s *RedisSessionQuotaStore) Active(ctx context.Context, scope, id string) (int, error) {
+	cutoff := time.Now().Add(-s.ttl).UnixMilli()
+	count, err := s.redis.Client.ZCount(ctx, sessionQuotaKey(scope, id), "("+strconv.FormatInt(cutoff, 10), "+inf").Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active sessions: %w", err)
+	}
+	return int(count), nil
+}
+
+// GetLimits returns the limits set through the admin API, or nil if none have been set
+func (s *RedisSessionQuotaStore) GetLimits(ctx context.Context) (*models.SessionLimits, error) {
+	data, err := s.redis.Client.Get(ctx, sessionLimitsKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session limits: %w", err)
+	}
+
+	var limits models.SessionLimits
+	if err := json.Unmarshal([]byte(data), &limits); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session limits: %w", err)
+	}
+	return &limits, nil
+}
+
+// SetLimits stores new limits; they apply to sessions created from then on
+func (s *RedisSessionQuotaStore) SetLimits(ctx context.Context, limits models.SessionLimits) error {
+	data, err := json.Marshal(limits)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session limits: %w", err)
+	}
+	if err := s.redis.Client.Set(ctx, sessionLimitsKey, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to save session limits: %w", err)
+	}
+	return nil
+}
+
+// sessionQuotaKey returns the Redis key counting sessions in a quota
+func sessionQuotaKey(scope, id string) string {
+	return fmt.Sprintf("session_quota:%s:%s", scope, id)
+}
+
+// sessionQuotaHeldKey returns the Redis key listing the quotas a session counts against
+func sessionQuotaHeldKey(sessionID string) string {
+	return fmt.Sprintf("session_quota_held:%s", sessionID)
+}
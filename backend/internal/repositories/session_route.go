@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultSessionRouteTTL is how long a route outlives the last renewal by its instance, so the
+// sessions of an instance that died are free to be claimed again soon after
+const DefaultSessionRouteTTL = 2 * time.Minute
+
+// renewRouteScript extends a route's TTL if it still points at the instance renewing it
+//
+// KEYS: the route key. ARGV: instance ID, TTL in milliseconds.
+var renewRouteScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// transferRouteScript moves a route from one instance to another, unless a third instance
+// took it meanwhile. A route that expired is claimed outright.
+//
+// KEYS: the route key. ARGV: previous instance ID, new instance ID, TTL in milliseconds.
+var transferRouteScript = redis.NewScript(`
+local owner = redis.call('GET', KEYS[1])
+if owner and owner ~= ARGV[1] and owner ~= ARGV[2] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// releaseRouteScript drops a route if it still points at the instance releasing it
+//
+// KEYS: the route key. ARGV: instance ID.
+var releaseRouteScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// SessionRouteStore records which backend instance holds the in-memory state of each session.
+// Routes expire unless their instance renews them.
+type SessionRouteStore interface {
+	Claim(ctx context.Context, sessionID, instanceID string) (string, error)
+	Owner(ctx context.Context, sessionID string) (string, error)
+	// Renew extends the route if it still points at the instance, reporting whether it does
+	Renew(ctx context.Context, sessionID, instanceID string) (bool, error)
+	// Transfer moves the route from an instance that stopped serving the session to another,
+	// reporting whether it moved
+	Transfer(ctx context.Context, sessionID, from, to string) (bool, error)
+	// Release drops the route if it still points at the instance
+	Release(ctx context.Context, sessionID, instanceID string) error
+	// Delete drops the route of a session that ended, whoever holds it
+	Delete(ctx context.Context, sessionID string) error
+}
+
+// RedisSessionRouteStore keeps session routes in Redis so every instance and load balancer sees them
+type RedisSessionRouteStore struct {
+	redis *database.RedisClient
+	ttl   time.Duration
+}
+
+// NewSessionRouteStore creates a Redis-backed session route store; routes expire after ttl
+func NewSessionRouteStore(redis *database.RedisClient, ttl time.Duration) SessionRouteStore {
+	if ttl <= 0 {
+		ttl = DefaultSessionRouteTTL
+	}
+	return &RedisSessionRouteStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Claim pins a session to an instance unless another instance already holds it, and returns
+// the instance that does
+func (s *RedisSessionRouteStore) Claim(ctx context.Context, sessionID, instanceID string) (string, error) {
+	claimed, err := s.redis.Client.SetNX(ctx, sessionRouteKey(sessionID), instanceID, s.ttl).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to claim session route: %w", err)
+	}
+	if claimed {
+		return instanceID, nil
+	}
+
+	owner, err := s.Owner(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	if owner == "" {
+		// The route expired between the two calls; nobody holds the session now
+		return s.Claim(ctx, sessionID, instanceID)
+	}
+	return owner, nil
+}
+
+// Owner returns the instance a session is pinned to, or an empty string for unrouted sessions
+func (s *RedisSessionRouteStore) Owner(ctx context.Context, sessionID string) (string, error) {
+	instanceID, err := s.redis.Get(ctx, sessionRouteKey(sessionID))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get session route: %w", err)
+	}
+	return instanceID, nil
+}
+
+// Renew extends the route if it still points at the instance, reporting whether it does
+func (s *RedisSessionRouteStore) Renew(ctx context.Context, sessionID, instanceID string) (bool, error) {
+	renewed, err := renewRouteScript.Run(ctx, s.redis.Client, []string{sessionRouteKey(sessionID)}, instanceID, s.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to renew session route: %w", err)
+	}
+	return renewed == 1, nil
+}
+
+// Transfer moves the route from an instance that stopped serving the session to another,
+// reporting whether it moved
+func (s *RedisSessionRouteStore) Transfer(ctx context.Context, sessionID, from, to string) (bool, error) {
+	moved, err := transferRouteScript.Run(ctx, s.redis.Client, []string{sessionRouteKey(sessionID)}, from, to, s.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to transfer session route: %w", err)
+	}
+	return moved == 1, nil
+}
+
+// Release drops the route if it still points at the instance
+func (s *RedisSessionRouteStore) Release(ctx context.Context, sessionID, instanceID string) error {
+	if err := releaseRouteScript.Run(ctx, s.redis.Client, []string{sessionRouteKey(sessionID)}, instanceID).Err(); err != nil {
+		return fmt.Errorf("failed to release session route: %w", err)
+	}
+	return nil
+}
+
+// Delete drops the route of a session that ended, whoever holds it
+func (s *RedisSessionRouteStore) Delete(ctx context.Context, sessionID string) error {
+	if err := s.redis.Delete(ctx, sessionRouteKey(sessionID)); err != nil {
+		return fmt.Errorf("failed to delete session route: %w", err)
+	}
+	return nil
+}
+
+// sessionRouteKey returns the Redis key for a session's route
+func sessionRouteKey(sessionID string) string {
+	return fmt.Sprintf("sessionroute:%s", sessionID)
+}
@@ -0,0 +1,269 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DefaultSnapshotIdleTTL is how long an untouched session snapshot stays in memory
+const DefaultSnapshotIdleTTL = 30 * time.Minute
+
+// sessionSnapshot is the in-memory copy of an active session
+type sessionSnapshot struct {
+	session    *models.GameSession
+	lastAccess time.Time
+}
+
+// SessionSnapshotRepository keeps an authoritative in-memory snapshot of active
+// sessions in front of another GameSessionRepository. Reads are served from memory
+// and writes go through to the underlying store before the snapshot is updated.
+// Writes made by other instances never reach the snapshot, so it is only safe when a
+// single instance serves every session.
+type SessionSnapshotRepository struct {
+	inner     GameSessionRepository
+	snapshots map[string]*sessionSnapshot
+	mu        sync.RWMutex
+	idleTTL   time.Duration
+
+	hits   *monitoring.Counter
+	misses *monitoring.Counter
+	size   *monitoring.Gauge
+}
+
+// NewSessionSnapshotRepository wraps a game session repository with an in-memory snapshot cache.
+// Idle snapshots are evicted in the background until ctx is cancelled.
+func NewSessionSnapshotRepository(ctx context.Context, inner GameSessionRepository, idleTTL time.Duration) GameSessionRepository {
+	if idleTTL <= 0 {
+		idleTTL = DefaultSnapshotIdleTTL
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	repo := &SessionSnapshotRepository{
+		inner:     inner,
+		snapshots: make(map[string]*sessionSnapshot),
+		idleTTL:   idleTTL,
+		hits:      collector.NewCounter("session_snapshot_hits_total", "Session reads served from the in-memory snapshot", nil),
+		misses:    collector.NewCounter("session_snapshot_misses_total", "Session reads that fell through to the database", nil),
+		size:      collector.NewGauge("session_snapshots_active", "Number of sessions held in the snapshot cache", nil),
+	}
+
+	go repo.evictionRoutine(ctx)
+
+	return repo
+}
+
+// Create creates a session and snapshots it
+func (r *SessionSnapshotRepository) Create(ctx context.Context, session *models.GameSession) error {
+	if err := r.inner.Create(ctx, session); err != nil {
+		return err
+	}
+
+	r.store(session)
+	return nil
+}
+
+// GetByID returns a copy of the snapshot, loading it from the underlying store on a miss
+func (r *SessionSnapshotRepository) GetByID(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	r.mu.Lock()
+	if snapshot, exists := r.snapshots[sessionID]; exists {
+		snapshot.lastAccess = time.Now()
+		session, err := cloneSession(snapshot.session)
+		r.mu.Unlock()
+
+		r.hits.Inc()
+		if err != nil {
+			return nil, err
+		}
+		session.MarkLoaded()
+		return session, nil
+	}
+	r.mu.Unlock()
+
+	r.misses.Inc()
+	session, err := r.inner.GetByID(ctx, sessionID)
+	if err != nil || session == nil {
+		return session, err
+	}
+
+	r.store(session)
+	return session, nil
+}
+
+// Update writes the session through to the underlying store, then refreshes the snapshot
+func (r *SessionSnapshotRepository) Update(ctx context.Context, session *models.GameSession) error {
+	if err := r.inner.Update(ctx, session); err != nil {
+		// The store may have partially applied the write, so don't trust the snapshot
+		r.evict(session.SessionID)
+		return err
+	}
+
+	r.store(session)
+	return nil
+}
+
+// Delete removes the session from the underlying store and the snapshot cache
+func (r *SessionSnapshotRepository) Delete(ctx context.Context, sessionID string) error {
+	r.evict(sessionID)
+	return r.inner.Delete(ctx, sessionID)
+}
+
+// GetActiveSessionsByStatus always queries the underlying store
+func (r *SessionSnapshotRepository) GetActiveSessionsByStatus(ctx context.Context, status models.GameStatus) ([]*models.GameSession, error) {
+	return r.inner.GetActiveSessionsByStatus(ctx, status)
+}
+
+// FindOpenSessions always queries the underlying store
+func (r *SessionSnapshotRepository) FindOpenSessions(ctx context.Context, limit int) ([]*models.GameSession, error) {
+	return r.inner.FindOpenSessions(ctx, limit)
+}
+
+// AddPlayerToSession adds the player in the underlying store and appends them to the snapshot,
+// counting the write like the store does so whole-session updates made from older copies merge it
+func (r *SessionSnapshotRepository) AddPlayerToSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
+	if err := r.inner.AddPlayerToSession(ctx, sessionID, player); err != nil {
+		r.evict(sessionID)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if snapshot, exists := r.snapshots[sessionID]; exists {
+		snapshot.session.Players = append(snapshot.session.Players, player)
+		snapshot.session.Version++
+		snapshot.lastAccess = time.Now()
+	}
+
+	return nil
+}
+
+// UpdatePlayerInSession updates the player in the underlying store and in the snapshot, counting
+// the write like the store does
+func (r *SessionSnapshotRepository) UpdatePlayerInSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
+	if err := r.inner.UpdatePlayerInSession(ctx, sessionID, player); err != nil {
+		r.evict(sessionID)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if snapshot, exists := r.snapshots[sessionID]; exists {
+		for i, p := range snapshot.session.Players {
+			if p.PlayerID == player.PlayerID {
+				snapshot.session.Players[i] = player
+				break
+			}
+		}
+		snapshot.session.Version++
+		snapshot.lastAccess = time.Now()
+	}
+
+	return nil
+}
+
+// AppendPlayerResponse adds the response in the underlying store and snapshots the session it returns
+func (r *SessionSnapshotRepository) AppendPlayerResponse(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	session, err := r.inner.AppendPlayerResponse(ctx, sessionID, response)
+	return r.storeResult(sessionID, session, err)
+}
+
+// SetResponseScore scores the response in the underlying store and snapshots the session it returns
+func (r *SessionSnapshotRepository) SetResponseScore(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	session, err := r.inner.SetResponseScore(ctx, sessionID, response)
+	return r.storeResult(sessionID, session, err)
+}
+
+// storeResult snapshots the session returned by an atomic update. Updates that fail or match
+// nothing evict the snapshot, since another instance may have changed the session since it was taken.
+func (r *SessionSnapshotRepository) storeResult(sessionID string, session *models.GameSession, err error) (*models.GameSession, error) {
+	if err != nil || session == nil {
+		r.evict(sessionID)
+		return session, err
+	}
+
+	r.store(session)
+	return session, nil
+}
+
+// store snapshots a copy of the session, dropping sessions that are over from memory
+func (r *SessionSnapshotRepository) store(session *models.GameSession) {
+	if session.Status == models.GameStatusCompleted || session.Status == models.GameStatusAbandoned {
+		r.evict(session.SessionID)
+		return
+	}
+
+	snapshot, err := cloneSession(session)
+	if err != nil {
+		fmt.Printf("Warning: failed to snapshot session %s: %v\n", session.SessionID, err)
+		r.evict(session.SessionID)
+		return
+	}
+
+	r.mu.Lock()
+	r.snapshots[session.SessionID] = &sessionSnapshot{
+		session:    snapshot,
+		lastAccess: time.Now(),
+	}
+	r.size.Set(float64(len(r.snapshots)))
+	r.mu.Unlock()
+}
+
+// evict removes a session snapshot
+func (r *SessionSnapshotRepository) evict(sessionID string) {
+	r.mu.Lock()
+	delete(r.snapshots, sessionID)
+	r.size.Set(float64(len(r.snapshots)))
+	r.mu.Unlock()
+}
+
+// evictionRoutine periodically evicts idle snapshots until the context is cancelled
+func (r *SessionSnapshotRepository) evictionRoutine(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.evictIdle(now)
+		}
+	}
+}
+
+// evictIdle drops snapshots that have not been touched within the idle TTL
+func (r *SessionSnapshotRepository) evictIdle(now time.Time) {
+	cutoff := now.Add(-r.idleTTL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for sessionID, snapshot := range r.snapshots {
+		if snapshot.lastAccess.Before(cutoff) {
+			delete(r.snapshots, sessionID)
+		}
+	}
+	r.size.Set(float64(len(r.snapshots)))
+}
+
+// cloneSession deep-copies a session through BSON so callers can mutate it freely
+// and the copy has the same shape as a document read back from MongoDB
+func cloneSession(session *models.GameSession) (*models.GameSession, error) {
+	data, err := bson.Marshal(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode session snapshot: %w", err)
+	}
+
+	var clone models.GameSession
+	if err := bson.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to decode session snapshot: %w", err)
+	}
+
+	return &clone, nil
+}
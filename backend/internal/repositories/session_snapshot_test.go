@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingSessionRepository is an in-memory GameSessionRepository that counts reads
+type countingSessionRepository struct {
+	sessions  map[string]*models.GameSession
+	reads     int
+	updateErr error
+}
+
+func newCountingSessionRepository() *countingSessionRepository {
+	return &countingSessionRepository{sessions: make(map[string]*models.GameSession)}
+}
+
+func (m *countingSessionRepository) Create(ctx context.Context, session *models.GameSession) error {
+	m.sessions[session.SessionID] = session
+	return nil
+}
+
+func (m *countingSessionRepository) GetByID(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	m.reads++
+	session, exists := m.sessions[sessionID]
+	if !exists {
+		return nil, nil
+	}
+	return session, nil
+}
+
+func (m *countingSessionRepository) Update(ctx context.Context, session *models.GameSession) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.sessions[session.SessionID] = session
+	return nil
+}
+
+func (m *countingSessionRepository) Delete(ctx context.Context, sessionID string) error {
+	delete(m.sessions, sessionID)
+	return nil
+}
+
+func (m *countingSessionRepository) GetActiveSessionsByStatus(ctx context.Context, status models.GameStatus) ([]*models.GameSession, error) {
+	return nil, nil
+}
+
+func (m *countingSessionRepository) FindOpenSessions(ctx context.Context, limit int) ([]*models.GameSession, error) {
+	return nil, nil
+}
+
+func (m *countingSessionRepository) AddPlayerToSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
+	if session, exists := m.sessions[sessionID]; exists {
+		session.Players = append(session.Players, player)
+	}
+	return nil
+}
+
+func (m *countingSessionRepository) UpdatePlayerInSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
+	return nil
+}
+
+func (m *countingSessionRepository) AppendPlayerResponse(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	session, exists := m.sessions[sessionID]
+	if !exists {
+		return nil, nil
+	}
+	for i := range session.Players {
+		if session.Players[i].PlayerID != response.PlayerID {
+			continue
+		}
+		for _, existing := range session.Players[i].Responses {
+			if existing.DoorID == response.DoorID {
+				return nil, nil
+			}
+		}
+		session.Players[i].Responses = append(session.Players[i].Responses, response)
+		session.Players[i].TotalScore += response.AIScore
+		return session, nil
+	}
+	return nil, nil
+}
+
+func (m *countingSessionRepository) SetResponseScore(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	return nil, nil
+}
+
+func newTestSession(sessionID string) *models.GameSession {
+	return &models.GameSession{
+		SessionID: sessionID,
+		Mode:      models.GameModeMultiplayer,
+		Status:    models.GameStatusActive,
+		Players: []models.PlayerInfo{
+			{PlayerID: "player1", Username: "Player One", IsActive: true},
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// newTestSnapshotRepository wraps inner in a snapshot cache whose eviction stops with the test
+func newTestSnapshotRepository(t *testing.T, inner GameSessionRepository) *SessionSnapshotRepository {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return NewSessionSnapshotRepository(ctx, inner, time.Minute).(*SessionSnapshotRepository)
+}
+
+func TestSessionSnapshot_ServesReadsFromMemory(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingSessionRepository()
+	repo := newTestSnapshotRepository(t, inner)
+
+	if err := repo.Create(ctx, newTestSession("s1")); err != nil {
+		t.Fatalf("Unexpected create error: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		session, err := repo.GetByID(ctx, "s1")
+		if err != nil || session == nil {
+			t.Fatalf("Expected cached session, got %v, %v", session, err)
+		}
+	}
+
+	if inner.reads != 0 {
+		t.Errorf("Expected no reads from the underlying store, got %d", inner.reads)
+	}
+}
+
+func TestSessionSnapshot_ReturnsIndependentCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSnapshotRepository(t, newCountingSessionRepository())
+	repo.Create(ctx, newTestSession("s1"))
+
+	first, _ := repo.GetByID(ctx, "s1")
+	first.Players[0].TotalScore = 99
+
+	second, _ := repo.GetByID(ctx, "s1")
+	if second.Players[0].TotalScore != 0 {
+		t.Errorf("Mutating a returned session leaked into the snapshot")
+	}
+}
+
+func TestSessionSnapshot_WriteThrough(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingSessionRepository()
+	repo := newTestSnapshotRepository(t, inner)
+	repo.Create(ctx, newTestSession("s1"))
+
+	session, _ := repo.GetByID(ctx, "s1")
+	session.Players[0].TotalScore = 42
+	if err := repo.Update(ctx, session); err != nil {
+		t.Fatalf("Unexpected update error: %v", err)
+	}
+
+	if inner.sessions["s1"].Players[0].TotalScore != 42 {
+		t.Errorf("Expected update to reach the underlying store")
+	}
+
+	updated, _ := repo.GetByID(ctx, "s1")
+	if updated.Players[0].TotalScore != 42 {
+		t.Errorf("Expected snapshot to reflect update, got %d", updated.Players[0].TotalScore)
+	}
+
+	if err := repo.AddPlayerToSession(ctx, "s1", models.PlayerInfo{PlayerID: "player2"}); err != nil {
+		t.Fatalf("Unexpected add player error: %v", err)
+	}
+
+	updated, _ = repo.GetByID(ctx, "s1")
+	if len(updated.Players) != 2 {
+		t.Errorf("Expected 2 players in snapshot, got %d", len(updated.Players))
+	}
+}
+
+func TestSessionSnapshot_FailedUpdateEvicts(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingSessionRepository()
+	repo := newTestSnapshotRepository(t, inner)
+	repo.Create(ctx, newTestSession("s1"))
+
+	inner.updateErr = errors.New("write failed")
+	session, _ := repo.GetByID(ctx, "s1")
+	session.Players[0].TotalScore = 42
+	if err := repo.Update(ctx, session); err == nil {
+		t.Fatal("Expected update error")
+	}
+
+	reloaded, _ := repo.GetByID(ctx, "s1")
+	if inner.reads != 1 {
+		t.Errorf("Expected the snapshot to be reloaded from the store, got %d reads", inner.reads)
+	}
+	if reloaded.Players[0].TotalScore != 0 {
+		t.Errorf("Expected stored score 0 after failed update, got %d", reloaded.Players[0].TotalScore)
+	}
+}
+
+func TestSessionSnapshot_CompletedSessionsAreEvicted(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingSessionRepository()
+	repo := newTestSnapshotRepository(t, inner)
+	repo.Create(ctx, newTestSession("s1"))
+
+	session, _ := repo.GetByID(ctx, "s1")
+	session.Status = models.GameStatusCompleted
+	repo.Update(ctx, session)
+
+	repo.GetByID(ctx, "s1")
+	if inner.reads != 1 {
+		t.Errorf("Expected completed session to be read from the store, got %d reads", inner.reads)
+	}
+}
+
+func TestSessionSnapshot_AtomicUpdatesRefreshSnapshot(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingSessionRepository()
+	repo := newTestSnapshotRepository(t, inner)
+	repo.Create(ctx, newTestSession("s1"))
+	repo.AddPlayerToSession(ctx, "s1", models.PlayerInfo{PlayerID: "player2"})
+
+	// Another instance records player2's response behind this instance's snapshot
+	inner.sessions["s1"].Players[1].Responses = []models.PlayerResponse{{PlayerID: "player2", DoorID: "door-1", AIScore: 60}}
+
+	updated, err := repo.AppendPlayerResponse(ctx, "s1", models.PlayerResponse{PlayerID: "player1", DoorID: "door-1", AIScore: 70})
+	if err != nil || updated == nil {
+		t.Fatalf("Expected the response to be added, got %v, %v", updated, err)
+	}
+
+	session, _ := repo.GetByID(ctx, "s1")
+	if inner.reads != 0 {
+		t.Errorf("Expected the returned session to be snapshotted, got %d reads", inner.reads)
+	}
+	if len(session.Players[0].Responses) != 1 || session.Players[0].TotalScore != 70 || len(session.Players[1].Responses) != 1 {
+		t.Errorf("Expected the snapshot to hold both players' responses, got %+v", session.Players)
+	}
+
+	// A duplicate matches nothing, so the snapshot can't be trusted either
+	if duplicate, err := repo.AppendPlayerResponse(ctx, "s1", models.PlayerResponse{PlayerID: "player1", DoorID: "door-1"}); err != nil || duplicate != nil {
+		t.Fatalf("Expected a duplicate response to be refused, got %v, %v", duplicate, err)
+	}
+	repo.GetByID(ctx, "s1")
+	if inner.reads != 1 {
+		t.Errorf("Expected the snapshot to be reloaded after a refused update, got %d reads", inner.reads)
+	}
+}
+
+func TestSessionSnapshot_DropsSessionsThatAreOverOrIdle(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingSessionRepository()
+	repo := newTestSnapshotRepository(t, inner)
+	repo.Create(ctx, newTestSession("abandoned"))
+	repo.Create(ctx, newTestSession("idle"))
+
+	session, _ := repo.GetByID(ctx, "abandoned")
+	session.Status = models.GameStatusAbandoned
+	repo.Update(ctx, session)
+	repo.evictIdle(time.Now().Add(2 * time.Minute))
+
+	repo.GetByID(ctx, "abandoned")
+	repo.GetByID(ctx, "idle")
+	if inner.reads != 2 {
+		t.Errorf("Expected both sessions to be read from the store, got %d reads", inner.reads)
+	}
+}
+
+func TestSessionSnapshot_CountsPlayerWritesInVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSnapshotRepository(t, newCountingSessionRepository())
+	repo.Create(ctx, newTestSession("s1"))
+
+	before, _ := repo.GetByID(ctx, "s1")
+	repo.AddPlayerToSession(ctx, "s1", models.PlayerInfo{PlayerID: "player2"})
+	repo.UpdatePlayerInSession(ctx, "s1", models.PlayerInfo{PlayerID: "player2", IsActive: true})
+
+	after, _ := repo.GetByID(ctx, "s1")
+	if after.Version != before.Version+2 {
+		t.Errorf("Expected each player write to bump the version from %d, got %d", before.Version, after.Version)
+	}
+}
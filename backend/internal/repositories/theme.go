@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrThemeExists is returned when creating a theme whose ID is already taken
+var ErrThemeExists = errors.New("theme already exists")
+
+// ThemeRepository stores the theme catalog
+type ThemeRepository interface {
+	Create(ctx context.Context, theme *models.Theme) error
+	GetByID(ctx context.Context, themeID string) (*models.Theme, error)
+	List(ctx context.Context, enabledOnly bool) ([]*models.Theme, error)
+	Update(ctx context.Context, theme *models.Theme) error
+	Delete(ctx context.Context, themeID string) error
+}
+
+// ThemeRepositoryImpl implements the ThemeRepository interface
+type ThemeRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewThemeRepository creates a new theme repository
+func NewThemeRepository(mongodb *database.MongoClient) ThemeRepository {
+	return &ThemeRepositoryImpl{
+		collection: mongodb.GetCollection("themes"),
+	}
+}
+
+// Create adds a theme to the catalog
+func (r *ThemeRepositoryImpl) Create(ctx context.Context, theme *models.Theme) error {
+	if _, err := r.collection.InsertOne(ctx, theme); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrThemeExists
+		}
+		return fmt.Errorf("failed to create theme: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns a theme, or nil if there is none with that ID
+func (r *ThemeRepositoryImpl) GetByID(ctx context.Context, themeID string) (*models.Theme, error) {
+	var theme models.Theme
+	err := r.collection.FindOne(ctx, bson.M{"themeId": themeID}).Decode(&theme)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get theme: %w", err)
+	}
+	return &theme, nil
+}
+
+// List returns the catalog ordered by name, optionally only the enabled themes
+func (r *ThemeRepositoryImpl) List(ctx context.Context, enabledOnly bool) ([]*models.Theme, error) {
+	filter := bson.M{}
+	if enabledOnly {
+		filter["enabled"] = true
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list themes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	themes := []*models.Theme{}
+	if err := cursor.All(ctx, &themes); err != nil {
+		return nil, fmt.Errorf("failed to decode themes: %w", err)
+	}
+	return themes, nil
+}
+
+// Update replaces a stored theme
+func (r *ThemeRepositoryImpl) Update(ctx context.Context, theme *models.Theme) error {
+	if _, err := r.collection.ReplaceOne(ctx, bson.M{"themeId": theme.ThemeID}, theme); err != nil {
+		return fmt.Errorf("failed to update theme: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a theme from the catalog
+func (r *ThemeRepositoryImpl) Delete(ctx context.Context, themeID string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"themeId": themeID}); err != nil {
+		return fmt.Errorf("failed to delete theme: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"dumdoors-backend/internal/database"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Tournament repository errors
+var (
+	ErrTournamentConflict = errors.New("tournament was modified concurrently")
+	ErrTournamentClosed   = errors.New("tournament is full, already started, or already joined")
+)
+
+// TournamentRepository interface defines operations for tournaments
+type TournamentRepository interface {
+	Create(ctx context.Context, tournament *models.Tournament) error
+	GetByID(ctx context.Context, tournamentID string) (*models.Tournament, error)
+	AddPlayer(ctx context.Context, tournamentID string, player models.TournamentPlayer) error
+	Update(ctx context.Context, tournament *models.Tournament) error
+	GetByStatus(ctx context.Context, status models.TournamentStatus) ([]*models.Tournament, error)
+}
+
+// TournamentRepositoryImpl implements the TournamentRepository interface
+type TournamentRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+// NewTournamentRepository creates a new tournament repository
+func NewTournamentRepository(mongodb *database.MongoClient) TournamentRepository {
+	return &TournamentRepositoryImpl{
+		collection: mongodb.GetCollection("tournaments"),
+	}
+}
+
+// Create stores a new tournament
+func (r *TournamentRepositoryImpl) Create(ctx context.Context, tournament *models.Tournament) error {
+	tournament.CreatedAt = time.Now()
+	tournament.Version = 1
+
+	result, err := r.collection.InsertOne(ctx, tournament)
+	if err != nil {
+		return fmt.Errorf("failed to create tournament: %w", err)
+	}
+
+	tournament.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves a tournament by ID, returning nil when it does not exist
+func (r *TournamentRepositoryImpl) GetByID(ctx context.Context, tournamentID string) (*models.Tournament, error) {
+	var tournament models.Tournament
+	err := r.collection.FindOne(ctx, bson.M{"tournamentId": tournamentID}).Decode(&tournament)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	return &tournament, nil
+}
+
+// AddPlayer registers a player atomically, so concurrent joins can never overfill the bracket
+func (r *TournamentRepositoryImpl) AddPlayer(ctx context.Context, tournamentID string, player models.TournamentPlayer) error {
+	filter := bson.M{
+		"tournamentId":     tournamentID,
+		"status":           models.TournamentStatusRegistering,
+		"players.playerId": bson.M{"$ne": player.PlayerID},
+		"$expr":            bson.M{"$lt": bson.A{bson.M{"$size": "$players"}, "$size"}},
+	}
+	update := bson.M{
+		"$push": bson.M{"players": player},
+		"$inc":  bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to add player to tournament: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrTournamentClosed
+	}
+	return nil
+}
+
+// Update saves a tournament if nobody else changed it since it was read
+func (r *TournamentRepositoryImpl) Update(ctx context.Context, tournament *models.Tournament) error {
+	filter := bson.M{"tournamentId": tournament.TournamentID, "version": tournament.Version}
+	tournament.Version++
+
+	result, err := r.collection.ReplaceOne(ctx, filter, tournament)
+	if err != nil {
+		tournament.Version--
+		return fmt.Errorf("failed to update tournament: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		tournament.Version--
+		return ErrTournamentConflict
+	}
+	return nil
+}
+
+// GetByStatus retrieves all tournaments in the given status
+func (r *TournamentRepositoryImpl) GetByStatus(ctx context.Context, status models.TournamentStatus) ([]*models.Tournament, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"status": status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tournaments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tournaments []*models.Tournament
+	if err := cursor.All(ctx, &tournaments); err != nil {
+		return nil, fmt.Errorf("failed to decode tournaments: %w", err)
+	}
+	return tournaments, nil
+}
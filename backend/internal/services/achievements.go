@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"time"
+)
+
+// Achievement thresholds
+const (
+	PerfectRunLength   = 5               // doors in a row a perfect run needs
+	PerfectRunMinScore = 80              // lowest score that keeps a perfect run going
+	HighScoreThreshold = 95              // a single response must score above this
+	SpeedWinDuration   = 3 * time.Minute // wins faster than this count as speed wins
+	VeteranGamesPlayed = 10              // completed games for the veteran badge
+)
+
+// AchievementTrigger is the point in a game where achievement rules are checked
+type AchievementTrigger string
+
+const (
+	TriggerResponseScored AchievementTrigger = "response-scored"
+	TriggerGameCompleted  AchievementTrigger = "game-completed"
+)
+
+// AchievementEvent is what achievement rules inspect. Response is set for scored responses and
+// Profile, when profiles are enabled, for completed games.
+type AchievementEvent struct {
+	Trigger  AchievementTrigger
+	Session  *models.GameSession
+	Player   *models.PlayerInfo
+	Response *models.PlayerResponse
+	Profile  *models.PlayerProfile
+}
+
+// AchievementRule unlocks an achievement when its condition holds for an event with its trigger
+type AchievementRule struct {
+	Achievement models.Achievement
+	Trigger     AchievementTrigger
+	Unlocked    func(event AchievementEvent) bool
+}
+
+// DefaultAchievementRules are the achievements every player can unlock
+var DefaultAchievementRules = []AchievementRule{
+	{
+		Achievement: models.Achievement{ID: models.AchievementFirstWin, Name: "First Win", Description: "Win a game"},
+		Trigger:     TriggerGameCompleted,
+		Unlocked: func(event AchievementEvent) bool {
+			return event.Session.WinnerID == event.Player.PlayerID
+		},
+	},
+	{
+		Achievement: models.Achievement{ID: models.AchievementPerfectRun, Name: "Perfect Run", Description: fmt.Sprintf("Score %d or more on %d doors in a row", PerfectRunMinScore, PerfectRunLength)},
+		Trigger:     TriggerResponseScored,
+		Unlocked:    isPerfectRun,
+	},
+	{
+		Achievement: models.Achievement{ID: models.AchievementHighScore, Name: "Genius", Description: fmt.Sprintf("Score over %d on a single door", HighScoreThreshold)},
+		Trigger:     TriggerResponseScored,
+		Unlocked: func(event AchievementEvent) bool {
+			return !event.Response.ScoringPending && event.Response.AIScore > HighScoreThreshold
+		},
+	},
+	{
+		Achievement: models.Achievement{ID: models.AchievementSpeedWin, Name: "Speedrunner", Description: fmt.Sprintf("Win a game in under %d minutes", int(SpeedWinDuration.Minutes()))},
+		Trigger:     TriggerGameCompleted,
+		Unlocked: func(event AchievementEvent) bool {
+			session := event.Session
+			if session.WinnerID != event.Player.PlayerID || session.StartedAt == nil || session.CompletedAt == nil {
+				return false
+			}
+			return session.CompletedAt.Sub(*session.StartedAt) < SpeedWinDuration
+		},
+	},
+	{
+		Achievement: models.Achievement{ID: models.AchievementVeteran, Name: "Veteran", Description: fmt.Sprintf("Play %d games", VeteranGamesPlayed)},
+		Trigger:     TriggerGameCompleted,
+		Unlocked: func(event AchievementEvent) bool {
+			return event.Profile != nil && event.Profile.GamesPlayed >= VeteranGamesPlayed
+		},
+	},
+}
+
+// isPerfectRun reports whether the player's latest responses in the session all scored well
+func isPerfectRun(event AchievementEvent) bool {
+	responses := event.Player.Responses
+	if len(responses) < PerfectRunLength {
+		return false
+	}
+	for _, response := range responses[len(responses)-PerfectRunLength:] {
+		if response.ScoringPending || response.AIScore < PerfectRunMinScore {
+			return false
+		}
+	}
+	return true
+}
+
+// AchievementService awards achievements as players answer doors and finish games
+type AchievementService interface {
+	Evaluate(ctx context.Context, event AchievementEvent) ([]models.PlayerAchievement, error)
+	ListAchievements(ctx context.Context, playerID string) ([]models.PlayerAchievement, error)
+}
+
+// AchievementServiceImpl implements the AchievementService interface
+type AchievementServiceImpl struct {
+	achievementRepo repositories.AchievementRepository
+	wsManager       WebSocketManager
+	rules           []AchievementRule
+}
+
+// AchievementServiceOption configures optional behaviour of the achievement service
+type AchievementServiceOption func(*AchievementServiceImpl)
+
+// WithAchievementRules replaces the default achievement rules
+func WithAchievementRules(rules ...AchievementRule) AchievementServiceOption {
+	return func(s *AchievementServiceImpl) {
+		s.rules = rules
+	}
+}
+
+// NewAchievementService creates a new achievement service
+func NewAchievementService(achievementRepo repositories.AchievementRepository, wsManager WebSocketManager, opts ...AchievementServiceOption) AchievementService {
+	service := &AchievementServiceImpl{
+		achievementRepo: achievementRepo,
+		wsManager:       wsManager,
+		rules:           DefaultAchievementRules,
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
+
+// Evaluate runs the rules for the event's trigger, records any achievements the player has newly
+// unlocked and announces them to the session
+func (s *AchievementServiceImpl) Evaluate(ctx context.Context, event AchievementEvent) ([]models.PlayerAchievement, error) {
+	if event.Session == nil || event.Player == nil {
+		return nil, fmt.Errorf("achievement event needs a session and a player")
+	}
+	if event.Trigger == TriggerResponseScored && event.Response == nil {
+		return nil, fmt.Errorf("response-scored achievement event needs a response")
+	}
+
+	owned, err := s.achievementRepo.ListByPlayer(ctx, event.Player.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	unlocked := make(map[models.AchievementID]bool, len(owned))
+	for _, achievement := range owned {
+		unlocked[achievement.ID] = true
+	}
+
+	awarded := []models.PlayerAchievement{}
+	for _, rule := range s.rules {
+		if rule.Trigger != event.Trigger || unlocked[rule.Achievement.ID] || !rule.Unlocked(event) {
+			continue
+		}
+
+		achievement := models.PlayerAchievement{
+			PlayerID:    event.Player.PlayerID,
+			Achievement: rule.Achievement,
+			SessionID:   event.Session.SessionID,
+			UnlockedAt:  time.Now(),
+		}
+		isNew, err := s.achievementRepo.Award(ctx, &achievement)
+		if err != nil {
+			return awarded, err
+		}
+		if !isNew {
+			continue
+		}
+		awarded = append(awarded, achievement)
+		s.announce(event, achievement)
+	}
+	return awarded, nil
+}
+
+// ListAchievements returns the achievements a player has unlocked
+func (s *AchievementServiceImpl) ListAchievements(ctx context.Context, playerID string) ([]models.PlayerAchievement, error) {
+	return s.achievementRepo.ListByPlayer(ctx, playerID)
+}
+
+// announce tells everyone in the session that the player unlocked an achievement
+func (s *AchievementServiceImpl) announce(event AchievementEvent, achievement models.PlayerAchievement) {
+	if s.wsManager == nil {
+		return
+	}
+
+	message := WebSocketEvent{
+		Type:      "achievement-unlocked",
+		SessionID: event.Session.SessionID,
+		PlayerID:  event.Player.PlayerID,
+		Data: map[string]interface{}{
+			"playerId":    event.Player.PlayerID,
+			"username":    event.Player.Username,
+			"achievement": achievement,
+			"message":     fmt.Sprintf("%s unlocked %s", event.Player.Username, achievement.Name),
+		},
+		Timestamp: time.Now(),
+	}
+	if err := s.wsManager.BroadcastToSession(event.Session.SessionID, message); err != nil {
+		fmt.Printf("Warning: failed to broadcast achievement: %v\n", err)
+	}
+}
+
+// checkResponseAchievements evaluates achievements for a player's newly scored response
+func (s *GameServiceImpl) checkResponseAchievements(ctx context.Context, session *models.GameSession, player models.PlayerInfo, response models.PlayerResponse) {
+	if s.achievements == nil || player.IsBot {
+		return
+	}
+
+	s.runInBackground(ctx, session.SessionID, "check-achievements", func(ctx context.Context) {
+		event := AchievementEvent{Trigger: TriggerResponseScored, Session: session, Player: &player, Response: &response}
+		if _, err := s.achievements.Evaluate(ctx, event); err != nil {
+			fmt.Printf("Warning: failed to check achievements for player %s: %v\n", player.PlayerID, err)
+		}
+	})
+}
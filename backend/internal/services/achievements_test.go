@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// MockAchievementRepository keeps awarded achievements in memory
+type MockAchievementRepository struct {
+	achievements []models.PlayerAchievement
+}
+
+func (m *MockAchievementRepository) Award(ctx context.Context, achievement *models.PlayerAchievement) (bool, error) {
+	for _, existing := range m.achievements {
+		if existing.PlayerID == achievement.PlayerID && existing.ID == achievement.ID {
+			return false, nil
+		}
+	}
+	m.achievements = append(m.achievements, *achievement)
+	return true, nil
+}
+
+func (m *MockAchievementRepository) ListByPlayer(ctx context.Context, playerID string) ([]models.PlayerAchievement, error) {
+	achievements := []models.PlayerAchievement{}
+	for _, achievement := range m.achievements {
+		if achievement.PlayerID == playerID {
+			achievements = append(achievements, achievement)
+		}
+	}
+	return achievements, nil
+}
+
+// broadcastRecordingWebSocketManager records events broadcast to sessions
+type broadcastRecordingWebSocketManager struct {
+	*MockWebSocketManager
+	broadcasts []WebSocketEvent
+}
+
+func (m *broadcastRecordingWebSocketManager) BroadcastToSession(sessionID string, event WebSocketEvent) error {
+	m.broadcasts = append(m.broadcasts, event)
+	return nil
+}
+
+// achievementIDs lists the IDs of the achievements in order
+func achievementIDs(achievements []models.PlayerAchievement) []models.AchievementID {
+	ids := make([]models.AchievementID, 0, len(achievements))
+	for _, achievement := range achievements {
+		ids = append(ids, achievement.ID)
+	}
+	return ids
+}
+
+func TestAchievementService_ResponseRulesUnlockOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockAchievementRepository{}
+	wsManager := &broadcastRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	achievements := NewAchievementService(repo, wsManager)
+
+	session := newCompletedSession("s1", "space", "", time.Now(), 85, 90, 82, 88, 97)
+	player := &session.Players[0]
+	event := AchievementEvent{Trigger: TriggerResponseScored, Session: session, Player: player, Response: &player.Responses[4]}
+
+	awarded, err := achievements.Evaluate(ctx, event)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	ids := achievementIDs(awarded)
+	if len(ids) != 2 || ids[0] != models.AchievementPerfectRun || ids[1] != models.AchievementHighScore {
+		t.Errorf("Expected a perfect run and a high score, got %v", ids)
+	}
+	if len(wsManager.broadcasts) != 2 || wsManager.broadcasts[0].Type != "achievement-unlocked" || wsManager.broadcasts[0].PlayerID != "p1" {
+		t.Errorf("Expected an achievement-unlocked broadcast per award, got %+v", wsManager.broadcasts)
+	}
+
+	// Achievements already unlocked are not awarded or announced again
+	if awarded, err = achievements.Evaluate(ctx, event); err != nil || len(awarded) != 0 {
+		t.Errorf("Expected nothing new on a second evaluation, got %v, %v", achievementIDs(awarded), err)
+	}
+	if len(wsManager.broadcasts) != 2 {
+		t.Errorf("Expected no further broadcasts, got %d", len(wsManager.broadcasts))
+	}
+}
+
+func TestAchievementService_PerfectRunNeedsConsecutiveHighScores(t *testing.T) {
+	session := newCompletedSession("s1", "space", "", time.Now(), 90, 90, 79, 90, 90, 90)
+	player := &session.Players[0]
+	if isPerfectRun(AchievementEvent{Session: session, Player: player}) {
+		t.Error("Expected a score under the minimum within the last five doors to break the run")
+	}
+
+	player.Responses = append(player.Responses, models.PlayerResponse{AIScore: 90, ScoringPending: true})
+	if isPerfectRun(AchievementEvent{Session: session, Player: player}) {
+		t.Error("Expected a response still being scored not to count towards a run")
+	}
+}
+
+func TestAchievementService_CompletionRules(t *testing.T) {
+	ctx := context.Background()
+	achievements := NewAchievementService(&MockAchievementRepository{}, nil)
+
+	completedAt := time.Now()
+	startedAt := completedAt.Add(-2 * time.Minute)
+	session := newCompletedSession("s1", "space", "p1", completedAt, 60)
+	session.StartedAt = &startedAt
+	session.Players = append(session.Players, models.PlayerInfo{PlayerID: "p2", Username: "Player 2"})
+
+	profile := &models.PlayerProfile{PlayerID: "p1", GamesPlayed: VeteranGamesPlayed}
+	awarded, err := achievements.Evaluate(ctx, AchievementEvent{Trigger: TriggerGameCompleted, Session: session, Player: &session.Players[0], Profile: profile})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	ids := achievementIDs(awarded)
+	if len(ids) != 3 || ids[0] != models.AchievementFirstWin || ids[1] != models.AchievementSpeedWin || ids[2] != models.AchievementVeteran {
+		t.Errorf("Expected first win, speed win and veteran, got %v", ids)
+	}
+
+	// Losing players earn nothing, and players without a profile can't be veterans yet
+	awarded, err = achievements.Evaluate(ctx, AchievementEvent{Trigger: TriggerGameCompleted, Session: session, Player: &session.Players[1]})
+	if err != nil || len(awarded) != 0 {
+		t.Errorf("Expected no achievements for the loser, got %v, %v", achievementIDs(awarded), err)
+	}
+}
+
+func TestHandleGameCompletion_AwardsAchievements(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Players[0].Responses = []models.PlayerResponse{{DoorID: "door-1", AIScore: 70}}
+	gameSessionRepo.sessions["s1"] = session
+	achievementRepo := &MockAchievementRepository{}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithProfileService(NewProfileService(NewMockPlayerProfileRepository())),
+		WithAchievementService(NewAchievementService(achievementRepo, nil)),
+	)
+
+	if err := gameService.(*GameServiceImpl).handleGameCompletion(ctx, "s1", "p1"); err != nil {
+		t.Fatalf("handleGameCompletion failed: %v", err)
+	}
+
+	unlocked, _ := achievementRepo.ListByPlayer(ctx, "p1")
+	if ids := achievementIDs(unlocked); len(ids) != 1 || ids[0] != models.AchievementFirstWin {
+		t.Errorf("Expected the winner to unlock their first win, got %v", ids)
+	}
+	if unlocked, _ := achievementRepo.ListByPlayer(ctx, "p2"); len(unlocked) != 0 {
+		t.Errorf("Expected players without responses to be skipped, got %v", achievementIDs(unlocked))
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/random"
+	"testing"
+)
+
+func TestAIClient_SeededMockProviderIsReproducible(t *testing.T) {
+	defer random.Reset()
+
+	// An unreachable URL would be used if the mock provider were not frozen in
+	client := NewAIClient("http://127.0.0.1:0", nil, WithMockProvider())
+	ctx := context.Background()
+
+	generate := func() (string, int) {
+		random.Seed(42)
+		door, err := client.GenerateDoor(ctx, "workplace", 2, "en")
+		if err != nil {
+			t.Fatalf("GenerateDoor failed: %v", err)
+		}
+		result, err := client.ScoreResponse(ctx, door, "I would build a tiny boat and sail away, funny but practical")
+		if err != nil {
+			t.Fatalf("ScoreResponse failed: %v", err)
+		}
+		return door.DoorID, result.Metrics.Creativity + result.Metrics.Feasibility + result.Metrics.Humor + result.Metrics.Originality
+	}
+
+	firstID, firstScore := generate()
+	secondID, secondScore := generate()
+
+	if firstID != secondID {
+		t.Errorf("Expected the same door ID for the same seed, got %s and %s", firstID, secondID)
+	}
+	if firstScore != secondScore {
+		t.Errorf("Expected the same mock score for the same seed, got %d and %d", firstScore, secondScore)
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/aipb"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// DefaultAIGRPCPoolSize is how many connections calls to the AI service are spread across
+	DefaultAIGRPCPoolSize = 4
+	// DefaultAIGRPCDeadline bounds each gRPC call to the AI service
+	DefaultAIGRPCDeadline = 5 * time.Second
+)
+
+// AIGRPCTransport calls the AI service's gRPC API over a pool of connections. Calls that fail
+// trip a circuit breaker, so while the service is unreachable the client goes straight to HTTP
+// instead of waiting out a deadline on every call.
+type AIGRPCTransport struct {
+	conns   []*grpc.ClientConn
+	clients []aipb.AIServiceClient
+	next    atomic.Uint32
+	breaker *middleware.CircuitBreaker
+}
+
+// aiGRPCConfig holds the settings AIGRPCOptions adjust
+type aiGRPCConfig struct {
+	poolSize    int
+	deadline    time.Duration
+	dialOptions []grpc.DialOption
+}
+
+// AIGRPCOption configures an AIGRPCTransport
+type AIGRPCOption func(*aiGRPCConfig)
+
+// WithAIGRPCPoolSize sets how many connections to open to the AI service
+func WithAIGRPCPoolSize(size int) AIGRPCOption {
+	return func(c *aiGRPCConfig) {
+		if size > 0 {
+			c.poolSize = size
+		}
+	}
+}
+
+// WithAIGRPCDeadline sets the deadline for each call to the AI service
+func WithAIGRPCDeadline(deadline time.Duration) AIGRPCOption {
+	return func(c *aiGRPCConfig) {
+		if deadline > 0 {
+			c.deadline = deadline
+		}
+	}
+}
+
+// WithAIGRPCDialOptions adds options used when connecting, such as credentials or a custom dialer
+func WithAIGRPCDialOptions(opts ...grpc.DialOption) AIGRPCOption {
+	return func(c *aiGRPCConfig) {
+		c.dialOptions = append(c.dialOptions, opts...)
+	}
+}
+
+// NewAIGRPCTransport prepares connections to the AI service's gRPC API at target. Connections
+// are made lazily, so an AI service that is down does not stop the backend from starting.
+func NewAIGRPCTransport(target string, opts ...AIGRPCOption) (*AIGRPCTransport, error) {
+	config := &aiGRPCConfig{
+		poolSize:    DefaultAIGRPCPoolSize,
+		deadline:    DefaultAIGRPCDeadline,
+		dialOptions: []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	transport := &AIGRPCTransport{
+		breaker: middleware.NewCircuitBreaker("ai_grpc", middleware.CircuitBreakerConfig{
+			MaxFailures:      3,
+			ResetTimeout:     30 * time.Second,
+			SuccessThreshold: 1,
+			Timeout:          config.deadline,
+		}),
+	}
+	for i := 0; i < config.poolSize; i++ {
+		conn, err := grpc.NewClient(target, config.dialOptions...)
+		if err != nil {
+			transport.Close()
+			return nil, fmt.Errorf("failed to create AI service gRPC client: %w", err)
+		}
+		transport.conns = append(transport.conns, conn)
+		transport.clients = append(transport.clients, aipb.NewAIServiceClient(conn))
+	}
+	return transport, nil
+}
+
+// Close closes the pooled connections
+func (t *AIGRPCTransport) Close() error {
+	var errs []error
+	for _, conn := range t.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// call runs fn on the next pooled connection, under the call deadline and circuit breaker
+func (t *AIGRPCTransport) call(ctx context.Context, fn func(ctx context.Context, client aipb.AIServiceClient) error) error {
+	client := t.clients[int(t.next.Add(1)-1)%len(t.clients)]
+	return t.breaker.Execute(ctx, func(ctx context.Context) error {
+		return fn(ctx, client)
+	})
+}
+
+// WithGRPCTransport sends door generation and scoring to the AI service over gRPC, falling back
+// to HTTP whenever a gRPC call fails
+func WithGRPCTransport(transport *AIGRPCTransport) AIClientOption {
+	return func(c *AIClientImpl) {
+		c.grpc = transport
+	}
+}
+
+// generateDoorGRPC generates a door over gRPC. It reports false if gRPC isn't configured or the
+// call failed, leaving the caller to fall back to HTTP.
+func (c *AIClientImpl) generateDoorGRPC(ctx context.Context, theme, difficulty, locale string) (*models.Door, bool) {
+	if c.grpc == nil || c.mockOnly {
+		return nil, false
+	}
+
+	req := &aipb.GenerateDoorRequest{Theme: theme, Difficulty: difficulty}
+	if !i18n.IsDefault(locale) {
+		req.Locale = locale
+		req.Language = i18n.LanguageName(locale)
+	}
+
+	var generated *aipb.Door
+	err := c.grpc.call(ctx, func(ctx context.Context, client aipb.AIServiceClient) error {
+		var err error
+		generated, err = client.GenerateDoor(ctx, req)
+		return err
+	})
+	if err != nil {
+		grpcFallback("GenerateDoor", err)
+		return nil, false
+	}
+
+	return &models.Door{
+		DoorID:                generated.GetDoorId(),
+		Content:               generated.GetContent(),
+		Theme:                 generated.GetTheme(),
+		Difficulty:            doorDifficulty(generated.GetDifficulty()),
+		Locale:                locale,
+		ExpectedSolutionTypes: generated.GetExpectedSolutionTypes(),
+		CreatedAt:             time.UnixMilli(generated.GetCreatedAtUnixMs()),
+	}, true
+}
+
+// scoreResponseGRPC scores a response over gRPC, reporting false if the caller should fall back
+// to HTTP
+func (c *AIClientImpl) scoreResponseGRPC(ctx context.Context, door *models.Door, response string) (*ScoreResult, bool) {
+	if c.grpc == nil || c.mockOnly {
+		return nil, false
+	}
+
+	req := &aipb.ScoreResponseRequest{
+		ResponseId:  random.ID(),
+		DoorContent: door.Content,
+		Response:    response,
+	}
+
+	var scored *aipb.ScoreResult
+	err := c.grpc.call(ctx, func(ctx context.Context, client aipb.AIServiceClient) error {
+		var err error
+		scored, err = client.ScoreResponse(ctx, req)
+		return err
+	})
+	if err != nil {
+		grpcFallback("ScoreResponse", err)
+		return nil, false
+	}
+
+	result := scoringResultFromProto(scored)
+	return result.scoreResult(), true
+}
+
+// batchScoreGRPC scores a batch over gRPC, reporting false if the caller should fall back to
+// HTTP. Responses are identified by ids, which line up with requests.
+func (c *AIClientImpl) batchScoreGRPC(ctx context.Context, ids []string, requests []ScoreRequest) ([]aiScoringResult, bool) {
+	if c.grpc == nil || c.mockOnly {
+		return nil, false
+	}
+
+	req := &aipb.BatchScoreRequest{Requests: make([]*aipb.ScoreResponseRequest, len(requests))}
+	for i, request := range requests {
+		req.Requests[i] = &aipb.ScoreResponseRequest{
+			ResponseId:  ids[i],
+			DoorContent: request.Door.Content,
+			Response:    request.Response,
+		}
+	}
+
+	var scored *aipb.BatchScoreResponse
+	err := c.grpc.call(ctx, func(ctx context.Context, client aipb.AIServiceClient) error {
+		var err error
+		scored, err = client.BatchScore(ctx, req)
+		return err
+	})
+	if err != nil {
+		grpcFallback("BatchScore", err)
+		return nil, false
+	}
+
+	results := make([]aiScoringResult, len(scored.GetResults()))
+	for i, result := range scored.GetResults() {
+		results[i] = scoringResultFromProto(result)
+	}
+	return results, true
+}
+
+// scoringResultFromProto converts a gRPC score to the shape the HTTP API returns
+func scoringResultFromProto(scored *aipb.ScoreResult) aiScoringResult {
+	result := aiScoringResult{
+		ResponseID:         scored.GetResponseId(),
+		TotalScore:         scored.GetTotalScore(),
+		Feedback:           scored.GetFeedback(),
+		PathRecommendation: scored.GetPathRecommendation(),
+		ProcessingTimeMs:   scored.GetProcessingTimeMs(),
+	}
+	result.Metrics.Creativity = scored.GetMetrics().GetCreativity()
+	result.Metrics.Feasibility = scored.GetMetrics().GetFeasibility()
+	result.Metrics.Humor = scored.GetMetrics().GetHumor()
+	result.Metrics.Originality = scored.GetMetrics().GetOriginality()
+	return result
+}
+
+// grpcFallback notes a failed gRPC call. Calls refused by the open circuit breaker aren't logged
+// again; the failures that opened it were.
+func grpcFallback(method string, err error) {
+	var open *middleware.CircuitBreakerError
+	if errors.As(err, &open) {
+		return
+	}
+	fmt.Printf("Warning: AI service gRPC %s failed, falling back to HTTP: %v\n", method, err)
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/aipb"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeAIServer answers gRPC calls with fixed doors and scores, or fails them all
+type fakeAIServer struct {
+	aipb.UnimplementedAIServiceServer
+	fail  bool
+	calls atomic.Int32
+}
+
+func (s *fakeAIServer) GenerateDoor(ctx context.Context, req *aipb.GenerateDoorRequest) (*aipb.Door, error) {
+	s.calls.Add(1)
+	if s.fail {
+		return nil, status.Error(codes.Unavailable, "model is overloaded")
+	}
+	return &aipb.Door{DoorId: "grpc-door", Content: "A door made of " + req.GetLanguage(), Theme: req.GetTheme(), Difficulty: "hard"}, nil
+}
+
+func (s *fakeAIServer) BatchScore(ctx context.Context, req *aipb.BatchScoreRequest) (*aipb.BatchScoreResponse, error) {
+	s.calls.Add(1)
+	if s.fail {
+		return nil, status.Error(codes.Unavailable, "model is overloaded")
+	}
+	// The second response is left unscored
+	first := req.GetRequests()[0]
+	return &aipb.BatchScoreResponse{Results: []*aipb.ScoreResult{{
+		ResponseId: first.GetResponseId(),
+		Metrics:    &aipb.ScoringMetrics{Creativity: 90.4, Feasibility: 80, Humor: 70.5, Originality: 60},
+		Feedback:   "Scored over gRPC",
+	}}}, nil
+}
+
+// newTestAIGRPCTransport serves server over an in-memory listener
+func newTestAIGRPCTransport(t *testing.T, server aipb.AIServiceServer) *AIGRPCTransport {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	aipb.RegisterAIServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	transport, err := NewAIGRPCTransport("passthrough:///bufnet",
+		WithAIGRPCPoolSize(2),
+		WithAIGRPCDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})),
+	)
+	if err != nil {
+		t.Fatalf("NewAIGRPCTransport failed: %v", err)
+	}
+	t.Cleanup(func() { transport.Close() })
+	return transport
+}
+
+func TestAIClient_PrefersGRPC(t *testing.T) {
+	server := &fakeAIServer{}
+	client := NewAIClient("http://127.0.0.1:0", nil, WithGRPCTransport(newTestAIGRPCTransport(t, server)))
+	ctx := context.Background()
+
+	door, err := client.GenerateDoor(ctx, "workplace", 3, "es")
+	if err != nil {
+		t.Fatalf("GenerateDoor failed: %v", err)
+	}
+	if door.DoorID != "grpc-door" || door.Content != "A door made of Spanish" || door.Difficulty != 3 || door.Locale != "es" {
+		t.Errorf("Expected the door generated over gRPC, got %+v", door)
+	}
+
+	results, err := client.ScoreResponses(ctx, []ScoreRequest{
+		{Door: door, Response: "Ask nicely"},
+		{Door: door, Response: "Walk away"},
+	})
+	if err != nil {
+		t.Fatalf("ScoreResponses failed: %v", err)
+	}
+	if results[0].Feedback != "Scored over gRPC" || results[0].Metrics.Creativity != 90 || results[0].Metrics.Humor != 71 {
+		t.Errorf("Expected the first response scored over gRPC, got %+v", results[0])
+	}
+	if results[1] == nil || results[1].Feedback == "Scored over gRPC" {
+		t.Errorf("Expected the unscored response to fall back to mock scoring, got %+v", results[1])
+	}
+}
+
+func TestAIClient_FallsBackToHTTPWhenGRPCFails(t *testing.T) {
+	var httpCalls atomic.Int32
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpCalls.Add(1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"door_id":    "http-door",
+			"content":    "A door served over HTTP",
+			"theme":      "workplace",
+			"difficulty": "easy",
+		})
+	}))
+	defer httpServer.Close()
+
+	server := &fakeAIServer{fail: true}
+	client := NewAIClient(httpServer.URL, nil, WithGRPCTransport(newTestAIGRPCTransport(t, server)))
+
+	for i := 0; i < 5; i++ {
+		door, err := client.GenerateDoor(context.Background(), "workplace", 1, "en")
+		if err != nil {
+			t.Fatalf("GenerateDoor failed: %v", err)
+		}
+		if door.DoorID != "http-door" {
+			t.Fatalf("Expected the HTTP fallback's door, got %+v", door)
+		}
+	}
+
+	if httpCalls.Load() != 5 {
+		t.Errorf("Expected every call to reach the HTTP API, got %d", httpCalls.Load())
+	}
+	if server.calls.Load() != 3 {
+		t.Errorf("Expected the circuit breaker to stop gRPC calls after 3 failures, got %d", server.calls.Load())
+	}
+}
+
+func TestAIClient_MockProviderSkipsGRPC(t *testing.T) {
+	server := &fakeAIServer{}
+	client := NewAIClient("http://127.0.0.1:0", nil, WithMockProvider(), WithGRPCTransport(newTestAIGRPCTransport(t, server)))
+
+	if _, err := client.ScoreResponse(context.Background(), &models.Door{Content: "A door"}, "Open it"); err != nil {
+		t.Fatalf("ScoreResponse failed: %v", err)
+	}
+	if server.calls.Load() != 0 {
+		t.Errorf("Expected the mock provider to keep calls off the AI service, got %d", server.calls.Load())
+	}
+}
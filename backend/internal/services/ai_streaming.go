@@ -0,0 +1,190 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"dumdoors-backend/internal/aipb"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ScoreProgress is one metric of a response's score, delivered before the rest are ready
+type ScoreProgress struct {
+	Metric string
+	Score  int
+}
+
+// StreamingScorer is implemented by AI clients that can report each metric of a response's
+// score as soon as the AI service has scored it
+type StreamingScorer interface {
+	StreamScoreResponse(ctx context.Context, door *models.Door, response string, onProgress func(ScoreProgress)) (*ScoreResult, error)
+}
+
+// scoringMetricNames are the metrics the AI service may stream
+var scoringMetricNames = map[string]bool{
+	"creativity":  true,
+	"feasibility": true,
+	"humor":       true,
+	"originality": true,
+}
+
+// StreamScoreResponse scores a response, calling onProgress with each metric as the AI service
+// streams it. The stream is read over gRPC when configured and server-sent events otherwise; if
+// streaming fails the response is scored in one call instead, without progress.
+func (c *AIClientImpl) StreamScoreResponse(ctx context.Context, door *models.Door, response string, onProgress func(ScoreProgress)) (*ScoreResult, error) {
+	if result, ok := c.streamScoreGRPC(ctx, door, response, onProgress); ok {
+		return result, nil
+	}
+
+	result, err := c.streamScoreSSE(ctx, door, response, onProgress)
+	if err == nil {
+		return result, nil
+	}
+	if !errors.Is(err, errMockProvider) {
+		fmt.Printf("Warning: streamed scoring failed, scoring in one call: %v\n", err)
+	}
+	return c.ScoreResponse(ctx, door, response)
+}
+
+// streamScoreGRPC reads a score stream over gRPC, reporting false if the caller should fall back
+func (c *AIClientImpl) streamScoreGRPC(ctx context.Context, door *models.Door, response string, onProgress func(ScoreProgress)) (*ScoreResult, bool) {
+	if c.grpc == nil || c.mockOnly {
+		return nil, false
+	}
+
+	req := &aipb.ScoreResponseRequest{
+		ResponseId:  random.ID(),
+		DoorContent: door.Content,
+		Response:    response,
+	}
+
+	var result *ScoreResult
+	err := c.grpc.call(ctx, func(ctx context.Context, client aipb.AIServiceClient) error {
+		stream, err := client.StreamScore(ctx, req)
+		if err != nil {
+			return err
+		}
+		for {
+			update, err := stream.Recv()
+			if err == io.EOF {
+				return fmt.Errorf("score stream ended without a result")
+			}
+			if err != nil {
+				return err
+			}
+			if update.GetResult() != nil {
+				scored := scoringResultFromProto(update.GetResult())
+				result = scored.scoreResult()
+				return nil
+			}
+			reportProgress(onProgress, update.GetMetric(), update.GetScore())
+		}
+	})
+	if err != nil {
+		grpcFallback("StreamScore", err)
+		return nil, false
+	}
+	return result, true
+}
+
+// streamScoreSSE reads a score stream from the AI service's server-sent events endpoint. Each
+// scored metric arrives as a "metric" event and the complete score as a final "result" event.
+func (c *AIClientImpl) streamScoreSSE(ctx context.Context, door *models.Door, response string, onProgress func(ScoreProgress)) (*ScoreResult, error) {
+	if c.mockOnly {
+		return nil, errMockProvider
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"response_id":  random.ID(),
+		"door_content": door.Content,
+		"response":     response,
+		"context":      nil,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/scoring/score-response/stream", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("score stream returned status %d", resp.StatusCode)
+	}
+
+	var event string
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// A blank line ends the event
+			result, err := handleScoreEvent(event, data.String(), onProgress)
+			if result != nil || err != nil {
+				return result, err
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read score stream: %w", err)
+	}
+	return nil, fmt.Errorf("score stream ended without a result")
+}
+
+// handleScoreEvent applies one server-sent event, returning the score once the result arrives
+func handleScoreEvent(event, data string, onProgress func(ScoreProgress)) (*ScoreResult, error) {
+	switch event {
+	case "metric":
+		var metric struct {
+			Metric string  `json:"metric"`
+			Score  float64 `json:"score"`
+		}
+		if err := json.Unmarshal([]byte(data), &metric); err != nil {
+			return nil, fmt.Errorf("invalid metric event: %w", err)
+		}
+		reportProgress(onProgress, metric.Metric, metric.Score)
+	case "result":
+		var scored aiScoringResult
+		if err := json.Unmarshal([]byte(data), &scored); err != nil {
+			return nil, fmt.Errorf("invalid result event: %w", err)
+		}
+		return scored.scoreResult(), nil
+	case "error":
+		return nil, fmt.Errorf("AI service failed to score the response: %s", data)
+	}
+	return nil, nil
+}
+
+// reportProgress passes a streamed metric on, rounded like final scores, ignoring unknown metrics
+func reportProgress(onProgress func(ScoreProgress), metric string, score float64) {
+	if onProgress == nil || !scoringMetricNames[metric] {
+		return
+	}
+	onProgress(ScoreProgress{Metric: metric, Score: int(score + 0.5)})
+}
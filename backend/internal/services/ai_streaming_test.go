@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/aipb"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func (s *fakeAIServer) StreamScore(req *aipb.ScoreResponseRequest, stream aipb.AIService_StreamScoreServer) error {
+	s.calls.Add(1)
+	for _, update := range []*aipb.ScoreUpdate{
+		{Metric: "humor", Score: 71.6},
+		{Metric: "creativity", Score: 82},
+		{Result: &aipb.ScoreResult{ResponseId: req.GetResponseId(), Metrics: &aipb.ScoringMetrics{Creativity: 82, Feasibility: 40, Humor: 71.6, Originality: 65}}},
+	} {
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectProgress records streamed metrics as "metric=score"
+func collectProgress(progress *[]string) func(ScoreProgress) {
+	return func(p ScoreProgress) {
+		*progress = append(*progress, fmt.Sprintf("%s=%d", p.Metric, p.Score))
+	}
+}
+
+func TestStreamScoreResponse_ServerSentEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scoring/score-response/stream" || r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Unexpected request %s with Accept %q", r.URL.Path, r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: metric\ndata: {\"metric\": \"creativity\", \"score\": 82.4}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: metric\ndata: {\"metric\": \"sarcasm\", \"score\": 99}\n\n")
+		fmt.Fprint(w, "event: metric\ndata: {\"metric\": \"humor\", \"score\": 70}\n\n")
+		fmt.Fprint(w, "event: result\ndata: {\"metrics\": {\"creativity\": 82.4, \"feasibility\": 50, \"humor\": 70, \"originality\": 60}, \"feedback\": \"Bold\"}\n\n")
+	}))
+	defer server.Close()
+
+	client := NewAIClient(server.URL, nil).(*AIClientImpl)
+	var progress []string
+	result, err := client.StreamScoreResponse(context.Background(), &models.Door{Content: "A door"}, "Open it", collectProgress(&progress))
+	if err != nil {
+		t.Fatalf("StreamScoreResponse failed: %v", err)
+	}
+
+	if fmt.Sprint(progress) != "[creativity=82 humor=70]" {
+		t.Errorf("Expected the known metrics in the order they were scored, got %v", progress)
+	}
+	if result.Metrics.Creativity != 82 || result.Metrics.Originality != 60 || result.Feedback != "Bold" {
+		t.Errorf("Expected the streamed result, got %+v", result)
+	}
+}
+
+func TestStreamScoreResponse_GRPC(t *testing.T) {
+	server := &fakeAIServer{}
+	client := NewAIClient("http://127.0.0.1:0", nil, WithGRPCTransport(newTestAIGRPCTransport(t, server))).(*AIClientImpl)
+
+	var progress []string
+	result, err := client.StreamScoreResponse(context.Background(), &models.Door{Content: "A door"}, "Open it", collectProgress(&progress))
+	if err != nil {
+		t.Fatalf("StreamScoreResponse failed: %v", err)
+	}
+
+	if fmt.Sprint(progress) != "[humor=72 creativity=82]" {
+		t.Errorf("Expected metrics streamed over gRPC, got %v", progress)
+	}
+	if result.Metrics.Humor != 72 || result.Metrics.Feasibility != 40 {
+		t.Errorf("Expected the streamed result, got %+v", result)
+	}
+}
+
+func TestStreamScoreResponse_FallsBackToSingleCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scoring/score-response/stream" {
+			fmt.Fprint(w, "event: metric\ndata: {\"metric\": \"humor\", \"score\": 70}\n\n")
+			return // cut off before the result
+		}
+		fmt.Fprint(w, `{"metrics": {"creativity": 10, "feasibility": 20, "humor": 30, "originality": 40}, "feedback": "Unary"}`)
+	}))
+	defer server.Close()
+
+	client := NewAIClient(server.URL, nil).(*AIClientImpl)
+	result, err := client.StreamScoreResponse(context.Background(), &models.Door{Content: "A door"}, "Open it", nil)
+	if err != nil {
+		t.Fatalf("StreamScoreResponse failed: %v", err)
+	}
+	if result.Feedback != "Unary" || result.Metrics.Originality != 40 {
+		t.Errorf("Expected the single-call score after the stream broke off, got %+v", result)
+	}
+}
+
+// streamingAIClient streams fixed metrics before returning the mock score
+type streamingAIClient struct {
+	MockAIClient
+}
+
+func (c *streamingAIClient) StreamScoreResponse(ctx context.Context, door *models.Door, response string, onProgress func(ScoreProgress)) (*ScoreResult, error) {
+	onProgress(ScoreProgress{Metric: "creativity", Score: 82})
+	onProgress(ScoreProgress{Metric: "humor", Score: 64})
+	return c.ScoreResponse(ctx, door, response)
+}
+
+func TestScoringQueue_RelaysStreamedMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ScoringQueueOption
+		expected string
+	}{
+		{"streaming", []ScoringQueueOption{WithStreamingScores()}, "[queued scoring metric:creativity=82 metric:humor=64 scored]"},
+		{"not streaming", nil, "[queued scoring scored]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wsManager := &recordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+			queue := NewScoringQueue(&streamingAIClient{}, wsManager, 1, 0, tt.opts...)
+
+			if _, err := queue.Score(context.Background(), "session", "player1", &models.Door{DoorID: "door-1"}, "response"); err != nil {
+				t.Fatalf("Unexpected scoring error: %v", err)
+			}
+
+			var statuses []string
+			for _, event := range wsManager.events {
+				data := event.Data.(map[string]interface{})
+				status := data["status"].(string)
+				if status == "metric" {
+					status = fmt.Sprintf("metric:%s=%d", data["metric"], data["score"])
+				}
+				statuses = append(statuses, status)
+			}
+			if fmt.Sprint(statuses) != tt.expected {
+				t.Errorf("Expected progress %s, got %v", tt.expected, statuses)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrPlayerActivityNotFound is returned when a player has no recorded activity to review
+var ErrPlayerActivityNotFound = errors.New("no activity recorded for this player")
+
+// Anomaly detection defaults
+const (
+	DefaultFastResponseThreshold = 2 * time.Second
+	DefaultFastResponseLimit     = 5
+	DefaultAnomalyWindow         = 20
+	DefaultAnomalyPageSize       = 20
+	MaxAnomalyPageSize           = 100
+)
+
+// Response lengths are bucketed before their entropy is measured, so answers a few characters
+// apart count as the same length. Below lowLengthEntropy bits, the lengths barely vary.
+const (
+	lengthEntropyBucket = 10
+	lowLengthEntropy    = 1.0
+)
+
+// AnomalyConfig configures when a player's cadence looks scripted
+type AnomalyConfig struct {
+	FastThreshold time.Duration // responses arriving sooner than this after the door opened are fast
+	FastLimit     int           // fast responses among the recent ones that flag the player
+	Window        int           // how many recent responses are kept per player
+}
+
+// AnomalyService watches how quickly and how uniformly players answer, and flags players whose
+// play looks scripted so they can be kept off the global leaderboards and reviewed by admins
+type AnomalyService interface {
+	Observe(ctx context.Context, playerID, username string, sample models.PlaySample)
+	ListFlagged(ctx context.Context, filter models.PlayerActivityFilter) (*models.PlayerActivityPage, error)
+	Clear(ctx context.Context, playerID, clearedBy string) (*models.PlayerActivity, error)
+}
+
+// AnomalyServiceImpl implements the AnomalyService interface
+type AnomalyServiceImpl struct {
+	config      AnomalyConfig
+	activity    repositories.PlayerActivityRepository
+	leaderboard LeaderboardService
+	flagged     *monitoring.Counter
+}
+
+// NewAnomalyService creates a new anomaly service; leaderboard may be nil, in which case flagged
+// players are only listed for review
+func NewAnomalyService(config AnomalyConfig, activity repositories.PlayerActivityRepository, leaderboard LeaderboardService) AnomalyService {
+	if config.FastThreshold <= 0 {
+		config.FastThreshold = DefaultFastResponseThreshold
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultAnomalyWindow
+	}
+	if config.FastLimit <= 0 || config.FastLimit > config.Window {
+		config.FastLimit = min(DefaultFastResponseLimit, config.Window)
+	}
+
+	return &AnomalyServiceImpl{
+		config:      config,
+		activity:    activity,
+		leaderboard: leaderboard,
+		flagged:     monitoring.GetGlobalMetricsCollector().NewCounter("play_anomalies_flagged_total", "Players flagged for scripted play", nil),
+	}
+}
+
+// Observe records a submitted response and flags the player once enough of their recent
+// responses arrived faster than a person could have read the door. Failures are logged, as
+// anomaly detection never holds up play.
+func (s *AnomalyServiceImpl) Observe(ctx context.Context, playerID, username string, sample models.PlaySample) {
+	fast := sample.Latency < s.config.FastThreshold
+	activity, err := s.activity.Record(ctx, playerID, username, sample, fast, s.config.Window)
+	if err != nil {
+		fmt.Printf("Warning: failed to record activity of player %s: %v\n", playerID, err)
+		return
+	}
+	if activity.Flagged || !fast {
+		return
+	}
+
+	fastCount := 0
+	for _, recent := range activity.Samples {
+		if recent.Latency < s.config.FastThreshold {
+			fastCount++
+		}
+	}
+	if fastCount < s.config.FastLimit {
+		return
+	}
+
+	reasons := []string{models.AnomalyFastResponses}
+	if lengthEntropy(activity.Samples) < lowLengthEntropy {
+		reasons = append(reasons, models.AnomalyUniformLength)
+	}
+	flagged, err := s.activity.Flag(ctx, playerID, reasons)
+	if err != nil {
+		fmt.Printf("Warning: failed to flag player %s: %v\n", playerID, err)
+		return
+	}
+	if !flagged {
+		return // flagged concurrently
+	}
+
+	s.flagged.Inc()
+	fmt.Printf("Flagged player %s for scripted play: %d of their last %d responses took under %s\n",
+		playerID, fastCount, len(activity.Samples), s.config.FastThreshold)
+	if s.leaderboard != nil {
+		if err := s.leaderboard.ShadowPlayer(ctx, playerID, true); err != nil {
+			fmt.Printf("Warning: failed to shadow flagged player %s: %v\n", playerID, err)
+		}
+	}
+}
+
+// ListFlagged returns one page of flagged players, most recently flagged first, with the
+// cadence that got them flagged
+func (s *AnomalyServiceImpl) ListFlagged(ctx context.Context, filter models.PlayerActivityFilter) (*models.PlayerActivityPage, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = DefaultAnomalyPageSize
+	}
+	if filter.PageSize > MaxAnomalyPageSize {
+		filter.PageSize = MaxAnomalyPageSize
+	}
+
+	players, total, err := s.activity.ListFlagged(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flagged players: %w", err)
+	}
+	for _, player := range players {
+		describeActivity(player)
+	}
+
+	return &models.PlayerActivityPage{
+		Players:  players,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+// Clear lifts a player's flag after review and lists their games on the leaderboards again
+func (s *AnomalyServiceImpl) Clear(ctx context.Context, playerID, clearedBy string) (*models.PlayerActivity, error) {
+	activity, err := s.activity.Clear(ctx, playerID, clearedBy)
+	if err != nil {
+		return nil, err
+	}
+	if activity == nil {
+		return nil, ErrPlayerActivityNotFound
+	}
+
+	if s.leaderboard != nil {
+		if err := s.leaderboard.ShadowPlayer(ctx, playerID, false); err != nil {
+			return nil, err
+		}
+	}
+	describeActivity(activity)
+	return activity, nil
+}
+
+// describeActivity fills in the cadence figures computed from the recent samples
+func describeActivity(activity *models.PlayerActivity) {
+	activity.LengthEntropy = lengthEntropy(activity.Samples)
+	activity.MedianLatency = 0
+	if len(activity.Samples) == 0 {
+		return
+	}
+
+	latencies := make([]time.Duration, len(activity.Samples))
+	for i, sample := range activity.Samples {
+		latencies[i] = sample.Latency
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	activity.MedianLatency = latencies[len(latencies)/2]
+}
+
+// lengthEntropy is the Shannon entropy, in bits, of the samples' bucketed response lengths.
+// It is zero when every response falls in the same bucket.
+func lengthEntropy(samples []models.PlaySample) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	buckets := make(map[int]int)
+	for _, sample := range samples {
+		buckets[sample.Length/lengthEntropyBucket]++
+	}
+	entropy := 0.0
+	for _, count := range buckets {
+		p := float64(count) / float64(len(samples))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// WithAnomalyDetection reports each player's response cadence for scripted play detection
+func WithAnomalyDetection(anomalies AnomalyService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.anomalies = anomalies
+	}
+}
+
+// observePlay reports how long after its door opened a response arrived. Bots answer as fast as
+// they are scheduled to and are never observed.
+func (s *GameServiceImpl) observePlay(ctx context.Context, session *models.GameSession, player models.PlayerInfo, content string, receivedAt time.Time) {
+	if s.anomalies == nil || session.RoundOpenedAt == nil || player.IsBot {
+		return
+	}
+
+	sample := models.PlaySample{
+		SessionID: session.SessionID,
+		Latency:   receivedAt.Sub(*session.RoundOpenedAt),
+		Length:    len([]rune(content)),
+		At:        receivedAt,
+	}
+	s.runInBackground(ctx, session.SessionID, "observe-play", func(ctx context.Context) {
+		s.anomalies.Observe(ctx, player.PlayerID, player.Username, sample)
+	})
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockPlayerActivityRepository keeps player activity in memory
+type MockPlayerActivityRepository struct {
+	players map[string]*models.PlayerActivity
+}
+
+func NewMockPlayerActivityRepository() *MockPlayerActivityRepository {
+	return &MockPlayerActivityRepository{players: make(map[string]*models.PlayerActivity)}
+}
+
+func (m *MockPlayerActivityRepository) Record(ctx context.Context, playerID, username string, sample models.PlaySample, fast bool, window int) (*models.PlayerActivity, error) {
+	activity := m.players[playerID]
+	if activity == nil {
+		activity = &models.PlayerActivity{PlayerID: playerID}
+		m.players[playerID] = activity
+	}
+	activity.Username = username
+	activity.Samples = append(activity.Samples, sample)
+	if len(activity.Samples) > window {
+		activity.Samples = activity.Samples[len(activity.Samples)-window:]
+	}
+	activity.Submissions++
+	if fast {
+		activity.FastSubmissions++
+	}
+	copied := *activity
+	return &copied, nil
+}
+
+func (m *MockPlayerActivityRepository) Flag(ctx context.Context, playerID string, reasons []string) (bool, error) {
+	activity := m.players[playerID]
+	if activity == nil || activity.Flagged {
+		return false, nil
+	}
+	now := time.Now()
+	activity.Flagged, activity.Reasons, activity.FlaggedAt = true, reasons, &now
+	return true, nil
+}
+
+func (m *MockPlayerActivityRepository) Clear(ctx context.Context, playerID, clearedBy string) (*models.PlayerActivity, error) {
+	activity := m.players[playerID]
+	if activity == nil {
+		return nil, nil
+	}
+	activity.Flagged, activity.Reasons, activity.FlaggedAt, activity.Samples = false, nil, nil, nil
+	activity.ClearedBy = clearedBy
+	copied := *activity
+	return &copied, nil
+}
+
+func (m *MockPlayerActivityRepository) IsFlagged(ctx context.Context, playerID string) (bool, error) {
+	activity := m.players[playerID]
+	return activity != nil && activity.Flagged, nil
+}
+
+func (m *MockPlayerActivityRepository) ListFlagged(ctx context.Context, filter models.PlayerActivityFilter) ([]*models.PlayerActivity, int64, error) {
+	var flagged []*models.PlayerActivity
+	for _, activity := range m.players {
+		if activity.Flagged {
+			flagged = append(flagged, activity)
+		}
+	}
+	return flagged, int64(len(flagged)), nil
+}
+
+// observe reports a submission of the given latency and length for p1
+func observe(service AnomalyService, latency time.Duration, length int) {
+	service.Observe(context.Background(), "p1", "Speedy", models.PlaySample{SessionID: "s1", Latency: latency, Length: length, At: time.Now()})
+}
+
+func TestAnomaly_FlagsRepeatedFastResponsesAndShadowsPlayer(t *testing.T) {
+	ctx := context.Background()
+	activityRepo := NewMockPlayerActivityRepository()
+	leaderboardRepo := NewMockLeaderboardRepository()
+	leaderboardRepo.AddEntry(ctx, &models.LeaderboardEntry{PlayerID: "p1", DoorsCompleted: 3})
+	leaderboardRepo.AddEntry(ctx, &models.LeaderboardEntry{PlayerID: "p2", DoorsCompleted: 3})
+	leaderboard := NewLeaderboardService(leaderboardRepo, NewMockGameSessionRepository(), WithShadowedPlayers(activityRepo))
+	service := NewAnomalyService(AnomalyConfig{FastLimit: 3}, activityRepo, leaderboard)
+
+	observe(service, 900*time.Millisecond, 42)
+	observe(service, 20*time.Second, 42)
+	observe(service, time.Second, 45)
+	if activityRepo.players["p1"].Flagged {
+		t.Fatal("Expected two fast responses not to flag the player")
+	}
+
+	observe(service, 1500*time.Millisecond, 41)
+	activity := activityRepo.players["p1"]
+	if !activity.Flagged || activity.FastSubmissions != 3 {
+		t.Fatalf("Expected the third fast response to flag the player, got %+v", activity)
+	}
+	if len(activity.Reasons) != 2 || activity.Reasons[1] != models.AnomalyUniformLength {
+		t.Errorf("Expected same-length responses to be noted, got %v", activity.Reasons)
+	}
+	if !leaderboardRepo.entries[0].Shadowed || leaderboardRepo.entries[1].Shadowed {
+		t.Errorf("Expected only p1's leaderboard entries to be shadowed, got %+v", leaderboardRepo.entries)
+	}
+
+	page, err := service.ListFlagged(ctx, models.PlayerActivityFilter{})
+	if err != nil {
+		t.Fatalf("ListFlagged failed: %v", err)
+	}
+	if page.Total != 1 || page.PageSize != DefaultAnomalyPageSize || page.Players[0].MedianLatency != 1500*time.Millisecond {
+		t.Errorf("Expected p1 listed with their median latency, got %+v", page)
+	}
+}
+
+func TestAnomaly_ClearListsPlayerAgain(t *testing.T) {
+	ctx := context.Background()
+	activityRepo := NewMockPlayerActivityRepository()
+	leaderboardRepo := NewMockLeaderboardRepository()
+	leaderboardRepo.AddEntry(ctx, &models.LeaderboardEntry{PlayerID: "p1", DoorsCompleted: 3})
+	service := NewAnomalyService(AnomalyConfig{FastLimit: 1}, activityRepo, NewLeaderboardService(leaderboardRepo, NewMockGameSessionRepository()))
+
+	if _, err := service.Clear(ctx, "p1", "admin"); !errors.Is(err, ErrPlayerActivityNotFound) {
+		t.Fatalf("Expected ErrPlayerActivityNotFound, got %v", err)
+	}
+
+	observe(service, time.Second, 30)
+	activity, err := service.Clear(ctx, "p1", "admin")
+	if err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if activity.Flagged || len(activity.Samples) != 0 || leaderboardRepo.entries[0].Shadowed {
+		t.Errorf("Expected the player cleared and listed again, got %+v", activity)
+	}
+}
+
+func TestRecordGameCompletion_ShadowsFlaggedPlayer(t *testing.T) {
+	ctx := context.Background()
+	activityRepo := NewMockPlayerActivityRepository()
+	activityRepo.players["p1"] = &models.PlayerActivity{PlayerID: "p1", Flagged: true}
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Players[0].Responses = []models.PlayerResponse{{DoorID: "door-1", AIScore: 90}}
+	session.Players[1].Responses = []models.PlayerResponse{{DoorID: "door-1", AIScore: 40}}
+	gameSessionRepo.sessions["s1"] = session
+	leaderboardRepo := NewMockLeaderboardRepository()
+	leaderboard := NewLeaderboardService(leaderboardRepo, gameSessionRepo, WithShadowedPlayers(activityRepo))
+
+	for _, playerID := range []string{"p1", "p2"} {
+		if err := leaderboard.RecordGameCompletion(ctx, "s1", playerID); err != nil {
+			t.Fatalf("RecordGameCompletion failed: %v", err)
+		}
+	}
+	if !leaderboardRepo.entries[0].Shadowed || leaderboardRepo.entries[1].Shadowed {
+		t.Errorf("Expected only the flagged player's entry to be shadowed, got %+v", leaderboardRepo.entries)
+	}
+}
+
+func TestLengthEntropy(t *testing.T) {
+	samples := func(lengths ...int) []models.PlaySample {
+		var result []models.PlaySample
+		for _, length := range lengths {
+			result = append(result, models.PlaySample{Length: length})
+		}
+		return result
+	}
+
+	if entropy := lengthEntropy(samples(40, 42, 45, 41)); entropy != 0 {
+		t.Errorf("Expected lengths in one bucket to have no entropy, got %v", entropy)
+	}
+	if entropy := lengthEntropy(samples(5, 25, 45, 65)); entropy != 2 {
+		t.Errorf("Expected four equally likely buckets to have 2 bits, got %v", entropy)
+	}
+}
+
+func TestSubmitResponse_ObservesTimeSinceDoorOpened(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	opened := time.Now().Add(-500 * time.Millisecond)
+	session.RoundOpenedAt = &opened
+	session.Players[0].IsActive, session.Players[1].IsActive = true, true // p2 keeps the round open
+	gameSessionRepo.sessions["s1"] = session
+	activityRepo := NewMockPlayerActivityRepository()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+		WithAnomalyDetection(NewAnomalyService(AnomalyConfig{}, activityRepo, nil)),
+	)
+
+	if _, err := gameService.SubmitResponse(context.Background(), "s1", "p1", "Knock politely"); err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	activity := activityRepo.players["p1"]
+	if activity == nil || len(activity.Samples) != 1 {
+		t.Fatalf("Expected the submission to be observed, got %+v", activity)
+	}
+	if sample := activity.Samples[0]; sample.Latency < 500*time.Millisecond || sample.Latency > time.Minute || sample.Length != 14 {
+		t.Errorf("Expected the latency since the door opened and the response length, got %+v", sample)
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"time"
+)
+
+// EventAnswerProgress tells a session how many of the players facing a door have answered it
+const EventAnswerProgress = "answer-progress"
+
+// answerProgress counts the active players facing a door and how many of them have answered it
+func answerProgress(session *models.GameSession, doorID string) (answered, total int) {
+	for _, player := range session.Players {
+		door := session.DoorForPlayer(player.PlayerID)
+		if !player.IsActive || door == nil || door.DoorID != doorID {
+			continue
+		}
+		total++
+		if hasRespondedToDoor(session, player.PlayerID, doorID) {
+			answered++
+		}
+	}
+	return answered, total
+}
+
+// answerProgressEvent builds the "N of M players have answered" status for a door, so players
+// who already answered see the round moving rather than a silent wait
+func answerProgressEvent(session *models.GameSession, doorID string) WebSocketEvent {
+	answered, total := answerProgress(session, doorID)
+	return WebSocketEvent{
+		Type:      EventAnswerProgress,
+		SessionID: session.SessionID,
+		Data: systemMessage(map[string]interface{}{
+			"doorId":   doorID,
+			"answered": answered,
+			"total":    total,
+			"waiting":  total - answered,
+		}, session.Locale, i18n.MsgAnswerProgress, answered, total),
+		Timestamp: time.Now(),
+	}
+}
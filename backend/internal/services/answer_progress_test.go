@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSubmitResponse_BroadcastsAnswerProgress(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newBatchedScoringSession()
+	wsManager := &broadcastRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), wsManager, &MockAIClient{}, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+	)
+
+	for _, playerID := range []string{"p1", "p2"} {
+		if _, err := gameService.SubmitResponse(ctx, "s1", playerID, "I would pick the lock."); err != nil {
+			t.Fatalf("SubmitResponse failed for %s: %v", playerID, err)
+		}
+	}
+
+	var progress []map[string]interface{}
+	for _, event := range wsManager.broadcasts {
+		if event.Type == EventAnswerProgress {
+			progress = append(progress, event.Data.(map[string]interface{}))
+		}
+	}
+	if len(progress) != 2 {
+		t.Fatalf("Expected an answer-progress event per submission, got %d", len(progress))
+	}
+	last := progress[1]
+	if last["doorId"] != "door-1" || last["answered"] != 2 || last["total"] != 3 || last["waiting"] != 1 {
+		t.Errorf("Expected 2 of 3 players to have answered door-1, got %v", last)
+	}
+	if last["message"] != "2 of 3 players have answered." {
+		t.Errorf("Expected a localized progress message, got %v", last["message"])
+	}
+}
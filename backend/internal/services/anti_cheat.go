@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrDuplicateResponse is returned when anti-cheat refuses a response that repeats an earlier answer
+var ErrDuplicateResponse = errors.New("response repeats an earlier answer")
+
+// Anti-cheat defaults
+const (
+	DefaultCheatSimilarityThreshold = 0.8
+	DefaultCheatPenalty             = 0.5
+	DefaultCheatMinWords            = 4
+)
+
+// AntiCheatConfig configures how copied responses are detected and handled
+type AntiCheatConfig struct {
+	Reject    bool    // reject suspected copies instead of penalizing them
+	Penalty   float64 // share of a suspected copy's score withheld; zero only flags it
+	Threshold float64 // estimated similarity at which a response counts as a copy
+	MinWords  int     // shorter responses are never suspected; "open the door" is a fair answer twice
+}
+
+// AntiCheatService catches players reusing their own answers or copying other players'
+type AntiCheatService interface {
+	Check(ctx context.Context, session *models.GameSession, playerID, responseID, text string) (*models.CheatSuspicion, error)
+	Remember(ctx context.Context, sessionID, playerID, responseID, text string)
+}
+
+// AntiCheatServiceImpl implements the AntiCheatService interface by fingerprinting responses
+type AntiCheatServiceImpl struct {
+	config    AntiCheatConfig
+	history   repositories.FingerprintStore
+	audit     AuditService
+	collector *monitoring.MetricsCollector
+}
+
+// NewAntiCheatService creates a new anti-cheat service; history and audit may be nil, in which
+// case only the current session is compared and suspicions are not audited
+func NewAntiCheatService(config AntiCheatConfig, history repositories.FingerprintStore, audit AuditService) AntiCheatService {
+	if config.Threshold <= 0 || config.Threshold > 1 {
+		config.Threshold = DefaultCheatSimilarityThreshold
+	}
+	if config.Penalty < 0 || config.Penalty > 1 {
+		config.Penalty = DefaultCheatPenalty
+	}
+	if config.MinWords <= 0 {
+		config.MinWords = DefaultCheatMinWords
+	}
+
+	return &AntiCheatServiceImpl{
+		config:    config,
+		history:   history,
+		audit:     audit,
+		collector: monitoring.GetGlobalMetricsCollector(),
+	}
+}
+
+// Check compares a response with the player's earlier answers and with every other player's
+// answers in the session, including those to the current door. It returns the closest match if
+// it reaches the threshold, or ErrDuplicateResponse when suspected copies are rejected.
+func (s *AntiCheatServiceImpl) Check(ctx context.Context, session *models.GameSession, playerID, responseID, text string) (*models.CheatSuspicion, error) {
+	if len(contentWords(text)) < s.config.MinWords {
+		return nil, nil
+	}
+	fingerprint := fingerprintResponse(session.SessionID, responseID, text)
+
+	var best *models.CheatSuspicion
+	consider := func(kind models.CheatKind, other *models.ResponseFingerprint, otherPlayerID string) {
+		exact := other.Hash == fingerprint.Hash
+		similarity := 1.0
+		if !exact {
+			similarity = signatureSimilarity(fingerprint.Signature, other.Signature)
+		}
+		if similarity < s.config.Threshold || (best != nil && similarity <= best.Similarity) {
+			return
+		}
+		best = &models.CheatSuspicion{
+			Kind:              kind,
+			MatchedResponseID: other.ResponseID,
+			MatchedPlayerID:   otherPlayerID,
+			Similarity:        similarity,
+			Exact:             exact,
+		}
+	}
+
+	for _, player := range session.Players {
+		kind := models.CheatPlagiarism
+		if player.PlayerID == playerID {
+			kind = models.CheatRepeat
+		}
+		for _, response := range player.Responses {
+			if len(contentWords(response.Content)) < s.config.MinWords {
+				continue
+			}
+			consider(kind, fingerprintResponse(session.SessionID, response.ResponseID, response.Content), player.PlayerID)
+		}
+	}
+
+	if s.history != nil {
+		earlier, err := s.history.Recent(ctx, playerID)
+		if err != nil {
+			fmt.Printf("Warning: failed to get response history of player %s: %v\n", playerID, err)
+		}
+		for _, other := range earlier {
+			if other.SessionID != session.SessionID && len(other.Signature) == doorMinHashSize {
+				consider(models.CheatRepeat, other, playerID)
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, nil
+	}
+	if !s.config.Reject {
+		best.Penalty = s.config.Penalty
+	}
+	s.report(ctx, session.SessionID, playerID, responseID, text, best)
+
+	if s.config.Reject {
+		return best, ErrDuplicateResponse
+	}
+	return best, nil
+}
+
+// Remember adds a submitted response to the player's history, so later games catch it being reused
+func (s *AntiCheatServiceImpl) Remember(ctx context.Context, sessionID, playerID, responseID, text string) {
+	if s.history == nil || len(contentWords(text)) < s.config.MinWords {
+		return
+	}
+	if err := s.history.Append(ctx, playerID, fingerprintResponse(sessionID, responseID, text)); err != nil {
+		fmt.Printf("Warning: failed to remember response of player %s: %v\n", playerID, err)
+	}
+}
+
+// report counts a suspected copy and records it in the audit log for review
+func (s *AntiCheatServiceImpl) report(ctx context.Context, sessionID, playerID, responseID, text string, suspicion *models.CheatSuspicion) {
+	action := "penalized"
+	if s.config.Reject {
+		action = "rejected"
+	}
+	s.collector.NewCounter("response_cheat_suspicions_total", "Responses suspected of repeating or copying an earlier answer", map[string]string{
+		"kind":   string(suspicion.Kind),
+		"action": action,
+	}).Inc()
+
+	if s.audit == nil {
+		return
+	}
+	event := &models.AuditEvent{
+		Action: models.AuditCheatSuspected,
+		Actor:  models.AuditActor{ID: playerID},
+		Target: models.AuditTarget{Type: "response", ID: responseID, SessionID: sessionID},
+		After: map[string]interface{}{
+			"content":   text,
+			"action":    action,
+			"suspicion": suspicion,
+		},
+	}
+	if err := s.audit.Record(ctx, event); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// fingerprintResponse hashes a response's normalized words and estimates its shingles
+func fingerprintResponse(sessionID, responseID, text string) *models.ResponseFingerprint {
+	hash := sha256.Sum256([]byte(strings.Join(contentWords(text), " ")))
+	return &models.ResponseFingerprint{
+		ResponseID:  responseID,
+		SessionID:   sessionID,
+		Hash:        hex.EncodeToString(hash[:]),
+		Signature:   doorSignature(text),
+		SubmittedAt: time.Now(),
+	}
+}
+
+// cheatPenalty withholds the suspicion's share of a score
+func cheatPenalty(score int, suspicion *models.CheatSuspicion) int {
+	if suspicion == nil || suspicion.Penalty <= 0 {
+		return score
+	}
+	return int(math.Round(float64(score) * (1 - suspicion.Penalty)))
+}
+
+// WithAntiCheat checks responses for repeated and copied answers before they are scored
+func WithAntiCheat(antiCheat AntiCheatService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.antiCheat = antiCheat
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"errors"
+	"testing"
+)
+
+// memoryFingerprintStore keeps players' response fingerprints in memory
+type memoryFingerprintStore struct {
+	fingerprints map[string][]*models.ResponseFingerprint
+}
+
+func newMemoryFingerprintStore() *memoryFingerprintStore {
+	return &memoryFingerprintStore{fingerprints: make(map[string][]*models.ResponseFingerprint)}
+}
+
+func (m *memoryFingerprintStore) Append(ctx context.Context, playerID string, fingerprint *models.ResponseFingerprint) error {
+	m.fingerprints[playerID] = append(m.fingerprints[playerID], fingerprint)
+	return nil
+}
+
+func (m *memoryFingerprintStore) Recent(ctx context.Context, playerID string) ([]*models.ResponseFingerprint, error) {
+	return m.fingerprints[playerID], nil
+}
+
+const copiedAnswer = "I would bribe the guard with a lifetime supply of homemade cookies"
+
+func TestAntiCheat_FlagsRepeatsAndPlagiarism(t *testing.T) {
+	ctx := context.Background()
+	session := newDraftSession()
+	session.Players[0].Responses = []models.PlayerResponse{{ResponseID: "r1", DoorID: "door-0", Content: copiedAnswer}}
+	auditRepo := &MockAuditEventRepository{}
+	antiCheat := NewAntiCheatService(AntiCheatConfig{Penalty: DefaultCheatPenalty}, nil, NewAuditService(auditRepo))
+
+	suspicion, err := antiCheat.Check(ctx, session, "p1", "r2", "I would BRIBE the guard, with a lifetime supply of homemade cookies!")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if suspicion == nil || suspicion.Kind != models.CheatRepeat || !suspicion.Exact || suspicion.Penalty != DefaultCheatPenalty {
+		t.Fatalf("Expected an exact repeat of the player's own answer, got %+v", suspicion)
+	}
+
+	suspicion, err = antiCheat.Check(ctx, session, "p2", "r3", copiedAnswer+" today")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if suspicion == nil || suspicion.Kind != models.CheatPlagiarism || suspicion.MatchedPlayerID != "p1" || suspicion.Exact {
+		t.Fatalf("Expected a near copy of p1's answer, got %+v", suspicion)
+	}
+
+	if len(auditRepo.events) != 2 || auditRepo.events[1].Action != models.AuditCheatSuspected || auditRepo.events[1].Target.ID != "r3" {
+		t.Errorf("Expected both suspicions to be audited, got %+v", auditRepo.events)
+	}
+	counter := monitoring.GetGlobalMetricsCollector().NewCounter("response_cheat_suspicions_total", "", map[string]string{"kind": "plagiarism", "action": "penalized"})
+	if counter.Get() < 1 {
+		t.Errorf("Expected the plagiarism to be counted, got %v", counter.Get())
+	}
+}
+
+func TestAntiCheat_IgnoresShortAndOriginalAnswers(t *testing.T) {
+	session := newDraftSession()
+	session.Players[1].Responses = []models.PlayerResponse{
+		{ResponseID: "r1", Content: "Open the door"},
+		{ResponseID: "r2", Content: copiedAnswer},
+	}
+	antiCheat := NewAntiCheatService(AntiCheatConfig{}, nil, nil)
+
+	for _, text := range []string{"Open the door", "I would tunnel underneath it using only a teaspoon and patience"} {
+		suspicion, err := antiCheat.Check(context.Background(), session, "p1", "r3", text)
+		if err != nil || suspicion != nil {
+			t.Errorf("Expected %q not to be suspected, got %+v, %v", text, suspicion, err)
+		}
+	}
+}
+
+func TestAntiCheat_RemembersAnswersAcrossGames(t *testing.T) {
+	ctx := context.Background()
+	history := newMemoryFingerprintStore()
+	antiCheat := NewAntiCheatService(AntiCheatConfig{Reject: true}, history, nil)
+
+	antiCheat.Remember(ctx, "earlier-game", "p1", "r1", copiedAnswer)
+	suspicion, err := antiCheat.Check(ctx, newDraftSession(), "p1", "r2", copiedAnswer)
+	if !errors.Is(err, ErrDuplicateResponse) {
+		t.Fatalf("Expected ErrDuplicateResponse, got %v", err)
+	}
+	if suspicion.MatchedResponseID != "r1" || suspicion.Penalty != 0 {
+		t.Errorf("Expected a rejected repeat of r1 without a penalty, got %+v", suspicion)
+	}
+
+	if suspicion, err := antiCheat.Check(ctx, newDraftSession(), "p2", "r3", copiedAnswer); err != nil || suspicion != nil {
+		t.Errorf("Expected another player's history not to be compared, got %+v, %v", suspicion, err)
+	}
+}
+
+func TestSubmitResponse_PenalizesCopiedAnswer(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newDraftSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+		WithAntiCheat(NewAntiCheatService(AntiCheatConfig{Penalty: 0.5}, nil, nil)),
+	)
+
+	original, err := gameService.SubmitResponse(ctx, "s1", "p1", copiedAnswer)
+	if err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	copied, err := gameService.SubmitResponse(ctx, "s1", "p2", copiedAnswer)
+	if err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+
+	if original.CheatSuspicion != nil {
+		t.Errorf("Expected the original answer not to be suspected, got %+v", original.CheatSuspicion)
+	}
+	if copied.CheatSuspicion == nil || copied.CheatSuspicion.MatchedResponseID != original.ResponseID {
+		t.Fatalf("Expected the copy to be matched to the original, got %+v", copied.CheatSuspicion)
+	}
+	if expected := cheatPenalty(original.AIScore, copied.CheatSuspicion); copied.AIScore != expected || expected >= original.AIScore {
+		t.Errorf("Expected the copy to score %d against the original's %d, got %d", expected, original.AIScore, copied.AIScore)
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+	"time"
+)
+
+// Audit log page sizes
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 200
+)
+
+// AuditService records privileged actions, such as host kicks and door edits, for later review
+type AuditService interface {
+	Record(ctx context.Context, event *models.AuditEvent) error
+	ListEvents(ctx context.Context, filter models.AuditEventFilter) (*models.AuditEventPage, error)
+}
+
+// AuditServiceImpl implements the AuditService interface
+type AuditServiceImpl struct {
+	auditRepo repositories.AuditEventRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo repositories.AuditEventRepository) AuditService {
+	return &AuditServiceImpl{auditRepo: auditRepo}
+}
+
+// Record stores an audit event, stamping it with an ID, the time and the request it was made in
+func (s *AuditServiceImpl) Record(ctx context.Context, event *models.AuditEvent) error {
+	event.EventID = fmt.Sprintf("audit_%s", random.ID())
+	event.CreatedAt = time.Now()
+	if event.RequestID == "" {
+		event.RequestID = tracing.FromContext(ctx).RequestID
+	}
+
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		return fmt.Errorf("failed to record %s audit event: %w", event.Action, err)
+	}
+	return nil
+}
+
+// ListEvents returns one page of audit events, newest first
+func (s *AuditServiceImpl) ListEvents(ctx context.Context, filter models.AuditEventFilter) (*models.AuditEventPage, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = DefaultAuditPageSize
+	}
+	if filter.PageSize > MaxAuditPageSize {
+		filter.PageSize = MaxAuditPageSize
+	}
+
+	events, total, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return &models.AuditEventPage{
+		Events:   events,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
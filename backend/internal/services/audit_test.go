@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"testing"
+	"time"
+)
+
+// MockAuditEventRepository keeps audit events in memory
+type MockAuditEventRepository struct {
+	events  []*models.AuditEvent
+	filters []models.AuditEventFilter
+}
+
+func (m *MockAuditEventRepository) Record(ctx context.Context, event *models.AuditEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *MockAuditEventRepository) List(ctx context.Context, filter models.AuditEventFilter) ([]*models.AuditEvent, int64, error) {
+	m.filters = append(m.filters, filter)
+	events := []*models.AuditEvent{}
+	for _, event := range m.events {
+		if (filter.ActorID == "" || event.Actor.ID == filter.ActorID) && (filter.Since == nil || !event.CreatedAt.Before(*filter.Since)) {
+			events = append(events, event)
+		}
+	}
+	return events, int64(len(events)), nil
+}
+
+func TestAuditService_RecordsRequestContext(t *testing.T) {
+	repo := &MockAuditEventRepository{}
+	audit := NewAuditService(repo)
+	ctx := tracing.WithRequestID(context.Background(), "req-42")
+
+	err := audit.Record(ctx, &models.AuditEvent{
+		Action: models.AuditPlayerKicked,
+		Actor:  models.AuditActor{ID: "p1"},
+		Target: models.AuditTarget{Type: "player", ID: "p2", SessionID: "s1"},
+	})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	event := repo.events[0]
+	if event.EventID == "" || event.RequestID != "req-42" || event.CreatedAt.IsZero() {
+		t.Errorf("Expected the event to be stamped with an ID, the request ID and a time, got %+v", event)
+	}
+}
+
+func TestAuditService_ListsEventsByActorAndTime(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockAuditEventRepository{}
+	audit := NewAuditService(repo)
+	audit.Record(ctx, &models.AuditEvent{Action: models.AuditMetricsReset, Actor: models.AuditActor{ID: "admin"}})
+	audit.Record(ctx, &models.AuditEvent{Action: models.AuditDoorDeleted, Actor: models.AuditActor{ID: "mod"}})
+	repo.events[0].CreatedAt = time.Now().Add(-48 * time.Hour)
+
+	since := time.Now().Add(-time.Hour)
+	page, err := audit.ListEvents(ctx, models.AuditEventFilter{Since: &since, PageSize: 10_000})
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	if page.Total != 1 || page.Events[0].Actor.ID != "mod" {
+		t.Errorf("Expected only the recent event, got %+v", page.Events)
+	}
+	if page.Page != 1 || page.PageSize != MaxAuditPageSize {
+		t.Errorf("Expected the page to be clamped, got page %d of size %d", page.Page, page.PageSize)
+	}
+
+	if page, _ = audit.ListEvents(ctx, models.AuditEventFilter{ActorID: "admin"}); page.Total != 1 || page.PageSize != DefaultAuditPageSize {
+		t.Errorf("Expected the admin's event on a default-sized page, got %+v", page)
+	}
+}
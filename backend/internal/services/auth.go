@@ -0,0 +1,140 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"dumdoors-backend/internal/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Auth defaults
+const (
+	DefaultTokenTTL = 15 * time.Minute
+	TokenIssuer     = "dumdoors-backend"
+	TokenType       = "Bearer"
+)
+
+// Token verification errors
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// jwtHeader is the fixed HS256 header used for every issued token
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// AuthService issues and verifies short-lived player access tokens
+type AuthService interface {
+	IssueToken(user *models.RedditUser) (*models.AuthToken, error)
+	VerifyToken(token string) (*models.PlayerClaims, error)
+}
+
+// AuthServiceImpl implements AuthService with HS256-signed JWTs
+type AuthServiceImpl struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewAuthService creates an auth service signing tokens with the given secret
+func NewAuthService(secret []byte, ttl time.Duration) AuthService {
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+	return &AuthServiceImpl{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// GenerateTokenSecret returns a random signing secret for deployments that do not configure one
+func GenerateTokenSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate token secret: %w", err)
+	}
+	return secret, nil
+}
+
+// IssueToken signs a token for a verified Reddit identity
+func (a *AuthServiceImpl) IssueToken(user *models.RedditUser) (*models.AuthToken, error) {
+	if user == nil || user.ID == "" {
+		return nil, errors.New("user identity is required")
+	}
+
+	issuedAt := a.now()
+	expiresAt := issuedAt.Add(a.ttl)
+	claims := models.PlayerClaims{
+		PlayerID:  user.ID,
+		Username:  user.Username,
+		Issuer:    TokenIssuer,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	signingInput := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	token := signingInput + "." + a.sign(signingInput)
+
+	return &models.AuthToken{
+		Token:     token,
+		TokenType: TokenType,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+		PlayerID:  claims.PlayerID,
+		Username:  claims.Username,
+	}, nil
+}
+
+// VerifyToken checks a token's signature, issuer and expiry and returns its claims
+func (a *AuthServiceImpl) VerifyToken(token string) (*models.PlayerClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	expected, _ := base64.RawURLEncoding.DecodeString(a.sign(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, expected) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims models.PlayerClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Issuer != TokenIssuer || claims.PlayerID == "" {
+		return nil, ErrInvalidToken
+	}
+	if a.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+// sign returns the base64url HMAC-SHA256 signature of the signing input
+func (a *AuthServiceImpl) sign(signingInput string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
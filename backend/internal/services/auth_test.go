@@ -0,0 +1,76 @@
+package services
+
+import (
+	"dumdoors-backend/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthService_IssueAndVerify(t *testing.T) {
+	auth := NewAuthService([]byte("test-secret"), time.Minute)
+
+	token, err := auth.IssueToken(&models.RedditUser{ID: "t2_abc", Username: "doorfan"})
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+	if token.TokenType != TokenType || token.PlayerID != "t2_abc" {
+		t.Fatalf("unexpected token metadata: %+v", token)
+	}
+
+	claims, err := auth.VerifyToken(token.Token)
+	if err != nil {
+		t.Fatalf("VerifyToken failed: %v", err)
+	}
+	if claims.PlayerID != "t2_abc" || claims.Username != "doorfan" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+	if claims.ExpiresAt-claims.IssuedAt != 60 {
+		t.Errorf("expected a one minute lifetime, got %ds", claims.ExpiresAt-claims.IssuedAt)
+	}
+}
+
+func TestAuthService_RejectsExpiredToken(t *testing.T) {
+	auth := NewAuthService([]byte("test-secret"), time.Minute).(*AuthServiceImpl)
+	issuedAt := time.Now()
+	auth.now = func() time.Time { return issuedAt }
+
+	token, err := auth.IssueToken(&models.RedditUser{ID: "t2_abc", Username: "doorfan"})
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+
+	auth.now = func() time.Time { return issuedAt.Add(2 * time.Minute) }
+	if _, err := auth.VerifyToken(token.Token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("expected ErrTokenExpired, got %v", err)
+	}
+}
+
+func TestAuthService_RejectsTamperedToken(t *testing.T) {
+	auth := NewAuthService([]byte("test-secret"), time.Minute)
+	forger := NewAuthService([]byte("other-secret"), time.Minute)
+
+	token, err := auth.IssueToken(&models.RedditUser{ID: "t2_abc", Username: "doorfan"})
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+	forged, err := forger.IssueToken(&models.RedditUser{ID: "t2_victim", Username: "victim"})
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+
+	// Swap in another player's claims while keeping the original signature
+	parts := strings.Split(token.Token, ".")
+	spoofed := strings.Join([]string{parts[0], strings.Split(forged.Token, ".")[1], parts[2]}, ".")
+
+	for name, candidate := range map[string]string{
+		"wrong secret":    forged.Token,
+		"swapped payload": spoofed,
+		"malformed":       "not-a-token",
+	} {
+		if _, err := auth.VerifyToken(candidate); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
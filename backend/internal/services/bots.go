@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"fmt"
+	"time"
+)
+
+// MaxBotOpponents is the most AI opponents a single-player session can have
+const MaxBotOpponents = 3
+
+// Default range of time a bot takes to answer a door, so bots don't answer instantly
+const (
+	DefaultBotMinThinkTime = 8 * time.Second
+	DefaultBotMaxThinkTime = 30 * time.Second
+)
+
+// botPersona is the name and skill (0-100) of an AI opponent
+type botPersona struct {
+	Username string
+	Skill    int
+}
+
+// botPersonas are handed out to a session's bots in order
+var botPersonas = []botPersona{
+	{Username: "Knobert (bot)", Skill: 45},
+	{Username: "Hinge Harriet (bot)", Skill: 65},
+	{Username: "Deadbolt Dave (bot)", Skill: 85},
+}
+
+// WithBotThinkTime sets the range of time bots take to answer a door
+func WithBotThinkTime(min, max time.Duration) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.botMinThinkTime = min
+		s.botMaxThinkTime = max
+	}
+}
+
+// newBotPlayers creates the AI opponents of a session
+func newBotPlayers(sessionID string, count int) []models.PlayerInfo {
+	bots := make([]models.PlayerInfo, 0, count)
+	for i := 0; i < count && i < len(botPersonas); i++ {
+		bots = append(bots, models.PlayerInfo{
+			PlayerID:  fmt.Sprintf("bot-%d-%s", i+1, sessionID),
+			Username:  botPersonas[i].Username,
+			JoinedAt:  time.Now(),
+			Responses: []models.PlayerResponse{},
+			IsActive:  true,
+			IsBot:     true,
+		})
+	}
+	return bots
+}
+
+// botSkill returns the skill of a bot, by its persona
+func botSkill(bot models.PlayerInfo) int {
+	for _, persona := range botPersonas {
+		if persona.Username == bot.Username {
+			return persona.Skill
+		}
+	}
+	return 50
+}
+
+// scheduleBotResponses has each bot in the session answer its door after a short think
+func (s *GameServiceImpl) scheduleBotResponses(ctx context.Context, session *models.GameSession) {
+	if s.aiClient == nil {
+		return
+	}
+
+	for _, player := range session.Players {
+		if !player.IsBot || !player.IsActive {
+			continue
+		}
+		door := session.DoorForPlayer(player.PlayerID)
+		if door == nil {
+			continue
+		}
+
+		bot, doorID := player, door.DoorID
+		time.AfterFunc(s.botThinkTime(), func() {
+			s.runInBackground(ctx, session.SessionID, "bot-response", func(ctx context.Context) {
+				if err := s.playBotTurn(ctx, session.SessionID, bot, doorID); err != nil {
+					fmt.Printf("Warning: bot %s failed to respond: %v\n", bot.PlayerID, err)
+				}
+			})
+		})
+	}
+}
+
+// botThinkTime picks how long a bot takes to answer
+func (s *GameServiceImpl) botThinkTime() time.Duration {
+	spread := s.botMaxThinkTime - s.botMinThinkTime
+	if spread <= 0 {
+		return s.botMinThinkTime
+	}
+	return s.botMinThinkTime + time.Duration(random.Intn(int(spread)))
+}
+
+// playBotTurn generates a bot's answer to its door and submits it like any player's, so it is
+// scored and broadcast. Doors that have moved on or were already answered are skipped.
+func (s *GameServiceImpl) playBotTurn(ctx context.Context, sessionID string, bot models.PlayerInfo, doorID string) error {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil || s.stateMachine.Require(session, OpSubmitResponse) != nil {
+		return nil
+	}
+
+	door := session.DoorForPlayer(bot.PlayerID)
+	if door == nil || door.DoorID != doorID || hasRespondedToDoor(session, bot.PlayerID, doorID) {
+		return nil
+	}
+
+	response, err := s.aiClient.GenerateResponse(ctx, door, botSkill(bot))
+	if err != nil {
+		return fmt.Errorf("failed to generate response: %w", err)
+	}
+
+	if _, err := s.SubmitResponse(ctx, sessionID, bot.PlayerID, response); err != nil {
+		return fmt.Errorf("failed to submit response: %w", err)
+	}
+	return nil
+}
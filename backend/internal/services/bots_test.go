@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestCreateSession_AddsBotOpponents(t *testing.T) {
+	ctx := context.Background()
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	session, err := gameService.CreateSession(ctx, models.GameModeSinglePlayer, "p1", "Player 1", nil, "en", models.SessionSettings{BotOpponents: 2})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if len(session.Players) != 3 || session.Players[0].IsBot {
+		t.Fatalf("Expected the creator followed by two bots, got %+v", session.Players)
+	}
+	for _, bot := range session.Players[1:] {
+		if !bot.IsBot || !bot.IsActive || bot.Username == "" {
+			t.Errorf("Expected an active, named bot, got %+v", bot)
+		}
+	}
+	if session.Players[1].PlayerID == session.Players[2].PlayerID {
+		t.Error("Expected each bot to have its own player ID")
+	}
+
+	// Bots only play single-player sessions, and only a few of them
+	if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Player 1", nil, "en", models.SessionSettings{BotOpponents: 1}); !errors.Is(err, ErrInvalidSessionSettings) {
+		t.Errorf("Expected bots in a multiplayer session to be rejected, got %v", err)
+	}
+	if _, err := gameService.CreateSession(ctx, models.GameModeSinglePlayer, "p1", "Player 1", nil, "en", models.SessionSettings{BotOpponents: MaxBotOpponents + 1}); !errors.Is(err, ErrInvalidSessionSettings) {
+		t.Errorf("Expected too many bots to be rejected, got %v", err)
+	}
+}
+
+func TestPlayBotTurn_SubmitsScoredResponse(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Players[0].IsActive = true // Still thinking, so the round stays open
+	session.Players[1] = newBotPlayers("s1", 1)[0]
+	repo.sessions["s1"] = session
+	gameService := NewGameService(repo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil).(*GameServiceImpl)
+	bot := session.Players[1]
+
+	// A bot whose door has already moved on doesn't answer
+	if err := gameService.playBotTurn(ctx, "s1", bot, "door-0"); err != nil {
+		t.Fatalf("playBotTurn failed: %v", err)
+	}
+	if len(session.Players[1].Responses) != 0 {
+		t.Fatalf("Expected no answer to a stale door, got %+v", session.Players[1].Responses)
+	}
+
+	if err := gameService.playBotTurn(ctx, "s1", bot, "door-1"); err != nil {
+		t.Fatalf("playBotTurn failed: %v", err)
+	}
+	responses := session.Players[1].Responses
+	if len(responses) != 1 || responses[0].Content != "I would open door-1 carefully" || responses[0].AIScore != 70 {
+		t.Fatalf("Expected the bot's generated answer to be scored, got %+v", responses)
+	}
+	if session.Players[1].TotalScore != 70 {
+		t.Errorf("Expected the bot's score to count, got %d", session.Players[1].TotalScore)
+	}
+
+	// A door is answered only once
+	if err := gameService.playBotTurn(ctx, "s1", bot, "door-1"); err != nil {
+		t.Fatalf("playBotTurn failed: %v", err)
+	}
+	if len(session.Players[1].Responses) != 1 {
+		t.Errorf("Expected the bot to answer once, got %d responses", len(session.Players[1].Responses))
+	}
+}
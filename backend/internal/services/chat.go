@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Chat limits
+const (
+	MaxChatMessageLength  = 280
+	DefaultChatRateLimit  = 5
+	DefaultChatRateWindow = 10 * time.Second
+)
+
+// Chat errors
+var (
+	ErrChatSessionNotFound = ErrSessionNotFound
+	ErrChatNotMember       = errors.New("only players in the session may use its chat")
+	ErrInvalidChatMessage  = errors.New("invalid chat message")
+	ErrChatRateLimited     = errors.New("sending chat messages too quickly")
+)
+
+// ChatService lets the players of a session talk to each other
+type ChatService interface {
+	SendMessage(ctx context.Context, sessionID, playerID, text string) (*models.ChatMessage, error)
+	GetHistory(ctx context.Context, sessionID, playerID string) ([]*models.ChatMessage, error)
+	// SetRateLimit changes how many messages each player may send per window, as WithChatRateLimit does
+	SetRateLimit(limit int, window time.Duration)
+}
+
+// ChatServiceImpl implements the ChatService interface
+type ChatServiceImpl struct {
+	gameSessionRepo   repositories.GameSessionRepository
+	store             repositories.ChatStore
+	wsManager         WebSocketManager
+	moderationService ModerationService
+	limiter           *chatRateLimiter
+}
+
+// ChatServiceOption configures optional chat service settings
+type ChatServiceOption func(*ChatServiceImpl)
+
+// WithChatModeration screens chat messages with the moderation service before they are sent
+func WithChatModeration(moderationService ModerationService) ChatServiceOption {
+	return func(s *ChatServiceImpl) {
+		s.moderationService = moderationService
+	}
+}
+
+// WithChatRateLimit lets each player send at most limit messages per window
+func WithChatRateLimit(limit int, window time.Duration) ChatServiceOption {
+	return func(s *ChatServiceImpl) {
+		if limit > 0 && window > 0 {
+			s.limiter = newChatRateLimiter(limit, window)
+		}
+	}
+}
+
+// NewChatService creates a new chat service and registers it for "chat-message" socket messages
+func NewChatService(gameSessionRepo repositories.GameSessionRepository, store repositories.ChatStore, wsManager WebSocketManager, opts ...ChatServiceOption) ChatService {
+	service := &ChatServiceImpl{
+		gameSessionRepo: gameSessionRepo,
+		store:           store,
+		wsManager:       wsManager,
+		limiter:         newChatRateLimiter(DefaultChatRateLimit, DefaultChatRateWindow),
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	if wsManager != nil {
+		wsManager.OnMessage(MessageTypeChat, service.handleChatMessage)
+	}
+
+	return service
+}
+
+// SendMessage validates, moderates and stores a player's message, then broadcasts it to the session
+func (s *ChatServiceImpl) SendMessage(ctx context.Context, sessionID, playerID, text string) (*models.ChatMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: message is empty", ErrInvalidChatMessage)
+	}
+	if length := ResponseLength(text); length > MaxChatMessageLength {
+		return nil, fmt.Errorf("%w: message exceeds %d character limit by %d", ErrInvalidChatMessage, MaxChatMessageLength, length-MaxChatMessageLength)
+	}
+
+	session, player, err := s.sessionPlayer(ctx, sessionID, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.limiter.Allow(playerID, time.Now()) {
+		return nil, ErrChatRateLimited
+	}
+
+	if s.moderationService != nil {
+		result, err := s.moderationService.Moderate(ctx, models.ModerationSubject{SessionID: sessionID, PlayerID: playerID}, text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to moderate chat message: %w", err)
+		}
+		result = applyProfanityFilter(result, text, session.Settings.Profanity)
+		if result.Action == models.ModerationReject {
+			return nil, fmt.Errorf("%w: message was rejected by moderation", ErrInvalidChatMessage)
+		}
+		text = result.Text
+	}
+
+	message := &models.ChatMessage{
+		MessageID: fmt.Sprintf("chat_%s", random.ID()),
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Username:  player.Username,
+		Text:      text,
+		SentAt:    time.Now(),
+	}
+
+	if err := s.store.Append(ctx, message); err != nil {
+		return nil, err
+	}
+
+	if s.wsManager != nil {
+		event := WebSocketEvent{
+			Type:      "chat-message",
+			SessionID: sessionID,
+			PlayerID:  playerID,
+			Data:      message,
+			Timestamp: message.SentAt,
+		}
+		if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+			fmt.Printf("Warning: failed to broadcast chat message: %v\n", err)
+		}
+	}
+
+	return message, nil
+}
+
+// GetHistory returns the session's recent messages, oldest first, so reconnecting players can catch up
+func (s *ChatServiceImpl) GetHistory(ctx context.Context, sessionID, playerID string) ([]*models.ChatMessage, error) {
+	if _, _, err := s.sessionPlayer(ctx, sessionID, playerID); err != nil {
+		return nil, err
+	}
+	return s.store.Recent(ctx, sessionID)
+}
+
+// handleChatMessage sends a chat message received over the player's socket
+func (s *ChatServiceImpl) handleChatMessage(ctx context.Context, sessionID, playerID string, msg map[string]interface{}) error {
+	text, _ := msg["text"].(string)
+	_, err := s.SendMessage(ctx, sessionID, playerID, text)
+	return err
+}
+
+// sessionPlayer returns the session with the player's entry in it, failing if they are not part of it
+func (s *ChatServiceImpl) sessionPlayer(ctx context.Context, sessionID, playerID string) (*models.GameSession, *models.PlayerInfo, error) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, nil, ErrChatSessionNotFound
+	}
+
+	for i := range session.Players {
+		if session.Players[i].PlayerID == playerID {
+			return session, &session.Players[i], nil
+		}
+	}
+	return nil, nil, ErrChatNotMember
+}
+
+// SetRateLimit changes the per-player message limit; non-positive values are ignored
+func (s *ChatServiceImpl) SetRateLimit(limit int, window time.Duration) {
+	if limit > 0 && window > 0 {
+		s.limiter.SetLimits(limit, window)
+	}
+}
+
+// chatLimiterSweepSize is how many players the limiter tracks before forgetting idle ones
+const chatLimiterSweepSize = 1024
+
+// chatRateLimiter allows each player a fixed number of messages per sliding window
+type chatRateLimiter struct {
+	limit  int
+	window time.Duration
+	mu     sync.Mutex
+	sent   map[string][]time.Time // playerID -> send times within the window
+}
+
+// newChatRateLimiter creates a limiter allowing limit messages per window
+func newChatRateLimiter(limit int, window time.Duration) *chatRateLimiter {
+	return &chatRateLimiter{
+		limit:  limit,
+		window: window,
+		sent:   make(map[string][]time.Time),
+	}
+}
+
+// SetLimits changes the limit and window; sends already recorded count against the new limit
+func (l *chatRateLimiter) SetLimits(limit int, window time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit, l.window = limit, window
+}
+
+// Allow records a message sent at now if the player is under their limit
+func (l *chatRateLimiter) Allow(playerID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.sent) > chatLimiterSweepSize {
+		l.sweep(now)
+	}
+
+	recent := l.recent(playerID, now)
+	if len(recent) >= l.limit {
+		l.sent[playerID] = recent
+		return false
+	}
+	l.sent[playerID] = append(recent, now)
+	return true
+}
+
+// recent drops the player's sends that have left the window
+func (l *chatRateLimiter) recent(playerID string, now time.Time) []time.Time {
+	cutoff := now.Add(-l.window)
+	sent := l.sent[playerID]
+	kept := sent[:0]
+	for _, at := range sent {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	return kept
+}
+
+// sweep forgets players with no sends left in the window
+func (l *chatRateLimiter) sweep(now time.Time) {
+	for playerID := range l.sent {
+		if len(l.recent(playerID, now)) == 0 {
+			delete(l.sent, playerID)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockChatStore keeps chat messages in memory
+type MockChatStore struct {
+	messages map[string][]*models.ChatMessage
+}
+
+func NewMockChatStore() *MockChatStore {
+	return &MockChatStore{messages: make(map[string][]*models.ChatMessage)}
+}
+
+func (m *MockChatStore) Append(ctx context.Context, message *models.ChatMessage) error {
+	m.messages[message.SessionID] = append(m.messages[message.SessionID], message)
+	return nil
+}
+
+func (m *MockChatStore) Recent(ctx context.Context, sessionID string) ([]*models.ChatMessage, error) {
+	return m.messages[sessionID], nil
+}
+
+func newChatSession() *models.GameSession {
+	return &models.GameSession{
+		SessionID: "s1",
+		Status:    models.GameStatusWaiting,
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "alice"},
+			{PlayerID: "p2", Username: "bob"},
+		},
+	}
+}
+
+func TestChatService_StoresMessagesForHistory(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newChatSession()
+	chatService := NewChatService(gameSessionRepo, NewMockChatStore(), nil)
+
+	if _, err := chatService.SendMessage(ctx, "s1", "p1", "  anyone else stuck?  "); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if _, err := chatService.SendMessage(ctx, "s1", "p2", "always"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	history, err := chatService.GetHistory(ctx, "s1", "p2")
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(history))
+	}
+	if history[0].Text != "anyone else stuck?" || history[0].Username != "alice" {
+		t.Errorf("Expected trimmed message from alice, got %+v", history[0])
+	}
+}
+
+func TestChatService_RejectsOutsiders(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newChatSession()
+	chatService := NewChatService(gameSessionRepo, NewMockChatStore(), nil)
+
+	if _, err := chatService.SendMessage(ctx, "s1", "stranger", "hi"); !errors.Is(err, ErrChatNotMember) {
+		t.Errorf("Expected ErrChatNotMember sending, got %v", err)
+	}
+	if _, err := chatService.GetHistory(ctx, "s1", "stranger"); !errors.Is(err, ErrChatNotMember) {
+		t.Errorf("Expected ErrChatNotMember reading history, got %v", err)
+	}
+	if _, err := chatService.SendMessage(ctx, "missing", "p1", "hi"); !errors.Is(err, ErrChatSessionNotFound) {
+		t.Errorf("Expected ErrChatSessionNotFound, got %v", err)
+	}
+}
+
+func TestChatService_ValidatesMessages(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newChatSession()
+	chatService := NewChatService(gameSessionRepo, NewMockChatStore(), nil)
+
+	for _, text := range []string{"", "   ", strings.Repeat("a", MaxChatMessageLength+1)} {
+		if _, err := chatService.SendMessage(ctx, "s1", "p1", text); !errors.Is(err, ErrInvalidChatMessage) {
+			t.Errorf("Expected ErrInvalidChatMessage for %d characters, got %v", len(text), err)
+		}
+	}
+}
+
+func TestChatService_RateLimitsEachPlayer(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newChatSession()
+	chatService := NewChatService(gameSessionRepo, NewMockChatStore(), nil, WithChatRateLimit(2, time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if _, err := chatService.SendMessage(ctx, "s1", "p1", "knock knock"); err != nil {
+			t.Fatalf("SendMessage %d failed: %v", i, err)
+		}
+	}
+	if _, err := chatService.SendMessage(ctx, "s1", "p1", "knock knock"); !errors.Is(err, ErrChatRateLimited) {
+		t.Errorf("Expected ErrChatRateLimited, got %v", err)
+	}
+
+	// Other players keep their own allowance
+	if _, err := chatService.SendMessage(ctx, "s1", "p2", "who's there"); err != nil {
+		t.Errorf("Expected p2 to be unaffected, got %v", err)
+	}
+}
+
+func TestChatRateLimiter_AllowsAgainAfterWindow(t *testing.T) {
+	limiter := newChatRateLimiter(1, time.Second)
+	start := time.Now()
+
+	if !limiter.Allow("p1", start) {
+		t.Fatal("Expected first message to be allowed")
+	}
+	if limiter.Allow("p1", start.Add(500*time.Millisecond)) {
+		t.Error("Expected second message within the window to be refused")
+	}
+	if !limiter.Allow("p1", start.Add(1500*time.Millisecond)) {
+		t.Error("Expected a message after the window to be allowed")
+	}
+}
+
+func TestChatService_ModeratesMessages(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newChatSession()
+	moderation := NewModerationService(ModerationConfig{
+		Denylist:       []string{"shit"},
+		RejectPatterns: []string{`https?://`},
+	}, nil, nil)
+	chatService := NewChatService(gameSessionRepo, NewMockChatStore(), nil, WithChatModeration(moderation))
+
+	message, err := chatService.SendMessage(ctx, "s1", "p1", "this door is shit")
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if message.Text != "this door is s***" {
+		t.Errorf("Expected masked text, got %q", message.Text)
+	}
+
+	if _, err := chatService.SendMessage(ctx, "s1", "p1", "see http://spam.example"); !errors.Is(err, ErrInvalidChatMessage) {
+		t.Errorf("Expected rejected message, got %v", err)
+	}
+}
@@ -1,11 +1,15 @@
 package services
 
 import (
+	"context"
+	"dumdoors-backend/internal/middleware"
 	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/tracing"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,17 +22,44 @@ type DevvitIntegration interface {
 	StoreGameState(postID string, state *models.GameState) error
 	LoadGameState(postID string) (*models.GameState, error)
 	ValidateDevvitRequest(c *fiber.Ctx) error
+	UpdatePost(ctx context.Context, update *models.PostUpdate) error
 }
 
 // DevvitIntegrationImpl implements the DevvitIntegration interface
 type DevvitIntegrationImpl struct {
 	// In a real implementation, this would include Redis client, 
 	// authentication tokens, and other Devvit-specific configurations
+	
+	// Callback that updates a Reddit post when its game finishes
+	callbackURL    string
+	callbackSecret string
+	httpClient     *http.Client
+	retryClient    *middleware.RetryableHTTPClient
+	deadLetters    repositories.PostUpdateDeadLetterRepository
+	
+	// Secret the Devvit app signs its requests with
+	requestSecret []byte
+	allowUnsigned bool
 }
 
+// DevvitOption configures optional Devvit integration settings
+type DevvitOption func(*DevvitIntegrationImpl)
+
 // NewDevvitIntegration creates a new Devvit integration service
-func NewDevvitIntegration() DevvitIntegration {
-	return &DevvitIntegrationImpl{}
+func NewDevvitIntegration(opts ...DevvitOption) DevvitIntegration {
+	integration := &DevvitIntegrationImpl{
+		httpClient: &http.Client{
+			Timeout:   postCallbackTimeout,
+			Transport: tracing.NewTransport(nil),
+		},
+		retryClient: middleware.NewRetryableHTTPClient("devvit", middleware.DefaultRetryConfig()),
+	}
+	
+	for _, opt := range opts {
+		opt(integration)
+	}
+	
+	return integration
 }
 
 // GetCurrentUser extracts the current Reddit user from the request context
@@ -140,23 +171,10 @@ func (d *DevvitIntegrationImpl) LoadGameState(postID string) (*models.GameState,
 	}, nil
 }
 
-// ValidateDevvitRequest validates that the request comes from a valid Devvit context
+// ValidateDevvitRequest validates that the request was signed by the Devvit app, which vouches
+// for the Reddit user and post headers it carries
 func (d *DevvitIntegrationImpl) ValidateDevvitRequest(c *fiber.Ctx) error {
-	// In a real implementation, this would validate JWT tokens or other Devvit-specific auth
-	// For now, we'll do basic validation
-	
-	userAgent := c.Get("User-Agent")
-	if userAgent != "" && strings.Contains(strings.ToLower(userAgent), "devvit") {
-		return nil
-	}
-	
-	// Check for Devvit-specific headers
-	if c.Get("X-Reddit-Post-ID") != "" || c.Query("postId") != "" {
-		return nil
-	}
-	
-	// For development, allow requests without strict validation
-	return nil
+	return d.verifyRequestSignature(c)
 }
 
 // Helper function to serialize game state to JSON
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// postCallbackTimeout bounds each attempt to deliver a post update to Devvit
+const postCallbackTimeout = 10 * time.Second
+
+// WithPostCallback sends post updates to the Devvit app at url, authenticated with secret when
+// one is set. Updates that still fail after retrying are saved to deadLetters.
+func WithPostCallback(url, secret string, deadLetters repositories.PostUpdateDeadLetterRepository) DevvitOption {
+	return func(d *DevvitIntegrationImpl) {
+		d.callbackURL = url
+		d.callbackSecret = secret
+		d.deadLetters = deadLetters
+	}
+}
+
+// UpdatePost asks Devvit to update a Reddit post with its game's results. Network errors and
+// 5xx or 429 replies are retried with backoff; an update that never gets through is recorded as
+// a dead letter. Without a callback configured there is nothing to update.
+func (d *DevvitIntegrationImpl) UpdatePost(ctx context.Context, update *models.PostUpdate) error {
+	if d.callbackURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal post update: %w", err)
+	}
+
+	err = d.retryClient.Execute(ctx, func(ctx context.Context) error {
+		return d.sendPostUpdate(ctx, body)
+	})
+	if err == nil {
+		return nil
+	}
+
+	d.deadLetter(ctx, update, err)
+	return fmt.Errorf("failed to update post %s: %w", update.PostID, err)
+}
+
+// sendPostUpdate makes one attempt to deliver a post update, reporting failures worth retrying
+// as network or service-unavailable errors
+func (d *DevvitIntegrationImpl) sendPostUpdate(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.callbackSecret != "" {
+		req.Header.Set("Authorization", "Bearer "+d.callbackSecret)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return middleware.NetworkError(fmt.Sprintf("Devvit callback failed: %v", err)).WithCause(err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < http.StatusMultipleChoices:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		return middleware.ServiceUnavailableError(fmt.Sprintf("Devvit callback returned status %d", resp.StatusCode))
+	default:
+		return fmt.Errorf("Devvit callback rejected the update with status %d", resp.StatusCode)
+	}
+}
+
+// deadLetter saves a post update that could not be delivered so it can be replayed by hand. It
+// is saved even if the retries used up the caller's deadline.
+func (d *DevvitIntegrationImpl) deadLetter(ctx context.Context, update *models.PostUpdate, cause error) {
+	if d.deadLetters == nil {
+		fmt.Printf("Warning: dropped update for post %s: %v\n", update.PostID, cause)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), postCallbackTimeout)
+	defer cancel()
+
+	letter := &models.PostUpdateDeadLetter{
+		ID:       fmt.Sprintf("dead_%s", random.ID()),
+		Update:   *update,
+		Error:    cause.Error(),
+		FailedAt: time.Now(),
+	}
+	if err := d.deadLetters.Save(ctx, letter); err != nil {
+		fmt.Printf("Warning: failed to record undelivered update for post %s: %v\n", update.PostID, err)
+	}
+}
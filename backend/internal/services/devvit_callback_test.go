@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// memoryDeadLetterRepository is an in-memory PostUpdateDeadLetterRepository
+type memoryDeadLetterRepository struct {
+	mu      sync.Mutex
+	letters []*models.PostUpdateDeadLetter
+}
+
+func (r *memoryDeadLetterRepository) Save(ctx context.Context, letter *models.PostUpdateDeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.letters = append(r.letters, letter)
+	return nil
+}
+
+// postCallbackServer answers post updates with the given statuses in turn, repeating the last
+func postCallbackServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			t.Errorf("Expected the callback secret, got %q", r.Header.Get("Authorization"))
+		}
+		call := int(calls.Add(1)) - 1
+		if call >= len(statuses) {
+			call = len(statuses) - 1
+		}
+		w.WriteHeader(statuses[call])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestUpdatePost_RetriesTransientFailures(t *testing.T) {
+	server, calls := postCallbackServer(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+	deadLetters := &memoryDeadLetterRepository{}
+	devvit := NewDevvitIntegration(WithPostCallback(server.URL, "s3cret", deadLetters))
+
+	if err := devvit.UpdatePost(context.Background(), &models.PostUpdate{PostID: "t3_abc", SessionID: "s1"}); err != nil {
+		t.Fatalf("Expected the update to get through on the third attempt, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+	if len(deadLetters.letters) != 0 {
+		t.Errorf("Expected no dead letters for a delivered update, got %d", len(deadLetters.letters))
+	}
+}
+
+func TestUpdatePost_RecordsDeadLetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int32
+	}{
+		{"server errors are retried", http.StatusInternalServerError, 3},
+		{"rejections are not retried", http.StatusBadRequest, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := postCallbackServer(t, tt.status)
+			deadLetters := &memoryDeadLetterRepository{}
+			devvit := NewDevvitIntegration(WithPostCallback(server.URL, "s3cret", deadLetters))
+
+			if err := devvit.UpdatePost(context.Background(), &models.PostUpdate{PostID: "t3_abc", SessionID: "s1"}); err == nil {
+				t.Fatal("Expected the update to fail")
+			}
+			if calls.Load() != tt.attempts {
+				t.Errorf("Expected %d attempts, got %d", tt.attempts, calls.Load())
+			}
+			if len(deadLetters.letters) != 1 || deadLetters.letters[0].Update.PostID != "t3_abc" || deadLetters.letters[0].Error == "" {
+				t.Errorf("Expected the failed update to be dead-lettered, got %+v", deadLetters.letters)
+			}
+		})
+	}
+}
+
+func TestHandleGameCompletion_UpdatesOriginPost(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newResultCardSession(models.RevealAnonymous)
+	session.Status = models.GameStatusRevealing
+	session.Settings.Post = &models.PostContext{PostID: "t3_abc", SubredditName: "dumdoors"}
+	gameSessionRepo.sessions["s1"] = session
+	devvit := &recordingDevvitIntegration{DevvitIntegration: NewDevvitIntegration()}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+		WithPostUpdates(devvit),
+	)
+
+	if err := gameService.(*GameServiceImpl).handleGameCompletion(context.Background(), "s1", "p1"); err != nil {
+		t.Fatalf("handleGameCompletion failed: %v", err)
+	}
+
+	if len(devvit.updates) != 1 {
+		t.Fatalf("Expected one post update, got %d", len(devvit.updates))
+	}
+	update := devvit.updates[0]
+	if update.PostID != "t3_abc" || update.SubredditName != "dumdoors" || update.WinnerUsername != "alice" {
+		t.Errorf("Expected alice's win to be posted to t3_abc, got %+v", update)
+	}
+	if update.WinningResponse == nil || update.WinningResponse.ResponseID != "r3" || update.WinningResponse.Content != "Climb the wall" {
+		t.Errorf("Expected alice's best answer as the winning response, got %+v", update.WinningResponse)
+	}
+}
+
+// recordingDevvitIntegration records post updates instead of sending them
+type recordingDevvitIntegration struct {
+	DevvitIntegration
+	updates []*models.PostUpdate
+}
+
+func (d *recordingDevvitIntegration) UpdatePost(ctx context.Context, update *models.PostUpdate) error {
+	d.updates = append(d.updates, update)
+	return nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Headers the Devvit app signs its server-side requests with
+const (
+	DevvitSignatureHeader = "X-Devvit-Signature"
+	DevvitTimestampHeader = "X-Devvit-Timestamp"
+)
+
+// devvitSignatureMaxAge bounds how long a signed request can be replayed
+const devvitSignatureMaxAge = 5 * time.Minute
+
+// Devvit request validation errors
+var (
+	ErrUnsignedDevvitRequest  = errors.New("devvit request is not signed")
+	ErrInvalidDevvitSignature = errors.New("invalid devvit request signature")
+	ErrStaleDevvitRequest     = errors.New("devvit request signature has expired")
+)
+
+// WithRequestSigning only accepts requests the Devvit app signed with the shared secret, so the
+// Reddit identity headers it forwards can be trusted
+func WithRequestSigning(secret string) DevvitOption {
+	return func(d *DevvitIntegrationImpl) {
+		d.requestSecret = []byte(secret)
+	}
+}
+
+// WithUnsignedRequests accepts requests without a signature when no signing secret is set. Any
+// caller can then claim any Reddit identity, so it is only meant for local development.
+func WithUnsignedRequests() DevvitOption {
+	return func(d *DevvitIntegrationImpl) {
+		d.allowUnsigned = true
+	}
+}
+
+// SignDevvitRequest returns the signature the Devvit app sends for a request made at timestamp
+// (Unix seconds) on behalf of a Reddit user, optionally from a post
+func SignDevvitRequest(secret, timestamp, userID, username, postID string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{timestamp, userID, username, postID}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyRequestSignature checks that a request carries a fresh signature over the identity
+// headers it was sent with
+func (d *DevvitIntegrationImpl) verifyRequestSignature(c *fiber.Ctx) error {
+	if len(d.requestSecret) == 0 {
+		if d.allowUnsigned {
+			return nil
+		}
+		return ErrUnsignedDevvitRequest
+	}
+
+	signature, timestamp := c.Get(DevvitSignatureHeader), c.Get(DevvitTimestampHeader)
+	if signature == "" || timestamp == "" {
+		return ErrUnsignedDevvitRequest
+	}
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidDevvitSignature
+	}
+	if age := time.Since(time.Unix(signedAt, 0)); age > devvitSignatureMaxAge || age < -devvitSignatureMaxAge {
+		return ErrStaleDevvitRequest
+	}
+
+	expected := SignDevvitRequest(string(d.requestSecret), timestamp, c.Get("X-Reddit-User-ID"), c.Get("X-Reddit-Username"), c.Get("X-Reddit-Post-ID"))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidDevvitSignature
+	}
+	return nil
+}
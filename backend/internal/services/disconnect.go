@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DisconnectGracePeriod is how long a disconnected player has to reconnect before rounds stop
+// waiting for them
+const DisconnectGracePeriod = 5 * time.Minute
+
+// EventPlayerTimedOut announces a player who stayed disconnected past the grace period
+const EventPlayerTimedOut = "player-timed-out"
+
+// disconnectGracePrefix starts the scheduler key of a player's disconnect grace period
+const disconnectGracePrefix = "disconnect-grace:"
+
+// disconnectGraceKey is the scheduler key for the end of a player's disconnect grace period
+func disconnectGraceKey(playerID string) string {
+	return disconnectGracePrefix + playerID
+}
+
+// disconnectGracePlayer returns the player whose grace period a scheduler key ends, if it ends one
+func disconnectGracePlayer(key string) (string, bool) {
+	if !strings.HasPrefix(key, disconnectGracePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, disconnectGracePrefix), true
+}
+
+// WithDisconnectGrace sets how long disconnected players have to reconnect; non-positive values are ignored
+func WithDisconnectGrace(grace time.Duration) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		if grace > 0 {
+			s.disconnectGrace = grace
+		}
+	}
+}
+
+// handlePresence records a player's connection dropping or coming back
+func (s *GameServiceImpl) handlePresence(ctx context.Context, sessionID, playerID string, connected bool) {
+	var err error
+	if connected {
+		err = s.playerReconnected(ctx, sessionID, playerID)
+	} else {
+		err = s.playerDisconnected(ctx, sessionID, playerID)
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to record presence of player %s: %v\n", playerID, err)
+	}
+}
+
+// playerDisconnected stores when a player in a running game lost their connection and schedules
+// the end of their grace period, so it survives restarts and fires on one instance
+func (s *GameServiceImpl) playerDisconnected(ctx context.Context, sessionID, playerID string) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.playerDisconnected", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil || s.stateMachine.Require(session, OpForfeitPlayer) != nil {
+		return nil // Lobbies and finished games don't wait on anyone
+	}
+
+	player := sessionPlayer(session, playerID)
+	if player == nil || !player.IsActive || player.DisconnectedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	player.DisconnectedAt = &now
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to save disconnect: %w", err)
+	}
+	if err := s.scheduler.Schedule(ctx, sessionID, disconnectGraceKey(playerID), now.Add(s.disconnectGrace)); err != nil {
+		return fmt.Errorf("failed to schedule disconnect grace: %w", err)
+	}
+	return nil
+}
+
+// playerReconnected clears a player's disconnect, so rounds wait for them again from the next
+// door on
+func (s *GameServiceImpl) playerReconnected(ctx context.Context, sessionID, playerID string) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.playerReconnected", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil
+	}
+
+	player := sessionPlayer(session, playerID)
+	if player == nil || (player.DisconnectedAt == nil && !player.TimedOut) {
+		return nil
+	}
+	player.DisconnectedAt = nil
+	player.TimedOut = false
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to save reconnect: %w", err)
+	}
+	if err := s.scheduler.Cancel(ctx, sessionID, disconnectGraceKey(playerID)); err != nil {
+		return fmt.Errorf("failed to cancel disconnect grace: %w", err)
+	}
+	return nil
+}
+
+// handleDisconnectGrace fires when a disconnected player's grace period is over. Rounds stop
+// waiting for them until they reconnect, the door they are holding up is forfeited with a zero
+// score, and the round closes at once if everyone else has answered.
+func (s *GameServiceImpl) handleDisconnectGrace(ctx context.Context, sessionID, playerID string) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.handleDisconnectGrace", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get session for disconnect grace: %v\n", err)
+		return
+	}
+	if session == nil || s.stateMachine.Require(session, OpForfeitPlayer) != nil {
+		return
+	}
+
+	player := sessionPlayer(session, playerID)
+	if player == nil || !player.IsActive || player.TimedOut || player.DisconnectedAt == nil {
+		return // Reconnected, left or already timed out
+	}
+	player.TimedOut = true
+
+	// Only an open round has a door to forfeit
+	var forfeit *models.PlayerResponse
+	door := session.DoorForPlayer(playerID)
+	if session.Status == models.GameStatusActive && door != nil && !hasRespondedToDoor(session, playerID, door.DoorID) {
+		forfeit = &models.PlayerResponse{
+			ResponseID:  fmt.Sprintf("resp_%s_%s", random.ID(), playerID),
+			DoorID:      door.DoorID,
+			PlayerID:    playerID,
+			SubmittedAt: time.Now(),
+			Forfeited:   true,
+		}
+		player.Responses = append(player.Responses, *forfeit)
+	}
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		fmt.Printf("Warning: failed to save disconnect forfeit: %v\n", err)
+		return
+	}
+
+	if forfeit != nil {
+		s.recordEvent(ctx, &models.SessionEvent{
+			SessionID: sessionID,
+			Type:      models.SessionEventResponseMissed,
+			PlayerID:  playerID,
+			DoorID:    forfeit.DoorID,
+			Deadline:  session.RoundDeadline,
+		})
+		// Peer-vote paths move once voting closes
+		if !session.Settings.PeerVoting() {
+			if err := s.updatePlayerPath(ctx, playerID, pathScore(session, playerID, forfeit.DoorID, 0), forfeit.DoorID, s.shortcutThreshold(session)); err != nil {
+				fmt.Printf("Warning: failed to update player path: %v\n", err)
+			}
+		}
+	}
+
+	if s.wsManager != nil {
+		data := systemMessage(map[string]interface{}{
+			"playerId": playerID,
+		}, session.Locale, i18n.MsgPlayerTimedOut, player.Username)
+		if forfeit != nil {
+			data["doorId"] = forfeit.DoorID
+			data["responseId"] = forfeit.ResponseID
+		}
+		event := WebSocketEvent{
+			Type:      EventPlayerTimedOut,
+			SessionID: sessionID,
+			PlayerID:  playerID,
+			Data:      data,
+			Timestamp: time.Now(),
+		}
+		if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+			fmt.Printf("Warning: failed to broadcast player time out: %v\n", err)
+		}
+	}
+
+	// Don't keep the others waiting until the round's own deadline
+	unlock()
+	switch {
+	case session.Status == models.GameStatusActive && s.checkAllPlayersResponded(session):
+		s.closeRound(ctx, sessionID, session)
+	case session.Status == models.GameStatusScoring && session.VoteDeadline != nil && allVotesIn(session):
+		s.closeVoting(ctx, sessionID, session)
+	}
+}
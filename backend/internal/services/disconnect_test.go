@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestDisconnectGrace_ForfeitsDoorAndClosesRound(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(time.Minute))
+	session.Players[0].Responses = []models.PlayerResponse{{ResponseID: "r1", DoorID: "door-1", PlayerID: "p1", AIScore: 70}}
+	gameSessionRepo.sessions["s1"] = session
+	wsManager := &broadcastRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), wsManager, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	).(*GameServiceImpl)
+	gameService.scheduler.Schedule(ctx, "s1", "door-1", *session.RoundDeadline)
+
+	gameService.handlePresence(ctx, "s1", "p2", false)
+	if session.Players[1].DisconnectedAt == nil || !deadlineScheduled(gameService, disconnectGraceKey("p2")) {
+		t.Fatal("Expected the disconnect to be stored with its grace period scheduled")
+	}
+	if gameService.checkAllPlayersResponded(session) {
+		t.Fatal("Expected the round to wait for p2 during the grace period")
+	}
+
+	gameService.handleDisconnectGrace(ctx, "s1", "p2")
+	p2 := session.Players[1]
+	if !p2.TimedOut || len(p2.Responses) != 1 || !p2.Responses[0].Forfeited || p2.Responses[0].AIScore != 0 {
+		t.Fatalf("Expected p2 to time out with a zero-score forfeit, got %+v", p2)
+	}
+	if deadlineScheduled(gameService, "door-1") {
+		t.Error("Expected the round to close without waiting for its deadline")
+	}
+	if len(wsManager.broadcasts) != 1 || wsManager.broadcasts[0].Type != EventPlayerTimedOut {
+		t.Errorf("Expected the time out to be announced, got %v", wsManager.broadcasts)
+	}
+
+	// Back in time for the next door
+	gameService.handlePresence(ctx, "s1", "p2", true)
+	if session.Players[1].TimedOut || session.Players[1].DisconnectedAt != nil {
+		t.Errorf("Expected the reconnect to clear the disconnect, got %+v", session.Players[1])
+	}
+}
+
+func TestDisconnectGrace_ReconnectInTimeKeepsPlayer(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(time.Minute))
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	).(*GameServiceImpl)
+
+	gameService.handlePresence(ctx, "s1", "p2", false)
+	gameService.handlePresence(ctx, "s1", "p2", true)
+	if deadlineScheduled(gameService, disconnectGraceKey("p2")) {
+		t.Error("Expected the grace period to be cancelled on reconnect")
+	}
+
+	// A grace period that fires anyway, e.g. from another instance, finds the player back
+	gameService.handleDisconnectGrace(ctx, "s1", "p2")
+	if session.Players[1].TimedOut || len(session.Players[1].Responses) != 0 {
+		t.Errorf("Expected the reconnected player to keep playing, got %+v", session.Players[1])
+	}
+}
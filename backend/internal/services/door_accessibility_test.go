@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/accessibility"
+	"dumdoors-backend/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateDoor_PopulatesAccessibility(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil).(*GameServiceImpl)
+
+	door, err := gameService.generateDoor(context.Background(), "workplace", 3, "")
+	if err != nil {
+		t.Fatalf("generateDoor failed: %v", err)
+	}
+
+	if door.Accessibility == nil {
+		t.Fatal("Expected accessibility metadata on a generated door")
+	}
+	if !strings.HasPrefix(door.Accessibility.AltText, "A hard workplace door: ") {
+		t.Errorf("Unexpected alt text: %q", door.Accessibility.AltText)
+	}
+	if door.Accessibility.ReadingLevel <= 0 {
+		t.Errorf("Expected a reading level for an English door, got %v", door.Accessibility.ReadingLevel)
+	}
+}
+
+func TestDescribe_FlagsContentWarnings(t *testing.T) {
+	door := &models.Door{
+		Content:    "You are trapped in a hospital basement full of spiders. What do you do?",
+		Theme:      "survival",
+		Difficulty: 1,
+		Locale:     "es",
+	}
+
+	accessibility.Describe(door)
+
+	expected := []string{"medical", "phobia"}
+	if !reflect.DeepEqual(door.Accessibility.ContentWarnings, expected) {
+		t.Errorf("Expected warnings %v, got %v", expected, door.Accessibility.ContentWarnings)
+	}
+	if door.Accessibility.AltText != "An easy survival door: You are trapped in a hospital basement full of spiders." {
+		t.Errorf("Unexpected alt text: %q", door.Accessibility.AltText)
+	}
+	if door.Accessibility.ReadingLevel != 0 {
+		t.Errorf("Expected no reading level for a non-English door, got %v", door.Accessibility.ReadingLevel)
+	}
+}
+
+func TestDescribe_KeepsExistingMetadata(t *testing.T) {
+	existing := &models.DoorAccessibility{AltText: "curated", ContentWarnings: []string{}}
+	door := &models.Door{Content: "A spider appears.", Accessibility: existing}
+
+	accessibility.Describe(door)
+
+	if door.Accessibility != existing {
+		t.Error("Expected curated accessibility metadata to be left untouched")
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/accessibility"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Door listing page sizes
+const (
+	DefaultDoorPageSize = 20
+	MaxDoorPageSize     = 100
+)
+
+// Door administration errors
+var (
+	ErrDoorNotFound          = errors.New("door not found")
+	ErrInvalidDoorTransition = errors.New("invalid door status transition")
+)
+
+// DoorUpdate holds the door fields an admin may change; nil fields are left as they are
+type DoorUpdate struct {
+	Content               *string           `json:"content,omitempty"`
+	Theme                 *string           `json:"theme,omitempty"`
+	Difficulty            *int              `json:"difficulty,omitempty"`
+	Locale                *string           `json:"locale,omitempty"`
+	Translations          map[string]string `json:"translations,omitempty"` // sets each locale's translation; an empty translation removes it
+	ExpectedSolutionTypes []string          `json:"expectedSolutionTypes,omitempty"`
+}
+
+// DoorAdminService curates stored doors through a draft, approved, published workflow
+type DoorAdminService interface {
+	CreateDoor(ctx context.Context, door *models.Door) (*models.Door, error)
+	GetDoor(ctx context.Context, doorID string) (*models.Door, error)
+	UpdateDoor(ctx context.Context, doorID string, update DoorUpdate) (*models.Door, error)
+	SetDoorStatus(ctx context.Context, doorID string, status models.DoorStatus) (*models.Door, error)
+	DeleteDoor(ctx context.Context, doorID string) error
+	ListDoors(ctx context.Context, filter models.DoorFilter) (*models.DoorPage, error)
+	ImportDoors(ctx context.Context, data io.Reader, format DoorImportFormat, dryRun bool) (*DoorImportResult, error)
+}
+
+// DoorAdminServiceImpl implements the DoorAdminService interface
+type DoorAdminServiceImpl struct {
+	doorRepo            repositories.DoorRepository
+	similarityThreshold float64
+}
+
+// DoorAdminServiceOption configures optional behaviour of the door administration service
+type DoorAdminServiceOption func(*DoorAdminServiceImpl)
+
+// NewDoorAdminService creates a new door administration service
+func NewDoorAdminService(doorRepo repositories.DoorRepository, opts ...DoorAdminServiceOption) DoorAdminService {
+	service := &DoorAdminServiceImpl{
+		doorRepo:            doorRepo,
+		similarityThreshold: DefaultDoorSimilarityThreshold,
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
+
+// CreateDoor stores a curated door as a draft awaiting approval
+func (s *DoorAdminServiceImpl) CreateDoor(ctx context.Context, door *models.Door) (*models.Door, error) {
+	if err := prepareDraftDoor(door); err != nil {
+		return nil, err
+	}
+
+	if err := s.doorRepo.Create(ctx, door); err != nil {
+		return nil, fmt.Errorf("failed to create door: %w", err)
+	}
+	return door, nil
+}
+
+// GetDoor retrieves a door in any status
+func (s *DoorAdminServiceImpl) GetDoor(ctx context.Context, doorID string) (*models.Door, error) {
+	door, err := s.doorRepo.GetByID(ctx, doorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get door: %w", err)
+	}
+	if door == nil {
+		return nil, ErrDoorNotFound
+	}
+	return door, nil
+}
+
+// UpdateDoor edits a door. Changing what players see sends the door back to draft for review.
+func (s *DoorAdminServiceImpl) UpdateDoor(ctx context.Context, doorID string, update DoorUpdate) (*models.Door, error) {
+	door, err := s.GetDoor(ctx, doorID)
+	if err != nil {
+		return nil, err
+	}
+
+	contentChanged := false
+	if update.Content != nil && *update.Content != door.Content {
+		door.Content = *update.Content
+		contentChanged = true
+	}
+	if update.Theme != nil {
+		door.Theme = *update.Theme
+	}
+	if update.Difficulty != nil {
+		door.Difficulty = *update.Difficulty
+	}
+	if update.Locale != nil && i18n.Normalize(*update.Locale) != i18n.Normalize(door.Locale) {
+		door.Locale = i18n.Normalize(*update.Locale)
+		contentChanged = true
+	}
+	if update.ExpectedSolutionTypes != nil {
+		door.ExpectedSolutionTypes = update.ExpectedSolutionTypes
+	}
+	if len(update.Translations) > 0 {
+		if err := setTranslations(door, update.Translations); err != nil {
+			return nil, err
+		}
+		contentChanged = true
+	}
+
+	if err := validateDoor(door); err != nil {
+		return nil, err
+	}
+
+	if contentChanged {
+		door.Status = models.DoorStatusDraft
+		door.Accessibility = nil
+		accessibility.Describe(door)
+	}
+
+	return door, s.save(ctx, door)
+}
+
+// SetDoorStatus moves a door through the moderation workflow
+func (s *DoorAdminServiceImpl) SetDoorStatus(ctx context.Context, doorID string, status models.DoorStatus) (*models.Door, error) {
+	door, err := s.GetDoor(ctx, doorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !models.CanTransitionDoor(door.Status, status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidDoorTransition, door.Status, status)
+	}
+
+	door.Status = status
+	return door, s.save(ctx, door)
+}
+
+// DeleteDoor removes a door
+func (s *DoorAdminServiceImpl) DeleteDoor(ctx context.Context, doorID string) error {
+	if _, err := s.GetDoor(ctx, doorID); err != nil {
+		return err
+	}
+	if err := s.doorRepo.Delete(ctx, doorID); err != nil {
+		return fmt.Errorf("failed to delete door: %w", err)
+	}
+	return nil
+}
+
+// ListDoors returns one page of doors, newest first
+func (s *DoorAdminServiceImpl) ListDoors(ctx context.Context, filter models.DoorFilter) (*models.DoorPage, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = DefaultDoorPageSize
+	}
+	if filter.PageSize > MaxDoorPageSize {
+		filter.PageSize = MaxDoorPageSize
+	}
+
+	doors, total, err := s.doorRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list doors: %w", err)
+	}
+
+	return &models.DoorPage{
+		Doors:    doors,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+// save stamps and persists an edited door
+func (s *DoorAdminServiceImpl) save(ctx context.Context, door *models.Door) error {
+	now := time.Now()
+	door.UpdatedAt = &now
+
+	if err := s.doorRepo.Update(ctx, door); err != nil {
+		return fmt.Errorf("failed to update door: %w", err)
+	}
+	return nil
+}
+
+// prepareDraftDoor gives a new door its ID and draft status and checks its fields
+func prepareDraftDoor(door *models.Door) error {
+	door.DoorID = fmt.Sprintf("door_%s", random.ID())
+	door.Status = models.DoorStatusDraft
+	door.Locale = i18n.Normalize(door.Locale)
+	door.Accessibility = nil
+
+	translations := door.Translations
+	door.Translations = nil
+	if err := setTranslations(door, translations); err != nil {
+		return err
+	}
+	return validateDoor(door)
+}
+
+// setTranslations sets or, for empty content, removes the door's translations. Translations
+// must be in a supported locale other than the door's own.
+func setTranslations(door *models.Door, translations map[string]string) error {
+	for locale, content := range translations {
+		if !i18n.Supported(locale) {
+			return fmt.Errorf("unsupported translation locale %q", locale)
+		}
+		locale = i18n.Normalize(locale)
+		if locale == door.Locale {
+			return fmt.Errorf("door is already written in %s", i18n.LanguageName(locale))
+		}
+
+		content = strings.TrimSpace(content)
+		if content == "" {
+			delete(door.Translations, locale)
+			continue
+		}
+		if door.Translations == nil {
+			door.Translations = make(map[string]string)
+		}
+		door.Translations[locale] = content
+	}
+	if len(door.Translations) == 0 {
+		door.Translations = nil
+	}
+	return nil
+}
+
+// validateDoor checks the fields every curated door needs
+func validateDoor(door *models.Door) error {
+	door.Content = strings.TrimSpace(door.Content)
+	door.Theme = strings.TrimSpace(door.Theme)
+
+	switch {
+	case door.Content == "":
+		return fmt.Errorf("door content is required")
+	case door.Theme == "":
+		return fmt.Errorf("door theme is required")
+	case door.Difficulty < 1 || door.Difficulty > 3:
+		return fmt.Errorf("door difficulty must be between 1 and 3")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestDoorAdmin_CreatedDoorsAwaitApproval(t *testing.T) {
+	ctx := context.Background()
+	doorRepo := &MockDoorRepository{}
+	admin := NewDoorAdminService(doorRepo)
+	gameService := NewGameService(NewMockGameSessionRepository(), doorRepo, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	door, err := admin.CreateDoor(ctx, &models.Door{Content: "  The elevator only goes sideways.  ", Theme: "general", Difficulty: 1})
+	if err != nil {
+		t.Fatalf("CreateDoor failed: %v", err)
+	}
+	if door.Status != models.DoorStatusDraft || door.Content != "The elevator only goes sideways." {
+		t.Errorf("Expected a trimmed draft door, got %q in status %q", door.Content, door.Status)
+	}
+
+	served, err := gameService.GetNextDoor(ctx, "p1", 50, "en")
+	if err != nil {
+		t.Fatalf("GetNextDoor failed: %v", err)
+	}
+	if served.DoorID == door.DoorID {
+		t.Fatal("Expected a draft door not to be served")
+	}
+
+	if _, err := admin.SetDoorStatus(ctx, door.DoorID, models.DoorStatusApproved); err != nil {
+		t.Fatalf("SetDoorStatus failed: %v", err)
+	}
+	doorRepo.doors = []*models.Door{door}
+
+	served, err = gameService.GetNextDoor(ctx, "p1", 50, "en")
+	if err != nil {
+		t.Fatalf("GetNextDoor failed: %v", err)
+	}
+	if served.DoorID != door.DoorID {
+		t.Errorf("Expected the approved door to be served, got %s", served.DoorID)
+	}
+}
+
+func TestDoorAdmin_StatusWorkflow(t *testing.T) {
+	ctx := context.Background()
+	admin := NewDoorAdminService(&MockDoorRepository{})
+
+	door, err := admin.CreateDoor(ctx, &models.Door{Content: "A door.", Theme: "general", Difficulty: 2})
+	if err != nil {
+		t.Fatalf("CreateDoor failed: %v", err)
+	}
+
+	if _, err := admin.SetDoorStatus(ctx, door.DoorID, models.DoorStatusPublished); !errors.Is(err, ErrInvalidDoorTransition) {
+		t.Errorf("Expected publishing a draft to be rejected, got %v", err)
+	}
+
+	for _, status := range []models.DoorStatus{models.DoorStatusApproved, models.DoorStatusPublished} {
+		if door, err = admin.SetDoorStatus(ctx, door.DoorID, status); err != nil {
+			t.Fatalf("SetDoorStatus(%s) failed: %v", status, err)
+		}
+	}
+
+	content := "A different door."
+	door, err = admin.UpdateDoor(ctx, door.DoorID, DoorUpdate{Content: &content})
+	if err != nil {
+		t.Fatalf("UpdateDoor failed: %v", err)
+	}
+	if door.Status != models.DoorStatusDraft || door.UpdatedAt == nil {
+		t.Errorf("Expected edited content to need re-approval, got status %q", door.Status)
+	}
+
+	difficulty := 5
+	if _, err := admin.UpdateDoor(ctx, door.DoorID, DoorUpdate{Difficulty: &difficulty}); err == nil {
+		t.Error("Expected an out-of-range difficulty to be rejected")
+	}
+}
+
+func TestDoorAdmin_ListDoorsPaginates(t *testing.T) {
+	ctx := context.Background()
+	admin := NewDoorAdminService(&MockDoorRepository{})
+
+	for i := 0; i < 3; i++ {
+		if _, err := admin.CreateDoor(ctx, &models.Door{Content: "A door.", Theme: "social", Difficulty: 1}); err != nil {
+			t.Fatalf("CreateDoor failed: %v", err)
+		}
+	}
+
+	page, err := admin.ListDoors(ctx, models.DoorFilter{Theme: "social", Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListDoors failed: %v", err)
+	}
+	if page.Total != 3 || len(page.Doors) != 1 {
+		t.Errorf("Expected 1 of 3 doors on page 2, got %d of %d", len(page.Doors), page.Total)
+	}
+
+	page, err = admin.ListDoors(ctx, models.DoorFilter{PageSize: 1000})
+	if err != nil {
+		t.Fatalf("ListDoors failed: %v", err)
+	}
+	if page.Page != 1 || page.PageSize != MaxDoorPageSize {
+		t.Errorf("Expected defaults to clamp to page 1 of %d, got page %d of %d", MaxDoorPageSize, page.Page, page.PageSize)
+	}
+}
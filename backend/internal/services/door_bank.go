@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Door bank defaults
+const (
+	DefaultDoorBankSize           = 5
+	DefaultDoorBankRefillInterval = time.Minute
+)
+
+// doorBankRefillQueue bounds how many drained pools can wait for a refill between refill runs
+const doorBankRefillQueue = 64
+
+// DoorBankService keeps pools of pre-generated doors for every enabled theme, difficulty and
+// locale, so players moving to a new door don't wait for one to be generated
+type DoorBankService interface {
+	Start(ctx context.Context)
+	Take(ctx context.Context, pool models.DoorPool) *models.Door
+	Refill(ctx context.Context) (int, error)
+}
+
+// DoorBankServiceImpl implements the DoorBankService interface
+type DoorBankServiceImpl struct {
+	bank      repositories.DoorBank
+	generator AIClient
+	themes    ThemeService
+	dedup     DoorDeduplicator
+	locales   []string
+	size      int
+	interval  time.Duration
+	drained   chan models.DoorPool
+	collector *monitoring.MetricsCollector
+	served    *monitoring.Counter
+	empty     *monitoring.Counter
+}
+
+// DoorBankOption configures optional behaviour of the door bank
+type DoorBankOption func(*DoorBankServiceImpl)
+
+// WithDoorBankLocales banks doors in each of the locales as well as English
+func WithDoorBankLocales(locales ...string) DoorBankOption {
+	return func(b *DoorBankServiceImpl) {
+		for _, locale := range locales {
+			b.addLocale(locale)
+		}
+	}
+}
+
+// WithDoorBankDeduplicator keeps doors that nearly repeat a stored door out of the bank
+func WithDoorBankDeduplicator(dedup DoorDeduplicator) DoorBankOption {
+	return func(b *DoorBankServiceImpl) {
+		b.dedup = dedup
+	}
+}
+
+// NewDoorBankService creates a door bank that keeps size doors in each pool, topping every pool
+// up each interval and drained pools as soon as a door is taken. Doors are generated with the
+// generator, which should not cache doors, or every pool would fill with the same one.
+func NewDoorBankService(bank repositories.DoorBank, generator AIClient, themes ThemeService, size int, interval time.Duration, opts ...DoorBankOption) DoorBankService {
+	if size <= 0 {
+		size = DefaultDoorBankSize
+	}
+	if interval <= 0 {
+		interval = DefaultDoorBankRefillInterval
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	service := &DoorBankServiceImpl{
+		bank:      bank,
+		generator: generator,
+		themes:    themes,
+		size:      size,
+		interval:  interval,
+		drained:   make(chan models.DoorPool, doorBankRefillQueue),
+		collector: collector,
+		served:    collector.NewCounter("door_bank_served_total", "Doors served from the door bank", nil),
+		empty:     collector.NewCounter("door_bank_empty_total", "Doors requested from an empty door bank pool", nil),
+	}
+	service.addLocale(i18n.DefaultLocale)
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
+
+// Start fills every pool immediately, then tops them up each interval and refills drained pools
+// as they are reported
+func (b *DoorBankServiceImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	b.refillAndLog(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.refillAndLog(ctx)
+		case pool := <-b.drained:
+			if _, err := b.refillPool(ctx, pool); err != nil {
+				fmt.Printf("Warning: failed to refill door bank pool %s: %v\n", pool, err)
+			}
+		}
+	}
+}
+
+// Take removes a door from the pool and asks for the pool to be refilled. It returns nil when
+// the pool is empty or the bank can't be reached, so callers fall back to generating a door.
+func (b *DoorBankServiceImpl) Take(ctx context.Context, pool models.DoorPool) *models.Door {
+	pool.Locale = i18n.Normalize(pool.Locale)
+
+	door, err := b.bank.Pop(ctx, pool)
+	if err != nil {
+		fmt.Printf("Warning: failed to take door from bank pool %s: %v\n", pool, err)
+	}
+	if door == nil {
+		b.empty.Inc()
+	} else {
+		b.served.Inc()
+		b.depth(pool).Dec()
+	}
+
+	select {
+	case b.drained <- pool:
+	default: // a refill is already due; the next scheduled one catches up
+	}
+	return door
+}
+
+// Refill tops up every pool and returns how many doors were added. Pools that fail are
+// skipped and retried on the next run.
+func (b *DoorBankServiceImpl) Refill(ctx context.Context) (int, error) {
+	pools, err := b.pools(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	var firstErr error
+	for _, pool := range pools {
+		n, err := b.refillPool(ctx, pool)
+		added += n
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to refill pool %s: %w", pool, err)
+		}
+	}
+	return added, firstErr
+}
+
+// refillAndLog refills every pool and logs failures without stopping the worker
+func (b *DoorBankServiceImpl) refillAndLog(ctx context.Context) {
+	if _, err := b.Refill(ctx); err != nil {
+		fmt.Printf("Warning: door bank refill failed: %v\n", err)
+	}
+}
+
+// refillPool generates doors until the pool holds size of them. It stops early when the
+// generator repeats a door already banked or stored, as it will keep doing so for a while.
+func (b *DoorBankServiceImpl) refillPool(ctx context.Context, pool models.DoorPool) (int, error) {
+	banked, err := b.bank.List(ctx, pool)
+	if err != nil {
+		return 0, err
+	}
+	signatures := make([][]uint64, 0, b.size)
+	for _, door := range banked {
+		signatures = append(signatures, doorSignature(door.Content))
+	}
+
+	added := 0
+	defer func() { b.depth(pool).Set(float64(len(banked) + added)) }()
+
+	for len(banked)+added < b.size {
+		door, err := b.generator.GenerateDoor(ctx, pool.Theme, pool.Difficulty, pool.Locale)
+		if err != nil {
+			return added, fmt.Errorf("failed to generate door: %w", err)
+		}
+		if door == nil || i18n.Normalize(door.Locale) != pool.Locale {
+			return added, nil // the generator can't write doors in this locale right now
+		}
+
+		signature := doorSignature(door.Content)
+		for _, other := range signatures {
+			if signatureSimilarity(signature, other) >= DefaultDoorSimilarityThreshold {
+				return added, nil
+			}
+		}
+		if b.dedup != nil {
+			if existing, _, err := b.dedup.FindDuplicate(ctx, door); err == nil && existing != nil {
+				return added, nil
+			}
+		}
+
+		if door.DoorID == "" {
+			door.DoorID = fmt.Sprintf("door_%s", random.ID())
+		}
+		door.Theme = pool.Theme
+		door.Difficulty = pool.Difficulty
+		door.Locale = pool.Locale
+		door.Status = models.DoorStatusApproved
+		if door.CreatedAt.IsZero() {
+			door.CreatedAt = time.Now()
+		}
+
+		if err := b.bank.Push(ctx, pool, door); err != nil {
+			return added, err
+		}
+		signatures = append(signatures, signature)
+		added++
+	}
+	return added, nil
+}
+
+// pools lists a pool for every enabled theme, difficulty and banked locale
+func (b *DoorBankServiceImpl) pools(ctx context.Context) ([]models.DoorPool, error) {
+	themes, err := b.themes.ListThemes(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]models.DoorPool, 0, len(themes)*3*len(b.locales))
+	for _, theme := range themes {
+		for difficulty := 1; difficulty <= 3; difficulty++ {
+			for _, locale := range b.locales {
+				pools = append(pools, models.DoorPool{Theme: theme.ThemeID, Difficulty: difficulty, Locale: locale})
+			}
+		}
+	}
+	return pools, nil
+}
+
+// addLocale banks doors in the locale, once
+func (b *DoorBankServiceImpl) addLocale(locale string) {
+	locale = i18n.Normalize(locale)
+	for _, existing := range b.locales {
+		if existing == locale {
+			return
+		}
+	}
+	b.locales = append(b.locales, locale)
+}
+
+// depth is the gauge of how many doors wait in the pool
+func (b *DoorBankServiceImpl) depth(pool models.DoorPool) *monitoring.Gauge {
+	return b.collector.NewGauge("door_bank_depth", "Pre-generated doors waiting in a door bank pool", map[string]string{
+		"theme":      pool.Theme,
+		"difficulty": strconv.Itoa(pool.Difficulty),
+		"locale":     pool.Locale,
+	})
+}
+
+// WithDoorBank serves pre-generated doors from the bank before generating them on demand
+func WithDoorBank(bank DoorBankService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.doorBank = bank
+	}
+}
+
+// bankedDoor takes a door of the theme and difficulty from the bank and stores it for reuse,
+// or returns nil when the bank has none
+func (s *GameServiceImpl) bankedDoor(ctx context.Context, theme string, difficulty int, locale string) *models.Door {
+	if s.doorBank == nil {
+		return nil
+	}
+
+	door := s.doorBank.Take(ctx, models.DoorPool{Theme: theme, Difficulty: difficulty, Locale: locale})
+	if door == nil {
+		return nil
+	}
+	return s.saveGeneratedDoor(ctx, door)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"testing"
+	"time"
+)
+
+// memoryDoorBank keeps door bank pools in memory
+type memoryDoorBank struct {
+	pools map[models.DoorPool][]*models.Door
+}
+
+func newMemoryDoorBank() *memoryDoorBank {
+	return &memoryDoorBank{pools: make(map[models.DoorPool][]*models.Door)}
+}
+
+func (b *memoryDoorBank) Push(ctx context.Context, pool models.DoorPool, door *models.Door) error {
+	b.pools[pool] = append(b.pools[pool], door)
+	return nil
+}
+
+func (b *memoryDoorBank) Pop(ctx context.Context, pool models.DoorPool) (*models.Door, error) {
+	doors := b.pools[pool]
+	if len(doors) == 0 {
+		return nil, nil
+	}
+	b.pools[pool] = doors[1:]
+	return doors[0], nil
+}
+
+func (b *memoryDoorBank) List(ctx context.Context, pool models.DoorPool) ([]*models.Door, error) {
+	return b.pools[pool], nil
+}
+
+// scriptedDoorGenerator generates its doors' contents in order, repeating the last one once
+// it runs out
+type scriptedDoorGenerator struct {
+	MockAIClient
+	contents []string
+	calls    int
+}
+
+func (g *scriptedDoorGenerator) GenerateDoor(ctx context.Context, theme string, difficulty int, locale string) (*models.Door, error) {
+	content := g.contents[min(g.calls, len(g.contents)-1)]
+	g.calls++
+	return &models.Door{Content: content, Theme: theme, Difficulty: 2, Locale: locale}, nil
+}
+
+var bankedDoorContents = []string{
+	"Your office chair has developed opinions about your posture and voices them loudly during meetings",
+	"A family of raccoons has moved into the supply closet and is now running the coffee budget",
+	"The elevator only stops on floors whose names you can pronounce backwards",
+	"Every email you send is automatically translated into pirate speak for the rest of the week",
+}
+
+// newBankThemes returns a theme catalog with only the named theme enabled
+func newBankThemes(themeID string) ThemeService {
+	repo := NewMockThemeRepository()
+	repo.themes[themeID] = &models.Theme{ThemeID: themeID, Name: themeID, Enabled: true}
+	repo.themes["disabled"] = &models.Theme{ThemeID: "disabled", Name: "disabled"}
+	return NewThemeService(repo)
+}
+
+func TestDoorBank_RefillFillsEveryPool(t *testing.T) {
+	bank := newMemoryDoorBank()
+	generator := &scriptedDoorGenerator{contents: bankedDoorContents}
+	service := NewDoorBankService(bank, generator, newBankThemes("bank-fill"), 1, time.Minute)
+
+	added, err := service.Refill(context.Background())
+	if err != nil {
+		t.Fatalf("Refill failed: %v", err)
+	}
+
+	if added != 3 || len(bank.pools) != 3 {
+		t.Fatalf("Expected one door in each difficulty of the enabled theme, got %d in %v", added, bank.pools)
+	}
+	pool := models.DoorPool{Theme: "bank-fill", Difficulty: 3, Locale: "en"}
+	door := bank.pools[pool][0]
+	if door.Difficulty != 3 || door.Status != models.DoorStatusApproved || door.DoorID == "" {
+		t.Errorf("Expected an approved door of the pool's difficulty, got %+v", door)
+	}
+	gauge := monitoring.GetGlobalMetricsCollector().NewGauge("door_bank_depth", "", map[string]string{"theme": "bank-fill", "difficulty": "3", "locale": "en"})
+	if gauge.Get() != 1 {
+		t.Errorf("Expected the depth gauge to report 1 door, got %v", gauge.Get())
+	}
+}
+
+func TestDoorBank_RefillStopsWhenGeneratorRepeats(t *testing.T) {
+	bank := newMemoryDoorBank()
+	generator := &scriptedDoorGenerator{contents: bankedDoorContents[:2]}
+	service := NewDoorBankService(bank, generator, newBankThemes("bank-repeat"), 5, time.Minute).(*DoorBankServiceImpl)
+	pool := models.DoorPool{Theme: "bank-repeat", Difficulty: 1, Locale: "en"}
+
+	added, err := service.refillPool(context.Background(), pool)
+	if err != nil {
+		t.Fatalf("refillPool failed: %v", err)
+	}
+	if added != 2 || generator.calls != 3 {
+		t.Errorf("Expected two distinct doors and a stop at the first repeat, got %d doors from %d calls", added, generator.calls)
+	}
+}
+
+func TestDoorBank_TakeServesOldestAndQueuesRefill(t *testing.T) {
+	bank := newMemoryDoorBank()
+	service := NewDoorBankService(bank, &scriptedDoorGenerator{contents: bankedDoorContents}, newBankThemes("bank-take"), 2, time.Minute).(*DoorBankServiceImpl)
+	pool := models.DoorPool{Theme: "bank-take", Difficulty: 2, Locale: "en"}
+	bank.Push(context.Background(), pool, &models.Door{DoorID: "first"})
+	bank.Push(context.Background(), pool, &models.Door{DoorID: "second"})
+
+	if door := service.Take(context.Background(), models.DoorPool{Theme: "bank-take", Difficulty: 2, Locale: "EN-us"}); door == nil || door.DoorID != "first" {
+		t.Fatalf("Expected the oldest door, got %+v", door)
+	}
+	if drained := <-service.drained; drained != pool {
+		t.Errorf("Expected the pool to be queued for a refill, got %v", drained)
+	}
+
+	service.Take(context.Background(), pool)
+	<-service.drained
+	if door := service.Take(context.Background(), pool); door != nil {
+		t.Errorf("Expected an empty pool to return nil, got %+v", door)
+	}
+}
+
+func TestNextDoor_ServesBankedDoorBeforeWrongDifficulty(t *testing.T) {
+	ctx := context.Background()
+	doorRepo := &MockDoorRepository{doors: []*models.Door{
+		{DoorID: "stored-hard", Content: "A door nobody finds easy", Theme: "general", Difficulty: 3, Status: models.DoorStatusApproved},
+	}}
+	bank := newMemoryDoorBank()
+	bank.Push(ctx, models.DoorPool{Theme: "general", Difficulty: 1, Locale: "en"}, &models.Door{
+		DoorID: "banked", Content: bankedDoorContents[0], Theme: "general", Difficulty: 1, Locale: "en", Status: models.DoorStatusApproved,
+	})
+	doorBank := NewDoorBankService(bank, &scriptedDoorGenerator{contents: bankedDoorContents}, newBankThemes("general"), 1, time.Minute)
+	gameService := NewGameService(NewMockGameSessionRepository(), doorRepo, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithDoorBank(doorBank),
+	).(*GameServiceImpl)
+
+	door, err := gameService.nextDoor(ctx, "p1", 50, "en")
+	if err != nil {
+		t.Fatalf("nextDoor failed: %v", err)
+	}
+	if door.DoorID != "banked" {
+		t.Errorf("Expected the banked easy door, got %s", door.DoorID)
+	}
+	if len(doorRepo.doors) != 2 {
+		t.Errorf("Expected the banked door to be stored for reuse, got %d doors", len(doorRepo.doors))
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Calibration defaults
+const (
+	DefaultDoorCalibrationInterval = time.Hour
+	DefaultMinCalibrationResponses = 20
+)
+
+// DoorCalibrator periodically recomputes each door's empirical difficulty from its scores
+type DoorCalibrator interface {
+	Start(ctx context.Context)
+	Calibrate(ctx context.Context) (int, error)
+}
+
+// DoorCalibratorImpl implements the DoorCalibrator interface
+type DoorCalibratorImpl struct {
+	doorRepo     repositories.DoorRepository
+	scoreRepo    repositories.DoorScoreRepository
+	interval     time.Duration
+	minResponses int
+	lastRun      time.Time
+}
+
+// NewDoorCalibrator creates a calibrator that runs every interval. Doors are only calibrated
+// once they have received minResponses scores.
+func NewDoorCalibrator(doorRepo repositories.DoorRepository, scoreRepo repositories.DoorScoreRepository, interval time.Duration, minResponses int) DoorCalibrator {
+	if interval <= 0 {
+		interval = DefaultDoorCalibrationInterval
+	}
+	if minResponses <= 0 {
+		minResponses = DefaultMinCalibrationResponses
+	}
+
+	return &DoorCalibratorImpl{
+		doorRepo:     doorRepo,
+		scoreRepo:    scoreRepo,
+		interval:     interval,
+		minResponses: minResponses,
+	}
+}
+
+// Start calibrates immediately, then again every interval
+func (c *DoorCalibratorImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.calibrateAndLog(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.calibrateAndLog(ctx)
+		}
+	}
+}
+
+// Calibrate recomputes the difficulty of every door scored since the last successful run and
+// returns how many doors were updated. Failed doors are retried on the next run.
+func (c *DoorCalibratorImpl) Calibrate(ctx context.Context) (int, error) {
+	started := time.Now()
+
+	distributions, err := c.scoreRepo.ListUpdatedSince(ctx, c.lastRun)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list door scores: %w", err)
+	}
+
+	calibrated := 0
+	var firstErr error
+	for _, distribution := range distributions {
+		if distribution.Count < c.minResponses || len(distribution.Scores) == 0 {
+			continue
+		}
+
+		difficulty := empiricalDifficulty(distribution.Scores)
+		if err := c.doorRepo.UpdateCalibration(ctx, distribution.DoorID, difficulty, distribution.Count); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to calibrate door %s: %w", distribution.DoorID, err)
+			}
+			continue
+		}
+		calibrated++
+	}
+
+	if firstErr != nil {
+		return calibrated, firstErr
+	}
+	c.lastRun = started
+	return calibrated, nil
+}
+
+// calibrateAndLog runs a calibration and logs failures without stopping the job
+func (c *DoorCalibratorImpl) calibrateAndLog(ctx context.Context) {
+	if _, err := c.Calibrate(ctx); err != nil {
+		fmt.Printf("Warning: door calibration failed: %v\n", err)
+	}
+}
+
+// empiricalDifficulty maps a door's median score onto the 1-3 difficulty scale: doors nobody
+// scores well on are hardest. The result keeps two decimals.
+func empiricalDifficulty(scores []int) float64 {
+	sorted := append([]int(nil), scores...)
+	sort.Ints(sorted)
+
+	median := float64(sorted[len(sorted)/2])
+	if len(sorted)%2 == 0 {
+		median = float64(sorted[len(sorted)/2-1]+sorted[len(sorted)/2]) / 2
+	}
+
+	difficulty := 3 - 2*median/100
+	difficulty = math.Max(1, math.Min(3, difficulty))
+	return math.Round(difficulty*100) / 100
+}
+
+// WithDoorScoreRepository records every score against its door so door difficulty can be calibrated
+func WithDoorScoreRepository(repo repositories.DoorScoreRepository) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.doorScores = repo
+	}
+}
+
+// recordDoorScore adds a response's score to its door's distribution in the background
+func (s *GameServiceImpl) recordDoorScore(ctx context.Context, sessionID, doorID string, score int) {
+	if s.doorScores == nil {
+		return
+	}
+
+	s.runInBackground(ctx, sessionID, "record-door-score", func(ctx context.Context) {
+		if err := s.doorScores.RecordScore(ctx, doorID, score); err != nil {
+			fmt.Printf("Warning: failed to record score for door %s: %v\n", doorID, err)
+		}
+	})
+}
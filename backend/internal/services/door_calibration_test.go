@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// MockDoorScoreRepository keeps door score distributions in memory
+type MockDoorScoreRepository struct {
+	scores map[string]*models.DoorScores
+}
+
+func NewMockDoorScoreRepository() *MockDoorScoreRepository {
+	return &MockDoorScoreRepository{scores: make(map[string]*models.DoorScores)}
+}
+
+func (m *MockDoorScoreRepository) RecordScore(ctx context.Context, doorID string, score int) error {
+	distribution, exists := m.scores[doorID]
+	if !exists {
+		distribution = &models.DoorScores{DoorID: doorID}
+		m.scores[doorID] = distribution
+	}
+	distribution.Scores = append(distribution.Scores, score)
+	distribution.Count++
+	distribution.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MockDoorScoreRepository) ListUpdatedSince(ctx context.Context, since time.Time) ([]*models.DoorScores, error) {
+	var updated []*models.DoorScores
+	for _, distribution := range m.scores {
+		if distribution.UpdatedAt.After(since) {
+			updated = append(updated, distribution)
+		}
+	}
+	return updated, nil
+}
+
+func TestEmpiricalDifficulty_FollowsMedianScore(t *testing.T) {
+	tests := []struct {
+		scores   []int
+		expected float64
+	}{
+		{[]int{100, 95, 100}, 1},
+		{[]int{0, 10, 0}, 3},
+		{[]int{50}, 2},
+		{[]int{20, 40, 60, 100}, 2}, // Median of an even count is the mean of the middle two
+		{[]int{90, 10, 75}, 1.5},
+	}
+
+	for _, test := range tests {
+		if difficulty := empiricalDifficulty(test.scores); difficulty != test.expected {
+			t.Errorf("Expected difficulty %.2f for scores %v, got %.2f", test.expected, test.scores, difficulty)
+		}
+	}
+}
+
+func TestDoorCalibrator_CalibratesDoorsWithEnoughScores(t *testing.T) {
+	ctx := context.Background()
+	doorRepo := &MockDoorRepository{doors: approvedDoors("general", 2)}
+	scoreRepo := NewMockDoorScoreRepository()
+	calibrator := NewDoorCalibrator(doorRepo, scoreRepo, time.Hour, 3)
+
+	// door-01 is scored poorly by everyone; door-02 has too few scores to judge
+	for _, score := range []int{10, 20, 15} {
+		scoreRepo.RecordScore(ctx, "door-01", score)
+	}
+	scoreRepo.RecordScore(ctx, "door-02", 90)
+
+	calibrated, err := calibrator.Calibrate(ctx)
+	if err != nil {
+		t.Fatalf("Calibrate failed: %v", err)
+	}
+	if calibrated != 1 {
+		t.Errorf("Expected one door calibrated, got %d", calibrated)
+	}
+	if door := doorRepo.doors[0]; door.EmpiricalDifficulty != 2.7 || door.ResponseCount != 3 || door.EffectiveDifficulty() != 3 {
+		t.Errorf("Expected door-01 calibrated as hard, got %+v", door)
+	}
+	if door := doorRepo.doors[1]; door.EmpiricalDifficulty != 0 || door.EffectiveDifficulty() != door.Difficulty {
+		t.Errorf("Expected door-02 to keep its authored difficulty, got %+v", door)
+	}
+
+	// Only doors scored since the last run are recalibrated
+	if calibrated, _ := calibrator.Calibrate(ctx); calibrated != 0 {
+		t.Errorf("Expected unchanged doors to be skipped, got %d calibrated", calibrated)
+	}
+	for _, score := range []int{100, 100} {
+		scoreRepo.RecordScore(ctx, "door-02", score)
+	}
+	if calibrated, _ := calibrator.Calibrate(ctx); calibrated != 1 || doorRepo.doors[1].EmpiricalDifficulty != 1 {
+		t.Errorf("Expected door-02 calibrated once it had enough scores, got %d calibrated and %+v", calibrated, doorRepo.doors[1])
+	}
+}
+
+func TestNextDoor_PrefersCalibratedDifficulty(t *testing.T) {
+	ctx := context.Background()
+	doorRepo := &MockDoorRepository{doors: approvedDoors("general", 8)}
+	pathRepo := NewMockPlayerPathRepository()
+	pathRepo.paths["p1"] = &models.PlayerPath{PlayerID: "p1", Theme: "general", CurrentDifficulty: 3}
+	gameService := NewGameService(NewMockGameSessionRepository(), doorRepo, pathRepo, nil, nil, nil, nil)
+
+	// door-01 was authored as easy, but players find it hard
+	doorRepo.doors[0].EmpiricalDifficulty = 2.8
+	door, err := gameService.GetNextDoor(ctx, "p1", 50, "en")
+	if err != nil || door.DoorID != "door-01" {
+		t.Errorf("Expected the calibrated hard door, got %+v (err %v)", door, err)
+	}
+}
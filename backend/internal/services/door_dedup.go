@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// DefaultDoorSimilarityThreshold is the estimated similarity at which a generated door counts
+// as a duplicate of a stored one
+const DefaultDoorSimilarityThreshold = 0.8
+
+// Door signature parameters: doors are compared as sets of three-word shingles, estimated
+// with a MinHash signature of doorMinHashSize hashes
+const (
+	doorShingleSize = 3
+	doorMinHashSize = 64
+)
+
+// DoorDeduplicator finds stored doors that a new door nearly duplicates
+type DoorDeduplicator interface {
+	FindDuplicate(ctx context.Context, door *models.Door) (*models.Door, float64, error)
+}
+
+// DoorDeduplicatorImpl implements the DoorDeduplicator interface with MinHash over word shingles
+type DoorDeduplicatorImpl struct {
+	doorRepo   repositories.DoorRepository
+	threshold  float64
+	checks     *monitoring.Counter
+	duplicates *monitoring.Counter
+}
+
+// NewDoorDeduplicator creates a deduplicator that flags doors at or above the similarity threshold
+func NewDoorDeduplicator(doorRepo repositories.DoorRepository, threshold float64) DoorDeduplicator {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultDoorSimilarityThreshold
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	return &DoorDeduplicatorImpl{
+		doorRepo:   doorRepo,
+		threshold:  threshold,
+		checks:     collector.NewCounter("door_similarity_checks_total", "Generated doors checked for near-duplicates", nil),
+		duplicates: collector.NewCounter("door_duplicates_rejected_total", "Generated doors rejected as near-duplicates of stored doors", nil),
+	}
+}
+
+// FindDuplicate returns the stored door in the same theme most similar to the door, with its
+// estimated similarity, if that similarity reaches the threshold. Otherwise it returns nil.
+func (d *DoorDeduplicatorImpl) FindDuplicate(ctx context.Context, door *models.Door) (*models.Door, float64, error) {
+	d.checks.Inc()
+
+	candidates, err := d.doorRepo.GetByTheme(ctx, door.Theme)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get doors to compare: %w", err)
+	}
+
+	signature := doorSignature(door.Content)
+	var closest *models.Door
+	best := 0.0
+	for _, candidate := range candidates {
+		if candidate.DoorID == door.DoorID {
+			continue
+		}
+		if similarity := signatureSimilarity(signature, doorSignature(candidate.Content)); similarity > best {
+			closest, best = candidate, similarity
+		}
+	}
+
+	if closest == nil || best < d.threshold {
+		return nil, best, nil
+	}
+	d.duplicates.Inc()
+	return closest, best, nil
+}
+
+// doorSignature is the MinHash signature of a door's normalized word shingles
+func doorSignature(content string) []uint64 {
+	signature := make([]uint64, doorMinHashSize)
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+
+	for _, shingle := range doorShingles(content) {
+		hash := fnv.New64a()
+		hash.Write([]byte(shingle))
+		base := hash.Sum64()
+		for i := range signature {
+			if h := mixHash(base ^ (uint64(i+1) * 0x9e3779b97f4a7c15)); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+	return signature
+}
+
+// doorShingles splits content into overlapping runs of words, ignoring case and punctuation.
+// Content shorter than a shingle is a single shingle.
+func doorShingles(content string) []string {
+	words := contentWords(content)
+	if len(words) == 0 {
+		return nil
+	}
+	if len(words) <= doorShingleSize {
+		return []string{strings.Join(words, " ")}
+	}
+
+	shingles := make([]string, 0, len(words)-doorShingleSize+1)
+	for i := 0; i+doorShingleSize <= len(words); i++ {
+		shingles = append(shingles, strings.Join(words[i:i+doorShingleSize], " "))
+	}
+	return shingles
+}
+
+// contentWords splits content into lower case words, dropping punctuation
+func contentWords(content string) []string {
+	return strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// mixHash scrambles a hash so each signature position behaves like an independent hash function
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// signatureSimilarity estimates the Jaccard similarity of two shingle sets from their signatures
+func signatureSimilarity(a, b []uint64) float64 {
+	if a[0] == ^uint64(0) || b[0] == ^uint64(0) {
+		return 0 // Empty content is never a duplicate
+	}
+
+	matches := 0
+	for i := range a {
+		if a[i] == b[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(a))
+}
+
+// WithDoorDeduplicator rejects generated doors that nearly duplicate stored ones
+func WithDoorDeduplicator(dedup DoorDeduplicator) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.dedup = dedup
+	}
+}
+
+// saveGeneratedDoor stores a freshly generated door unless it nearly duplicates a stored one.
+// It returns the door to serve: the stored duplicate when it can be played, else the new door.
+func (s *GameServiceImpl) saveGeneratedDoor(ctx context.Context, door *models.Door) *models.Door {
+	if s.dedup != nil {
+		existing, similarity, err := s.dedup.FindDuplicate(ctx, door)
+		if err != nil {
+			fmt.Printf("Warning: failed to check generated door for duplicates: %v\n", err)
+		} else if existing != nil {
+			fmt.Printf("Rejected generated door %s: %.0f%% similar to door %s\n", door.DoorID, similarity*100, existing.DoorID)
+			if existing.Servable() && i18n.Normalize(existing.Locale) == i18n.Normalize(door.Locale) {
+				return existing
+			}
+			return door
+		}
+	}
+
+	if err := s.doorRepo.Create(ctx, door); err != nil {
+		// Log error but don't fail - we can still return the door
+		fmt.Printf("Warning: failed to save generated door: %v\n", err)
+	}
+	return door
+}
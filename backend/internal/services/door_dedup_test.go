@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+)
+
+const fishDoor = "Your coworker keeps microwaving fish in the office kitchen. How do you address this delicate situation?"
+
+func TestDoorDeduplicator_FindsNearDuplicates(t *testing.T) {
+	ctx := context.Background()
+	stored := &models.Door{DoorID: "stored", Theme: "workplace", Content: fishDoor}
+	doorRepo := &MockDoorRepository{doors: []*models.Door{
+		stored,
+		{DoorID: "other", Theme: "workplace", Content: "The printer has jammed during your big presentation and is now on fire. What now?"},
+	}}
+	dedup := NewDoorDeduplicator(doorRepo, DefaultDoorSimilarityThreshold).(*DoorDeduplicatorImpl)
+	rejected := dedup.duplicates.Get()
+
+	// Case and punctuation don't hide a repeat
+	repeat := &models.Door{DoorID: "new", Theme: "workplace", Content: "your coworker keeps microwaving FISH in the office kitchen -- how do you address this delicate situation"}
+	existing, similarity, err := dedup.FindDuplicate(ctx, repeat)
+	if err != nil {
+		t.Fatalf("FindDuplicate failed: %v", err)
+	}
+	if existing != stored || similarity != 1 {
+		t.Errorf("Expected the stored door as an exact duplicate, got %+v at %.2f", existing, similarity)
+	}
+	if dedup.duplicates.Get() != rejected+1 {
+		t.Errorf("Expected the duplicate to be counted")
+	}
+
+	fresh := &models.Door{DoorID: "new", Theme: "workplace", Content: "A pigeon has taken over the break room and claims seniority. How do you negotiate?"}
+	if existing, _, _ := dedup.FindDuplicate(ctx, fresh); existing != nil {
+		t.Errorf("Expected an unrelated door to pass, got a match with %s", existing.DoorID)
+	}
+
+	// Doors in other themes are never compared
+	elsewhere := &models.Door{DoorID: "new", Theme: "social", Content: fishDoor}
+	if existing, _, _ := dedup.FindDuplicate(ctx, elsewhere); existing != nil {
+		t.Errorf("Expected doors in other themes to be ignored, got %s", existing.DoorID)
+	}
+}
+
+func TestDoorDeduplicator_ThresholdControlsRewordings(t *testing.T) {
+	ctx := context.Background()
+	doorRepo := &MockDoorRepository{doors: []*models.Door{{DoorID: "stored", Theme: "workplace", Content: fishDoor}}}
+	reworded := &models.Door{DoorID: "new", Theme: "workplace", Content: "Your coworker keeps microwaving salmon in the office kitchen. How do you address this delicate situation?"}
+
+	if existing, _, _ := NewDoorDeduplicator(doorRepo, 0.95).FindDuplicate(ctx, reworded); existing != nil {
+		t.Error("Expected a strict threshold to let the reworded door through")
+	}
+	if existing, _, _ := NewDoorDeduplicator(doorRepo, 0.5).FindDuplicate(ctx, reworded); existing == nil {
+		t.Error("Expected a loose threshold to catch the reworded door")
+	}
+}
+
+func TestNextDoor_DoesNotSaveDuplicateGeneratedDoors(t *testing.T) {
+	ctx := context.Background()
+	// The only stored workplace door is a draft repeating the built-in door
+	draft := &models.Door{DoorID: "draft", Theme: "workplace", Content: fishDoor, Status: models.DoorStatusDraft}
+	doorRepo := &MockDoorRepository{doors: []*models.Door{draft}}
+	pathRepo := NewMockPlayerPathRepository()
+	pathRepo.paths["p1"] = &models.PlayerPath{PlayerID: "p1", Theme: "workplace", CurrentDifficulty: 1}
+	gameService := NewGameService(NewMockGameSessionRepository(), doorRepo, pathRepo, nil, nil, nil, nil,
+		WithDoorDeduplicator(NewDoorDeduplicator(doorRepo, DefaultDoorSimilarityThreshold)))
+
+	door, err := gameService.GetNextDoor(ctx, "p1", 50, "en")
+	if err != nil {
+		t.Fatalf("GetNextDoor failed: %v", err)
+	}
+	if door.Content != fishDoor || door.DoorID == "draft" {
+		t.Errorf("Expected the generated door to be served while the draft awaits approval, got %+v", door)
+	}
+	if len(doorRepo.doors) != 1 {
+		t.Errorf("Expected the duplicate not to be saved, got %d doors", len(doorRepo.doors))
+	}
+
+	// Once the stored door can be played, it is served in place of the duplicate
+	draft.Status = models.DoorStatusApproved
+	if served := gameService.(*GameServiceImpl).saveGeneratedDoor(context.Background(), door); served != draft {
+		t.Errorf("Expected the approved stored door to be served, got %+v", served)
+	}
+}
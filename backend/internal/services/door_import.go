@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxDoorImportRows caps how many doors a single import may contain
+const MaxDoorImportRows = 1000
+
+// DoorImportFormat is the file format of a door import
+type DoorImportFormat string
+
+const (
+	DoorImportJSON DoorImportFormat = "json" // an array of door objects
+	DoorImportCSV  DoorImportFormat = "csv"  // a header row naming the columns, then one door per row
+)
+
+// Door import errors
+var (
+	ErrInvalidDoorImport  = errors.New("invalid door import")
+	ErrDoorImportRejected = errors.New("door import rejected")
+)
+
+// doorImportColumns are the CSV columns an import may have; content, theme and difficulty are required
+var doorImportColumns = map[string]bool{
+	"content":               true,
+	"theme":                 true,
+	"difficulty":            true,
+	"locale":                false,
+	"expectedsolutiontypes": false,
+}
+
+// DoorImportRow is one door in an import file. In CSV files expected solution types are
+// separated by semicolons, and translations cannot be given.
+type DoorImportRow struct {
+	Content               string            `json:"content"`
+	Theme                 string            `json:"theme"`
+	Difficulty            int               `json:"difficulty"`
+	Locale                string            `json:"locale,omitempty"`
+	Translations          map[string]string `json:"translations,omitempty"`
+	ExpectedSolutionTypes []string          `json:"expectedSolutionTypes,omitempty"`
+}
+
+// DoorImportError explains why a row of an import was rejected. Rows are numbered from 1, not
+// counting a CSV header.
+type DoorImportError struct {
+	Row            int     `json:"row"`
+	Message        string  `json:"message"`
+	DuplicateOf    string  `json:"duplicateOf,omitempty"`    // the stored door the row nearly duplicates
+	DuplicateOfRow int     `json:"duplicateOfRow,omitempty"` // the earlier row it nearly duplicates
+	Similarity     float64 `json:"similarity,omitempty"`
+}
+
+// DoorImportResult reports the outcome of a door import
+type DoorImportResult struct {
+	Rows     int               `json:"rows"`
+	Imported int               `json:"imported"`
+	DryRun   bool              `json:"dryRun,omitempty"`
+	Doors    []*models.Door    `json:"doors,omitempty"`
+	Errors   []DoorImportError `json:"errors,omitempty"`
+}
+
+// doorImportRecord is a parsed row, or the reason it could not be parsed
+type doorImportRecord struct {
+	row DoorImportRow
+	err error
+}
+
+// WithImportSimilarityThreshold sets the estimated similarity at which an imported door counts
+// as a duplicate of a stored door or of another row
+func WithImportSimilarityThreshold(threshold float64) DoorAdminServiceOption {
+	return func(s *DoorAdminServiceImpl) {
+		if threshold > 0 && threshold <= 1 {
+			s.similarityThreshold = threshold
+		}
+	}
+}
+
+// ImportDoors stores every door in the file as a draft, in one batch. If any row is invalid or
+// nearly duplicates a stored door or an earlier row, nothing is stored and the result lists every
+// rejected row alongside ErrDoorImportRejected. A dry run only checks the file.
+func (s *DoorAdminServiceImpl) ImportDoors(ctx context.Context, data io.Reader, format DoorImportFormat, dryRun bool) (*DoorImportResult, error) {
+	records, err := parseDoorImport(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DoorImportResult{Rows: len(records), DryRun: dryRun}
+	doors := make([]*models.Door, len(records))
+	for i, record := range records {
+		err := record.err
+		if err == nil {
+			doors[i] = &models.Door{
+				Content:               record.row.Content,
+				Theme:                 record.row.Theme,
+				Difficulty:            record.row.Difficulty,
+				Locale:                record.row.Locale,
+				Translations:          record.row.Translations,
+				ExpectedSolutionTypes: record.row.ExpectedSolutionTypes,
+			}
+			err = prepareDraftDoor(doors[i])
+		}
+		if err != nil {
+			doors[i] = nil
+			result.Errors = append(result.Errors, DoorImportError{Row: i + 1, Message: err.Error()})
+		}
+	}
+
+	duplicates, err := s.findImportDuplicates(ctx, doors)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = append(result.Errors, duplicates...)
+
+	if len(result.Errors) > 0 {
+		sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+		return result, fmt.Errorf("%w: %d of %d rows have errors", ErrDoorImportRejected, len(result.Errors), len(records))
+	}
+
+	result.Doors = doors
+	if dryRun {
+		return result, nil
+	}
+	if err := s.doorRepo.CreateMany(ctx, doors); err != nil {
+		return nil, fmt.Errorf("failed to import doors: %w", err)
+	}
+	result.Imported = len(doors)
+	return result, nil
+}
+
+// findImportDuplicates compares each valid door with the stored doors of its theme and with the
+// valid rows before it, reporting those at or above the similarity threshold
+func (s *DoorAdminServiceImpl) findImportDuplicates(ctx context.Context, doors []*models.Door) ([]DoorImportError, error) {
+	type candidate struct {
+		doorID    string
+		row       int
+		signature []uint64
+	}
+	byTheme := make(map[string][]candidate)
+
+	var duplicates []DoorImportError
+	for i, door := range doors {
+		if door == nil {
+			continue
+		}
+
+		candidates, loaded := byTheme[door.Theme]
+		if !loaded {
+			stored, err := s.doorRepo.GetByTheme(ctx, door.Theme)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get doors to compare: %w", err)
+			}
+			candidates = make([]candidate, 0, len(stored))
+			for _, existing := range stored {
+				candidates = append(candidates, candidate{doorID: existing.DoorID, signature: doorSignature(existing.Content)})
+			}
+		}
+
+		signature := doorSignature(door.Content)
+		var closest *candidate
+		best := 0.0
+		for j := range candidates {
+			if similarity := signatureSimilarity(signature, candidates[j].signature); similarity > best {
+				closest, best = &candidates[j], similarity
+			}
+		}
+
+		if closest != nil && best >= s.similarityThreshold {
+			duplicate := DoorImportError{Row: i + 1, DuplicateOf: closest.doorID, DuplicateOfRow: closest.row, Similarity: best}
+			if closest.row > 0 {
+				duplicate.Message = fmt.Sprintf("door nearly duplicates row %d", closest.row)
+			} else {
+				duplicate.Message = fmt.Sprintf("door nearly duplicates stored door %s", closest.doorID)
+			}
+			duplicates = append(duplicates, duplicate)
+		} else {
+			candidates = append(candidates, candidate{row: i + 1, signature: signature})
+		}
+		byTheme[door.Theme] = candidates
+	}
+	return duplicates, nil
+}
+
+// parseDoorImport reads the rows of an import file. A file that cannot be read at all is an
+// ErrInvalidDoorImport; a row that cannot be read is returned with its error.
+func parseDoorImport(data io.Reader, format DoorImportFormat) ([]doorImportRecord, error) {
+	var records []doorImportRecord
+	var err error
+	switch format {
+	case DoorImportJSON:
+		records, err = parseDoorImportJSON(data)
+	case DoorImportCSV:
+		records, err = parseDoorImportCSV(data)
+	default:
+		return nil, fmt.Errorf("%w: format must be json or csv", ErrInvalidDoorImport)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(records) == 0:
+		return nil, fmt.Errorf("%w: the file contains no doors", ErrInvalidDoorImport)
+	case len(records) > MaxDoorImportRows:
+		return nil, fmt.Errorf("%w: at most %d doors can be imported at once", ErrInvalidDoorImport, MaxDoorImportRows)
+	}
+	return records, nil
+}
+
+// parseDoorImportJSON reads an array of doors, decoding each separately so one malformed door
+// doesn't hide the errors in the others
+func parseDoorImportJSON(data io.Reader) ([]doorImportRecord, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(data).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: expected a JSON array of doors: %v", ErrInvalidDoorImport, err)
+	}
+
+	records := make([]doorImportRecord, len(raw))
+	for i, message := range raw {
+		records[i].err = json.Unmarshal(message, &records[i].row)
+	}
+	return records, nil
+}
+
+// parseDoorImportCSV reads a header row followed by one door per row
+func parseDoorImportCSV(data io.Reader) ([]doorImportRecord, error) {
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrInvalidDoorImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, known := doorImportColumns[name]; !known {
+			return nil, fmt.Errorf("%w: unknown CSV column %q", ErrInvalidDoorImport, header[i])
+		}
+		columns[name] = i
+	}
+	for name, required := range doorImportColumns {
+		if _, present := columns[name]; required && !present {
+			return nil, fmt.Errorf("%w: the CSV header has no %s column", ErrInvalidDoorImport, name)
+		}
+	}
+
+	var records []doorImportRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDoorImport, err)
+		}
+		if len(fields) != len(header) {
+			records = append(records, doorImportRecord{err: fmt.Errorf("expected %d columns, got %d", len(header), len(fields))})
+			continue
+		}
+		records = append(records, csvDoorImportRecord(fields, columns))
+	}
+}
+
+// csvDoorImportRecord reads a door from a CSV row
+func csvDoorImportRecord(fields []string, columns map[string]int) doorImportRecord {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+
+	row := DoorImportRow{
+		Content: field("content"),
+		Theme:   field("theme"),
+		Locale:  field("locale"),
+	}
+	difficulty, err := strconv.Atoi(field("difficulty"))
+	if err != nil {
+		return doorImportRecord{err: fmt.Errorf("door difficulty must be a number, got %q", field("difficulty"))}
+	}
+	row.Difficulty = difficulty
+
+	for _, solutionType := range strings.Split(field("expectedsolutiontypes"), ";") {
+		if solutionType = strings.TrimSpace(solutionType); solutionType != "" {
+			row.ExpectedSolutionTypes = append(row.ExpectedSolutionTypes, solutionType)
+		}
+	}
+	return doorImportRecord{row: row}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestImportDoors_StoresCSVRowsAsDrafts(t *testing.T) {
+	repo := &MockDoorRepository{}
+	service := NewDoorAdminService(repo)
+
+	file := "content,theme,difficulty,expectedSolutionTypes\n" +
+		"\"A bridge made of jelly wobbles over a canyon, and you need to cross it\",workplace,2,creative;practical\n" +
+		"Your coworker has replaced every chair in the office with a trampoline,workplace,1,\n"
+	result, err := service.ImportDoors(context.Background(), strings.NewReader(file), DoorImportCSV, false)
+	if err != nil {
+		t.Fatalf("ImportDoors failed: %v", err)
+	}
+
+	if result.Rows != 2 || result.Imported != 2 || len(repo.doors) != 2 {
+		t.Fatalf("Expected both rows to be stored, got %+v with %d stored", result, len(repo.doors))
+	}
+	door := repo.doors[0]
+	if door.Status != models.DoorStatusDraft || !strings.HasPrefix(door.DoorID, "door_") {
+		t.Errorf("Expected a draft door with a generated ID, got %+v", door)
+	}
+	if len(door.ExpectedSolutionTypes) != 2 || door.ExpectedSolutionTypes[1] != "practical" {
+		t.Errorf("Expected semicolon-separated solution types, got %v", door.ExpectedSolutionTypes)
+	}
+}
+
+func TestImportDoors_RejectsWholeFileWithRowErrors(t *testing.T) {
+	repo := &MockDoorRepository{doors: []*models.Door{
+		{DoorID: "door_stored", Theme: "workplace", Content: "The office printer has become sentient and is demanding a raise before printing anything"},
+	}}
+	service := NewDoorAdminService(repo)
+
+	file := `[
+		{"content": "A flock of pigeons has taken over the break room and elected a leader", "theme": "workplace", "difficulty": 2},
+		{"content": "", "theme": "workplace", "difficulty": 1},
+		{"content": "The office printer has become sentient and is demanding a raise before printing anything!", "theme": "workplace", "difficulty": 2},
+		{"content": "A flock of pigeons has taken over the break room and elected a leader.", "theme": "workplace", "difficulty": 3},
+		{"content": "Meeting room", "theme": "workplace", "difficulty": "hard"}
+	]`
+	result, err := service.ImportDoors(context.Background(), strings.NewReader(file), DoorImportJSON, false)
+	if !errors.Is(err, ErrDoorImportRejected) {
+		t.Fatalf("Expected ErrDoorImportRejected, got %v", err)
+	}
+	if len(repo.doors) != 1 {
+		t.Errorf("Expected nothing to be stored, got %d doors", len(repo.doors))
+	}
+
+	if len(result.Errors) != 4 {
+		t.Fatalf("Expected rows 2 to 5 to be rejected, got %+v", result.Errors)
+	}
+	for i, rowErr := range result.Errors {
+		if rowErr.Row != i+2 {
+			t.Errorf("Expected errors in row order, got row %d at %d", rowErr.Row, i)
+		}
+	}
+	if result.Errors[1].DuplicateOf != "door_stored" {
+		t.Errorf("Expected row 3 to duplicate the stored door, got %+v", result.Errors[1])
+	}
+	if result.Errors[2].DuplicateOfRow != 1 {
+		t.Errorf("Expected row 4 to duplicate row 1, got %+v", result.Errors[2])
+	}
+}
+
+func TestImportDoors_DryRunStoresNothing(t *testing.T) {
+	repo := &MockDoorRepository{}
+	service := NewDoorAdminService(repo)
+
+	file := `[{"content": "The vending machine only accepts compliments as payment", "theme": "workplace", "difficulty": 1}]`
+	result, err := service.ImportDoors(context.Background(), strings.NewReader(file), DoorImportJSON, true)
+	if err != nil {
+		t.Fatalf("ImportDoors failed: %v", err)
+	}
+	if result.Imported != 0 || len(result.Doors) != 1 || len(repo.doors) != 0 {
+		t.Errorf("Expected the door to be checked but not stored, got %+v", result)
+	}
+}
+
+func TestImportDoors_RejectsUnreadableFiles(t *testing.T) {
+	service := NewDoorAdminService(&MockDoorRepository{})
+
+	tests := []struct {
+		name   string
+		file   string
+		format DoorImportFormat
+	}{
+		{"unknown format", "content", "xml"},
+		{"not an array", `{"content": "A door"}`, DoorImportJSON},
+		{"empty", `[]`, DoorImportJSON},
+		{"missing column", "content,theme\nA door,workplace\n", DoorImportCSV},
+		{"unknown column", "content,theme,difficulty,mood\nA door,workplace,1,happy\n", DoorImportCSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ImportDoors(context.Background(), strings.NewReader(tt.file), tt.format, false)
+			if !errors.Is(err, ErrInvalidDoorImport) {
+				t.Errorf("Expected ErrInvalidDoorImport, got %v", err)
+			}
+		})
+	}
+}
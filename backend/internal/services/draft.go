@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"time"
+)
+
+// DraftService autosaves players' in-progress responses so a dropped connection doesn't lose them
+type DraftService interface {
+	SaveDraft(ctx context.Context, sessionID, playerID, content string) (*models.ResponseDraft, error)
+	GetDraft(ctx context.Context, sessionID, playerID string) (*models.ResponseDraft, error)
+}
+
+// DraftServiceImpl implements the DraftService interface
+type DraftServiceImpl struct {
+	gameSessionRepo repositories.GameSessionRepository
+	store           repositories.ResponseDraftStore
+	stateMachine    SessionStateMachine
+}
+
+// NewDraftService creates a new draft service instance
+func NewDraftService(gameSessionRepo repositories.GameSessionRepository, store repositories.ResponseDraftStore) DraftService {
+	return &DraftServiceImpl{
+		gameSessionRepo: gameSessionRepo,
+		store:           store,
+		stateMachine:    NewSessionStateMachine(),
+	}
+}
+
+// SaveDraft stores the player's partial response to the current door. An empty draft clears it.
+func (s *DraftServiceImpl) SaveDraft(ctx context.Context, sessionID, playerID, content string) (*models.ResponseDraft, error) {
+	session, err := s.sessionForDraft(ctx, sessionID, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	door := session.DoorForPlayer(playerID)
+	if door == nil {
+		return nil, ErrNoActiveDoor
+	}
+	doorID := door.DoorID
+
+	if hasRespondedToDoor(session, playerID, doorID) {
+		return nil, ErrAlreadyResponded
+	}
+
+	if content == "" {
+		if err := s.store.DeleteDraft(ctx, sessionID, doorID, playerID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	limit := ResponseLimit(session, playerID)
+	if remaining, _ := ValidateResponseLimit(content, limit); remaining < 0 {
+		return nil, fmt.Errorf("draft exceeds %d character limit by %d", limit, -remaining)
+	}
+
+	draft := &models.ResponseDraft{
+		SessionID: sessionID,
+		DoorID:    doorID,
+		PlayerID:  playerID,
+		Content:   content,
+		Limit:     limit,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.store.SaveDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// GetDraft returns the player's draft for the current door, or nil when there is nothing to restore
+func (s *DraftServiceImpl) GetDraft(ctx context.Context, sessionID, playerID string) (*models.ResponseDraft, error) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session == nil {
+		return nil, nil
+	}
+
+	door := session.DoorForPlayer(playerID)
+	if door == nil {
+		return nil, nil
+	}
+
+	// A submitted door has nothing left to restore
+	if hasRespondedToDoor(session, playerID, door.DoorID) {
+		return nil, nil
+	}
+
+	return s.store.GetDraft(ctx, sessionID, door.DoorID, playerID)
+}
+
+// sessionForDraft loads a session that is collecting responses from the given player
+func (s *DraftServiceImpl) sessionForDraft(ctx context.Context, sessionID, playerID string) (*models.GameSession, error) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	if err := s.stateMachine.Require(session, OpSubmitResponse); err != nil {
+		return nil, err
+	}
+
+	for _, player := range session.Players {
+		if player.PlayerID == playerID {
+			return session, nil
+		}
+	}
+
+	return nil, ErrPlayerNotInSession
+}
+
+// hasRespondedToDoor reports whether the player has already submitted a response to the door
+func hasRespondedToDoor(session *models.GameSession, playerID, doorID string) bool {
+	for _, player := range session.Players {
+		if player.PlayerID != playerID {
+			continue
+		}
+		for _, response := range player.Responses {
+			if response.DoorID == doorID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+// MockResponseDraftStore is an in-memory ResponseDraftStore for testing
+type MockResponseDraftStore struct {
+	drafts map[string]*models.ResponseDraft
+}
+
+func NewMockResponseDraftStore() *MockResponseDraftStore {
+	return &MockResponseDraftStore{drafts: make(map[string]*models.ResponseDraft)}
+}
+
+func (m *MockResponseDraftStore) SaveDraft(ctx context.Context, draft *models.ResponseDraft) error {
+	m.drafts[draft.SessionID+":"+draft.DoorID+":"+draft.PlayerID] = draft
+	return nil
+}
+
+func (m *MockResponseDraftStore) GetDraft(ctx context.Context, sessionID, doorID, playerID string) (*models.ResponseDraft, error) {
+	return m.drafts[sessionID+":"+doorID+":"+playerID], nil
+}
+
+func (m *MockResponseDraftStore) DeleteDraft(ctx context.Context, sessionID, doorID, playerID string) error {
+	delete(m.drafts, sessionID+":"+doorID+":"+playerID)
+	return nil
+}
+
+func newDraftSession() *models.GameSession {
+	return &models.GameSession{
+		SessionID:   "s1",
+		Status:      models.GameStatusActive,
+		CurrentDoor: &models.Door{DoorID: "door-1"},
+		Players:     []models.PlayerInfo{{PlayerID: "p1"}, {PlayerID: "p2"}},
+	}
+}
+
+func TestDraftService_RestoresDraftForCurrentDoor(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newDraftSession()
+	draftService := NewDraftService(gameSessionRepo, NewMockResponseDraftStore())
+
+	if _, err := draftService.SaveDraft(ctx, "s1", "p1", "I would knock twi"); err != nil {
+		t.Fatalf("SaveDraft failed: %v", err)
+	}
+
+	draft, err := draftService.GetDraft(ctx, "s1", "p1")
+	if err != nil {
+		t.Fatalf("GetDraft failed: %v", err)
+	}
+	if draft == nil || draft.Content != "I would knock twi" || draft.DoorID != "door-1" {
+		t.Errorf("Expected the saved draft for door-1, got %+v", draft)
+	}
+
+	// Once the door moves on, the old draft is no longer offered
+	gameSessionRepo.sessions["s1"].CurrentDoor = &models.Door{DoorID: "door-2"}
+	if draft, _ := draftService.GetDraft(ctx, "s1", "p1"); draft != nil {
+		t.Errorf("Expected no draft for a new door, got %+v", draft)
+	}
+}
+
+func TestDraftService_NoDraftAfterSubmission(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	gameSessionRepo.sessions["s1"] = session
+	draftService := NewDraftService(gameSessionRepo, NewMockResponseDraftStore())
+
+	draftService.SaveDraft(ctx, "s1", "p1", "almost done")
+	session.Players[0].Responses = append(session.Players[0].Responses, models.PlayerResponse{DoorID: "door-1"})
+
+	if draft, _ := draftService.GetDraft(ctx, "s1", "p1"); draft != nil {
+		t.Errorf("Expected no draft after submitting, got %+v", draft)
+	}
+	if _, err := draftService.SaveDraft(ctx, "s1", "p1", "second thoughts"); err == nil {
+		t.Error("Expected saving a draft for an answered door to fail")
+	}
+}
+
+func TestDraftService_RejectsDraftsOutsideResponseWindow(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Status = models.GameStatusRevealing
+	gameSessionRepo.sessions["s1"] = session
+	draftService := NewDraftService(gameSessionRepo, NewMockResponseDraftStore())
+
+	if _, err := draftService.SaveDraft(context.Background(), "s1", "p1", "too late"); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("Expected ErrIllegalOperation, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Drain defaults
+const (
+	DefaultDrainWindow         = 30 * time.Second
+	DefaultDrainReconnectDelay = 2 * time.Second
+	drainPollInterval          = 500 * time.Millisecond
+)
+
+// EventServerDraining tells clients this instance is shutting down and they should reconnect
+const EventServerDraining = "server-draining"
+
+// DrainService takes an instance out of rotation before it shuts down, so deploys don't
+// cut games off mid-door
+type DrainService interface {
+	Draining() bool
+	Drain(ctx context.Context) error
+}
+
+// DrainServiceImpl implements the DrainService interface
+type DrainServiceImpl struct {
+	gameSessionRepo repositories.GameSessionRepository
+	wsManager       WebSocketManager
+	scheduler       DeadlineScheduler
+	window          time.Duration
+	reconnectDelay  time.Duration
+	pollInterval    time.Duration
+
+	draining atomic.Bool
+}
+
+// NewDrainService creates a drain service that waits up to window for the doors in play on
+// this instance to finish
+func NewDrainService(gameSessionRepo repositories.GameSessionRepository, wsManager WebSocketManager, scheduler DeadlineScheduler, window time.Duration) DrainService {
+	if window <= 0 {
+		window = DefaultDrainWindow
+	}
+	return &DrainServiceImpl{
+		gameSessionRepo: gameSessionRepo,
+		wsManager:       wsManager,
+		scheduler:       scheduler,
+		window:          window,
+		reconnectDelay:  DefaultDrainReconnectDelay,
+		pollInterval:    drainPollInterval,
+	}
+}
+
+// Draining reports whether the instance has started draining and should refuse new sessions
+func (s *DrainServiceImpl) Draining() bool {
+	return s.draining.Load()
+}
+
+// Drain stops the instance taking on new games, tells its connected clients to reconnect
+// elsewhere, waits up to the drain window for the doors in play to finish and then closes
+// its connections. Deadlines of doors still in play are persisted so another instance fires
+// them. Draining twice does nothing.
+func (s *DrainServiceImpl) Drain(ctx context.Context) error {
+	if !s.draining.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	sessionIDs := s.wsManager.LocalSessionIDs()
+	inPlay := make(map[string]string) // sessionID -> door in play
+	for _, sessionID := range sessionIDs {
+		session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+		if err != nil {
+			fmt.Printf("Warning: failed to load draining session %s: %v\n", sessionID, err)
+		} else if doorID := doorInPlay(session); doorID != "" {
+			inPlay[sessionID] = doorID
+		}
+		s.notify(sessionID, session)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, s.window)
+	defer cancel()
+	s.awaitDoors(waitCtx, inPlay)
+
+	if len(inPlay) > 0 {
+		fmt.Printf("Warning: drain window elapsed with %d doors still in play\n", len(inPlay))
+		s.persistDeadlines(ctx, inPlay)
+	}
+	for _, sessionID := range sessionIDs {
+		s.wsManager.CloseLocalSession(sessionID)
+	}
+	return nil
+}
+
+// notify tells the session's clients on this instance to reconnect, in the session's locale
+func (s *DrainServiceImpl) notify(sessionID string, session *models.GameSession) {
+	locale := i18n.DefaultLocale
+	if session != nil && session.Locale != "" {
+		locale = session.Locale
+	}
+
+	event := WebSocketEvent{
+		Type:      EventServerDraining,
+		SessionID: sessionID,
+		Data: systemMessage(map[string]interface{}{
+			"reconnect":        true,
+			"reconnectAfterMs": s.reconnectDelay.Milliseconds(),
+		}, locale, i18n.MsgServerDraining),
+		Timestamp: time.Now(),
+	}
+	if err := s.wsManager.SendToLocalSession(sessionID, event); err != nil {
+		fmt.Printf("Warning: failed to notify session %s of drain: %v\n", sessionID, err)
+	}
+}
+
+// awaitDoors polls the sessions until each has moved past the door it had in play, removing
+// them from inPlay, or the context ends
+func (s *DrainServiceImpl) awaitDoors(ctx context.Context, inPlay map[string]string) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for len(inPlay) > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for sessionID, doorID := range inPlay {
+			session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+			if err != nil {
+				fmt.Printf("Warning: failed to check draining session %s: %v\n", sessionID, err)
+				continue
+			}
+			if doorInPlay(session) != doorID {
+				delete(inPlay, sessionID)
+			}
+		}
+	}
+}
+
+// persistDeadlines schedules the round deadline of every door still in play, so it fires on
+// whichever instance picks the game up even if scheduling it failed the first time
+func (s *DrainServiceImpl) persistDeadlines(ctx context.Context, inPlay map[string]string) {
+	if s.scheduler == nil {
+		return
+	}
+
+	for sessionID, doorID := range inPlay {
+		session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+		if err != nil || session == nil || session.RoundDeadline == nil {
+			continue
+		}
+		if err := s.scheduler.Schedule(ctx, sessionID, doorID, *session.RoundClosesAt()); err != nil {
+			fmt.Printf("Warning: failed to persist deadline for session %s: %v\n", sessionID, err)
+		}
+	}
+}
+
+// doorInPlay returns the door players are answering or waiting on scores for, if any
+func doorInPlay(session *models.GameSession) string {
+	if session == nil || session.CurrentDoor == nil {
+		return ""
+	}
+	switch session.Status {
+	case models.GameStatusActive, models.GameStatusScoring, models.GameStatusRevealing:
+		return session.CurrentDoor.DoorID
+	}
+	return ""
+}
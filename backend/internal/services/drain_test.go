@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// drainRecordingWebSocketManager holds connections to fixed sessions and records what drain
+// does with them
+type drainRecordingWebSocketManager struct {
+	*MockWebSocketManager
+	localSessions []string
+	sent          []WebSocketEvent
+	closed        []string
+}
+
+func (m *drainRecordingWebSocketManager) LocalSessionIDs() []string { return m.localSessions }
+
+func (m *drainRecordingWebSocketManager) SendToLocalSession(sessionID string, event WebSocketEvent) error {
+	m.sent = append(m.sent, event)
+	return nil
+}
+
+func (m *drainRecordingWebSocketManager) CloseLocalSession(sessionID string) {
+	m.closed = append(m.closed, sessionID)
+}
+
+// advancingSessionRepository moves s1 on to its next door after a number of reads
+type advancingSessionRepository struct {
+	*MockGameSessionRepository
+	readsUntilNextDoor int
+}
+
+func (r *advancingSessionRepository) GetByID(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	if sessionID == "s1" {
+		if r.readsUntilNextDoor == 0 {
+			r.sessions["s1"].CurrentDoor = &models.Door{DoorID: "door-2"}
+		}
+		r.readsUntilNextDoor--
+	}
+	return r.MockGameSessionRepository.GetByID(ctx, sessionID)
+}
+
+func newTestDrain(repo *advancingSessionRepository, store *MockDeadlineStore, window time.Duration) (*DrainServiceImpl, *drainRecordingWebSocketManager) {
+	repo.sessions["s1"] = newDraftSession()
+	repo.sessions["lobby"] = &models.GameSession{SessionID: "lobby", Status: models.GameStatusWaiting, Locale: "es"}
+	wsManager := &drainRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager(), localSessions: []string{"s1", "lobby"}}
+
+	drain := NewDrainService(repo, wsManager, NewPersistentScheduler(store, time.Hour), window).(*DrainServiceImpl)
+	drain.pollInterval = time.Millisecond
+	return drain, wsManager
+}
+
+func TestDrainService_WaitsForDoorsInPlay(t *testing.T) {
+	repo := &advancingSessionRepository{MockGameSessionRepository: NewMockGameSessionRepository(), readsUntilNextDoor: 3}
+	store := NewMockDeadlineStore()
+	drain, wsManager := newTestDrain(repo, store, time.Minute)
+
+	start := time.Now()
+	if err := drain.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+	if !drain.Draining() {
+		t.Error("Expected the instance to report draining")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected drain to finish once the door moved on, took %v", elapsed)
+	}
+
+	if len(wsManager.sent) != 2 {
+		t.Fatalf("Expected both sessions to be told to reconnect, got %d events", len(wsManager.sent))
+	}
+	for _, event := range wsManager.sent {
+		data := event.Data.(map[string]interface{})
+		if event.Type != EventServerDraining || data["reconnect"] != true || data["reconnectAfterMs"] == nil {
+			t.Errorf("Expected a server-draining event with a reconnect hint, got %+v", event)
+		}
+	}
+	if data := wsManager.sent[1].Data.(map[string]interface{}); data["message"] == wsManager.sent[0].Data.(map[string]interface{})["message"] {
+		t.Error("Expected each session to be notified in its own locale")
+	}
+	if len(wsManager.closed) != 2 {
+		t.Errorf("Expected the local connections to be closed, got %v", wsManager.closed)
+	}
+	if len(store.deadlines) != 0 {
+		t.Errorf("Expected no deadlines to be persisted for finished doors, got %v", store.deadlines)
+	}
+}
+
+func TestDrainService_PersistsDeadlinesOfUnfinishedDoors(t *testing.T) {
+	repo := &advancingSessionRepository{MockGameSessionRepository: NewMockGameSessionRepository(), readsUntilNextDoor: -1}
+	store := NewMockDeadlineStore()
+	drain, wsManager := newTestDrain(repo, store, 20*time.Millisecond)
+	deadline := time.Now().Add(time.Minute)
+	repo.sessions["s1"].RoundDeadline = &deadline
+
+	if err := drain.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+	if persisted, exists := store.deadlines["s1/door-1"]; !exists || !persisted.At.Equal(deadline) {
+		t.Errorf("Expected the unfinished door's deadline to be persisted, got %v", store.deadlines)
+	}
+
+	// Draining again doesn't notify anyone twice
+	if err := drain.Drain(context.Background()); err != nil || len(wsManager.sent) != 2 {
+		t.Errorf("Expected a second drain to do nothing, got %v and %d events", err, len(wsManager.sent))
+	}
+}
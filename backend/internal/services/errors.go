@@ -0,0 +1,15 @@
+package services
+
+import "errors"
+
+// Game session errors. Handlers match them with errors.Is to choose the status code a
+// client receives, so services return (or wrap) them rather than ad-hoc messages.
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionFull         = errors.New("session is full")
+	ErrNotAcceptingPlayers = errors.New("session is not accepting players")
+	ErrAlreadyInSession    = errors.New("player already in session")
+	ErrPlayerNotInSession  = errors.New("player not found in session")
+	ErrNoActiveDoor        = errors.New("no active door in session")
+	ErrAlreadyResponded    = errors.New("player has already responded to this door")
+)
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestJoinSession_ReturnsTypedErrors(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newHostedSession()
+
+	full := newHostedSession()
+	full.SessionID = "full"
+	for len(full.Players) < models.MaxMultiplayerPlayers {
+		full.Players = append(full.Players, models.PlayerInfo{PlayerID: fmt.Sprintf("extra-%d", len(full.Players)), IsActive: true})
+	}
+	gameSessionRepo.sessions["full"] = full
+
+	started := newHostedSession()
+	started.SessionID = "started"
+	started.Status = models.GameStatusActive
+	gameSessionRepo.sessions["started"] = started
+
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	cases := []struct {
+		sessionID string
+		playerID  string
+		expected  error
+	}{
+		{"missing", "p4", ErrSessionNotFound},
+		{"s1", "p2", ErrAlreadyInSession},
+		{"full", "p4", ErrSessionFull},
+		{"started", "p4", ErrNotAcceptingPlayers},
+	}
+	for _, tc := range cases {
+		if _, err := gameService.JoinSession(ctx, tc.sessionID, tc.playerID, "Player", ""); !errors.Is(err, tc.expected) {
+			t.Errorf("Joining %s as %s: expected %v, got %v", tc.sessionID, tc.playerID, tc.expected, err)
+		}
+	}
+
+	// Refusals by the session state machine stay recognizable as such
+	if _, err := gameService.JoinSession(ctx, "started", "p4", "Player", ""); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("Expected the state machine's error to be wrapped too, got %v", err)
+	}
+}
+
+func TestSubmitResponse_ReturnsTypedErrors(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Players[0].Responses = []models.PlayerResponse{{DoorID: "door-1", Content: "I would knock"}}
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p1", "I would knock again"); !errors.Is(err, ErrAlreadyResponded) {
+		t.Errorf("Expected ErrAlreadyResponded, got %v", err)
+	}
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p9", "I would knock"); !errors.Is(err, ErrPlayerNotInSession) {
+		t.Errorf("Expected ErrPlayerNotInSession, got %v", err)
+	}
+
+	session.CurrentDoor = nil
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p2", "I would knock"); !errors.Is(err, ErrNoActiveDoor) {
+		t.Errorf("Expected ErrNoActiveDoor, got %v", err)
+	}
+}
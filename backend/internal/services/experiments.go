@@ -0,0 +1,206 @@
+package services
+
+import (
+	"crypto/sha256"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// DefaultExperiments are the experiments that can be switched on with EXPERIMENTS
+var DefaultExperiments = []models.Experiment{
+	{
+		ID:   "response-timer",
+		Unit: models.ExperimentUnitSession,
+		Variants: []models.ExperimentVariant{
+			{Name: "control", Weight: 50, Tuning: models.GameTuning{ResponseTimeLimit: ResponseTimeLimit}},
+			{Name: "short", Weight: 50, Tuning: models.GameTuning{ResponseTimeLimit: 45 * time.Second}},
+		},
+	},
+	{
+		ID:   "shortcut-threshold",
+		Unit: models.ExperimentUnitPlayer,
+		Variants: []models.ExperimentVariant{
+			{Name: "control", Weight: 50, Tuning: models.GameTuning{ShortcutThreshold: DefaultShortcutThreshold}},
+			{Name: "lenient", Weight: 50, Tuning: models.GameTuning{ShortcutThreshold: 60}},
+		},
+	},
+}
+
+// ExperimentService buckets sessions into experiment variants and tunes their games to match
+type ExperimentService interface {
+	// Assign picks the session's variant of every running experiment, keyed by experiment ID
+	Assign(session *models.GameSession) map[string]string
+	// Tuning merges the tuning of the session's variants
+	Tuning(session *models.GameSession) models.GameTuning
+	// ObserveScore records a scored response against the session's variants
+	ObserveScore(session *models.GameSession, score int)
+	// ObserveCompletion records a finished game against the session's variants
+	ObserveCompletion(session *models.GameSession)
+}
+
+// ExperimentServiceImpl implements the ExperimentService interface
+type ExperimentServiceImpl struct {
+	experiments []models.Experiment
+	collector   *monitoring.MetricsCollector
+}
+
+// NewExperimentService creates an experiment service running the given experiments
+func NewExperimentService(experiments []models.Experiment) ExperimentService {
+	return &ExperimentServiceImpl{
+		experiments: experiments,
+		collector:   monitoring.GetGlobalMetricsCollector(),
+	}
+}
+
+// SelectExperiments looks up experiments by ID among the default experiments
+func SelectExperiments(ids []string) ([]models.Experiment, error) {
+	selected := make([]models.Experiment, 0, len(ids))
+	for _, id := range ids {
+		found := false
+		for _, experiment := range DefaultExperiments {
+			if experiment.ID == id {
+				selected = append(selected, experiment)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown experiment %q", id)
+		}
+	}
+	return selected, nil
+}
+
+// WithExperiments runs A/B experiments on new sessions
+func WithExperiments(experiments ExperimentService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.experiments = experiments
+	}
+}
+
+// Assign buckets the session into each experiment by hashing the experiment ID with the
+// session or creator ID, so the same unit always lands in the same variant
+func (e *ExperimentServiceImpl) Assign(session *models.GameSession) map[string]string {
+	if len(e.experiments) == 0 {
+		return nil
+	}
+
+	assigned := make(map[string]string, len(e.experiments))
+	for _, experiment := range e.experiments {
+		unitID := session.SessionID
+		if experiment.Unit == models.ExperimentUnitPlayer {
+			unitID = session.HostID
+		}
+		if variant := bucketVariant(experiment, unitID); variant != nil {
+			assigned[experiment.ID] = variant.Name
+		}
+	}
+	return assigned
+}
+
+// Tuning merges the tuning of the session's variants. Experiments that have since been switched
+// off no longer tune the game.
+func (e *ExperimentServiceImpl) Tuning(session *models.GameSession) models.GameTuning {
+	var tuning models.GameTuning
+	for _, experiment := range e.experiments {
+		name, ok := session.Experiments[experiment.ID]
+		if !ok {
+			continue
+		}
+		for _, variant := range experiment.Variants {
+			if variant.Name != name {
+				continue
+			}
+			if variant.Tuning.ResponseTimeLimit > 0 {
+				tuning.ResponseTimeLimit = variant.Tuning.ResponseTimeLimit
+			}
+			if variant.Tuning.ShortcutThreshold > 0 {
+				tuning.ShortcutThreshold = variant.Tuning.ShortcutThreshold
+			}
+		}
+	}
+	return tuning
+}
+
+// ObserveScore records the score in a histogram per experiment variant
+func (e *ExperimentServiceImpl) ObserveScore(session *models.GameSession, score int) {
+	for experiment, variant := range session.Experiments {
+		e.collector.NewHistogramWithBuckets("experiment_response_score", "Scores of responses in sessions enrolled in an experiment",
+			map[string]string{"experiment": experiment, "variant": variant}, scoreBuckets).Observe(float64(score))
+	}
+}
+
+// ObserveCompletion counts the finished game, and how long it took, per experiment variant
+func (e *ExperimentServiceImpl) ObserveCompletion(session *models.GameSession) {
+	for experiment, variant := range session.Experiments {
+		labels := map[string]string{"experiment": experiment, "variant": variant}
+		e.collector.NewCounter("experiment_games_completed_total", "Games completed in sessions enrolled in an experiment", labels).Inc()
+		if session.StartedAt != nil && session.CompletedAt != nil {
+			e.collector.NewHistogram("experiment_game_duration_seconds", "Length of games in sessions enrolled in an experiment", labels).
+				Observe(session.CompletedAt.Sub(*session.StartedAt).Seconds())
+		}
+	}
+}
+
+// scoreBuckets spread response scores evenly over their 0-100 range
+var scoreBuckets = []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+
+// bucketVariant deterministically picks a variant for the unit, in proportion to the weights
+func bucketVariant(experiment models.Experiment, unitID string) *models.ExperimentVariant {
+	total := 0
+	for _, variant := range experiment.Variants {
+		if variant.Weight > 0 {
+			total += variant.Weight
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	hash := sha256.Sum256([]byte(experiment.ID + ":" + unitID))
+	point := int(binary.BigEndian.Uint64(hash[:8]) % uint64(total))
+	for i, variant := range experiment.Variants {
+		if variant.Weight <= 0 {
+			continue
+		}
+		if point < variant.Weight {
+			return &experiment.Variants[i]
+		}
+		point -= variant.Weight
+	}
+	return nil
+}
+
+// tuning returns the session's experiment tuning, empty when no experiments run
+func (s *GameServiceImpl) tuning(session *models.GameSession) models.GameTuning {
+	if s.experiments == nil || len(session.Experiments) == 0 {
+		return models.GameTuning{}
+	}
+	return s.experiments.Tuning(session)
+}
+
+// responseTimeLimit is how long the session's players have to answer a door
+func (s *GameServiceImpl) responseTimeLimit(session *models.GameSession) time.Duration {
+	if limit := s.tuning(session).ResponseTimeLimit; limit > 0 {
+		return limit
+	}
+	return ResponseTimeLimit
+}
+
+// shortcutThreshold is the score above which a response shortens the player's path
+func (s *GameServiceImpl) shortcutThreshold(session *models.GameSession) int {
+	if threshold := s.tuning(session).ShortcutThreshold; threshold > 0 {
+		return threshold
+	}
+	return DefaultShortcutThreshold
+}
+
+// observeExperimentScore tags a scored response with the session's experiment variants
+func (s *GameServiceImpl) observeExperimentScore(session *models.GameSession, score int) {
+	if s.experiments != nil && len(session.Experiments) > 0 {
+		s.experiments.ObserveScore(session, score)
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBucketVariant_IsDeterministicAndWeighted(t *testing.T) {
+	experiment := models.Experiment{
+		ID: "timer",
+		Variants: []models.ExperimentVariant{
+			{Name: "control", Weight: 90},
+			{Name: "disabled", Weight: 0},
+			{Name: "short", Weight: 10},
+		},
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		unitID := fmt.Sprintf("session-%d", i)
+		variant := bucketVariant(experiment, unitID)
+		if again := bucketVariant(experiment, unitID); again.Name != variant.Name {
+			t.Fatalf("Expected %s to stay in %s, got %s", unitID, variant.Name, again.Name)
+		}
+		counts[variant.Name]++
+	}
+
+	if counts["disabled"] != 0 {
+		t.Errorf("Expected no units in a zero-weight variant, got %d", counts["disabled"])
+	}
+	if counts["short"] < 140 || counts["short"] > 260 {
+		t.Errorf("Expected about a tenth of units in the short variant, got %d of 2000", counts["short"])
+	}
+
+	if variant := bucketVariant(models.Experiment{ID: "empty"}, "session-1"); variant != nil {
+		t.Errorf("Expected no variant for an experiment without weights, got %+v", variant)
+	}
+}
+
+func TestCreateSession_AssignsExperiments(t *testing.T) {
+	ctx := context.Background()
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithExperiments(NewExperimentService(DefaultExperiments)),
+	)
+
+	var sessions []*models.GameSession
+	for i := 0; i < 10; i++ {
+		session, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Player 1", nil, "en", models.SessionSettings{})
+		if err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+		if session.Experiments["response-timer"] == "" || session.Experiments["shortcut-threshold"] == "" {
+			t.Fatalf("Expected the session to be enrolled in every experiment, got %v", session.Experiments)
+		}
+		sessions = append(sessions, session)
+	}
+
+	// Player experiments follow the creator from session to session
+	for _, session := range sessions[1:] {
+		if session.Experiments["shortcut-threshold"] != sessions[0].Experiments["shortcut-threshold"] {
+			t.Errorf("Expected p1 to keep one shortcut-threshold variant, got %v and %v", sessions[0].Experiments, session.Experiments)
+		}
+	}
+}
+
+func TestExperimentTuning(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithExperiments(NewExperimentService(DefaultExperiments)),
+	).(*GameServiceImpl)
+
+	tests := []struct {
+		name              string
+		experiments       map[string]string
+		expectedLimit     time.Duration
+		expectedThreshold int
+	}{
+		{"not enrolled", nil, ResponseTimeLimit, DefaultShortcutThreshold},
+		{"short timer", map[string]string{"response-timer": "short"}, 45 * time.Second, DefaultShortcutThreshold},
+		{"lenient shortcut", map[string]string{"response-timer": "control", "shortcut-threshold": "lenient"}, ResponseTimeLimit, 60},
+		{"retired experiment", map[string]string{"door-count": "long"}, ResponseTimeLimit, DefaultShortcutThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &models.GameSession{Experiments: tt.experiments}
+			if limit := gameService.responseTimeLimit(session); limit != tt.expectedLimit {
+				t.Errorf("Expected a %s time limit, got %s", tt.expectedLimit, limit)
+			}
+			if threshold := gameService.shortcutThreshold(session); threshold != tt.expectedThreshold {
+				t.Errorf("Expected a shortcut threshold of %d, got %d", tt.expectedThreshold, threshold)
+			}
+		})
+	}
+}
+
+func TestPresentDoorToSession_UsesExperimentTimer(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Experiments = map[string]string{"response-timer": "short"}
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithExperiments(NewExperimentService(DefaultExperiments)),
+	)
+
+	if err := gameService.PresentDoorToSession(ctx, "s1", &models.Door{DoorID: "shared"}); err != nil {
+		t.Fatalf("PresentDoorToSession failed: %v", err)
+	}
+
+	remaining := time.Until(*gameSessionRepo.sessions["s1"].RoundDeadline)
+	if remaining > 45*time.Second || remaining < 40*time.Second {
+		t.Errorf("Expected the short variant's 45s deadline, got %s remaining", remaining)
+	}
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultExportChunkSize is how many records an export reads from the database at a time
+const DefaultExportChunkSize = 500
+
+// ErrInvalidExportFormat is returned when an export is requested in a format other than CSV or NDJSON
+var ErrInvalidExportFormat = errors.New("export format must be csv or ndjson")
+
+// ExportService exports leaderboard and session data for offline analysis
+type ExportService interface {
+	ExportLeaderboard(ctx context.Context, filter models.ExportFilter) (*Export, error)
+	ExportSessions(ctx context.Context, filter models.ExportFilter) (*Export, error)
+}
+
+// ExportServiceImpl implements the ExportService interface
+type ExportServiceImpl struct {
+	repo      repositories.ExportRepository
+	chunkSize int
+}
+
+// NewExportService creates an export service reading chunkSize records at a time
+func NewExportService(repo repositories.ExportRepository, chunkSize int) ExportService {
+	if chunkSize <= 0 {
+		chunkSize = DefaultExportChunkSize
+	}
+	return &ExportServiceImpl{repo: repo, chunkSize: chunkSize}
+}
+
+// exportChunk reads the records after a cursor, returning them with the cursor of the last one
+type exportChunk func(ctx context.Context, after string) ([]interface{}, string, error)
+
+// Export is a data export that is read from the database one chunk at a time while it is
+// written. The first chunk is read up front, so a bad cursor or an unavailable database is
+// reported before anything is written.
+type Export struct {
+	format  models.ExportFormat
+	header  []string
+	rows    func(record interface{}) [][]string
+	next    exportChunk
+	pending []interface{}
+	cursor  string
+}
+
+// ExportLeaderboard exports leaderboard entries, oldest first
+func (s *ExportServiceImpl) ExportLeaderboard(ctx context.Context, filter models.ExportFilter) (*Export, error) {
+	next := func(ctx context.Context, after string) ([]interface{}, string, error) {
+		chunkFilter := filter
+		chunkFilter.After = after
+		entries, err := s.repo.LeaderboardChunk(ctx, chunkFilter, s.chunkSize)
+		if err != nil || len(entries) == 0 {
+			return nil, "", err
+		}
+		records := make([]interface{}, len(entries))
+		for i, entry := range entries {
+			records[i] = entry
+		}
+		return records, entries[len(entries)-1].ID.Hex(), nil
+	}
+	return s.open(ctx, filter, leaderboardExportHeader, leaderboardExportRows, next)
+}
+
+// ExportSessions exports session summaries, oldest first
+func (s *ExportServiceImpl) ExportSessions(ctx context.Context, filter models.ExportFilter) (*Export, error) {
+	next := func(ctx context.Context, after string) ([]interface{}, string, error) {
+		chunkFilter := filter
+		chunkFilter.After = after
+		sessions, err := s.repo.SessionChunk(ctx, chunkFilter, s.chunkSize)
+		if err != nil || len(sessions) == 0 {
+			return nil, "", err
+		}
+		records := make([]interface{}, len(sessions))
+		for i, session := range sessions {
+			records[i] = sessionExport(session)
+		}
+		return records, sessions[len(sessions)-1].ID.Hex(), nil
+	}
+	return s.open(ctx, filter, sessionExportHeader, sessionExportRows, next)
+}
+
+// open validates the export and reads its first chunk
+func (s *ExportServiceImpl) open(ctx context.Context, filter models.ExportFilter, header []string, rows func(interface{}) [][]string, next exportChunk) (*Export, error) {
+	if filter.Format != models.ExportCSV && filter.Format != models.ExportNDJSON {
+		return nil, ErrInvalidExportFormat
+	}
+
+	export := &Export{format: filter.Format, header: header, rows: rows, next: next}
+	records, cursor, err := next(ctx, filter.After)
+	if err != nil {
+		return nil, err
+	}
+	export.pending, export.cursor = records, cursor
+	return export, nil
+}
+
+// ContentType is the MIME type of the export's format
+func (e *Export) ContentType() string {
+	if e.format == models.ExportCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Stream writes the export to w, reading the next chunk once the previous one has been written.
+// Writers with a Flush method are flushed after every chunk.
+func (e *Export) Stream(ctx context.Context, w io.Writer) error {
+	var csvWriter *csv.Writer
+	if e.format == models.ExportCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(e.header); err != nil {
+			return fmt.Errorf("failed to write export header: %w", err)
+		}
+	}
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(interface{ Flush() error })
+
+	for len(e.pending) > 0 {
+		for _, record := range e.pending {
+			var err error
+			if csvWriter != nil {
+				err = csvWriter.WriteAll(e.rows(record))
+			} else {
+				err = encoder.Encode(record)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+		if flusher != nil {
+			if err := flusher.Flush(); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+
+		records, cursor, err := e.next(ctx, e.cursor)
+		if err != nil {
+			return fmt.Errorf("export stopped after cursor %s: %w", e.cursor, err)
+		}
+		e.pending, e.cursor = records, cursor
+	}
+	if csvWriter != nil {
+		csvWriter.Flush()
+		return csvWriter.Error()
+	}
+	return nil
+}
+
+// leaderboardExportHeader names the CSV columns of exported leaderboard entries
+var leaderboardExportHeader = []string{"cursor", "playerId", "username", "sessionId", "gameMode", "theme",
+	"totalScore", "averageScore", "doorsCompleted", "completionTimeSeconds", "completedAt", "experiments"}
+
+// leaderboardExportRows writes a leaderboard entry as a CSV row
+func leaderboardExportRows(record interface{}) [][]string {
+	entry := record.(*models.LeaderboardEntry)
+	theme := ""
+	if entry.Theme != nil {
+		theme = *entry.Theme
+	}
+	return [][]string{{
+		entry.ID.Hex(),
+		entry.PlayerID,
+		entry.Username,
+		entry.SessionID,
+		string(entry.GameMode),
+		theme,
+		strconv.Itoa(entry.TotalScore),
+		strconv.FormatFloat(entry.AverageScore, 'f', 2, 64),
+		strconv.Itoa(entry.DoorsCompleted),
+		strconv.FormatFloat(entry.CompletionTime.Seconds(), 'f', 0, 64),
+		entry.CompletedAt.UTC().Format(time.RFC3339),
+		formatExperiments(entry.Experiments),
+	}}
+}
+
+// sessionExportHeader names the CSV columns of exported sessions, one row per player
+var sessionExportHeader = []string{"cursor", "sessionId", "mode", "theme", "locale", "status", "winnerId",
+	"createdAt", "startedAt", "completedAt", "experiments",
+	"playerId", "username", "isBot", "totalScore", "doorsCompleted", "averageScore"}
+
+// sessionExportRows writes a session as one CSV row per player
+func sessionExportRows(record interface{}) [][]string {
+	session := record.(*models.SessionExport)
+	columns := []string{
+		session.Cursor,
+		session.SessionID,
+		string(session.Mode),
+		session.Theme,
+		session.Locale,
+		string(session.Status),
+		session.WinnerID,
+		session.CreatedAt.UTC().Format(time.RFC3339),
+		formatExportTime(session.StartedAt),
+		formatExportTime(session.CompletedAt),
+		formatExperiments(session.Experiments),
+	}
+
+	rows := make([][]string, 0, len(session.Players))
+	for _, player := range session.Players {
+		row := append(append([]string(nil), columns...),
+			player.PlayerID,
+			player.Username,
+			strconv.FormatBool(player.IsBot),
+			strconv.Itoa(player.TotalScore),
+			strconv.Itoa(player.DoorsCompleted),
+			strconv.FormatFloat(player.AverageScore, 'f', 2, 64),
+		)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// sessionExport summarises a session for export, leaving out what players wrote
+func sessionExport(session *models.GameSession) *models.SessionExport {
+	export := &models.SessionExport{
+		Cursor:      session.ID.Hex(),
+		SessionID:   session.SessionID,
+		Mode:        session.Mode,
+		Locale:      session.Locale,
+		Status:      session.Status,
+		WinnerID:    session.WinnerID,
+		CreatedAt:   session.CreatedAt,
+		StartedAt:   session.StartedAt,
+		CompletedAt: session.CompletedAt,
+		Experiments: session.Experiments,
+		Players:     make([]models.SessionExportPlayer, 0, len(session.Players)),
+	}
+	if session.Theme != nil {
+		export.Theme = *session.Theme
+	}
+
+	for _, player := range session.Players {
+		var averageScore float64
+		if len(player.Responses) > 0 {
+			averageScore = float64(player.TotalScore) / float64(len(player.Responses))
+		}
+		export.Players = append(export.Players, models.SessionExportPlayer{
+			PlayerID:       player.PlayerID,
+			Username:       player.Username,
+			IsBot:          player.IsBot,
+			TotalScore:     player.TotalScore,
+			DoorsCompleted: len(player.Responses),
+			AverageScore:   averageScore,
+		})
+	}
+	return export
+}
+
+// formatExportTime writes an optional time as RFC 3339, or nothing
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatExperiments writes experiment variants as "experiment=variant" pairs separated by semicolons
+func formatExperiments(experiments map[string]string) string {
+	pairs := make([]string, 0, len(experiments))
+	for experiment, variant := range experiments {
+		pairs = append(pairs, experiment+"="+variant)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"dumdoors-backend/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// pagedExportRepository serves fixed records in chunks, counting the chunks read
+type pagedExportRepository struct {
+	entries  []*models.LeaderboardEntry
+	sessions []*models.GameSession
+	chunks   int
+}
+
+func (r *pagedExportRepository) LeaderboardChunk(ctx context.Context, filter models.ExportFilter, limit int) ([]*models.LeaderboardEntry, error) {
+	r.chunks++
+	var chunk []*models.LeaderboardEntry
+	for _, entry := range r.entries {
+		if entry.ID.Hex() > filter.After && len(chunk) < limit {
+			chunk = append(chunk, entry)
+		}
+	}
+	return chunk, nil
+}
+
+func (r *pagedExportRepository) SessionChunk(ctx context.Context, filter models.ExportFilter, limit int) ([]*models.GameSession, error) {
+	r.chunks++
+	var chunk []*models.GameSession
+	for _, session := range r.sessions {
+		if session.ID.Hex() > filter.After && len(chunk) < limit {
+			chunk = append(chunk, session)
+		}
+	}
+	return chunk, nil
+}
+
+// exportID returns a fixed, ordered document ID
+func exportID(i int) primitive.ObjectID {
+	id, _ := primitive.ObjectIDFromHex(fmt.Sprintf("%024x", i))
+	return id
+}
+
+func TestExportLeaderboard_StreamsCSVInChunks(t *testing.T) {
+	completedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := &pagedExportRepository{}
+	for i := 1; i <= 5; i++ {
+		repo.entries = append(repo.entries, &models.LeaderboardEntry{
+			ID:             exportID(i),
+			PlayerID:       fmt.Sprintf("p%d", i),
+			Username:       fmt.Sprintf("Player, %d", i),
+			TotalScore:     i * 100,
+			AverageScore:   float64(i) * 10,
+			DoorsCompleted: i,
+			GameMode:       models.GameModeMultiplayer,
+			CompletionTime: 90 * time.Second,
+			CompletedAt:    completedAt,
+			Experiments:    map[string]string{"response-timer": "short", "shortcut-threshold": "control"},
+		})
+	}
+
+	export, err := NewExportService(repo, 2).ExportLeaderboard(context.Background(), models.ExportFilter{Format: models.ExportCSV})
+	if err != nil {
+		t.Fatalf("ExportLeaderboard failed: %v", err)
+	}
+	var out bytes.Buffer
+	if err := export.Stream(context.Background(), &out); err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected a header and 5 rows, got %d lines:\n%s", len(lines), out.String())
+	}
+	if !strings.HasPrefix(lines[0], "cursor,playerId,username") {
+		t.Errorf("Expected the CSV header first, got %s", lines[0])
+	}
+	expected := exportID(1).Hex() + `,p1,"Player, 1",,multiplayer,,100,10.00,1,90,2024-03-01T12:00:00Z,response-timer=short;shortcut-threshold=control`
+	if lines[1] != expected {
+		t.Errorf("Expected row\n%s\ngot\n%s", expected, lines[1])
+	}
+	if repo.chunks != 4 {
+		t.Errorf("Expected 3 chunks of 2 and an empty one to end the export, got %d reads", repo.chunks)
+	}
+}
+
+func TestExportSessions_NDJSONLeavesOutResponses(t *testing.T) {
+	repo := &pagedExportRepository{sessions: []*models.GameSession{
+		{ID: exportID(1), SessionID: "s1", Status: models.GameStatusCompleted, Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "Player 1", TotalScore: 150, Responses: []models.PlayerResponse{
+				{Content: "A secret answer", AIScore: 70},
+				{Content: "Another", AIScore: 80},
+			}},
+		}},
+		{ID: exportID(2), SessionID: "s2", Status: models.GameStatusWaiting},
+	}}
+
+	export, err := NewExportService(repo, 10).ExportSessions(context.Background(), models.ExportFilter{Format: models.ExportNDJSON})
+	if err != nil {
+		t.Fatalf("ExportSessions failed: %v", err)
+	}
+	var out bytes.Buffer
+	if err := export.Stream(context.Background(), &out); err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	if strings.Contains(out.String(), "secret") {
+		t.Errorf("Expected response text to be left out, got %s", out.String())
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per session, got %d", len(lines))
+	}
+	var first models.SessionExport
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Expected JSON lines, got %v", err)
+	}
+	if first.Cursor != exportID(1).Hex() || len(first.Players) != 1 || first.Players[0].AverageScore != 75 || first.Players[0].DoorsCompleted != 2 {
+		t.Errorf("Expected the summarised session, got %+v", first)
+	}
+
+	// Resuming after the first session exports only the second
+	resumed, err := NewExportService(repo, 10).ExportSessions(context.Background(), models.ExportFilter{Format: models.ExportNDJSON, After: first.Cursor})
+	if err != nil {
+		t.Fatalf("ExportSessions failed: %v", err)
+	}
+	out.Reset()
+	resumed.Stream(context.Background(), &out)
+	if !strings.Contains(out.String(), `"sessionId":"s2"`) || strings.Contains(out.String(), `"sessionId":"s1"`) {
+		t.Errorf("Expected the resumed export to start after s1, got %s", out.String())
+	}
+}
+
+func TestExport_RejectsUnknownFormat(t *testing.T) {
+	repo := &pagedExportRepository{}
+	_, err := NewExportService(repo, 10).ExportLeaderboard(context.Background(), models.ExportFilter{Format: "xml"})
+	if !errors.Is(err, ErrInvalidExportFormat) {
+		t.Errorf("Expected ErrInvalidExportFormat, got %v", err)
+	}
+	if repo.chunks != 0 {
+		t.Errorf("Expected nothing to be read for a bad request, got %d reads", repo.chunks)
+	}
+}
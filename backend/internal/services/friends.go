@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"strings"
+)
+
+// MaxFriends caps the size of a friend list, which also bounds friends-only leaderboard queries
+const MaxFriends = 200
+
+// Friend list errors
+var (
+	ErrInvalidFriend     = errors.New("players cannot add themselves as a friend")
+	ErrAlreadyFriends    = errors.New("player is already on the friend list")
+	ErrNotFriends        = errors.New("player is not on the friend list")
+	ErrFriendListFull    = errors.New("friend list is full")
+	ErrFriendsNotEnabled = errors.New("friend lists are not enabled")
+)
+
+// FriendService manages players' friend lists
+type FriendService interface {
+	AddFriend(ctx context.Context, playerID, friendID string) error
+	RemoveFriend(ctx context.Context, playerID, friendID string) error
+	ListFriends(ctx context.Context, playerID string) ([]models.Friendship, error)
+	// CircleOf returns the player followed by everyone on their friend list
+	CircleOf(ctx context.Context, playerID string) ([]string, error)
+}
+
+// FriendServiceImpl implements the FriendService interface
+type FriendServiceImpl struct {
+	friendRepo repositories.FriendRepository
+}
+
+// NewFriendService creates a new friend service
+func NewFriendService(friendRepo repositories.FriendRepository) FriendService {
+	return &FriendServiceImpl{
+		friendRepo: friendRepo,
+	}
+}
+
+// AddFriend puts another player on the player's friend list
+func (s *FriendServiceImpl) AddFriend(ctx context.Context, playerID, friendID string) error {
+	friendID = strings.TrimSpace(friendID)
+	if friendID == "" || friendID == playerID {
+		return ErrInvalidFriend
+	}
+
+	count, err := s.friendRepo.Count(ctx, playerID)
+	if err != nil {
+		return err
+	}
+	if count >= MaxFriends {
+		return ErrFriendListFull
+	}
+
+	added, err := s.friendRepo.Add(ctx, playerID, friendID)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return ErrAlreadyFriends
+	}
+	return nil
+}
+
+// RemoveFriend takes a player off the player's friend list
+func (s *FriendServiceImpl) RemoveFriend(ctx context.Context, playerID, friendID string) error {
+	removed, err := s.friendRepo.Remove(ctx, playerID, friendID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotFriends
+	}
+	return nil
+}
+
+// ListFriends returns the player's friend list
+func (s *FriendServiceImpl) ListFriends(ctx context.Context, playerID string) ([]models.Friendship, error) {
+	return s.friendRepo.List(ctx, playerID)
+}
+
+// CircleOf returns the players on a friends-only leaderboard: the player and their friends
+func (s *FriendServiceImpl) CircleOf(ctx context.Context, playerID string) ([]string, error) {
+	friends, err := s.friendRepo.List(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	circle := make([]string, 0, len(friends)+1)
+	circle = append(circle, playerID)
+	for _, friend := range friends {
+		circle = append(circle, friend.FriendID)
+	}
+	return circle, nil
+}
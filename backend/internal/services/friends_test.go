@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+// MockFriendRepository keeps friend lists in memory
+type MockFriendRepository struct {
+	friendships []models.Friendship
+}
+
+func (m *MockFriendRepository) Add(ctx context.Context, playerID, friendID string) (bool, error) {
+	for _, friendship := range m.friendships {
+		if friendship.PlayerID == playerID && friendship.FriendID == friendID {
+			return false, nil
+		}
+	}
+	m.friendships = append(m.friendships, models.Friendship{PlayerID: playerID, FriendID: friendID})
+	return true, nil
+}
+
+func (m *MockFriendRepository) Remove(ctx context.Context, playerID, friendID string) (bool, error) {
+	for i, friendship := range m.friendships {
+		if friendship.PlayerID == playerID && friendship.FriendID == friendID {
+			m.friendships = append(m.friendships[:i], m.friendships[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockFriendRepository) List(ctx context.Context, playerID string) ([]models.Friendship, error) {
+	friends := []models.Friendship{}
+	for _, friendship := range m.friendships {
+		if friendship.PlayerID == playerID {
+			friends = append(friends, friendship)
+		}
+	}
+	return friends, nil
+}
+
+func (m *MockFriendRepository) Count(ctx context.Context, playerID string) (int64, error) {
+	friends, _ := m.List(ctx, playerID)
+	return int64(len(friends)), nil
+}
+
+// filterRecordingLeaderboardRepository records the filter of the last leaderboard query
+type filterRecordingLeaderboardRepository struct {
+	*MockLeaderboardRepository
+	lastFilter models.LeaderboardFilter
+}
+
+func (m *filterRecordingLeaderboardRepository) GetFastestCompletions(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
+	m.lastFilter = filter
+	return m.MockLeaderboardRepository.GetFastestCompletions(ctx, filter)
+}
+
+func TestFriendService_AddAndRemove(t *testing.T) {
+	ctx := context.Background()
+	friends := NewFriendService(&MockFriendRepository{})
+
+	if err := friends.AddFriend(ctx, "p1", "p2"); err != nil {
+		t.Fatalf("AddFriend failed: %v", err)
+	}
+	if err := friends.AddFriend(ctx, "p1", "p2"); !errors.Is(err, ErrAlreadyFriends) {
+		t.Errorf("Expected ErrAlreadyFriends, got %v", err)
+	}
+	if err := friends.AddFriend(ctx, "p1", "p1"); !errors.Is(err, ErrInvalidFriend) {
+		t.Errorf("Expected ErrInvalidFriend adding yourself, got %v", err)
+	}
+
+	// Friend lists are one-way
+	if list, _ := friends.ListFriends(ctx, "p2"); len(list) != 0 {
+		t.Errorf("Expected p2's friend list to be empty, got %+v", list)
+	}
+
+	if err := friends.RemoveFriend(ctx, "p1", "p2"); err != nil {
+		t.Fatalf("RemoveFriend failed: %v", err)
+	}
+	if err := friends.RemoveFriend(ctx, "p1", "p2"); !errors.Is(err, ErrNotFriends) {
+		t.Errorf("Expected ErrNotFriends, got %v", err)
+	}
+}
+
+func TestFriendService_CapsFriendList(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockFriendRepository{}
+	for i := 0; i < MaxFriends; i++ {
+		repo.friendships = append(repo.friendships, models.Friendship{PlayerID: "p1", FriendID: string(rune('a' + i%26))})
+	}
+	friends := NewFriendService(repo)
+
+	if err := friends.AddFriend(ctx, "p1", "one-too-many"); !errors.Is(err, ErrFriendListFull) {
+		t.Errorf("Expected ErrFriendListFull, got %v", err)
+	}
+}
+
+func TestLeaderboardService_FriendsOfRestrictsToFriendCircle(t *testing.T) {
+	ctx := context.Background()
+	friendRepo := &MockFriendRepository{}
+	friends := NewFriendService(friendRepo)
+	for _, friendID := range []string{"p2", "p3"} {
+		if err := friends.AddFriend(ctx, "p1", friendID); err != nil {
+			t.Fatalf("AddFriend failed: %v", err)
+		}
+	}
+
+	leaderboardRepo := &filterRecordingLeaderboardRepository{MockLeaderboardRepository: NewMockLeaderboardRepository()}
+	leaderboardService := NewLeaderboardService(leaderboardRepo, NewMockGameSessionRepository(), WithFriendService(friends))
+
+	friendsOf := "p1"
+	if _, err := leaderboardService.GetFastestCompletions(ctx, models.LeaderboardFilter{FriendsOf: &friendsOf}); err != nil {
+		t.Fatalf("GetFastestCompletions failed: %v", err)
+	}
+	players := leaderboardRepo.lastFilter.PlayerIDs
+	if len(players) != 3 || players[0] != "p1" || players[1] != "p2" || players[2] != "p3" {
+		t.Errorf("Expected the player and their friends, got %v", players)
+	}
+
+	// Without a friends filter the leaderboard stays global
+	if _, err := leaderboardService.GetFastestCompletions(ctx, models.LeaderboardFilter{}); err != nil {
+		t.Fatalf("GetFastestCompletions failed: %v", err)
+	}
+	if leaderboardRepo.lastFilter.PlayerIDs != nil {
+		t.Errorf("Expected no player restriction, got %v", leaderboardRepo.lastFilter.PlayerIDs)
+	}
+}
+
+func TestLeaderboardService_FriendsOfNeedsFriendService(t *testing.T) {
+	leaderboardService := NewLeaderboardService(NewMockLeaderboardRepository(), NewMockGameSessionRepository())
+
+	friendsOf := "p1"
+	_, err := leaderboardService.GetGlobalLeaderboard(context.Background(), models.LeaderboardFilter{FriendsOf: &friendsOf})
+	if !errors.Is(err, ErrFriendsNotEnabled) {
+		t.Errorf("Expected ErrFriendsNotEnabled, got %v", err)
+	}
+}
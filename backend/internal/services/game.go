@@ -2,26 +2,55 @@ package services
 
 import (
 	"context"
+	"dumdoors-backend/internal/accessibility"
+	"dumdoors-backend/internal/i18n"
 	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
 	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/tracing"
+	"errors"
 	"fmt"
 	"time"
-
-	"github.com/google/uuid"
 )
 
+// ErrSpectatorReadOnly is returned when a spectator tries to act as a player
+var ErrSpectatorReadOnly = errors.New("spectators cannot submit responses")
+
+// ErrResponseRejected is returned when moderation refuses a submitted response
+var ErrResponseRejected = errors.New("response rejected by moderation")
+
+// ResponseTimeLimit is how long players have to answer a door (requirements 2.5)
+const ResponseTimeLimit = 60 * time.Second
+
 // GameService interface defines the contract for game operations
 type GameService interface {
-	CreateSession(ctx context.Context, mode models.GameMode, creatorID, username string, theme *string) (*models.GameSession, error)
-	JoinSession(ctx context.Context, sessionID, playerID, username string) (*models.GameSession, error)
+	CreateSession(ctx context.Context, mode models.GameMode, creatorID, username string, theme *string, locale string, settings models.SessionSettings) (*models.GameSession, error)
+	JoinSession(ctx context.Context, sessionID, playerID, username, password string) (*models.GameSession, error)
+	JoinAsSpectator(ctx context.Context, sessionID, spectatorID, username string) (*models.GameSession, error)
 	StartGame(ctx context.Context, sessionID string) error
 	StartGameWithFirstDoor(ctx context.Context, sessionID string) error
 	PresentDoorToSession(ctx context.Context, sessionID string, door *models.Door) error
-	SubmitResponse(ctx context.Context, sessionID, playerID, response string) error
-	GetNextDoor(playerID string, currentScore int) (*models.Door, error)
+	SubmitResponse(ctx context.Context, sessionID, playerID, response string) (*models.PlayerResponse, error)
+	CastVote(ctx context.Context, sessionID, voterID, responseID string, stars int) error
+	GetNextDoor(ctx context.Context, playerID string, currentScore int, locale string) (*models.Door, error)
 	CalculatePlayerPath(playerID string, scores []int) error
 	GetSessionStatus(ctx context.Context, sessionID string) (*models.GameSession, error)
+	ResumeSession(ctx context.Context, sessionID, playerID string) (*SessionResume, error)
+	SetPlayerReady(ctx context.Context, sessionID, playerID string, ready bool) (*ReadyStatus, error)
+	KickPlayer(ctx context.Context, sessionID, hostID, playerID string) (*models.GameSession, error)
+	TransferHost(ctx context.Context, sessionID, hostID, newHostID string) (*models.GameSession, error)
+	SetLobbyLocked(ctx context.Context, sessionID, hostID string, locked bool) (*models.GameSession, error)
+	AssignTeam(ctx context.Context, sessionID, hostID, playerID, teamID string) (*models.GameSession, error)
+	BalanceTeams(ctx context.Context, sessionID, hostID string) (*models.GameSession, error)
+	SetPlayerOptions(ctx context.Context, sessionID, playerID string, options models.PlayerOptions) (*models.GameSession, error)
+	PauseGame(ctx context.Context, sessionID, hostID string) (*models.GameSession, error)
+	ResumeGame(ctx context.Context, sessionID, hostID string) (*models.GameSession, error)
+	LeaveSession(ctx context.Context, sessionID, playerID string) (*models.GameSession, error)
 	ValidatePlayerJoin(ctx context.Context, sessionID, playerID string) error
+	LookupJoinCode(ctx context.Context, code string) (*JoinCodeLookup, error)
+	ListOpenSessions(ctx context.Context, limit int) ([]models.OpenSession, error)
+	SnapshotRound(ctx context.Context, sessionID string) (*models.RoundSnapshot, error)
+	RecoverRound(ctx context.Context, snapshot *models.RoundSnapshot) error
 }
 
 // GameServiceImpl implements the GameService interface
@@ -33,11 +62,115 @@ type GameServiceImpl struct {
 	aiClient           AIClient
 	progressService    ProgressService
 	leaderboardService LeaderboardService
+	workerPool         WorkerPool
+	scoringQueue       ScoringQueue
+	scoringBatcher     ScoringBatcher
+	rankingEngine      RankingEngine
+	stateMachine       SessionStateMachine
+	scheduler          DeadlineScheduler
+	backgroundTimeout  time.Duration
+	moderation         ModerationService
+	sessionLocks       *sessionLocks
+	joinCodes          repositories.JoinCodeStore
+	openSessions       repositories.OpenSessionCache
+	profileService     ProfileService
+	achievements       AchievementService
+	pathGraph          PathGraphService
+	botMinThinkTime    time.Duration
+	botMaxThinkTime    time.Duration
+	doorScores         repositories.DoorScoreRepository
+	dedup              DoorDeduplicator
+	themes             ThemeService
+	history            SessionHistoryService
+	revealDisabled     bool
+	devvit             DevvitIntegration
+	scoringStrategy    ScoringStrategy
+	experiments        ExperimentService
+	doorBank           DoorBankService
+	antiCheat          AntiCheatService
+	anomalies          AnomalyService
+	quota              SessionQuotaService
+	routing            RoutingService
+	disconnectGrace    time.Duration
+}
+
+// GameServiceOption configures optional dependencies of the game service
+type GameServiceOption func(*GameServiceImpl)
+
+// WithWorkerPool sets the pool used for background broadcasts and round processing
+func WithWorkerPool(pool WorkerPool) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.workerPool = pool
+	}
+}
+
+// WithScoringQueue sets the queue that smooths scoring requests to the AI service
+func WithScoringQueue(queue ScoringQueue) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.scoringQueue = queue
+	}
+}
+
+// WithScoringBatcher scores responses in batches off the request path; scores are applied
+// and broadcast as they arrive, and a closed round is revealed once its last score lands
+func WithScoringBatcher(batcher ScoringBatcher) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.scoringBatcher = batcher
+	}
+}
+
+// WithRankingEngine sets the engine used for final rankings and performance statistics
+func WithRankingEngine(engine RankingEngine) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.rankingEngine = engine
+	}
+}
+
+// WithDeadlineScheduler sets the scheduler that fires door response deadlines
+func WithDeadlineScheduler(scheduler DeadlineScheduler) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.scheduler = scheduler
+	}
+}
+
+// WithBackgroundTimeout bounds each background task spawned by the game service
+func WithBackgroundTimeout(timeout time.Duration) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.backgroundTimeout = timeout
+	}
+}
+
+// WithModerationService screens submitted responses before they are scored
+func WithModerationService(moderation ModerationService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.moderation = moderation
+	}
+}
+
+// WithProfileService adds each completed game to its players' career statistics
+func WithProfileService(profiles ProfileService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.profileService = profiles
+	}
+}
+
+// WithAchievementService awards achievements as responses are scored and games complete
+func WithAchievementService(achievements AchievementService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.achievements = achievements
+	}
+}
+
+// WithPathGraphService seeds the door graph when a game starts and serves doors along it
+func WithPathGraphService(pathGraph PathGraphService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.pathGraph = pathGraph
+	}
 }
 
 // NewGameService creates a new game service instance
-func NewGameService(gameSessionRepo repositories.GameSessionRepository, doorRepo repositories.DoorRepository, playerPathRepo repositories.PlayerPathRepository, wsManager WebSocketManager, aiClient AIClient, progressService ProgressService, leaderboardService LeaderboardService) GameService {
-	return &GameServiceImpl{
+func NewGameService(gameSessionRepo repositories.GameSessionRepository, doorRepo repositories.DoorRepository, playerPathRepo repositories.PlayerPathRepository, wsManager WebSocketManager, aiClient AIClient, progressService ProgressService, leaderboardService LeaderboardService, opts ...GameServiceOption) GameService {
+	service := &GameServiceImpl{
 		gameSessionRepo:    gameSessionRepo,
 		doorRepo:           doorRepo,
 		playerPathRepo:     playerPathRepo,
@@ -45,13 +178,177 @@ func NewGameService(gameSessionRepo repositories.GameSessionRepository, doorRepo
 		aiClient:           aiClient,
 		progressService:    progressService,
 		leaderboardService: leaderboardService,
+		stateMachine:       NewSessionStateMachine(),
+		backgroundTimeout:  tracing.DefaultDetachedTimeout,
+		sessionLocks:       newSessionLocks(),
+		botMinThinkTime:    DefaultBotMinThinkTime,
+		botMaxThinkTime:    DefaultBotMaxThinkTime,
+		disconnectGrace:    DisconnectGracePeriod,
+	}
+	
+	for _, opt := range opts {
+		opt(service)
 	}
+	
+	if service.workerPool == nil {
+		service.workerPool = NewWorkerPool("game", DefaultWorkerPoolSize, DefaultWorkerPoolQueueSize)
+	}
+	
+	if service.rankingEngine == nil {
+		service.rankingEngine = NewRankingEngine(playerPathRepo)
+	}
+	
+	if service.scoringQueue == nil && aiClient != nil {
+		service.scoringQueue = NewScoringQueue(aiClient, wsManager, DefaultScoringConcurrency, DefaultScoringRatePerSec)
+	}
+	
+	if service.scheduler == nil {
+		service.scheduler = NewInProcessScheduler()
+	}
+	if wsManager != nil {
+		wsManager.OnMessage(MessageTypeCastVote, service.handleVoteMessage)
+		wsManager.OnMessage(MessageTypeSubmitResponse, service.handleSubmitMessage)
+		wsManager.OnMessage(MessageTypeReadyCheck, service.handleReadyMessage)
+		wsManager.OnPresence(service.handlePresence)
+	}
+	
+	// The deadline is claimed by the time it fires, so its task must not be dropped
+	service.scheduler.Handle(func(ctx context.Context, sessionID, doorID string) {
+		if isReadyCountdownKey(doorID) {
+			service.runOrInline(ctx, sessionID, "ready-countdown", func(ctx context.Context) {
+				service.finishReadyCountdown(ctx, sessionID)
+			})
+			return
+		}
+		if playerID, ok := disconnectGracePlayer(doorID); ok {
+			service.runOrInline(ctx, sessionID, "disconnect-grace", func(ctx context.Context) {
+				service.handleDisconnectGrace(ctx, sessionID, playerID)
+			})
+			return
+		}
+		if votingDoorID, ok := votingDeadlineDoor(doorID); ok {
+			service.runOrInline(ctx, sessionID, "voting-timeout", func(ctx context.Context) {
+				service.handleVotingTimeout(ctx, sessionID, votingDoorID)
+			})
+			return
+		}
+		service.runOrInline(ctx, sessionID, "response-timeout", func(ctx context.Context) {
+			service.handleResponseTimeout(ctx, sessionID, doorID)
+		})
+	})
+	
+	return service
 }
 
-// CreateSession creates a new game session
-func (s *GameServiceImpl) CreateSession(ctx context.Context, mode models.GameMode, creatorID, username string, theme *string) (*models.GameSession, error) {
+// runInBackground queues a task on the worker pool, logging it if the pool rejects it.
+// The task gets a context detached from ctx's cancellation but carrying its trace and
+// the session ID, bounded by the service's background timeout.
+func (s *GameServiceImpl) runInBackground(ctx context.Context, sessionID, name string, task func(ctx context.Context)) {
+	traced := tracing.Carry(tracing.WithSessionID(ctx, sessionID))
+	
+	if err := s.workerPool.Submit(name, s.detachedTask(traced, name, task)); err != nil {
+		tracing.Logger(traced).WithOperation(name).Warn(fmt.Sprintf("failed to schedule background task: %v", err))
+	}
+}
+
+// runOrInline queues a task like runInBackground, but runs it on the caller if the pool rejects
+// it, for tasks that nothing else would retry
+func (s *GameServiceImpl) runOrInline(ctx context.Context, sessionID, name string, task func(ctx context.Context)) {
+	traced := tracing.Carry(tracing.WithSessionID(ctx, sessionID))
+	run := s.detachedTask(traced, name, task)
+	
+	if err := s.workerPool.Submit(name, run); err != nil {
+		tracing.Logger(traced).WithOperation(name).Warn(fmt.Sprintf("failed to schedule background task, running it inline: %v", err))
+		run()
+	}
+}
+
+// detachedTask wraps a task so it runs with its own traced, time-bounded context
+func (s *GameServiceImpl) detachedTask(traced context.Context, name string, task func(ctx context.Context)) func() {
+	return func() {
+		taskCtx, cancel := tracing.Detach(traced, name, s.backgroundTimeout)
+		defer cancel()
+		
+		taskCtx, span := tracing.StartSpan(taskCtx, "GameService.background."+name)
+		defer span.End()
+		task(taskCtx)
+	}
+}
+
+// handleSubmitMessage submits a response sent over the player's socket
+func (s *GameServiceImpl) handleSubmitMessage(ctx context.Context, sessionID, playerID string, msg map[string]interface{}) error {
+	response, _ := msg["response"].(string)
+	_, err := s.SubmitResponse(ctx, sessionID, playerID, response)
+	return err
+}
+
+// transition moves a session to a new state, persists it and announces the change to its players
+func (s *GameServiceImpl) transition(ctx context.Context, session *models.GameSession, to models.GameStatus) error {
+	change, err := s.stateMachine.Transition(session, to)
+	if err != nil {
+		return err
+	}
+	
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to save session state %s: %w", to, err)
+	}
+	
+	s.releaseQuota(ctx, session)
+	s.releaseRoute(ctx, session)
+	announceTransition(s.wsManager, change)
+	return nil
+}
+
+// CreateSession creates a new game session. System messages and doors use the session's
+// locale; unsupported locales fall back to English.
+func (s *GameServiceImpl) CreateSession(ctx context.Context, mode models.GameMode, creatorID, username string, theme *string, locale string, settings models.SessionSettings) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.CreateSession", tracing.PlayerIDKey.String(creatorID))
+	defer span.End()
+	
+	if err := validateSessionSettings(mode, settings); err != nil {
+		return nil, err
+	}
+	if settings.BestOf() && settings.Rounds == 0 {
+		settings.Rounds = DefaultMatchRounds
+	}
+	
+	// Sessions can only be played in the catalog's enabled themes
+	if theme != nil && s.themes != nil {
+		if err := s.themes.RequireEnabled(ctx, *theme); err != nil {
+			return nil, err
+		}
+	}
+	
 	// Generate unique session ID
-	sessionID := uuid.New().String()
+	sessionID := random.ID()
+	
+	// Private sessions may be protected by a password, stored only as a hash
+	var passwordHash string
+	if settings.Password != "" {
+		hash, err := hashSessionPassword(settings.Password)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = hash
+		settings.Password = ""
+	}
+	
+	// Busy subreddits and players may already have as many sessions running as they are allowed
+	if s.quota != nil {
+		if err := s.quota.Acquire(ctx, sessionID, creatorID, settings.Post); err != nil {
+			return nil, err
+		}
+	}
+	
+	// A short code is easier to share than the session ID; sessions still work without one
+	var joinCode string
+	if s.joinCodes != nil {
+		code, err := s.issueJoinCode(ctx, sessionID)
+		if err != nil {
+			fmt.Printf("Warning: failed to issue join code: %v\n", err)
+		}
+		joinCode = code
+	}
 	
 	// Create the creator as the first player
 	creator := models.PlayerInfo{
@@ -64,20 +361,39 @@ func (s *GameServiceImpl) CreateSession(ctx context.Context, mode models.GameMod
 		Responses:       []models.PlayerResponse{},
 		IsActive:        true,
 	}
+	if settings.TeamPlay() {
+		creator.TeamID = models.TeamIDs[0]
+	}
 	
 	// Create the game session
 	session := &models.GameSession{
 		SessionID:   sessionID,
 		Mode:        mode,
+		HostID:      creatorID,
+		JoinCode:    joinCode,
 		Theme:       theme,
-		Players:     []models.PlayerInfo{creator},
+		Locale:      i18n.Normalize(locale),
+		Settings:    settings,
+		Players:     append([]models.PlayerInfo{creator}, newBotPlayers(sessionID, settings.BotOpponents)...),
 		Status:      models.GameStatusWaiting,
 		CurrentDoor: nil,
 		CreatedAt:   time.Now(),
 	}
+	session.PasswordHash = passwordHash
+	if s.experiments != nil {
+		session.Experiments = s.experiments.Assign(session)
+	}
 	
 	// Save to database
 	if err := s.gameSessionRepo.Create(ctx, session); err != nil {
+		if joinCode != "" {
+			if releaseErr := s.joinCodes.Release(ctx, joinCode); releaseErr != nil {
+				fmt.Printf("Warning: failed to release join code: %v\n", releaseErr)
+			}
+		}
+		if s.quota != nil {
+			s.quota.Release(ctx, sessionID)
+		}
 		return nil, fmt.Errorf("failed to create game session: %w", err)
 	}
 	
@@ -86,12 +402,21 @@ func (s *GameServiceImpl) CreateSession(ctx context.Context, mode models.GameMod
 		// Log error but don't fail session creation
 		fmt.Printf("Warning: failed to create player in Neo4j: %v\n", err)
 	}
+	for _, bot := range session.Players[1:] {
+		if err := s.playerPathRepo.CreatePlayer(ctx, bot.PlayerID, bot.Username); err != nil {
+			fmt.Printf("Warning: failed to create bot in Neo4j: %v\n", err)
+		}
+	}
 	
 	return session, nil
 }
 
-// JoinSession allows a player to join an existing session
-func (s *GameServiceImpl) JoinSession(ctx context.Context, sessionID, playerID, username string) (*models.GameSession, error) {
+// JoinSession allows a player to join an existing session. The password is checked only
+// for password-protected sessions.
+func (s *GameServiceImpl) JoinSession(ctx context.Context, sessionID, playerID, username, password string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.JoinSession", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+	
 	// Validate that the player can join
 	if err := s.ValidatePlayerJoin(ctx, sessionID, playerID); err != nil {
 		return nil, err
@@ -104,7 +429,11 @@ func (s *GameServiceImpl) JoinSession(ctx context.Context, sessionID, playerID,
 	}
 	
 	if session == nil {
-		return nil, fmt.Errorf("session not found")
+		return nil, ErrSessionNotFound
+	}
+	
+	if err := checkSessionPassword(session, password); err != nil {
+		return nil, err
 	}
 	
 	// Create new player info
@@ -118,6 +447,9 @@ func (s *GameServiceImpl) JoinSession(ctx context.Context, sessionID, playerID,
 		Responses:       []models.PlayerResponse{},
 		IsActive:        true,
 	}
+	if session.Settings.TeamPlay() {
+		newPlayer.TeamID = openTeam(session)
+	}
 	
 	// Add player to session
 	if err := s.gameSessionRepo.AddPlayerToSession(ctx, sessionID, newPlayer); err != nil {
@@ -142,57 +474,145 @@ func (s *GameServiceImpl) JoinSession(ctx context.Context, sessionID, playerID,
 			Type:      "player-joined",
 			SessionID: sessionID,
 			PlayerID:  playerID,
-			Data: map[string]interface{}{
-				"playerId": playerID,
-				"username": username,
-				"message":  fmt.Sprintf("%s joined the game", username),
-				"session":  updatedSession,
-			},
+			Data: systemMessage(map[string]interface{}{
+				"playerId":    playerID,
+				"username":    username,
+				"playerInfo":  newPlayer,
+				"playerCount": len(updatedSession.Players),
+			}, updatedSession.Locale, i18n.MsgPlayerJoined, username),
 			Timestamp: time.Now(),
 		}
 		
 		// Broadcast to session (this will be handled gracefully if no WebSocket connections exist yet)
-		go func() {
+		s.runInBackground(ctx, sessionID, "broadcast-player-joined", func(ctx context.Context) {
 			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
 				fmt.Printf("Warning: failed to broadcast player join event: %v\n", err)
 			}
-		}()
+		})
 	}
 	
 	return updatedSession, nil
 }
 
+// JoinAsSpectator adds a read-only viewer to a session. Spectators can watch a game in any
+// phase until it completes and receive its public events, but cannot submit responses.
+func (s *GameServiceImpl) JoinAsSpectator(ctx context.Context, sessionID, spectatorID, username string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.JoinAsSpectator", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(spectatorID))
+	defer span.End()
+	
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	
+	if session.Status == models.GameStatusCompleted {
+		return nil, fmt.Errorf("cannot spectate a completed session")
+	}
+	
+	for _, player := range session.Players {
+		if player.PlayerID == spectatorID {
+			return nil, fmt.Errorf("players cannot spectate their own session")
+		}
+	}
+	
+	// Rejoining as a spectator is a no-op
+	if isSpectator(session, spectatorID) {
+		return session, nil
+	}
+	
+	session.Spectators = append(session.Spectators, models.SpectatorInfo{
+		SpectatorID: spectatorID,
+		Username:    username,
+		JoinedAt:    time.Now(),
+	})
+	
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to add spectator to session: %w", err)
+	}
+	
+	if s.wsManager != nil {
+		event := WebSocketEvent{
+			Type:      "spectator-joined",
+			SessionID: sessionID,
+			Data: map[string]interface{}{
+				"spectatorId":    spectatorID,
+				"username":       username,
+				"spectatorCount": len(session.Spectators),
+			},
+			Timestamp: time.Now(),
+		}
+		
+		s.runInBackground(ctx, sessionID, "broadcast-spectator-joined", func(ctx context.Context) {
+			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+				fmt.Printf("Warning: failed to broadcast spectator join event: %v\n", err)
+			}
+		})
+	}
+	
+	return session, nil
+}
+
+// isSpectator reports whether the ID belongs to one of the session's spectators
+func isSpectator(session *models.GameSession, spectatorID string) bool {
+	for _, spectator := range session.Spectators {
+		if spectator.SpectatorID == spectatorID {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidatePlayerJoin validates that a player can join a session
 func (s *GameServiceImpl) ValidatePlayerJoin(ctx context.Context, sessionID, playerID string) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.ValidatePlayerJoin", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+	
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get session: %w", err)
 	}
 	
 	if session == nil {
-		return fmt.Errorf("session not found")
+		return ErrSessionNotFound
 	}
 	
 	// Check if session is still accepting players
-	if session.Status != models.GameStatusWaiting {
-		return fmt.Errorf("session is not accepting new players")
+	if err := s.stateMachine.Require(session, OpJoinSession); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotAcceptingPlayers, err)
+	}
+	
+	// The host decides who else may join
+	if session.WasKicked(playerID) {
+		return ErrPlayerKicked
+	}
+	if session.Locked {
+		return ErrLobbyLocked
 	}
 	
 	// Check if player is already in the session
 	for _, player := range session.Players {
 		if player.PlayerID == playerID {
-			return fmt.Errorf("player already in session")
+			return ErrAlreadyInSession
 		}
 	}
 	
-	// Check player limit for multiplayer mode (max 8 players)
-	if session.Mode == models.GameModeMultiplayer && len(session.Players) >= 8 {
-		return fmt.Errorf("session is full (maximum 8 players)")
+	// Check player limit for multiplayer mode
+	if session.Mode == models.GameModeMultiplayer && len(session.Players) >= models.MaxMultiplayerPlayers {
+		return fmt.Errorf("%w (maximum %d players)", ErrSessionFull, models.MaxMultiplayerPlayers)
+	}
+	
+	// Team sessions are full once both teams are
+	if session.Settings.TeamPlay() && openTeam(session) == "" {
+		return fmt.Errorf("%w (both teams have %d players)", ErrSessionFull, session.Settings.TeamSize)
 	}
 	
 	// Single player mode should only have 1 player
 	if session.Mode == models.GameModeSinglePlayer && len(session.Players) >= 1 {
-		return fmt.Errorf("single player session already has a player")
+		return fmt.Errorf("%w: single player session already has a player", ErrSessionFull)
 	}
 	
 	return nil
@@ -200,13 +620,16 @@ func (s *GameServiceImpl) ValidatePlayerJoin(ctx context.Context, sessionID, pla
 
 // GetSessionStatus retrieves the current status of a game session
 func (s *GameServiceImpl) GetSessionStatus(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.GetSessionStatus", tracing.SessionIDKey.String(sessionID))
+	defer span.End()
+	
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session status: %w", err)
 	}
 	
 	if session == nil {
-		return nil, fmt.Errorf("session not found")
+		return nil, ErrSessionNotFound
 	}
 	
 	return session, nil
@@ -214,18 +637,21 @@ func (s *GameServiceImpl) GetSessionStatus(ctx context.Context, sessionID string
 
 // StartGame starts a game session
 func (s *GameServiceImpl) StartGame(ctx context.Context, sessionID string) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.StartGame", tracing.SessionIDKey.String(sessionID))
+	defer span.End()
+	
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get session: %w", err)
 	}
 	
 	if session == nil {
-		return fmt.Errorf("session not found")
+		return ErrSessionNotFound
 	}
 	
 	// Validate session can be started
-	if session.Status != models.GameStatusWaiting {
-		return fmt.Errorf("session cannot be started (current status: %s)", session.Status)
+	if err := s.stateMachine.Require(session, OpStartGame); err != nil {
+		return err
 	}
 	
 	// Check minimum players for multiplayer (at least 2)
@@ -233,13 +659,36 @@ func (s *GameServiceImpl) StartGame(ctx context.Context, sessionID string) error
 		return fmt.Errorf("multiplayer session requires at least 2 players")
 	}
 	
-	// Update session status
-	now := time.Now()
-	session.Status = models.GameStatusActive
-	session.StartedAt = &now
+	// Team sessions start with both teams full
+	if session.Settings.TeamPlay() {
+		if err := checkTeamsFull(session); err != nil {
+			return err
+		}
+	}
 	
-	// Save updated session
-	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+	// Sessions with a ready check wait for enough players to confirm they're present
+	if !session.ReadyThresholdMet() {
+		ready, total := session.ReadyCount()
+		return fmt.Errorf("%w: %d of %d players ready, %d%% required", ErrPlayersNotReady, ready, total, session.Settings.ReadyPercent)
+	}
+	session.StartsAt = nil
+	
+	// Sessions without a ready countdown start here rather than when the countdown begins
+	if session.Status == models.GameStatusWaiting {
+		if err := s.transition(ctx, session, models.GameStatusStarting); err != nil {
+			return fmt.Errorf("failed to start game session: %w", err)
+		}
+	}
+	
+	// Place every player at the entry of the session's door graph; doors fall back to the theme without one
+	if s.pathGraph != nil {
+		if err := s.pathGraph.InitializeSession(ctx, session); err != nil {
+			fmt.Printf("Warning: failed to initialize door graph: %v\n", err)
+		}
+	}
+	
+	// Move the session to active, which stamps its start time
+	if err := s.transition(ctx, session, models.GameStatusActive); err != nil {
 		return fmt.Errorf("failed to start game session: %w", err)
 	}
 	
@@ -248,28 +697,40 @@ func (s *GameServiceImpl) StartGame(ctx context.Context, sessionID string) error
 		event := WebSocketEvent{
 			Type:      "game-started",
 			SessionID: sessionID,
-			Data: map[string]interface{}{
-				"message":   "Game has started!",
-				"session":   session,
+			Data: systemMessage(map[string]interface{}{
+				"players":   playerDeltas(session),
 				"startedAt": session.StartedAt,
-			},
+			}, session.Locale, i18n.MsgGameStarted),
 			Timestamp: time.Now(),
 		}
 		
 		// Broadcast to all players in the session
-		go func() {
+		s.runInBackground(ctx, sessionID, "broadcast-game-started", func(ctx context.Context) {
 			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
 				fmt.Printf("Warning: failed to broadcast game start event: %v\n", err)
 			}
-		}()
+		})
 	}
 	
 	return nil
 }
 
-// GetNextDoor retrieves the next door for a player based on their current score and position
-func (s *GameServiceImpl) GetNextDoor(playerID string, currentScore int) (*models.Door, error) {
-	ctx := context.Background()
+// GetNextDoor retrieves the next door for a player based on their current score and position,
+// in the player's locale
+func (s *GameServiceImpl) GetNextDoor(ctx context.Context, playerID string, currentScore int, locale string) (*models.Door, error) {
+	return s.nextDoor(ctx, playerID, currentScore, locale)
+}
+
+// nextDoor picks the player's next door in the given locale, reusing a stored door when one fits
+func (s *GameServiceImpl) nextDoor(ctx context.Context, playerID string, currentScore int, locale string) (*models.Door, error) {
+	locale = i18n.Normalize(locale)
+	
+	// Follow the player's door graph when one has been seeded
+	if s.pathGraph != nil {
+		if door := s.graphDoor(ctx, playerID, currentScore, locale); door != nil {
+			return door, nil
+		}
+	}
 	
 	// Get player's current path information from Neo4j
 	playerPath, err := s.playerPathRepo.GetPlayerPath(ctx, playerID)
@@ -293,37 +754,52 @@ func (s *GameServiceImpl) GetNextDoor(playerID string, currentScore int) (*model
 		}
 	}
 	
-	// Try to get an existing door from the database first
+	// Try to get an existing door from the database first; only moderated doors are served.
+	// Doors are matched on their calibrated difficulty once they have one.
 	doors, err := s.doorRepo.GetByTheme(ctx, theme)
+	if err == nil {
+		doors = doorsInLocale(servableDoors(doors), locale)
+	}
 	if err == nil && len(doors) > 0 {
 		// Find a door with appropriate difficulty
 		for _, door := range doors {
-			if door.Difficulty == difficulty {
+			if door.EffectiveDifficulty() == difficulty {
 				return door, nil
 			}
 		}
-		// If no exact difficulty match, return the first door of the theme
+	}
+	
+	// A pre-generated door of the right difficulty beats a stored door of the wrong one
+	if door := s.bankedDoor(ctx, theme, difficulty, locale); door != nil {
+		return door, nil
+	}
+	
+	// If no exact difficulty match, return the first door of the theme
+	if err == nil && len(doors) > 0 {
 		return doors[0], nil
 	}
 	
 	// If no existing doors, generate a new one using AI service
 	// For now, we'll create a simple door since AI service integration is basic
-	door, err := s.generateDoor(ctx, theme, difficulty)
+	door, err := s.generateDoor(ctx, theme, difficulty, locale)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate door: %w", err)
 	}
 	
-	// Save the generated door to database for future use
-	if err := s.doorRepo.Create(ctx, door); err != nil {
-		// Log error but don't fail - we can still return the door
-		fmt.Printf("Warning: failed to save generated door: %v\n", err)
+	// Generated doors are approved for reuse; curators can send them back to draft
+	if door.Status == "" {
+		door.Status = models.DoorStatusApproved
 	}
 	
-	return door, nil
+	// Save the generated door to database for future use, unless a similar door is already stored
+	return s.saveGeneratedDoor(ctx, door), nil
 }
 
 // PresentDoorToSession presents a door to all players in a session
 func (s *GameServiceImpl) PresentDoorToSession(ctx context.Context, sessionID string, door *models.Door) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.PresentDoorToSession", tracing.SessionIDKey.String(sessionID))
+	defer span.End()
+	
 	// Get the session to validate it exists and is active
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
@@ -331,29 +807,66 @@ func (s *GameServiceImpl) PresentDoorToSession(ctx context.Context, sessionID st
 	}
 	
 	if session == nil {
-		return fmt.Errorf("session not found")
+		return ErrSessionNotFound
 	}
 	
-	if session.Status != models.GameStatusActive {
-		return fmt.Errorf("session is not active")
+	if err := s.stateMachine.Require(session, OpPresentDoor); err != nil {
+		return err
 	}
 	
-	// Update session with current door
+	// Backfill accessibility metadata for doors stored before it existed
+	accessibility.Describe(door)
+	
+	// Update session with current door, opening the next round if scores were being revealed.
+	// Everyone shares this door, so any divergent per-player doors are cleared.
 	session.CurrentDoor = door
-	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+	for i := range session.Players {
+		session.Players[i].CurrentDoor = nil
+	}
+	timeLimit := s.responseTimeLimit(session)
+	presentedAt := time.Now()
+	deadline := presentedAt.Add(timeLimit)
+	session.RoundOpenedAt = &presentedAt
+	session.RoundDeadline = &deadline
+	session.RoundLimit = timeLimit
+	if session.Status == models.GameStatusRevealing {
+		if err := s.transition(ctx, session, models.GameStatusActive); err != nil {
+			return fmt.Errorf("failed to update session with current door: %w", err)
+		}
+	} else if err := s.gameSessionRepo.Update(ctx, session); err != nil {
 		return fmt.Errorf("failed to update session with current door: %w", err)
 	}
+	s.recordEvent(ctx, &models.SessionEvent{
+		SessionID: sessionID,
+		Type:      models.SessionEventDoorPresented,
+		DoorID:    door.DoorID,
+		Deadline:  &deadline,
+	})
+	s.scheduleBotResponses(ctx, session)
 	
 	// Broadcast door to all players via WebSocket
 	if s.wsManager != nil {
+		data := systemMessage(map[string]interface{}{
+			"door":          door,
+			"accessibility": door.Accessibility,
+			"timeLimit":     int(timeLimit.Seconds()),
+			"deadline":      deadline,
+			"maxLength":     SessionResponseLimit(session),
+		}, session.Locale, i18n.MsgDoorPresented, int(timeLimit.Seconds()))
+		if extended := playerDeadlines(session); len(extended) > 0 {
+			data["playerDeadlines"] = extended
+		}
+		if raised := playerLimits(session); len(raised) > 0 {
+			data["playerMaxLengths"] = raised
+		}
+		if round := session.MatchRound(); round > 0 {
+			data["round"] = round
+			data["rounds"] = len(session.MatchDoors)
+		}
 		event := WebSocketEvent{
 			Type:      "door-presented",
 			SessionID: sessionID,
-			Data: map[string]interface{}{
-				"door":      door,
-				"message":   "New door presented! You have 60 seconds to respond.",
-				"timeLimit": 60, // 60 seconds as per requirements
-			},
+			Data:      data,
 			Timestamp: time.Now(),
 		}
 		
@@ -361,8 +874,8 @@ func (s *GameServiceImpl) PresentDoorToSession(ctx context.Context, sessionID st
 			return fmt.Errorf("failed to broadcast door to session: %w", err)
 		}
 		
-		// Start timeout timer for this door (60 seconds as per requirements 2.5)
-		go s.startResponseTimeout(sessionID, door.DoorID, 60*time.Second)
+		// Start timeout timer for this door; it waits for the players with the most time
+		s.startResponseTimeout(ctx, sessionID, door.DoorID, *session.RoundClosesAt())
 	}
 	
 	return nil
@@ -370,6 +883,9 @@ func (s *GameServiceImpl) PresentDoorToSession(ctx context.Context, sessionID st
 
 // StartGameWithFirstDoor starts a game and presents the first door
 func (s *GameServiceImpl) StartGameWithFirstDoor(ctx context.Context, sessionID string) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.StartGameWithFirstDoor", tracing.SessionIDKey.String(sessionID))
+	defer span.End()
+	
 	// Start the game first
 	if err := s.StartGame(ctx, sessionID); err != nil {
 		return err
@@ -381,6 +897,11 @@ func (s *GameServiceImpl) StartGameWithFirstDoor(ctx context.Context, sessionID
 		return fmt.Errorf("failed to get session after starting: %w", err)
 	}
 	
+	// Best-of matches play a fixed set of doors chosen up front
+	if session.Settings.BestOf() {
+		return s.startMatch(ctx, session)
+	}
+	
 	// For multiplayer, all players get the same door initially
 	// For single player, generate based on theme if provided
 	theme := "general"
@@ -389,7 +910,7 @@ func (s *GameServiceImpl) StartGameWithFirstDoor(ctx context.Context, sessionID
 	}
 	
 	// Generate the first door
-	door, err := s.generateDoor(ctx, theme, 1) // Start with difficulty 1
+	door, err := s.generateDoor(ctx, theme, 1, session.Locale) // Start with difficulty 1
 	if err != nil {
 		return fmt.Errorf("failed to generate first door: %w", err)
 	}
@@ -403,11 +924,21 @@ func (s *GameServiceImpl) StartGameWithFirstDoor(ctx context.Context, sessionID
 }
 
 // generateDoor creates a new door using available methods
-func (s *GameServiceImpl) generateDoor(ctx context.Context, theme string, difficulty int) (*models.Door, error) {
+func (s *GameServiceImpl) generateDoor(ctx context.Context, theme string, difficulty int, locale string) (*models.Door, error) {
+	// The built-in doors are English, so other locales ask the AI service first
+	locale = i18n.Normalize(locale)
+	if locale != i18n.DefaultLocale && s.aiClient != nil {
+		door, err := s.aiClient.GenerateDoor(ctx, theme, difficulty, locale)
+		if err == nil && door != nil && i18n.Normalize(door.Locale) == locale {
+			return door, nil
+		}
+		fmt.Printf("Warning: no %s door available for theme %s, falling back to English\n", locale, theme)
+	}
+	
 	// For now, create doors directly since AI service is basic
 	// This will be enhanced when AI service endpoints are fully implemented
 	
-	doorID := fmt.Sprintf("door_%d_%s_%d", time.Now().Unix(), theme, difficulty)
+	doorID := fmt.Sprintf("door_%s_%s_%d", random.ID(), theme, difficulty)
 	
 	var content string
 	switch theme {
@@ -457,30 +988,48 @@ func (s *GameServiceImpl) generateDoor(ctx context.Context, theme string, diffic
 		ExpectedSolutionTypes: []string{"creative", "practical", "humorous"},
 		CreatedAt:             time.Now(),
 	}
+	accessibility.Describe(door)
 	
 	return door, nil
 }
 
-// SubmitResponse handles player response submission with validation, scoring, and state updates
-func (s *GameServiceImpl) SubmitResponse(ctx context.Context, sessionID, playerID, response string) error {
+// SubmitResponse handles player response submission with validation, scoring, and state updates.
+// It returns the recorded response; batched responses are returned before their scores arrive.
+func (s *GameServiceImpl) SubmitResponse(ctx context.Context, sessionID, playerID, response string) (*models.PlayerResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.SubmitResponse", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+	receivedAt := time.Now()
+	
+	// Responses and batched scores both rewrite the session, so they take turns
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+	
 	// Get the current session
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to get session: %w", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 	
 	if session == nil {
-		return fmt.Errorf("session not found")
+		return nil, ErrSessionNotFound
 	}
 	
-	// Validate session is active
-	if session.Status != models.GameStatusActive {
-		return fmt.Errorf("session is not active")
-	}
-	
-	// Validate current door exists
-	if session.CurrentDoor == nil {
-		return fmt.Errorf("no active door in session")
+	// Validate session is collecting responses; paused rounds take answers again once resumed
+	if err := s.stateMachine.Require(session, OpSubmitResponse); err != nil {
+		if session.Status == models.GameStatusPaused {
+			return nil, fmt.Errorf("%w: %w", ErrGamePaused, err)
+		}
+		// A response that arrives after the round closed is logged with when it arrived
+		if session.RoundDeadline != nil && isActivePlayer(session, playerID) {
+			s.recordEvent(ctx, &models.SessionEvent{
+				SessionID: sessionID,
+				Type:      models.SessionEventResponseLate,
+				PlayerID:  playerID,
+				Deadline:  session.RoundDeadline,
+				At:        receivedAt,
+			})
+		}
+		return nil, err
 	}
 	
 	// Find the player in the session
@@ -493,67 +1042,138 @@ func (s *GameServiceImpl) SubmitResponse(ctx context.Context, sessionID, playerI
 	}
 	
 	if playerIndex == -1 {
-		return fmt.Errorf("player not found in session")
+		if isSpectator(session, playerID) {
+			return nil, ErrSpectatorReadOnly
+		}
+		return nil, ErrPlayerNotInSession
+	}
+	
+	// Validate the player has a door to answer, either their own or the session's
+	currentDoor := session.DoorForPlayer(playerID)
+	if currentDoor == nil {
+		return nil, ErrNoActiveDoor
 	}
 	
 	// Check if player has already responded to this door
-	currentDoorID := session.CurrentDoor.DoorID
+	currentDoorID := currentDoor.DoorID
 	for _, response := range session.Players[playerIndex].Responses {
 		if response.DoorID == currentDoorID {
-			return fmt.Errorf("player has already responded to this door")
+			return nil, ErrAlreadyResponded
 		}
 	}
 	
-	// Validate response length (500 character limit as per requirements 2.4)
-	if len(response) > 500 {
-		return fmt.Errorf("response exceeds 500 character limit")
+	// Players with extra time keep the round open after everyone else's time is up
+	if responseWindowClosed(session, playerID, receivedAt) {
+		s.recordEvent(ctx, &models.SessionEvent{
+			SessionID: sessionID,
+			Type:      models.SessionEventResponseLate,
+			PlayerID:  playerID,
+			Deadline:  session.PlayerDeadline(playerID),
+			At:        receivedAt,
+		})
+		return nil, ErrResponseWindowClosed
 	}
 	
-	if len(response) == 0 {
-		return fmt.Errorf("response cannot be empty")
+	// Validate response length against the player's character limit (the session's, 500 unless set, requirement 2.4)
+	characterLimit := ResponseLimit(session, playerID)
+	if _, err := ValidateResponseLimit(response, characterLimit); err != nil {
+		return nil, err
 	}
 	
-	// Score the response using AI service
-	scoringMetrics, err := s.aiClient.ScoreResponse(ctx, session.CurrentDoor, response)
-	if err != nil {
-		// If AI service fails, use fallback scoring
-		fmt.Printf("Warning: AI scoring failed, using fallback: %v\n", err)
-		scoringMetrics = &models.ScoringMetrics{
-			Creativity:  50,
-			Feasibility: 50,
-			Humor:       50,
-			Originality: 50,
+	// Reject or mask offensive content before it is scored or shown to anyone
+	if s.moderation != nil {
+		subject := models.ModerationSubject{SessionID: sessionID, PlayerID: playerID, DoorID: currentDoorID}
+		result, err := s.moderation.Moderate(ctx, subject, response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to moderate response: %w", err)
+		}
+		result = applyProfanityFilter(result, response, session.Settings.Profanity)
+		if result.Action == models.ModerationReject {
+			return nil, ErrResponseRejected
 		}
+		response = result.Text
 	}
 	
-	// Calculate total AI score (average of all metrics)
-	totalScore := (scoringMetrics.Creativity + scoringMetrics.Feasibility + 
-				  scoringMetrics.Humor + scoringMetrics.Originality) / 4
+	// Peer-vote responses stay unscored until the other players have voted on them
+	peerVote := session.Settings.PeerVoting()
+	responseID := fmt.Sprintf("resp_%s_%s", random.ID(), playerID)
 	
-	// Create player response record
-	playerResponse := models.PlayerResponse{
-		ResponseID:     fmt.Sprintf("resp_%d_%s", time.Now().Unix(), playerID),
-		DoorID:         currentDoorID,
-		PlayerID:       playerID,
-		Content:        response,
-		AIScore:        totalScore,
-		SubmittedAt:    time.Now(),
-		ScoringMetrics: *scoringMetrics,
+	// Answers repeating the player's own or copying another player's are penalized or rejected
+	var suspicion *models.CheatSuspicion
+	if s.antiCheat != nil {
+		suspicion, err = s.antiCheat.Check(ctx, session, playerID, responseID, response)
+		if err != nil {
+			return nil, err
+		}
 	}
 	
-	// Add response to player's record and update total score
-	session.Players[playerIndex].Responses = append(session.Players[playerIndex].Responses, playerResponse)
-	session.Players[playerIndex].TotalScore += totalScore
+	// The strategy is recorded with the response, so its score is combined the same way later
+	var strategyName models.ScoringStrategy
+	if !peerVote {
+		strategyName = s.strategyFor(session.Settings.ScoringStrategy).Name()
+	}
 	
-	// Update session in database
-	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
-		return fmt.Errorf("failed to update session with response: %w", err)
+	// Responses are saved unscored and scored without holding the session lock, so a slow AI
+	// call never holds up the other players. Peer-vote responses are scored by the votes instead.
+	scoringPending, batched := !peerVote, false
+	if !peerVote && s.scoringBatcher != nil {
+		// Batched scores are applied once they arrive; the lock held here keeps them waiting until the response is saved
+		err := s.scoringBatcher.Submit(ScoreRequest{Door: currentDoor, Response: response}, s.scoreDelivery(ctx, sessionID, playerID, responseID, currentDoor))
+		if err != nil {
+			fmt.Printf("Warning: %v, scoring response inline\n", err)
+		}
+		batched = err == nil
 	}
 	
-	// Update player path in Neo4j based on score
-	if err := s.updatePlayerPath(ctx, playerID, totalScore, currentDoorID); err != nil {
-		// Log error but don't fail the response submission
-		fmt.Printf("Warning: failed to update player path: %v\n", err)
+	// Create player response record
+	playerResponse := models.PlayerResponse{
+		ResponseID:      responseID,
+		DoorID:          currentDoorID,
+		PlayerID:        playerID,
+		Content:         response,
+		SubmittedAt:     time.Now(),
+		ScoringPending:  scoringPending,
+		ScoringStrategy: strategyName,
+		CheatSuspicion:  suspicion,
+		CharacterLimit:  characterLimit,
+	}
+	
+	// Add response to player's record and update total score in one atomic write, so submissions
+	// handled elsewhere at the same time are never overwritten
+	updated, err := s.gameSessionRepo.AppendPlayerResponse(ctx, sessionID, playerResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update session with response: %w", err)
+	}
+	if updated == nil {
+		return nil, ErrAlreadyResponded
+	}
+	unlock()
+	
+	s.recordEvent(ctx, &models.SessionEvent{
+		SessionID:  sessionID,
+		Type:       models.SessionEventResponseSubmitted,
+		PlayerID:   playerID,
+		DoorID:     currentDoorID,
+		ResponseID: responseID,
+		Deadline:   session.RoundDeadline,
+		At:         receivedAt,
+	})
+	if s.antiCheat != nil {
+		s.antiCheat.Remember(ctx, sessionID, playerID, responseID, response)
+	}
+	s.observePlay(ctx, session, session.Players[playerIndex], response, receivedAt)
+	
+	// Carry on with the stored session, which includes responses submitted concurrently
+	session = updated
+	playerIndex = -1
+	for i, player := range session.Players {
+		if player.PlayerID == playerID {
+			playerIndex = i
+			break
+		}
+	}
+	if playerIndex == -1 {
+		return nil, ErrPlayerNotInSession
 	}
 	
 	// Broadcast response submission to all players in session
@@ -562,62 +1182,136 @@ func (s *GameServiceImpl) SubmitResponse(ctx context.Context, sessionID, playerI
 			Type:      "response-submitted",
 			SessionID: sessionID,
 			PlayerID:  playerID,
-			Data: map[string]interface{}{
-				"playerId":    playerID,
-				"score":       totalScore,
-				"message":     fmt.Sprintf("Player %s submitted their response", session.Players[playerIndex].Username),
-				"responseId":  playerResponse.ResponseID,
-				"submittedAt": playerResponse.SubmittedAt,
-			},
+			Data: systemMessage(map[string]interface{}{
+				"playerId":       playerID,
+				"score":          playerResponse.AIScore,
+				"scoringPending": scoringPending,
+				"responseId":     playerResponse.ResponseID,
+				"submittedAt":    playerResponse.SubmittedAt,
+			}, session.Locale, i18n.MsgResponseIn, session.Players[playerIndex].Username),
 			Timestamp: time.Now(),
 		}
+		progress := answerProgressEvent(session, currentDoorID)
 		
-		go func() {
+		s.runInBackground(ctx, sessionID, "broadcast-response-submitted", func(ctx context.Context) {
 			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
 				fmt.Printf("Warning: failed to broadcast response submission: %v\n", err)
 			}
-		}()
-		
-		// Broadcast real-time score update using progress service
-		if s.progressService != nil {
-			go func() {
-				if err := s.progressService.BroadcastRealTimeScoreUpdate(ctx, sessionID, playerID, totalScore, session.Players[playerIndex].TotalScore); err != nil {
-					fmt.Printf("Warning: failed to broadcast real-time score update: %v\n", err)
-				}
-			}()
-			
-			// Track player response and update progress
-			go func() {
-				if err := s.progressService.TrackPlayerResponse(ctx, sessionID, playerID, totalScore); err != nil {
-					fmt.Printf("Warning: failed to track player response: %v\n", err)
-				}
-			}()
-		} else {
-			// Fallback to basic score update if progress service not available
-			go func() {
-				if err := s.wsManager.BroadcastScoreUpdate(sessionID, playerID, totalScore, session.Players[playerIndex].TotalScore); err != nil {
-					fmt.Printf("Warning: failed to broadcast score update: %v\n", err)
-				}
-			}()
+			if err := s.wsManager.BroadcastToSession(sessionID, progress); err != nil {
+				fmt.Printf("Warning: failed to broadcast answer progress: %v\n", err)
+			}
+		})
+	}
+	
+	// Score the response now the lock is released, queued so a burst of submissions doesn't
+	// overload the AI service, and apply the score as a batched one would be. Peer-vote scores
+	// are announced when voting closes, batched scores when they arrive.
+	if scoringPending && !batched {
+		scored, scoreErr := s.scoringQueue.Score(ctx, sessionID, playerID, currentDoor, response)
+		applied, err := s.applyScore(ctx, sessionID, playerID, responseID, currentDoor, scored, scoreErr)
+		if err != nil {
+			fmt.Printf("Error applying score: %v\n", err)
+		}
+		if applied != nil {
+			playerResponse = *applied
 		}
 	}
 	
 	// Check if all players have responded to current door
-	allResponded := s.checkAllPlayersResponded(session, currentDoorID)
-	if allResponded {
-		// All players have responded, trigger next phase
-		go func() {
-			if err := s.processAllResponses(ctx, sessionID); err != nil {
-				fmt.Printf("Error processing all responses: %v\n", err)
+	if s.checkAllPlayersResponded(session) {
+		s.closeRound(ctx, sessionID, session)
+	}
+	
+	return &playerResponse, nil
+}
+
+// closeRound ends a round every active player has answered without waiting for its deadline
+func (s *GameServiceImpl) closeRound(ctx context.Context, sessionID string, session *models.GameSession) {
+	// The round is ending early, so its deadlines no longer need to fire
+	for _, door := range session.CurrentDoors() {
+		if err := s.scheduler.Cancel(ctx, sessionID, door.DoorID); err != nil {
+			fmt.Printf("Warning: failed to cancel response deadline: %v\n", err)
+		}
+	}
+	
+	// All players have responded, trigger next phase. Dropping the round transition would stall
+	// the session, so push back on the caller instead.
+	s.runOrInline(ctx, sessionID, "process-all-responses", func(ctx context.Context) {
+		if err := s.processAllResponses(ctx, sessionID); err != nil {
+			fmt.Printf("Error processing all responses: %v\n", err)
+		}
+	})
+}
+
+// publishScore broadcasts a player's new score and tracks it for session progress
+func (s *GameServiceImpl) publishScore(ctx context.Context, sessionID, playerID string, score, totalScore int) {
+	if s.wsManager == nil {
+		return
+	}
+	
+	// Broadcast real-time score update using progress service
+	if s.progressService != nil {
+		s.runInBackground(ctx, sessionID, "broadcast-score-update", func(ctx context.Context) {
+			if err := s.progressService.BroadcastRealTimeScoreUpdate(ctx, sessionID, playerID, score, totalScore); err != nil {
+				fmt.Printf("Warning: failed to broadcast real-time score update: %v\n", err)
 			}
-		}()
+		})
+		
+		// Track player response and update progress
+		s.runInBackground(ctx, sessionID, "track-player-response", func(ctx context.Context) {
+			if err := s.progressService.TrackPlayerResponse(ctx, sessionID, playerID, score); err != nil {
+				fmt.Printf("Warning: failed to track player response: %v\n", err)
+			}
+		})
+	} else {
+		// Fallback to basic score update if progress service not available
+		s.runInBackground(ctx, sessionID, "broadcast-score-update", func(ctx context.Context) {
+			if err := s.wsManager.BroadcastScoreUpdate(sessionID, playerID, score, totalScore); err != nil {
+				fmt.Printf("Warning: failed to broadcast score update: %v\n", err)
+			}
+		})
+	}
+}
+
+// EventResponseFeedback tells a player why their response scored what it did
+const EventResponseFeedback = "response-feedback"
+
+// sendFeedback tells the submitting player, and only them, why their response scored what it did
+func (s *GameServiceImpl) sendFeedback(ctx context.Context, sessionID string, response models.PlayerResponse) {
+	if s.wsManager == nil {
+		return
 	}
 	
-	return nil
+	event := WebSocketEvent{
+		Type:      EventResponseFeedback,
+		SessionID: sessionID,
+		PlayerID:  response.PlayerID,
+		Data: map[string]interface{}{
+			"responseId":     response.ResponseID,
+			"doorId":         response.DoorID,
+			"score":          response.AIScore,
+			"scoringMetrics": response.ScoringMetrics,
+			"feedback":       response.Feedback,
+		},
+		Timestamp: time.Now(),
+	}
+	
+	s.runInBackground(ctx, sessionID, "send-response-feedback", func(ctx context.Context) {
+		if err := s.wsManager.SendToPlayer(response.PlayerID, event); err != nil {
+			fmt.Printf("Warning: failed to send response feedback: %v\n", err)
+		}
+	})
 }
 
 // updatePlayerPath updates the player's path in Neo4j based on their score
-func (s *GameServiceImpl) updatePlayerPath(ctx context.Context, playerID string, score int, doorID string) error {
+func (s *GameServiceImpl) updatePlayerPath(ctx context.Context, playerID string, score int, doorID string, shortcutThreshold int) error {
+	// Move the player through their door graph
+	if s.pathGraph != nil {
+		if err := s.playerPathRepo.UpdatePlayerPosition(ctx, playerID, doorID); err != nil {
+			return fmt.Errorf("failed to update player position: %w", err)
+		}
+	}
+	
 	// Get current player path
 	playerPath, err := s.playerPathRepo.GetPlayerPath(ctx, playerID)
 	if err != nil {
@@ -642,7 +1336,7 @@ func (s *GameServiceImpl) updatePlayerPath(ctx context.Context, playerID string,
 	playerPath.CurrentPosition++
 	
 	// Adjust path based on score (requirements 3.4, 3.5)
-	if score > 70 {
+	if score > shortcutThreshold {
 		// Good performance - shorter path
 		if playerPath.TotalDoors > 5 {
 			playerPath.TotalDoors--
@@ -664,17 +1358,22 @@ func (s *GameServiceImpl) updatePlayerPath(ctx context.Context, playerID string,
 	return s.playerPathRepo.UpdatePlayerPath(ctx, playerPath)
 }
 
-// checkAllPlayersResponded checks if all active players have responded to the current door
-func (s *GameServiceImpl) checkAllPlayersResponded(session *models.GameSession, doorID string) bool {
+// checkAllPlayersResponded checks if all active players have responded to their current door
+func (s *GameServiceImpl) checkAllPlayersResponded(session *models.GameSession) bool {
 	for _, player := range session.Players {
-		if !player.IsActive {
-			continue // Skip inactive players
+		if !player.AwaitedInRound() {
+			continue // Skip players who left or stayed disconnected past the grace period
+		}
+		
+		door := session.DoorForPlayer(player.PlayerID)
+		if door == nil {
+			return false
 		}
 		
 		// Check if this player has responded to the current door
 		hasResponded := false
 		for _, response := range player.Responses {
-			if response.DoorID == doorID {
+			if response.DoorID == door.DoorID {
 				hasResponded = true
 				break
 			}
@@ -690,75 +1389,134 @@ func (s *GameServiceImpl) checkAllPlayersResponded(session *models.GameSession,
 
 // processAllResponses handles the logic when all players have responded
 func (s *GameServiceImpl) processAllResponses(ctx context.Context, sessionID string) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.processAllResponses", tracing.SessionIDKey.String(sessionID))
+	defer span.End()
+	
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+	
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get session: %w", err)
 	}
 	
 	if session == nil {
-		return fmt.Errorf("session not found")
+		return ErrSessionNotFound
+	}
+	
+	// Close the round so a later timeout or duplicate trigger for it is rejected
+	if err := s.transition(ctx, session, models.GameStatusScoring); err != nil {
+		return fmt.Errorf("failed to close round: %w", err)
+	}
+	
+	// Batched scores still on their way reveal the round when the last one is applied
+	if hasPendingScores(session) {
+		return nil
+	}
+	unlock()
+	
+	// Peer-vote rounds are scored by the players before anything is revealed
+	if session.Settings.PeerVoting() {
+		return s.startVoting(ctx, session)
+	}
+	
+	return s.revealRound(ctx, session)
+}
+
+// revealRound shows a scored round's results, then ends the game or presents the next doors
+func (s *GameServiceImpl) revealRound(ctx context.Context, session *models.GameSession) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.revealRound", tracing.SessionIDKey.String(session.SessionID))
+	defer span.End()
+	
+	sessionID := session.SessionID
+	
+	// Every response in the round has been scored, so the round's scores can be revealed
+	if err := s.transition(ctx, session, models.GameStatusRevealing); err != nil {
+		return fmt.Errorf("failed to reveal scores: %w", err)
 	}
 	
 	// Broadcast scores update to all players
 	if s.wsManager != nil {
-		// Collect all player scores for this door
+		// Collect each player's score for the door they answered this round
 		doorScores := make(map[string]int)
-		currentDoorID := session.CurrentDoor.DoorID
+		playerDoors := make(map[string]string)
 		
 		for _, player := range session.Players {
+			door := session.DoorForPlayer(player.PlayerID)
+			if door == nil {
+				continue
+			}
+			playerDoors[player.PlayerID] = door.DoorID
+			
 			for _, response := range player.Responses {
-				if response.DoorID == currentDoorID {
+				if response.DoorID == door.DoorID {
 					doorScores[player.PlayerID] = response.AIScore
 					break
 				}
 			}
 		}
 		
+		data := systemMessage(map[string]interface{}{
+			"scores":      doorScores,
+			"totalScores": totalScores(session),
+		}, session.Locale, i18n.MsgScoresUpdated)
+		if session.CurrentDoor != nil {
+			data["doorId"] = session.CurrentDoor.DoorID
+		} else {
+			data["playerDoors"] = playerDoors
+		}
+		if session.Settings.TeamPlay() {
+			data["teamScores"] = teamScores(session)
+		}
+		
 		event := WebSocketEvent{
 			Type:      "scores-updated",
 			SessionID: sessionID,
-			Data: map[string]interface{}{
-				"doorId":     currentDoorID,
-				"scores":     doorScores,
-				"message":    "All players have responded! Scores updated.",
-				"session":    session,
-			},
+			Data:      data,
 			Timestamp: time.Now(),
 		}
 		
 		if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
 			fmt.Printf("Warning: failed to broadcast scores update: %v\n", err)
 		}
+		s.revealResponses(session)
 		
-		// Broadcast complete progress update after all responses are processed
+		// Queue a debounced progress and leaderboard update after all responses are processed
 		if s.progressService != nil {
-			go func() {
-				if err := s.progressService.BroadcastProgressUpdates(ctx, sessionID); err != nil {
-					fmt.Printf("Warning: failed to broadcast progress updates: %v\n", err)
-				}
-				
-				// Also broadcast updated leaderboard
-				leaderboard, err := s.progressService.GetLeaderboard(ctx, sessionID)
-				if err == nil {
-					if err := s.wsManager.BroadcastLeaderboardUpdate(sessionID, leaderboard); err != nil {
-						fmt.Printf("Warning: failed to broadcast leaderboard update: %v\n", err)
-					}
-				}
-			}()
+			s.progressService.ScheduleProgressBroadcast(sessionID)
 		}
 	}
 	
-	// Check if any player has completed their path (won the game)
-	for _, player := range session.Players {
-		hasWon, err := s.checkWinCondition(ctx, sessionID, player.PlayerID)
-		if err != nil {
-			fmt.Printf("Warning: failed to check win condition for player %s: %v\n", player.PlayerID, err)
-			continue // Skip on error
+	return s.advanceRound(ctx, session)
+}
+
+// advanceRound ends the game if a revealed round produced a winner, or presents the next doors
+func (s *GameServiceImpl) advanceRound(ctx context.Context, session *models.GameSession) error {
+	sessionID := session.SessionID
+	
+	// Best-of matches ignore paths and end after their last door
+	if session.Settings.BestOf() {
+		return s.advanceMatch(ctx, session)
+	}
+	
+	// Teams win together, once their average position reaches the end of their paths
+	if session.Settings.TeamPlay() {
+		if _, mvp := s.teamWinner(ctx, session); mvp != "" {
+			return s.handleGameCompletion(ctx, sessionID, mvp)
 		}
-		
-		if hasWon {
-			// Player has won!
-			return s.handleGameCompletion(ctx, sessionID, player.PlayerID)
+	} else {
+		// Check if any player has completed their path (won the game)
+		for _, player := range session.Players {
+			hasWon, err := s.checkWinCondition(ctx, sessionID, player.PlayerID)
+			if err != nil {
+				fmt.Printf("Warning: failed to check win condition for player %s: %v\n", player.PlayerID, err)
+				continue // Skip on error
+			}
+			
+			if hasWon {
+				// Player has won!
+				return s.handleGameCompletion(ctx, sessionID, player.PlayerID)
+			}
 		}
 	}
 	
@@ -778,7 +1536,7 @@ func (s *GameServiceImpl) processAllResponses(ctx context.Context, sessionID str
 				lastScore = session.Players[0].Responses[len(session.Players[0].Responses)-1].AIScore
 			}
 			
-			nextDoor, err := s.GetNextDoor(playerID, lastScore)
+			nextDoor, err := s.nextDoor(ctx, playerID, lastScore, session.Locale)
 			if err != nil {
 				return fmt.Errorf("failed to get next door for single player: %w", err)
 			}
@@ -790,42 +1548,138 @@ func (s *GameServiceImpl) processAllResponses(ctx context.Context, sessionID str
 	return nil
 }
 
-// presentNextDoorsToPlayers presents appropriate next doors to each player in multiplayer
+// presentNextDoorsToPlayers gives each player in multiplayer the next door on their own path,
+// chosen from their path's theme and difficulty and their latest score
 func (s *GameServiceImpl) presentNextDoorsToPlayers(ctx context.Context, sessionID string) error {
-	// For now, present the same door to all players
-	// This can be enhanced later to give different doors based on individual paths
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get session: %w", err)
 	}
 	
-	// Get average score to determine next door difficulty
-	totalScore := 0
-	activePlayerCount := 0
+	if session == nil {
+		return ErrSessionNotFound
+	}
+	
+	doors := make(map[string]*models.Door, len(session.Players))
 	for _, player := range session.Players {
-		if player.IsActive && len(player.Responses) > 0 {
-			totalScore += player.Responses[len(player.Responses)-1].AIScore
-			activePlayerCount++
+		if !player.IsActive {
+			continue
+		}
+		
+		lastScore := 50 // Default score
+		if len(player.Responses) > 0 {
+			lastScore = player.Responses[len(player.Responses)-1].AIScore
+		}
+		
+		door, err := s.nextDoor(ctx, player.PlayerID, lastScore, session.Locale)
+		if err != nil {
+			return fmt.Errorf("failed to get next door for player %s: %w", player.PlayerID, err)
 		}
+		doors[player.PlayerID] = door
 	}
 	
-	averageScore := 50 // Default
-	if activePlayerCount > 0 {
-		averageScore = totalScore / activePlayerCount
+	return s.presentPlayerDoors(ctx, session, doors)
+}
+
+// presentPlayerDoors opens a round where each player answers their own door. Each door is
+// sent only to its player, and every distinct door gets a response deadline.
+func (s *GameServiceImpl) presentPlayerDoors(ctx context.Context, session *models.GameSession, doors map[string]*models.Door) error {
+	if err := s.stateMachine.Require(session, OpPresentDoor); err != nil {
+		return err
 	}
 	
-	// Generate next door based on average performance
-	theme := "general"
-	if session.Theme != nil {
-		theme = *session.Theme
+	session.CurrentDoor = nil
+	for i := range session.Players {
+		door := doors[session.Players[i].PlayerID]
+		accessibility.Describe(door)
+		session.Players[i].CurrentDoor = door
 	}
+	timeLimit := s.responseTimeLimit(session)
+	presentedAt := time.Now()
+	deadline := presentedAt.Add(timeLimit)
+	session.RoundOpenedAt = &presentedAt
+	session.RoundDeadline = &deadline
+	session.RoundLimit = timeLimit
 	
-	nextDoor, err := s.generateDoor(ctx, theme, s.calculateDifficultyFromScore(averageScore))
-	if err != nil {
-		return fmt.Errorf("failed to generate next door: %w", err)
+	if session.Status == models.GameStatusRevealing {
+		if err := s.transition(ctx, session, models.GameStatusActive); err != nil {
+			return fmt.Errorf("failed to update session with player doors: %w", err)
+		}
+	} else if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to update session with player doors: %w", err)
+	}
+	for playerID, door := range doors {
+		s.recordEvent(ctx, &models.SessionEvent{
+			SessionID: session.SessionID,
+			Type:      models.SessionEventDoorPresented,
+			PlayerID:  playerID,
+			DoorID:    door.DoorID,
+			Deadline:  session.PlayerDeadline(playerID),
+		})
+	}
+	s.scheduleBotResponses(ctx, session)
+	
+	if s.wsManager == nil {
+		return nil
+	}
+	
+	for playerID, door := range doors {
+		// Each player is shown their own deadline, including any extra time they have
+		playerDeadline := session.PlayerDeadline(playerID)
+		playerLimit := int(playerDeadline.Sub(presentedAt).Seconds())
+		event := WebSocketEvent{
+			Type:      "door-presented",
+			SessionID: session.SessionID,
+			PlayerID:  playerID,
+			Data: systemMessage(map[string]interface{}{
+				"door":          door,
+				"accessibility": door.Accessibility,
+				"timeLimit":     playerLimit,
+				"deadline":      playerDeadline,
+				"maxLength":     ResponseLimit(session, playerID),
+			}, session.Locale, i18n.MsgDoorPresented, playerLimit),
+			Timestamp: time.Now(),
+		}
+		
+		if err := s.wsManager.SendToPlayer(playerID, event); err != nil {
+			fmt.Printf("Warning: failed to send door to player %s: %v\n", playerID, err)
+		}
 	}
 	
-	return s.PresentDoorToSession(ctx, sessionID, nextDoor)
+	// Every door's deadline closes the whole round, so all wait for the players with the most time
+	for _, door := range session.CurrentDoors() {
+		s.startResponseTimeout(ctx, session.SessionID, door.DoorID, *session.RoundClosesAt())
+	}
+	
+	return nil
+}
+
+// doorsInLocale keeps the doors written in or translated to the given locale, as players of
+// that locale see them; doors without a locale are English
+func doorsInLocale(doors []*models.Door, locale string) []*models.Door {
+	var matching []*models.Door
+	for _, door := range doors {
+		localized, ok := door.InLocale(locale)
+		if !ok {
+			continue
+		}
+		if localized != door {
+			accessibility.Describe(localized)
+		}
+		matching = append(matching, localized)
+	}
+	return matching
+}
+
+// servableDoors filters out doors that have not been approved for play
+func servableDoors(doors []*models.Door) []*models.Door {
+	servable := make([]*models.Door, 0, len(doors))
+	for _, door := range doors {
+		if door.Servable() {
+			servable = append(servable, door)
+		}
+	}
+	return servable
 }
 
 // calculateDifficultyFromScore determines door difficulty based on player score
@@ -845,20 +1699,26 @@ func (s *GameServiceImpl) handleGameCompletion(ctx context.Context, sessionID, w
 		return fmt.Errorf("failed to get session: %w", err)
 	}
 	
-	// Mark session as completed
-	now := time.Now()
-	session.Status = models.GameStatusCompleted
-	session.CompletedAt = &now
-	
-	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+	// The game is over once it starts completing; the winner and end time are saved before
+	// anything below reads the session back
+	session.WinnerID = winnerPlayerID
+	if session.Settings.TeamPlay() {
+		if winner := sessionPlayer(session, winnerPlayerID); winner != nil {
+			session.WinnerTeamID = winner.TeamID
+		}
+	}
+	if err := s.transition(ctx, session, models.GameStatusCompleting); err != nil {
 		return fmt.Errorf("failed to update session completion: %w", err)
 	}
+	if s.experiments != nil && len(session.Experiments) > 0 {
+		s.experiments.ObserveCompletion(session)
+	}
 	
 	// Record game completion for all players in the leaderboard
 	if s.leaderboardService != nil {
 		for _, player := range session.Players {
-			// Only record if player has completed at least one door
-			if len(player.Responses) > 0 {
+			// Only record if player has completed at least one door; bots stay off the leaderboard
+			if len(player.Responses) > 0 && !player.IsBot {
 				if err := s.leaderboardService.RecordGameCompletion(ctx, sessionID, player.PlayerID); err != nil {
 					fmt.Printf("Warning: failed to record leaderboard entry for player %s: %v\n", player.PlayerID, err)
 				}
@@ -866,20 +1726,46 @@ func (s *GameServiceImpl) handleGameCompletion(ctx context.Context, sessionID, w
 		}
 	}
 	
+	// Add the game to each player's career statistics, then award any achievements it earned
+	for i := range session.Players {
+		player := &session.Players[i]
+		if len(player.Responses) == 0 || player.IsBot {
+			continue
+		}
+		
+		var profile *models.PlayerProfile
+		if s.profileService != nil {
+			if profile, err = s.profileService.RecordGame(ctx, session, player.PlayerID); err != nil {
+				fmt.Printf("Warning: failed to update profile for player %s: %v\n", player.PlayerID, err)
+			}
+		}
+		if s.achievements != nil {
+			event := AchievementEvent{Trigger: TriggerGameCompleted, Session: session, Player: player, Profile: profile}
+			if _, err := s.achievements.Evaluate(ctx, event); err != nil {
+				fmt.Printf("Warning: failed to check achievements for player %s: %v\n", player.PlayerID, err)
+			}
+		}
+	}
+	
 	// Calculate final rankings and performance statistics
-	finalRankings, err := s.calculateFinalRankings(ctx, session)
+	finalRankings, err := s.rankingEngine.Rankings(ctx, session)
 	if err != nil {
 		fmt.Printf("Warning: failed to calculate final rankings: %v\n", err)
 		finalRankings = []models.PlayerRanking{} // Use empty rankings as fallback
 	}
 	
 	// Calculate performance statistics for all players
-	performanceStats, err := s.calculatePerformanceStatistics(ctx, session)
+	performanceStats, err := s.rankingEngine.PerformanceStatistics(ctx, session)
 	if err != nil {
 		fmt.Printf("Warning: failed to calculate performance statistics: %v\n", err)
 		performanceStats = []models.PlayerPerformanceStats{} // Use empty stats as fallback
 	}
 	
+	// Results are recorded; mark session as completed
+	if err := s.transition(ctx, session, models.GameStatusCompleted); err != nil {
+		return fmt.Errorf("failed to update session completion: %w", err)
+	}
+	
 	// Find winner's username and details
 	winnerUsername := "Unknown"
 	var winnerRanking *models.PlayerRanking
@@ -903,20 +1789,25 @@ func (s *GameServiceImpl) handleGameCompletion(ctx context.Context, sessionID, w
 	
 	// Broadcast game completion with comprehensive results
 	if s.wsManager != nil {
+		data := systemMessage(map[string]interface{}{
+			"winnerId":         winnerPlayerID,
+			"winnerUsername":   winnerUsername,
+			"players":          playerDeltas(session),
+			"completedAt":      session.CompletedAt,
+			"finalRankings":    finalRankings,
+			"performanceStats": performanceStats,
+			"gameMode":         session.Mode,
+			"gameDuration":     s.calculateGameDuration(session),
+		}, session.Locale, i18n.MsgGameWon, winnerUsername)
+		if session.Settings.TeamPlay() {
+			data["winnerTeamId"] = session.WinnerTeamID
+			data["teamRankings"] = rankTeams(session, finalRankings)
+		}
+		
 		event := WebSocketEvent{
 			Type:      "game-completed",
 			SessionID: sessionID,
-			Data: map[string]interface{}{
-				"winnerId":           winnerPlayerID,
-				"winnerUsername":     winnerUsername,
-				"message":            fmt.Sprintf("%s has won the game!", winnerUsername),
-				"session":            session,
-				"completedAt":        session.CompletedAt,
-				"finalRankings":      finalRankings,
-				"performanceStats":   performanceStats,
-				"gameMode":           session.Mode,
-				"gameDuration":       s.calculateGameDuration(session),
-			},
+			Data:      data,
 			Timestamp: time.Now(),
 		}
 		
@@ -926,17 +1817,20 @@ func (s *GameServiceImpl) handleGameCompletion(ctx context.Context, sessionID, w
 		
 		// Also broadcast final leaderboard update
 		if s.progressService != nil {
-			go func() {
+			s.runInBackground(ctx, sessionID, "broadcast-final-leaderboard", func(ctx context.Context) {
 				leaderboard, err := s.progressService.GetLeaderboard(ctx, sessionID)
 				if err == nil {
 					if err := s.wsManager.BroadcastLeaderboardUpdate(sessionID, leaderboard); err != nil {
 						fmt.Printf("Warning: failed to broadcast final leaderboard: %v\n", err)
 					}
 				}
-			}()
+			})
 		}
 	}
 	
+	// Show the results on the Reddit post the game was started from
+	s.updateOriginPost(ctx, session, winnerUsername, finalRankings)
+	
 	return nil
 }
 
@@ -946,43 +1840,49 @@ func (s *GameServiceImpl) CalculatePlayerPath(playerID string, scores []int) err
 	return nil
 }
 
-// startResponseTimeout starts a timeout timer for door responses
-func (s *GameServiceImpl) startResponseTimeout(sessionID, doorID string, timeout time.Duration) {
-	time.Sleep(timeout)
-	
-	ctx := context.Background()
+// startResponseTimeout schedules the deadline for door responses at the round deadline players
+// were shown, so timer events read from the scheduler agree with it. The scheduler fires it
+// through handleResponseTimeout, which ignores doors that have already moved on.
+func (s *GameServiceImpl) startResponseTimeout(ctx context.Context, sessionID, doorID string, deadline time.Time) {
+	if err := s.scheduler.Schedule(ctx, sessionID, doorID, deadline); err != nil {
+		fmt.Printf("Warning: failed to schedule response deadline for door %s: %v\n", doorID, err)
+	}
+}
+
+// handleResponseTimeout processes a door whose response window has expired
+func (s *GameServiceImpl) handleResponseTimeout(ctx context.Context, sessionID, doorID string) {
 	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		fmt.Printf("Error getting session for timeout: %v\n", err)
 		return
 	}
 	
-	if session == nil || session.Status != models.GameStatusActive {
-		return // Session no longer active
+	if session == nil || s.stateMachine.Require(session, OpResponseTimeout) != nil {
+		return // Session no longer collecting responses
 	}
 	
-	// Check if this door is still the current door
-	if session.CurrentDoor == nil || session.CurrentDoor.DoorID != doorID {
+	// Check if this door is still open for any player
+	if !session.IsCurrentDoor(doorID) {
 		return // Door has already changed
 	}
 	
 	// Check if all players have already responded
-	if s.checkAllPlayersResponded(session, doorID) {
+	if s.checkAllPlayersResponded(session) {
 		return // All players already responded
 	}
 	
 	// Handle timeout - process responses from players who did respond
 	fmt.Printf("Response timeout reached for door %s in session %s\n", doorID, sessionID)
+	s.recordMissedResponses(ctx, session)
 	
 	// Broadcast timeout event
 	if s.wsManager != nil {
 		event := WebSocketEvent{
 			Type:      "response-timeout",
 			SessionID: sessionID,
-			Data: map[string]interface{}{
-				"doorId":  doorID,
-				"message": "Time's up! Processing responses from players who submitted.",
-			},
+			Data: systemMessage(map[string]interface{}{
+				"doorId": doorID,
+			}, session.Locale, i18n.MsgResponseTimeout),
 			Timestamp: time.Now(),
 		}
 		
@@ -991,226 +1891,19 @@ func (s *GameServiceImpl) startResponseTimeout(sessionID, doorID string, timeout
 		}
 	}
 	
-	// Process responses even if not all players responded
-	go func() {
-		if err := s.processAllResponses(ctx, sessionID); err != nil {
-			fmt.Printf("Error processing responses after timeout: %v\n", err)
-		}
-	}()
-}
-
-// calculateFinalRankings calculates the final rankings for all players in the session
-func (s *GameServiceImpl) calculateFinalRankings(ctx context.Context, session *models.GameSession) ([]models.PlayerRanking, error) {
-	var rankings []models.PlayerRanking
-	
-	// Calculate rankings for each player
-	for _, player := range session.Players {
-		// Get player path for completion information
-		playerPath, err := s.playerPathRepo.GetPlayerPath(ctx, player.PlayerID)
-		if err != nil {
-			// Use default values if path not found
-			playerPath = &models.PlayerPath{
-				PlayerID:        player.PlayerID,
-				CurrentPosition: len(player.Responses),
-				TotalDoors:      10, // Default
+	// Players on divergent paths have a deadline per door; this one closes the whole round
+	for _, door := range session.CurrentDoors() {
+		if door.DoorID != doorID {
+			if err := s.scheduler.Cancel(ctx, sessionID, door.DoorID); err != nil {
+				fmt.Printf("Warning: failed to cancel response deadline: %v\n", err)
 			}
 		}
-		
-		// Calculate completion rate
-		completionRate := 0.0
-		if playerPath.TotalDoors > 0 {
-			completionRate = float64(playerPath.CurrentPosition) / float64(playerPath.TotalDoors) * 100
-		}
-		
-		// Calculate average score
-		averageScore := 0.0
-		if len(player.Responses) > 0 {
-			totalScore := 0
-			for _, response := range player.Responses {
-				totalScore += response.AIScore
-			}
-			averageScore = float64(totalScore) / float64(len(player.Responses))
-		}
-		
-		// Calculate completion time (if completed)
-		var completionTime *time.Duration
-		isWinner := playerPath.CurrentPosition >= playerPath.TotalDoors
-		if isWinner && len(player.Responses) > 0 && session.StartedAt != nil {
-			lastResponseTime := player.Responses[len(player.Responses)-1].SubmittedAt
-			duration := lastResponseTime.Sub(*session.StartedAt)
-			completionTime = &duration
-		}
-		
-		ranking := models.PlayerRanking{
-			PlayerID:       player.PlayerID,
-			Username:       player.Username,
-			CompletionTime: completionTime,
-			TotalScore:     player.TotalScore,
-			AverageScore:   averageScore,
-			DoorsCompleted: len(player.Responses),
-			TotalDoors:     playerPath.TotalDoors,
-			CompletionRate: completionRate,
-			IsWinner:       isWinner,
-		}
-		
-		rankings = append(rankings, ranking)
 	}
 	
-	// Sort rankings by completion status, then by completion time, then by score
-	for i := 0; i < len(rankings)-1; i++ {
-		for j := 0; j < len(rankings)-i-1; j++ {
-			shouldSwap := false
-			
-			// Winners first
-			if rankings[j+1].IsWinner && !rankings[j].IsWinner {
-				shouldSwap = true
-			} else if rankings[j].IsWinner == rankings[j+1].IsWinner {
-				// Both winners or both non-winners
-				if rankings[j].IsWinner {
-					// Both are winners - sort by completion time
-					if rankings[j+1].CompletionTime != nil && rankings[j].CompletionTime != nil {
-						if *rankings[j+1].CompletionTime < *rankings[j].CompletionTime {
-							shouldSwap = true
-						}
-					} else if rankings[j+1].CompletionTime != nil && rankings[j].CompletionTime == nil {
-						shouldSwap = true
-					}
-				} else {
-					// Both non-winners - sort by completion rate, then by average score
-					if rankings[j+1].CompletionRate > rankings[j].CompletionRate {
-						shouldSwap = true
-					} else if rankings[j+1].CompletionRate == rankings[j].CompletionRate {
-						if rankings[j+1].AverageScore > rankings[j].AverageScore {
-							shouldSwap = true
-						}
-					}
-				}
-			}
-			
-			if shouldSwap {
-				rankings[j], rankings[j+1] = rankings[j+1], rankings[j]
-			}
-		}
-	}
-	
-	// Assign ranks
-	for i := range rankings {
-		rankings[i].Rank = i + 1
+	// Process responses even if not all players responded (already running on a worker)
+	if err := s.processAllResponses(ctx, sessionID); err != nil {
+		fmt.Printf("Error processing responses after timeout: %v\n", err)
 	}
-	
-	return rankings, nil
-}
-
-// calculatePerformanceStatistics calculates detailed performance statistics for all players
-func (s *GameServiceImpl) calculatePerformanceStatistics(ctx context.Context, session *models.GameSession) ([]models.PlayerPerformanceStats, error) {
-	var stats []models.PlayerPerformanceStats
-	
-	for _, player := range session.Players {
-		// Get player path for completion information
-		playerPath, err := s.playerPathRepo.GetPlayerPath(ctx, player.PlayerID)
-		if err != nil {
-			playerPath = &models.PlayerPath{
-				PlayerID:        player.PlayerID,
-				CurrentPosition: len(player.Responses),
-				TotalDoors:      10, // Default
-			}
-		}
-		
-		// Initialize statistics
-		playerStats := models.PlayerPerformanceStats{
-			PlayerID:       player.PlayerID,
-			Username:       player.Username,
-			TotalScore:     player.TotalScore,
-			DoorsCompleted: len(player.Responses),
-			TotalDoors:     playerPath.TotalDoors,
-		}
-		
-		// Calculate completion rate
-		if playerPath.TotalDoors > 0 {
-			playerStats.CompletionRate = float64(playerPath.CurrentPosition) / float64(playerPath.TotalDoors) * 100
-		}
-		
-		// Calculate completion time if player finished
-		if playerPath.CurrentPosition >= playerPath.TotalDoors && len(player.Responses) > 0 && session.StartedAt != nil {
-			lastResponseTime := player.Responses[len(player.Responses)-1].SubmittedAt
-			duration := lastResponseTime.Sub(*session.StartedAt)
-			playerStats.CompletionTime = &duration
-		}
-		
-		// Calculate path efficiency (lower total doors = better efficiency)
-		if playerPath.TotalDoors > 0 {
-			// Efficiency is inverse of total doors, normalized to 0-100 scale
-			// Assume 5 doors is perfect (100%), 15 doors is poor (33%)
-			minDoors := 5.0
-			maxDoors := 15.0
-			efficiency := (maxDoors - float64(playerPath.TotalDoors)) / (maxDoors - minDoors) * 100
-			if efficiency < 0 {
-				efficiency = 0
-			} else if efficiency > 100 {
-				efficiency = 100
-			}
-			playerStats.PathEfficiency = efficiency
-		}
-		
-		// Calculate response-based statistics
-		if len(player.Responses) > 0 {
-			totalScore := 0
-			totalCreativity := 0
-			totalFeasibility := 0
-			totalHumor := 0
-			totalOriginality := 0
-			totalResponseTime := time.Duration(0)
-			
-			highestScore := player.Responses[0].AIScore
-			lowestScore := player.Responses[0].AIScore
-			
-			var firstResponseTime *time.Time
-			if session.StartedAt != nil {
-				firstResponseTime = session.StartedAt
-			} else {
-				firstResponseTime = &player.Responses[0].SubmittedAt
-			}
-			
-			for i, response := range player.Responses {
-				totalScore += response.AIScore
-				totalCreativity += response.ScoringMetrics.Creativity
-				totalFeasibility += response.ScoringMetrics.Feasibility
-				totalHumor += response.ScoringMetrics.Humor
-				totalOriginality += response.ScoringMetrics.Originality
-				
-				if response.AIScore > highestScore {
-					highestScore = response.AIScore
-				}
-				if response.AIScore < lowestScore {
-					lowestScore = response.AIScore
-				}
-				
-				// Calculate response time (time between doors or from game start)
-				var responseStartTime time.Time
-				if i == 0 {
-					responseStartTime = *firstResponseTime
-				} else {
-					responseStartTime = player.Responses[i-1].SubmittedAt
-				}
-				responseTime := response.SubmittedAt.Sub(responseStartTime)
-				totalResponseTime += responseTime
-			}
-			
-			responseCount := len(player.Responses)
-			playerStats.AverageScore = float64(totalScore) / float64(responseCount)
-			playerStats.HighestScore = highestScore
-			playerStats.LowestScore = lowestScore
-			playerStats.AverageResponseTime = totalResponseTime / time.Duration(responseCount)
-			playerStats.CreativityAverage = float64(totalCreativity) / float64(responseCount)
-			playerStats.FeasibilityAverage = float64(totalFeasibility) / float64(responseCount)
-			playerStats.HumorAverage = float64(totalHumor) / float64(responseCount)
-			playerStats.OriginalityAverage = float64(totalOriginality) / float64(responseCount)
-		}
-		
-		stats = append(stats, playerStats)
-	}
-	
-	return stats, nil
 }
 
 // calculateGameDuration calculates the total duration of the game
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Hot path benchmarks run against a full-size session: 8 players, 15 doors.
+// Track them over time with scripts/bench.sh.
+const (
+	benchPlayers = 8
+	benchDoors   = 15
+	benchSession = "bench-session"
+)
+
+// inlineWorkerPool runs background tasks on the caller's goroutine so benchmarks
+// measure the whole submission path and never race the non-thread-safe mocks
+type inlineWorkerPool struct{}
+
+func (inlineWorkerPool) Submit(name string, task func()) error {
+	task()
+	return nil
+}
+
+func (inlineWorkerPool) Stats() WorkerPoolStats { return WorkerPoolStats{Name: "inline"} }
+
+func (inlineWorkerPool) Shutdown(ctx context.Context) error { return nil }
+
+// newBenchSession builds an active session where every player has answered the first
+// answeredDoors doors and the current door is the next one
+func newBenchSession(answeredDoors int) *models.GameSession {
+	startedAt := time.Now().Add(-30 * time.Minute)
+	session := &models.GameSession{
+		SessionID: benchSession,
+		Mode:      models.GameModeMultiplayer,
+		Status:    models.GameStatusActive,
+		StartedAt: &startedAt,
+		CurrentDoor: &models.Door{
+			DoorID:  fmt.Sprintf("door-%d", answeredDoors),
+			Content: "You wake up inside a vending machine. What do you do?",
+		},
+	}
+
+	for p := 0; p < benchPlayers; p++ {
+		player := models.PlayerInfo{
+			PlayerID: fmt.Sprintf("player-%d", p),
+			Username: fmt.Sprintf("Player %d", p),
+			IsActive: true,
+		}
+		for d := 0; d < answeredDoors; d++ {
+			score := 40 + (p*7+d*11)%60
+			player.Responses = append(player.Responses, models.PlayerResponse{
+				ResponseID:  fmt.Sprintf("resp-%d-%d", p, d),
+				DoorID:      fmt.Sprintf("door-%d", d),
+				PlayerID:    player.PlayerID,
+				Content:     "I would calmly improvise.",
+				AIScore:     score,
+				SubmittedAt: startedAt.Add(time.Duration(d*p+d) * time.Minute),
+			})
+			player.TotalScore += score
+		}
+		session.Players = append(session.Players, player)
+	}
+
+	return session
+}
+
+// newBenchPaths gives every player a path of benchDoors doors advanced to position
+func newBenchPaths(playerPathRepo *MockPlayerPathRepository, position int) {
+	for p := 0; p < benchPlayers; p++ {
+		playerID := fmt.Sprintf("player-%d", p)
+		playerPathRepo.paths[playerID] = &models.PlayerPath{
+			PlayerID:          playerID,
+			Theme:             "general",
+			CurrentDifficulty: 2,
+			CurrentPosition:   position,
+			TotalDoors:        benchDoors,
+		}
+	}
+}
+
+func BenchmarkSubmitResponse(b *testing.B) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	playerPathRepo := NewMockPlayerPathRepository()
+	wsManager := NewMockWebSocketManager()
+	aiClient := &MockAIClient{}
+
+	progressService := NewProgressService(gameSessionRepo, playerPathRepo, wsManager, WithProgressBroadcastInterval(time.Hour))
+	// Keep the debounced session broadcast from firing on another goroutine mid-benchmark
+	progressService.(*ProgressServiceImpl).lastBroadcasts[benchSession] = time.Now()
+
+	gameService := NewGameService(gameSessionRepo, nil, playerPathRepo, wsManager, aiClient, progressService, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+		WithScoringQueue(NewScoringQueue(aiClient, nil, DefaultScoringConcurrency, 0)),
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// The last door of the game, with the other seven players still writing
+		b.StopTimer()
+		gameSessionRepo.sessions[benchSession] = newBenchSession(benchDoors - 1)
+		newBenchPaths(playerPathRepo, benchDoors-1)
+		b.StartTimer()
+
+		if _, err := gameService.SubmitResponse(ctx, benchSession, "player-0", "I would climb out through the coin slot."); err != nil {
+			b.Fatalf("SubmitResponse failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkCalculateSessionProgress(b *testing.B) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	playerPathRepo := NewMockPlayerPathRepository()
+	progressService := NewProgressService(gameSessionRepo, playerPathRepo, NewMockWebSocketManager())
+
+	gameSessionRepo.sessions[benchSession] = newBenchSession(benchDoors)
+	newBenchPaths(playerPathRepo, benchDoors)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := progressService.CalculateSessionProgress(ctx, benchSession); err != nil {
+			b.Fatalf("CalculateSessionProgress failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkCalculateFinalRankings(b *testing.B) {
+	ctx := context.Background()
+	playerPathRepo := NewMockPlayerPathRepository()
+	rankingEngine := NewRankingEngine(playerPathRepo)
+
+	session := newBenchSession(benchDoors)
+	newBenchPaths(playerPathRepo, benchDoors)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := rankingEngine.Rankings(ctx, session); err != nil {
+			b.Fatalf("Rankings failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkCalculatePerformanceStatistics(b *testing.B) {
+	ctx := context.Background()
+	playerPathRepo := NewMockPlayerPathRepository()
+	rankingEngine := NewRankingEngine(playerPathRepo)
+
+	session := newBenchSession(benchDoors)
+	newBenchPaths(playerPathRepo, benchDoors)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := rankingEngine.PerformanceStatistics(ctx, session); err != nil {
+			b.Fatalf("PerformanceStatistics failed: %v", err)
+		}
+	}
+}
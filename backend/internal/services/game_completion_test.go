@@ -74,6 +74,16 @@ func (m *MockLeaderboardRepository) GetRecentWinners(ctx context.Context, filter
 	return m.entries, nil
 }
 
+func (m *MockLeaderboardRepository) GetBestOfTotals(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
+	var result []models.LeaderboardEntry
+	for _, entry := range m.entries {
+		if entry.Format == models.MatchFormatBestOf {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
 func (m *MockLeaderboardRepository) GetGlobalLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (*models.GlobalLeaderboard, error) {
 	fastest, _ := m.GetFastestCompletions(ctx, filter)
 	highest, _ := m.GetHighestAverageScores(ctx, filter)
@@ -97,6 +107,17 @@ func (m *MockLeaderboardRepository) GetPlayerRank(ctx context.Context, playerID
 	return 1, nil
 }
 
+func (m *MockLeaderboardRepository) SetShadowed(ctx context.Context, playerID string, shadowed bool) (int64, error) {
+	var changed int64
+	for i := range m.entries {
+		if m.entries[i].PlayerID == playerID && m.entries[i].Shadowed != shadowed {
+			m.entries[i].Shadowed = shadowed
+			changed++
+		}
+	}
+	return changed, nil
+}
+
 // TestWinnerDetectionAndGameCompletion tests the complete winner detection and game completion flow
 func TestWinnerDetectionAndGameCompletion(t *testing.T) {
 	// Setup mocks
@@ -236,7 +257,7 @@ func TestWinnerDetectionAndGameCompletion(t *testing.T) {
 	}
 	
 	// Test final rankings calculation
-	finalRankings, err := gameService.(*GameServiceImpl).calculateFinalRankings(ctx, session)
+	finalRankings, err := gameService.(*GameServiceImpl).rankingEngine.Rankings(ctx, session)
 	if err != nil {
 		t.Fatalf("Expected no error calculating final rankings, got: %v", err)
 	}
@@ -283,7 +304,7 @@ func TestWinnerDetectionAndGameCompletion(t *testing.T) {
 	}
 	
 	// Test performance statistics calculation
-	performanceStats, err := gameService.(*GameServiceImpl).calculatePerformanceStatistics(ctx, session)
+	performanceStats, err := gameService.(*GameServiceImpl).rankingEngine.PerformanceStatistics(ctx, session)
 	if err != nil {
 		t.Fatalf("Expected no error calculating performance statistics, got: %v", err)
 	}
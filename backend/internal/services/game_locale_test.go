@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateDoor_RequestsLocalizedDoorFromAI(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil).(*GameServiceImpl)
+
+	door, err := gameService.generateDoor(context.Background(), "workplace", 2, "es-MX")
+	if err != nil {
+		t.Fatalf("generateDoor failed: %v", err)
+	}
+
+	if door.DoorID != "mock-door" || door.Locale != "es" {
+		t.Errorf("Expected a Spanish door from the AI client, got %s in %q", door.DoorID, door.Locale)
+	}
+}
+
+func TestGenerateDoor_DefaultLocaleUsesBuiltInDoors(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil).(*GameServiceImpl)
+
+	door, err := gameService.generateDoor(context.Background(), "workplace", 2, "")
+	if err != nil {
+		t.Fatalf("generateDoor failed: %v", err)
+	}
+
+	if door.DoorID == "mock-door" || door.Content == "" {
+		t.Errorf("Expected a built-in English door, got %s", door.DoorID)
+	}
+}
+
+func TestCreateSession_NormalizesLocale(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	session, err := gameService.CreateSession(context.Background(), models.GameModeSinglePlayer, "p1", "Player 1", nil, "xx-YY", models.SessionSettings{})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	if session.Locale != "en" {
+		t.Errorf("Expected unsupported locale to fall back to en, got %q", session.Locale)
+	}
+}
+
+func TestGetNextDoor_ServesTranslations(t *testing.T) {
+	ctx := context.Background()
+	doors := approvedDoors("general", 1)
+	doors[0].Translations = map[string]string{"fr": "Vous êtes coincé dans un ascenseur."}
+	gameService := NewGameService(NewMockGameSessionRepository(), &MockDoorRepository{doors: doors}, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	door, err := gameService.GetNextDoor(ctx, "p1", 50, "fr-CA")
+	if err != nil {
+		t.Fatalf("GetNextDoor failed: %v", err)
+	}
+	if door.DoorID != "door-01" || door.Locale != "fr" || door.Content != "Vous êtes coincé dans un ascenseur." {
+		t.Errorf("Expected the French translation of door-01, got %s in %q: %q", door.DoorID, door.Locale, door.Content)
+	}
+	if doors[0].Locale != "" || doors[0].Content == door.Content {
+		t.Error("Expected the stored door to keep its original content")
+	}
+
+	if door, err = gameService.GetNextDoor(ctx, "p1", 50, "en"); err != nil || door.Content != doors[0].Content {
+		t.Errorf("Expected the original door in English, got %+v (%v)", door, err)
+	}
+}
+
+func TestDoorAdmin_SetsTranslations(t *testing.T) {
+	ctx := context.Background()
+	doorAdmin := NewDoorAdminService(&MockDoorRepository{})
+
+	door, err := doorAdmin.CreateDoor(ctx, &models.Door{
+		Content:      "The office fridge is talking to you.",
+		Theme:        "workplace",
+		Difficulty:   1,
+		Translations: map[string]string{"ES": " La nevera de la oficina te habla. "},
+	})
+	if err != nil {
+		t.Fatalf("CreateDoor failed: %v", err)
+	}
+	if door.Translations["es"] != "La nevera de la oficina te habla." {
+		t.Errorf("Expected a normalized Spanish translation, got %v", door.Translations)
+	}
+
+	door.Status = models.DoorStatusApproved
+	door, err = doorAdmin.UpdateDoor(ctx, door.DoorID, DoorUpdate{Translations: map[string]string{"es": "", "de": "Der Bürokühlschrank spricht mit dir."}})
+	if err != nil {
+		t.Fatalf("UpdateDoor failed: %v", err)
+	}
+	if !reflect.DeepEqual(door.Translations, map[string]string{"de": "Der Bürokühlschrank spricht mit dir."}) || door.Status != models.DoorStatusDraft {
+		t.Errorf("Expected the German translation alone and the door back in draft, got %v (%s)", door.Translations, door.Status)
+	}
+
+	for _, translations := range []map[string]string{{"en": "Again in English"}, {"xx": "Unknown"}} {
+		if _, err := doorAdmin.UpdateDoor(ctx, door.DoorID, DoorUpdate{Translations: translations}); err == nil {
+			t.Errorf("Expected translations %v to be rejected", translations)
+		}
+	}
+}
+
+func TestSystemMessage_CarriesCodeAndParams(t *testing.T) {
+	data := systemMessage(map[string]interface{}{"playerId": "p1"}, "de", i18n.MsgPlayerJoined, "Alice")
+
+	if data["message"] != "Alice ist dem Spiel beigetreten" {
+		t.Errorf("Expected the message in the session's locale, got %q", data["message"])
+	}
+	if data["messageCode"] != i18n.MsgPlayerJoined || !reflect.DeepEqual(data["messageParams"], []interface{}{"Alice"}) {
+		t.Errorf("Expected the message code and params, got %v %v", data["messageCode"], data["messageParams"])
+	}
+
+	if params := systemMessage(map[string]interface{}{}, "en", i18n.MsgGameStarted)["messageParams"]; params == nil || len(params.([]interface{})) != 0 {
+		t.Errorf("Expected empty params for a message without arguments, got %v", params)
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"testing"
+)
+
+// MockDoorRepository is an in-memory DoorRepository for testing
+type MockDoorRepository struct {
+	doors []*models.Door
+}
+
+func (m *MockDoorRepository) Create(ctx context.Context, door *models.Door) error {
+	m.doors = append(m.doors, door)
+	return nil
+}
+
+func (m *MockDoorRepository) CreateMany(ctx context.Context, doors []*models.Door) error {
+	m.doors = append(m.doors, doors...)
+	return nil
+}
+
+func (m *MockDoorRepository) GetByID(ctx context.Context, doorID string) (*models.Door, error) {
+	for _, door := range m.doors {
+		if door.DoorID == doorID {
+			return door, nil
+		}
+	}
+	return nil, fmt.Errorf("door not found")
+}
+
+func (m *MockDoorRepository) GetByTheme(ctx context.Context, theme string) ([]*models.Door, error) {
+	var doors []*models.Door
+	for _, door := range m.doors {
+		if door.Theme == theme {
+			doors = append(doors, door)
+		}
+	}
+	return doors, nil
+}
+
+func (m *MockDoorRepository) GetByDifficulty(ctx context.Context, difficulty int) ([]*models.Door, error) {
+	var doors []*models.Door
+	for _, door := range m.doors {
+		if door.Difficulty == difficulty {
+			doors = append(doors, door)
+		}
+	}
+	return doors, nil
+}
+
+func (m *MockDoorRepository) Update(ctx context.Context, door *models.Door) error { return nil }
+
+func (m *MockDoorRepository) UpdateCalibration(ctx context.Context, doorID string, empiricalDifficulty float64, responseCount int) error {
+	for _, door := range m.doors {
+		if door.DoorID == doorID {
+			door.EmpiricalDifficulty = empiricalDifficulty
+			door.ResponseCount = responseCount
+		}
+	}
+	return nil
+}
+
+func (m *MockDoorRepository) Delete(ctx context.Context, doorID string) error {
+	for i, door := range m.doors {
+		if door.DoorID == doorID {
+			m.doors = append(m.doors[:i], m.doors[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockDoorRepository) List(ctx context.Context, filter models.DoorFilter) ([]*models.Door, int64, error) {
+	var matched []*models.Door
+	for _, door := range m.doors {
+		if (filter.Theme == "" || door.Theme == filter.Theme) && (filter.Status == "" || door.Status == filter.Status) {
+			matched = append(matched, door)
+		}
+	}
+
+	start := (filter.Page - 1) * filter.PageSize
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + filter.PageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], int64(len(matched)), nil
+}
+
+func TestPresentNextDoorsToPlayers_FollowsEachPlayersPath(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	playerPathRepo := NewMockPlayerPathRepository()
+	doorRepo := &MockDoorRepository{}
+	for difficulty := 1; difficulty <= 3; difficulty++ {
+		doorRepo.Create(ctx, &models.Door{
+			DoorID:     fmt.Sprintf("workplace-%d", difficulty),
+			Content:    "Your stapler has unionized. What now?",
+			Theme:      "workplace",
+			Difficulty: difficulty,
+			Status:     models.DoorStatusApproved,
+		})
+	}
+
+	session := newDraftSession()
+	session.Mode = models.GameModeMultiplayer
+	session.Status = models.GameStatusRevealing
+	for i := range session.Players {
+		session.Players[i].IsActive = true
+	}
+	gameSessionRepo.sessions["s1"] = session
+	playerPathRepo.paths["p1"] = &models.PlayerPath{PlayerID: "p1", Theme: "workplace", CurrentDifficulty: 1}
+	playerPathRepo.paths["p2"] = &models.PlayerPath{PlayerID: "p2", Theme: "workplace", CurrentDifficulty: 3}
+
+	gameService := NewGameService(gameSessionRepo, doorRepo, playerPathRepo, nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	).(*GameServiceImpl)
+
+	if err := gameService.presentNextDoorsToPlayers(ctx, "s1"); err != nil {
+		t.Fatalf("presentNextDoorsToPlayers failed: %v", err)
+	}
+
+	session = gameSessionRepo.sessions["s1"]
+	if session.Status != models.GameStatusActive || session.CurrentDoor != nil {
+		t.Fatalf("Expected an active round without a shared door, got %s with %+v", session.Status, session.CurrentDoor)
+	}
+	if door := session.DoorForPlayer("p1"); door == nil || door.DoorID != "workplace-1" {
+		t.Errorf("Expected p1 to get the easy door, got %+v", door)
+	}
+	if door := session.DoorForPlayer("p2"); door == nil || door.DoorID != "workplace-3" {
+		t.Errorf("Expected p2 to get the hard door, got %+v", door)
+	}
+
+	// Each player answers their own door, and the round only closes once both have
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p2", "I would negotiate."); err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	session = gameSessionRepo.sessions["s1"]
+	if session.Players[1].Responses[0].DoorID != "workplace-3" {
+		t.Errorf("Expected p2's response to be recorded against their door, got %s", session.Players[1].Responses[0].DoorID)
+	}
+	if gameService.checkAllPlayersResponded(session) {
+		t.Error("Expected the round to stay open until p1 responds")
+	}
+}
+
+func TestPresentDoorToSession_ClearsPlayerDoors(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Players[0].CurrentDoor = &models.Door{DoorID: "own-door"}
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if err := gameService.PresentDoorToSession(ctx, "s1", &models.Door{DoorID: "shared"}); err != nil {
+		t.Fatalf("PresentDoorToSession failed: %v", err)
+	}
+
+	if door := gameSessionRepo.sessions["s1"].DoorForPlayer("p1"); door.DoorID != "shared" {
+		t.Errorf("Expected p1 back on the shared door, got %s", door.DoorID)
+	}
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sharedStoreSessionRepository behaves like the database shared by several backend instances:
+// every read returns an independent copy and Update replaces the whole stored session, merging
+// in the writes made since it was read like the Mongo repository's version check. The first two
+// reads wait for each other, so both callers work from the same starting state.
+type sharedStoreSessionRepository struct {
+	*MockGameSessionRepository
+	mu      sync.Mutex
+	reads   int32
+	barrier sync.WaitGroup
+
+	// When set, whole-session updates wait for the first response or player to be appended
+	appended   chan struct{}
+	appendOnce sync.Once
+}
+
+func newSharedStoreSessionRepository(session *models.GameSession) *sharedStoreSessionRepository {
+	repo := &sharedStoreSessionRepository{MockGameSessionRepository: NewMockGameSessionRepository()}
+	repo.sessions[session.SessionID] = session
+	repo.barrier.Add(2)
+	return repo
+}
+
+func (m *sharedStoreSessionRepository) GetByID(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	m.mu.Lock()
+	session := copySession(m.sessions[sessionID])
+	m.mu.Unlock()
+	if session != nil {
+		session.MarkLoaded()
+	}
+
+	if atomic.AddInt32(&m.reads, 1) <= 2 {
+		m.barrier.Done()
+		m.barrier.Wait()
+	}
+	return session, nil
+}
+
+func (m *sharedStoreSessionRepository) Update(ctx context.Context, session *models.GameSession) error {
+	if m.appended != nil {
+		<-m.appended
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored := copySession(m.sessions[session.SessionID]); stored.Version != session.Version {
+		if !session.MergeConcurrentWrites(stored) {
+			return repositories.ErrSessionConflict
+		}
+		session.Version = stored.Version
+	}
+	session.Version++
+	m.sessions[session.SessionID] = copySession(session)
+	session.MarkLoaded()
+	return nil
+}
+
+func (m *sharedStoreSessionRepository) AddPlayerToSession(ctx context.Context, sessionID string, player models.PlayerInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.appended != nil {
+		defer m.appendOnce.Do(func() { close(m.appended) })
+	}
+	session := m.sessions[sessionID]
+	session.Players = append(session.Players, player)
+	bumpVersion(session)
+	return nil
+}
+
+func (m *sharedStoreSessionRepository) AppendPlayerResponse(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.appended != nil {
+		defer m.appendOnce.Do(func() { close(m.appended) })
+	}
+	return copySession(bumpVersion(appendPlayerResponse(m.sessions[sessionID], response))), nil
+}
+
+func (m *sharedStoreSessionRepository) SetResponseScore(ctx context.Context, sessionID string, response models.PlayerResponse) (*models.GameSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return copySession(bumpVersion(setResponseScore(m.sessions[sessionID], response))), nil
+}
+
+// bumpVersion counts an atomic write to a stored session, as the Mongo repository does
+func bumpVersion(session *models.GameSession) *models.GameSession {
+	if session != nil {
+		session.Version++
+	}
+	return session
+}
+
+// stored returns a copy of the session as currently stored
+func (m *sharedStoreSessionRepository) stored(sessionID string) *models.GameSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return copySession(m.sessions[sessionID])
+}
+
+// copySession deep-copies a session the way a database round trip would
+func copySession(session *models.GameSession) *models.GameSession {
+	if session == nil {
+		return nil
+	}
+	data, err := bson.Marshal(session)
+	if err != nil {
+		panic(err)
+	}
+	var copied models.GameSession
+	if err := bson.Unmarshal(data, &copied); err != nil {
+		panic(err)
+	}
+	return &copied
+}
+
+// newRaceSession is an active session with a third player, so two responses don't end the round
+func newRaceSession() *models.GameSession {
+	session := newDraftSession()
+	session.Players = append(session.Players, models.PlayerInfo{PlayerID: "p3"})
+	for i := range session.Players {
+		session.Players[i].IsActive = true
+	}
+	return session
+}
+
+// newRaceGameService is one backend instance serving the shared store. Each gets its own door
+// repository, as a round that does end moves on to a door of its own.
+func newRaceGameService(repo *sharedStoreSessionRepository) GameService {
+	return NewGameService(repo, &MockDoorRepository{}, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+}
+
+func TestSubmitResponse_ConcurrentSubmissionsAreAllKept(t *testing.T) {
+	repo := newSharedStoreSessionRepository(newRaceSession())
+
+	// Each player is served by a different backend instance, so per-session locks don't help
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, playerID := range []string{"p1", "p2"} {
+		gameService := newRaceGameService(repo)
+		wg.Add(1)
+		go func(playerID string) {
+			defer wg.Done()
+			if _, err := gameService.SubmitResponse(context.Background(), "s1", playerID, "I would knock politely"); err != nil {
+				errs <- err
+			}
+		}(playerID)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+
+	stored := repo.stored("s1")
+	for _, player := range stored.Players[:2] {
+		if len(player.Responses) != 1 || player.TotalScore != player.Responses[0].AIScore {
+			t.Errorf("Expected %s's response and score to be kept, got %+v", player.PlayerID, player)
+		}
+	}
+}
+
+func TestApplyScore_KeepsResponsesSubmittedMeanwhile(t *testing.T) {
+	session := newRaceSession()
+	session.Players[0].Responses = []models.PlayerResponse{{ResponseID: "r1", PlayerID: "p1", DoorID: "door-1", ScoringPending: true}}
+	repo := newSharedStoreSessionRepository(session)
+
+	scorer := newRaceGameService(repo).(*GameServiceImpl)
+	submitter := newRaceGameService(repo)
+
+	var wg sync.WaitGroup
+	var scoreErr, submitErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		result := &ScoreResult{Metrics: models.ScoringMetrics{Creativity: 80, Feasibility: 80, Humor: 80, Originality: 80}}
+		_, scoreErr = scorer.applyScore(context.Background(), "s1", "p1", "r1", nil, result, nil)
+	}()
+	go func() {
+		defer wg.Done()
+		_, submitErr = submitter.SubmitResponse(context.Background(), "s1", "p2", "I would knock politely")
+	}()
+	wg.Wait()
+	if scoreErr != nil || submitErr != nil {
+		t.Fatalf("Expected both writes to succeed, got %v and %v", scoreErr, submitErr)
+	}
+
+	stored := repo.stored("s1")
+	if scored := stored.Players[0].Responses[0]; scored.ScoringPending || scored.AIScore != 80 || stored.Players[0].TotalScore != 80 {
+		t.Errorf("Expected p1's response to be scored, got %+v with total %d", scored, stored.Players[0].TotalScore)
+	}
+	if len(stored.Players[1].Responses) != 1 {
+		t.Errorf("Expected p2's response to be kept, got %+v", stored.Players[1].Responses)
+	}
+}
+
+func TestTransition_KeepsResponsesSubmittedMeanwhile(t *testing.T) {
+	session := newRaceSession()
+	session.Mode = models.GameModeMultiplayer
+	session.HostID = "p1"
+	repo := newSharedStoreSessionRepository(session)
+	repo.appended = make(chan struct{})
+
+	// The host pauses the game on one instance while p2 answers on another, whose response is
+	// saved between the host reading the session and saving it paused
+	host := newRaceGameService(repo)
+	submitter := newRaceGameService(repo)
+
+	var wg sync.WaitGroup
+	var pauseErr, submitErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, pauseErr = host.PauseGame(context.Background(), "s1", "p1")
+	}()
+	go func() {
+		defer wg.Done()
+		_, submitErr = submitter.SubmitResponse(context.Background(), "s1", "p2", "I would knock politely")
+	}()
+	wg.Wait()
+	if pauseErr != nil || submitErr != nil {
+		t.Fatalf("Expected both writes to succeed, got %v and %v", pauseErr, submitErr)
+	}
+
+	stored := repo.stored("s1")
+	if stored.Status != models.GameStatusPaused {
+		t.Errorf("Expected the game to be paused, got %s", stored.Status)
+	}
+	if len(stored.Players[1].Responses) != 1 || stored.Players[1].TotalScore != stored.Players[1].Responses[0].AIScore {
+		t.Errorf("Expected p2's response and score to survive the pause, got %+v", stored.Players[1])
+	}
+}
+
+// slowScoringAIClient holds the score of one response until released
+type slowScoringAIClient struct {
+	*MockAIClient
+	slow    string
+	scoring chan struct{}
+	release chan struct{}
+}
+
+func (c *slowScoringAIClient) ScoreResponse(ctx context.Context, door *models.Door, response string) (*ScoreResult, error) {
+	if response == c.slow {
+		close(c.scoring)
+		<-c.release
+	}
+	return c.MockAIClient.ScoreResponse(ctx, door, response)
+}
+
+func TestSubmitResponse_ScoresWithoutHoldingTheSession(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newRaceSession()
+	aiClient := &slowScoringAIClient{MockAIClient: &MockAIClient{}, slow: "I would wait it out", scoring: make(chan struct{}), release: make(chan struct{})}
+	gameService := NewGameService(gameSessionRepo, &MockDoorRepository{}, NewMockPlayerPathRepository(), nil, aiClient, nil, nil)
+
+	submitted := make(chan *models.PlayerResponse, 1)
+	go func() {
+		response, err := gameService.SubmitResponse(context.Background(), "s1", "p1", "I would wait it out")
+		if err != nil {
+			t.Errorf("SubmitResponse failed: %v", err)
+		}
+		submitted <- response
+	}()
+	<-aiClient.scoring
+
+	// p1's response is still being scored, yet p2 answers without waiting for it
+	if _, err := gameService.SubmitResponse(context.Background(), "s1", "p2", "I would knock politely"); err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	if p2 := gameSessionRepo.sessions["s1"].Players[1]; len(p2.Responses) != 1 || p2.Responses[0].ScoringPending {
+		t.Fatalf("Expected p2's response to be scored while p1's was pending, got %+v", p2.Responses)
+	}
+
+	close(aiClient.release)
+	response := <-submitted
+	if response == nil || response.ScoringPending || response.AIScore != 70 || response.Feedback == "" {
+		t.Fatalf("Expected p1's response to come back scored, got %+v", response)
+	}
+	if p1 := gameSessionRepo.sessions["s1"].Players[0]; p1.Responses[0].ScoringPending || p1.TotalScore != 70 {
+		t.Errorf("Expected p1's score to be saved, got %+v with total %d", p1.Responses[0], p1.TotalScore)
+	}
+}
+
+// newLobbySession is a session waiting for players, hosted by p1
+func newLobbySession() *models.GameSession {
+	session := newRaceSession()
+	session.Status = models.GameStatusWaiting
+	session.CurrentDoor = nil
+	session.HostID = "p1"
+	return session
+}
+
+func TestKickPlayer_KeepsPlayerWhoJoinedMeanwhile(t *testing.T) {
+	repo := newSharedStoreSessionRepository(newLobbySession())
+	repo.appended = make(chan struct{})
+
+	// The host and the joining player are served by different backend instances
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, err := newRaceGameService(repo).KickPlayer(context.Background(), "s1", "p1", "p2"); err != nil {
+			errs <- err
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := newRaceGameService(repo).JoinSession(context.Background(), "s1", "p4", "dave", ""); err != nil {
+			errs <- err
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Expected the kick and the join to both succeed, got %v", err)
+	}
+
+	players := make(map[string]bool)
+	for _, player := range repo.stored("s1").Players {
+		players[player.PlayerID] = true
+	}
+	if !players["p4"] || players["p2"] || !players["p1"] || !players["p3"] {
+		t.Errorf("Expected p4 to have joined and p2 to have been kicked, got %v", players)
+	}
+}
+
+func TestUpdate_RejectsConflictingStateChange(t *testing.T) {
+	repo := newSharedStoreSessionRepository(newRaceSession())
+	ctx := context.Background()
+
+	// Both writers read the session before either saves it
+	var paused, completed *models.GameSession
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); paused, _ = repo.GetByID(ctx, "s1") }()
+	go func() { defer wg.Done(); completed, _ = repo.GetByID(ctx, "s1") }()
+	wg.Wait()
+	paused.Status = models.GameStatusPaused
+	if err := repo.Update(ctx, paused); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	completed.Status = models.GameStatusCompleted
+	if err := repo.Update(ctx, completed); !errors.Is(err, repositories.ErrSessionConflict) {
+		t.Errorf("Expected completing a session paused meanwhile to conflict, got %v", err)
+	}
+	if status := repo.stored("s1").Status; status != models.GameStatusPaused {
+		t.Errorf("Expected the pause to stand, got %s", status)
+	}
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Host control errors
+var (
+	ErrNotHost      = errors.New("only the session host can do that")
+	ErrLobbyLocked  = errors.New("session lobby is locked")
+	ErrPlayerKicked = errors.New("player was removed from this session by the host")
+)
+
+// KickPlayer removes a player from a session's lobby at the host's request and closes their
+// socket. Kicked players cannot join the session again.
+func (s *GameServiceImpl) KickPlayer(ctx context.Context, sessionID, hostID, playerID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.KickPlayer", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(hostID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.hostedSession(ctx, sessionID, hostID, OpKickPlayer)
+	if err != nil {
+		return nil, err
+	}
+	if playerID == hostID {
+		return nil, fmt.Errorf("the host cannot kick themselves")
+	}
+
+	kicked := -1
+	for i, player := range session.Players {
+		if player.PlayerID == playerID {
+			kicked = i
+			break
+		}
+	}
+	if kicked < 0 {
+		return nil, ErrPlayerNotInSession
+	}
+	username := session.Players[kicked].Username
+	session.Players = append(session.Players[:kicked], session.Players[kicked+1:]...)
+	session.KickedPlayers = append(session.KickedPlayers, playerID)
+
+	// The kicked player may have been the one holding the ready check together
+	var countdownCancelled *SessionTransition
+	if session.StartsAt != nil && !session.ReadyThresholdMet() {
+		if countdownCancelled, err = s.stateMachine.Transition(session, models.GameStatusWaiting); err != nil {
+			return nil, err
+		}
+		session.StartsAt = nil
+	}
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to remove player from session: %w", err)
+	}
+	if countdownCancelled != nil {
+		announceTransition(s.wsManager, countdownCancelled)
+		if err := s.scheduler.Cancel(ctx, sessionID, readyCountdownKey); err != nil {
+			fmt.Printf("Warning: failed to cancel ready countdown: %v\n", err)
+		}
+	}
+
+	if s.wsManager != nil {
+		event := WebSocketEvent{
+			Type:      "player-kicked",
+			SessionID: sessionID,
+			PlayerID:  playerID,
+			Data: systemMessage(map[string]interface{}{
+				"playerId": playerID,
+				"username": username,
+				"hostId":   hostID,
+			}, session.Locale, i18n.MsgPlayerKicked, username),
+			Timestamp: time.Now(),
+		}
+
+		// The kicked player hears about it before their socket closes
+		s.runInBackground(ctx, sessionID, "broadcast-player-kicked", func(ctx context.Context) {
+			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+				fmt.Printf("Warning: failed to broadcast player kick: %v\n", err)
+			}
+			if err := s.wsManager.DisconnectPlayer(playerID); err != nil {
+				fmt.Printf("Warning: failed to disconnect kicked player: %v\n", err)
+			}
+		})
+	}
+
+	return session, nil
+}
+
+// TransferHost hands moderation of a session to another active player
+func (s *GameServiceImpl) TransferHost(ctx context.Context, sessionID, hostID, newHostID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.TransferHost", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(hostID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.hostedSession(ctx, sessionID, hostID, OpTransferHost)
+	if err != nil {
+		return nil, err
+	}
+	if newHostID == hostID {
+		return nil, fmt.Errorf("player is already the host")
+	}
+	if !isActivePlayer(session, newHostID) {
+		return nil, fmt.Errorf("new host must be an active player in the session")
+	}
+
+	session.HostID = newHostID
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to transfer host: %w", err)
+	}
+
+	if s.wsManager != nil {
+		event := WebSocketEvent{
+			Type:      "host-transferred",
+			SessionID: sessionID,
+			PlayerID:  newHostID,
+			Data: map[string]interface{}{
+				"previousHostId": hostID,
+				"hostId":         newHostID,
+			},
+			Timestamp: time.Now(),
+		}
+
+		s.runInBackground(ctx, sessionID, "broadcast-host-transferred", func(ctx context.Context) {
+			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+				fmt.Printf("Warning: failed to broadcast host transfer: %v\n", err)
+			}
+		})
+	}
+
+	return session, nil
+}
+
+// SetLobbyLocked locks a waiting session against new joins, or opens it again
+func (s *GameServiceImpl) SetLobbyLocked(ctx context.Context, sessionID, hostID string, locked bool) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.SetLobbyLocked", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(hostID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.hostedSession(ctx, sessionID, hostID, OpLockLobby)
+	if err != nil {
+		return nil, err
+	}
+	if session.Locked == locked {
+		return session, nil
+	}
+
+	session.Locked = locked
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update lobby lock: %w", err)
+	}
+
+	if s.wsManager != nil {
+		eventType := "lobby-unlocked"
+		if locked {
+			eventType = "lobby-locked"
+		}
+		event := WebSocketEvent{
+			Type:      eventType,
+			SessionID: sessionID,
+			PlayerID:  hostID,
+			Data: map[string]interface{}{
+				"locked": locked,
+				"hostId": hostID,
+			},
+			Timestamp: time.Now(),
+		}
+
+		s.runInBackground(ctx, sessionID, "broadcast-lobby-lock", func(ctx context.Context) {
+			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+				fmt.Printf("Warning: failed to broadcast lobby lock: %v\n", err)
+			}
+		})
+	}
+
+	return session, nil
+}
+
+// hostedSession loads a session for a host-only operation, checking that the caller is the
+// host and that the operation is allowed in the session's state
+func (s *GameServiceImpl) hostedSession(ctx context.Context, sessionID, hostID string, operation SessionOperation) (*models.GameSession, error) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if session.Host() != hostID {
+		return nil, ErrNotHost
+	}
+	if err := s.stateMachine.Require(session, operation); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func newHostedSession() *models.GameSession {
+	return &models.GameSession{
+		SessionID: "s1",
+		Mode:      models.GameModeMultiplayer,
+		Status:    models.GameStatusWaiting,
+		HostID:    "p1",
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "Host", IsActive: true},
+			{PlayerID: "p2", Username: "Player 2", IsActive: true},
+			{PlayerID: "p3", Username: "Player 3", IsActive: true},
+		},
+	}
+}
+
+func TestKickPlayer_RemovesPlayerAndClosesSocket(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newHostedSession()
+	wsManager := NewMockWebSocketManager()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), wsManager, nil, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+	)
+
+	if _, err := gameService.KickPlayer(ctx, "s1", "p2", "p3"); !errors.Is(err, ErrNotHost) {
+		t.Fatalf("Expected ErrNotHost for a non-host, got %v", err)
+	}
+
+	session, err := gameService.KickPlayer(ctx, "s1", "p1", "p2")
+	if err != nil {
+		t.Fatalf("KickPlayer failed: %v", err)
+	}
+	if len(session.Players) != 2 || isActivePlayer(session, "p2") {
+		t.Errorf("Expected p2 to be removed, got %+v", session.Players)
+	}
+	if len(wsManager.disconnectedPlayers) != 1 || wsManager.disconnectedPlayers[0] != "p2" {
+		t.Errorf("Expected p2's socket to be closed, got %v", wsManager.disconnectedPlayers)
+	}
+
+	if _, err := gameService.JoinSession(ctx, "s1", "p2", "Player 2", ""); !errors.Is(err, ErrPlayerKicked) {
+		t.Errorf("Expected a kicked player to be refused, got %v", err)
+	}
+}
+
+func TestKickPlayer_CancelsReadyCountdown(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newHostedSession()
+	session.Settings.ReadyPercent = 60
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	)
+
+	for _, playerID := range []string{"p1", "p2"} {
+		if _, err := gameService.SetPlayerReady(ctx, "s1", playerID, true); err != nil {
+			t.Fatalf("SetPlayerReady failed: %v", err)
+		}
+	}
+	if session.Status != models.GameStatusStarting {
+		t.Fatalf("Expected 2 of 3 ready players to start the countdown, got %s", session.Status)
+	}
+
+	// Without p2 only 1 of 2 players is ready
+	if _, err := gameService.KickPlayer(ctx, "s1", "p1", "p2"); err != nil {
+		t.Fatalf("KickPlayer failed: %v", err)
+	}
+	if session.Status != models.GameStatusWaiting || session.StartsAt != nil {
+		t.Errorf("Expected the countdown to be cancelled, got %s (startsAt %v)", session.Status, session.StartsAt)
+	}
+}
+
+func TestTransferHost_MovesModerationToNewHost(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newHostedSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if _, err := gameService.TransferHost(ctx, "s1", "p1", "p9"); err == nil {
+		t.Fatal("Expected transferring to a player outside the session to fail")
+	}
+	if _, err := gameService.TransferHost(ctx, "s1", "p1", "p2"); err != nil {
+		t.Fatalf("TransferHost failed: %v", err)
+	}
+
+	if _, err := gameService.SetLobbyLocked(ctx, "s1", "p1", true); !errors.Is(err, ErrNotHost) {
+		t.Errorf("Expected the previous host to lose host controls, got %v", err)
+	}
+	if _, err := gameService.SetLobbyLocked(ctx, "s1", "p2", true); err != nil {
+		t.Errorf("Expected the new host to lock the lobby, got %v", err)
+	}
+}
+
+func TestSetLobbyLocked_RefusesJoinsUntilUnlocked(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newHostedSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if _, err := gameService.SetLobbyLocked(ctx, "s1", "p1", true); err != nil {
+		t.Fatalf("SetLobbyLocked failed: %v", err)
+	}
+	if _, err := gameService.JoinSession(ctx, "s1", "p4", "Player 4", ""); !errors.Is(err, ErrLobbyLocked) {
+		t.Fatalf("Expected ErrLobbyLocked, got %v", err)
+	}
+
+	if _, err := gameService.SetLobbyLocked(ctx, "s1", "p1", false); err != nil {
+		t.Fatalf("SetLobbyLocked failed: %v", err)
+	}
+	if _, err := gameService.JoinSession(ctx, "s1", "p4", "Player 4", ""); err != nil {
+		t.Errorf("Expected the unlocked lobby to accept the player, got %v", err)
+	}
+}
+
+func TestHostControls_SessionsWithoutHostFallBackToFirstPlayer(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newHostedSession()
+	session.HostID = ""
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if _, err := gameService.SetLobbyLocked(context.Background(), "s1", "p1", true); err != nil {
+		t.Errorf("Expected the first player to act as host, got %v", err)
+	}
+}
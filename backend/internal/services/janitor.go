@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Session janitor defaults
+const (
+	DefaultSessionInactivityTimeout = 30 * time.Minute
+	DefaultSessionJanitorInterval   = 5 * time.Minute
+)
+
+// unfinishedStatuses are the states a session can be abandoned in
+var unfinishedStatuses = []models.GameStatus{
+	models.GameStatusWaiting,
+	models.GameStatusStarting,
+	models.GameStatusActive,
+	models.GameStatusScoring,
+	models.GameStatusRevealing,
+	models.GameStatusPaused,
+	models.GameStatusCompleting,
+}
+
+// SessionJanitor marks sessions abandoned once nothing has happened in them for too long
+type SessionJanitor interface {
+	Start(ctx context.Context)
+	Sweep(ctx context.Context) (int, error)
+	Expire(ctx context.Context, sessionID string) (*SessionTransition, error)
+	ListSessions(ctx context.Context, status models.GameStatus) ([]*models.GameSession, error)
+	// SetInactivityTimeout changes how long a session may sit idle, from the next sweep on
+	SetInactivityTimeout(inactivity time.Duration)
+}
+
+// SessionJanitorImpl implements the SessionJanitor interface
+type SessionJanitorImpl struct {
+	gameSessionRepo repositories.GameSessionRepository
+	playerPathRepo  repositories.PlayerPathRepository
+	wsManager       WebSocketManager
+	stateMachine    SessionStateMachine
+	quota           SessionQuotaService
+	routing         RoutingService
+	inactivity      atomic.Int64 // nanoseconds, changed by SetInactivityTimeout
+	interval        time.Duration
+
+	abandoned     *monitoring.Counter
+	cleanupErrors *monitoring.Counter
+	duration      *monitoring.Histogram
+}
+
+// SessionJanitorOption configures optional dependencies of the session janitor
+type SessionJanitorOption func(*SessionJanitorImpl)
+
+// WithJanitorSessionQuota releases the session quota of every session the janitor abandons
+func WithJanitorSessionQuota(quota SessionQuotaService) SessionJanitorOption {
+	return func(j *SessionJanitorImpl) {
+		j.quota = quota
+	}
+}
+
+// WithJanitorSessionRoutes drops the route of every session the janitor abandons
+func WithJanitorSessionRoutes(routing RoutingService) SessionJanitorOption {
+	return func(j *SessionJanitorImpl) {
+		j.routing = routing
+	}
+}
+
+// NewSessionJanitor creates a janitor that every interval abandons sessions inactive for longer than inactivity
+func NewSessionJanitor(
+	gameSessionRepo repositories.GameSessionRepository,
+	playerPathRepo repositories.PlayerPathRepository,
+	wsManager WebSocketManager,
+	inactivity, interval time.Duration,
+	opts ...SessionJanitorOption,
+) SessionJanitor {
+	if inactivity <= 0 {
+		inactivity = DefaultSessionInactivityTimeout
+	}
+	if interval <= 0 {
+		interval = DefaultSessionJanitorInterval
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	janitor := &SessionJanitorImpl{
+		gameSessionRepo: gameSessionRepo,
+		playerPathRepo:  playerPathRepo,
+		wsManager:       wsManager,
+		stateMachine:    NewSessionStateMachine(),
+		interval:        interval,
+		abandoned:       collector.NewCounter("sessions_abandoned_total", "Sessions marked abandoned after inactivity", nil),
+		cleanupErrors:   collector.NewCounter("session_cleanup_errors_total", "Failures while abandoning inactive sessions", nil),
+		duration:        collector.NewHistogram("session_cleanup_duration_seconds", "Time spent sweeping for inactive sessions", nil),
+	}
+	for _, opt := range opts {
+		opt(janitor)
+	}
+	janitor.inactivity.Store(int64(inactivity))
+	return janitor
+}
+
+// SetInactivityTimeout changes the inactivity window; non-positive values are ignored
+func (j *SessionJanitorImpl) SetInactivityTimeout(inactivity time.Duration) {
+	if inactivity > 0 {
+		j.inactivity.Store(int64(inactivity))
+	}
+}
+
+// Start sweeps every interval until the context is cancelled
+func (j *SessionJanitorImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := j.Sweep(ctx); err != nil {
+				fmt.Printf("Warning: session cleanup failed: %v\n", err)
+			}
+		}
+	}
+}
+
+// Sweep abandons every unfinished session inactive for longer than the inactivity window and
+// returns how many it abandoned. A session that fails to clean up is retried on the next sweep.
+func (j *SessionJanitorImpl) Sweep(ctx context.Context) (int, error) {
+	start := time.Now()
+	defer func() {
+		j.duration.Observe(time.Since(start).Seconds())
+	}()
+
+	cutoff := start.Add(-time.Duration(j.inactivity.Load()))
+	abandoned := 0
+	for _, status := range unfinishedStatuses {
+		sessions, err := j.gameSessionRepo.GetActiveSessionsByStatus(ctx, status)
+		if err != nil {
+			j.cleanupErrors.Inc()
+			return abandoned, fmt.Errorf("failed to list %s sessions: %w", status, err)
+		}
+
+		for _, session := range sessions {
+			if !session.LastActivity().Before(cutoff) {
+				continue
+			}
+			if _, err := j.abandon(ctx, session); err != nil {
+				j.cleanupErrors.Inc()
+				fmt.Printf("Warning: failed to abandon session %s: %v\n", session.SessionID, err)
+				continue
+			}
+			abandoned++
+		}
+	}
+
+	return abandoned, nil
+}
+
+// Expire abandons a single unfinished session now, however recently it was active
+func (j *SessionJanitorImpl) Expire(ctx context.Context, sessionID string) (*SessionTransition, error) {
+	session, err := j.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	change, err := j.abandon(ctx, session)
+	if err != nil {
+		j.cleanupErrors.Inc()
+		return nil, err
+	}
+	return change, nil
+}
+
+// ListSessions returns the sessions in a state, e.g. the abandoned ones for inspection
+func (j *SessionJanitorImpl) ListSessions(ctx context.Context, status models.GameStatus) ([]*models.GameSession, error) {
+	sessions, err := j.gameSessionRepo.GetActiveSessionsByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s sessions: %w", status, err)
+	}
+	return sessions, nil
+}
+
+// abandon marks the session abandoned, tells its players, closes their sockets and releases
+// their path state
+func (j *SessionJanitorImpl) abandon(ctx context.Context, session *models.GameSession) (*SessionTransition, error) {
+	change, err := j.stateMachine.Transition(session, models.GameStatusAbandoned)
+	if err != nil {
+		return nil, err
+	}
+	if err := j.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save abandoned session: %w", err)
+	}
+	j.abandoned.Inc()
+	if j.quota != nil {
+		j.quota.Release(ctx, session.SessionID)
+	}
+	if j.routing != nil {
+		if err := j.routing.Release(ctx, session.SessionID); err != nil {
+			fmt.Printf("Warning: failed to release session route: %v\n", err)
+		}
+	}
+
+	announceTransition(j.wsManager, change)
+	if j.wsManager != nil {
+		if err := j.wsManager.CloseSession(session.SessionID); err != nil {
+			fmt.Printf("Warning: failed to close session connections: %v\n", err)
+		}
+	}
+
+	for _, player := range session.Players {
+		if err := j.playerPathRepo.ReleasePlayer(ctx, player.PlayerID); err != nil {
+			fmt.Printf("Warning: failed to release player path: %v\n", err)
+		}
+	}
+
+	return change, nil
+}
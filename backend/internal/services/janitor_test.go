@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newIdleSession(id string, status models.GameStatus, idle time.Duration) *models.GameSession {
+	return &models.GameSession{
+		SessionID:    id,
+		Status:       status,
+		CreatedAt:    time.Now().Add(-2 * idle),
+		LastActiveAt: time.Now().Add(-idle),
+		Players:      []models.PlayerInfo{{PlayerID: id + "-player", IsActive: true}},
+	}
+}
+
+func TestSessionJanitor_AbandonsInactiveSessions(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["stale-lobby"] = newIdleSession("stale-lobby", models.GameStatusWaiting, 2*time.Hour)
+	gameSessionRepo.sessions["stale-game"] = newIdleSession("stale-game", models.GameStatusActive, 2*time.Hour)
+	gameSessionRepo.sessions["busy-game"] = newIdleSession("busy-game", models.GameStatusActive, time.Minute)
+	gameSessionRepo.sessions["finished"] = newIdleSession("finished", models.GameStatusCompleted, 2*time.Hour)
+
+	playerPathRepo := NewMockPlayerPathRepository()
+	playerPathRepo.paths["stale-game-player"] = &models.PlayerPath{PlayerID: "stale-game-player", CurrentPosition: 3}
+	wsManager := NewMockWebSocketManager()
+	janitor := NewSessionJanitor(gameSessionRepo, playerPathRepo, wsManager, time.Hour, time.Minute)
+
+	abandoned, err := janitor.Sweep(ctx)
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if abandoned != 2 {
+		t.Errorf("Expected 2 sessions to be abandoned, got %d", abandoned)
+	}
+
+	expected := map[string]models.GameStatus{
+		"stale-lobby": models.GameStatusAbandoned,
+		"stale-game":  models.GameStatusAbandoned,
+		"busy-game":   models.GameStatusActive,
+		"finished":    models.GameStatusCompleted,
+	}
+	for id, status := range expected {
+		if got := gameSessionRepo.sessions[id].Status; got != status {
+			t.Errorf("Expected %s to be %s, got %s", id, status, got)
+		}
+	}
+	if gameSessionRepo.sessions["stale-game"].AbandonedAt == nil {
+		t.Error("Expected the abandonment time to be recorded")
+	}
+	if len(wsManager.closedSessions) != 2 {
+		t.Errorf("Expected both abandoned sessions' sockets to be closed, got %v", wsManager.closedSessions)
+	}
+	if _, exists := playerPathRepo.paths["stale-game-player"]; exists {
+		t.Error("Expected the abandoned player's path state to be released")
+	}
+
+	listed, err := janitor.ListSessions(ctx, models.GameStatusAbandoned)
+	if err != nil {
+		t.Fatalf("ListSessions failed: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Errorf("Expected 2 abandoned sessions listed, got %d", len(listed))
+	}
+
+	// Abandoned sessions are left alone on later sweeps
+	if abandoned, _ := janitor.Sweep(ctx); abandoned != 0 {
+		t.Errorf("Expected nothing left to abandon, got %d", abandoned)
+	}
+}
+
+func TestSessionJanitor_ExpiresSessionOnDemand(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["busy-game"] = newIdleSession("busy-game", models.GameStatusActive, time.Minute)
+	gameSessionRepo.sessions["finished"] = newIdleSession("finished", models.GameStatusCompleted, time.Minute)
+	janitor := NewSessionJanitor(gameSessionRepo, NewMockPlayerPathRepository(), NewMockWebSocketManager(), time.Hour, time.Minute)
+
+	change, err := janitor.Expire(ctx, "busy-game")
+	if err != nil {
+		t.Fatalf("Expire failed: %v", err)
+	}
+	if change.From != models.GameStatusActive || gameSessionRepo.sessions["busy-game"].Status != models.GameStatusAbandoned {
+		t.Errorf("Expected an active session to be abandoned, got %+v", change)
+	}
+
+	if _, err := janitor.Expire(ctx, "finished"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected a finished session to stay finished, got %v", err)
+	}
+	if _, err := janitor.Expire(ctx, "missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Join code settings
+const (
+	JoinCodeLength      = 6
+	maxJoinCodeAttempts = 10
+	maxPasswordLength   = 72 // bcrypt ignores anything longer
+)
+
+// joinCodeAlphabet leaves out characters that are easy to misread, such as 0/O and 1/I/L
+const joinCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// Join code and password errors
+var (
+	ErrJoinCodeNotFound  = errors.New("no session found for that join code")
+	ErrIncorrectPassword = errors.New("incorrect session password")
+)
+
+// JoinCodeLookup describes the session a join code leads to, without revealing its players
+type JoinCodeLookup struct {
+	Code              string            `json:"code"`
+	SessionID         string            `json:"sessionId"`
+	Mode              models.GameMode   `json:"mode"`
+	Status            models.GameStatus `json:"status"`
+	IsPrivate         bool              `json:"isPrivate"`
+	PasswordProtected bool              `json:"passwordProtected"`
+	PlayerCount       int               `json:"playerCount"`
+}
+
+// WithJoinCodes issues every new session a short join code, reserved in the given store
+func WithJoinCodes(store repositories.JoinCodeStore) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.joinCodes = store
+	}
+}
+
+// NormalizeJoinCode upper-cases a join code and strips the spaces players add when copying it
+func NormalizeJoinCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
+
+// LookupJoinCode resolves a join code to its session
+func (s *GameServiceImpl) LookupJoinCode(ctx context.Context, code string) (*JoinCodeLookup, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.LookupJoinCode")
+	defer span.End()
+
+	code = NormalizeJoinCode(code)
+	if s.joinCodes == nil || len(code) != JoinCodeLength {
+		return nil, ErrJoinCodeNotFound
+	}
+
+	sessionID, err := s.joinCodes.Resolve(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if sessionID == "" {
+		return nil, ErrJoinCodeNotFound
+	}
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrJoinCodeNotFound
+	}
+
+	return &JoinCodeLookup{
+		Code:              code,
+		SessionID:         session.SessionID,
+		Mode:              session.Mode,
+		Status:            session.Status,
+		IsPrivate:         session.Settings.IsPrivate,
+		PasswordProtected: session.PasswordProtected(),
+		PlayerCount:       len(session.Players),
+	}, nil
+}
+
+// issueJoinCode reserves an unused join code for a session, retrying on collisions
+func (s *GameServiceImpl) issueJoinCode(ctx context.Context, sessionID string) (string, error) {
+	for attempt := 0; attempt < maxJoinCodeAttempts; attempt++ {
+		code := generateJoinCode()
+		reserved, err := s.joinCodes.Reserve(ctx, code, sessionID)
+		if err != nil {
+			return "", err
+		}
+		if reserved {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("no free join code after %d attempts", maxJoinCodeAttempts)
+}
+
+// generateJoinCode returns a random join code
+func generateJoinCode() string {
+	code := make([]byte, JoinCodeLength)
+	for i := range code {
+		code[i] = joinCodeAlphabet[random.Intn(len(joinCodeAlphabet))]
+	}
+	return string(code)
+}
+
+// hashSessionPassword hashes a session's join password for storage
+func hashSessionPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash session password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// checkSessionPassword returns ErrIncorrectPassword unless the password opens the session
+func checkSessionPassword(session *models.GameSession, password string) error {
+	if !session.PasswordProtected() {
+		return nil
+	}
+	if bcrypt.CompareHashAndPassword([]byte(session.PasswordHash), []byte(password)) != nil {
+		return ErrIncorrectPassword
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockJoinCodeStore keeps join codes in memory
+type MockJoinCodeStore struct {
+	codes map[string]string
+}
+
+func NewMockJoinCodeStore() *MockJoinCodeStore {
+	return &MockJoinCodeStore{codes: make(map[string]string)}
+}
+
+func (m *MockJoinCodeStore) Reserve(ctx context.Context, code, sessionID string) (bool, error) {
+	if _, taken := m.codes[code]; taken {
+		return false, nil
+	}
+	m.codes[code] = sessionID
+	return true, nil
+}
+
+func (m *MockJoinCodeStore) Resolve(ctx context.Context, code string) (string, error) {
+	return m.codes[code], nil
+}
+
+func (m *MockJoinCodeStore) Release(ctx context.Context, code string) error {
+	delete(m.codes, code)
+	return nil
+}
+
+func TestCreateSession_IssuesJoinCodeThatResolves(t *testing.T) {
+	ctx := context.Background()
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithJoinCodes(NewMockJoinCodeStore()),
+	)
+
+	session, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Player 1", nil, "en", models.SessionSettings{IsPrivate: true})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if len(session.JoinCode) != JoinCodeLength || strings.Trim(session.JoinCode, joinCodeAlphabet) != "" {
+		t.Fatalf("Expected a %d-character join code, got %q", JoinCodeLength, session.JoinCode)
+	}
+
+	// Codes are matched however players type them
+	lookup, err := gameService.LookupJoinCode(ctx, " "+strings.ToLower(session.JoinCode))
+	if err != nil {
+		t.Fatalf("LookupJoinCode failed: %v", err)
+	}
+	if lookup.SessionID != session.SessionID || !lookup.IsPrivate || lookup.PasswordProtected {
+		t.Errorf("Unexpected lookup result: %+v", lookup)
+	}
+
+	if _, err := gameService.LookupJoinCode(ctx, "ZZZZZZ"); !errors.Is(err, ErrJoinCodeNotFound) {
+		t.Errorf("Expected ErrJoinCodeNotFound for an unknown code, got %v", err)
+	}
+}
+
+func TestCreateSession_RetriesJoinCodeCollisions(t *testing.T) {
+	// Replay the seeded draws CreateSession makes, the session ID first, to learn its first code
+	random.Seed(7)
+	random.ID()
+	first := generateJoinCode()
+	random.Seed(7)
+	defer random.Reset()
+
+	store := NewMockJoinCodeStore()
+	store.codes[first] = "older-session"
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithJoinCodes(store),
+	)
+
+	session, err := gameService.CreateSession(context.Background(), models.GameModeMultiplayer, "p1", "Player 1", nil, "en", models.SessionSettings{})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if session.JoinCode == "" || session.JoinCode == first {
+		t.Errorf("Expected a fresh code after the collision on %s, got %q", first, session.JoinCode)
+	}
+	if store.codes[first] != "older-session" {
+		t.Error("The colliding code should still belong to the older session")
+	}
+}
+
+func TestJoinSession_VerifiesPassword(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	session, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Player 1", nil, "en", models.SessionSettings{IsPrivate: true, Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if session.PasswordHash == "" || session.PasswordHash == "hunter2" || session.Settings.Password != "" {
+		t.Fatal("Expected the password to be stored only as a hash")
+	}
+
+	if _, err := gameService.JoinSession(ctx, session.SessionID, "p2", "Player 2", "wrong"); !errors.Is(err, ErrIncorrectPassword) {
+		t.Fatalf("Expected ErrIncorrectPassword, got %v", err)
+	}
+	if _, err := gameService.JoinSession(ctx, session.SessionID, "p2", "Player 2", "hunter2"); err != nil {
+		t.Errorf("Expected the right password to join, got %v", err)
+	}
+}
+
+func TestCreateSession_PasswordRequiresPrivateSession(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	_, err := gameService.CreateSession(context.Background(), models.GameModeMultiplayer, "p1", "Player 1", nil, "en", models.SessionSettings{Password: "hunter2"})
+	if !errors.Is(err, ErrInvalidSessionSettings) {
+		t.Errorf("Expected ErrInvalidSessionSettings, got %v", err)
+	}
+}
@@ -16,23 +16,58 @@ type LeaderboardService interface {
 	GetPlayerRank(ctx context.Context, playerID string, category string) (int, error)
 	GetFastestCompletions(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error)
 	GetHighestAverageScores(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error)
+	ShadowPlayer(ctx context.Context, playerID string, shadowed bool) error
 }
 
 // LeaderboardServiceImpl implements the LeaderboardService interface
 type LeaderboardServiceImpl struct {
 	leaderboardRepo repositories.LeaderboardRepository
 	gameSessionRepo repositories.GameSessionRepository
+	materializer    LeaderboardMaterializer
+	friends         FriendService
+	flagged         repositories.PlayerActivityRepository
+}
+
+// LeaderboardServiceOption configures optional leaderboard service dependencies
+type LeaderboardServiceOption func(*LeaderboardServiceImpl)
+
+// WithLeaderboardMaterializer serves global leaderboard reads from materialized snapshots
+func WithLeaderboardMaterializer(materializer LeaderboardMaterializer) LeaderboardServiceOption {
+	return func(s *LeaderboardServiceImpl) {
+		s.materializer = materializer
+	}
+}
+
+// WithFriendService enables friends-only leaderboards
+func WithFriendService(friends FriendService) LeaderboardServiceOption {
+	return func(s *LeaderboardServiceImpl) {
+		s.friends = friends
+	}
+}
+
+// WithShadowedPlayers keeps the games of players flagged for scripted play off the leaderboards
+func WithShadowedPlayers(activity repositories.PlayerActivityRepository) LeaderboardServiceOption {
+	return func(s *LeaderboardServiceImpl) {
+		s.flagged = activity
+	}
 }
 
 // NewLeaderboardService creates a new leaderboard service
 func NewLeaderboardService(
 	leaderboardRepo repositories.LeaderboardRepository,
 	gameSessionRepo repositories.GameSessionRepository,
+	opts ...LeaderboardServiceOption,
 ) LeaderboardService {
-	return &LeaderboardServiceImpl{
+	service := &LeaderboardServiceImpl{
 		leaderboardRepo: leaderboardRepo,
 		gameSessionRepo: gameSessionRepo,
 	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
 }
 
 // RecordGameCompletion records a player's game completion for leaderboard tracking
@@ -57,7 +92,7 @@ func (s *LeaderboardServiceImpl) RecordGameCompletion(ctx context.Context, sessi
 	}
 	
 	if player == nil {
-		return fmt.Errorf("player not found in session: %s", playerID)
+		return fmt.Errorf("%w: %s", ErrPlayerNotInSession, playerID)
 	}
 	
 	// Calculate completion time
@@ -89,6 +124,17 @@ func (s *LeaderboardServiceImpl) RecordGameCompletion(ctx context.Context, sessi
 		Theme:          session.Theme,
 		SessionID:      session.SessionID,
 		CompletedAt:    time.Now(),
+		Experiments:    session.Experiments,
+		Format:         session.Settings.Format,
+	}
+	
+	// Flagged players still see their own games recorded, but nobody else sees them listed
+	if s.flagged != nil {
+		flagged, err := s.flagged.IsFlagged(ctx, playerID)
+		if err != nil {
+			fmt.Printf("Warning: failed to check whether player %s is flagged: %v\n", playerID, err)
+		}
+		entry.Shadowed = flagged
 	}
 	
 	// Only record if the player actually completed doors
@@ -96,6 +142,11 @@ func (s *LeaderboardServiceImpl) RecordGameCompletion(ctx context.Context, sessi
 		if err := s.leaderboardRepo.AddEntry(ctx, entry); err != nil {
 			return fmt.Errorf("failed to add leaderboard entry: %w", err)
 		}
+		
+		// New entries make the materialized snapshots stale
+		if s.materializer != nil {
+			s.materializer.Invalidate(ctx)
+		}
 	}
 	
 	return nil
@@ -110,7 +161,17 @@ func (s *LeaderboardServiceImpl) GetGlobalLeaderboard(ctx context.Context, filte
 	
 	// Ensure limit doesn't exceed maximum
 	if filter.Limit > 100 {
-		filter.Limit = 100
+		filter.Limit = MaxLeaderboardLimit
+	}
+	
+	if err := s.resolveFriendsFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	
+	if s.materializer != nil {
+		if leaderboard, ok := s.materializer.GetGlobalLeaderboard(ctx, filter); ok {
+			return leaderboard, nil
+		}
 	}
 	
 	leaderboard, err := s.leaderboardRepo.GetGlobalLeaderboard(ctx, filter)
@@ -123,6 +184,12 @@ func (s *LeaderboardServiceImpl) GetGlobalLeaderboard(ctx context.Context, filte
 
 // GetLeaderboardStats retrieves aggregated leaderboard statistics
 func (s *LeaderboardServiceImpl) GetLeaderboardStats(ctx context.Context) (*models.LeaderboardStats, error) {
+	if s.materializer != nil {
+		if stats, ok := s.materializer.GetLeaderboardStats(ctx); ok {
+			return stats, nil
+		}
+	}
+	
 	stats, err := s.leaderboardRepo.GetLeaderboardStats(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get leaderboard stats: %w", err)
@@ -138,10 +205,11 @@ func (s *LeaderboardServiceImpl) GetPlayerRank(ctx context.Context, playerID str
 		"fastest":        true,
 		"highest_avg":    true,
 		"most_completed": true,
+		"best_of":        true,
 	}
 	
 	if !validCategories[category] {
-		return 0, fmt.Errorf("invalid category: %s. Valid categories are: fastest, highest_avg, most_completed", category)
+		return 0, fmt.Errorf("invalid category: %s. Valid categories are: fastest, highest_avg, most_completed, best_of", category)
 	}
 	
 	rank, err := s.leaderboardRepo.GetPlayerRank(ctx, playerID, category)
@@ -159,6 +227,10 @@ func (s *LeaderboardServiceImpl) GetFastestCompletions(ctx context.Context, filt
 		filter.Limit = 10
 	}
 	
+	if err := s.resolveFriendsFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	
 	entries, err := s.leaderboardRepo.GetFastestCompletions(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get fastest completions: %w", err)
@@ -174,10 +246,43 @@ func (s *LeaderboardServiceImpl) GetHighestAverageScores(ctx context.Context, fi
 		filter.Limit = 10
 	}
 	
+	if err := s.resolveFriendsFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	
 	entries, err := s.leaderboardRepo.GetHighestAverageScores(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get highest average scores: %w", err)
 	}
 	
 	return entries, nil
+}
+
+// ShadowPlayer hides the player's games from the leaderboards, or lists them again
+func (s *LeaderboardServiceImpl) ShadowPlayer(ctx context.Context, playerID string, shadowed bool) error {
+	if _, err := s.leaderboardRepo.SetShadowed(ctx, playerID, shadowed); err != nil {
+		return fmt.Errorf("failed to shadow player: %w", err)
+	}
+	
+	if s.materializer != nil {
+		s.materializer.Invalidate(ctx)
+	}
+	return nil
+}
+
+// resolveFriendsFilter restricts a friends-only filter to the player and their friends
+func (s *LeaderboardServiceImpl) resolveFriendsFilter(ctx context.Context, filter *models.LeaderboardFilter) error {
+	if filter.FriendsOf == nil {
+		return nil
+	}
+	if s.friends == nil {
+		return ErrFriendsNotEnabled
+	}
+	
+	circle, err := s.friends.CircleOf(ctx, *filter.FriendsOf)
+	if err != nil {
+		return fmt.Errorf("failed to get friend list: %w", err)
+	}
+	filter.PlayerIDs = circle
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Materialization defaults
+const (
+	DefaultLeaderboardMaterializeInterval = time.Minute
+	MaxLeaderboardLimit                   = 100
+)
+
+// LeaderboardMaterializer periodically precomputes the global leaderboard into a snapshot store
+type LeaderboardMaterializer interface {
+	Start(ctx context.Context)
+	Materialize(ctx context.Context) error
+	Invalidate(ctx context.Context)
+	GetGlobalLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (*models.GlobalLeaderboard, bool)
+	GetLeaderboardStats(ctx context.Context) (*models.LeaderboardStats, bool)
+}
+
+// LeaderboardMaterializerImpl implements the LeaderboardMaterializer interface
+type LeaderboardMaterializerImpl struct {
+	leaderboardRepo repositories.LeaderboardRepository
+	store           repositories.LeaderboardSnapshotStore
+	interval        time.Duration
+	refresh         chan struct{}
+
+	hits     *monitoring.Counter
+	misses   *monitoring.Counter
+	duration *monitoring.Histogram
+}
+
+// NewLeaderboardMaterializer creates a materializer that rebuilds snapshots every interval
+func NewLeaderboardMaterializer(
+	leaderboardRepo repositories.LeaderboardRepository,
+	store repositories.LeaderboardSnapshotStore,
+	interval time.Duration,
+) LeaderboardMaterializer {
+	if interval <= 0 {
+		interval = DefaultLeaderboardMaterializeInterval
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	return &LeaderboardMaterializerImpl{
+		leaderboardRepo: leaderboardRepo,
+		store:           store,
+		interval:        interval,
+		refresh:         make(chan struct{}, 1),
+		hits:            collector.NewCounter("leaderboard_snapshot_hits_total", "Leaderboard reads served from materialized snapshots", nil),
+		misses:          collector.NewCounter("leaderboard_snapshot_misses_total", "Leaderboard reads that fell back to MongoDB", nil),
+		duration:        collector.NewHistogram("leaderboard_materialize_duration_seconds", "Time spent materializing leaderboards", nil),
+	}
+}
+
+// Start materializes immediately, then again every interval or whenever the snapshots are invalidated
+func (m *LeaderboardMaterializerImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.materializeAndLog(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.materializeAndLog(ctx)
+		case <-m.refresh:
+			m.materializeAndLog(ctx)
+		}
+	}
+}
+
+// Materialize computes every unfiltered time range and the aggregate stats and stores them
+func (m *LeaderboardMaterializerImpl) Materialize(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		m.duration.Observe(time.Since(start).Seconds())
+	}()
+
+	for _, timeRange := range repositories.LeaderboardSnapshotTimeRanges {
+		filter := models.LeaderboardFilter{Limit: MaxLeaderboardLimit}
+		if timeRange != "all" {
+			rangeValue := timeRange
+			filter.TimeRange = &rangeValue
+		}
+
+		leaderboard, err := m.leaderboardRepo.GetGlobalLeaderboard(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to compute %s leaderboard: %w", timeRange, err)
+		}
+
+		if err := m.store.SaveLeaderboard(ctx, timeRange, leaderboard); err != nil {
+			return fmt.Errorf("failed to store %s leaderboard: %w", timeRange, err)
+		}
+	}
+
+	stats, err := m.leaderboardRepo.GetLeaderboardStats(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to compute leaderboard stats: %w", err)
+	}
+
+	if err := m.store.SaveStats(ctx, stats); err != nil {
+		return fmt.Errorf("failed to store leaderboard stats: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate drops the current snapshots and asks the job to rebuild them right away.
+// Repeated invalidations before the rebuild starts are coalesced.
+func (m *LeaderboardMaterializerImpl) Invalidate(ctx context.Context) {
+	if err := m.store.Invalidate(ctx); err != nil {
+		fmt.Printf("Warning: failed to invalidate leaderboard snapshots: %v\n", err)
+	}
+
+	select {
+	case m.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// GetGlobalLeaderboard serves an unfiltered leaderboard from its snapshot.
+// It reports false when the filter is not materialized or the snapshot is missing.
+func (m *LeaderboardMaterializerImpl) GetGlobalLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (*models.GlobalLeaderboard, bool) {
+	if filter.GameMode != nil || filter.Theme != nil || filter.PlayerIDs != nil {
+		return nil, false
+	}
+
+	timeRange := "all"
+	if filter.TimeRange != nil {
+		timeRange = *filter.TimeRange
+	}
+	if !isMaterializedTimeRange(timeRange) {
+		return nil, false
+	}
+	// Snapshots use rolling windows; calendar ranges in a player's timezone are computed live
+	if filter.Timezone != nil && timeRange != "all" {
+		return nil, false
+	}
+
+	leaderboard, err := m.store.GetLeaderboard(ctx, timeRange)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrLeaderboardSnapshotMiss) {
+			fmt.Printf("Warning: failed to read leaderboard snapshot: %v\n", err)
+		}
+		m.misses.Inc()
+		return nil, false
+	}
+	m.hits.Inc()
+
+	limit := filter.Limit
+	leaderboard.FastestCompletions = truncateEntries(leaderboard.FastestCompletions, limit)
+	leaderboard.HighestAverages = truncateEntries(leaderboard.HighestAverages, limit)
+	leaderboard.MostCompleted = truncateEntries(leaderboard.MostCompleted, limit)
+	leaderboard.RecentWinners = truncateEntries(leaderboard.RecentWinners, limit)
+	leaderboard.BestOfTotals = truncateEntries(leaderboard.BestOfTotals, limit)
+
+	return leaderboard, true
+}
+
+// GetLeaderboardStats serves the aggregate stats from their snapshot
+func (m *LeaderboardMaterializerImpl) GetLeaderboardStats(ctx context.Context) (*models.LeaderboardStats, bool) {
+	stats, err := m.store.GetStats(ctx)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrLeaderboardSnapshotMiss) {
+			fmt.Printf("Warning: failed to read leaderboard stats snapshot: %v\n", err)
+		}
+		m.misses.Inc()
+		return nil, false
+	}
+	m.hits.Inc()
+
+	return stats, true
+}
+
+// materializeAndLog runs a materialization and logs failures without stopping the job
+func (m *LeaderboardMaterializerImpl) materializeAndLog(ctx context.Context) {
+	if err := m.Materialize(ctx); err != nil {
+		fmt.Printf("Warning: leaderboard materialization failed: %v\n", err)
+	}
+}
+
+// isMaterializedTimeRange reports whether a time range has a snapshot
+func isMaterializedTimeRange(timeRange string) bool {
+	for _, materialized := range repositories.LeaderboardSnapshotTimeRanges {
+		if materialized == timeRange {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateEntries limits a category to the requested number of entries
+func truncateEntries(entries []models.LeaderboardEntry, limit int) []models.LeaderboardEntry {
+	if limit > 0 && len(entries) > limit {
+		return entries[:limit]
+	}
+	return entries
+}
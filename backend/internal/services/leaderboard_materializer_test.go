@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MockLeaderboardSnapshotStore implements LeaderboardSnapshotStore in memory
+type MockLeaderboardSnapshotStore struct {
+	mu            sync.Mutex
+	leaderboards  map[string]*models.GlobalLeaderboard
+	stats         *models.LeaderboardStats
+	invalidations int
+}
+
+func NewMockLeaderboardSnapshotStore() *MockLeaderboardSnapshotStore {
+	return &MockLeaderboardSnapshotStore{
+		leaderboards: make(map[string]*models.GlobalLeaderboard),
+	}
+}
+
+func (m *MockLeaderboardSnapshotStore) SaveLeaderboard(ctx context.Context, timeRange string, leaderboard *models.GlobalLeaderboard) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leaderboards[timeRange] = leaderboard
+	return nil
+}
+
+func (m *MockLeaderboardSnapshotStore) GetLeaderboard(ctx context.Context, timeRange string) (*models.GlobalLeaderboard, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	leaderboard, exists := m.leaderboards[timeRange]
+	if !exists {
+		return nil, repositories.ErrLeaderboardSnapshotMiss
+	}
+	copied := *leaderboard
+	return &copied, nil
+}
+
+func (m *MockLeaderboardSnapshotStore) SaveStats(ctx context.Context, stats *models.LeaderboardStats) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats = stats
+	return nil
+}
+
+func (m *MockLeaderboardSnapshotStore) GetStats(ctx context.Context) (*models.LeaderboardStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stats == nil {
+		return nil, repositories.ErrLeaderboardSnapshotMiss
+	}
+	return m.stats, nil
+}
+
+func (m *MockLeaderboardSnapshotStore) Invalidate(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.invalidations++
+	m.leaderboards = make(map[string]*models.GlobalLeaderboard)
+	m.stats = nil
+	return nil
+}
+
+func TestLeaderboardMaterializer_ServesSnapshots(t *testing.T) {
+	ctx := context.Background()
+	leaderboardRepo := NewMockLeaderboardRepository()
+	for i := 0; i < 5; i++ {
+		leaderboardRepo.AddEntry(ctx, &models.LeaderboardEntry{
+			PlayerID:       "player" + string(rune('a'+i)),
+			CompletionTime: time.Duration(i+1) * time.Minute,
+			AverageScore:   float64(50 + i),
+			DoorsCompleted: 3,
+		})
+	}
+
+	store := NewMockLeaderboardSnapshotStore()
+	materializer := NewLeaderboardMaterializer(leaderboardRepo, store, time.Minute)
+
+	if _, ok := materializer.GetGlobalLeaderboard(ctx, models.LeaderboardFilter{Limit: 10}); ok {
+		t.Fatal("Expected a miss before the first materialization")
+	}
+
+	if err := materializer.Materialize(ctx); err != nil {
+		t.Fatalf("Failed to materialize: %v", err)
+	}
+
+	for _, timeRange := range repositories.LeaderboardSnapshotTimeRanges {
+		if _, exists := store.leaderboards[timeRange]; !exists {
+			t.Errorf("Expected %s leaderboard to be materialized", timeRange)
+		}
+	}
+
+	leaderboard, ok := materializer.GetGlobalLeaderboard(ctx, models.LeaderboardFilter{Limit: 2})
+	if !ok {
+		t.Fatal("Expected the leaderboard to be served from the snapshot")
+	}
+	if len(leaderboard.FastestCompletions) != 2 {
+		t.Errorf("Expected snapshot to be trimmed to 2 entries, got %d", len(leaderboard.FastestCompletions))
+	}
+	if leaderboard.FastestCompletions[0].PlayerID != "playera" {
+		t.Errorf("Expected playera to be fastest, got %s", leaderboard.FastestCompletions[0].PlayerID)
+	}
+
+	stats, ok := materializer.GetLeaderboardStats(ctx)
+	if !ok || stats.TotalGamesCompleted != 5 {
+		t.Errorf("Expected materialized stats with 5 games, got %+v", stats)
+	}
+
+	// Filtered requests are not materialized
+	theme := "space"
+	if _, ok := materializer.GetGlobalLeaderboard(ctx, models.LeaderboardFilter{Limit: 10, Theme: &theme}); ok {
+		t.Error("Expected themed leaderboard requests to bypass the snapshot")
+	}
+}
+
+func TestLeaderboardMaterializer_InvalidatesOnNewEntry(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	leaderboardRepo := NewMockLeaderboardRepository()
+	store := NewMockLeaderboardSnapshotStore()
+	materializer := NewLeaderboardMaterializer(leaderboardRepo, store, time.Hour)
+	leaderboardService := NewLeaderboardService(leaderboardRepo, gameSessionRepo, WithLeaderboardMaterializer(materializer))
+
+	if err := materializer.Materialize(ctx); err != nil {
+		t.Fatalf("Failed to materialize: %v", err)
+	}
+
+	startedAt := time.Now().Add(-5 * time.Minute)
+	completedAt := time.Now()
+	gameSessionRepo.sessions["session1"] = &models.GameSession{
+		SessionID:   "session1",
+		Mode:        models.GameModeSinglePlayer,
+		StartedAt:   &startedAt,
+		CompletedAt: &completedAt,
+		Players: []models.PlayerInfo{
+			{
+				PlayerID:  "player1",
+				Responses: []models.PlayerResponse{{AIScore: 80}},
+			},
+		},
+	}
+
+	if err := leaderboardService.RecordGameCompletion(ctx, "session1", "player1"); err != nil {
+		t.Fatalf("Failed to record completion: %v", err)
+	}
+
+	if store.invalidations != 1 {
+		t.Errorf("Expected snapshots to be invalidated once, got %d", store.invalidations)
+	}
+
+	// With no snapshot the service falls back to the repository and sees the new entry
+	leaderboard, err := leaderboardService.GetGlobalLeaderboard(ctx, models.LeaderboardFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("Failed to get leaderboard: %v", err)
+	}
+	if len(leaderboard.FastestCompletions) != 1 {
+		t.Errorf("Expected the new entry after invalidation, got %d entries", len(leaderboard.FastestCompletions))
+	}
+
+	// The running job rebuilds the snapshot after an invalidation
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go materializer.Start(jobCtx)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := materializer.GetGlobalLeaderboard(ctx, models.LeaderboardFilter{Limit: 10}); ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Expected the job to rematerialize the leaderboard")
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+	"time"
+)
+
+// LeaveSession takes a player out of a session at their own request. A player leaving a lobby
+// gives up their seat and may join again; once the game has started they forfeit and stay on
+// the results as inactive. The host role passes to another player, the round closes if only
+// the leaving player was holding it up, and a session nobody is left in is abandoned.
+func (s *GameServiceImpl) LeaveSession(ctx context.Context, sessionID, playerID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.LeaveSession", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if err := s.stateMachine.Require(session, OpLeaveSession); err != nil {
+		return nil, err
+	}
+
+	leaving := -1
+	for i, player := range session.Players {
+		if player.PlayerID == playerID && player.IsActive {
+			leaving = i
+			break
+		}
+	}
+	if leaving < 0 {
+		return nil, ErrPlayerNotInSession
+	}
+	username := session.Players[leaving].Username
+	wasHost := session.Host() == playerID
+
+	inLobby := session.Status == models.GameStatusWaiting || session.Status == models.GameStatusStarting
+	if inLobby {
+		session.Players = append(session.Players[:leaving], session.Players[leaving+1:]...)
+	} else {
+		session.Players[leaving].IsActive = false
+	}
+
+	newHostID := ""
+	if wasHost {
+		for _, player := range session.Players {
+			if player.IsActive && !player.IsBot {
+				newHostID = player.PlayerID
+				break
+			}
+		}
+		session.HostID = newHostID
+	}
+
+	var changes []*SessionTransition
+	countdownCancelled := false
+	switch {
+	case activeHumans(session) == 0:
+		change, err := s.stateMachine.Transition(session, models.GameStatusAbandoned)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	case session.StartsAt != nil && !session.ReadyThresholdMet():
+		// The leaving player may have been the one holding the ready check together
+		change, err := s.stateMachine.Transition(session, models.GameStatusWaiting)
+		if err != nil {
+			return nil, err
+		}
+		session.StartsAt = nil
+		changes = append(changes, change)
+		countdownCancelled = true
+	}
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to remove player from session: %w", err)
+	}
+	s.releaseQuota(ctx, session)
+	s.releaseRoute(ctx, session)
+	for _, change := range changes {
+		announceTransition(s.wsManager, change)
+	}
+	if countdownCancelled {
+		if err := s.scheduler.Cancel(ctx, sessionID, readyCountdownKey); err != nil {
+			fmt.Printf("Warning: failed to cancel ready countdown: %v\n", err)
+		}
+	}
+	if err := s.playerPathRepo.ReleasePlayer(ctx, playerID); err != nil {
+		fmt.Printf("Warning: failed to release player path: %v\n", err)
+	}
+
+	if s.wsManager != nil {
+		events := []WebSocketEvent{{
+			Type:      "player-left",
+			SessionID: sessionID,
+			PlayerID:  playerID,
+			Data: systemMessage(map[string]interface{}{
+				"playerId":  playerID,
+				"username":  username,
+				"forfeited": !inLobby,
+			}, session.Locale, i18n.MsgPlayerQuit, username),
+			Timestamp: time.Now(),
+		}}
+		if newHostID != "" {
+			events = append(events, WebSocketEvent{
+				Type:      "host-transferred",
+				SessionID: sessionID,
+				PlayerID:  newHostID,
+				Data: map[string]interface{}{
+					"previousHostId": playerID,
+					"hostId":         newHostID,
+				},
+				Timestamp: time.Now(),
+			})
+		}
+
+		// The leaving player hears about it before their socket closes
+		s.runInBackground(ctx, sessionID, "broadcast-player-left", func(ctx context.Context) {
+			for _, event := range events {
+				if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+					fmt.Printf("Warning: failed to broadcast %s: %v\n", event.Type, err)
+				}
+			}
+			if err := s.wsManager.DisconnectPlayer(playerID); err != nil {
+				fmt.Printf("Warning: failed to disconnect leaving player: %v\n", err)
+			}
+		})
+	}
+
+	// Don't keep the others waiting on someone who has left
+	switch {
+	case session.Status == models.GameStatusActive && s.checkAllPlayersResponded(session):
+		s.closeRound(ctx, sessionID, session)
+	case session.Status == models.GameStatusScoring && session.VoteDeadline != nil && allVotesIn(session):
+		s.closeVoting(ctx, sessionID, session)
+	}
+
+	return session, nil
+}
+
+// activeHumans counts the players still playing in a session, leaving out bots
+func activeHumans(session *models.GameSession) int {
+	count := 0
+	for _, player := range session.Players {
+		if player.IsActive && !player.IsBot {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLeaveSession_FreesLobbySeatAndPassesHost(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newHostedSession()
+	wsManager := NewMockWebSocketManager()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), wsManager, nil, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+	)
+
+	session, err := gameService.LeaveSession(ctx, "s1", "p1")
+	if err != nil {
+		t.Fatalf("LeaveSession failed: %v", err)
+	}
+	if len(session.Players) != 2 || session.Host() != "p2" {
+		t.Errorf("Expected the host's seat to be freed and p2 to host, got %d players hosted by %s", len(session.Players), session.Host())
+	}
+	if len(wsManager.disconnectedPlayers) != 1 || wsManager.disconnectedPlayers[0] != "p1" {
+		t.Errorf("Expected p1's socket to be closed, got %v", wsManager.disconnectedPlayers)
+	}
+
+	if _, err := gameService.LeaveSession(ctx, "s1", "p1"); !errors.Is(err, ErrPlayerNotInSession) {
+		t.Errorf("Expected ErrPlayerNotInSession leaving twice, got %v", err)
+	}
+	if _, err := gameService.JoinSession(ctx, "s1", "p1", "Host", ""); err != nil {
+		t.Errorf("Expected a player who left the lobby to be able to join again, got %v", err)
+	}
+}
+
+func TestLeaveSession_ForfeitClosesRound(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newHostedSession()
+	session.Status = models.GameStatusActive
+	session.CurrentDoor = &models.Door{DoorID: "door-1"}
+	for i := range session.Players[:2] {
+		session.Players[i].Responses = []models.PlayerResponse{{DoorID: "door-1"}}
+	}
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	)
+	gameService.(*GameServiceImpl).startResponseTimeout(ctx, "s1", "door-1", time.Now().Add(time.Minute))
+
+	// p3 is the only player yet to answer, so the round closes without waiting out the deadline
+	if _, err := gameService.LeaveSession(ctx, "s1", "p3"); err != nil {
+		t.Fatalf("LeaveSession failed: %v", err)
+	}
+	if len(session.Players) != 3 || isActivePlayer(session, "p3") {
+		t.Errorf("Expected p3 to stay on the results as inactive, got %+v", session.Players)
+	}
+	if deadlineScheduled(gameService, "door-1") {
+		t.Error("Expected the round to close once only the leaving player was pending")
+	}
+
+	// The game is abandoned once the last player leaves
+	for _, playerID := range []string{"p1", "p2"} {
+		if _, err := gameService.LeaveSession(ctx, "s1", playerID); err != nil {
+			t.Fatalf("LeaveSession failed: %v", err)
+		}
+	}
+	if session.Status != models.GameStatusAbandoned {
+		t.Errorf("Expected an empty game to be abandoned, got %s", session.Status)
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"fmt"
+	"time"
+)
+
+// Best-of match limits
+const (
+	DefaultMatchRounds = 5
+	MaxMatchRounds     = 15
+)
+
+// EventRoundResults follows each scored round of a best-of match with the standings so far
+const EventRoundResults = "round-results"
+
+// startMatch picks a best-of match's doors and presents the first. Every player answers the
+// same doors in the same order, so the doors are chosen once, when the match starts.
+func (s *GameServiceImpl) startMatch(ctx context.Context, session *models.GameSession) error {
+	doors, err := s.matchDoors(ctx, session)
+	if err != nil {
+		return fmt.Errorf("failed to choose match doors: %w", err)
+	}
+
+	session.MatchDoors = doors
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to save match doors: %w", err)
+	}
+
+	if err := s.PresentDoorToSession(ctx, session.SessionID, doors[0]); err != nil {
+		return fmt.Errorf("failed to present first door: %w", err)
+	}
+	return nil
+}
+
+// matchDoors chooses the doors of a best-of match in the session's theme and locale, getting
+// harder as the match goes on. Stored doors are used once each before pre-generated and
+// generated ones fill any gaps.
+func (s *GameServiceImpl) matchDoors(ctx context.Context, session *models.GameSession) ([]*models.Door, error) {
+	theme := "general"
+	if session.Theme != nil {
+		theme = *session.Theme
+	}
+	locale := i18n.Normalize(session.Locale)
+	rounds := session.Settings.Rounds
+	if rounds <= 0 {
+		rounds = DefaultMatchRounds
+	}
+
+	var stored []*models.Door
+	if s.doorRepo != nil {
+		doors, err := s.doorRepo.GetByTheme(ctx, theme)
+		if err != nil {
+			fmt.Printf("Warning: failed to get stored doors for match: %v\n", err)
+		}
+		stored = doorsInLocale(servableDoors(doors), locale)
+		// Shuffle so repeat matches in a theme don't replay the same doors
+		for i := len(stored) - 1; i > 0; i-- {
+			j := random.Intn(i + 1)
+			stored[i], stored[j] = stored[j], stored[i]
+		}
+	}
+
+	used := make(map[string]bool, rounds)
+	take := func(match func(door *models.Door) bool) *models.Door {
+		for _, door := range stored {
+			if !used[door.DoorID] && match(door) {
+				used[door.DoorID] = true
+				return door
+			}
+		}
+		return nil
+	}
+
+	doors := make([]*models.Door, 0, rounds)
+	for round := 0; round < rounds; round++ {
+		difficulty := matchDifficulty(round, rounds)
+
+		door := take(func(door *models.Door) bool { return door.EffectiveDifficulty() == difficulty })
+		if door == nil {
+			door = s.bankedDoor(ctx, theme, difficulty, locale)
+		}
+		if door == nil {
+			door = take(func(door *models.Door) bool { return true })
+		}
+		if door == nil {
+			generated, err := s.generateDoor(ctx, theme, difficulty, locale)
+			if err != nil {
+				return nil, err
+			}
+			door = generated
+		}
+		doors = append(doors, door)
+	}
+	return doors, nil
+}
+
+// matchDifficulty spreads a match's rounds over the three difficulties, easiest first
+func matchDifficulty(round, rounds int) int {
+	return 1 + round*3/rounds
+}
+
+// advanceMatch reports a best-of round's results, then presents the next door or, after the
+// last round, ends the match in favor of the highest total score, or the higher scoring team
+func (s *GameServiceImpl) advanceMatch(ctx context.Context, session *models.GameSession) error {
+	round, rounds := session.MatchRound(), len(session.MatchDoors)
+	leader := matchLeader(session)
+
+	if s.wsManager != nil && leader != nil {
+		scores := make(map[string]int)
+		for _, player := range session.Players {
+			for _, response := range player.Responses {
+				if response.DoorID == session.CurrentDoor.DoorID {
+					scores[player.PlayerID] = response.AIScore
+					break
+				}
+			}
+		}
+
+		data := systemMessage(map[string]interface{}{
+			"round":       round,
+			"rounds":      rounds,
+			"scores":      scores,
+			"totalScores": totalScores(session),
+			"leaderId":    leader.PlayerID,
+		}, session.Locale, i18n.MsgRoundResults, round, rounds, leader.Username, leader.TotalScore)
+		if session.Settings.TeamPlay() {
+			data["teamScores"] = teamScores(session)
+			data["leaderTeamId"] = leader.TeamID
+		}
+
+		event := WebSocketEvent{
+			Type:      EventRoundResults,
+			SessionID: session.SessionID,
+			Data:      data,
+			Timestamp: time.Now(),
+		}
+		if err := s.wsManager.BroadcastToSession(session.SessionID, event); err != nil {
+			fmt.Printf("Warning: failed to broadcast round results: %v\n", err)
+		}
+	}
+
+	if round == 0 || round >= rounds {
+		if leader == nil {
+			return fmt.Errorf("best-of match %s has no players", session.SessionID)
+		}
+		return s.handleGameCompletion(ctx, session.SessionID, leader.PlayerID)
+	}
+
+	time.Sleep(3 * time.Second) // Give players time to see the standings
+	return s.PresentDoorToSession(ctx, session.SessionID, session.MatchDoors[round])
+}
+
+// matchLeader returns the player with the highest total score, or in team sessions the top
+// scorer of the higher scoring team
+func matchLeader(session *models.GameSession) *models.PlayerInfo {
+	if session.Settings.TeamPlay() {
+		return topScorer(session, leadingTeam(session))
+	}
+	return topScorer(session, "")
+}
+
+// topScorer returns the player with the highest total score, on the given team unless teamID
+// is empty. Ties go to the player who joined first, and players who left are only considered
+// if nobody stayed.
+func topScorer(session *models.GameSession, teamID string) *models.PlayerInfo {
+	var leader *models.PlayerInfo
+	for i := range session.Players {
+		player := &session.Players[i]
+		if teamID != "" && player.TeamID != teamID {
+			continue
+		}
+		switch {
+		case leader == nil:
+			leader = player
+		case player.IsActive && !leader.IsActive:
+			leader = player
+		case player.IsActive == leader.IsActive && player.TotalScore > leader.TotalScore:
+			leader = player
+		}
+	}
+	return leader
+}
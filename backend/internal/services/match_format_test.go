@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCreateSession_BestOfDefaultsRounds(t *testing.T) {
+	ctx := context.Background()
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+
+	session, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", models.SessionSettings{Format: models.MatchFormatBestOf})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if session.Settings.Rounds != DefaultMatchRounds {
+		t.Errorf("Expected %d rounds by default, got %d", DefaultMatchRounds, session.Settings.Rounds)
+	}
+
+	invalid := []models.SessionSettings{
+		{Rounds: 3},
+		{Format: models.MatchFormatBestOf, Rounds: MaxMatchRounds + 1},
+		{Format: "knockout"},
+	}
+	for _, settings := range invalid {
+		if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", settings); !errors.Is(err, ErrInvalidSessionSettings) {
+			t.Errorf("Expected %+v to be refused, got %v", settings, err)
+		}
+	}
+}
+
+func TestStartGameWithFirstDoor_BestOfChoosesFixedDoors(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+
+	session, err := gameService.CreateSession(ctx, models.GameModeSinglePlayer, "p1", "Alice", nil, "", models.SessionSettings{Format: models.MatchFormatBestOf, Rounds: 3})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if err := gameService.StartGameWithFirstDoor(ctx, session.SessionID); err != nil {
+		t.Fatalf("StartGameWithFirstDoor failed: %v", err)
+	}
+
+	started := gameSessionRepo.sessions[session.SessionID]
+	if len(started.MatchDoors) != 3 {
+		t.Fatalf("Expected 3 match doors, got %d", len(started.MatchDoors))
+	}
+	for i, door := range started.MatchDoors {
+		if door.Difficulty != i+1 {
+			t.Errorf("Expected round %d to have difficulty %d, got %d", i+1, i+1, door.Difficulty)
+		}
+	}
+	if started.MatchRound() != 1 || started.CurrentDoor.DoorID != started.MatchDoors[0].DoorID {
+		t.Errorf("Expected the first match door to be presented, got round %d", started.MatchRound())
+	}
+}
+
+func TestAdvanceMatch_LastRoundCompletesWithHighestTotal(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	wsManager := &broadcastRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), wsManager, &MockAIClient{}, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+	).(*GameServiceImpl)
+
+	doors := []*models.Door{{DoorID: "door-1"}, {DoorID: "door-2"}}
+	session := &models.GameSession{
+		SessionID:   "s1",
+		Mode:        models.GameModeMultiplayer,
+		Status:      models.GameStatusRevealing,
+		Settings:    models.SessionSettings{Format: models.MatchFormatBestOf, Rounds: 2},
+		MatchDoors:  doors,
+		CurrentDoor: doors[1],
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "Alice", IsActive: true, TotalScore: 120, Responses: []models.PlayerResponse{{DoorID: "door-2", AIScore: 40}}},
+			{PlayerID: "p2", Username: "Bob", IsActive: true, TotalScore: 150, Responses: []models.PlayerResponse{{DoorID: "door-2", AIScore: 90}}},
+		},
+	}
+	gameSessionRepo.sessions["s1"] = session
+
+	if err := gameService.advanceMatch(ctx, session); err != nil {
+		t.Fatalf("advanceMatch failed: %v", err)
+	}
+
+	completed := gameSessionRepo.sessions["s1"]
+	if completed.Status != models.GameStatusCompleted || completed.WinnerID != "p2" {
+		t.Errorf("Expected p2 to win the completed match, got %s won by %q", completed.Status, completed.WinnerID)
+	}
+
+	var results *WebSocketEvent
+	for i := range wsManager.broadcasts {
+		if wsManager.broadcasts[i].Type == EventRoundResults {
+			results = &wsManager.broadcasts[i]
+		}
+	}
+	if results == nil {
+		t.Fatal("Expected the last round's results to be broadcast")
+	}
+	data := results.Data.(map[string]interface{})
+	if data["round"] != 2 || data["rounds"] != 2 || data["leaderId"] != "p2" || data["scores"].(map[string]int)["p1"] != 40 {
+		t.Errorf("Unexpected round results: %+v", data)
+	}
+}
+
+// memorySessionCacheStore is an in-memory store for a real session cache
+type memorySessionCacheStore struct {
+	entries   map[string]string
+	revisions map[string]int64
+}
+
+func (m *memorySessionCacheStore) Load(ctx context.Context, sessionID string) (string, int64, error) {
+	return m.entries[sessionID], m.revisions[sessionID], nil
+}
+
+func (m *memorySessionCacheStore) Save(ctx context.Context, sessionID, entry string, ttl time.Duration) error {
+	m.entries[sessionID] = entry
+	return nil
+}
+
+func (m *memorySessionCacheStore) Bump(ctx context.Context, sessionID string, ttl time.Duration) (int64, error) {
+	m.revisions[sessionID]++
+	delete(m.entries, sessionID)
+	return m.revisions[sessionID], nil
+}
+
+// cachedSessionRepository serves every session read from the Redis session cache, as the Mongo
+// repository does once a session is cached
+type cachedSessionRepository struct {
+	*MockGameSessionRepository
+	cache *repositories.SessionCache
+}
+
+func (r *cachedSessionRepository) GetByID(ctx context.Context, sessionID string) (*models.GameSession, error) {
+	stored, err := r.MockGameSessionRepository.GetByID(ctx, sessionID)
+	if err != nil || stored == nil {
+		return stored, err
+	}
+	if err := r.cache.WriteThrough(ctx, stored); err != nil {
+		return nil, err
+	}
+	cached, _, err := r.cache.Get(ctx, sessionID)
+	return cached, err
+}
+
+func TestAdvanceMatch_PresentsNextDoorOfCachedSession(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := &cachedSessionRepository{
+		MockGameSessionRepository: NewMockGameSessionRepository(),
+		cache:                     repositories.NewSessionCache(&memorySessionCacheStore{entries: map[string]string{}, revisions: map[string]int64{}}, time.Minute),
+	}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	).(*GameServiceImpl)
+
+	doors := []*models.Door{{DoorID: "door-1"}, {DoorID: "door-2"}, {DoorID: "door-3"}}
+	gameSessionRepo.sessions["s1"] = &models.GameSession{
+		SessionID:   "s1",
+		Mode:        models.GameModeMultiplayer,
+		Status:      models.GameStatusRevealing,
+		Settings:    models.SessionSettings{Format: models.MatchFormatBestOf, Rounds: 3},
+		MatchDoors:  doors,
+		CurrentDoor: doors[0],
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "Alice", IsActive: true, TotalScore: 40, Responses: []models.PlayerResponse{{DoorID: "door-1", AIScore: 40}}},
+			{PlayerID: "p2", Username: "Bob", IsActive: true, TotalScore: 90, Responses: []models.PlayerResponse{{DoorID: "door-1", AIScore: 90}}},
+		},
+	}
+
+	session, err := gameSessionRepo.GetByID(ctx, "s1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if session.MatchRound() != 1 || len(session.MatchDoors) != 3 {
+		t.Fatalf("Expected the cached session to keep its match doors, got round %d of %d", session.MatchRound(), len(session.MatchDoors))
+	}
+	if err := gameService.advanceMatch(ctx, session); err != nil {
+		t.Fatalf("advanceMatch failed: %v", err)
+	}
+
+	advanced := gameSessionRepo.sessions["s1"]
+	if advanced.Status != models.GameStatusActive || advanced.CurrentDoor == nil || advanced.CurrentDoor.DoorID != "door-2" {
+		t.Errorf("Expected round 2's door to be presented, got %s on %+v", advanced.Status, advanced.CurrentDoor)
+	}
+}
+
+func TestMatchLeader_PrefersActivePlayersAndEarlierJoins(t *testing.T) {
+	session := &models.GameSession{Players: []models.PlayerInfo{
+		{PlayerID: "left", TotalScore: 300},
+		{PlayerID: "first", IsActive: true, TotalScore: 100},
+		{PlayerID: "tied", IsActive: true, TotalScore: 100},
+	}}
+	if leader := matchLeader(session); leader.PlayerID != "first" {
+		t.Errorf("Expected the first active player among the tied to lead, got %s", leader.PlayerID)
+	}
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Matchmaking defaults
+const (
+	MinMatchPlayers            = 2
+	MaxMatchPlayers            = 8
+	DefaultMatchmakingInterval = 2 * time.Second
+	DefaultMatchmakingMaxWait  = 15 * time.Second
+	DefaultMatchmakingTheme    = "general"
+)
+
+// MatchmakingService pairs queued players into multiplayer sessions
+type MatchmakingService interface {
+	Start(ctx context.Context)
+	Enqueue(ctx context.Context, playerID, username, theme string, skillBucket int) (*models.MatchmakingTicket, error)
+	Cancel(ctx context.Context, playerID string) error
+	GetTicket(ctx context.Context, playerID string) (*models.MatchmakingTicket, error)
+	FormMatches(ctx context.Context) ([]*models.GameSession, error)
+}
+
+// MatchmakingServiceImpl implements the MatchmakingService interface
+type MatchmakingServiceImpl struct {
+	queue       repositories.MatchmakingQueue
+	gameService GameService
+	wsManager   WebSocketManager
+	interval    time.Duration
+	maxWait     time.Duration
+	themes      ThemeService
+}
+
+// MatchmakingOption configures optional matchmaking settings
+type MatchmakingOption func(*MatchmakingServiceImpl)
+
+// WithMatchmakingInterval sets how often waiting pools are checked for matches
+func WithMatchmakingInterval(interval time.Duration) MatchmakingOption {
+	return func(m *MatchmakingServiceImpl) {
+		if interval > 0 {
+			m.interval = interval
+		}
+	}
+}
+
+// WithMatchmakingMaxWait sets how long a pool waits to fill a full session before starting
+// a smaller one
+func WithMatchmakingMaxWait(maxWait time.Duration) MatchmakingOption {
+	return func(m *MatchmakingServiceImpl) {
+		if maxWait >= 0 {
+			m.maxWait = maxWait
+		}
+	}
+}
+
+// WithMatchmakingThemeCatalog only lets players queue for the catalog's enabled themes
+func WithMatchmakingThemeCatalog(themes ThemeService) MatchmakingOption {
+	return func(m *MatchmakingServiceImpl) {
+		m.themes = themes
+	}
+}
+
+// NewMatchmakingService creates a new matchmaking service
+func NewMatchmakingService(queue repositories.MatchmakingQueue, gameService GameService, wsManager WebSocketManager, opts ...MatchmakingOption) MatchmakingService {
+	service := &MatchmakingServiceImpl{
+		queue:       queue,
+		gameService: gameService,
+		wsManager:   wsManager,
+		interval:    DefaultMatchmakingInterval,
+		maxWait:     DefaultMatchmakingMaxWait,
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// Start forms matches every interval until the context is cancelled
+func (m *MatchmakingServiceImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.FormMatches(ctx); err != nil {
+				fmt.Printf("Warning: failed to form matches: %v\n", err)
+			}
+		}
+	}
+}
+
+// Enqueue puts a player in the pool for their preferred theme and skill bucket, replacing
+// any ticket they already hold
+func (m *MatchmakingServiceImpl) Enqueue(ctx context.Context, playerID, username, theme string, skillBucket int) (*models.MatchmakingTicket, error) {
+	if playerID == "" {
+		return nil, fmt.Errorf("player ID is required")
+	}
+
+	if skillBucket < models.MinSkillBucket || skillBucket > models.MaxSkillBucket {
+		return nil, fmt.Errorf("skill bucket must be between %d and %d", models.MinSkillBucket, models.MaxSkillBucket)
+	}
+
+	if theme == "" {
+		theme = DefaultMatchmakingTheme
+	}
+	if m.themes != nil {
+		if err := m.themes.RequireEnabled(ctx, theme); err != nil {
+			return nil, err
+		}
+	}
+
+	ticket := &models.MatchmakingTicket{
+		PlayerID:    playerID,
+		Username:    username,
+		Theme:       theme,
+		SkillBucket: skillBucket,
+		EnqueuedAt:  time.Now(),
+	}
+
+	if err := m.queue.Enqueue(ctx, ticket); err != nil {
+		return nil, fmt.Errorf("failed to enqueue player: %w", err)
+	}
+
+	return ticket, nil
+}
+
+// Cancel takes a player out of matchmaking
+func (m *MatchmakingServiceImpl) Cancel(ctx context.Context, playerID string) error {
+	if err := m.queue.Remove(ctx, playerID); err != nil {
+		return fmt.Errorf("failed to cancel matchmaking: %w", err)
+	}
+	return nil
+}
+
+// GetTicket returns the player's ticket; a matched ticket carries the session to join
+func (m *MatchmakingServiceImpl) GetTicket(ctx context.Context, playerID string) (*models.MatchmakingTicket, error) {
+	ticket, err := m.queue.GetTicket(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchmaking ticket: %w", err)
+	}
+	return ticket, nil
+}
+
+// FormMatches creates a session for every pool that can fill one. A pool matches as soon as
+// it has MaxMatchPlayers waiting, or MinMatchPlayers once its longest-waiting player has
+// waited maxWait.
+func (m *MatchmakingServiceImpl) FormMatches(ctx context.Context) ([]*models.GameSession, error) {
+	pools, err := m.queue.Pools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matchmaking pools: %w", err)
+	}
+
+	var sessions []*models.GameSession
+	for _, pool := range pools {
+		for {
+			session, err := m.matchPool(ctx, pool)
+			if err != nil {
+				fmt.Printf("Warning: failed to match pool %s: %v\n", pool, err)
+				break
+			}
+			if session == nil {
+				break
+			}
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// matchPool forms at most one session from a pool, returning nil when the pool is not ready
+func (m *MatchmakingServiceImpl) matchPool(ctx context.Context, pool string) (*models.GameSession, error) {
+	tickets, err := m.queue.Waiting(ctx, pool, MaxMatchPlayers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tickets) < MinMatchPlayers {
+		return nil, nil
+	}
+	if len(tickets) < MaxMatchPlayers && time.Since(tickets[0].EnqueuedAt) < m.maxWait {
+		return nil, nil
+	}
+
+	playerIDs := make([]string, len(tickets))
+	for i, ticket := range tickets {
+		playerIDs[i] = ticket.PlayerID
+	}
+
+	claimedIDs, err := m.queue.Claim(ctx, pool, playerIDs)
+	if err != nil {
+		m.requeue(ctx, claimedTickets(tickets, claimedIDs))
+		return nil, err
+	}
+
+	// Another instance took some of these players; put the rest back for the next pass
+	claimed := claimedTickets(tickets, claimedIDs)
+	if len(claimed) < MinMatchPlayers {
+		m.requeue(ctx, claimed)
+		return nil, nil
+	}
+
+	return m.createMatch(ctx, claimed)
+}
+
+// createMatch starts a multiplayer session hosted by the longest-waiting player and tells
+// every player where to go. Players who fail to join are requeued, as is everyone if fewer
+// than MinMatchPlayers make it in.
+func (m *MatchmakingServiceImpl) createMatch(ctx context.Context, tickets []*models.MatchmakingTicket) (*models.GameSession, error) {
+	host := tickets[0]
+	theme := host.Theme
+
+	session, err := m.gameService.CreateSession(ctx, models.GameModeMultiplayer, host.PlayerID, host.Username, &theme, i18n.DefaultLocale, models.SessionSettings{})
+	if err != nil {
+		m.requeue(ctx, tickets)
+		return nil, fmt.Errorf("failed to create matched session: %w", err)
+	}
+
+	matched := []*models.MatchmakingTicket{host}
+	for _, ticket := range tickets[1:] {
+		if _, err := m.gameService.JoinSession(ctx, session.SessionID, ticket.PlayerID, ticket.Username, ""); err != nil {
+			fmt.Printf("Warning: failed to add matched player %s to session: %v\n", ticket.PlayerID, err)
+			m.requeue(ctx, []*models.MatchmakingTicket{ticket})
+			continue
+		}
+		matched = append(matched, ticket)
+	}
+
+	// A session nobody else could join is no match; abandon it and put the host back in line
+	if len(matched) < MinMatchPlayers {
+		if _, err := m.gameService.LeaveSession(ctx, session.SessionID, host.PlayerID); err != nil {
+			fmt.Printf("Warning: failed to abandon unmatched session %s: %v\n", session.SessionID, err)
+		}
+		m.requeue(ctx, matched)
+		return nil, fmt.Errorf("only %d of %d matched players joined session %s", len(matched), len(tickets), session.SessionID)
+	}
+
+	matchedAt := time.Now()
+	playerIDs := make([]string, len(matched))
+	for i, ticket := range matched {
+		playerIDs[i] = ticket.PlayerID
+		ticket.SessionID = session.SessionID
+		ticket.MatchedAt = &matchedAt
+
+		// Keep the ticket so players who aren't connected can find their session by polling
+		if err := m.queue.SaveTicket(ctx, ticket); err != nil {
+			fmt.Printf("Warning: failed to record match for player %s: %v\n", ticket.PlayerID, err)
+		}
+	}
+
+	m.notifyMatchFound(session, matched, playerIDs)
+
+	log.Printf("Matchmaking formed session %s with %d players in pool %s", session.SessionID, len(matched), host.Pool())
+
+	return session, nil
+}
+
+// notifyMatchFound sends a "match-found" event to each matched player who is connected
+func (m *MatchmakingServiceImpl) notifyMatchFound(session *models.GameSession, tickets []*models.MatchmakingTicket, playerIDs []string) {
+	if m.wsManager == nil {
+		return
+	}
+
+	for _, ticket := range tickets {
+		event := WebSocketEvent{
+			Type:      "match-found",
+			SessionID: session.SessionID,
+			PlayerID:  ticket.PlayerID,
+			Data: map[string]interface{}{
+				"sessionId":   session.SessionID,
+				"theme":       ticket.Theme,
+				"skillBucket": ticket.SkillBucket,
+				"players":     playerIDs,
+				"hostId":      playerIDs[0],
+			},
+			Timestamp: time.Now(),
+		}
+
+		// Players waiting without a socket pick the match up from their ticket instead
+		if err := m.wsManager.SendToPlayer(ticket.PlayerID, event); err != nil {
+			log.Printf("Match found for player %s, who is not connected: %v", ticket.PlayerID, err)
+		}
+	}
+}
+
+// requeue returns claimed players to their pool, keeping their original place in line
+func (m *MatchmakingServiceImpl) requeue(ctx context.Context, tickets []*models.MatchmakingTicket) {
+	for _, ticket := range tickets {
+		if err := m.queue.Enqueue(ctx, ticket); err != nil {
+			fmt.Printf("Warning: failed to requeue player %s: %v\n", ticket.PlayerID, err)
+		}
+	}
+}
+
+// claimedTickets returns the tickets whose players were claimed, in queue order
+func claimedTickets(tickets []*models.MatchmakingTicket, claimedIDs []string) []*models.MatchmakingTicket {
+	claimed := make(map[string]bool, len(claimedIDs))
+	for _, playerID := range claimedIDs {
+		claimed[playerID] = true
+	}
+
+	result := make([]*models.MatchmakingTicket, 0, len(claimedIDs))
+	for _, ticket := range tickets {
+		if claimed[ticket.PlayerID] {
+			result = append(result, ticket)
+		}
+	}
+	return result
+}
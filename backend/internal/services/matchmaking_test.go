@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+// MockMatchmakingQueue is an in-memory MatchmakingQueue for testing
+type MockMatchmakingQueue struct {
+	tickets map[string]*models.MatchmakingTicket
+	pools   map[string]map[string]bool
+}
+
+func NewMockMatchmakingQueue() *MockMatchmakingQueue {
+	return &MockMatchmakingQueue{
+		tickets: make(map[string]*models.MatchmakingTicket),
+		pools:   make(map[string]map[string]bool),
+	}
+}
+
+func (m *MockMatchmakingQueue) Enqueue(ctx context.Context, ticket *models.MatchmakingTicket) error {
+	m.Remove(ctx, ticket.PlayerID)
+	m.tickets[ticket.PlayerID] = ticket
+	if m.pools[ticket.Pool()] == nil {
+		m.pools[ticket.Pool()] = make(map[string]bool)
+	}
+	m.pools[ticket.Pool()][ticket.PlayerID] = true
+	return nil
+}
+
+func (m *MockMatchmakingQueue) Remove(ctx context.Context, playerID string) error {
+	if ticket, exists := m.tickets[playerID]; exists {
+		delete(m.pools[ticket.Pool()], playerID)
+		delete(m.tickets, playerID)
+	}
+	return nil
+}
+
+func (m *MockMatchmakingQueue) GetTicket(ctx context.Context, playerID string) (*models.MatchmakingTicket, error) {
+	return m.tickets[playerID], nil
+}
+
+func (m *MockMatchmakingQueue) SaveTicket(ctx context.Context, ticket *models.MatchmakingTicket) error {
+	m.tickets[ticket.PlayerID] = ticket
+	return nil
+}
+
+func (m *MockMatchmakingQueue) Pools(ctx context.Context) ([]string, error) {
+	var pools []string
+	for pool, players := range m.pools {
+		if len(players) > 0 {
+			pools = append(pools, pool)
+		}
+	}
+	sort.Strings(pools)
+	return pools, nil
+}
+
+func (m *MockMatchmakingQueue) Waiting(ctx context.Context, pool string, limit int) ([]*models.MatchmakingTicket, error) {
+	var tickets []*models.MatchmakingTicket
+	for playerID := range m.pools[pool] {
+		tickets = append(tickets, m.tickets[playerID])
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].EnqueuedAt.Before(tickets[j].EnqueuedAt) })
+	if len(tickets) > limit {
+		tickets = tickets[:limit]
+	}
+	return tickets, nil
+}
+
+func (m *MockMatchmakingQueue) Claim(ctx context.Context, pool string, playerIDs []string) ([]string, error) {
+	var claimed []string
+	for _, playerID := range playerIDs {
+		if m.pools[pool][playerID] {
+			delete(m.pools[pool], playerID)
+			claimed = append(claimed, playerID)
+		}
+	}
+	return claimed, nil
+}
+
+func newTestMatchmaking(opts ...MatchmakingOption) (MatchmakingService, *MockMatchmakingQueue, *MockGameSessionRepository) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+	queue := NewMockMatchmakingQueue()
+	return NewMatchmakingService(queue, gameService, nil, opts...), queue, gameSessionRepo
+}
+
+func enqueuePlayers(t *testing.T, matchmaking MatchmakingService, count int, theme string, bucket int) {
+	for i := 0; i < count; i++ {
+		playerID := fmt.Sprintf("%s-%d-%d", theme, bucket, i)
+		if _, err := matchmaking.Enqueue(context.Background(), playerID, playerID, theme, bucket); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+}
+
+func TestFormMatches_FullPoolMatchesImmediately(t *testing.T) {
+	matchmaking, queue, gameSessionRepo := newTestMatchmaking()
+	enqueuePlayers(t, matchmaking, MaxMatchPlayers+1, "workplace", 2)
+
+	sessions, err := matchmaking.FormMatches(context.Background())
+	if err != nil {
+		t.Fatalf("FormMatches failed: %v", err)
+	}
+
+	if len(sessions) != 1 {
+		t.Fatalf("Expected one session, got %d", len(sessions))
+	}
+
+	session := gameSessionRepo.sessions[sessions[0].SessionID]
+	if len(session.Players) != MaxMatchPlayers || session.Mode != models.GameModeMultiplayer {
+		t.Errorf("Expected a full multiplayer session, got %d players in %s mode", len(session.Players), session.Mode)
+	}
+	if len(queue.pools["workplace:2"]) != 1 {
+		t.Errorf("Expected the ninth player to keep waiting, got %d waiting", len(queue.pools["workplace:2"]))
+	}
+
+	ticket, _ := matchmaking.GetTicket(context.Background(), session.Players[1].PlayerID)
+	if ticket == nil || ticket.SessionID != session.SessionID {
+		t.Errorf("Expected the matched ticket to point at the session, got %+v", ticket)
+	}
+}
+
+// joinFailingGameService refuses every join, as when the matched players' sessions are full
+type joinFailingGameService struct {
+	GameService
+}
+
+func (joinFailingGameService) JoinSession(ctx context.Context, sessionID, playerID, username, password string) (*models.GameSession, error) {
+	return nil, ErrSessionFull
+}
+
+func TestFormMatches_RequeuesEveryoneWhenTooFewJoin(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameService := joinFailingGameService{NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)}
+	queue := NewMockMatchmakingQueue()
+	matchmaking := NewMatchmakingService(queue, gameService, nil, WithMatchmakingMaxWait(0))
+	enqueuePlayers(t, matchmaking, MinMatchPlayers, "mystery", 1)
+
+	if sessions, _ := matchmaking.FormMatches(context.Background()); len(sessions) != 0 {
+		t.Fatalf("Expected no match when nobody could join the host, got %d", len(sessions))
+	}
+	if len(queue.pools["mystery:1"]) != MinMatchPlayers {
+		t.Errorf("Expected every player back in the queue, got %d waiting", len(queue.pools["mystery:1"]))
+	}
+	if len(gameSessionRepo.sessions) != 1 {
+		t.Fatalf("Expected the host's session to have been created, got %d sessions", len(gameSessionRepo.sessions))
+	}
+	for _, session := range gameSessionRepo.sessions {
+		if session.Status != models.GameStatusAbandoned {
+			t.Errorf("Expected the host's lone session to be abandoned, got %s", session.Status)
+		}
+	}
+	if ticket := queue.tickets["mystery-1-0"]; ticket == nil || ticket.SessionID != "" {
+		t.Errorf("Expected the host's ticket to carry no session, got %+v", ticket)
+	}
+}
+
+func TestFormMatches_SmallPoolWaitsForMaxWait(t *testing.T) {
+	matchmaking, _, _ := newTestMatchmaking(WithMatchmakingMaxWait(time.Hour))
+	enqueuePlayers(t, matchmaking, MinMatchPlayers, "mystery", 1)
+
+	if sessions, _ := matchmaking.FormMatches(context.Background()); len(sessions) != 0 {
+		t.Errorf("Expected no match before the wait elapses, got %d", len(sessions))
+	}
+
+	matchmaking, _, _ = newTestMatchmaking(WithMatchmakingMaxWait(0))
+	enqueuePlayers(t, matchmaking, MinMatchPlayers, "mystery", 1)
+
+	if sessions, _ := matchmaking.FormMatches(context.Background()); len(sessions) != 1 {
+		t.Errorf("Expected a two-player match once the wait elapses, got %d", len(sessions))
+	}
+}
+
+func TestFormMatches_OnlyMatchesCompatiblePlayers(t *testing.T) {
+	matchmaking, _, _ := newTestMatchmaking(WithMatchmakingMaxWait(0))
+	enqueuePlayers(t, matchmaking, 1, "comedy", 1)
+	enqueuePlayers(t, matchmaking, 1, "comedy", 3)
+	enqueuePlayers(t, matchmaking, 1, "survival", 1)
+
+	if sessions, _ := matchmaking.FormMatches(context.Background()); len(sessions) != 0 {
+		t.Errorf("Expected no match across themes or skill buckets, got %d", len(sessions))
+	}
+}
+
+func TestEnqueue_ValidatesSkillBucket(t *testing.T) {
+	matchmaking, _, _ := newTestMatchmaking()
+
+	if _, err := matchmaking.Enqueue(context.Background(), "p1", "Player 1", "", 4); err == nil {
+		t.Error("Expected an out-of-range skill bucket to be rejected")
+	}
+
+	ticket, err := matchmaking.Enqueue(context.Background(), "p1", "Player 1", "", 2)
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if ticket.Theme != DefaultMatchmakingTheme {
+		t.Errorf("Expected the default theme, got %q", ticket.Theme)
+	}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Moderation event page sizes
+const (
+	DefaultModerationPageSize = 20
+	MaxModerationPageSize     = 100
+)
+
+// DefaultDenylist holds the word stems masked when no denylist is configured
+var DefaultDenylist = []string{
+	"fuck", "shit", "bitch", "bastard", "asshole", "dick", "cunt", "piss", "slut", "whore",
+}
+
+// ModerationConfig configures how player text is screened
+type ModerationConfig struct {
+	Denylist         []string // word stems masked wherever a word starts with them
+	RejectPatterns   []string // regular expressions that reject text outright
+	RejectDenylisted bool     // reject denylisted words instead of masking them
+	UseAI            bool     // consult the AI service moderation endpoint
+}
+
+// ModerationService screens player text before it is used in play
+type ModerationService interface {
+	Moderate(ctx context.Context, subject models.ModerationSubject, text string) (*models.ModerationResult, error)
+	ListEvents(ctx context.Context, filter models.ModerationEventFilter) (*models.ModerationEventPage, error)
+}
+
+// ModerationServiceImpl implements the ModerationService interface
+type ModerationServiceImpl struct {
+	config         ModerationConfig
+	denylist       *regexp.Regexp
+	rejectPatterns []*regexp.Regexp
+	aiClient       AIClient
+	eventRepo      repositories.ModerationEventRepository
+}
+
+// NewModerationService creates a new moderation service; aiClient and eventRepo may be nil
+func NewModerationService(config ModerationConfig, aiClient AIClient, eventRepo repositories.ModerationEventRepository) ModerationService {
+	service := &ModerationServiceImpl{
+		config:    config,
+		aiClient:  aiClient,
+		eventRepo: eventRepo,
+	}
+
+	var stems []string
+	for _, word := range config.Denylist {
+		if word = strings.TrimSpace(word); word != "" {
+			stems = append(stems, regexp.QuoteMeta(word))
+		}
+	}
+	if len(stems) > 0 {
+		service.denylist = regexp.MustCompile(`(?i)\b(?:` + strings.Join(stems, "|") + `)\w*`)
+	}
+
+	for _, pattern := range config.RejectPatterns {
+		compiled, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			fmt.Printf("Warning: ignoring invalid moderation pattern %q: %v\n", pattern, err)
+			continue
+		}
+		service.rejectPatterns = append(service.rejectPatterns, compiled)
+	}
+
+	return service
+}
+
+// Moderate rejects or masks offensive text and records any intervention
+func (s *ModerationServiceImpl) Moderate(ctx context.Context, subject models.ModerationSubject, text string) (*models.ModerationResult, error) {
+	result := s.screen(ctx, text)
+
+	if result.Action != models.ModerationAllow && s.eventRepo != nil {
+		event := &models.ModerationEvent{
+			EventID:   fmt.Sprintf("mod_%s", random.ID()),
+			Subject:   subject,
+			Original:  text,
+			Action:    result.Action,
+			Reasons:   result.Reasons,
+			CreatedAt: time.Now(),
+		}
+		if result.Action == models.ModerationMask {
+			event.Result = result.Text
+		}
+		if err := s.eventRepo.Record(ctx, event); err != nil {
+			fmt.Printf("Warning: failed to record moderation event: %v\n", err)
+		}
+	}
+
+	return result, nil
+}
+
+// ListEvents returns one page of recorded moderation events, newest first
+func (s *ModerationServiceImpl) ListEvents(ctx context.Context, filter models.ModerationEventFilter) (*models.ModerationEventPage, error) {
+	if s.eventRepo == nil {
+		return nil, fmt.Errorf("moderation events are not being recorded")
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = DefaultModerationPageSize
+	}
+	if filter.PageSize > MaxModerationPageSize {
+		filter.PageSize = MaxModerationPageSize
+	}
+
+	events, total, err := s.eventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation events: %w", err)
+	}
+
+	return &models.ModerationEventPage{
+		Events:   events,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+// screen runs the reject patterns, the AI check and the denylist in that order
+func (s *ModerationServiceImpl) screen(ctx context.Context, text string) *models.ModerationResult {
+	for _, pattern := range s.rejectPatterns {
+		if pattern.MatchString(text) {
+			return &models.ModerationResult{
+				Action:  models.ModerationReject,
+				Reasons: []string{fmt.Sprintf("pattern:%s", pattern.String())},
+			}
+		}
+	}
+
+	if s.config.UseAI && s.aiClient != nil {
+		verdict, err := s.aiClient.ModerateContent(ctx, text)
+		if err != nil {
+			fmt.Printf("Warning: AI moderation unavailable, using local filters only: %v\n", err)
+		} else if verdict.Flagged {
+			reasons := []string{"ai"}
+			for _, category := range verdict.Categories {
+				reasons = append(reasons, fmt.Sprintf("ai:%s", category))
+			}
+			return &models.ModerationResult{Action: models.ModerationReject, Reasons: reasons}
+		}
+	}
+
+	if s.denylist != nil {
+		if matches := s.denylist.FindAllString(text, -1); len(matches) > 0 {
+			reasons := []string{fmt.Sprintf("denylist:%d", len(matches))}
+			if s.config.RejectDenylisted {
+				return &models.ModerationResult{Action: models.ModerationReject, Reasons: reasons}
+			}
+			return &models.ModerationResult{
+				Action:  models.ModerationMask,
+				Text:    s.denylist.ReplaceAllStringFunc(text, maskWord),
+				Reasons: reasons,
+			}
+		}
+	}
+
+	return &models.ModerationResult{Action: models.ModerationAllow, Text: text}
+}
+
+// applyProfanityFilter adjusts a moderation result to a session's profanity filter. Only
+// masked words are affected: text the server refuses stays refused whatever the session chose.
+func applyProfanityFilter(result *models.ModerationResult, original string, filter models.ProfanityFilter) *models.ModerationResult {
+	if result.Action != models.ModerationMask {
+		return result
+	}
+
+	switch filter {
+	case models.ProfanityReject:
+		return &models.ModerationResult{Action: models.ModerationReject, Reasons: result.Reasons}
+	case models.ProfanityAllow:
+		return &models.ModerationResult{Action: models.ModerationAllow, Text: original, Reasons: result.Reasons}
+	}
+	return result
+}
+
+// maskWord keeps the first letter of a word and stars out the rest
+func maskWord(word string) string {
+	runes := []rune(word)
+	return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+// MockModerationEventRepository is an in-memory ModerationEventRepository for testing
+type MockModerationEventRepository struct {
+	events []*models.ModerationEvent
+}
+
+func (m *MockModerationEventRepository) Record(ctx context.Context, event *models.ModerationEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *MockModerationEventRepository) List(ctx context.Context, filter models.ModerationEventFilter) ([]*models.ModerationEvent, int64, error) {
+	var matched []*models.ModerationEvent
+	for _, event := range m.events {
+		if (filter.PlayerID == "" || event.Subject.PlayerID == filter.PlayerID) && (filter.Action == "" || event.Action == filter.Action) {
+			matched = append(matched, event)
+		}
+	}
+	return matched, int64(len(matched)), nil
+}
+
+var testSubject = models.ModerationSubject{SessionID: "s1", PlayerID: "p1", DoorID: "door-1"}
+
+func TestModerate_MasksDenylistedWords(t *testing.T) {
+	events := &MockModerationEventRepository{}
+	moderation := NewModerationService(ModerationConfig{Denylist: DefaultDenylist}, nil, events)
+
+	result, err := moderation.Moderate(context.Background(), testSubject, "Kick the Shitty door down")
+	if err != nil {
+		t.Fatalf("Moderate failed: %v", err)
+	}
+	if result.Action != models.ModerationMask || result.Text != "Kick the S***** door down" {
+		t.Errorf("Expected the word to be masked, got %s %q", result.Action, result.Text)
+	}
+	if len(events.events) != 1 || events.events[0].Original != "Kick the Shitty door down" {
+		t.Errorf("Expected the masked response to be recorded, got %+v", events.events)
+	}
+
+	result, _ = moderation.Moderate(context.Background(), testSubject, "Bribe the assholes guarding it")
+	if result.Action != models.ModerationMask {
+		t.Errorf("Expected denylisted stems to match longer words, got %s", result.Action)
+	}
+
+	result, _ = moderation.Moderate(context.Background(), testSubject, "Pick the lock with a hairpin")
+	if result.Action != models.ModerationAllow || result.Text != "Pick the lock with a hairpin" {
+		t.Errorf("Expected clean text to pass unchanged, got %s %q", result.Action, result.Text)
+	}
+	if len(events.events) != 2 {
+		t.Errorf("Expected allowed text not to be recorded, got %d events", len(events.events))
+	}
+}
+
+func TestModerate_RejectPatternsAndInvalidPatterns(t *testing.T) {
+	moderation := NewModerationService(ModerationConfig{RejectPatterns: []string{"(unclosed", `kill\s+yourself`}}, nil, nil)
+
+	result, err := moderation.Moderate(context.Background(), testSubject, "Just KILL  yourself")
+	if err != nil {
+		t.Fatalf("Moderate failed: %v", err)
+	}
+	if result.Action != models.ModerationReject {
+		t.Errorf("Expected the pattern to reject the text, got %s", result.Action)
+	}
+}
+
+func TestModerate_AIFlagsRejectAndErrorsFallBack(t *testing.T) {
+	aiClient := &MockAIClient{flagged: []string{"harassment"}}
+	moderation := NewModerationService(ModerationConfig{Denylist: DefaultDenylist, UseAI: true}, aiClient, nil)
+
+	result, _ := moderation.Moderate(context.Background(), testSubject, "Something subtle but nasty")
+	if result.Action != models.ModerationReject || len(result.Reasons) != 2 || result.Reasons[1] != "ai:harassment" {
+		t.Errorf("Expected an AI flag to reject with its category, got %+v", result)
+	}
+
+	aiClient.flagged = nil
+	aiClient.moderateErr = errors.New("moderation endpoint down")
+	result, err := moderation.Moderate(context.Background(), testSubject, "Well, shit")
+	if err != nil {
+		t.Fatalf("Expected AI errors not to fail moderation, got %v", err)
+	}
+	if result.Action != models.ModerationMask {
+		t.Errorf("Expected the denylist to still apply when AI is down, got %s", result.Action)
+	}
+}
+
+func TestSubmitResponse_AppliesModeration(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newDraftSession()
+	moderation := NewModerationService(ModerationConfig{Denylist: DefaultDenylist, RejectPatterns: []string{"forbidden"}}, nil, nil)
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+		WithModerationService(moderation),
+	)
+
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p1", "A forbidden answer"); !errors.Is(err, ErrResponseRejected) {
+		t.Fatalf("Expected ErrResponseRejected, got %v", err)
+	}
+	if len(gameSessionRepo.sessions["s1"].Players[0].Responses) != 0 {
+		t.Fatal("Expected a rejected response not to be stored")
+	}
+
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p1", "Smash it, bitches"); err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	if content := gameSessionRepo.sessions["s1"].Players[0].Responses[0].Content; content != "Smash it, b******" {
+		t.Errorf("Expected the stored response to be masked, got %q", content)
+	}
+}
+
+func TestApplyProfanityFilter(t *testing.T) {
+	moderation := NewModerationService(ModerationConfig{Denylist: DefaultDenylist, RejectPatterns: []string{"forbidden"}}, nil, nil)
+	text := "Smash it, bitches"
+	masked, err := moderation.Moderate(context.Background(), testSubject, text)
+	if err != nil || masked.Action != models.ModerationMask {
+		t.Fatalf("Expected the text to be masked, got %+v, %v", masked, err)
+	}
+
+	if result := applyProfanityFilter(masked, text, ""); result != masked {
+		t.Errorf("Expected the default filter to keep the mask, got %+v", result)
+	}
+	if result := applyProfanityFilter(masked, text, models.ProfanityReject); result.Action != models.ModerationReject {
+		t.Errorf("Expected the reject filter to refuse the text, got %+v", result)
+	}
+	if result := applyProfanityFilter(masked, text, models.ProfanityAllow); result.Action != models.ModerationAllow || result.Text != text {
+		t.Errorf("Expected the allow filter to keep the text as written, got %+v", result)
+	}
+
+	rejected := &models.ModerationResult{Action: models.ModerationReject}
+	if result := applyProfanityFilter(rejected, "A forbidden answer", models.ProfanityAllow); result.Action != models.ModerationReject {
+		t.Errorf("Expected a refused text to stay refused whatever the filter, got %+v", result)
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Door graph defaults
+const (
+	DefaultLongPathLength    = 10 // the path length players start with
+	DefaultShortPathLength   = 5  // the shortest path good play can earn
+	DefaultShortcutThreshold = 70 // scores above this already shorten a player's path
+	pathGraphTheme           = "general"
+)
+
+// ErrNotEnoughDoors is returned when a theme has too few servable doors to build a graph from
+var ErrNotEnoughDoors = errors.New("not enough doors to build a door graph")
+
+// PathGraphConfig shapes the door graphs built for each theme
+type PathGraphConfig struct {
+	LongPathLength    int
+	ShortPathLength   int
+	ShortcutThreshold int
+}
+
+// DefaultPathGraphConfig returns the door graph shape used unless configured otherwise
+func DefaultPathGraphConfig() PathGraphConfig {
+	return PathGraphConfig{
+		LongPathLength:    DefaultLongPathLength,
+		ShortPathLength:   DefaultShortPathLength,
+		ShortcutThreshold: DefaultShortcutThreshold,
+	}
+}
+
+// PathGraphService seeds and maintains the Neo4j door graphs that players traverse
+type PathGraphService interface {
+	// EnsureGraph builds the theme's graph from its doors, rewriting the stored graph if the doors changed
+	EnsureGraph(ctx context.Context, theme string) (*models.PathGraph, error)
+	// InitializeSession makes sure the session's theme has a graph and places every player at its entry
+	InitializeSession(ctx context.Context, session *models.GameSession) error
+}
+
+// PathGraphServiceImpl implements the PathGraphService interface
+type PathGraphServiceImpl struct {
+	doorRepo  repositories.DoorRepository
+	graphRepo repositories.DoorGraphRepository
+	config    PathGraphConfig
+	locks     *sessionLocks // serializes graph rewrites per theme
+}
+
+// PathGraphServiceOption configures optional behaviour of the path graph service
+type PathGraphServiceOption func(*PathGraphServiceImpl)
+
+// WithPathGraphConfig changes the shape of the door graphs
+func WithPathGraphConfig(config PathGraphConfig) PathGraphServiceOption {
+	return func(s *PathGraphServiceImpl) {
+		s.config = config
+	}
+}
+
+// NewPathGraphService creates a new path graph service
+func NewPathGraphService(doorRepo repositories.DoorRepository, graphRepo repositories.DoorGraphRepository, opts ...PathGraphServiceOption) PathGraphService {
+	service := &PathGraphServiceImpl{
+		doorRepo:  doorRepo,
+		graphRepo: graphRepo,
+		config:    DefaultPathGraphConfig(),
+		locks:     newSessionLocks(),
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
+
+// EnsureGraph builds the theme's door graph and saves it unless the stored graph already matches
+func (s *PathGraphServiceImpl) EnsureGraph(ctx context.Context, theme string) (*models.PathGraph, error) {
+	doors, err := s.doorRepo.GetByTheme(ctx, theme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get doors for theme %s: %w", theme, err)
+	}
+
+	graph, err := buildPathGraph(theme, servableDoors(doors), s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := s.locks.Lock(theme)
+	defer unlock()
+
+	stored, err := s.graphRepo.GetSignature(ctx, theme)
+	if err != nil {
+		return nil, err
+	}
+	if stored == graph.Signature {
+		return graph, nil
+	}
+
+	if err := s.graphRepo.SaveGraph(ctx, graph); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// InitializeSession places the session's players at the entry of its theme's door graph
+func (s *PathGraphServiceImpl) InitializeSession(ctx context.Context, session *models.GameSession) error {
+	theme := pathGraphTheme
+	if session.Theme != nil && *session.Theme != "" {
+		theme = *session.Theme
+	}
+
+	if _, err := s.EnsureGraph(ctx, theme); err != nil {
+		return err
+	}
+
+	for _, player := range session.Players {
+		if err := s.graphRepo.PlacePlayer(ctx, player.PlayerID, theme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildPathGraph lays a theme's doors out as a graph. The long path runs through the easiest
+// doors in order of difficulty; the short path is spread evenly along it from its first door
+// to its last, and scoring at least the shortcut threshold on a short path door skips ahead
+// to the next one.
+func buildPathGraph(theme string, doors []*models.Door, config PathGraphConfig) (*models.PathGraph, error) {
+	if len(doors) < 2 || config.LongPathLength < 2 || config.ShortPathLength < 2 {
+		return nil, fmt.Errorf("%w: theme %s has %d", ErrNotEnoughDoors, theme, len(doors))
+	}
+
+	sorted := append([]*models.Door(nil), doors...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].EffectiveDifficulty() != sorted[j].EffectiveDifficulty() {
+			return sorted[i].EffectiveDifficulty() < sorted[j].EffectiveDifficulty()
+		}
+		return sorted[i].DoorID < sorted[j].DoorID
+	})
+	if len(sorted) > config.LongPathLength {
+		sorted = sorted[:config.LongPathLength]
+	}
+
+	graph := &models.PathGraph{
+		Theme:   theme,
+		EntryID: "start:" + theme,
+		Doors:   sorted,
+	}
+	for _, door := range sorted {
+		graph.LongPath = append(graph.LongPath, door.DoorID)
+	}
+
+	// Spread the short path evenly over the long one, keeping its first and last doors
+	shortLength := config.ShortPathLength
+	if shortLength > len(graph.LongPath) {
+		shortLength = len(graph.LongPath)
+	}
+	shortIndexes := make([]int, shortLength)
+	for j := range shortIndexes {
+		shortIndexes[j] = j * (len(graph.LongPath) - 1) / (shortLength - 1)
+		graph.ShortPath = append(graph.ShortPath, graph.LongPath[shortIndexes[j]])
+	}
+
+	graph.Edges = append(graph.Edges, models.PathEdge{From: graph.EntryID, To: graph.LongPath[0]})
+	for i := 0; i+1 < len(graph.LongPath); i++ {
+		graph.Edges = append(graph.Edges, models.PathEdge{From: graph.LongPath[i], To: graph.LongPath[i+1]})
+	}
+	for j := 0; j+1 < len(shortIndexes); j++ {
+		// Neighbouring doors are already joined by the long path
+		if shortIndexes[j+1] == shortIndexes[j]+1 {
+			continue
+		}
+		graph.Edges = append(graph.Edges, models.PathEdge{
+			From:           graph.ShortPath[j],
+			To:             graph.ShortPath[j+1],
+			ScoreThreshold: config.ShortcutThreshold,
+		})
+	}
+
+	graph.Signature = pathGraphSignature(graph, config)
+	return graph, nil
+}
+
+// pathGraphSignature digests everything a stored graph is built from
+func pathGraphSignature(graph *models.PathGraph, config PathGraphConfig) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d/%d/%d\n", config.LongPathLength, config.ShortPathLength, config.ShortcutThreshold)
+	for _, door := range graph.Doors {
+		fmt.Fprintf(hash, "%s/%d/%s\n", door.DoorID, door.EffectiveDifficulty(), door.Content)
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:12])
+}
+
+// graphDoor returns the door the player's door graph leads to from their current position, or
+// nil if the graph has no servable door for them in the session's locale
+func (s *GameServiceImpl) graphDoor(ctx context.Context, playerID string, currentScore int, locale string) *models.Door {
+	doorID, err := s.playerPathRepo.GetNextDoor(ctx, playerID, currentScore)
+	if err != nil {
+		fmt.Printf("Warning: failed to follow door graph: %v\n", err)
+		return nil
+	}
+	if doorID == "" || doorID == "end" {
+		return nil
+	}
+
+	door, err := s.doorRepo.GetByID(ctx, doorID)
+	if err != nil || door == nil {
+		return nil
+	}
+	localized := doorsInLocale(servableDoors([]*models.Door{door}), locale)
+	if len(localized) == 0 {
+		return nil
+	}
+	return localized[0]
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// MockDoorGraphRepository records the door graphs saved and the players placed in them
+type MockDoorGraphRepository struct {
+	graphs map[string]*models.PathGraph
+	saves  int
+	placed map[string]string
+	// positions overrides where a player is; otherwise placed players are at their graph's entry
+	positions map[string]string
+}
+
+func NewMockDoorGraphRepository() *MockDoorGraphRepository {
+	return &MockDoorGraphRepository{
+		graphs:    make(map[string]*models.PathGraph),
+		placed:    make(map[string]string),
+		positions: make(map[string]string),
+	}
+}
+
+func (m *MockDoorGraphRepository) GetSignature(ctx context.Context, theme string) (string, error) {
+	if graph, exists := m.graphs[theme]; exists {
+		return graph.Signature, nil
+	}
+	return "", nil
+}
+
+func (m *MockDoorGraphRepository) SaveGraph(ctx context.Context, graph *models.PathGraph) error {
+	m.graphs[graph.Theme] = graph
+	m.saves++
+	return nil
+}
+
+func (m *MockDoorGraphRepository) PlacePlayer(ctx context.Context, playerID, theme string) error {
+	m.placed[playerID] = theme
+	return nil
+}
+
+func (m *MockDoorGraphRepository) GetGraph(ctx context.Context, theme string) (*models.PathGraph, error) {
+	return m.graphs[theme], nil
+}
+
+func (m *MockDoorGraphRepository) GetPlayerPosition(ctx context.Context, playerID string) (string, error) {
+	if position, exists := m.positions[playerID]; exists {
+		return position, nil
+	}
+	if theme, placed := m.placed[playerID]; placed {
+		return m.graphs[theme].EntryID, nil
+	}
+	return "", nil
+}
+
+// approvedDoors creates servable doors door-01 to door-n in the theme, easiest first
+func approvedDoors(theme string, n int) []*models.Door {
+	doors := make([]*models.Door, 0, n)
+	for i := 1; i <= n; i++ {
+		doors = append(doors, &models.Door{
+			DoorID:     fmt.Sprintf("door-%02d", i),
+			Theme:      theme,
+			Content:    fmt.Sprintf("Door %d", i),
+			Difficulty: (i + 3) / 4,
+			Status:     models.DoorStatusApproved,
+		})
+	}
+	return doors
+}
+
+func TestBuildPathGraph_ShortPathSkipsAlongLongPath(t *testing.T) {
+	doors := approvedDoors("space", 12)
+	// Shuffle the doors; the graph orders them by difficulty
+	doors[0], doors[11] = doors[11], doors[0]
+
+	graph, err := buildPathGraph("space", doors, DefaultPathGraphConfig())
+	if err != nil {
+		t.Fatalf("buildPathGraph failed: %v", err)
+	}
+
+	if len(graph.LongPath) != DefaultLongPathLength || graph.LongPath[0] != "door-01" || graph.LongPath[9] != "door-10" {
+		t.Errorf("Expected the ten easiest doors in order, got %v", graph.LongPath)
+	}
+	expectedShort := []string{"door-01", "door-03", "door-05", "door-07", "door-10"}
+	if fmt.Sprint(graph.ShortPath) != fmt.Sprint(expectedShort) {
+		t.Errorf("Expected short path %v, got %v", expectedShort, graph.ShortPath)
+	}
+
+	// One entry edge, nine long path edges and a shortcut between each pair of short path doors
+	shortcuts := 0
+	for _, edge := range graph.Edges {
+		if edge.ScoreThreshold == 0 {
+			continue
+		}
+		shortcuts++
+		if edge.ScoreThreshold != DefaultShortcutThreshold {
+			t.Errorf("Expected shortcuts at the default threshold, got %+v", edge)
+		}
+	}
+	if len(graph.Edges) != 14 || shortcuts != 4 {
+		t.Errorf("Expected 14 edges including 4 shortcuts, got %d edges and %d shortcuts", len(graph.Edges), shortcuts)
+	}
+	if graph.Edges[0].From != "start:space" || graph.Edges[0].To != "door-01" {
+		t.Errorf("Expected the entry to lead to the first door, got %+v", graph.Edges[0])
+	}
+}
+
+func TestBuildPathGraph_SmallThemes(t *testing.T) {
+	graph, err := buildPathGraph("tiny", approvedDoors("tiny", 3), DefaultPathGraphConfig())
+	if err != nil {
+		t.Fatalf("buildPathGraph failed: %v", err)
+	}
+	if len(graph.LongPath) != 3 || len(graph.ShortPath) != 3 {
+		t.Errorf("Expected both paths to use every door, got %v and %v", graph.LongPath, graph.ShortPath)
+	}
+	for _, edge := range graph.Edges {
+		if edge.ScoreThreshold != 0 {
+			t.Errorf("Expected no shortcuts between neighbouring doors, got %+v", edge)
+		}
+	}
+
+	if _, err := buildPathGraph("tiny", approvedDoors("tiny", 1), DefaultPathGraphConfig()); !errors.Is(err, ErrNotEnoughDoors) {
+		t.Errorf("Expected ErrNotEnoughDoors for a single door, got %v", err)
+	}
+}
+
+func TestBuildPathGraph_SignatureFollowsDoors(t *testing.T) {
+	doors := approvedDoors("space", 6)
+	first, _ := buildPathGraph("space", doors, DefaultPathGraphConfig())
+	again, _ := buildPathGraph("space", doors, DefaultPathGraphConfig())
+	if first.Signature != again.Signature {
+		t.Error("Expected the same doors to produce the same signature")
+	}
+
+	doors[2].Difficulty = 3
+	changed, _ := buildPathGraph("space", doors, DefaultPathGraphConfig())
+	if changed.Signature == first.Signature {
+		t.Error("Expected a difficulty change to change the signature")
+	}
+
+	config := DefaultPathGraphConfig()
+	config.ShortcutThreshold = 80
+	reconfigured, _ := buildPathGraph("space", doors, config)
+	if reconfigured.Signature == changed.Signature {
+		t.Error("Expected a new threshold to change the signature")
+	}
+}
+
+func TestPathGraphService_InitializeSessionSeedsGraphOnce(t *testing.T) {
+	ctx := context.Background()
+	doorRepo := &MockDoorRepository{doors: approvedDoors("general", 8)}
+	// Drafts are never part of a graph
+	doorRepo.doors = append(doorRepo.doors, &models.Door{DoorID: "draft", Theme: "general", Status: models.DoorStatusDraft})
+	graphRepo := NewMockDoorGraphRepository()
+	pathGraph := NewPathGraphService(doorRepo, graphRepo)
+
+	session := newDraftSession()
+	if err := pathGraph.InitializeSession(ctx, session); err != nil {
+		t.Fatalf("InitializeSession failed: %v", err)
+	}
+	if graphRepo.saves != 1 || graphRepo.placed["p1"] != "general" || graphRepo.placed["p2"] != "general" {
+		t.Errorf("Expected the graph saved and both players placed, got %d saves and %v", graphRepo.saves, graphRepo.placed)
+	}
+	for _, id := range graphRepo.graphs["general"].LongPath {
+		if id == "draft" {
+			t.Error("Expected draft doors to be left out of the graph")
+		}
+	}
+
+	// An unchanged theme is not rewritten
+	if err := pathGraph.InitializeSession(ctx, session); err != nil {
+		t.Fatalf("InitializeSession failed: %v", err)
+	}
+	if graphRepo.saves != 1 {
+		t.Errorf("Expected the stored graph to be reused, got %d saves", graphRepo.saves)
+	}
+
+	doorRepo.doors[0].Content = "A reworded door"
+	if _, err := pathGraph.EnsureGraph(ctx, "general"); err != nil {
+		t.Fatalf("EnsureGraph failed: %v", err)
+	}
+	if graphRepo.saves != 2 {
+		t.Errorf("Expected edited doors to rewrite the graph, got %d saves", graphRepo.saves)
+	}
+}
+
+func TestNextDoor_FollowsDoorGraph(t *testing.T) {
+	ctx := context.Background()
+	doorRepo := &MockDoorRepository{doors: approvedDoors("general", 4)}
+	pathGraph := NewPathGraphService(doorRepo, NewMockDoorGraphRepository())
+
+	// Without a graph the theme's door of the right difficulty is served
+	gameService := NewGameService(NewMockGameSessionRepository(), doorRepo, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+	door, err := gameService.GetNextDoor(ctx, "p1", 50, "en")
+	if err != nil || door.DoorID != "door-01" {
+		t.Fatalf("Expected door-01 without a graph, got %+v (err %v)", door, err)
+	}
+
+	// With one, the door the graph leads to is served
+	doorRepo.doors = append(doorRepo.doors, &models.Door{DoorID: "next-door", Theme: "general", Difficulty: 3, Status: models.DoorStatusApproved})
+	gameService = NewGameService(NewMockGameSessionRepository(), doorRepo, NewMockPlayerPathRepository(), nil, nil, nil, nil, WithPathGraphService(pathGraph))
+	if door, err = gameService.GetNextDoor(ctx, "p1", 50, "en"); err != nil || door.DoorID != "next-door" {
+		t.Errorf("Expected the graph's next door, got %+v (err %v)", door, err)
+	}
+
+	// Graph doors that can't be served fall back to the theme
+	doorRepo.doors[4].Status = models.DoorStatusDraft
+	if door, err = gameService.GetNextDoor(ctx, "p1", 50, "en"); err != nil || door.DoorID != "door-01" {
+		t.Errorf("Expected a fallback to door-01, got %+v (err %v)", door, err)
+	}
+}
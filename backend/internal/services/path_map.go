@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+)
+
+// ErrPathGraphUnavailable is returned when the session's theme has no door graph to draw
+var ErrPathGraphUnavailable = errors.New("no door graph is available for this session")
+
+// PathMapService describes players' journeys through the door graph for drawing as a map
+type PathMapService interface {
+	GetPlayerMap(ctx context.Context, sessionID, playerID string) (*models.PathMap, error)
+}
+
+// PathMapServiceImpl implements the PathMapService interface
+type PathMapServiceImpl struct {
+	gameSessionRepo repositories.GameSessionRepository
+	graphRepo       repositories.DoorGraphRepository
+}
+
+// NewPathMapService creates a new path map service
+func NewPathMapService(gameSessionRepo repositories.GameSessionRepository, graphRepo repositories.DoorGraphRepository) PathMapService {
+	return &PathMapServiceImpl{
+		gameSessionRepo: gameSessionRepo,
+		graphRepo:       graphRepo,
+	}
+}
+
+// GetPlayerMap returns the session's door graph marked with the doors the player has visited,
+// where they are now and the branches open to them from there
+func (s *PathMapServiceImpl) GetPlayerMap(ctx context.Context, sessionID, playerID string) (*models.PathMap, error) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	var player *models.PlayerInfo
+	for i := range session.Players {
+		if session.Players[i].PlayerID == playerID {
+			player = &session.Players[i]
+			break
+		}
+	}
+	if player == nil {
+		return nil, ErrPlayerNotInSession
+	}
+
+	theme := pathGraphTheme
+	if session.Theme != nil && *session.Theme != "" {
+		theme = *session.Theme
+	}
+	graph, err := s.graphRepo.GetGraph(ctx, theme)
+	if err != nil {
+		return nil, err
+	}
+	if graph == nil || len(graph.Doors) == 0 {
+		return nil, ErrPathGraphUnavailable
+	}
+
+	position, err := s.graphRepo.GetPlayerPosition(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	pathMap := buildPathMap(graph, position, player.Responses)
+	pathMap.SessionID = sessionID
+	pathMap.PlayerID = playerID
+	return pathMap, nil
+}
+
+// buildPathMap marks the graph's nodes and edges with the player's journey. A position outside
+// the graph, left over from another game, is replaced by the last door the player answered.
+func buildPathMap(graph *models.PathGraph, position string, responses []models.PlayerResponse) *models.PathMap {
+	doors := make(map[string]*models.Door, len(graph.Doors))
+	for _, door := range graph.Doors {
+		doors[door.DoorID] = door
+	}
+
+	// The player's walk from the entry, and their score on each door they answered
+	walk := []string{graph.EntryID}
+	scores := make(map[string]int)
+	for _, response := range responses {
+		if doors[response.DoorID] == nil {
+			continue
+		}
+		if walk[len(walk)-1] != response.DoorID {
+			walk = append(walk, response.DoorID)
+		}
+		if !response.ScoringPending {
+			scores[response.DoorID] = response.AIScore
+		}
+	}
+	if position != graph.EntryID && doors[position] == nil {
+		position = walk[len(walk)-1]
+	}
+
+	visited := make(map[string]bool, len(walk))
+	traversed := make(map[models.PathEdge]bool, len(walk))
+	for i, id := range walk {
+		visited[id] = true
+		if i > 0 {
+			traversed[models.PathEdge{From: walk[i-1], To: id}] = true
+		}
+	}
+	next := make(map[string]bool)
+	for _, edge := range graph.Edges {
+		if edge.From == position {
+			next[edge.To] = true
+		}
+	}
+	onShortPath := make(map[string]bool, len(graph.ShortPath))
+	for _, id := range graph.ShortPath {
+		onShortPath[id] = true
+	}
+
+	state := func(id string) models.PathNodeState {
+		switch {
+		case id == position:
+			return models.PathNodeCurrent
+		case visited[id]:
+			return models.PathNodeVisited
+		case next[id]:
+			return models.PathNodeNext
+		}
+		return models.PathNodeUpcoming
+	}
+
+	pathMap := &models.PathMap{Theme: graph.Theme, Position: position}
+	pathMap.Nodes = append(pathMap.Nodes, models.PathMapNode{ID: graph.EntryID, Entry: true, State: state(graph.EntryID)})
+
+	steps := make(map[string]int, len(graph.LongPath))
+	for i, id := range graph.LongPath {
+		steps[id] = i + 1
+	}
+	ordered := make([]*models.Door, 0, len(graph.Doors))
+	for _, id := range graph.LongPath {
+		if door := doors[id]; door != nil {
+			ordered = append(ordered, door)
+		}
+	}
+	for _, door := range graph.Doors {
+		if _, onLongPath := steps[door.DoorID]; !onLongPath {
+			ordered = append(ordered, door)
+		}
+	}
+
+	for _, door := range ordered {
+		node := models.PathMapNode{
+			ID:         door.DoorID,
+			Content:    door.Content,
+			Difficulty: door.Difficulty,
+			Step:       steps[door.DoorID],
+			ShortPath:  onShortPath[door.DoorID],
+			State:      state(door.DoorID),
+		}
+		if score, scored := scores[door.DoorID]; scored {
+			node.Score = &score
+		}
+		pathMap.Nodes = append(pathMap.Nodes, node)
+	}
+
+	for _, edge := range graph.Edges {
+		pathMap.Edges = append(pathMap.Edges, models.PathMapEdge{
+			PathEdge:  edge,
+			Shortcut:  edge.ScoreThreshold > 0,
+			Traversed: traversed[models.PathEdge{From: edge.From, To: edge.To}],
+		})
+	}
+	return pathMap
+}
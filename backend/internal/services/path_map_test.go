@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+// newPathMapFixture stores a six door graph for the general theme, whose short path is
+// door-01, door-03 and door-06, and a session in which p1 has answered door-01 and door-03
+func newPathMapFixture(t *testing.T) (*MockGameSessionRepository, *MockDoorGraphRepository) {
+	t.Helper()
+	graph, err := buildPathGraph("general", approvedDoors("general", 6), PathGraphConfig{LongPathLength: 6, ShortPathLength: 3, ShortcutThreshold: 70})
+	if err != nil {
+		t.Fatalf("buildPathGraph failed: %v", err)
+	}
+	graphRepo := NewMockDoorGraphRepository()
+	graphRepo.graphs["general"] = graph
+
+	session := newDraftSession()
+	session.Players[0].Responses = []models.PlayerResponse{
+		{DoorID: "door-01", AIScore: 80},
+		{DoorID: "door-03", ScoringPending: true},
+	}
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = session
+	return gameSessionRepo, graphRepo
+}
+
+func TestPathMap_MarksJourneyAndBranches(t *testing.T) {
+	gameSessionRepo, graphRepo := newPathMapFixture(t)
+	graphRepo.positions["p1"] = "door-03"
+
+	pathMap, err := NewPathMapService(gameSessionRepo, graphRepo).GetPlayerMap(context.Background(), "s1", "p1")
+	if err != nil {
+		t.Fatalf("GetPlayerMap failed: %v", err)
+	}
+
+	states := make(map[string]models.PathNodeState)
+	for _, node := range pathMap.Nodes {
+		states[node.ID] = node.State
+	}
+	expected := map[string]models.PathNodeState{
+		"start:general": models.PathNodeVisited,
+		"door-01":       models.PathNodeVisited,
+		"door-02":       models.PathNodeUpcoming,
+		"door-03":       models.PathNodeCurrent,
+		"door-04":       models.PathNodeNext,
+		"door-05":       models.PathNodeUpcoming,
+		"door-06":       models.PathNodeNext, // the shortcut from door-03
+	}
+	for id, state := range expected {
+		if states[id] != state {
+			t.Errorf("Expected %s to be %s, got %s", id, state, states[id])
+		}
+	}
+
+	if pathMap.Nodes[1].ID != "door-01" || pathMap.Nodes[1].Step != 1 || pathMap.Nodes[1].Score == nil || *pathMap.Nodes[1].Score != 80 {
+		t.Errorf("Expected door-01 first with its score, got %+v", pathMap.Nodes[1])
+	}
+	if pathMap.Nodes[3].Score != nil {
+		t.Errorf("Expected no score for a response still being scored, got %d", *pathMap.Nodes[3].Score)
+	}
+
+	traversed := 0
+	for _, edge := range pathMap.Edges {
+		if edge.Traversed {
+			traversed++
+			if edge.To == "door-03" && (!edge.Shortcut || edge.ScoreThreshold != 70) {
+				t.Errorf("Expected the shortcut to door-03 to carry its threshold, got %+v", edge)
+			}
+		}
+	}
+	if traversed != 2 {
+		t.Errorf("Expected the entry edge and the shortcut to be traversed, got %d edges", traversed)
+	}
+}
+
+func TestPathMap_StalePositionFallsBackToLastDoor(t *testing.T) {
+	gameSessionRepo, graphRepo := newPathMapFixture(t)
+	graphRepo.positions["p1"] = "some-other-theme-door"
+
+	pathMap, err := NewPathMapService(gameSessionRepo, graphRepo).GetPlayerMap(context.Background(), "s1", "p1")
+	if err != nil {
+		t.Fatalf("GetPlayerMap failed: %v", err)
+	}
+	if pathMap.Position != "door-03" {
+		t.Errorf("Expected the last answered door as the position, got %s", pathMap.Position)
+	}
+}
+
+func TestPathMap_Errors(t *testing.T) {
+	gameSessionRepo, graphRepo := newPathMapFixture(t)
+	service := NewPathMapService(gameSessionRepo, graphRepo)
+
+	if _, err := service.GetPlayerMap(context.Background(), "s1", "stranger"); !errors.Is(err, ErrPlayerNotInSession) {
+		t.Errorf("Expected ErrPlayerNotInSession, got %v", err)
+	}
+
+	theme := "unseeded"
+	gameSessionRepo.sessions["s1"].Theme = &theme
+	if _, err := service.GetPlayerMap(context.Background(), "s1", "p1"); !errors.Is(err, ErrPathGraphUnavailable) {
+		t.Errorf("Expected ErrPathGraphUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrGamePaused is returned when players answer a door while the host has paused the game
+var ErrGamePaused = errors.New("game is paused")
+
+// Events announcing the host pausing and resuming the game
+const (
+	EventGamePaused  = "game-paused"
+	EventGameResumed = "game-resumed"
+)
+
+// PauseGame freezes an open round of a multiplayer game at the host's request. The response
+// deadline stops counting down until the host resumes the game.
+func (s *GameServiceImpl) PauseGame(ctx context.Context, sessionID, hostID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.PauseGame", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(hostID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.hostedSession(ctx, sessionID, hostID, OpPauseGame)
+	if err != nil {
+		return nil, err
+	}
+	if session.Mode != models.GameModeMultiplayer {
+		return nil, fmt.Errorf("only multiplayer games can be paused")
+	}
+
+	pausedAt := time.Now()
+	session.PausedAt = &pausedAt
+	if err := s.transition(ctx, session, models.GameStatusPaused); err != nil {
+		return nil, fmt.Errorf("failed to pause game: %w", err)
+	}
+
+	// The deadlines are scheduled again with the time that was left when the game resumes
+	for _, door := range session.CurrentDoors() {
+		if err := s.scheduler.Cancel(ctx, sessionID, door.DoorID); err != nil {
+			fmt.Printf("Warning: failed to cancel response deadline: %v\n", err)
+		}
+	}
+
+	s.broadcastPause(ctx, session, EventGamePaused, hostID, systemMessage(map[string]interface{}{
+		"hostId":           hostID,
+		"pausedAt":         pausedAt,
+		"remainingSeconds": remainingSeconds(session.TimeRemaining(pausedAt)),
+	}, session.Locale, i18n.MsgGamePaused))
+
+	return session, nil
+}
+
+// ResumeGame restarts a paused game, giving players the time they had left when it was paused
+func (s *GameServiceImpl) ResumeGame(ctx context.Context, sessionID, hostID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.ResumeGame", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(hostID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.hostedSession(ctx, sessionID, hostID, OpResumeGame)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	remaining := session.TimeRemaining(now)
+	if session.RoundDeadline != nil {
+		deadline := now.Add(remaining)
+		if session.PausedAt != nil {
+			// Moving the deadline by the length of the pause also keeps the extra time of
+			// players who were still answering after everyone else's time was up
+			deadline = session.RoundDeadline.Add(now.Sub(*session.PausedAt))
+		}
+		session.RoundDeadline = &deadline
+	}
+	session.PausedAt = nil
+	if err := s.transition(ctx, session, models.GameStatusActive); err != nil {
+		return nil, fmt.Errorf("failed to resume game: %w", err)
+	}
+
+	if session.RoundDeadline != nil {
+		for _, door := range session.CurrentDoors() {
+			if err := s.scheduler.Schedule(ctx, sessionID, door.DoorID, *session.RoundClosesAt()); err != nil {
+				fmt.Printf("Warning: failed to schedule response deadline for door %s: %v\n", door.DoorID, err)
+			}
+		}
+	}
+
+	seconds := remainingSeconds(remaining)
+	s.broadcastPause(ctx, session, EventGameResumed, hostID, systemMessage(map[string]interface{}{
+		"hostId":           hostID,
+		"deadline":         session.RoundDeadline,
+		"remainingSeconds": seconds,
+	}, session.Locale, i18n.MsgGameResumed, seconds))
+
+	return session, nil
+}
+
+// broadcastPause tells the session's players the game was paused or resumed
+func (s *GameServiceImpl) broadcastPause(ctx context.Context, session *models.GameSession, eventType, hostID string, data interface{}) {
+	if s.wsManager == nil {
+		return
+	}
+
+	event := WebSocketEvent{
+		Type:      eventType,
+		SessionID: session.SessionID,
+		PlayerID:  hostID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	s.runInBackground(ctx, session.SessionID, "broadcast-"+eventType, func(ctx context.Context) {
+		if err := s.wsManager.BroadcastToSession(session.SessionID, event); err != nil {
+			fmt.Printf("Warning: failed to broadcast %s: %v\n", eventType, err)
+		}
+	})
+}
+
+// remainingSeconds rounds time left on a round to whole seconds for clients
+func remainingSeconds(remaining time.Duration) int {
+	return int(remaining.Round(time.Second).Seconds())
+}
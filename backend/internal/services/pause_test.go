@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newPausableSession(deadline time.Time) *models.GameSession {
+	return &models.GameSession{
+		SessionID:     "s1",
+		Mode:          models.GameModeMultiplayer,
+		Status:        models.GameStatusActive,
+		HostID:        "p1",
+		CurrentDoor:   &models.Door{DoorID: "door-1"},
+		RoundDeadline: &deadline,
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "Host", IsActive: true},
+			{PlayerID: "p2", Username: "Player 2", IsActive: true},
+		},
+	}
+}
+
+func deadlineScheduled(gameService GameService, doorID string) bool {
+	scheduler := gameService.(*GameServiceImpl).scheduler.(*InProcessScheduler)
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+	_, exists := scheduler.timers["s1/"+doorID]
+	return exists
+}
+
+func TestPauseGame_FreezesResponseTimer(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(40 * time.Second))
+	gameSessionRepo.sessions["s1"] = session
+	wsManager := &broadcastRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), wsManager, nil, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+	)
+	impl := gameService.(*GameServiceImpl)
+	impl.startResponseTimeout(ctx, "s1", "door-1", time.Now().Add(40*time.Second))
+
+	if _, err := gameService.PauseGame(ctx, "s1", "p2"); !errors.Is(err, ErrNotHost) {
+		t.Fatalf("Expected ErrNotHost for a non-host, got %v", err)
+	}
+	if _, err := gameService.PauseGame(ctx, "s1", "p1"); err != nil {
+		t.Fatalf("PauseGame failed: %v", err)
+	}
+	if session.Status != models.GameStatusPaused || session.PausedAt == nil {
+		t.Fatalf("Expected a paused session, got %s", session.Status)
+	}
+	if deadlineScheduled(gameService, "door-1") {
+		t.Error("Expected the response deadline to be cancelled while paused")
+	}
+
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p2", "wait for me"); !errors.Is(err, ErrGamePaused) {
+		t.Errorf("Expected ErrGamePaused for a response while paused, got %v", err)
+	}
+
+	// A long break doesn't eat into the round: 30 seconds were left when it was paused
+	pausedAt := time.Now().Add(-time.Hour)
+	deadline := pausedAt.Add(30 * time.Second)
+	session.PausedAt, session.RoundDeadline = &pausedAt, &deadline
+
+	if _, err := gameService.ResumeGame(ctx, "s1", "p1"); err != nil {
+		t.Fatalf("ResumeGame failed: %v", err)
+	}
+	if session.Status != models.GameStatusActive || session.PausedAt != nil {
+		t.Fatalf("Expected an active session, got %s", session.Status)
+	}
+	if left := time.Until(*session.RoundDeadline); left < 29*time.Second || left > 30*time.Second {
+		t.Errorf("Expected about 30 seconds left after resuming, got %v", left)
+	}
+	if !deadlineScheduled(gameService, "door-1") {
+		t.Error("Expected the response deadline to be scheduled again")
+	}
+
+	var resumed map[string]interface{}
+	for _, event := range wsManager.broadcasts {
+		if event.Type == "game-resumed" {
+			resumed, _ = event.Data.(map[string]interface{})
+		}
+	}
+	if resumed == nil || resumed["remainingSeconds"] != 30 {
+		t.Errorf("Expected a game-resumed event with 30 seconds remaining, got %v", resumed)
+	}
+}
+
+func TestPauseGame_OnlyOpenRounds(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(time.Minute))
+	session.Status = models.GameStatusScoring
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if _, err := gameService.PauseGame(context.Background(), "s1", "p1"); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("Expected ErrIllegalOperation while scoring, got %v", err)
+	}
+	if _, err := gameService.ResumeGame(context.Background(), "s1", "p1"); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("Expected ErrIllegalOperation resuming a game that isn't paused, got %v", err)
+	}
+}
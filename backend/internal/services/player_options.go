@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxTimeMultiplier is the most a player's response timer can be stretched
+const MaxTimeMultiplier = 3.0
+
+// EventPlayerOptionsUpdated announces a player's new handicap and accessibility options
+const EventPlayerOptionsUpdated = "player-options-updated"
+
+// Player option errors
+var (
+	ErrInvalidPlayerOptions = errors.New("invalid player options")
+	ErrResponseWindowClosed = errors.New("your time to answer this door is up")
+)
+
+// validatePlayerOptions checks the options a player chose for a session
+func validatePlayerOptions(options models.PlayerOptions) error {
+	if options.TimeMultiplier != 0 && (options.TimeMultiplier < 1 || options.TimeMultiplier > MaxTimeMultiplier) {
+		return fmt.Errorf("%w: time multiplier must be between 1 and %g", ErrInvalidPlayerOptions, MaxTimeMultiplier)
+	}
+	if options.MaxResponseLength != 0 && (options.MaxResponseLength < MaxResponseLength || options.MaxResponseLength > MaxExtendedResponseLength) {
+		return fmt.Errorf("%w: character limit must be between %d and %d", ErrInvalidPlayerOptions, MaxResponseLength, MaxExtendedResponseLength)
+	}
+	return nil
+}
+
+// SetPlayerOptions sets a player's handicap and accessibility options for a session. Options
+// are chosen in the lobby, so every player knows the conditions before the first door.
+func (s *GameServiceImpl) SetPlayerOptions(ctx context.Context, sessionID, playerID string, options models.PlayerOptions) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.SetPlayerOptions", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+
+	if err := validatePlayerOptions(options); err != nil {
+		return nil, err
+	}
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if err := s.stateMachine.Require(session, OpSetOptions); err != nil {
+		return nil, err
+	}
+
+	player := sessionPlayer(session, playerID)
+	if player == nil {
+		return nil, ErrPlayerNotInSession
+	}
+	player.Options = &options
+	if options == (models.PlayerOptions{}) {
+		player.Options = nil
+	}
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save player options: %w", err)
+	}
+
+	if s.wsManager != nil {
+		event := WebSocketEvent{
+			Type:      EventPlayerOptionsUpdated,
+			SessionID: sessionID,
+			PlayerID:  playerID,
+			Data: map[string]interface{}{
+				"playerId": playerID,
+				"options":  options,
+			},
+			Timestamp: time.Now(),
+		}
+
+		s.runInBackground(ctx, sessionID, "broadcast-player-options", func(ctx context.Context) {
+			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+				fmt.Printf("Warning: failed to broadcast player options: %v\n", err)
+			}
+		})
+	}
+
+	return session, nil
+}
+
+// playerDeadlines returns the deadline of every player in the open round who has extra time
+// to answer, keyed by player ID
+func playerDeadlines(session *models.GameSession) map[string]*time.Time {
+	deadlines := make(map[string]*time.Time)
+	for _, player := range session.Players {
+		if player.ExtraTime(session.RoundLimit) > 0 {
+			deadlines[player.PlayerID] = session.PlayerDeadline(player.PlayerID)
+		}
+	}
+	return deadlines
+}
+
+// playerLimits returns the character limit of every player whose options raise it above the
+// session's, keyed by player ID
+func playerLimits(session *models.GameSession) map[string]int {
+	limits := make(map[string]int)
+	for _, player := range session.Players {
+		if limit := ResponseLimit(session, player.PlayerID); limit > SessionResponseLimit(session) {
+			limits[player.PlayerID] = limit
+		}
+	}
+	return limits
+}
+
+// responseWindowClosed reports whether a response arriving at receivedAt is too late for the
+// player although the round is still open for players with extra time. Without extra time the
+// round's own deadline closes it, as before.
+func responseWindowClosed(session *models.GameSession, playerID string, receivedAt time.Time) bool {
+	deadline, closesAt := session.PlayerDeadline(playerID), session.RoundClosesAt()
+	if deadline == nil || closesAt == nil {
+		return false
+	}
+	return receivedAt.After(*deadline) && deadline.Before(*closesAt)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetPlayerOptions_ValidatesAndOnlyInLobby(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(time.Minute))
+	session.Status = models.GameStatusWaiting
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+
+	invalid := []models.PlayerOptions{
+		{TimeMultiplier: 0.5},
+		{TimeMultiplier: MaxTimeMultiplier + 1},
+		{MaxResponseLength: 100},
+		{MaxResponseLength: MaxExtendedResponseLength + 1},
+	}
+	for _, options := range invalid {
+		if _, err := gameService.SetPlayerOptions(ctx, "s1", "p2", options); !errors.Is(err, ErrInvalidPlayerOptions) {
+			t.Errorf("Expected %+v to be refused, got %v", options, err)
+		}
+	}
+
+	options := models.PlayerOptions{TimeMultiplier: 2, MaxResponseLength: 800, TextOnly: true}
+	if _, err := gameService.SetPlayerOptions(ctx, "s1", "p2", options); err != nil {
+		t.Fatalf("SetPlayerOptions failed: %v", err)
+	}
+	if got := session.Players[1].Options; got == nil || *got != options {
+		t.Fatalf("Expected the options recorded on the player, got %+v", got)
+	}
+
+	session.Status = models.GameStatusActive
+	if _, err := gameService.SetPlayerOptions(ctx, "s1", "p2", models.PlayerOptions{}); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("Expected options to be fixed once the game has started, got %v", err)
+	}
+}
+
+func TestSubmitResponse_EnforcesEachPlayersLimitAndDeadline(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	openedAt := time.Now().Add(-50 * time.Second)
+	session := newPausableSession(openedAt.Add(40 * time.Second))
+	session.RoundOpenedAt, session.RoundLimit = &openedAt, 40*time.Second
+	session.Players[1].Options = &models.PlayerOptions{TimeMultiplier: 2, MaxResponseLength: 800}
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+	)
+
+	if closesAt := session.RoundClosesAt(); !closesAt.Equal(openedAt.Add(80 * time.Second)) {
+		t.Fatalf("Expected the round to stay open for the doubled timer, closing at %v", closesAt)
+	}
+
+	// p1's 40 seconds are up while p2 still has 30 of their 80 left
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p1", "Too late"); !errors.Is(err, ErrResponseWindowClosed) {
+		t.Fatalf("Expected the standard player's late response to be refused, got %v", err)
+	}
+
+	long := strings.Repeat("a", 600)
+	submitted, err := gameService.SubmitResponse(ctx, "s1", "p2", long)
+	if err != nil {
+		t.Fatalf("Expected the extended player's long response in time, got %v", err)
+	}
+	if submitted.CharacterLimit != 800 {
+		t.Errorf("Expected the response to record its 800 character limit, got %d", submitted.CharacterLimit)
+	}
+
+	session.Players[0].Options = &models.PlayerOptions{TimeMultiplier: 2}
+	var tooLong *ResponseLengthError
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p1", long); !errors.As(err, &tooLong) || tooLong.Limit != MaxResponseLength {
+		t.Errorf("Expected the standard character limit for p1, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+)
+
+// WithPostUpdates updates the Reddit post a session was started from once its game finishes
+func WithPostUpdates(devvit DevvitIntegration) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.devvit = devvit
+	}
+}
+
+// updateOriginPost sends a finished game's rankings and winning response to the Reddit post
+// it was started from. Delivery is retried in the background, so completion doesn't wait on Devvit.
+func (s *GameServiceImpl) updateOriginPost(ctx context.Context, session *models.GameSession, winnerUsername string, rankings []models.PlayerRanking) {
+	post := session.Settings.Post
+	if s.devvit == nil || post == nil || post.PostID == "" {
+		return
+	}
+
+	update := &models.PostUpdate{
+		PostID:          post.PostID,
+		SubredditName:   post.SubredditName,
+		SessionID:       session.SessionID,
+		WinnerID:        session.WinnerID,
+		WinnerUsername:  winnerUsername,
+		WinningResponse: winningResponse(session, !s.revealDisabled && session.Settings.RevealsResponses()),
+		FinalRankings:   rankings,
+		CompletedAt:     session.CompletedAt,
+	}
+
+	s.runInBackground(ctx, session.SessionID, "update-devvit-post", func(ctx context.Context) {
+		if err := s.devvit.UpdatePost(ctx, update); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	})
+}
+
+// winningResponse returns the winner's highest-scoring response, without its text unless the
+// session shows answers to others
+func winningResponse(session *models.GameSession, showContent bool) *models.ResponseHighlight {
+	for _, player := range session.Players {
+		if player.PlayerID != session.WinnerID {
+			continue
+		}
+
+		var best *models.PlayerResponse
+		for i := range player.Responses {
+			response := &player.Responses[i]
+			if !response.ScoringPending && (best == nil || outscores(response, best)) {
+				best = response
+			}
+		}
+		if best == nil {
+			return nil
+		}
+
+		highlight := &models.ResponseHighlight{
+			DoorID:     best.DoorID,
+			ResponseID: best.ResponseID,
+			Score:      best.AIScore,
+			PlayerID:   player.PlayerID,
+			Username:   player.Username,
+		}
+		if showContent {
+			highlight.Content = best.Content
+		}
+		return highlight
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Profile settings
+const (
+	FavoriteThemeCount    = 3
+	recentProfileSessions = 20        // completed sessions remembered per profile to skip repeats
+	defaultProfileTheme   = "general" // sessions without a theme play general doors
+)
+
+// ErrProfileNotFound is returned for players who have not completed a game yet
+var ErrProfileNotFound = errors.New("player profile not found")
+
+// ProfileService keeps each player's career statistics
+type ProfileService interface {
+	RecordGame(ctx context.Context, session *models.GameSession, playerID string) (*models.PlayerProfile, error)
+	GetProfile(ctx context.Context, playerID string) (*models.PlayerProfile, error)
+}
+
+// ProfileServiceImpl implements the ProfileService interface
+type ProfileServiceImpl struct {
+	profileRepo repositories.PlayerProfileRepository
+	locks       *sessionLocks // serializes updates to the same profile
+}
+
+// NewProfileService creates a new profile service
+func NewProfileService(profileRepo repositories.PlayerProfileRepository) ProfileService {
+	return &ProfileServiceImpl{
+		profileRepo: profileRepo,
+		locks:       newSessionLocks(),
+	}
+}
+
+// RecordGame folds a player's completed game into their profile. Games already recorded
+// for the player are ignored.
+func (s *ProfileServiceImpl) RecordGame(ctx context.Context, session *models.GameSession, playerID string) (*models.PlayerProfile, error) {
+	var player *models.PlayerInfo
+	for i := range session.Players {
+		if session.Players[i].PlayerID == playerID {
+			player = &session.Players[i]
+			break
+		}
+	}
+	if player == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPlayerNotInSession, playerID)
+	}
+
+	unlock := s.locks.Lock(playerID)
+	defer unlock()
+
+	profile, err := s.profileRepo.Get(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = &models.PlayerProfile{PlayerID: playerID}
+	}
+	if containsString(profile.RecentSessionIDs, session.SessionID) {
+		return profile, nil
+	}
+
+	playedAt := time.Now()
+	if session.CompletedAt != nil {
+		playedAt = *session.CompletedAt
+	}
+	applyGameToProfile(profile, session, player, playedAt)
+
+	if err := s.profileRepo.Save(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// GetProfile returns a player's career statistics
+func (s *ProfileServiceImpl) GetProfile(ctx context.Context, playerID string) (*models.PlayerProfile, error) {
+	profile, err := s.profileRepo.Get(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+// applyGameToProfile adds one completed game to a profile's totals, averages and streaks
+func applyGameToProfile(profile *models.PlayerProfile, session *models.GameSession, player *models.PlayerInfo, playedAt time.Time) {
+	profile.Username = player.Username
+	profile.GamesPlayed++
+
+	for _, response := range player.Responses {
+		profile.DoorsAnswered++
+		profile.TotalScore += response.AIScore
+		if response.AIScore > profile.BestScore {
+			profile.BestScore = response.AIScore
+		}
+		if response.ScoringPending {
+			continue
+		}
+		profile.ScoredResponses++
+		profile.MetricTotals.Creativity += response.ScoringMetrics.Creativity
+		profile.MetricTotals.Feasibility += response.ScoringMetrics.Feasibility
+		profile.MetricTotals.Humor += response.ScoringMetrics.Humor
+		profile.MetricTotals.Originality += response.ScoringMetrics.Originality
+	}
+	if profile.DoorsAnswered > 0 {
+		profile.AverageScore = float64(profile.TotalScore) / float64(profile.DoorsAnswered)
+	}
+	if scored := float64(profile.ScoredResponses); scored > 0 {
+		profile.AverageMetrics = models.MetricAverages{
+			Creativity:  float64(profile.MetricTotals.Creativity) / scored,
+			Feasibility: float64(profile.MetricTotals.Feasibility) / scored,
+			Humor:       float64(profile.MetricTotals.Humor) / scored,
+			Originality: float64(profile.MetricTotals.Originality) / scored,
+		}
+	}
+
+	theme := defaultProfileTheme
+	if session.Theme != nil && *session.Theme != "" {
+		theme = *session.Theme
+	}
+	if profile.ThemeCounts == nil {
+		profile.ThemeCounts = make(map[string]int)
+	}
+	profile.ThemeCounts[theme]++
+	profile.FavoriteThemes = favoriteThemes(profile.ThemeCounts, FavoriteThemeCount)
+
+	if session.WinnerID == player.PlayerID {
+		profile.Wins++
+		profile.CurrentWinStreak++
+	} else {
+		profile.CurrentWinStreak = 0
+	}
+	if profile.CurrentWinStreak > profile.LongestWinStreak {
+		profile.LongestWinStreak = profile.CurrentWinStreak
+	}
+
+	// Daily streaks count calendar days in UTC
+	today := playedAt.UTC().Truncate(24 * time.Hour)
+	switch {
+	case profile.LastPlayedAt == nil:
+		profile.CurrentDailyStreak = 1
+	case profile.LastPlayedAt.UTC().Truncate(24 * time.Hour).Equal(today):
+		// Another game on the same day keeps the streak where it is
+	case profile.LastPlayedAt.UTC().Truncate(24 * time.Hour).Equal(today.Add(-24 * time.Hour)):
+		profile.CurrentDailyStreak++
+	default:
+		profile.CurrentDailyStreak = 1
+	}
+	if profile.CurrentDailyStreak > profile.LongestDailyStreak {
+		profile.LongestDailyStreak = profile.CurrentDailyStreak
+	}
+	profile.LastPlayedAt = &playedAt
+
+	profile.RecentSessionIDs = append(profile.RecentSessionIDs, session.SessionID)
+	if len(profile.RecentSessionIDs) > recentProfileSessions {
+		profile.RecentSessionIDs = profile.RecentSessionIDs[len(profile.RecentSessionIDs)-recentProfileSessions:]
+	}
+}
+
+// favoriteThemes returns the most played themes, most played first with ties broken by name
+func favoriteThemes(counts map[string]int, limit int) []string {
+	themes := make([]string, 0, len(counts))
+	for theme := range counts {
+		themes = append(themes, theme)
+	}
+	sort.Slice(themes, func(i, j int) bool {
+		if counts[themes[i]] != counts[themes[j]] {
+			return counts[themes[i]] > counts[themes[j]]
+		}
+		return themes[i] < themes[j]
+	})
+	if len(themes) > limit {
+		themes = themes[:limit]
+	}
+	return themes
+}
+
+// containsString reports whether the list contains the value
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
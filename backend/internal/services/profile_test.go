@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockPlayerProfileRepository keeps profiles in memory
+type MockPlayerProfileRepository struct {
+	profiles map[string]*models.PlayerProfile
+}
+
+func NewMockPlayerProfileRepository() *MockPlayerProfileRepository {
+	return &MockPlayerProfileRepository{profiles: make(map[string]*models.PlayerProfile)}
+}
+
+func (m *MockPlayerProfileRepository) Get(ctx context.Context, playerID string) (*models.PlayerProfile, error) {
+	profile, exists := m.profiles[playerID]
+	if !exists {
+		return nil, nil
+	}
+	copied := *profile
+	return &copied, nil
+}
+
+func (m *MockPlayerProfileRepository) Save(ctx context.Context, profile *models.PlayerProfile) error {
+	copied := *profile
+	m.profiles[profile.PlayerID] = &copied
+	return nil
+}
+
+// newCompletedSession returns a finished session in which p1 answered with the given scores
+func newCompletedSession(sessionID, theme, winnerID string, completedAt time.Time, scores ...int) *models.GameSession {
+	player := models.PlayerInfo{PlayerID: "p1", Username: "Player 1", IsActive: true}
+	for _, score := range scores {
+		player.Responses = append(player.Responses, models.PlayerResponse{
+			AIScore:        score,
+			ScoringMetrics: models.ScoringMetrics{Creativity: score, Humor: score / 2},
+		})
+	}
+	return &models.GameSession{
+		SessionID:   sessionID,
+		Theme:       &theme,
+		Status:      models.GameStatusCompleted,
+		Players:     []models.PlayerInfo{player},
+		WinnerID:    winnerID,
+		CompletedAt: &completedAt,
+	}
+}
+
+func TestRecordGame_AggregatesCareerStatistics(t *testing.T) {
+	ctx := context.Background()
+	profiles := NewProfileService(NewMockPlayerProfileRepository())
+	day := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	games := []*models.GameSession{
+		newCompletedSession("g1", "space", "p1", day, 60, 80),
+		newCompletedSession("g2", "space", "p1", day.Add(time.Hour), 90),
+		newCompletedSession("g3", "jungle", "p2", day.Add(24*time.Hour), 30),
+	}
+	for _, game := range games {
+		if _, err := profiles.RecordGame(ctx, game, "p1"); err != nil {
+			t.Fatalf("RecordGame failed: %v", err)
+		}
+	}
+	// Recording the same game again changes nothing
+	profile, err := profiles.RecordGame(ctx, games[2], "p1")
+	if err != nil {
+		t.Fatalf("RecordGame failed: %v", err)
+	}
+
+	if profile.GamesPlayed != 3 || profile.Wins != 2 || profile.DoorsAnswered != 4 {
+		t.Errorf("Expected 3 games, 2 wins and 4 doors, got %d, %d and %d", profile.GamesPlayed, profile.Wins, profile.DoorsAnswered)
+	}
+	if profile.AverageScore != 65 || profile.BestScore != 90 {
+		t.Errorf("Expected an average of 65 and a best of 90, got %.2f and %d", profile.AverageScore, profile.BestScore)
+	}
+	if profile.AverageMetrics.Creativity != 65 || profile.AverageMetrics.Humor != 32.5 {
+		t.Errorf("Unexpected metric averages: %+v", profile.AverageMetrics)
+	}
+	if len(profile.FavoriteThemes) != 2 || profile.FavoriteThemes[0] != "space" {
+		t.Errorf("Expected space to be the favorite theme, got %v", profile.FavoriteThemes)
+	}
+	if profile.CurrentWinStreak != 0 || profile.LongestWinStreak != 2 {
+		t.Errorf("Expected the win streak to end at 2, got current %d longest %d", profile.CurrentWinStreak, profile.LongestWinStreak)
+	}
+	if profile.CurrentDailyStreak != 2 || profile.LongestDailyStreak != 2 {
+		t.Errorf("Expected a 2-day streak, got current %d longest %d", profile.CurrentDailyStreak, profile.LongestDailyStreak)
+	}
+
+	// Skipping a day starts the daily streak over
+	if profile, err = profiles.RecordGame(ctx, newCompletedSession("g4", "space", "p1", day.Add(72*time.Hour), 50), "p1"); err != nil {
+		t.Fatalf("RecordGame failed: %v", err)
+	}
+	if profile.CurrentDailyStreak != 1 || profile.LongestDailyStreak != 2 || profile.CurrentWinStreak != 1 {
+		t.Errorf("Unexpected streaks after a break: %+v", profile)
+	}
+}
+
+func TestGetProfile_NotFoundBeforeFirstGame(t *testing.T) {
+	profiles := NewProfileService(NewMockPlayerProfileRepository())
+
+	if _, err := profiles.GetProfile(context.Background(), "p1"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound, got %v", err)
+	}
+}
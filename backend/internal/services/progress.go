@@ -5,6 +5,7 @@ import (
 	"dumdoors-backend/internal/models"
 	"dumdoors-backend/internal/repositories"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -21,6 +22,7 @@ type ProgressService interface {
 	GetFinalRankings(ctx context.Context, sessionID string) ([]models.PlayerRanking, error)
 	GetPerformanceStatistics(ctx context.Context, sessionID string) ([]models.PlayerPerformanceStats, error)
 	BroadcastGameCompletion(ctx context.Context, sessionID, winnerID string, rankings []models.PlayerRanking, stats []models.PlayerPerformanceStats) error
+	ScheduleProgressBroadcast(sessionID string)
 }
 
 // ProgressServiceImpl implements the ProgressService interface
@@ -28,15 +30,55 @@ type ProgressServiceImpl struct {
 	gameSessionRepo repositories.GameSessionRepository
 	playerPathRepo  repositories.PlayerPathRepository
 	wsManager       WebSocketManager
+	rankingEngine   RankingEngine
+	
+	// Debounced session broadcasts
+	broadcastInterval time.Duration
+	broadcastMu       sync.Mutex
+	pendingBroadcasts map[string]*time.Timer
+	lastBroadcasts    map[string]time.Time
+}
+
+// DefaultProgressBroadcastInterval is the minimum spacing between session progress broadcasts
+const DefaultProgressBroadcastInterval = 500 * time.Millisecond
+
+// ProgressServiceOption configures optional behaviour of the progress service
+type ProgressServiceOption func(*ProgressServiceImpl)
+
+// WithProgressBroadcastInterval sets the minimum spacing between session progress broadcasts
+func WithProgressBroadcastInterval(interval time.Duration) ProgressServiceOption {
+	return func(p *ProgressServiceImpl) {
+		p.broadcastInterval = interval
+	}
+}
+
+// WithProgressRankingEngine sets the engine used for final rankings and performance statistics
+func WithProgressRankingEngine(engine RankingEngine) ProgressServiceOption {
+	return func(p *ProgressServiceImpl) {
+		p.rankingEngine = engine
+	}
 }
 
 // NewProgressService creates a new progress service instance
-func NewProgressService(gameSessionRepo repositories.GameSessionRepository, playerPathRepo repositories.PlayerPathRepository, wsManager WebSocketManager) ProgressService {
-	return &ProgressServiceImpl{
-		gameSessionRepo: gameSessionRepo,
-		playerPathRepo:  playerPathRepo,
-		wsManager:       wsManager,
+func NewProgressService(gameSessionRepo repositories.GameSessionRepository, playerPathRepo repositories.PlayerPathRepository, wsManager WebSocketManager, opts ...ProgressServiceOption) ProgressService {
+	service := &ProgressServiceImpl{
+		gameSessionRepo:   gameSessionRepo,
+		playerPathRepo:    playerPathRepo,
+		wsManager:         wsManager,
+		broadcastInterval: DefaultProgressBroadcastInterval,
+		pendingBroadcasts: make(map[string]*time.Timer),
+		lastBroadcasts:    make(map[string]time.Time),
+	}
+	
+	for _, opt := range opts {
+		opt(service)
+	}
+	
+	if service.rankingEngine == nil {
+		service.rankingEngine = NewRankingEngine(playerPathRepo)
 	}
+	
+	return service
 }
 
 // CalculatePlayerProgress calculates the current progress for a specific player
@@ -48,7 +90,7 @@ func (p *ProgressServiceImpl) CalculatePlayerProgress(ctx context.Context, sessi
 	}
 	
 	if session == nil {
-		return nil, fmt.Errorf("session not found")
+		return nil, ErrSessionNotFound
 	}
 	
 	// Find the player in the session
@@ -61,7 +103,7 @@ func (p *ProgressServiceImpl) CalculatePlayerProgress(ctx context.Context, sessi
 	}
 	
 	if player == nil {
-		return nil, fmt.Errorf("player not found in session")
+		return nil, ErrPlayerNotInSession
 	}
 	
 	// Get player path from Neo4j
@@ -121,7 +163,7 @@ func (p *ProgressServiceImpl) CalculateSessionProgress(ctx context.Context, sess
 	}
 	
 	if session == nil {
-		return nil, fmt.Errorf("session not found")
+		return nil, ErrSessionNotFound
 	}
 	
 	// Calculate progress for each player
@@ -234,33 +276,7 @@ func (p *ProgressServiceImpl) GetLeaderboard(ctx context.Context, sessionID stri
 	// Sort players by progress (position/totalDoors) and then by average score
 	players := sessionProgress.Players
 	
-	// Simple bubble sort for leaderboard (can be optimized with sort.Slice if needed)
-	for i := 0; i < len(players)-1; i++ {
-		for j := 0; j < len(players)-i-1; j++ {
-			// Calculate progress percentages
-			progressJ := 0.0
-			if players[j].TotalDoors > 0 {
-				progressJ = float64(players[j].CurrentPosition) / float64(players[j].TotalDoors)
-			}
-			
-			progressNext := 0.0
-			if players[j+1].TotalDoors > 0 {
-				progressNext = float64(players[j+1].CurrentPosition) / float64(players[j+1].TotalDoors)
-			}
-			
-			// Sort by progress first, then by average score
-			shouldSwap := false
-			if progressNext > progressJ {
-				shouldSwap = true
-			} else if progressNext == progressJ && players[j+1].AverageScore > players[j].AverageScore {
-				shouldSwap = true
-			}
-			
-			if shouldSwap {
-				players[j], players[j+1] = players[j+1], players[j]
-			}
-		}
-	}
+	sortPlayerProgress(players)
 	
 	return players, nil
 }
@@ -303,6 +319,9 @@ func (p *ProgressServiceImpl) TrackPlayerResponse(ctx context.Context, sessionID
 		}
 	}
 	
+	// Refresh the session-wide view, coalesced with other responses in the same window
+	p.ScheduleProgressBroadcast(sessionID)
+	
 	return nil
 }
 
@@ -378,107 +397,10 @@ func (p *ProgressServiceImpl) GetFinalRankings(ctx context.Context, sessionID st
 	}
 	
 	if session == nil {
-		return nil, fmt.Errorf("session not found")
-	}
-	
-	var rankings []models.PlayerRanking
-	
-	// Calculate rankings for each player
-	for _, player := range session.Players {
-		// Get player path for completion information
-		playerPath, err := p.playerPathRepo.GetPlayerPath(ctx, player.PlayerID)
-		if err != nil {
-			// Use default values if path not found
-			playerPath = &models.PlayerPath{
-				PlayerID:        player.PlayerID,
-				CurrentPosition: len(player.Responses),
-				TotalDoors:      10, // Default
-			}
-		}
-		
-		// Calculate completion rate
-		completionRate := 0.0
-		if playerPath.TotalDoors > 0 {
-			completionRate = float64(playerPath.CurrentPosition) / float64(playerPath.TotalDoors) * 100
-		}
-		
-		// Calculate average score
-		averageScore := 0.0
-		if len(player.Responses) > 0 {
-			totalScore := 0
-			for _, response := range player.Responses {
-				totalScore += response.AIScore
-			}
-			averageScore = float64(totalScore) / float64(len(player.Responses))
-		}
-		
-		// Calculate completion time (if completed)
-		var completionTime *time.Duration
-		isWinner := playerPath.CurrentPosition >= playerPath.TotalDoors
-		if isWinner && len(player.Responses) > 0 && session.StartedAt != nil {
-			lastResponseTime := player.Responses[len(player.Responses)-1].SubmittedAt
-			duration := lastResponseTime.Sub(*session.StartedAt)
-			completionTime = &duration
-		}
-		
-		ranking := models.PlayerRanking{
-			PlayerID:       player.PlayerID,
-			Username:       player.Username,
-			CompletionTime: completionTime,
-			TotalScore:     player.TotalScore,
-			AverageScore:   averageScore,
-			DoorsCompleted: len(player.Responses),
-			TotalDoors:     playerPath.TotalDoors,
-			CompletionRate: completionRate,
-			IsWinner:       isWinner,
-		}
-		
-		rankings = append(rankings, ranking)
-	}
-	
-	// Sort rankings by completion status, then by completion time, then by score
-	for i := 0; i < len(rankings)-1; i++ {
-		for j := 0; j < len(rankings)-i-1; j++ {
-			shouldSwap := false
-			
-			// Winners first
-			if rankings[j+1].IsWinner && !rankings[j].IsWinner {
-				shouldSwap = true
-			} else if rankings[j].IsWinner == rankings[j+1].IsWinner {
-				// Both winners or both non-winners
-				if rankings[j].IsWinner {
-					// Both are winners - sort by completion time
-					if rankings[j+1].CompletionTime != nil && rankings[j].CompletionTime != nil {
-						if *rankings[j+1].CompletionTime < *rankings[j].CompletionTime {
-							shouldSwap = true
-						}
-					} else if rankings[j+1].CompletionTime != nil && rankings[j].CompletionTime == nil {
-						shouldSwap = true
-					}
-				} else {
-					// Both non-winners - sort by completion rate, then by average score
-					if rankings[j+1].CompletionRate > rankings[j].CompletionRate {
-						shouldSwap = true
-					} else if rankings[j+1].CompletionRate == rankings[j].CompletionRate {
-						if rankings[j+1].AverageScore > rankings[j].AverageScore {
-							shouldSwap = true
-						}
-					}
-				}
-			}
-			
-			if shouldSwap {
-				rankings[j], rankings[j+1] = rankings[j+1], rankings[j]
-			}
-		}
-	}
-	
-	// Assign ranks
-	for i := range rankings {
-		rankings[i].Rank = i + 1
+		return nil, ErrSessionNotFound
 	}
 	
-	return rankings, nil
+	return p.rankingEngine.Rankings(ctx, session)
 }
 
 // GetPerformanceStatistics calculates and returns detailed performance statistics for all players
@@ -490,117 +412,10 @@ func (p *ProgressServiceImpl) GetPerformanceStatistics(ctx context.Context, sess
 	}
 	
 	if session == nil {
-		return nil, fmt.Errorf("session not found")
-	}
-	
-	var stats []models.PlayerPerformanceStats
-	
-	for _, player := range session.Players {
-		// Get player path for completion information
-		playerPath, err := p.playerPathRepo.GetPlayerPath(ctx, player.PlayerID)
-		if err != nil {
-			playerPath = &models.PlayerPath{
-				PlayerID:        player.PlayerID,
-				CurrentPosition: len(player.Responses),
-				TotalDoors:      10, // Default
-			}
-		}
-		
-		// Initialize statistics
-		playerStats := models.PlayerPerformanceStats{
-			PlayerID:       player.PlayerID,
-			Username:       player.Username,
-			TotalScore:     player.TotalScore,
-			DoorsCompleted: len(player.Responses),
-			TotalDoors:     playerPath.TotalDoors,
-		}
-		
-		// Calculate completion rate
-		if playerPath.TotalDoors > 0 {
-			playerStats.CompletionRate = float64(playerPath.CurrentPosition) / float64(playerPath.TotalDoors) * 100
-		}
-		
-		// Calculate completion time if player finished
-		if playerPath.CurrentPosition >= playerPath.TotalDoors && len(player.Responses) > 0 && session.StartedAt != nil {
-			lastResponseTime := player.Responses[len(player.Responses)-1].SubmittedAt
-			duration := lastResponseTime.Sub(*session.StartedAt)
-			playerStats.CompletionTime = &duration
-		}
-		
-		// Calculate path efficiency (lower total doors = better efficiency)
-		if playerPath.TotalDoors > 0 {
-			// Efficiency is inverse of total doors, normalized to 0-100 scale
-			// Assume 5 doors is perfect (100%), 15 doors is poor (33%)
-			minDoors := 5.0
-			maxDoors := 15.0
-			efficiency := (maxDoors - float64(playerPath.TotalDoors)) / (maxDoors - minDoors) * 100
-			if efficiency < 0 {
-				efficiency = 0
-			} else if efficiency > 100 {
-				efficiency = 100
-			}
-			playerStats.PathEfficiency = efficiency
-		}
-		
-		// Calculate response-based statistics
-		if len(player.Responses) > 0 {
-			totalScore := 0
-			totalCreativity := 0
-			totalFeasibility := 0
-			totalHumor := 0
-			totalOriginality := 0
-			totalResponseTime := time.Duration(0)
-			
-			highestScore := player.Responses[0].AIScore
-			lowestScore := player.Responses[0].AIScore
-			
-			var firstResponseTime *time.Time
-			if session.StartedAt != nil {
-				firstResponseTime = session.StartedAt
-			} else {
-				firstResponseTime = &player.Responses[0].SubmittedAt
-			}
-			
-			for i, response := range player.Responses {
-				totalScore += response.AIScore
-				totalCreativity += response.ScoringMetrics.Creativity
-				totalFeasibility += response.ScoringMetrics.Feasibility
-				totalHumor += response.ScoringMetrics.Humor
-				totalOriginality += response.ScoringMetrics.Originality
-				
-				if response.AIScore > highestScore {
-					highestScore = response.AIScore
-				}
-				if response.AIScore < lowestScore {
-					lowestScore = response.AIScore
-				}
-				
-				// Calculate response time (time between doors or from game start)
-				var responseStartTime time.Time
-				if i == 0 {
-					responseStartTime = *firstResponseTime
-				} else {
-					responseStartTime = player.Responses[i-1].SubmittedAt
-				}
-				responseTime := response.SubmittedAt.Sub(responseStartTime)
-				totalResponseTime += responseTime
-			}
-			
-			responseCount := len(player.Responses)
-			playerStats.AverageScore = float64(totalScore) / float64(responseCount)
-			playerStats.HighestScore = highestScore
-			playerStats.LowestScore = lowestScore
-			playerStats.AverageResponseTime = totalResponseTime / time.Duration(responseCount)
-			playerStats.CreativityAverage = float64(totalCreativity) / float64(responseCount)
-			playerStats.FeasibilityAverage = float64(totalFeasibility) / float64(responseCount)
-			playerStats.HumorAverage = float64(totalHumor) / float64(responseCount)
-			playerStats.OriginalityAverage = float64(totalOriginality) / float64(responseCount)
-		}
-		
-		stats = append(stats, playerStats)
+		return nil, ErrSessionNotFound
 	}
 	
-	return stats, nil
+	return p.rankingEngine.PerformanceStatistics(ctx, session)
 }
 
 // BroadcastGameCompletion broadcasts comprehensive game completion information
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+	"time"
+)
+
+// ScheduleProgressBroadcast requests a session-wide progress and leaderboard broadcast.
+// Requests are coalesced so a session receives at most one broadcast per interval,
+// always computed from the latest state when the broadcast fires.
+func (p *ProgressServiceImpl) ScheduleProgressBroadcast(sessionID string) {
+	if p.wsManager == nil {
+		return
+	}
+
+	p.broadcastMu.Lock()
+	defer p.broadcastMu.Unlock()
+
+	// A broadcast is already pending and will pick up the latest state
+	if _, pending := p.pendingBroadcasts[sessionID]; pending {
+		return
+	}
+
+	delay := time.Duration(0)
+	if last, exists := p.lastBroadcasts[sessionID]; exists {
+		if elapsed := time.Since(last); elapsed < p.broadcastInterval {
+			delay = p.broadcastInterval - elapsed
+		}
+	}
+
+	p.pendingBroadcasts[sessionID] = time.AfterFunc(delay, func() {
+		p.flushProgressBroadcast(sessionID)
+	})
+}
+
+// flushProgressBroadcast sends the coalesced progress and leaderboard update for a session
+func (p *ProgressServiceImpl) flushProgressBroadcast(sessionID string) {
+	p.broadcastMu.Lock()
+	delete(p.pendingBroadcasts, sessionID)
+	p.lastBroadcasts[sessionID] = time.Now()
+	p.broadcastMu.Unlock()
+
+	ctx, cancel := tracing.Detach(tracing.WithSessionID(context.Background(), sessionID), "progress-broadcast", tracing.DefaultDetachedTimeout)
+	defer cancel()
+
+	sessionProgress, err := p.CalculateSessionProgress(ctx, sessionID)
+	if err != nil {
+		fmt.Printf("Warning: failed to calculate session progress for broadcast: %v\n", err)
+		p.forgetSession(sessionID)
+		return
+	}
+
+	if err := p.wsManager.BroadcastProgressUpdate(sessionID, *sessionProgress); err != nil {
+		fmt.Printf("Warning: failed to broadcast progress updates: %v\n", err)
+	}
+
+	// The leaderboard is the same progress data in ranked order
+	leaderboard := make([]PlayerProgress, len(sessionProgress.Players))
+	copy(leaderboard, sessionProgress.Players)
+	sortPlayerProgress(leaderboard)
+
+	if err := p.wsManager.BroadcastLeaderboardUpdate(sessionID, leaderboard); err != nil {
+		fmt.Printf("Warning: failed to broadcast leaderboard update: %v\n", err)
+	}
+
+	if sessionProgress.GameStatus == string(models.GameStatusCompleted) {
+		p.forgetSession(sessionID)
+	}
+}
+
+// forgetSession drops debounce bookkeeping for a session that no longer needs broadcasts
+func (p *ProgressServiceImpl) forgetSession(sessionID string) {
+	p.broadcastMu.Lock()
+	defer p.broadcastMu.Unlock()
+
+	if _, pending := p.pendingBroadcasts[sessionID]; !pending {
+		delete(p.lastBroadcasts, sessionID)
+	}
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"sort"
+	"time"
+)
+
+// Path efficiency bounds: a path this short scores 100%, this long or longer scores 0%
+const (
+	efficientPathDoors   = 5.0
+	inefficientPathDoors = 15.0
+)
+
+// defaultPathLength is assumed for players whose path cannot be loaded
+const defaultPathLength = 10
+
+// RankingEngine computes end-of-game rankings and performance statistics for a session
+type RankingEngine interface {
+	Rankings(ctx context.Context, session *models.GameSession) ([]models.PlayerRanking, error)
+	PerformanceStatistics(ctx context.Context, session *models.GameSession) ([]models.PlayerPerformanceStats, error)
+}
+
+// RankingEngineImpl implements the RankingEngine interface
+type RankingEngineImpl struct {
+	playerPathRepo repositories.PlayerPathRepository
+}
+
+// NewRankingEngine creates a ranking engine that reads player paths from playerPathRepo
+func NewRankingEngine(playerPathRepo repositories.PlayerPathRepository) RankingEngine {
+	return &RankingEngineImpl{
+		playerPathRepo: playerPathRepo,
+	}
+}
+
+// playerStanding is how far a player got along their path
+type playerStanding struct {
+	path           *models.PlayerPath
+	completionRate float64
+	isWinner       bool
+	completionTime *time.Duration
+}
+
+// Rankings ranks every player in the session, winners first
+func (e *RankingEngineImpl) Rankings(ctx context.Context, session *models.GameSession) ([]models.PlayerRanking, error) {
+	rankings := make([]models.PlayerRanking, 0, len(session.Players))
+
+	for _, player := range session.Players {
+		standing := e.standing(ctx, session, player)
+
+		rankings = append(rankings, models.PlayerRanking{
+			PlayerID:       player.PlayerID,
+			Username:       player.Username,
+			CompletionTime: standing.completionTime,
+			TotalScore:     player.TotalScore,
+			AverageScore:   averageResponseScore(player.Responses),
+			DoorsCompleted: len(player.Responses),
+			TotalDoors:     standing.path.TotalDoors,
+			CompletionRate: standing.completionRate,
+			IsWinner:       standing.isWinner,
+		})
+	}
+
+	// Sort rankings by completion status, then by completion time, then by score
+	sortPlayerRankings(rankings)
+
+	return rankings, nil
+}
+
+// PerformanceStatistics calculates detailed per-player statistics for the session
+func (e *RankingEngineImpl) PerformanceStatistics(ctx context.Context, session *models.GameSession) ([]models.PlayerPerformanceStats, error) {
+	stats := make([]models.PlayerPerformanceStats, 0, len(session.Players))
+
+	for _, player := range session.Players {
+		standing := e.standing(ctx, session, player)
+
+		playerStats := models.PlayerPerformanceStats{
+			PlayerID:       player.PlayerID,
+			Username:       player.Username,
+			TotalScore:     player.TotalScore,
+			DoorsCompleted: len(player.Responses),
+			TotalDoors:     standing.path.TotalDoors,
+			CompletionRate: standing.completionRate,
+			CompletionTime: standing.completionTime,
+			PathEfficiency: pathEfficiency(standing.path.TotalDoors),
+		}
+
+		if len(player.Responses) > 0 {
+			applyResponseStatistics(&playerStats, session, player.Responses)
+		}
+
+		stats = append(stats, playerStats)
+	}
+
+	return stats, nil
+}
+
+// standing loads the player's path and derives completion rate, win status and completion time
+func (e *RankingEngineImpl) standing(ctx context.Context, session *models.GameSession, player models.PlayerInfo) playerStanding {
+	playerPath, err := e.playerPathRepo.GetPlayerPath(ctx, player.PlayerID)
+	if err != nil || playerPath == nil {
+		// Use default values if path not found
+		playerPath = &models.PlayerPath{
+			PlayerID:        player.PlayerID,
+			CurrentPosition: len(player.Responses),
+			TotalDoors:      defaultPathLength,
+		}
+	}
+
+	standing := playerStanding{
+		path:     playerPath,
+		isWinner: playerPath.CurrentPosition >= playerPath.TotalDoors,
+	}
+
+	if playerPath.TotalDoors > 0 {
+		standing.completionRate = float64(playerPath.CurrentPosition) / float64(playerPath.TotalDoors) * 100
+	}
+
+	// Completion time is measured from game start to the player's final response
+	if standing.isWinner && len(player.Responses) > 0 && session.StartedAt != nil {
+		lastResponseTime := player.Responses[len(player.Responses)-1].SubmittedAt
+		duration := lastResponseTime.Sub(*session.StartedAt)
+		standing.completionTime = &duration
+	}
+
+	return standing
+}
+
+// averageResponseScore returns the mean AI score of the responses, or 0 when there are none
+func averageResponseScore(responses []models.PlayerResponse) float64 {
+	if len(responses) == 0 {
+		return 0
+	}
+
+	totalScore := 0
+	for _, response := range responses {
+		totalScore += response.AIScore
+	}
+	return float64(totalScore) / float64(len(responses))
+}
+
+// pathEfficiency scores a path length from 0 to 100, shorter paths scoring higher
+func pathEfficiency(totalDoors int) float64 {
+	if totalDoors <= 0 {
+		return 0
+	}
+
+	efficiency := (inefficientPathDoors - float64(totalDoors)) / (inefficientPathDoors - efficientPathDoors) * 100
+	if efficiency < 0 {
+		return 0
+	}
+	if efficiency > 100 {
+		return 100
+	}
+	return efficiency
+}
+
+// applyResponseStatistics fills in score, metric and response-time statistics from a non-empty response list
+func applyResponseStatistics(stats *models.PlayerPerformanceStats, session *models.GameSession, responses []models.PlayerResponse) {
+	totalScore := 0
+	totalCreativity := 0
+	totalFeasibility := 0
+	totalHumor := 0
+	totalOriginality := 0
+	totalResponseTime := time.Duration(0)
+
+	highestScore := responses[0].AIScore
+	lowestScore := responses[0].AIScore
+
+	// The first response is timed from game start when it is known
+	previousSubmission := responses[0].SubmittedAt
+	if session.StartedAt != nil {
+		previousSubmission = *session.StartedAt
+	}
+
+	for _, response := range responses {
+		totalScore += response.AIScore
+		totalCreativity += response.ScoringMetrics.Creativity
+		totalFeasibility += response.ScoringMetrics.Feasibility
+		totalHumor += response.ScoringMetrics.Humor
+		totalOriginality += response.ScoringMetrics.Originality
+
+		if response.AIScore > highestScore {
+			highestScore = response.AIScore
+		}
+		if response.AIScore < lowestScore {
+			lowestScore = response.AIScore
+		}
+
+		// Response time is the gap since the previous door (or game start)
+		totalResponseTime += response.SubmittedAt.Sub(previousSubmission)
+		previousSubmission = response.SubmittedAt
+	}
+
+	responseCount := len(responses)
+	stats.AverageScore = float64(totalScore) / float64(responseCount)
+	stats.HighestScore = highestScore
+	stats.LowestScore = lowestScore
+	stats.AverageResponseTime = totalResponseTime / time.Duration(responseCount)
+	stats.CreativityAverage = float64(totalCreativity) / float64(responseCount)
+	stats.FeasibilityAverage = float64(totalFeasibility) / float64(responseCount)
+	stats.HumorAverage = float64(totalHumor) / float64(responseCount)
+	stats.OriginalityAverage = float64(totalOriginality) / float64(responseCount)
+}
+
+// rankingKey holds the fields used to order players in rankings and leaderboards
+type rankingKey struct {
+	isWinner       bool
+	completionTime *time.Duration
+	completionRate float64
+	averageScore   float64
+}
+
+// ranksAhead reports whether a should be placed before b.
+// Tie-breaking rules, in order:
+//  1. Winners before non-winners
+//  2. Between winners, the faster completion time first (a recorded time beats a missing one)
+//  3. Higher completion rate first
+//  4. Higher average score first
+//
+// Players equal on every rule keep their original relative order.
+func ranksAhead(a, b rankingKey) bool {
+	if a.isWinner != b.isWinner {
+		return a.isWinner
+	}
+
+	if a.isWinner {
+		switch {
+		case a.completionTime != nil && b.completionTime == nil:
+			return true
+		case a.completionTime == nil && b.completionTime != nil:
+			return false
+		case a.completionTime != nil && b.completionTime != nil && *a.completionTime != *b.completionTime:
+			return *a.completionTime < *b.completionTime
+		}
+	}
+
+	if a.completionRate != b.completionRate {
+		return a.completionRate > b.completionRate
+	}
+
+	return a.averageScore > b.averageScore
+}
+
+// playerRankingKey builds the ranking key for a final ranking entry
+func playerRankingKey(r models.PlayerRanking) rankingKey {
+	return rankingKey{
+		isWinner:       r.IsWinner,
+		completionTime: r.CompletionTime,
+		completionRate: r.CompletionRate,
+		averageScore:   r.AverageScore,
+	}
+}
+
+// playerProgressKey builds the ranking key for a live progress entry
+func playerProgressKey(p PlayerProgress) rankingKey {
+	progress := 0.0
+	if p.TotalDoors > 0 {
+		progress = float64(p.CurrentPosition) / float64(p.TotalDoors)
+	}
+
+	return rankingKey{
+		completionRate: progress,
+		averageScore:   p.AverageScore,
+	}
+}
+
+// sortPlayerRankings orders final rankings and assigns 1-based ranks
+func sortPlayerRankings(rankings []models.PlayerRanking) {
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return ranksAhead(playerRankingKey(rankings[i]), playerRankingKey(rankings[j]))
+	})
+
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+	}
+}
+
+// sortPlayerProgress orders live session progress by progress and then average score
+func sortPlayerProgress(players []PlayerProgress) {
+	sort.SliceStable(players, func(i, j int) bool {
+		return ranksAhead(playerProgressKey(players[i]), playerProgressKey(players[j]))
+	})
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestSortPlayerRankings_WinnersFirst(t *testing.T) {
+	rankings := []models.PlayerRanking{
+		{PlayerID: "loser", CompletionRate: 90, AverageScore: 95},
+		{PlayerID: "winner", IsWinner: true, CompletionRate: 100, AverageScore: 40, CompletionTime: durationPtr(10 * time.Minute)},
+	}
+
+	sortPlayerRankings(rankings)
+
+	if rankings[0].PlayerID != "winner" {
+		t.Errorf("Expected winner first, got %s", rankings[0].PlayerID)
+	}
+	if rankings[0].Rank != 1 || rankings[1].Rank != 2 {
+		t.Errorf("Expected ranks 1 and 2, got %d and %d", rankings[0].Rank, rankings[1].Rank)
+	}
+}
+
+func TestSortPlayerRankings_WinnersByCompletionTime(t *testing.T) {
+	rankings := []models.PlayerRanking{
+		{PlayerID: "no-time", IsWinner: true, CompletionRate: 100, AverageScore: 99},
+		{PlayerID: "slow", IsWinner: true, CompletionRate: 100, CompletionTime: durationPtr(8 * time.Minute)},
+		{PlayerID: "fast", IsWinner: true, CompletionRate: 100, CompletionTime: durationPtr(3 * time.Minute)},
+	}
+
+	sortPlayerRankings(rankings)
+
+	expected := []string{"fast", "slow", "no-time"}
+	for i, playerID := range expected {
+		if rankings[i].PlayerID != playerID {
+			t.Errorf("Position %d: expected %s, got %s", i, playerID, rankings[i].PlayerID)
+		}
+	}
+}
+
+func TestSortPlayerRankings_WinnersWithEqualTimeFallBackToScore(t *testing.T) {
+	rankings := []models.PlayerRanking{
+		{PlayerID: "low", IsWinner: true, CompletionRate: 100, AverageScore: 50, CompletionTime: durationPtr(5 * time.Minute)},
+		{PlayerID: "high", IsWinner: true, CompletionRate: 100, AverageScore: 80, CompletionTime: durationPtr(5 * time.Minute)},
+	}
+
+	sortPlayerRankings(rankings)
+
+	if rankings[0].PlayerID != "high" {
+		t.Errorf("Expected higher average score to break time tie, got %s first", rankings[0].PlayerID)
+	}
+}
+
+func TestSortPlayerRankings_NonWinnersByRateThenScore(t *testing.T) {
+	rankings := []models.PlayerRanking{
+		{PlayerID: "c", CompletionRate: 40, AverageScore: 90},
+		{PlayerID: "b", CompletionRate: 60, AverageScore: 30},
+		{PlayerID: "a", CompletionRate: 60, AverageScore: 70},
+	}
+
+	sortPlayerRankings(rankings)
+
+	expected := []string{"a", "b", "c"}
+	for i, playerID := range expected {
+		if rankings[i].PlayerID != playerID {
+			t.Errorf("Position %d: expected %s, got %s", i, playerID, rankings[i].PlayerID)
+		}
+	}
+}
+
+func TestSortPlayerRankings_FullTiesKeepOriginalOrder(t *testing.T) {
+	rankings := []models.PlayerRanking{
+		{PlayerID: "first", CompletionRate: 50, AverageScore: 50},
+		{PlayerID: "second", CompletionRate: 50, AverageScore: 50},
+		{PlayerID: "third", CompletionRate: 50, AverageScore: 50},
+	}
+
+	sortPlayerRankings(rankings)
+
+	expected := []string{"first", "second", "third"}
+	for i, playerID := range expected {
+		if rankings[i].PlayerID != playerID {
+			t.Errorf("Position %d: expected %s, got %s", i, playerID, rankings[i].PlayerID)
+		}
+	}
+}
+
+func TestSortPlayerProgress_ByProgressThenScore(t *testing.T) {
+	players := []PlayerProgress{
+		{PlayerID: "no-doors", CurrentPosition: 0, TotalDoors: 0, AverageScore: 100},
+		{PlayerID: "half-low", CurrentPosition: 5, TotalDoors: 10, AverageScore: 40},
+		{PlayerID: "half-high", CurrentPosition: 3, TotalDoors: 6, AverageScore: 75},
+		{PlayerID: "ahead", CurrentPosition: 8, TotalDoors: 10, AverageScore: 20},
+	}
+
+	sortPlayerProgress(players)
+
+	expected := []string{"ahead", "half-high", "half-low", "no-doors"}
+	for i, playerID := range expected {
+		if players[i].PlayerID != playerID {
+			t.Errorf("Position %d: expected %s, got %s", i, playerID, players[i].PlayerID)
+		}
+	}
+}
+
+// generateRankings builds a shuffled set of rankings for benchmarking large sessions
+func TestRankingEngine_MissingPathUsesDefaultLength(t *testing.T) {
+	engine := NewRankingEngine(NewMockPlayerPathRepository())
+	session := &models.GameSession{
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Responses: []models.PlayerResponse{{AIScore: 60}, {AIScore: 80}}},
+		},
+	}
+
+	rankings, err := engine.Rankings(context.Background(), session)
+	if err != nil {
+		t.Fatalf("Rankings failed: %v", err)
+	}
+
+	if rankings[0].TotalDoors != defaultPathLength {
+		t.Errorf("Expected default path length %d, got %d", defaultPathLength, rankings[0].TotalDoors)
+	}
+	if rankings[0].CompletionRate != 20 {
+		t.Errorf("Expected completion rate 20, got %f", rankings[0].CompletionRate)
+	}
+	if rankings[0].AverageScore != 70 {
+		t.Errorf("Expected average score 70, got %f", rankings[0].AverageScore)
+	}
+}
+
+func TestRankingEngine_PerformanceStatistics(t *testing.T) {
+	playerPathRepo := NewMockPlayerPathRepository()
+	playerPathRepo.paths["p1"] = &models.PlayerPath{PlayerID: "p1", CurrentPosition: 2, TotalDoors: 2}
+
+	startedAt := time.Now().Add(-10 * time.Minute)
+	session := &models.GameSession{
+		StartedAt: &startedAt,
+		Players: []models.PlayerInfo{
+			{
+				PlayerID:   "p1",
+				TotalScore: 140,
+				Responses: []models.PlayerResponse{
+					{AIScore: 50, SubmittedAt: startedAt.Add(2 * time.Minute)},
+					{AIScore: 90, SubmittedAt: startedAt.Add(6 * time.Minute)},
+				},
+			},
+		},
+	}
+
+	stats, err := NewRankingEngine(playerPathRepo).PerformanceStatistics(context.Background(), session)
+	if err != nil {
+		t.Fatalf("PerformanceStatistics failed: %v", err)
+	}
+
+	got := stats[0]
+	if got.HighestScore != 90 || got.LowestScore != 50 || got.AverageScore != 70 {
+		t.Errorf("Unexpected scores: highest %d, lowest %d, average %f", got.HighestScore, got.LowestScore, got.AverageScore)
+	}
+	if got.AverageResponseTime != 3*time.Minute {
+		t.Errorf("Expected average response time 3m, got %v", got.AverageResponseTime)
+	}
+	if got.CompletionTime == nil || *got.CompletionTime != 6*time.Minute {
+		t.Errorf("Expected completion time 6m, got %v", got.CompletionTime)
+	}
+	if got.PathEfficiency != 100 {
+		t.Errorf("Expected a 2-door path to be fully efficient, got %f", got.PathEfficiency)
+	}
+}
+
+func generateRankings(count int) []models.PlayerRanking {
+	rng := rand.New(rand.NewSource(42))
+	rankings := make([]models.PlayerRanking, count)
+	for i := range rankings {
+		ranking := models.PlayerRanking{
+			PlayerID:       fmt.Sprintf("player-%d", i),
+			CompletionRate: float64(rng.Intn(100)),
+			AverageScore:   float64(rng.Intn(100)),
+		}
+		if rng.Intn(10) == 0 {
+			ranking.IsWinner = true
+			ranking.CompletionRate = 100
+			ranking.CompletionTime = durationPtr(time.Duration(rng.Intn(3600)) * time.Second)
+		}
+		rankings[i] = ranking
+	}
+	return rankings
+}
+
+func BenchmarkSortPlayerRankings(b *testing.B) {
+	for _, size := range []int{8, 100, 1000, 10000} {
+		source := generateRankings(size)
+		b.Run(fmt.Sprintf("players-%d", size), func(b *testing.B) {
+			rankings := make([]models.PlayerRanking, len(source))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				copy(rankings, source)
+				sortPlayerRankings(rankings)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReadyCountdown is how long the game waits to start once enough players are ready
+const ReadyCountdown = 5 * time.Second
+
+// ErrPlayersNotReady is returned when a session with a ready check is started too early
+var ErrPlayersNotReady = errors.New("not enough players are ready")
+
+// readyCountdownKey is the scheduler key for a session's ready countdown
+const readyCountdownKey = "ready-countdown"
+
+// isReadyCountdownKey reports whether a scheduler key ends a ready countdown rather than a door
+func isReadyCountdownKey(key string) bool {
+	return key == readyCountdownKey
+}
+
+// ReadyStatus describes how close a session is to passing its ready check
+type ReadyStatus struct {
+	SessionID       string     `json:"sessionId"`
+	PlayerID        string     `json:"playerId"`
+	Ready           bool       `json:"ready"`
+	ReadyCount      int        `json:"readyCount"`
+	TotalPlayers    int        `json:"totalPlayers"`
+	RequiredPercent int        `json:"requiredPercent"`
+	ThresholdMet    bool       `json:"thresholdMet"`
+	StartsAt        *time.Time `json:"startsAt,omitempty"`
+}
+
+// SetPlayerReady marks a player in a waiting session as ready or not. Once the session's
+// ready threshold is met a countdown starts, after which the game starts on its own; the
+// countdown is cancelled if too many players take their ready back.
+func (s *GameServiceImpl) SetPlayerReady(ctx context.Context, sessionID, playerID string, ready bool) (*ReadyStatus, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.SetPlayerReady", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if err := s.stateMachine.Require(session, OpReadyCheck); err != nil {
+		return nil, err
+	}
+
+	if !isActivePlayer(session, playerID) {
+		if isSpectator(session, playerID) {
+			return nil, ErrSpectatorReadOnly
+		}
+		return nil, ErrPlayerNotInSession
+	}
+	for i := range session.Players {
+		if session.Players[i].PlayerID == playerID {
+			session.Players[i].IsReady = ready
+		}
+	}
+
+	// Start the countdown when the threshold is first met, and stop it if it no longer is. The
+	// session is starting while the countdown runs.
+	countdownStarted, countdownCancelled := false, false
+	var change *SessionTransition
+	thresholdMet := session.ReadyThresholdMet()
+	switch {
+	case session.Settings.ReadyPercent > 0 && thresholdMet && session.StartsAt == nil:
+		if change, err = s.stateMachine.Transition(session, models.GameStatusStarting); err != nil {
+			return nil, err
+		}
+		startsAt := time.Now().Add(ReadyCountdown)
+		session.StartsAt = &startsAt
+		countdownStarted = true
+	case !thresholdMet && session.StartsAt != nil:
+		if change, err = s.stateMachine.Transition(session, models.GameStatusWaiting); err != nil {
+			return nil, err
+		}
+		session.StartsAt = nil
+		countdownCancelled = true
+	}
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save ready state: %w", err)
+	}
+	if change != nil {
+		announceTransition(s.wsManager, change)
+	}
+
+	if countdownStarted {
+		if err := s.scheduler.Schedule(ctx, sessionID, readyCountdownKey, *session.StartsAt); err != nil {
+			fmt.Printf("Warning: failed to schedule ready countdown: %v\n", err)
+		}
+	}
+	if countdownCancelled {
+		if err := s.scheduler.Cancel(ctx, sessionID, readyCountdownKey); err != nil {
+			fmt.Printf("Warning: failed to cancel ready countdown: %v\n", err)
+		}
+	}
+
+	readyCount, total := session.ReadyCount()
+	status := &ReadyStatus{
+		SessionID:       sessionID,
+		PlayerID:        playerID,
+		Ready:           ready,
+		ReadyCount:      readyCount,
+		TotalPlayers:    total,
+		RequiredPercent: session.Settings.ReadyPercent,
+		ThresholdMet:    thresholdMet,
+		StartsAt:        session.StartsAt,
+	}
+
+	if s.wsManager != nil {
+		events := []WebSocketEvent{{
+			Type:      "player-ready",
+			SessionID: sessionID,
+			PlayerID:  playerID,
+			Data:      status,
+			Timestamp: time.Now(),
+		}}
+		if countdownStarted {
+			events = append(events, WebSocketEvent{
+				Type:      "ready-countdown",
+				SessionID: sessionID,
+				Data: map[string]interface{}{
+					"startsAt": session.StartsAt,
+					"seconds":  int(ReadyCountdown.Seconds()),
+				},
+				Timestamp: time.Now(),
+			})
+		}
+		if countdownCancelled {
+			events = append(events, WebSocketEvent{
+				Type:      "ready-countdown-cancelled",
+				SessionID: sessionID,
+				Data: map[string]interface{}{
+					"readyCount":   readyCount,
+					"totalPlayers": total,
+				},
+				Timestamp: time.Now(),
+			})
+		}
+
+		s.runInBackground(ctx, sessionID, "broadcast-player-ready", func(ctx context.Context) {
+			for _, event := range events {
+				if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+					fmt.Printf("Warning: failed to broadcast %s: %v\n", event.Type, err)
+				}
+			}
+		})
+	}
+
+	return status, nil
+}
+
+// finishReadyCountdown starts the game when its ready countdown ends, unless the game has
+// already been started or the countdown was cancelled in the meantime
+func (s *GameServiceImpl) finishReadyCountdown(ctx context.Context, sessionID string) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session == nil {
+		fmt.Printf("Warning: failed to load session for ready countdown: %v\n", err)
+		return
+	}
+	if session.Status != models.GameStatusStarting || session.StartsAt == nil || !session.ReadyThresholdMet() {
+		return
+	}
+
+	if err := s.StartGameWithFirstDoor(ctx, sessionID); err != nil {
+		fmt.Printf("Error starting game after ready countdown: %v\n", err)
+	}
+}
+
+// handleReadyMessage records a ready check answered over the player's socket
+func (s *GameServiceImpl) handleReadyMessage(ctx context.Context, sessionID, playerID string, msg map[string]interface{}) error {
+	ready, _ := msg["ready"].(bool)
+	_, err := s.SetPlayerReady(ctx, sessionID, playerID, ready)
+	return err
+}
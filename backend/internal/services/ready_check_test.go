@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func newReadyCheckSession(readyPercent int) *models.GameSession {
+	return &models.GameSession{
+		SessionID: "s1",
+		Mode:      models.GameModeMultiplayer,
+		Status:    models.GameStatusWaiting,
+		Settings:  models.SessionSettings{ReadyPercent: readyPercent},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", IsActive: true},
+			{PlayerID: "p2", IsActive: true},
+			{PlayerID: "p3", IsActive: true},
+			{PlayerID: "p4", IsActive: true},
+		},
+	}
+}
+
+func countdownScheduled(gameService GameService) bool {
+	scheduler := gameService.(*GameServiceImpl).scheduler.(*InProcessScheduler)
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+	_, exists := scheduler.timers["s1/"+readyCountdownKey]
+	return exists
+}
+
+func TestStartGame_RequiresReadyThreshold(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newReadyCheckSession(75)
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	)
+
+	for _, playerID := range []string{"p1", "p2"} {
+		if _, err := gameService.SetPlayerReady(ctx, "s1", playerID, true); err != nil {
+			t.Fatalf("SetPlayerReady failed: %v", err)
+		}
+	}
+	if err := gameService.StartGame(ctx, "s1"); !errors.Is(err, ErrPlayersNotReady) {
+		t.Fatalf("Expected ErrPlayersNotReady with 2 of 4 ready, got %v", err)
+	}
+
+	status, err := gameService.SetPlayerReady(ctx, "s1", "p3", true)
+	if err != nil {
+		t.Fatalf("SetPlayerReady failed: %v", err)
+	}
+	if !status.ThresholdMet || status.ReadyCount != 3 || status.StartsAt == nil {
+		t.Errorf("Expected 3 of 4 ready to meet 75%% and start the countdown, got %+v", status)
+	}
+	if !countdownScheduled(gameService) {
+		t.Error("Expected the ready countdown to be scheduled")
+	}
+	if session := gameSessionRepo.sessions["s1"]; session.Status != models.GameStatusStarting {
+		t.Errorf("Expected the session to be starting during the countdown, got %s", session.Status)
+	}
+
+	if err := gameService.StartGame(ctx, "s1"); err != nil {
+		t.Fatalf("Expected the game to start once enough players are ready, got %v", err)
+	}
+	if session := gameSessionRepo.sessions["s1"]; session.Status != models.GameStatusActive || session.StartsAt != nil {
+		t.Errorf("Expected an active session with the countdown cleared, got %s (startsAt %v)", session.Status, session.StartsAt)
+	}
+}
+
+func TestSetPlayerReady_CancelsCountdownWhenPlayerUnreadies(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newReadyCheckSession(100)
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	)
+
+	for _, playerID := range []string{"p1", "p2", "p3", "p4"} {
+		if _, err := gameService.SetPlayerReady(ctx, "s1", playerID, true); err != nil {
+			t.Fatalf("SetPlayerReady failed: %v", err)
+		}
+	}
+	if !countdownScheduled(gameService) {
+		t.Fatal("Expected the countdown to start once every player is ready")
+	}
+
+	status, err := gameService.SetPlayerReady(ctx, "s1", "p2", false)
+	if err != nil {
+		t.Fatalf("SetPlayerReady failed: %v", err)
+	}
+	if status.ThresholdMet || status.StartsAt != nil {
+		t.Errorf("Expected the threshold to be lost, got %+v", status)
+	}
+	if countdownScheduled(gameService) {
+		t.Error("Expected the countdown to be cancelled")
+	}
+
+	// A countdown that fires after being cancelled leaves the session waiting
+	gameService.(*GameServiceImpl).finishReadyCountdown(ctx, "s1")
+	if session := gameSessionRepo.sessions["s1"]; session.Status != models.GameStatusWaiting {
+		t.Errorf("Expected the session to keep waiting, got %s", session.Status)
+	}
+}
+
+func TestSetPlayerReady_OnlyWhileWaiting(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newReadyCheckSession(100)
+	session.Status = models.GameStatusActive
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if _, err := gameService.SetPlayerReady(context.Background(), "s1", "p1", true); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("Expected ErrIllegalOperation once the game is running, got %v", err)
+	}
+}
+
+func TestCreateSession_ReadyCheckRequiresMultiplayer(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	_, err := gameService.CreateSession(context.Background(), models.GameModeSinglePlayer, "p1", "Player 1", nil, "en", models.SessionSettings{ReadyPercent: 100})
+	if !errors.Is(err, ErrInvalidSessionSettings) {
+		t.Errorf("Expected ErrInvalidSessionSettings, got %v", err)
+	}
+}
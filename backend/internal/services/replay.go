@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Replay settings
+const (
+	DefaultReplayPageSize   = 100
+	MaxReplayPageSize       = 500
+	DefaultReplaySpeed      = 1.0
+	MinReplaySpeed          = 0.25
+	MaxReplaySpeed          = 16.0
+	DefaultReplayBufferSize = 1024
+	maxReplayGap            = 5 * time.Second // idle stretches longer than this are shortened when streaming
+	replayWriteTimeout      = 5 * time.Second
+)
+
+// Replay errors
+var (
+	ErrReplayNotFound    = ErrSessionNotFound
+	ErrReplayUnavailable = errors.New("replay is available once the game has completed")
+)
+
+// ReplayRecorder receives broadcast events to add to their session's replay log
+type ReplayRecorder interface {
+	Record(event WebSocketEvent)
+}
+
+// ReplayService records each session's public events and plays them back after the game
+type ReplayService interface {
+	ReplayRecorder
+	Start(ctx context.Context)
+	GetReplay(ctx context.Context, sessionID string, page, pageSize int) (*models.ReplayPage, error)
+	StreamReplay(ctx context.Context, sessionID string, speed float64, send func(*models.ReplayEvent) error) error
+}
+
+// ReplayServiceImpl implements the ReplayService interface. Events are buffered and written
+// by a background loop so recording never slows down a broadcast.
+type ReplayServiceImpl struct {
+	replayRepo      repositories.ReplayRepository
+	gameSessionRepo repositories.GameSessionRepository
+	pending         chan *models.ReplayEvent
+	droppedEvents   *monitoring.Counter
+}
+
+// NewReplayService creates a new replay service buffering up to bufferSize unwritten events
+func NewReplayService(replayRepo repositories.ReplayRepository, gameSessionRepo repositories.GameSessionRepository, bufferSize int) ReplayService {
+	if bufferSize <= 0 {
+		bufferSize = DefaultReplayBufferSize
+	}
+	return &ReplayServiceImpl{
+		replayRepo:      replayRepo,
+		gameSessionRepo: gameSessionRepo,
+		pending:         make(chan *models.ReplayEvent, bufferSize),
+		droppedEvents:   monitoring.GetGlobalMetricsCollector().NewCounter("replay_events_dropped_total", "Replay events dropped because the write buffer was full", nil),
+	}
+}
+
+// Record queues a session event for the replay log. Only events spectators may see are kept,
+// so replays never expose drafts or other private messages.
+func (r *ReplayServiceImpl) Record(event WebSocketEvent) {
+	if event.SessionID == "" || !isSpectatorEvent(event.Type) {
+		return
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		fmt.Printf("Warning: failed to encode %s event for replay: %v\n", event.Type, err)
+		return
+	}
+
+	at := event.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	replayEvent := &models.ReplayEvent{
+		SessionID: event.SessionID,
+		Type:      event.Type,
+		PlayerID:  event.PlayerID,
+		Data:      data,
+		At:        at,
+	}
+
+	select {
+	case r.pending <- replayEvent:
+	default:
+		r.droppedEvents.Inc()
+		log.Printf("Replay buffer full, dropping %s event for session %s", event.Type, event.SessionID)
+	}
+}
+
+// Start writes recorded events until the context is cancelled, then flushes what is buffered
+func (r *ReplayServiceImpl) Start(ctx context.Context) {
+	for {
+		select {
+		case event := <-r.pending:
+			r.write(event)
+		case <-ctx.Done():
+			for {
+				select {
+				case event := <-r.pending:
+					r.write(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// write appends one event to the replay log
+func (r *ReplayServiceImpl) write(event *models.ReplayEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), replayWriteTimeout)
+	defer cancel()
+
+	if err := r.replayRepo.Append(ctx, event); err != nil {
+		fmt.Printf("Warning: failed to record replay event: %v\n", err)
+	}
+}
+
+// GetReplay returns one page of a completed session's replay log, oldest first
+func (r *ReplayServiceImpl) GetReplay(ctx context.Context, sessionID string, page, pageSize int) (*models.ReplayPage, error) {
+	if err := r.requireCompleted(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultReplayPageSize
+	}
+	if pageSize > MaxReplayPageSize {
+		pageSize = MaxReplayPageSize
+	}
+
+	events, total, err := r.replayRepo.List(ctx, sessionID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replay: %w", err)
+	}
+
+	return &models.ReplayPage{
+		SessionID: sessionID,
+		Events:    events,
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+	}, nil
+}
+
+// StreamReplay sends a completed session's events in order, spaced as they originally happened
+// divided by speed. It stops early when send fails or the context is cancelled.
+func (r *ReplayServiceImpl) StreamReplay(ctx context.Context, sessionID string, speed float64, send func(*models.ReplayEvent) error) error {
+	if err := r.requireCompleted(ctx, sessionID); err != nil {
+		return err
+	}
+
+	speed = clampReplaySpeed(speed)
+
+	var previous time.Time
+	for offset := 0; ; offset += MaxReplayPageSize {
+		events, _, err := r.replayRepo.List(ctx, sessionID, offset, MaxReplayPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get replay: %w", err)
+		}
+
+		for _, event := range events {
+			if !previous.IsZero() {
+				if err := waitReplayGap(ctx, replayDelay(event.At.Sub(previous), speed)); err != nil {
+					return err
+				}
+			}
+			previous = event.At
+
+			if err := send(event); err != nil {
+				return fmt.Errorf("failed to send replay event: %w", err)
+			}
+		}
+
+		if len(events) < MaxReplayPageSize {
+			return nil
+		}
+	}
+}
+
+// requireCompleted checks that the session exists and its game is over
+func (r *ReplayServiceImpl) requireCompleted(ctx context.Context, sessionID string) error {
+	session, err := r.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return ErrReplayNotFound
+	}
+	if session.Status != models.GameStatusCompleted {
+		return ErrReplayUnavailable
+	}
+	return nil
+}
+
+// clampReplaySpeed keeps a requested playback speed within the supported range
+func clampReplaySpeed(speed float64) float64 {
+	switch {
+	case speed <= 0:
+		return DefaultReplaySpeed
+	case speed < MinReplaySpeed:
+		return MinReplaySpeed
+	case speed > MaxReplaySpeed:
+		return MaxReplaySpeed
+	}
+	return speed
+}
+
+// replayDelay is how long to wait between two events at the given speed
+func replayDelay(gap time.Duration, speed float64) time.Duration {
+	if gap < 0 {
+		gap = 0
+	}
+	if gap > maxReplayGap {
+		gap = maxReplayGap
+	}
+	return time.Duration(float64(gap) / speed)
+}
+
+// waitReplayGap sleeps for the delay unless the context is cancelled first
+func waitReplayGap(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
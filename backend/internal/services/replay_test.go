@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MockReplayRepository is an in-memory ReplayRepository for testing
+type MockReplayRepository struct {
+	mu     sync.Mutex
+	events []*models.ReplayEvent
+}
+
+func (m *MockReplayRepository) Append(ctx context.Context, event *models.ReplayEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	event.Sequence = int64(len(m.events) + 1)
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *MockReplayRepository) List(ctx context.Context, sessionID string, offset, limit int) ([]*models.ReplayEvent, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []*models.ReplayEvent
+	for _, event := range m.events {
+		if event.SessionID == sessionID {
+			matched = append(matched, event)
+		}
+	}
+	total := int64(len(matched))
+	if offset >= len(matched) {
+		return []*models.ReplayEvent{}, total, nil
+	}
+	matched = matched[offset:]
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total, nil
+}
+
+func (m *MockReplayRepository) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.events)
+}
+
+func newTestReplay(status models.GameStatus) (ReplayService, *MockReplayRepository) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = &models.GameSession{SessionID: "s1", Status: status}
+	replayRepo := &MockReplayRepository{}
+	return NewReplayService(replayRepo, gameSessionRepo, 16), replayRepo
+}
+
+func TestReplay_RecordsPublicEventsInOrder(t *testing.T) {
+	replay, replayRepo := newTestReplay(models.GameStatusCompleted)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := time.Now()
+	replay.Record(WebSocketEvent{Type: "door-presented", SessionID: "s1", PlayerID: "p1", Data: map[string]interface{}{"doorId": "door-1"}, Timestamp: started})
+	replay.Record(WebSocketEvent{Type: "draft-saved", SessionID: "s1", PlayerID: "p1", Timestamp: started})
+	replay.Record(WebSocketEvent{Type: "scores-updated", SessionID: "s1", Timestamp: started.Add(time.Millisecond)})
+
+	cancel()
+	replay.Start(ctx) // flushes the buffer and returns
+
+	page, err := replay.GetReplay(context.Background(), "s1", 1, 10)
+	if err != nil {
+		t.Fatalf("GetReplay failed: %v", err)
+	}
+	if page.Total != 2 || page.Events[0].Type != "door-presented" || page.Events[1].Type != "scores-updated" {
+		t.Fatalf("Expected the two public events in order, got %d events", replayRepo.count())
+	}
+	if string(page.Events[0].Data) != `{"doorId":"door-1"}` {
+		t.Errorf("Expected the event data to be kept, got %s", page.Events[0].Data)
+	}
+
+	page, _ = replay.GetReplay(context.Background(), "s1", 2, 1)
+	if len(page.Events) != 1 || page.Events[0].Sequence != 2 {
+		t.Errorf("Expected the second page to hold the second event, got %+v", page.Events)
+	}
+}
+
+func TestReplay_UnavailableUntilCompleted(t *testing.T) {
+	replay, _ := newTestReplay(models.GameStatusActive)
+
+	if _, err := replay.GetReplay(context.Background(), "s1", 1, 10); !errors.Is(err, ErrReplayUnavailable) {
+		t.Errorf("Expected ErrReplayUnavailable, got %v", err)
+	}
+	if _, err := replay.GetReplay(context.Background(), "missing", 1, 10); !errors.Is(err, ErrReplayNotFound) {
+		t.Errorf("Expected ErrReplayNotFound, got %v", err)
+	}
+}
+
+func TestReplay_StreamPacesEventsBySpeed(t *testing.T) {
+	replay, replayRepo := newTestReplay(models.GameStatusCompleted)
+	started := time.Now()
+	for _, gap := range []time.Duration{0, 40 * time.Millisecond, 80 * time.Millisecond} {
+		replayRepo.Append(context.Background(), &models.ReplayEvent{SessionID: "s1", Type: "scores-updated", At: started.Add(gap), Data: []byte(`{}`)})
+	}
+
+	var sequences []int64
+	begin := time.Now()
+	err := replay.StreamReplay(context.Background(), "s1", 2, func(event *models.ReplayEvent) error {
+		sequences = append(sequences, event.Sequence)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamReplay failed: %v", err)
+	}
+	if len(sequences) != 3 || sequences[0] != 1 || sequences[2] != 3 {
+		t.Errorf("Expected all events in order, got %v", sequences)
+	}
+	if elapsed := time.Since(begin); elapsed < 35*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Expected 80ms of events to play in about 40ms at double speed, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = replay.StreamReplay(ctx, "s1", 1, func(event *models.ReplayEvent) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled stream to stop, got %v", err)
+	}
+}
+
+func TestClampReplaySpeed(t *testing.T) {
+	cases := map[float64]float64{0: DefaultReplaySpeed, -1: DefaultReplaySpeed, 0.1: MinReplaySpeed, 4: 4, 100: MaxReplaySpeed}
+	for speed, want := range cases {
+		if got := clampReplaySpeed(speed); got != want {
+			t.Errorf("clampReplaySpeed(%v) = %v, want %v", speed, got, want)
+		}
+	}
+}
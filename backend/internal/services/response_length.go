@@ -0,0 +1,85 @@
+package services
+
+import (
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+
+	"github.com/rivo/uniseg"
+)
+
+// MaxResponseLength is the longest response a player may submit, in characters (requirement 2.4)
+const MaxResponseLength = 500
+
+// MaxExtendedResponseLength is the most a player's own options can raise their character limit to
+const MaxExtendedResponseLength = 1000
+
+// Bounds of the character limit a session can be created with
+const (
+	MinSessionResponseLength = 100
+	MaxSessionResponseLength = 2000
+)
+
+// ErrResponseTooLong is returned for responses over the player's character limit
+var ErrResponseTooLong = errors.New("response is too long")
+
+// ResponseLengthError reports a response over its character limit and by how much
+type ResponseLengthError struct {
+	Limit     int
+	Remaining int // negative: how many characters over the limit the response is
+}
+
+func (e *ResponseLengthError) Error() string {
+	return fmt.Sprintf("response exceeds %d character limit by %d", e.Limit, -e.Remaining)
+}
+
+func (e *ResponseLengthError) Unwrap() error {
+	return ErrResponseTooLong
+}
+
+// ResponseLength counts a response in user-perceived characters: grapheme clusters, so an
+// emoji with modifiers or a letter with combining marks counts once, whatever its byte size
+func ResponseLength(response string) int {
+	return uniseg.GraphemeClusterCount(response)
+}
+
+// SessionResponseLimit returns the session's character limit: MaxResponseLength unless it was
+// created with its own
+func SessionResponseLimit(session *models.GameSession) int {
+	if session.Settings.MaxLength > 0 {
+		return session.Settings.MaxLength
+	}
+	return MaxResponseLength
+}
+
+// ResponseLimit returns the player's character limit: the session's, unless the player's
+// options raise it
+func ResponseLimit(session *models.GameSession, playerID string) int {
+	limit := SessionResponseLimit(session)
+	if player := sessionPlayer(session, playerID); player != nil && player.Options != nil && player.Options.MaxResponseLength > limit {
+		return player.Options.MaxResponseLength
+	}
+	return limit
+}
+
+// ValidateResponseLength checks a response against MaxResponseLength and returns how many
+// characters remain; the remainder is negative when the response is too long
+func ValidateResponseLength(response string) (int, error) {
+	return ValidateResponseLimit(response, MaxResponseLength)
+}
+
+// ValidateResponseLimit checks a response against the given character limit and returns how
+// many characters remain; the remainder is negative when the response is too long
+func ValidateResponseLimit(response string, limit int) (int, error) {
+	length := ResponseLength(response)
+	remaining := limit - length
+
+	if length == 0 {
+		return remaining, fmt.Errorf("response cannot be empty")
+	}
+	if remaining < 0 {
+		return remaining, &ResponseLengthError{Limit: limit, Remaining: remaining}
+	}
+
+	return remaining, nil
+}
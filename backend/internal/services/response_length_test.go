@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResponseLength_CountsCharactersNotBytes(t *testing.T) {
+	tests := map[string]int{
+		"hello":        5,
+		"¿Qué pasó?":   10,
+		"日本語":          3,
+		"👍🏽":           1, // thumbs up with a skin tone modifier
+		"👨‍👩‍👧":        1, // family joined with zero-width joiners
+		"e\u0301clair": 6, // e + combining acute accent
+	}
+
+	for response, expected := range tests {
+		if got := ResponseLength(response); got != expected {
+			t.Errorf("%q: expected %d characters, got %d", response, expected, got)
+		}
+	}
+}
+
+func TestValidateResponseLength(t *testing.T) {
+	// 500 emoji are well over 500 bytes but exactly at the limit
+	remaining, err := ValidateResponseLength(strings.Repeat("🚪", MaxResponseLength))
+	if err != nil || remaining != 0 {
+		t.Errorf("Expected 500 emoji to be accepted with 0 remaining, got %d, %v", remaining, err)
+	}
+
+	remaining, err = ValidateResponseLength(strings.Repeat("a", MaxResponseLength+3))
+	if err == nil || remaining != -3 {
+		t.Errorf("Expected an error 3 characters over, got %d, %v", remaining, err)
+	}
+
+	if _, err := ValidateResponseLength(""); err == nil {
+		t.Error("Expected an empty response to be rejected")
+	}
+}
+
+func TestResponseLimit_SessionLimitAndPlayerOptions(t *testing.T) {
+	session := newPausableSession(time.Now().Add(time.Minute))
+	if limit := ResponseLimit(session, "p1"); limit != MaxResponseLength {
+		t.Errorf("Expected the standard limit without a session limit, got %d", limit)
+	}
+
+	session.Settings.MaxLength = 1500
+	session.Players[1].Options = &models.PlayerOptions{MaxResponseLength: 800}
+	if limit := ResponseLimit(session, "p2"); limit != 1500 {
+		t.Errorf("Expected the session's higher limit to win over the player's, got %d", limit)
+	}
+
+	session.Settings.MaxLength = 200
+	if limit := ResponseLimit(session, "p1"); limit != 200 {
+		t.Errorf("Expected the session's limit, got %d", limit)
+	}
+	if limit := ResponseLimit(session, "p2"); limit != 800 {
+		t.Errorf("Expected the player's raised limit, got %d", limit)
+	}
+	if limits := playerLimits(session); len(limits) != 1 || limits["p2"] != 800 {
+		t.Errorf("Expected only p2's raised limit to be announced, got %v", limits)
+	}
+}
+
+func TestSessionResponseLimit_ValidatedAndEnforced(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	)
+
+	for _, limit := range []int{MinSessionResponseLength - 1, MaxSessionResponseLength + 1} {
+		if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", models.SessionSettings{MaxLength: limit}); !errors.Is(err, ErrInvalidSessionSettings) {
+			t.Errorf("Expected a %d character limit to be refused, got %v", limit, err)
+		}
+	}
+
+	session := newDraftSession()
+	session.Settings.MaxLength = 100
+	gameSessionRepo.sessions["s1"] = session
+
+	var tooLong *ResponseLengthError
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p1", strings.Repeat("a", 101)); !errors.As(err, &tooLong) || tooLong.Limit != 100 || tooLong.Remaining != -1 {
+		t.Fatalf("Expected the session's 100 character limit, got %v", err)
+	}
+	submitted, err := gameService.SubmitResponse(ctx, "s1", "p1", strings.Repeat("a", 100))
+	if err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	if submitted.CharacterLimit != 100 {
+		t.Errorf("Expected the response to record the session's limit, got %d", submitted.CharacterLimit)
+	}
+}
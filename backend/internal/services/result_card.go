@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrSummaryUnavailable is returned when a result card is requested before the game has ended
+var ErrSummaryUnavailable = errors.New("the game summary is available once the game has completed")
+
+// ResultCardService builds the shareable summary of a completed game
+type ResultCardService interface {
+	GetResultCard(ctx context.Context, sessionID string) (*models.ResultCard, error)
+}
+
+// ResultCardServiceImpl implements the ResultCardService interface
+type ResultCardServiceImpl struct {
+	gameSessionRepo repositories.GameSessionRepository
+	doorRepo        repositories.DoorRepository
+	revealDisabled  bool
+}
+
+// NewResultCardService creates a new result card service. With reveal disabled, cards never
+// include what anyone wrote, whatever the session's own reveal setting.
+func NewResultCardService(gameSessionRepo repositories.GameSessionRepository, doorRepo repositories.DoorRepository, revealEnabled bool) ResultCardService {
+	return &ResultCardServiceImpl{
+		gameSessionRepo: gameSessionRepo,
+		doorRepo:        doorRepo,
+		revealDisabled:  !revealEnabled,
+	}
+}
+
+// GetResultCard summarizes a completed game: its winner, the best response to each door, the
+// best response overall and the door players scored worst on
+func (s *ResultCardServiceImpl) GetResultCard(ctx context.Context, sessionID string) (*models.ResultCard, error) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if session.Status != models.GameStatusCompleted {
+		return nil, ErrSummaryUnavailable
+	}
+
+	card := buildResultCard(session, !s.revealDisabled && session.Settings.RevealsResponses())
+	s.addDoorContent(ctx, session, card)
+	return card, nil
+}
+
+// addDoorContent fills in the text of each door on the card, in the session's language where
+// the door has a translation. Doors that can't be loaded are shown by ID only.
+func (s *ResultCardServiceImpl) addDoorContent(ctx context.Context, session *models.GameSession, card *models.ResultCard) {
+	if s.doorRepo == nil {
+		return
+	}
+
+	contents := make(map[string]string)
+	content := func(doorID string) string {
+		if text, exists := contents[doorID]; exists {
+			return text
+		}
+		door, err := s.doorRepo.GetByID(ctx, doorID)
+		if err != nil || door == nil {
+			fmt.Printf("Warning: failed to load door %s for result card: %v\n", doorID, err)
+			contents[doorID] = ""
+			return ""
+		}
+		if localized, ok := door.InLocale(session.Locale); ok {
+			door = localized
+		}
+		contents[doorID] = door.Content
+		return door.Content
+	}
+
+	for i := range card.BestResponses {
+		card.BestResponses[i].DoorContent = content(card.BestResponses[i].DoorID)
+	}
+	if card.FunniestAnswer != nil {
+		card.FunniestAnswer.DoorContent = content(card.FunniestAnswer.DoorID)
+	}
+	if card.HardestDoor != nil {
+		card.HardestDoor.Content = content(card.HardestDoor.DoorID)
+	}
+}
+
+// doorTally collects the scored responses to one door
+type doorTally struct {
+	doorID     string
+	best       *models.PlayerResponse
+	bestAuthor *models.PlayerInfo
+	total      int
+	count      int
+	firstAt    time.Time
+}
+
+// buildResultCard computes a completed session's result card. Response text is only included
+// when showContent is set, and authors only when the session reveals answers attributed.
+func buildResultCard(session *models.GameSession, showContent bool) *models.ResultCard {
+	card := &models.ResultCard{
+		SessionID:     session.SessionID,
+		Mode:          session.Mode,
+		Theme:         session.Theme,
+		PlayerCount:   len(session.Players),
+		CompletedAt:   session.CompletedAt,
+		BestResponses: []models.ResponseHighlight{},
+	}
+
+	tallies := make(map[string]*doorTally)
+	var order []*doorTally
+	for i := range session.Players {
+		player := &session.Players[i]
+		if player.PlayerID == session.WinnerID {
+			card.Winner = &models.ResultCardPlayer{PlayerID: player.PlayerID, Username: player.Username, TotalScore: player.TotalScore}
+		}
+
+		for j := range player.Responses {
+			response := &player.Responses[j]
+			if response.ScoringPending {
+				continue
+			}
+
+			tally, exists := tallies[response.DoorID]
+			if !exists {
+				tally = &doorTally{doorID: response.DoorID, firstAt: response.SubmittedAt}
+				tallies[response.DoorID] = tally
+				order = append(order, tally)
+			}
+			if response.SubmittedAt.Before(tally.firstAt) {
+				tally.firstAt = response.SubmittedAt
+			}
+			tally.total += response.AIScore
+			tally.count++
+			if tally.best == nil || outscores(response, tally.best) {
+				tally.best, tally.bestAuthor = response, player
+			}
+		}
+	}
+	card.DoorCount = len(order)
+
+	// Doors are listed in the order they came up, judged by when they were first answered
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].firstAt.Before(order[j].firstAt)
+	})
+
+	attributed := session.Settings.RevealMode == models.RevealAttributed
+	funniest, hardest := -1, math.Inf(1)
+	for i, tally := range order {
+		highlight := models.ResponseHighlight{
+			DoorID:     tally.doorID,
+			ResponseID: tally.best.ResponseID,
+			Score:      tally.best.AIScore,
+		}
+		if showContent {
+			highlight.Content = tally.best.Content
+		}
+		if attributed {
+			highlight.PlayerID = tally.bestAuthor.PlayerID
+			highlight.Username = tally.bestAuthor.Username
+		}
+		card.BestResponses = append(card.BestResponses, highlight)
+
+		if funniest < 0 || outscores(tally.best, order[funniest].best) {
+			funniest = i
+		}
+
+		average := float64(tally.total) / float64(tally.count)
+		if average < hardest {
+			hardest = average
+			card.HardestDoor = &models.DoorHighlight{
+				DoorID:        tally.doorID,
+				AverageScore:  math.Round(average*10) / 10,
+				ResponseCount: tally.count,
+			}
+		}
+	}
+
+	if funniest >= 0 {
+		funniestAnswer := card.BestResponses[funniest]
+		card.FunniestAnswer = &funniestAnswer
+	}
+	return card
+}
+
+// outscores reports whether a response beats another for a highlight: the higher score wins,
+// and the earlier submission breaks a tie
+func outscores(a, b *models.PlayerResponse) bool {
+	if a.AIScore != b.AIScore {
+		return a.AIScore > b.AIScore
+	}
+	return a.SubmittedAt.Before(b.SubmittedAt)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newResultCardSession returns a finished two-door game that alice won
+func newResultCardSession(mode models.RevealMode) *models.GameSession {
+	start := time.Now().Add(-time.Hour)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	return &models.GameSession{
+		SessionID: "s1",
+		Mode:      models.GameModeMultiplayer,
+		Status:    models.GameStatusCompleted,
+		WinnerID:  "p1",
+		Settings:  models.SessionSettings{RevealMode: mode},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", Username: "alice", TotalScore: 150, Responses: []models.PlayerResponse{
+				{ResponseID: "r1", DoorID: "door-1", PlayerID: "p1", Content: "Knock politely", AIScore: 60, SubmittedAt: at(1)},
+				{ResponseID: "r3", DoorID: "door-2", PlayerID: "p1", Content: "Climb the wall", AIScore: 90, SubmittedAt: at(5)},
+			}},
+			{PlayerID: "p2", Username: "bob", TotalScore: 100, Responses: []models.PlayerResponse{
+				{ResponseID: "r2", DoorID: "door-1", PlayerID: "p2", Content: "Kick it down", AIScore: 80, SubmittedAt: at(2)},
+				{ResponseID: "r4", DoorID: "door-2", PlayerID: "p2", Content: "Give up", AIScore: 20, SubmittedAt: at(6)},
+			}},
+		},
+	}
+}
+
+func TestResultCard_Highlights(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newResultCardSession(models.RevealAttributed)
+	doorRepo := &MockDoorRepository{doors: []*models.Door{
+		{DoorID: "door-1", Content: "A locked door"},
+		{DoorID: "door-2", Content: "A tall wall"},
+	}}
+
+	card, err := NewResultCardService(gameSessionRepo, doorRepo, true).GetResultCard(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("GetResultCard failed: %v", err)
+	}
+
+	if card.Winner == nil || card.Winner.Username != "alice" || card.Winner.TotalScore != 150 {
+		t.Errorf("Expected alice to win with 150 points, got %+v", card.Winner)
+	}
+	if len(card.BestResponses) != 2 || card.BestResponses[0].ResponseID != "r2" || card.BestResponses[1].ResponseID != "r3" {
+		t.Fatalf("Expected the best response to each door in play order, got %+v", card.BestResponses)
+	}
+	if best := card.BestResponses[0]; best.Username != "bob" || best.Content != "Kick it down" || best.DoorContent != "A locked door" {
+		t.Errorf("Expected bob's attributed answer to the locked door, got %+v", best)
+	}
+	if card.FunniestAnswer == nil || card.FunniestAnswer.ResponseID != "r3" {
+		t.Errorf("Expected the 90-point answer to be the funniest, got %+v", card.FunniestAnswer)
+	}
+	if card.HardestDoor == nil || card.HardestDoor.DoorID != "door-2" || card.HardestDoor.AverageScore != 55 || card.HardestDoor.Content != "A tall wall" {
+		t.Errorf("Expected door-2 to be the hardest with an average of 55, got %+v", card.HardestDoor)
+	}
+}
+
+func TestResultCard_RespectsRevealSettings(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newResultCardSession(models.RevealAnonymous)
+	gameSessionRepo.sessions["s2"] = newResultCardSession(models.RevealOff)
+	gameSessionRepo.sessions["s2"].SessionID = "s2"
+	service := NewResultCardService(gameSessionRepo, nil, true)
+
+	anonymous, err := service.GetResultCard(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("GetResultCard failed: %v", err)
+	}
+	if best := anonymous.FunniestAnswer; best.Content == "" || best.PlayerID != "" || best.Username != "" {
+		t.Errorf("Expected an anonymous session's card to show answers without authors, got %+v", best)
+	}
+
+	private, err := service.GetResultCard(context.Background(), "s2")
+	if err != nil {
+		t.Fatalf("GetResultCard failed: %v", err)
+	}
+	if best := private.FunniestAnswer; best.Content != "" || best.Score != 90 {
+		t.Errorf("Expected a private session's card to show scores but not answers, got %+v", best)
+	}
+
+	disabled, err := NewResultCardService(gameSessionRepo, nil, false).GetResultCard(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("GetResultCard failed: %v", err)
+	}
+	if disabled.FunniestAnswer.Content != "" {
+		t.Errorf("Expected the server switch to keep answers off the card, got %+v", disabled.FunniestAnswer)
+	}
+}
+
+func TestResultCard_RequiresCompletedGame(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newResultCardSession("")
+	session.Status = models.GameStatusActive
+	gameSessionRepo.sessions["s1"] = session
+	service := NewResultCardService(gameSessionRepo, nil, true)
+
+	if _, err := service.GetResultCard(context.Background(), "s1"); !errors.Is(err, ErrSummaryUnavailable) {
+		t.Errorf("Expected ErrSummaryUnavailable for a game in progress, got %v", err)
+	}
+	if _, err := service.GetResultCard(context.Background(), "missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+	"time"
+)
+
+// SessionResume is everything a reconnecting client needs to rebuild its game screen
+type SessionResume struct {
+	Session              *models.GameSession     `json:"session"`
+	CurrentDoor          *models.Door            `json:"currentDoor,omitempty"`
+	HasResponded         bool                    `json:"hasResponded"`
+	Deadline             *time.Time              `json:"deadline,omitempty"`
+	TimeRemainingSeconds int                     `json:"timeRemainingSeconds"`
+	Responses            []models.PlayerResponse `json:"responses"`
+	Progress             *SessionProgress        `json:"progress,omitempty"`
+}
+
+// ResumeSession returns the player's view of a session in progress: the door they are
+// answering, how long they have left, what they have answered so far and live progress
+func (s *GameServiceImpl) ResumeSession(ctx context.Context, sessionID, playerID string) (*SessionResume, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.ResumeSession", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(playerID))
+	defer span.End()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	var player *models.PlayerInfo
+	for i := range session.Players {
+		if session.Players[i].PlayerID == playerID {
+			player = &session.Players[i]
+			break
+		}
+	}
+
+	if player == nil {
+		return nil, ErrPlayerNotInSession
+	}
+
+	resume := &SessionResume{
+		Session:   session.ForPlayer(playerID),
+		Responses: player.Responses,
+	}
+	if resume.Responses == nil {
+		resume.Responses = []models.PlayerResponse{}
+	}
+
+	// Only an open round has a door to answer and a clock running, frozen while paused
+	if session.Status == models.GameStatusActive || session.Status == models.GameStatusPaused {
+		resume.CurrentDoor = session.DoorForPlayer(playerID)
+		if resume.CurrentDoor != nil {
+			resume.HasResponded = hasRespondedToDoor(session, playerID, resume.CurrentDoor.DoorID)
+		}
+
+		if session.RoundDeadline != nil {
+			resume.Deadline = session.RoundDeadline
+			resume.TimeRemainingSeconds = int(session.TimeRemaining(time.Now()).Round(time.Second).Seconds())
+		}
+	}
+
+	if s.progressService != nil {
+		progress, err := s.progressService.CalculateSessionProgress(ctx, sessionID)
+		if err != nil {
+			fmt.Printf("Warning: failed to calculate progress for resume: %v\n", err)
+		} else {
+			resume.Progress = progress
+		}
+	}
+
+	return resume, nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestResumeSession_RestoresOpenRound(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newDraftSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if err := gameService.PresentDoorToSession(ctx, "s1", &models.Door{DoorID: "door-2"}); err != nil {
+		t.Fatalf("PresentDoorToSession failed: %v", err)
+	}
+	session := gameSessionRepo.sessions["s1"]
+	session.Players[0].Responses = []models.PlayerResponse{{DoorID: "door-1", AIScore: 80}}
+
+	resume, err := gameService.ResumeSession(ctx, "s1", "p1")
+	if err != nil {
+		t.Fatalf("ResumeSession failed: %v", err)
+	}
+
+	if resume.CurrentDoor == nil || resume.CurrentDoor.DoorID != "door-2" || resume.HasResponded {
+		t.Errorf("Expected door-2 to be waiting for an answer, got %+v (responded: %v)", resume.CurrentDoor, resume.HasResponded)
+	}
+	limit := int(ResponseTimeLimit.Seconds())
+	if resume.TimeRemainingSeconds <= limit-2 || resume.TimeRemainingSeconds > limit {
+		t.Errorf("Expected about %d seconds remaining, got %d", limit, resume.TimeRemainingSeconds)
+	}
+	if len(resume.Responses) != 1 || resume.Responses[0].AIScore != 80 {
+		t.Errorf("Expected the player's earlier response, got %+v", resume.Responses)
+	}
+}
+
+func TestResumeSession_NoClockOutsideOpenRound(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Status = models.GameStatusRevealing
+	expired := time.Now().Add(-time.Second)
+	session.RoundDeadline = &expired
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	resume, err := gameService.ResumeSession(ctx, "s1", "p2")
+	if err != nil {
+		t.Fatalf("ResumeSession failed: %v", err)
+	}
+	if resume.CurrentDoor != nil || resume.TimeRemainingSeconds != 0 {
+		t.Errorf("Expected no door or clock while scores are revealed, got %+v", resume)
+	}
+
+	if _, err := gameService.ResumeSession(ctx, "s1", "stranger"); err == nil {
+		t.Error("Expected resuming as a non-player to fail")
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"fmt"
+	"time"
+)
+
+// EventResponsesRevealed shows the players what everyone answered in a scored round
+const EventResponsesRevealed = "responses-revealed"
+
+// WithResponseReveal turns the post-round reveal of everyone's answers on or off for every
+// session, overriding the sessions' own reveal setting when off
+func WithResponseReveal(enabled bool) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.revealDisabled = !enabled
+	}
+}
+
+// revealResponses broadcasts a scored round's responses with their scores. Anonymous reveals
+// are shuffled and carry no player IDs, so the order doesn't give away who wrote what; players
+// can still spot their own answer by its response ID.
+func (s *GameServiceImpl) revealResponses(session *models.GameSession) {
+	if s.revealDisabled || !session.Settings.RevealsResponses() {
+		return
+	}
+	responses := roundResponses(session)
+	if len(responses) == 0 {
+		return
+	}
+
+	attributed := session.Settings.RevealMode == models.RevealAttributed
+	usernames := make(map[string]string, len(session.Players))
+	for _, player := range session.Players {
+		usernames[player.PlayerID] = player.Username
+	}
+
+	revealed := make([]map[string]interface{}, 0, len(responses))
+	for _, response := range responses {
+		entry := map[string]interface{}{
+			"responseId": response.ResponseID,
+			"doorId":     response.DoorID,
+			"content":    response.Content,
+			"score":      response.AIScore,
+		}
+		if attributed {
+			entry["playerId"] = response.PlayerID
+			entry["username"] = usernames[response.PlayerID]
+		}
+		revealed = append(revealed, entry)
+	}
+	if !attributed {
+		for i := len(revealed) - 1; i > 0; i-- {
+			j := random.Intn(i + 1)
+			revealed[i], revealed[j] = revealed[j], revealed[i]
+		}
+	}
+
+	event := WebSocketEvent{
+		Type:      EventResponsesRevealed,
+		SessionID: session.SessionID,
+		Data: systemMessage(map[string]interface{}{
+			"responses":  revealed,
+			"attributed": attributed,
+		}, session.Locale, i18n.MsgRevealed),
+		Timestamp: time.Now(),
+	}
+	if err := s.wsManager.BroadcastToSession(session.SessionID, event); err != nil {
+		fmt.Printf("Warning: failed to reveal responses: %v\n", err)
+	}
+}
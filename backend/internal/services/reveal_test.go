@@ -0,0 +1,68 @@
+package services
+
+import (
+	"dumdoors-backend/internal/models"
+	"testing"
+)
+
+// newRevealSession returns a scored round in which p1 and p2 answered door-1
+func newRevealSession(mode models.RevealMode) *models.GameSession {
+	session := newBatchedScoringSession()
+	session.Status = models.GameStatusScoring
+	session.Settings.RevealMode = mode
+	session.Players[0].Username = "alice"
+	session.Players[0].Responses = []models.PlayerResponse{{ResponseID: "r1", DoorID: "door-1", PlayerID: "p1", Content: "Knock", AIScore: 40}}
+	session.Players[1].Username = "bob"
+	session.Players[1].Responses = []models.PlayerResponse{{ResponseID: "r2", DoorID: "door-1", PlayerID: "p2", Content: "Pick the lock", AIScore: 70}}
+	return session
+}
+
+// revealedResponses returns the responses in the reveal broadcast, or nil if none was sent
+func revealedResponses(t *testing.T, mode models.RevealMode, opts ...GameServiceOption) []map[string]interface{} {
+	t.Helper()
+
+	wsManager := &broadcastRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), wsManager, &MockAIClient{}, nil, nil, opts...)
+	gameService.(*GameServiceImpl).revealResponses(newRevealSession(mode))
+
+	for _, event := range wsManager.broadcasts {
+		if event.Type == EventResponsesRevealed {
+			return event.Data.(map[string]interface{})["responses"].([]map[string]interface{})
+		}
+	}
+	return nil
+}
+
+func TestRevealResponses_AnonymousByDefault(t *testing.T) {
+	revealed := revealedResponses(t, "")
+	if len(revealed) != 2 {
+		t.Fatalf("Expected both answers to be revealed, got %v", revealed)
+	}
+	for _, response := range revealed {
+		if _, exists := response["playerId"]; exists {
+			t.Errorf("Expected an anonymous reveal, got %v", response)
+		}
+		if response["content"] == nil || response["score"] == nil {
+			t.Errorf("Expected each answer with its score, got %v", response)
+		}
+	}
+}
+
+func TestRevealResponses_Attributed(t *testing.T) {
+	revealed := revealedResponses(t, models.RevealAttributed)
+	if len(revealed) != 2 {
+		t.Fatalf("Expected both answers to be revealed, got %v", revealed)
+	}
+	if revealed[0]["playerId"] != "p1" || revealed[0]["username"] != "alice" || revealed[0]["score"] != 40 {
+		t.Errorf("Expected alice's answer to be attributed to her, got %v", revealed[0])
+	}
+}
+
+func TestRevealResponses_Disabled(t *testing.T) {
+	if revealed := revealedResponses(t, models.RevealOff); revealed != nil {
+		t.Errorf("Expected a session with reveal off to keep answers hidden, got %v", revealed)
+	}
+	if revealed := revealedResponses(t, models.RevealAttributed, WithResponseReveal(false)); revealed != nil {
+		t.Errorf("Expected the server switch to override the session, got %v", revealed)
+	}
+}
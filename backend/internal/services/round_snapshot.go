@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+	"time"
+)
+
+// Round snapshot defaults
+const (
+	DefaultRoundSnapshotInterval = 5 * time.Second
+	roundSnapshotStaleAfter      = 3 // missed snapshot intervals before a round counts as abandoned
+	roundRecoveryBatchSize       = 100
+)
+
+// RoundSnapshotService periodically snapshots the rounds in play on this instance and rebuilds
+// the rounds of instances that died, so games survive a crash or deploy
+type RoundSnapshotService interface {
+	Snapshot(ctx context.Context) error
+	Recover(ctx context.Context) (int, error)
+	Start(ctx context.Context)
+}
+
+// RoundSnapshotServiceImpl implements RoundSnapshotService on top of a shared snapshot store
+type RoundSnapshotServiceImpl struct {
+	store       repositories.RoundSnapshotStore
+	gameService GameService
+	wsManager   WebSocketManager
+	instanceID  string
+	interval    time.Duration
+	now         func() time.Time
+
+	snapshotted map[string]bool // sessions whose snapshot this instance keeps fresh
+}
+
+// NewRoundSnapshotService creates a service snapshotting the rounds of sessions with players
+// connected to this instance every interval
+func NewRoundSnapshotService(store repositories.RoundSnapshotStore, gameService GameService, wsManager WebSocketManager, instanceID string, interval time.Duration) RoundSnapshotService {
+	if interval <= 0 {
+		interval = DefaultRoundSnapshotInterval
+	}
+	return &RoundSnapshotServiceImpl{
+		store:       store,
+		gameService: gameService,
+		wsManager:   wsManager,
+		instanceID:  instanceID,
+		interval:    interval,
+		now:         time.Now,
+		snapshotted: make(map[string]bool),
+	}
+}
+
+// Start rebuilds abandoned rounds right away, then snapshots this instance's rounds and looks
+// for rounds abandoned by other instances every interval until the context is cancelled
+func (s *RoundSnapshotServiceImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if recovered, err := s.Recover(ctx); err != nil {
+			fmt.Printf("Warning: failed to recover rounds: %v\n", err)
+		} else if recovered > 0 {
+			fmt.Printf("Recovered %d rounds from snapshots\n", recovered)
+		}
+		if err := s.Snapshot(ctx); err != nil {
+			fmt.Printf("Warning: failed to snapshot rounds: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Snapshot saves the round of every session with players connected here, and drops the
+// snapshots this instance saved of sessions whose round ended or whose players all left it.
+// Snapshots saved since by the instance the players moved to are kept.
+func (s *RoundSnapshotServiceImpl) Snapshot(ctx context.Context) error {
+	local := make(map[string]bool)
+	for _, sessionID := range s.wsManager.LocalSessionIDs() {
+		snapshot, err := s.gameService.SnapshotRound(ctx, sessionID)
+		if err != nil {
+			fmt.Printf("Warning: failed to snapshot round of session %s: %v\n", sessionID, err)
+			continue
+		}
+		if snapshot == nil {
+			continue
+		}
+
+		snapshot.InstanceID = s.instanceID
+		snapshot.TakenAt = s.now()
+		if err := s.store.Save(ctx, snapshot); err != nil {
+			return err
+		}
+		local[sessionID] = true
+	}
+
+	for sessionID := range s.snapshotted {
+		if local[sessionID] {
+			continue
+		}
+		if err := s.store.Delete(ctx, sessionID, s.instanceID); err != nil {
+			fmt.Printf("Warning: failed to drop round snapshot of session %s: %v\n", sessionID, err)
+		}
+	}
+	s.snapshotted = local
+	return nil
+}
+
+// Recover rebuilds the rounds whose snapshots stopped being refreshed, because the instance
+// running them died. Each round is claimed first, so only one instance rebuilds it.
+func (s *RoundSnapshotServiceImpl) Recover(ctx context.Context) (int, error) {
+	before := s.now().Add(-roundSnapshotStaleAfter * s.interval)
+	recovered := 0
+
+	for {
+		snapshots, err := s.store.Stale(ctx, before, roundRecoveryBatchSize)
+		if err != nil {
+			return recovered, err
+		}
+
+		for _, snapshot := range snapshots {
+			claimed, err := s.store.Claim(ctx, snapshot.SessionID)
+			if err != nil {
+				return recovered, err
+			}
+			if !claimed {
+				continue
+			}
+
+			if err := s.gameService.RecoverRound(ctx, snapshot); err != nil {
+				fmt.Printf("Warning: failed to recover round of session %s: %v\n", snapshot.SessionID, err)
+				continue
+			}
+			recovered++
+		}
+
+		if len(snapshots) < roundRecoveryBatchSize {
+			return recovered, nil
+		}
+	}
+}
+
+// SnapshotRound captures the transient state of a session's round in play: the door each
+// player is answering, the deadlines pending for the round and the stage it has reached. It
+// returns nil for sessions without a round in play.
+func (s *GameServiceImpl) SnapshotRound(ctx context.Context, sessionID string) (*models.RoundSnapshot, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.SnapshotRound", tracing.SessionIDKey.String(sessionID))
+	defer span.End()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, nil
+	}
+	switch session.Status {
+	case models.GameStatusActive, models.GameStatusScoring, models.GameStatusRevealing:
+	default:
+		return nil, nil // Lobbies, paused and finished games have no round in play
+	}
+
+	snapshot := &models.RoundSnapshot{
+		SessionID:   sessionID,
+		Status:      session.Status,
+		PlayerDoors: make(map[string]string),
+		Deadlines:   make(map[string]time.Time),
+	}
+	keys := make([]string, 0, len(session.Players)+1)
+	for _, door := range session.CurrentDoors() {
+		keys = append(keys, door.DoorID)
+	}
+	for _, player := range session.Players {
+		if door := session.DoorForPlayer(player.PlayerID); door != nil {
+			snapshot.PlayerDoors[player.PlayerID] = door.DoorID
+		}
+		if player.DisconnectedAt != nil && !player.TimedOut {
+			keys = append(keys, disconnectGraceKey(player.PlayerID))
+		}
+	}
+	if session.VoteDeadline != nil {
+		keys = append(keys, votingDeadlineKey(session))
+	}
+
+	if len(snapshot.PlayerDoors) == 0 {
+		return nil, nil
+	}
+
+	for _, key := range keys {
+		at, pending, err := s.scheduler.Deadline(ctx, sessionID, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read deadline %s: %w", key, err)
+		}
+		if pending {
+			snapshot.Deadlines[key] = at
+		}
+	}
+
+	return snapshot, nil
+}
+
+// RecoverRound rebuilds a round from its snapshot after the instance running it died. Deadlines
+// the round was still waiting on are scheduled again, and a round that was closed, scored or
+// revealed when the instance died is carried on from where it stopped.
+func (s *GameServiceImpl) RecoverRound(ctx context.Context, snapshot *models.RoundSnapshot) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.RecoverRound", tracing.SessionIDKey.String(snapshot.SessionID))
+	defer span.End()
+
+	sessionID := snapshot.SessionID
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil
+	}
+	// Players reconnecting are routed here now, rather than to the instance that died
+	if s.routing != nil && snapshot.InstanceID != "" {
+		if err := s.routing.Takeover(ctx, sessionID, snapshot.InstanceID); err != nil {
+			fmt.Printf("Warning: failed to take over route of session %s: %v\n", sessionID, err)
+		}
+	}
+	if !sameRound(session, snapshot) {
+		return nil // Finished, or moved on since the snapshot, so nothing was lost
+	}
+
+	for key, at := range snapshot.Deadlines {
+		if playerID, ok := disconnectGracePlayer(key); ok {
+			if player := sessionPlayer(session, playerID); player == nil || player.DisconnectedAt == nil {
+				continue // Reconnected since
+			}
+		}
+		if _, pending, err := s.scheduler.Deadline(ctx, sessionID, key); err != nil || pending {
+			continue
+		}
+		if err := s.scheduler.Schedule(ctx, sessionID, key, at); err != nil {
+			return fmt.Errorf("failed to reschedule deadline %s: %w", key, err)
+		}
+	}
+	unlock()
+
+	switch session.Status {
+	case models.GameStatusActive:
+		// Everyone answered, but the round was never closed
+		if s.checkAllPlayersResponded(session) {
+			s.closeRound(ctx, sessionID, session)
+		}
+	case models.GameStatusScoring:
+		if hasPendingScores(session) {
+			s.rescorePending(ctx, session)
+			return nil
+		}
+		// Voting already open closes on its rescheduled deadline
+		if session.VoteDeadline != nil {
+			return nil
+		}
+		s.runInBackground(ctx, sessionID, "recover-reveal", func(ctx context.Context) {
+			var err error
+			if session.Settings.PeerVoting() {
+				err = s.startVoting(ctx, session)
+			} else {
+				err = s.revealRound(ctx, session)
+			}
+			if err != nil {
+				fmt.Printf("Error recovering round reveal: %v\n", err)
+			}
+		})
+	case models.GameStatusRevealing:
+		s.runInBackground(ctx, sessionID, "recover-advance", func(ctx context.Context) {
+			if err := s.advanceRound(ctx, session); err != nil {
+				fmt.Printf("Error recovering round advance: %v\n", err)
+			}
+		})
+	}
+	return nil
+}
+
+// rescorePending scores again the responses whose scores were still on their way when the
+// instance scoring them died. The last score to arrive reveals the round, as usual.
+func (s *GameServiceImpl) rescorePending(ctx context.Context, session *models.GameSession) {
+	for _, player := range session.Players {
+		door := session.DoorForPlayer(player.PlayerID)
+		if door == nil {
+			continue
+		}
+
+		for _, response := range player.Responses {
+			if !response.ScoringPending || response.DoorID != door.DoorID {
+				continue
+			}
+			deliver := s.scoreDelivery(ctx, session.SessionID, player.PlayerID, response.ResponseID, door)
+
+			if s.scoringBatcher != nil {
+				if err := s.scoringBatcher.Submit(ScoreRequest{Door: door, Response: response.Content}, deliver); err == nil {
+					continue
+				}
+			}
+			playerID, content := player.PlayerID, response.Content
+			s.runInBackground(ctx, session.SessionID, "recover-score", func(ctx context.Context) {
+				deliver(s.scoringQueue.Score(ctx, session.SessionID, playerID, door, content))
+			})
+		}
+	}
+}
+
+// sameRound reports whether a session is still on the round a snapshot was taken of
+func sameRound(session *models.GameSession, snapshot *models.RoundSnapshot) bool {
+	doors := 0
+	for _, player := range session.Players {
+		door := session.DoorForPlayer(player.PlayerID)
+		if door == nil {
+			continue
+		}
+		if snapshot.PlayerDoors[player.PlayerID] != door.DoorID {
+			return false
+		}
+		doors++
+	}
+	return doors > 0 && doors == len(snapshot.PlayerDoors)
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// MockRoundSnapshotStore keeps round snapshots in memory
+type MockRoundSnapshotStore struct {
+	snapshots map[string]*models.RoundSnapshot
+}
+
+func NewMockRoundSnapshotStore() *MockRoundSnapshotStore {
+	return &MockRoundSnapshotStore{snapshots: make(map[string]*models.RoundSnapshot)}
+}
+
+func (m *MockRoundSnapshotStore) Save(ctx context.Context, snapshot *models.RoundSnapshot) error {
+	m.snapshots[snapshot.SessionID] = snapshot
+	return nil
+}
+
+func (m *MockRoundSnapshotStore) Delete(ctx context.Context, sessionID, instanceID string) error {
+	if snapshot, ok := m.snapshots[sessionID]; ok && snapshot.InstanceID != instanceID {
+		return nil
+	}
+	delete(m.snapshots, sessionID)
+	return nil
+}
+
+func (m *MockRoundSnapshotStore) Stale(ctx context.Context, before time.Time, limit int) ([]*models.RoundSnapshot, error) {
+	var stale []*models.RoundSnapshot
+	for _, snapshot := range m.snapshots {
+		if !snapshot.TakenAt.After(before) && len(stale) < limit {
+			stale = append(stale, snapshot)
+		}
+	}
+	return stale, nil
+}
+
+func (m *MockRoundSnapshotStore) Claim(ctx context.Context, sessionID string) (bool, error) {
+	if _, ok := m.snapshots[sessionID]; !ok {
+		return false, nil
+	}
+	delete(m.snapshots, sessionID)
+	return true, nil
+}
+
+func TestRoundSnapshot_RebuildsDeadlinesOfDeadInstance(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(time.Minute))
+	gameSessionRepo.sessions["s1"] = session
+	scheduler := NewPersistentScheduler(NewMockDeadlineStore(), time.Hour)
+	deadline := session.RoundDeadline.Truncate(time.Millisecond)
+	scheduler.Schedule(ctx, "s1", "door-1", deadline)
+	routes := NewMockSessionRouteStore()
+	routes.owners["s1"] = "instance-a"
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithDeadlineScheduler(scheduler),
+		WithWorkerPool(discardWorkerPool{}),
+		WithSessionRoutes(NewRoutingService("instance-b", routes, []byte("secret"), true)),
+	)
+
+	store := NewMockRoundSnapshotStore()
+	wsManager := &localSessionRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager(), local: []string{"s1"}}
+	crashed := NewRoundSnapshotService(store, gameService, wsManager, "instance-a", time.Second)
+	if err := crashed.Snapshot(ctx); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	snapshot := store.snapshots["s1"]
+	if snapshot == nil || snapshot.InstanceID != "instance-a" || snapshot.PlayerDoors["p2"] != "door-1" || !snapshot.Deadlines["door-1"].Equal(deadline) {
+		t.Fatalf("Expected the round's doors and deadline in the snapshot, got %+v", snapshot)
+	}
+
+	// The instance dies after claiming the deadline but before handling it
+	scheduler.Cancel(ctx, "s1", "door-1")
+
+	survivor := NewRoundSnapshotService(store, gameService, &localSessionRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}, "instance-b", time.Second).(*RoundSnapshotServiceImpl)
+	if recovered, err := survivor.Recover(ctx); err != nil || recovered != 0 {
+		t.Fatalf("Expected a fresh snapshot to be left to its instance, got %d, %v", recovered, err)
+	}
+
+	survivor.now = func() time.Time { return time.Now().Add(roundSnapshotStaleAfter * time.Second) }
+	if recovered, err := survivor.Recover(ctx); err != nil || recovered != 1 {
+		t.Fatalf("Expected the abandoned round to be recovered, got %d, %v", recovered, err)
+	}
+	if at, pending, _ := scheduler.Deadline(ctx, "s1", "door-1"); !pending || !at.Equal(deadline) {
+		t.Errorf("Expected the lost deadline to be scheduled again for %v, got %v, %v", deadline, at, pending)
+	}
+	if len(store.snapshots) != 0 {
+		t.Errorf("Expected the recovered snapshot to be claimed, got %v", store.snapshots)
+	}
+	if routes.owners["s1"] != "instance-b" {
+		t.Errorf("Expected reconnecting players to be routed to the survivor, got %s", routes.owners["s1"])
+	}
+}
+
+func TestRoundSnapshot_DropsSnapshotsOfRoundsNoLongerHere(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newPausableSession(time.Now().Add(time.Minute))
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+
+	store := NewMockRoundSnapshotStore()
+	wsManager := &localSessionRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager(), local: []string{"s1"}}
+	snapshots := NewRoundSnapshotService(store, gameService, wsManager, "instance-a", time.Second)
+	snapshots.Snapshot(ctx)
+
+	// Players left for another instance, which snapshots the round from now on
+	otherWSManager := &localSessionRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager(), local: []string{"s1"}}
+	other := NewRoundSnapshotService(store, gameService, otherWSManager, "instance-b", time.Second)
+	other.Snapshot(ctx)
+	wsManager.local = nil
+	snapshots.Snapshot(ctx)
+	if snapshot := store.snapshots["s1"]; snapshot == nil || snapshot.InstanceID != "instance-b" {
+		t.Fatalf("Expected the other instance's snapshot to be kept, got %+v", snapshot)
+	}
+
+	// Once they leave that instance too, its snapshot is dropped
+	otherWSManager.local = nil
+	other.Snapshot(ctx)
+	if len(store.snapshots) != 0 {
+		t.Errorf("Expected the snapshot to be dropped, got %v", store.snapshots)
+	}
+
+	// Lobbies have no round to snapshot
+	gameSessionRepo.sessions["s1"].Status = models.GameStatusWaiting
+	wsManager.local = []string{"s1"}
+	snapshots.Snapshot(ctx)
+	if len(store.snapshots) != 0 {
+		t.Errorf("Expected no snapshot of a lobby, got %v", store.snapshots)
+	}
+}
+
+func TestRecoverRound_LeavesRoundsThatMovedOn(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(time.Minute))
+	gameSessionRepo.sessions["s1"] = session
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	).(*GameServiceImpl)
+
+	snapshot := &models.RoundSnapshot{
+		SessionID:   "s1",
+		Status:      models.GameStatusActive,
+		PlayerDoors: map[string]string{"p1": "door-0", "p2": "door-0"},
+		Deadlines:   map[string]time.Time{"door-0": time.Now()},
+	}
+	if err := gameService.RecoverRound(ctx, snapshot); err != nil {
+		t.Fatalf("RecoverRound failed: %v", err)
+	}
+	if deadlineScheduled(gameService, "door-0") {
+		t.Error("Expected the previous round's deadline not to be scheduled again")
+	}
+
+	// Everyone answered before the instance died, so the round is closed without its deadline
+	snapshot.PlayerDoors = map[string]string{"p1": "door-1", "p2": "door-1"}
+	snapshot.Deadlines = map[string]time.Time{"door-1": *session.RoundDeadline}
+	for i := range session.Players {
+		session.Players[i].Responses = []models.PlayerResponse{{ResponseID: "r" + session.Players[i].PlayerID, DoorID: "door-1", PlayerID: session.Players[i].PlayerID}}
+	}
+	if err := gameService.RecoverRound(ctx, snapshot); err != nil {
+		t.Fatalf("RecoverRound failed: %v", err)
+	}
+	if deadlineScheduled(gameService, "door-1") {
+		t.Error("Expected the answered round to close instead of waiting for its deadline")
+	}
+}
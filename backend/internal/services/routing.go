@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// routeRenewInterval is how often an instance renews the routes of the sessions it holds, well
+// within the route TTL
+const routeRenewInterval = 30 * time.Second
+
+// Routing errors
+var (
+	ErrInvalidConnectionToken = errors.New("invalid connection token")
+	ErrRouteNotFound          = errors.New("session is not routed to any instance")
+	ErrWrongInstance          = errors.New("session is held by another instance")
+)
+
+// SessionRoute tells a load balancer or client which backend instance holds a session
+type SessionRoute struct {
+	SessionID       string `json:"sessionId"`
+	InstanceID      string `json:"instanceId"`
+	ConnectionToken string `json:"connectionToken"`
+	// Sticky routes must be followed. Otherwise broadcasts reach every instance over the event
+	// bus, any instance can serve the session and the route only saves a hop.
+	Sticky bool `json:"sticky"`
+}
+
+// routeClaims is the signed payload of a connection token
+type routeClaims struct {
+	SessionID  string `json:"sid"`
+	InstanceID string `json:"iid"`
+}
+
+// RoutingService pins sessions to the backend instance holding their in-memory state and issues
+// connection tokens that route clients back to it, while games move to multiple instances.
+// Routes last as long as their instance keeps renewing them, so the sessions of an instance
+// that died or drained are free to move.
+type RoutingService interface {
+	InstanceID() string
+	Claim(ctx context.Context, sessionID string) (*SessionRoute, error)
+	Route(ctx context.Context, sessionID string) (*SessionRoute, error)
+	CheckToken(token, sessionID string) (*SessionRoute, error)
+	// Takeover moves a session to this instance from an instance that died while holding it
+	Takeover(ctx context.Context, sessionID, from string) error
+	// Release drops the route of a session that ended
+	Release(ctx context.Context, sessionID string) error
+	// ReleaseAll gives up every session this instance holds, as it drains
+	ReleaseAll(ctx context.Context) error
+	// Start renews the routes of the sessions this instance holds until the context is cancelled
+	Start(ctx context.Context)
+}
+
+// RoutingServiceImpl implements RoutingService with routes kept in Redis and HMAC-signed tokens
+type RoutingServiceImpl struct {
+	instanceID string
+	routes     repositories.SessionRouteStore
+	secret     []byte
+	sticky     bool
+
+	mu   sync.Mutex
+	held map[string]bool // sessions routed to this instance, renewed until released
+}
+
+// NewRoutingService creates a routing service for this instance. With sticky set, connections
+// carrying a token for another instance are refused instead of served over the event bus.
+func NewRoutingService(instanceID string, routes repositories.SessionRouteStore, secret []byte, sticky bool) RoutingService {
+	return &RoutingServiceImpl{
+		instanceID: instanceID,
+		routes:     routes,
+		secret:     secret,
+		sticky:     sticky,
+		held:       make(map[string]bool),
+	}
+}
+
+// InstanceID returns the ID of this backend instance
+func (s *RoutingServiceImpl) InstanceID() string {
+	return s.instanceID
+}
+
+// Claim pins a session to this instance when it is created or joined here, unless another
+// instance already holds it, and returns the route to whichever instance does
+func (s *RoutingServiceImpl) Claim(ctx context.Context, sessionID string) (*SessionRoute, error) {
+	owner, err := s.routes.Claim(ctx, sessionID, s.instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == s.instanceID {
+		s.hold(sessionID, true)
+	}
+	return s.route(sessionID, owner), nil
+}
+
+// Takeover moves a session to this instance from an instance that died while holding it,
+// unless a live instance claimed it first
+func (s *RoutingServiceImpl) Takeover(ctx context.Context, sessionID, from string) error {
+	moved, err := s.routes.Transfer(ctx, sessionID, from, s.instanceID)
+	if err != nil {
+		return err
+	}
+	if moved {
+		s.hold(sessionID, true)
+	}
+	return nil
+}
+
+// Release drops the route of a session that ended, whichever instance holds it
+func (s *RoutingServiceImpl) Release(ctx context.Context, sessionID string) error {
+	s.hold(sessionID, false)
+	return s.routes.Delete(ctx, sessionID)
+}
+
+// ReleaseAll gives up every session this instance holds, so players reconnecting after it
+// drains are routed to an instance that stays up
+func (s *RoutingServiceImpl) ReleaseAll(ctx context.Context) error {
+	var errs []error
+	for _, sessionID := range s.heldSessions() {
+		s.hold(sessionID, false)
+		if err := s.routes.Release(ctx, sessionID, s.instanceID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start renews the routes of the sessions this instance holds every routeRenewInterval until
+// the context is cancelled
+func (s *RoutingServiceImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(routeRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.renew(ctx)
+		}
+	}
+}
+
+// renew extends the routes this instance holds, forgetting those that ended or moved elsewhere
+func (s *RoutingServiceImpl) renew(ctx context.Context) {
+	for _, sessionID := range s.heldSessions() {
+		renewed, err := s.routes.Renew(ctx, sessionID, s.instanceID)
+		if err != nil {
+			fmt.Printf("Warning: failed to renew route of session %s: %v\n", sessionID, err)
+			continue
+		}
+		if !renewed {
+			s.hold(sessionID, false)
+		}
+	}
+}
+
+// hold records whether this instance holds a session's route
+func (s *RoutingServiceImpl) hold(sessionID string, held bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if held {
+		s.held[sessionID] = true
+	} else {
+		delete(s.held, sessionID)
+	}
+}
+
+// heldSessions lists the sessions this instance holds
+func (s *RoutingServiceImpl) heldSessions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessionIDs := make([]string, 0, len(s.held))
+	for sessionID := range s.held {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	return sessionIDs
+}
+
+// Route returns the route to the instance holding a session
+func (s *RoutingServiceImpl) Route(ctx context.Context, sessionID string) (*SessionRoute, error) {
+	owner, err := s.routes.Owner(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == "" {
+		return nil, ErrRouteNotFound
+	}
+	return s.route(sessionID, owner), nil
+}
+
+// CheckToken verifies a connection token presented for a session and returns the route it
+// encodes. Sticky deployments refuse tokens for other instances with ErrWrongInstance, along
+// with the route the client should follow.
+func (s *RoutingServiceImpl) CheckToken(token, sessionID string) (*SessionRoute, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return nil, ErrInvalidConnectionToken
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidConnectionToken
+	}
+	var claims routeClaims
+	if err := json.Unmarshal(decoded, &claims); err != nil || claims.SessionID != sessionID || claims.InstanceID == "" {
+		return nil, ErrInvalidConnectionToken
+	}
+
+	route := s.route(claims.SessionID, claims.InstanceID)
+	if s.sticky && claims.InstanceID != s.instanceID {
+		return route, ErrWrongInstance
+	}
+	return route, nil
+}
+
+// route builds the route to an instance, with a fresh connection token
+func (s *RoutingServiceImpl) route(sessionID, instanceID string) *SessionRoute {
+	claims, _ := json.Marshal(routeClaims{SessionID: sessionID, InstanceID: instanceID})
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	return &SessionRoute{
+		SessionID:       sessionID,
+		InstanceID:      instanceID,
+		ConnectionToken: fmt.Sprintf("%s.%s", payload, s.sign(payload)),
+		Sticky:          s.sticky,
+	}
+}
+
+// sign returns the base64url HMAC-SHA256 signature of a token payload
+func (s *RoutingServiceImpl) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// WithSessionRoutes drops sessions' routes once they end
+func WithSessionRoutes(routing RoutingService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.routing = routing
+	}
+}
+
+// releaseRoute drops a session's route once it has ended
+func (s *GameServiceImpl) releaseRoute(ctx context.Context, session *models.GameSession) {
+	if s.routing == nil {
+		return
+	}
+	if session.Status == models.GameStatusCompleted || session.Status == models.GameStatusAbandoned {
+		if err := s.routing.Release(ctx, session.SessionID); err != nil {
+			fmt.Printf("Warning: failed to release session route: %v\n", err)
+		}
+	}
+}
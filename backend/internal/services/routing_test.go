@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockSessionRouteStore keeps session routes in memory
+type MockSessionRouteStore struct {
+	owners map[string]string
+}
+
+func NewMockSessionRouteStore() *MockSessionRouteStore {
+	return &MockSessionRouteStore{owners: make(map[string]string)}
+}
+
+func (m *MockSessionRouteStore) Claim(ctx context.Context, sessionID, instanceID string) (string, error) {
+	if owner, ok := m.owners[sessionID]; ok {
+		return owner, nil
+	}
+	m.owners[sessionID] = instanceID
+	return instanceID, nil
+}
+
+func (m *MockSessionRouteStore) Owner(ctx context.Context, sessionID string) (string, error) {
+	return m.owners[sessionID], nil
+}
+
+func (m *MockSessionRouteStore) Renew(ctx context.Context, sessionID, instanceID string) (bool, error) {
+	return m.owners[sessionID] == instanceID, nil
+}
+
+func (m *MockSessionRouteStore) Transfer(ctx context.Context, sessionID, from, to string) (bool, error) {
+	if owner, ok := m.owners[sessionID]; ok && owner != from && owner != to {
+		return false, nil
+	}
+	m.owners[sessionID] = to
+	return true, nil
+}
+
+func (m *MockSessionRouteStore) Release(ctx context.Context, sessionID, instanceID string) error {
+	if m.owners[sessionID] == instanceID {
+		delete(m.owners, sessionID)
+	}
+	return nil
+}
+
+func (m *MockSessionRouteStore) Delete(ctx context.Context, sessionID string) error {
+	delete(m.owners, sessionID)
+	return nil
+}
+
+func TestRouting_ClaimRoutesJoinsToTheOwningInstance(t *testing.T) {
+	ctx := context.Background()
+	routes := NewMockSessionRouteStore()
+	secret := []byte("secret")
+	first := NewRoutingService("instance-a", routes, secret, false)
+	second := NewRoutingService("instance-b", routes, secret, false)
+
+	if _, err := first.Route(ctx, "s1"); !errors.Is(err, ErrRouteNotFound) {
+		t.Fatalf("Expected an unclaimed session to have no route, got %v", err)
+	}
+
+	created, err := first.Claim(ctx, "s1")
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	joined, err := second.Claim(ctx, "s1")
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if created.InstanceID != "instance-a" || joined.InstanceID != "instance-a" {
+		t.Fatalf("Expected the session to stay on the instance that created it, got %s and %s", created.InstanceID, joined.InstanceID)
+	}
+
+	route, err := second.Route(ctx, "s1")
+	if err != nil || route.InstanceID != "instance-a" || route.ConnectionToken != created.ConnectionToken {
+		t.Errorf("Expected the route to the creating instance, got %+v, %v", route, err)
+	}
+}
+
+func TestRouting_CheckToken(t *testing.T) {
+	ctx := context.Background()
+	routes := NewMockSessionRouteStore()
+	owner := NewRoutingService("instance-a", routes, []byte("secret"), true)
+	route, _ := owner.Claim(ctx, "s1")
+
+	if checked, err := owner.CheckToken(route.ConnectionToken, "s1"); err != nil || checked.InstanceID != "instance-a" {
+		t.Fatalf("Expected the owner to accept its token, got %+v, %v", checked, err)
+	}
+
+	invalid := []struct {
+		name, token, sessionID string
+	}{
+		{"other session", route.ConnectionToken, "s2"},
+		{"tampered", route.ConnectionToken + "x", "s1"},
+		{"malformed", "not-a-token", "s1"},
+	}
+	for _, tc := range invalid {
+		if _, err := owner.CheckToken(tc.token, tc.sessionID); !errors.Is(err, ErrInvalidConnectionToken) {
+			t.Errorf("%s: expected the token to be refused, got %v", tc.name, err)
+		}
+	}
+	forged := NewRoutingService("instance-a", routes, []byte("other secret"), true)
+	if _, err := owner.CheckToken(mustClaim(t, forged, "s1").ConnectionToken, "s1"); !errors.Is(err, ErrInvalidConnectionToken) {
+		t.Errorf("Expected a token signed with another secret to be refused, got %v", err)
+	}
+
+	// Sticky instances send clients holding another instance's token on their way
+	sticky := NewRoutingService("instance-b", routes, []byte("secret"), true)
+	if checked, err := sticky.CheckToken(route.ConnectionToken, "s1"); !errors.Is(err, ErrWrongInstance) || checked.InstanceID != "instance-a" {
+		t.Errorf("Expected a sticky instance to redirect to instance-a, got %+v, %v", checked, err)
+	}
+	// Without stickiness the event bus lets any instance serve the session
+	shared := NewRoutingService("instance-b", routes, []byte("secret"), false)
+	if _, err := shared.CheckToken(route.ConnectionToken, "s1"); err != nil {
+		t.Errorf("Expected a cross-instance deployment to accept the token, got %v", err)
+	}
+}
+
+func TestRouting_RoutesAreGivenUpWhenSessionsMove(t *testing.T) {
+	ctx := context.Background()
+	routes := NewMockSessionRouteStore()
+	secret := []byte("secret")
+	dead := NewRoutingService("instance-a", routes, secret, true)
+	survivor := NewRoutingService("instance-b", routes, secret, true).(*RoutingServiceImpl)
+	mustClaim(t, dead, "s1")
+	mustClaim(t, dead, "s2")
+
+	// The survivor rebuilds s1 from its snapshot and takes its players over
+	if err := survivor.Takeover(ctx, "s1", "instance-a"); err != nil {
+		t.Fatalf("Takeover failed: %v", err)
+	}
+	if route := mustClaim(t, survivor, "s1"); route.InstanceID != "instance-b" {
+		t.Fatalf("Expected s1 to move to instance-b, got %s", route.InstanceID)
+	}
+	// A session a live instance claimed meanwhile is left alone
+	routes.owners["s2"] = "instance-c"
+	if err := survivor.Takeover(ctx, "s2", "instance-a"); err != nil || routes.owners["s2"] != "instance-c" {
+		t.Fatalf("Expected s2 to stay on instance-c, got %s, %v", routes.owners["s2"], err)
+	}
+
+	// Routes moved elsewhere are no longer renewed
+	routes.owners["s1"] = "instance-c"
+	survivor.renew(ctx)
+	if held := survivor.heldSessions(); len(held) != 0 {
+		t.Fatalf("Expected the moved route to be forgotten, still holding %v", held)
+	}
+
+	// Draining hands back every route this instance holds, and only those
+	mustClaim(t, survivor, "s3")
+	if err := survivor.ReleaseAll(ctx); err != nil {
+		t.Fatalf("ReleaseAll failed: %v", err)
+	}
+	if _, ok := routes.owners["s3"]; ok {
+		t.Error("Expected the drained instance's route to be released")
+	}
+	if routes.owners["s1"] != "instance-c" || routes.owners["s2"] != "instance-c" {
+		t.Errorf("Expected other instances' routes to be kept, got %v", routes.owners)
+	}
+
+	// Ended sessions drop their route whoever holds it
+	if err := survivor.Release(ctx, "s1"); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, ok := routes.owners["s1"]; ok {
+		t.Error("Expected the ended session's route to be released")
+	}
+}
+
+func mustClaim(t *testing.T, routing RoutingService, sessionID string) *SessionRoute {
+	t.Helper()
+	route, err := routing.Claim(context.Background(), sessionID)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	return route
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Scheduler defaults
+const (
+	DefaultSchedulerPollInterval = time.Second
+	schedulerBatchSize           = 100
+)
+
+// DeadlineHandler processes a door whose deadline has passed
+type DeadlineHandler func(ctx context.Context, sessionID, doorID string)
+
+// DeadlineScheduler fires door deadlines. Handlers must tolerate deadlines for doors that
+// have already moved on.
+type DeadlineScheduler interface {
+	Schedule(ctx context.Context, sessionID, doorID string, at time.Time) error
+	Cancel(ctx context.Context, sessionID, doorID string) error
+	Deadline(ctx context.Context, sessionID, doorID string) (time.Time, bool, error)
+	Handle(handler DeadlineHandler)
+	Start(ctx context.Context)
+}
+
+// PersistentScheduler polls a shared deadline store, so deadlines survive restarts and each
+// one fires on exactly one backend instance
+type PersistentScheduler struct {
+	store        repositories.DeadlineStore
+	pollInterval time.Duration
+
+	mu      sync.RWMutex
+	handler DeadlineHandler
+}
+
+// NewPersistentScheduler creates a scheduler that checks the store for due deadlines every pollInterval
+func NewPersistentScheduler(store repositories.DeadlineStore, pollInterval time.Duration) DeadlineScheduler {
+	if pollInterval <= 0 {
+		pollInterval = DefaultSchedulerPollInterval
+	}
+	return &PersistentScheduler{
+		store:        store,
+		pollInterval: pollInterval,
+	}
+}
+
+// Schedule persists a deadline for the door
+func (s *PersistentScheduler) Schedule(ctx context.Context, sessionID, doorID string, at time.Time) error {
+	return s.store.Schedule(ctx, models.DoorDeadline{SessionID: sessionID, DoorID: doorID, At: at})
+}
+
+// Cancel drops the door's pending deadline
+func (s *PersistentScheduler) Cancel(ctx context.Context, sessionID, doorID string) error {
+	return s.store.Cancel(ctx, sessionID, doorID)
+}
+
+// Deadline returns when the door's pending deadline falls due, and false if it has none
+func (s *PersistentScheduler) Deadline(ctx context.Context, sessionID, doorID string) (time.Time, bool, error) {
+	return s.store.Deadline(ctx, sessionID, doorID)
+}
+
+// Handle sets the function deadlines are fired to
+func (s *PersistentScheduler) Handle(handler DeadlineHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = handler
+}
+
+// Start fires due deadlines every poll interval until the context is cancelled. Deadlines
+// that fell due while no instance was running fire on the first poll.
+func (s *PersistentScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.fireDue(ctx, time.Now()); err != nil {
+			fmt.Printf("Warning: failed to fire door deadlines: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fireDue claims and fires every deadline due at now
+func (s *PersistentScheduler) fireDue(ctx context.Context, now time.Time) error {
+	s.mu.RLock()
+	handler := s.handler
+	s.mu.RUnlock()
+
+	if handler == nil {
+		return nil
+	}
+
+	for {
+		deadlines, err := s.store.Due(ctx, now, schedulerBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, deadline := range deadlines {
+			claimed, err := s.store.Claim(ctx, deadline)
+			if err != nil {
+				return err
+			}
+			if claimed {
+				handler(ctx, deadline.SessionID, deadline.DoorID)
+			}
+		}
+
+		if len(deadlines) < schedulerBatchSize {
+			return nil
+		}
+	}
+}
+
+// InProcessScheduler fires deadlines from in-memory timers. Deadlines are lost on restart,
+// so it is only suitable for a single instance and for tests.
+type InProcessScheduler struct {
+	mu        sync.Mutex
+	timers    map[string]*time.Timer
+	deadlines map[string]time.Time
+	handler   DeadlineHandler
+}
+
+// NewInProcessScheduler creates a timer-based scheduler
+func NewInProcessScheduler() DeadlineScheduler {
+	return &InProcessScheduler{
+		timers:    make(map[string]*time.Timer),
+		deadlines: make(map[string]time.Time),
+	}
+}
+
+// Schedule starts a timer for the door, replacing any earlier one
+func (s *InProcessScheduler) Schedule(ctx context.Context, sessionID, doorID string, at time.Time) error {
+	key := sessionID + "/" + doorID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, exists := s.timers[key]; exists {
+		timer.Stop()
+	}
+
+	s.deadlines[key] = at
+	s.timers[key] = time.AfterFunc(time.Until(at), func() {
+		s.mu.Lock()
+		delete(s.timers, key)
+		delete(s.deadlines, key)
+		handler := s.handler
+		s.mu.Unlock()
+
+		if handler != nil {
+			handler(context.Background(), sessionID, doorID)
+		}
+	})
+	return nil
+}
+
+// Cancel stops the door's timer
+func (s *InProcessScheduler) Cancel(ctx context.Context, sessionID, doorID string) error {
+	key := sessionID + "/" + doorID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, exists := s.timers[key]; exists {
+		timer.Stop()
+		delete(s.timers, key)
+		delete(s.deadlines, key)
+	}
+	return nil
+}
+
+// Deadline returns when the door's timer fires, and false if it has none
+func (s *InProcessScheduler) Deadline(ctx context.Context, sessionID, doorID string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at, exists := s.deadlines[sessionID+"/"+doorID]
+	return at, exists, nil
+}
+
+// Handle sets the function deadlines are fired to
+func (s *InProcessScheduler) Handle(handler DeadlineHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = handler
+}
+
+// Start does nothing; timers run on their own
+func (s *InProcessScheduler) Start(ctx context.Context) {}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MockDeadlineStore is an in-memory DeadlineStore for testing
+type MockDeadlineStore struct {
+	mu        sync.Mutex
+	deadlines map[string]models.DoorDeadline
+}
+
+func NewMockDeadlineStore() *MockDeadlineStore {
+	return &MockDeadlineStore{deadlines: make(map[string]models.DoorDeadline)}
+}
+
+func (m *MockDeadlineStore) Schedule(ctx context.Context, deadline models.DoorDeadline) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deadlines[deadline.SessionID+"/"+deadline.DoorID] = deadline
+	return nil
+}
+
+func (m *MockDeadlineStore) Due(ctx context.Context, now time.Time, limit int) ([]models.DoorDeadline, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []models.DoorDeadline
+	for _, deadline := range m.deadlines {
+		if !deadline.At.After(now) {
+			due = append(due, deadline)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].At.Before(due[j].At) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (m *MockDeadlineStore) Claim(ctx context.Context, deadline models.DoorDeadline) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := deadline.SessionID + "/" + deadline.DoorID
+	if _, exists := m.deadlines[key]; !exists {
+		return false, nil
+	}
+	delete(m.deadlines, key)
+	return true, nil
+}
+
+func (m *MockDeadlineStore) Cancel(ctx context.Context, sessionID, doorID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.deadlines, sessionID+"/"+doorID)
+	return nil
+}
+
+func (m *MockDeadlineStore) Deadline(ctx context.Context, sessionID, doorID string) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	deadline, exists := m.deadlines[sessionID+"/"+doorID]
+	return deadline.At, exists, nil
+}
+
+func TestPersistentScheduler_FiresOnlyDueDeadlines(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	scheduler := NewPersistentScheduler(NewMockDeadlineStore(), time.Hour).(*PersistentScheduler)
+
+	var fired []string
+	scheduler.Handle(func(ctx context.Context, sessionID, doorID string) {
+		fired = append(fired, sessionID+"/"+doorID)
+	})
+
+	scheduler.Schedule(ctx, "s1", "door-1", now.Add(-time.Second))
+	scheduler.Schedule(ctx, "s2", "door-1", now.Add(time.Minute))
+	scheduler.Schedule(ctx, "s3", "door-1", now.Add(-time.Second))
+	scheduler.Cancel(ctx, "s3", "door-1")
+
+	if err := scheduler.fireDue(ctx, now); err != nil {
+		t.Fatalf("fireDue failed: %v", err)
+	}
+	if err := scheduler.fireDue(ctx, now); err != nil {
+		t.Fatalf("fireDue failed: %v", err)
+	}
+
+	if len(fired) != 1 || fired[0] != "s1/door-1" {
+		t.Errorf("Expected only s1/door-1 to fire once, got %v", fired)
+	}
+}
+
+func TestPersistentScheduler_SurvivesRestartAndFiresOnce(t *testing.T) {
+	ctx := context.Background()
+	store := NewMockDeadlineStore()
+
+	// Scheduled by an instance that then went away
+	NewPersistentScheduler(store, time.Hour).Schedule(ctx, "s1", "door-1", time.Now().Add(-time.Minute))
+
+	// Two instances come up and poll the shared store
+	fired := 0
+	for i := 0; i < 2; i++ {
+		scheduler := NewPersistentScheduler(store, time.Hour).(*PersistentScheduler)
+		scheduler.Handle(func(ctx context.Context, sessionID, doorID string) { fired++ })
+		if err := scheduler.fireDue(ctx, time.Now()); err != nil {
+			t.Fatalf("fireDue failed: %v", err)
+		}
+	}
+
+	if fired != 1 {
+		t.Errorf("Expected the overdue deadline to fire exactly once, fired %d times", fired)
+	}
+}
+
+// discardWorkerPool accepts background tasks without running them
+type discardWorkerPool struct{}
+
+func (discardWorkerPool) Submit(name string, task func()) error { return nil }
+
+func (discardWorkerPool) Stats() WorkerPoolStats { return WorkerPoolStats{Name: "discard"} }
+
+func (discardWorkerPool) Shutdown(ctx context.Context) error { return nil }
+
+// fullWorkerPool rejects every background task, as a pool with a full queue does
+type fullWorkerPool struct{}
+
+func (fullWorkerPool) Submit(name string, task func()) error { return ErrWorkerPoolFull }
+
+func (fullWorkerPool) Stats() WorkerPoolStats { return WorkerPoolStats{Name: "full"} }
+
+func (fullWorkerPool) Shutdown(ctx context.Context) error { return nil }
+
+func TestScheduler_RunsClaimedDeadlineInlineWhenPoolIsFull(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newPausableSession(time.Now().Add(time.Minute))
+	disconnectedAt := time.Now().Add(-time.Minute)
+	session.Players[1].DisconnectedAt = &disconnectedAt
+	gameSessionRepo.sessions["s1"] = session
+
+	scheduler := NewPersistentScheduler(NewMockDeadlineStore(), time.Hour).(*PersistentScheduler)
+	NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(fullWorkerPool{}),
+		WithDeadlineScheduler(scheduler),
+	)
+
+	scheduler.Schedule(ctx, "s1", disconnectGraceKey("p2"), disconnectedAt)
+	if err := scheduler.fireDue(ctx, time.Now()); err != nil {
+		t.Fatalf("fireDue failed: %v", err)
+	}
+
+	if !session.Players[1].TimedOut {
+		t.Error("Expected the claimed grace period to time p2 out despite the full worker pool")
+	}
+}
+
+func TestSubmitResponse_CancelsDeadlineWhenAllResponded(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Players = session.Players[:1]
+	gameSessionRepo.sessions["s1"] = session
+
+	store := NewMockDeadlineStore()
+	scheduler := NewPersistentScheduler(store, time.Hour)
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+		WithDeadlineScheduler(scheduler),
+	)
+
+	scheduler.Schedule(ctx, "s1", "door-1", time.Now().Add(time.Minute))
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p1", "I would open it."); err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+
+	if len(store.deadlines) != 0 {
+		t.Errorf("Expected the deadline to be cancelled, got %v", store.deadlines)
+	}
+}
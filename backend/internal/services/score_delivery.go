@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+)
+
+// scoreDelivery returns the handler that applies a batched score to the response once it arrives
+func (s *GameServiceImpl) scoreDelivery(ctx context.Context, sessionID, playerID, responseID string, door *models.Door) ScoringResultHandler {
+	traced := tracing.Carry(tracing.WithSessionID(ctx, sessionID))
+
+	return func(result *ScoreResult, scoreErr error) {
+		// Applying the last score of a round reveals it, so the score must not be dropped
+		applyScore := s.detachedTask(traced, "apply-score", func(ctx context.Context) {
+			if _, err := s.applyScore(ctx, sessionID, playerID, responseID, door, result, scoreErr); err != nil {
+				fmt.Printf("Error applying score: %v\n", err)
+			}
+		})
+		if err := s.workerPool.Submit("apply-score", applyScore); err != nil {
+			fmt.Printf("Warning: %v, applying score inline\n", err)
+			applyScore()
+		}
+	}
+}
+
+// applyScore records a score and its feedback on a pending response and announces them, returning
+// the scored response, or nil if it was already scored. If the round has already closed and this
+// was its last pending score, the round is revealed. The metrics are combined with the strategy
+// recorded on the response when it was submitted.
+func (s *GameServiceImpl) applyScore(ctx context.Context, sessionID, playerID, responseID string, door *models.Door, result *ScoreResult, scoreErr error) (*models.PlayerResponse, error) {
+	if scoreErr != nil {
+		fmt.Printf("Warning: AI scoring failed, using fallback: %v\n", scoreErr)
+		result = fallbackScoreResult()
+	}
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	player, response := findResponse(session, playerID, responseID)
+	if response == nil || !response.ScoringPending {
+		return nil, nil // Already scored, or the player has left
+	}
+
+	score := cheatPenalty(s.strategyFor(response.ScoringStrategy).Score(door, result.Metrics), response.CheatSuspicion)
+	scored := *response
+	scored.ScoringMetrics = result.Metrics
+	scored.Feedback = result.Feedback
+	scored.AIScore = score
+	scored.ScoringPending = false
+
+	// Replace the pending response atomically so responses submitted meanwhile are kept
+	session, err = s.gameSessionRepo.SetResponseScore(ctx, sessionID, scored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save score: %w", err)
+	}
+	if session == nil {
+		return nil, nil // Scored elsewhere in the meantime
+	}
+	s.recordScore(ctx, sessionID, scored, scoreErr != nil)
+	player, _ = findResponse(session, playerID, responseID)
+	if player == nil {
+		return &scored, nil
+	}
+	totalScore := player.TotalScore
+
+	reveal := session.Status == models.GameStatusScoring && !hasPendingScores(session)
+	unlock()
+
+	if err := s.updatePlayerPath(ctx, playerID, pathScore(session, playerID, scored.DoorID, score), scored.DoorID, s.shortcutThreshold(session)); err != nil {
+		fmt.Printf("Warning: failed to update player path: %v\n", err)
+	}
+	s.recordDoorScore(ctx, sessionID, scored.DoorID, score)
+	s.observeExperimentScore(session, score)
+	s.publishScore(ctx, sessionID, playerID, score, totalScore)
+	s.sendFeedback(ctx, sessionID, scored)
+	s.checkResponseAchievements(ctx, session, *player, scored)
+
+	if reveal {
+		return &scored, s.revealRound(ctx, session)
+	}
+	return &scored, nil
+}
+
+// findResponse returns the player and their response with the given ID, if both exist
+func findResponse(session *models.GameSession, playerID, responseID string) (*models.PlayerInfo, *models.PlayerResponse) {
+	for i := range session.Players {
+		if session.Players[i].PlayerID != playerID {
+			continue
+		}
+		for j := range session.Players[i].Responses {
+			if session.Players[i].Responses[j].ResponseID == responseID {
+				return &session.Players[i], &session.Players[i].Responses[j]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// hasPendingScores reports whether any response in the session is still waiting for its score
+func hasPendingScores(session *models.GameSession) bool {
+	for _, player := range session.Players {
+		for _, response := range player.Responses {
+			if response.ScoringPending {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// averageScore is a response's total AI score, the average of its metrics
+func averageScore(metrics models.ScoringMetrics) int {
+	return (metrics.Creativity + metrics.Feasibility + metrics.Humor + metrics.Originality) / 4
+}
+
+// fallbackScoreResult holds the neutral scores used when the AI service cannot score a response
+func fallbackScoreResult() *ScoreResult {
+	return &ScoreResult{
+		Metrics: models.ScoringMetrics{
+			Creativity:  50,
+			Feasibility: 50,
+			Humor:       50,
+			Originality: 50,
+		},
+	}
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/monitoring"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Default scoring batcher settings
+const (
+	DefaultScoringBatchSize    = 8
+	DefaultScoringBatchWindow  = 50 * time.Millisecond
+	DefaultScoringBacklogSize  = 1024
+	DefaultScoringBatchTimeout = 30 * time.Second
+)
+
+// Scoring batcher errors; callers score the response themselves instead
+var (
+	ErrScoringBacklogFull    = errors.New("scoring backlog is full")
+	ErrScoringBatcherStopped = errors.New("scoring batcher has stopped")
+)
+
+// ScoringResultHandler receives a response's scores once its batch has been scored
+type ScoringResultHandler func(result *ScoreResult, err error)
+
+// ScoringBatcher groups pending responses into batch calls to the AI service and delivers
+// each score asynchronously, so submitting a response never waits on the AI service
+type ScoringBatcher interface {
+	Submit(request ScoreRequest, onScored ScoringResultHandler) error
+	Start(ctx context.Context)
+}
+
+// ScoringBatcherImpl implements the ScoringBatcher interface
+type ScoringBatcherImpl struct {
+	aiClient  AIClient
+	batchSize int
+	window    time.Duration
+	timeout   time.Duration
+
+	pending chan scoringJob
+	slots   chan struct{}
+	wg      sync.WaitGroup
+
+	mu      sync.RWMutex
+	stopped bool // set once Start stops collecting, after which nothing would score new jobs
+
+	batchSizes *monitoring.Histogram
+	backlog    *monitoring.Gauge
+}
+
+// scoringJob is one response waiting to be scored
+type scoringJob struct {
+	request  ScoreRequest
+	onScored ScoringResultHandler
+}
+
+// ScoringBatcherOption configures optional scoring batcher settings
+type ScoringBatcherOption func(*ScoringBatcherImpl)
+
+// WithScoringBatchWindow sets how long the first response of a batch waits for others to join it
+func WithScoringBatchWindow(window time.Duration) ScoringBatcherOption {
+	return func(b *ScoringBatcherImpl) {
+		if window > 0 {
+			b.window = window
+		}
+	}
+}
+
+// WithScoringBatchTimeout bounds each batch call to the AI service
+func WithScoringBatchTimeout(timeout time.Duration) ScoringBatcherOption {
+	return func(b *ScoringBatcherImpl) {
+		if timeout > 0 {
+			b.timeout = timeout
+		}
+	}
+}
+
+// WithScoringBacklogSize caps how many responses may wait for a batch
+func WithScoringBacklogSize(size int) ScoringBatcherOption {
+	return func(b *ScoringBatcherImpl) {
+		if size > 0 {
+			b.pending = make(chan scoringJob, size)
+		}
+	}
+}
+
+// NewScoringBatcher creates a batcher sending up to batchSize responses per AI call,
+// with at most concurrency batches in flight
+func NewScoringBatcher(aiClient AIClient, batchSize, concurrency int, opts ...ScoringBatcherOption) ScoringBatcher {
+	if batchSize <= 0 {
+		batchSize = DefaultScoringBatchSize
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultScoringConcurrency
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	batcher := &ScoringBatcherImpl{
+		aiClient:   aiClient,
+		batchSize:  batchSize,
+		window:     DefaultScoringBatchWindow,
+		timeout:    DefaultScoringBatchTimeout,
+		pending:    make(chan scoringJob, DefaultScoringBacklogSize),
+		slots:      make(chan struct{}, concurrency),
+		batchSizes: collector.NewHistogramWithBuckets("ai_scoring_batch_size", "Responses sent per batch scoring call", nil, []float64{1, 2, 4, 8, 16, 32, 64}),
+		backlog:    collector.NewGauge("ai_scoring_backlog", "Responses waiting to be batched for scoring", nil),
+	}
+
+	for _, opt := range opts {
+		opt(batcher)
+	}
+
+	return batcher
+}
+
+// Submit queues a response for scoring; onScored is called from a batcher goroutine once it is
+// scored. Responses are refused once the batcher has stopped.
+func (b *ScoringBatcherImpl) Submit(request ScoreRequest, onScored ScoringResultHandler) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.stopped {
+		return ErrScoringBatcherStopped
+	}
+
+	select {
+	case b.pending <- scoringJob{request: request, onScored: onScored}:
+		b.backlog.Inc()
+		return nil
+	default:
+		return ErrScoringBacklogFull
+	}
+}
+
+// Start collects pending responses into batches until the context is cancelled, then scores
+// whatever is still queued and waits for in-flight batches to deliver their results. Responses
+// submitted after that are refused.
+func (b *ScoringBatcherImpl) Start(ctx context.Context) {
+	for {
+		select {
+		case job := <-b.pending:
+			b.dispatch(b.collect(ctx, job))
+		case <-ctx.Done():
+			b.mu.Lock()
+			b.stopped = true
+			b.mu.Unlock()
+
+			b.drain()
+			b.wg.Wait()
+			return
+		}
+	}
+}
+
+// collect gathers responses behind the first until the batch is full or its window closes
+func (b *ScoringBatcherImpl) collect(ctx context.Context, first scoringJob) []scoringJob {
+	batch := []scoringJob{first}
+
+	timer := time.NewTimer(b.window)
+	defer timer.Stop()
+
+	for len(batch) < b.batchSize {
+		select {
+		case job := <-b.pending:
+			batch = append(batch, job)
+		case <-timer.C:
+			return batch
+		case <-ctx.Done():
+			return batch
+		}
+	}
+	return batch
+}
+
+// drain dispatches every queued response without waiting for batch windows
+func (b *ScoringBatcherImpl) drain() {
+	for {
+		batch := make([]scoringJob, 0, b.batchSize)
+	fill:
+		for len(batch) < b.batchSize {
+			select {
+			case job := <-b.pending:
+				batch = append(batch, job)
+			default:
+				break fill
+			}
+		}
+
+		if len(batch) == 0 {
+			return
+		}
+		b.dispatch(batch)
+	}
+}
+
+// dispatch waits for a free slot, then scores the batch on its own goroutine
+func (b *ScoringBatcherImpl) dispatch(batch []scoringJob) {
+	b.backlog.Add(-float64(len(batch)))
+	b.batchSizes.Observe(float64(len(batch)))
+
+	b.slots <- struct{}{}
+	b.wg.Add(1)
+	go func() {
+		defer func() {
+			<-b.slots
+			b.wg.Done()
+		}()
+		b.score(batch)
+	}()
+}
+
+// score sends one batch to the AI service and hands each result to its handler
+func (b *ScoringBatcherImpl) score(batch []scoringJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	requests := make([]ScoreRequest, len(batch))
+	for i, job := range batch {
+		requests[i] = job.request
+	}
+
+	results, err := b.aiClient.ScoreResponses(ctx, requests)
+	if err == nil && len(results) != len(batch) {
+		err = fmt.Errorf("AI service returned %d scores for %d responses", len(results), len(batch))
+	}
+
+	for i, job := range batch {
+		if err != nil {
+			job.onScored(nil, fmt.Errorf("failed to score batch: %w", err))
+			continue
+		}
+		job.onScored(results[i], nil)
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// captureScoringBatcher holds submitted responses until the test delivers their scores
+type captureScoringBatcher struct {
+	jobs []scoringJob
+}
+
+func (b *captureScoringBatcher) Submit(request ScoreRequest, onScored ScoringResultHandler) error {
+	b.jobs = append(b.jobs, scoringJob{request: request, onScored: onScored})
+	return nil
+}
+
+func (b *captureScoringBatcher) Start(ctx context.Context) {}
+
+func newBatchedScoringSession() *models.GameSession {
+	return &models.GameSession{
+		SessionID:   "s1",
+		Mode:        models.GameModeMultiplayer,
+		Status:      models.GameStatusActive,
+		CurrentDoor: &models.Door{DoorID: "door-1", Content: "A locked door"},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", IsActive: true},
+			{PlayerID: "p2", IsActive: true},
+			{PlayerID: "p3", IsActive: true},
+		},
+	}
+}
+
+// collectScores submits n responses and waits for all of their results
+func collectScores(t *testing.T, batcher ScoringBatcher, n int) []error {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		err := batcher.Submit(ScoreRequest{Door: &models.Door{Content: "A locked door"}, Response: "Knock"}, func(result *ScoreResult, err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+			wg.Done()
+		})
+		if err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for scores")
+	}
+	return errs
+}
+
+func TestScoringBatcher_GroupsResponsesIntoBatches(t *testing.T) {
+	aiClient := &MockAIClient{}
+	batcher := NewScoringBatcher(aiClient, 4, 2, WithScoringBatchWindow(20*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go batcher.Start(ctx)
+
+	for _, err := range collectScores(t, batcher, 10) {
+		if err != nil {
+			t.Errorf("Expected every response to be scored, got %v", err)
+		}
+	}
+
+	if calls := atomic.LoadInt32(&aiClient.batchCalls); calls != 3 {
+		t.Errorf("Expected 10 responses to be sent in 3 batches of up to 4, got %d", calls)
+	}
+}
+
+func TestScoringBatcher_DeliversBatchErrors(t *testing.T) {
+	aiClient := &MockAIClient{batchErr: errors.New("AI service unavailable")}
+	batcher := NewScoringBatcher(aiClient, 4, 1, WithScoringBatchWindow(5*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go batcher.Start(ctx)
+
+	for _, err := range collectScores(t, batcher, 2) {
+		if err == nil {
+			t.Error("Expected the batch error to reach every response")
+		}
+	}
+}
+
+func TestScoringBatcher_RejectsWhenBacklogIsFull(t *testing.T) {
+	batcher := NewScoringBatcher(&MockAIClient{}, 4, 1, WithScoringBacklogSize(1))
+	noop := func(*ScoreResult, error) {}
+
+	if err := batcher.Submit(ScoreRequest{}, noop); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if err := batcher.Submit(ScoreRequest{}, noop); !errors.Is(err, ErrScoringBacklogFull) {
+		t.Errorf("Expected ErrScoringBacklogFull, got %v", err)
+	}
+}
+
+func TestScoringBatcher_RefusesResponsesOnceStopped(t *testing.T) {
+	batcher := NewScoringBatcher(&MockAIClient{}, 4, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		batcher.Start(ctx)
+		close(stopped)
+	}()
+	cancel()
+	<-stopped
+
+	// Nothing would ever score the response, so the caller must score it itself
+	if err := batcher.Submit(ScoreRequest{}, func(*ScoreResult, error) {}); !errors.Is(err, ErrScoringBatcherStopped) {
+		t.Errorf("Expected ErrScoringBatcherStopped, got %v", err)
+	}
+}
+
+func TestSubmitResponse_AppliesBatchedScoresAsTheyArrive(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newBatchedScoringSession()
+	aiClient := &MockAIClient{}
+	batcher := &captureScoringBatcher{}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, aiClient, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+		WithScoringBatcher(batcher),
+	)
+
+	for _, playerID := range []string{"p1", "p2"} {
+		if _, err := gameService.SubmitResponse(ctx, "s1", playerID, "I would pick the lock."); err != nil {
+			t.Fatalf("SubmitResponse failed: %v", err)
+		}
+	}
+	if aiClient.scoreCalls != 0 {
+		t.Errorf("Expected submissions not to wait on the AI service, got %d calls", aiClient.scoreCalls)
+	}
+	if len(batcher.jobs) != 2 {
+		t.Fatalf("Expected 2 responses queued for scoring, got %d", len(batcher.jobs))
+	}
+
+	responseOf := func(playerID string) models.PlayerResponse {
+		for _, player := range gameSessionRepo.sessions["s1"].Players {
+			if player.PlayerID == playerID && len(player.Responses) > 0 {
+				return player.Responses[0]
+			}
+		}
+		t.Fatalf("No response from %s", playerID)
+		return models.PlayerResponse{}
+	}
+	if !responseOf("p1").ScoringPending {
+		t.Error("Expected the response to wait for its score")
+	}
+
+	batcher.jobs[0].onScored(&ScoreResult{
+		Metrics:  models.ScoringMetrics{Creativity: 80, Feasibility: 60, Humor: 90, Originality: 70},
+		Feedback: "Funny, but the lock wins.",
+	}, nil)
+	batcher.jobs[1].onScored(nil, errors.New("AI service unavailable"))
+
+	if got := responseOf("p1"); got.ScoringPending || got.AIScore != 75 || got.Feedback != "Funny, but the lock wins." {
+		t.Errorf("Expected p1 to be scored 75 with feedback, got %+v", got)
+	}
+	if got := responseOf("p2"); got.ScoringPending || got.AIScore != 50 {
+		t.Errorf("Expected p2 to get the fallback score, got %+v", got)
+	}
+	if total := gameSessionRepo.sessions["s1"].Players[0].TotalScore; total != 75 {
+		t.Errorf("Expected p1's total score to be 75, got %d", total)
+	}
+
+	// The round closes when the last player answers, but waits for that response's score
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p3", "I would pick the lock."); err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	session := gameSessionRepo.sessions["s1"]
+	if session.Status != models.GameStatusScoring {
+		t.Errorf("Expected the round to wait in scoring, got %s", session.Status)
+	}
+	if !hasPendingScores(session) {
+		t.Error("Expected p3's score to still be pending")
+	}
+}
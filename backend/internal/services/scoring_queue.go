@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Default scoring queue limits
+const (
+	DefaultScoringConcurrency = 4
+	DefaultScoringRatePerSec  = 20
+)
+
+// ScoringQueue smooths bursts of scoring requests in front of the AI service
+type ScoringQueue interface {
+	Score(ctx context.Context, sessionID, playerID string, door *models.Door, response string) (*ScoreResult, error)
+	Stats() ScoringQueueStats
+}
+
+// ScoringQueueStats is a snapshot of scoring queue activity
+type ScoringQueueStats struct {
+	Concurrency int     `json:"concurrency"`
+	RatePerSec  float64 `json:"ratePerSec"`
+	Waiting     int     `json:"waiting"`
+	InFlight    int     `json:"inFlight"`
+	Completed   uint64  `json:"completed"`
+	Abandoned   uint64  `json:"abandoned"`
+}
+
+// ScoringQueueImpl implements the ScoringQueue interface
+type ScoringQueueImpl struct {
+	aiClient  AIClient
+	wsManager WebSocketManager
+
+	slots       chan struct{}
+	minInterval time.Duration
+	concurrency int
+	streaming   bool
+
+	mu        sync.Mutex
+	nextStart time.Time
+	waiting   int
+	inFlight  int
+	completed uint64
+	abandoned uint64
+
+	waitTime     *monitoring.Histogram
+	waitingGauge *monitoring.Gauge
+}
+
+// ScoringQueueOption configures optional scoring queue behaviour
+type ScoringQueueOption func(*ScoringQueueImpl)
+
+// WithStreamingScores streams each metric of a response's score to the submitting player as the
+// AI service scores it, when the AI client supports streaming
+func WithStreamingScores() ScoringQueueOption {
+	return func(q *ScoringQueueImpl) {
+		q.streaming = true
+	}
+}
+
+// NewScoringQueue creates a scoring queue that runs at most concurrency requests at once
+// and starts at most ratePerSec requests per second (0 disables the rate limit)
+func NewScoringQueue(aiClient AIClient, wsManager WebSocketManager, concurrency int, ratePerSec float64, opts ...ScoringQueueOption) ScoringQueue {
+	if concurrency <= 0 {
+		concurrency = DefaultScoringConcurrency
+	}
+
+	var minInterval time.Duration
+	if ratePerSec > 0 {
+		minInterval = time.Duration(float64(time.Second) / ratePerSec)
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	queue := &ScoringQueueImpl{
+		aiClient:     aiClient,
+		wsManager:    wsManager,
+		slots:        make(chan struct{}, concurrency),
+		minInterval:  minInterval,
+		concurrency:  concurrency,
+		waitTime:     collector.NewHistogram("ai_scoring_queue_wait_seconds", "Time scoring requests spend waiting in the queue", nil),
+		waitingGauge: collector.NewGauge("ai_scoring_queue_waiting", "Scoring requests waiting for a slot", nil),
+	}
+	for _, opt := range opts {
+		opt(queue)
+	}
+	return queue
+}
+
+// Score waits for a scoring slot, then scores the response with the AI client.
+// The submitting player is sent scoring-progress events as the request moves through the queue.
+func (q *ScoringQueueImpl) Score(ctx context.Context, sessionID, playerID string, door *models.Door, response string) (*ScoreResult, error) {
+	enqueuedAt := time.Now()
+
+	q.mu.Lock()
+	q.waiting++
+	position := q.waiting + q.inFlight
+	q.mu.Unlock()
+	q.waitingGauge.Inc()
+
+	q.notifyProgress(sessionID, playerID, "queued", position)
+
+	if err := q.acquire(ctx); err != nil {
+		q.mu.Lock()
+		q.waiting--
+		q.abandoned++
+		q.mu.Unlock()
+		q.waitingGauge.Dec()
+		return nil, fmt.Errorf("scoring request abandoned: %w", err)
+	}
+	defer q.release()
+
+	q.waitTime.Observe(time.Since(enqueuedAt).Seconds())
+	q.notifyProgress(sessionID, playerID, "scoring", 0)
+
+	result, err := q.score(ctx, sessionID, playerID, door, response)
+
+	q.mu.Lock()
+	q.completed++
+	q.mu.Unlock()
+
+	if err == nil {
+		q.notifyProgress(sessionID, playerID, "scored", 0)
+	}
+
+	return result, err
+}
+
+// score scores a response, streaming its metrics to the player as they arrive if enabled
+func (q *ScoringQueueImpl) score(ctx context.Context, sessionID, playerID string, door *models.Door, response string) (*ScoreResult, error) {
+	streamer, ok := q.aiClient.(StreamingScorer)
+	if !q.streaming || !ok {
+		return q.aiClient.ScoreResponse(ctx, door, response)
+	}
+
+	return streamer.StreamScoreResponse(ctx, door, response, func(progress ScoreProgress) {
+		q.sendProgress(sessionID, playerID, map[string]interface{}{
+			"status": "metric",
+			"metric": progress.Metric,
+			"score":  progress.Score,
+		})
+	})
+}
+
+// Stats returns a snapshot of the queue counters
+func (q *ScoringQueueImpl) Stats() ScoringQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ratePerSec := 0.0
+	if q.minInterval > 0 {
+		ratePerSec = float64(time.Second) / float64(q.minInterval)
+	}
+
+	return ScoringQueueStats{
+		Concurrency: q.concurrency,
+		RatePerSec:  ratePerSec,
+		Waiting:     q.waiting,
+		InFlight:    q.inFlight,
+		Completed:   q.completed,
+		Abandoned:   q.abandoned,
+	}
+}
+
+// acquire blocks until a concurrency slot is free and the rate limit allows a new request
+func (q *ScoringQueueImpl) acquire(ctx context.Context) error {
+	select {
+	case q.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Reserve the next start time so requests are spaced by minInterval
+	q.mu.Lock()
+	now := time.Now()
+	start := now
+	if q.nextStart.After(now) {
+		start = q.nextStart
+	}
+	q.nextStart = start.Add(q.minInterval)
+	q.mu.Unlock()
+
+	if delay := start.Sub(now); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			<-q.slots
+			return ctx.Err()
+		}
+	}
+
+	q.mu.Lock()
+	q.waiting--
+	q.inFlight++
+	q.mu.Unlock()
+	q.waitingGauge.Dec()
+
+	return nil
+}
+
+// release frees a concurrency slot
+func (q *ScoringQueueImpl) release() {
+	q.mu.Lock()
+	q.inFlight--
+	q.mu.Unlock()
+	<-q.slots
+}
+
+// notifyProgress tells the submitting player where their response is in the scoring pipeline
+func (q *ScoringQueueImpl) notifyProgress(sessionID, playerID, status string, position int) {
+	data := map[string]interface{}{
+		"status": status,
+	}
+	if position > 0 {
+		data["queuePosition"] = position
+	}
+	q.sendProgress(sessionID, playerID, data)
+}
+
+// sendProgress sends the submitting player a scoring-progress event
+func (q *ScoringQueueImpl) sendProgress(sessionID, playerID string, data map[string]interface{}) {
+	if q.wsManager == nil {
+		return
+	}
+
+	event := WebSocketEvent{
+		Type:      "scoring-progress",
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	// Progress events are best effort; the player may not have a socket open
+	q.wsManager.SendToPlayer(playerID, event)
+}
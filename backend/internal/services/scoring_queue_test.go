@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// MockAIClient implements AIClient for testing
+type MockAIClient struct {
+	scoreDelay  time.Duration
+	inFlight    int32
+	maxInFlight int32
+	scoreCalls  int32
+	flagged     []string // categories returned by ModerateContent
+	moderateErr error
+	batchCalls  int32
+	batchErr    error
+}
+
+func (m *MockAIClient) GenerateDoor(ctx context.Context, theme string, difficulty int, locale string) (*models.Door, error) {
+	return &models.Door{DoorID: "mock-door", Theme: theme, Difficulty: difficulty, Locale: locale}, nil
+}
+
+func (m *MockAIClient) ScoreResponse(ctx context.Context, door *models.Door, response string) (*ScoreResult, error) {
+	current := atomic.AddInt32(&m.inFlight, 1)
+	defer atomic.AddInt32(&m.inFlight, -1)
+	atomic.AddInt32(&m.scoreCalls, 1)
+
+	for {
+		max := atomic.LoadInt32(&m.maxInFlight)
+		if current <= max || atomic.CompareAndSwapInt32(&m.maxInFlight, max, current) {
+			break
+		}
+	}
+
+	time.Sleep(m.scoreDelay)
+	return &ScoreResult{
+		Metrics:  models.ScoringMetrics{Creativity: 70, Feasibility: 70, Humor: 70, Originality: 70},
+		Feedback: "Solid, if a little safe.",
+	}, nil
+}
+
+func (m *MockAIClient) ScoreResponses(ctx context.Context, requests []ScoreRequest) ([]*ScoreResult, error) {
+	atomic.AddInt32(&m.batchCalls, 1)
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+
+	results := make([]*ScoreResult, len(requests))
+	for i, request := range requests {
+		results[i], _ = m.ScoreResponse(ctx, request.Door, request.Response)
+	}
+	return results, nil
+}
+
+func (m *MockAIClient) GetThemedDoors(ctx context.Context, theme string, count int) ([]*models.Door, error) {
+	return nil, nil
+}
+
+func (m *MockAIClient) GetNextDoorForPlayer(ctx context.Context, playerID, currentDoorID string, latestScore float64) (*NextDoorResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAIClient) InitializePlayerJourney(ctx context.Context, playerID, theme, difficulty string) (*PlayerJourneyResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAIClient) GetPlayerProgress(ctx context.Context, playerID string) (*PlayerProgressResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAIClient) HealthCheck(ctx context.Context) (*HealthCheckResponse, error) {
+	return &HealthCheckResponse{Status: "healthy"}, nil
+}
+
+func (m *MockAIClient) ModerateContent(ctx context.Context, text string) (*ModerationVerdict, error) {
+	if m.moderateErr != nil {
+		return nil, m.moderateErr
+	}
+	return &ModerationVerdict{Flagged: len(m.flagged) > 0, Categories: m.flagged}, nil
+}
+
+func (m *MockAIClient) GenerateResponse(ctx context.Context, door *models.Door, skill int) (string, error) {
+	return fmt.Sprintf("I would open %s carefully", door.DoorID), nil
+}
+
+// recordingWebSocketManager records events sent to individual players
+type recordingWebSocketManager struct {
+	*MockWebSocketManager
+	mu     sync.Mutex
+	events []WebSocketEvent
+}
+
+func (m *recordingWebSocketManager) SendToPlayer(playerID string, event WebSocketEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+	return nil
+}
+
+func TestScoringQueue_LimitsConcurrency(t *testing.T) {
+	aiClient := &MockAIClient{scoreDelay: 20 * time.Millisecond}
+	queue := NewScoringQueue(aiClient, nil, 2, 0)
+
+	door := &models.Door{DoorID: "door-1", Content: "test door"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := queue.Score(context.Background(), "session", "player", door, "response"); err != nil {
+				t.Errorf("Unexpected scoring error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if aiClient.maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent scoring calls, got %d", aiClient.maxInFlight)
+	}
+	if aiClient.scoreCalls != 8 {
+		t.Errorf("Expected 8 scoring calls, got %d", aiClient.scoreCalls)
+	}
+	if stats := queue.Stats(); stats.Completed != 8 || stats.Waiting != 0 || stats.InFlight != 0 {
+		t.Errorf("Unexpected queue stats after drain: %+v", stats)
+	}
+}
+
+func TestScoringQueue_RateLimitsStarts(t *testing.T) {
+	aiClient := &MockAIClient{}
+	queue := NewScoringQueue(aiClient, nil, 8, 50) // one start every 20ms
+
+	door := &models.Door{DoorID: "door-1"}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			queue.Score(context.Background(), "session", "player", door, "response")
+		}()
+	}
+	wg.Wait()
+
+	// Five starts spaced 20ms apart take at least 80ms
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected rate limit to spread starts over at least 80ms, took %v", elapsed)
+	}
+}
+
+func TestScoringQueue_AbandonsOnContextCancel(t *testing.T) {
+	aiClient := &MockAIClient{scoreDelay: 100 * time.Millisecond}
+	queue := NewScoringQueue(aiClient, nil, 1, 0)
+	door := &models.Door{DoorID: "door-1"}
+
+	go queue.Score(context.Background(), "session", "player1", door, "response")
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := queue.Score(ctx, "session", "player2", door, "response"); err == nil {
+		t.Fatal("Expected queued request to be abandoned when its context expires")
+	}
+
+	if stats := queue.Stats(); stats.Abandoned != 1 {
+		t.Errorf("Expected 1 abandoned request, got %d", stats.Abandoned)
+	}
+}
+
+func TestScoringQueue_SendsProgressEvents(t *testing.T) {
+	wsManager := &recordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	queue := NewScoringQueue(&MockAIClient{}, wsManager, 1, 0)
+
+	if _, err := queue.Score(context.Background(), "session", "player1", &models.Door{DoorID: "door-1"}, "response"); err != nil {
+		t.Fatalf("Unexpected scoring error: %v", err)
+	}
+
+	var statuses []string
+	for _, event := range wsManager.events {
+		if event.Type != "scoring-progress" {
+			t.Errorf("Unexpected event type %s", event.Type)
+		}
+		statuses = append(statuses, event.Data.(map[string]interface{})["status"].(string))
+	}
+
+	expected := []string{"queued", "scoring", "scored"}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Expected statuses %v, got %v", expected, statuses)
+	}
+	for i := range expected {
+		if statuses[i] != expected[i] {
+			t.Errorf("Expected status %s at %d, got %s", expected[i], i, statuses[i])
+		}
+	}
+}
+
+func TestSubmitResponse_ReturnsScoringFeedback(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newBatchedScoringSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	)
+
+	response, err := gameService.SubmitResponse(ctx, "s1", "p1", "I would pick the lock.")
+	if err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+	if response.AIScore != 70 || response.Feedback != "Solid, if a little safe." {
+		t.Errorf("Expected the scored response with its feedback, got %+v", response)
+	}
+
+	stored := gameSessionRepo.sessions["s1"].Players[0].Responses[0]
+	if stored.Feedback != response.Feedback {
+		t.Errorf("Expected feedback to be persisted, got %q", stored.Feedback)
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"dumdoors-backend/internal/models"
+	"fmt"
+)
+
+// ScoringStrategy combines the AI service's metrics for a response into its total score
+type ScoringStrategy interface {
+	Name() models.ScoringStrategy
+	Score(door *models.Door, metrics models.ScoringMetrics) int
+}
+
+// MetricWeights sets how much each metric counts towards a weighted score. The weights need
+// not add up to one; they are normalised.
+type MetricWeights struct {
+	Creativity  float64
+	Feasibility float64
+	Humor       float64
+	Originality float64
+}
+
+// DefaultMetricWeights favour funny, inventive answers over practical ones
+var DefaultMetricWeights = MetricWeights{Creativity: 0.3, Feasibility: 0.15, Humor: 0.3, Originality: 0.25}
+
+// ThemeMetricWeights adjust the weights for themes whose doors call for a particular kind of answer
+var ThemeMetricWeights = map[string]MetricWeights{
+	"comedy":     {Creativity: 0.2, Feasibility: 0.1, Humor: 0.5, Originality: 0.2},
+	"survival":   {Creativity: 0.2, Feasibility: 0.45, Humor: 0.1, Originality: 0.25},
+	"technology": {Creativity: 0.25, Feasibility: 0.35, Humor: 0.15, Originality: 0.25},
+	"mystery":    {Creativity: 0.35, Feasibility: 0.25, Humor: 0.1, Originality: 0.3},
+	"workplace":  {Creativity: 0.25, Feasibility: 0.3, Humor: 0.25, Originality: 0.2},
+}
+
+// RubricCriterion awards up to Points for one metric, in quarters by the band the metric falls in
+type RubricCriterion struct {
+	Metric string
+	Points int
+}
+
+// DefaultRubric is worth 100 points in total
+var DefaultRubric = []RubricCriterion{
+	{Metric: "creativity", Points: 30},
+	{Metric: "humor", Points: 30},
+	{Metric: "originality", Points: 25},
+	{Metric: "feasibility", Points: 15},
+}
+
+// rubricBands are the lowest metric scores for a quarter, half, three quarters and all of a criterion's points
+var rubricBands = [4]int{25, 50, 70, 85}
+
+// WithScoringStrategy sets the strategy used for sessions that don't choose their own
+func WithScoringStrategy(strategy ScoringStrategy) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.scoringStrategy = strategy
+	}
+}
+
+// NewScoringStrategy returns the built-in strategy with the given name
+func NewScoringStrategy(name models.ScoringStrategy) (ScoringStrategy, error) {
+	switch name {
+	case "", models.ScoringStrategyAverage:
+		return averageStrategy{}, nil
+	case models.ScoringStrategyWeighted:
+		return weightedStrategy{weights: DefaultMetricWeights}, nil
+	case models.ScoringStrategyMaxMetric:
+		return maxMetricStrategy{}, nil
+	case models.ScoringStrategyThemeWeighted:
+		return themeWeightedStrategy{themes: ThemeMetricWeights, fallback: DefaultMetricWeights}, nil
+	case models.ScoringStrategyRubric:
+		return rubricStrategy{criteria: DefaultRubric}, nil
+	}
+	return nil, fmt.Errorf("unknown scoring strategy %q", name)
+}
+
+// strategyFor picks the strategy for a session: its own choice, otherwise the server default
+func (s *GameServiceImpl) strategyFor(name models.ScoringStrategy) ScoringStrategy {
+	if name != "" {
+		if strategy, err := NewScoringStrategy(name); err == nil {
+			return strategy
+		}
+		fmt.Printf("Warning: unknown scoring strategy %q, using the default\n", name)
+	}
+	if s.scoringStrategy != nil {
+		return s.scoringStrategy
+	}
+	return averageStrategy{}
+}
+
+// averageStrategy scores a response as the plain average of its metrics
+type averageStrategy struct{}
+
+func (averageStrategy) Name() models.ScoringStrategy { return models.ScoringStrategyAverage }
+
+func (averageStrategy) Score(door *models.Door, metrics models.ScoringMetrics) int {
+	return averageScore(metrics)
+}
+
+// weightedStrategy scores a response as a weighted average of its metrics
+type weightedStrategy struct {
+	weights MetricWeights
+}
+
+func (weightedStrategy) Name() models.ScoringStrategy { return models.ScoringStrategyWeighted }
+
+func (w weightedStrategy) Score(door *models.Door, metrics models.ScoringMetrics) int {
+	return weightedScore(w.weights, metrics)
+}
+
+// maxMetricStrategy scores a response by its best metric, rewarding answers that excel at one thing
+type maxMetricStrategy struct{}
+
+func (maxMetricStrategy) Name() models.ScoringStrategy { return models.ScoringStrategyMaxMetric }
+
+func (maxMetricStrategy) Score(door *models.Door, metrics models.ScoringMetrics) int {
+	best := metrics.Creativity
+	for _, score := range []int{metrics.Feasibility, metrics.Humor, metrics.Originality} {
+		if score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// themeWeightedStrategy weights the metrics by the door's theme
+type themeWeightedStrategy struct {
+	themes   map[string]MetricWeights
+	fallback MetricWeights
+}
+
+func (themeWeightedStrategy) Name() models.ScoringStrategy {
+	return models.ScoringStrategyThemeWeighted
+}
+
+func (t themeWeightedStrategy) Score(door *models.Door, metrics models.ScoringMetrics) int {
+	weights := t.fallback
+	if door != nil {
+		if themed, ok := t.themes[door.Theme]; ok {
+			weights = themed
+		}
+	}
+	return weightedScore(weights, metrics)
+}
+
+// rubricStrategy grades each metric into bands and adds up the points the bands earn
+type rubricStrategy struct {
+	criteria []RubricCriterion
+}
+
+func (rubricStrategy) Name() models.ScoringStrategy { return models.ScoringStrategyRubric }
+
+func (r rubricStrategy) Score(door *models.Door, metrics models.ScoringMetrics) int {
+	earned, possible := 0, 0
+	for _, criterion := range r.criteria {
+		score := metricScore(metrics, criterion.Metric)
+		band := 0
+		for _, threshold := range rubricBands {
+			if score >= threshold {
+				band++
+			}
+		}
+		earned += criterion.Points * band
+		possible += criterion.Points * len(rubricBands)
+	}
+	if possible == 0 {
+		return 0
+	}
+	return earned * 100 / possible
+}
+
+// weightedScore is the weighted average of the metrics, rounded to the nearest point
+func weightedScore(weights MetricWeights, metrics models.ScoringMetrics) int {
+	total := weights.Creativity + weights.Feasibility + weights.Humor + weights.Originality
+	if total <= 0 {
+		return averageScore(metrics)
+	}
+	sum := weights.Creativity*float64(metrics.Creativity) +
+		weights.Feasibility*float64(metrics.Feasibility) +
+		weights.Humor*float64(metrics.Humor) +
+		weights.Originality*float64(metrics.Originality)
+	return int(sum/total + 0.5)
+}
+
+// metricScore looks up a metric by the name the AI service uses for it
+func metricScore(metrics models.ScoringMetrics, metric string) int {
+	switch metric {
+	case "creativity":
+		return metrics.Creativity
+	case "feasibility":
+		return metrics.Feasibility
+	case "humor":
+		return metrics.Humor
+	case "originality":
+		return metrics.Originality
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestScoringStrategies(t *testing.T) {
+	metrics := models.ScoringMetrics{Creativity: 90, Feasibility: 20, Humor: 60, Originality: 50}
+	tests := []struct {
+		name     models.ScoringStrategy
+		theme    string
+		expected int
+	}{
+		{models.ScoringStrategyAverage, "general", 55},
+		{models.ScoringStrategyWeighted, "general", 61},
+		{models.ScoringStrategyMaxMetric, "general", 90},
+		{models.ScoringStrategyThemeWeighted, "general", 61},
+		{models.ScoringStrategyThemeWeighted, "survival", 46},
+		{models.ScoringStrategyRubric, "general", 57},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.name)+"/"+tt.theme, func(t *testing.T) {
+			strategy, err := NewScoringStrategy(tt.name)
+			if err != nil {
+				t.Fatalf("NewScoringStrategy failed: %v", err)
+			}
+			if strategy.Name() != tt.name {
+				t.Errorf("Expected strategy %s, got %s", tt.name, strategy.Name())
+			}
+			if score := strategy.Score(&models.Door{Theme: tt.theme}, metrics); score != tt.expected {
+				t.Errorf("Expected score %d, got %d", tt.expected, score)
+			}
+		})
+	}
+
+	if _, err := NewScoringStrategy("median"); err == nil {
+		t.Error("Expected an unknown strategy to be rejected")
+	}
+}
+
+// unevenAIClient scores every response with metrics the strategies disagree on
+type unevenAIClient struct {
+	MockAIClient
+}
+
+func (c *unevenAIClient) ScoreResponse(ctx context.Context, door *models.Door, response string) (*ScoreResult, error) {
+	return &ScoreResult{Metrics: models.ScoringMetrics{Creativity: 90, Feasibility: 20, Humor: 60, Originality: 50}}, nil
+}
+
+func TestSubmitResponse_AppliesScoringStrategy(t *testing.T) {
+	maxMetric, _ := NewScoringStrategy(models.ScoringStrategyMaxMetric)
+	tests := []struct {
+		name             string
+		session          models.ScoringStrategy
+		opts             []GameServiceOption
+		expectedScore    int
+		expectedStrategy models.ScoringStrategy
+	}{
+		{"built-in default", "", nil, 55, models.ScoringStrategyAverage},
+		{"server default", "", []GameServiceOption{WithScoringStrategy(maxMetric)}, 90, models.ScoringStrategyMaxMetric},
+		{"session choice", models.ScoringStrategyRubric, []GameServiceOption{WithScoringStrategy(maxMetric)}, 57, models.ScoringStrategyRubric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameSessionRepo := NewMockGameSessionRepository()
+			session := newBatchedScoringSession()
+			session.Settings.ScoringStrategy = tt.session
+			gameSessionRepo.sessions["s1"] = session
+			opts := append([]GameServiceOption{WithWorkerPool(discardWorkerPool{})}, tt.opts...)
+			gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &unevenAIClient{}, nil, nil, opts...)
+
+			response, err := gameService.SubmitResponse(context.Background(), "s1", "p1", "I would pick the lock.")
+			if err != nil {
+				t.Fatalf("SubmitResponse failed: %v", err)
+			}
+			if response.AIScore != tt.expectedScore {
+				t.Errorf("Expected score %d, got %d", tt.expectedScore, response.AIScore)
+			}
+
+			stored := gameSessionRepo.sessions["s1"].Players[0].Responses[0]
+			if stored.ScoringStrategy != tt.expectedStrategy {
+				t.Errorf("Expected strategy %q to be persisted, got %q", tt.expectedStrategy, stored.ScoringStrategy)
+			}
+		})
+	}
+}
+
+func TestValidateSessionSettings_ScoringStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings models.SessionSettings
+		valid    bool
+	}{
+		{"default", models.SessionSettings{}, true},
+		{"known strategy", models.SessionSettings{ScoringStrategy: models.ScoringStrategyThemeWeighted}, true},
+		{"unknown strategy", models.SessionSettings{ScoringStrategy: "median"}, false},
+		{"peer vote", models.SessionSettings{ScoringMode: models.ScoringModePeerVote, ScoringStrategy: models.ScoringStrategyRubric}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSessionSettings(models.GameModeMultiplayer, tt.settings)
+			if tt.valid && err != nil {
+				t.Errorf("Expected settings to be valid, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSessionSettings) {
+				t.Errorf("Expected ErrInvalidSessionSettings, got %v", err)
+			}
+		})
+	}
+}
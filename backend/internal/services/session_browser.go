@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"dumdoors-backend/internal/tracing"
+	"fmt"
+	"time"
+)
+
+// Open session listing limits
+const (
+	DefaultOpenSessionsLimit = 20
+	MaxOpenSessionsLimit     = 50
+)
+
+// WithOpenSessionCache serves the open session listing from a short-lived cache
+func WithOpenSessionCache(cache repositories.OpenSessionCache) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.openSessions = cache
+	}
+}
+
+// ListOpenSessions returns public multiplayer sessions that are waiting for players and have
+// room to join, newest first
+func (s *GameServiceImpl) ListOpenSessions(ctx context.Context, limit int) ([]models.OpenSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.ListOpenSessions")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = DefaultOpenSessionsLimit
+	}
+	if limit > MaxOpenSessionsLimit {
+		limit = MaxOpenSessionsLimit
+	}
+
+	if s.openSessions != nil {
+		cached, err := s.openSessions.Get(ctx, limit)
+		if err != nil {
+			fmt.Printf("Warning: failed to read open sessions cache: %v\n", err)
+		} else if cached != nil {
+			return withSessionAges(cached, time.Now()), nil
+		}
+	}
+
+	sessions, err := s.gameSessionRepo.FindOpenSessions(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open sessions: %w", err)
+	}
+
+	listing := make([]models.OpenSession, 0, len(sessions))
+	for _, session := range sessions {
+		listing = append(listing, summarizeOpenSession(session))
+	}
+
+	if s.openSessions != nil {
+		if err := s.openSessions.Put(ctx, limit, listing); err != nil {
+			fmt.Printf("Warning: failed to cache open sessions: %v\n", err)
+		}
+	}
+
+	return withSessionAges(listing, time.Now()), nil
+}
+
+// summarizeOpenSession describes a session for the open session listing
+func summarizeOpenSession(session *models.GameSession) models.OpenSession {
+	summary := models.OpenSession{
+		SessionID:   session.SessionID,
+		JoinCode:    session.JoinCode,
+		Theme:       session.Theme,
+		Locale:      session.Locale,
+		ScoringMode: session.Settings.ScoringMode,
+		PlayerCount: len(session.Players),
+		MaxPlayers:  models.MaxMultiplayerPlayers,
+		CreatedAt:   session.CreatedAt,
+	}
+	if session.Settings.TeamPlay() {
+		summary.MaxPlayers = len(models.TeamIDs) * session.Settings.TeamSize
+	}
+	hostID := session.Host()
+	for _, player := range session.Players {
+		if player.PlayerID == hostID {
+			summary.HostUsername = player.Username
+			break
+		}
+	}
+	return summary
+}
+
+// withSessionAges stamps each listed session with its age; ages are left out of the cache
+// so cached listings don't report stale ages
+func withSessionAges(sessions []models.OpenSession, now time.Time) []models.OpenSession {
+	for i := range sessions {
+		sessions[i].AgeSeconds = int(now.Sub(sessions[i].CreatedAt).Seconds())
+	}
+	return sessions
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// memoryOpenSessionCache is an in-memory OpenSessionCache
+type memoryOpenSessionCache struct {
+	listings map[int][]models.OpenSession
+}
+
+func (m *memoryOpenSessionCache) Get(ctx context.Context, limit int) ([]models.OpenSession, error) {
+	listing, exists := m.listings[limit]
+	if !exists {
+		return nil, nil
+	}
+	return append([]models.OpenSession{}, listing...), nil
+}
+
+func (m *memoryOpenSessionCache) Put(ctx context.Context, limit int, sessions []models.OpenSession) error {
+	m.listings[limit] = append([]models.OpenSession{}, sessions...)
+	return nil
+}
+
+func newOpenSession(sessionID string, players int, age time.Duration) *models.GameSession {
+	session := &models.GameSession{
+		SessionID: sessionID,
+		Mode:      models.GameModeMultiplayer,
+		Status:    models.GameStatusWaiting,
+		CreatedAt: time.Now().Add(-age),
+	}
+	for i := 0; i < players; i++ {
+		session.Players = append(session.Players, models.PlayerInfo{PlayerID: fmt.Sprintf("%s-p%d", sessionID, i), Username: fmt.Sprintf("Player %d", i), IsActive: true})
+	}
+	return session
+}
+
+func TestListOpenSessions_OnlyJoinableSessions(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["older"] = newOpenSession("older", 2, 2*time.Minute)
+	gameSessionRepo.sessions["newer"] = newOpenSession("newer", 1, 10*time.Second)
+
+	full := newOpenSession("full", models.MaxMultiplayerPlayers, time.Minute)
+	private := newOpenSession("private", 1, time.Minute)
+	private.Settings.IsPrivate = true
+	started := newOpenSession("started", 2, time.Minute)
+	started.Status = models.GameStatusActive
+	solo := newOpenSession("solo", 1, time.Minute)
+	solo.Mode = models.GameModeSinglePlayer
+	for _, session := range []*models.GameSession{full, private, started, solo} {
+		gameSessionRepo.sessions[session.SessionID] = session
+	}
+
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+	sessions, err := gameService.ListOpenSessions(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ListOpenSessions failed: %v", err)
+	}
+
+	if len(sessions) != 2 || sessions[0].SessionID != "newer" || sessions[1].SessionID != "older" {
+		t.Fatalf("Expected the two joinable sessions newest first, got %+v", sessions)
+	}
+	older := sessions[1]
+	if older.PlayerCount != 2 || older.MaxPlayers != models.MaxMultiplayerPlayers || older.HostUsername != "Player 0" {
+		t.Errorf("Unexpected summary: %+v", older)
+	}
+	if older.AgeSeconds < 119 || older.AgeSeconds > 121 {
+		t.Errorf("Expected an age of about 120 seconds, got %d", older.AgeSeconds)
+	}
+}
+
+func TestListOpenSessions_ServesCachedListing(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newOpenSession("s1", 1, time.Minute)
+	cache := &memoryOpenSessionCache{listings: make(map[int][]models.OpenSession)}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithOpenSessionCache(cache),
+	)
+
+	if _, err := gameService.ListOpenSessions(ctx, 10); err != nil {
+		t.Fatalf("ListOpenSessions failed: %v", err)
+	}
+	if cached := cache.listings[10]; len(cached) != 1 || cached[0].AgeSeconds != 0 {
+		t.Fatalf("Expected the listing to be cached without ages, got %+v", cached)
+	}
+
+	// Sessions created while the listing is cached wait for it to expire
+	gameSessionRepo.sessions["s2"] = newOpenSession("s2", 1, 0)
+	sessions, err := gameService.ListOpenSessions(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListOpenSessions failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].SessionID != "s1" || sessions[0].AgeSeconds < 59 {
+		t.Errorf("Expected the cached listing with a fresh age, got %+v", sessions)
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"time"
+)
+
+// SessionHistoryService keeps each session's event log: when doors were presented, when each
+// response reached the server, how it was scored and who ran out of time. Players can read it
+// back to settle disputes about the timer or a score.
+type SessionHistoryService interface {
+	Record(ctx context.Context, event *models.SessionEvent) error
+	GetHistory(ctx context.Context, sessionID, playerID string) (*models.SessionHistory, error)
+}
+
+// SessionHistoryServiceImpl implements the SessionHistoryService interface
+type SessionHistoryServiceImpl struct {
+	eventRepo       repositories.SessionEventRepository
+	gameSessionRepo repositories.GameSessionRepository
+}
+
+// NewSessionHistoryService creates a new session history service
+func NewSessionHistoryService(eventRepo repositories.SessionEventRepository, gameSessionRepo repositories.GameSessionRepository) SessionHistoryService {
+	return &SessionHistoryServiceImpl{
+		eventRepo:       eventRepo,
+		gameSessionRepo: gameSessionRepo,
+	}
+}
+
+// Record stores an event, stamping it with an ID and, if it has none, the current time
+func (s *SessionHistoryServiceImpl) Record(ctx context.Context, event *models.SessionEvent) error {
+	event.EventID = fmt.Sprintf("event_%s", random.ID())
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	if err := s.eventRepo.Append(ctx, event); err != nil {
+		return fmt.Errorf("failed to record %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+// GetHistory returns a session's event log to someone who played in it, including players
+// who have since left
+func (s *SessionHistoryServiceImpl) GetHistory(ctx context.Context, sessionID, playerID string) (*models.SessionHistory, error) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if !playedIn(session, playerID) {
+		return nil, ErrPlayerNotInSession
+	}
+
+	events, err := s.eventRepo.List(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session history: %w", err)
+	}
+	return &models.SessionHistory{SessionID: sessionID, Events: events}, nil
+}
+
+// WithSessionHistory records door, response, score and timeout events in each session's history
+func WithSessionHistory(history SessionHistoryService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.history = history
+	}
+}
+
+// recordEvent adds an event to its session's history in the background. The event's time is
+// taken before it is queued, so a slow write doesn't move it.
+func (s *GameServiceImpl) recordEvent(ctx context.Context, event *models.SessionEvent) {
+	if s.history == nil {
+		return
+	}
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	s.runInBackground(ctx, event.SessionID, "record-session-event", func(ctx context.Context) {
+		if err := s.history.Record(ctx, event); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	})
+}
+
+// recordScore records the AI score given to a response and the metrics it was averaged from
+func (s *GameServiceImpl) recordScore(ctx context.Context, sessionID string, response models.PlayerResponse, fallback bool) {
+	score, metrics := response.AIScore, response.ScoringMetrics
+	s.recordEvent(ctx, &models.SessionEvent{
+		SessionID:  sessionID,
+		Type:       models.SessionEventResponseScored,
+		PlayerID:   response.PlayerID,
+		DoorID:     response.DoorID,
+		ResponseID: response.ResponseID,
+		Score:      &score,
+		Metrics:    &metrics,
+		Fallback:   fallback,
+	})
+}
+
+// recordMissedResponses records each active player who had a door open but didn't answer it
+// before the deadline
+func (s *GameServiceImpl) recordMissedResponses(ctx context.Context, session *models.GameSession) {
+	for _, player := range session.Players {
+		door := session.DoorForPlayer(player.PlayerID)
+		if !player.IsActive || door == nil || hasRespondedToDoor(session, player.PlayerID, door.DoorID) {
+			continue
+		}
+		s.recordEvent(ctx, &models.SessionEvent{
+			SessionID: session.SessionID,
+			Type:      models.SessionEventResponseMissed,
+			PlayerID:  player.PlayerID,
+			DoorID:    door.DoorID,
+			Deadline:  session.RoundDeadline,
+		})
+	}
+}
+
+// playedIn reports whether the player has a seat in the session, active or not
+func playedIn(session *models.GameSession, playerID string) bool {
+	for _, player := range session.Players {
+		if player.PlayerID == playerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memorySessionEventRepository is an in-memory SessionEventRepository
+type memorySessionEventRepository struct {
+	mu     sync.Mutex
+	events []*models.SessionEvent
+}
+
+func (r *memorySessionEventRepository) Append(ctx context.Context, event *models.SessionEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memorySessionEventRepository) List(ctx context.Context, sessionID string) ([]*models.SessionEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var events []*models.SessionEvent
+	for _, event := range r.events {
+		if event.SessionID == sessionID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func TestSessionHistory_RecordsResponseTimings(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newBatchedScoringSession()
+	deadline := time.Now().Add(time.Minute)
+	session.RoundDeadline = &deadline
+	gameSessionRepo.sessions["s1"] = session
+	history := NewSessionHistoryService(&memorySessionEventRepository{}, gameSessionRepo)
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+		WithSessionHistory(history),
+	)
+
+	before := time.Now()
+	response, err := gameService.SubmitResponse(ctx, "s1", "p1", "I would pick the lock.")
+	if err != nil {
+		t.Fatalf("SubmitResponse failed: %v", err)
+	}
+
+	// p3 runs out of time and p2's answer arrives after the round has closed
+	session.Players[1].Responses = []models.PlayerResponse{{DoorID: "door-1"}}
+	gameService.(*GameServiceImpl).recordMissedResponses(ctx, session)
+	session.Status = models.GameStatusScoring
+	if _, err := gameService.SubmitResponse(ctx, "s1", "p2", "Too late"); !errors.Is(err, ErrIllegalOperation) {
+		t.Fatalf("Expected the late response to be refused, got %v", err)
+	}
+
+	log, err := history.GetHistory(ctx, "s1", "p2")
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	types := make([]models.SessionEventType, len(log.Events))
+	for i, event := range log.Events {
+		types[i] = event.Type
+	}
+	expected := []models.SessionEventType{
+		models.SessionEventResponseSubmitted,
+		models.SessionEventResponseScored,
+		models.SessionEventResponseMissed,
+		models.SessionEventResponseLate,
+	}
+	if len(types) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, types)
+	}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf("Expected %s at %d, got %s", expected[i], i, types[i])
+		}
+	}
+
+	submitted, scored := log.Events[0], log.Events[1]
+	if submitted.At.Before(before) || submitted.At.After(response.SubmittedAt) {
+		t.Errorf("Expected the submission to be logged when it arrived, got %v", submitted.At)
+	}
+	if submitted.Deadline == nil || !submitted.Deadline.Equal(deadline) {
+		t.Errorf("Expected the submission to carry the round deadline, got %v", submitted.Deadline)
+	}
+	if scored.Score == nil || *scored.Score != response.AIScore || scored.Metrics == nil {
+		t.Errorf("Expected the score and its metrics to be logged, got %+v", scored)
+	}
+	if missed := log.Events[2]; missed.PlayerID != "p3" {
+		t.Errorf("Expected p3 to be logged as missing the deadline, got %s", missed.PlayerID)
+	}
+
+	if _, err := history.GetHistory(ctx, "s1", "stranger"); !errors.Is(err, ErrPlayerNotInSession) {
+		t.Errorf("Expected ErrPlayerNotInSession for someone who didn't play, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+import "sync"
+
+// sessionLocks serializes read-modify-write updates to the same session on this instance
+type sessionLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sessionLock
+}
+
+// sessionLock is one session's mutex and the number of callers holding or waiting for it
+type sessionLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// newSessionLocks creates an empty set of session locks
+func newSessionLocks() *sessionLocks {
+	return &sessionLocks{locks: make(map[string]*sessionLock)}
+}
+
+// Lock blocks until the caller holds the session's lock and returns a function releasing it.
+// The release function may be called more than once.
+func (l *sessionLocks) Lock(sessionID string) func() {
+	l.mu.Lock()
+	lock, exists := l.locks[sessionID]
+	if !exists {
+		lock = &sessionLock{}
+		l.locks[sessionID] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.mu.Lock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			lock.mu.Unlock()
+
+			l.mu.Lock()
+			lock.refs--
+			if lock.refs == 0 {
+				delete(l.locks, sessionID)
+			}
+			l.mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"time"
+)
+
+// DefaultSessionMetricsInterval is how often session and player gauges are refreshed
+const DefaultSessionMetricsInterval = 30 * time.Second
+
+// noThemeLabel labels sessions played without a theme in the per-theme series
+const noThemeLabel = "none"
+
+// SessionMetricsReporter keeps the active session, player and connection gauges up to date so
+// autoscalers and dashboards can read load from the metrics endpoint
+type SessionMetricsReporter interface {
+	Start(ctx context.Context)
+	Report(ctx context.Context) error
+}
+
+// SessionMetricsReporterImpl implements the SessionMetricsReporter interface
+type SessionMetricsReporterImpl struct {
+	gameSessionRepo repositories.GameSessionRepository
+	wsManager       WebSocketManager
+	collector       *monitoring.MetricsCollector
+	interval        time.Duration
+
+	// label values reported per breakdown gauge, so series that empty out are set back to zero
+	reported map[string]map[string]bool
+}
+
+// NewSessionMetricsReporter creates a reporter that refreshes the collector's gauges every interval
+func NewSessionMetricsReporter(gameSessionRepo repositories.GameSessionRepository, wsManager WebSocketManager, collector *monitoring.MetricsCollector, interval time.Duration) SessionMetricsReporter {
+	if interval <= 0 {
+		interval = DefaultSessionMetricsInterval
+	}
+	return &SessionMetricsReporterImpl{
+		gameSessionRepo: gameSessionRepo,
+		wsManager:       wsManager,
+		collector:       collector,
+		interval:        interval,
+		reported:        make(map[string]map[string]bool),
+	}
+}
+
+// Start reports immediately and then every interval until the context is cancelled
+func (r *SessionMetricsReporterImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Report(ctx); err != nil {
+			fmt.Printf("Warning: failed to report session metrics: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report counts the unfinished sessions and the players in them, broken down by status, mode
+// and theme, and the connections open on this instance. Nothing is updated if a query fails,
+// so a database blip doesn't read as a drop in load.
+func (r *SessionMetricsReporterImpl) Report(ctx context.Context) error {
+	byStatus := make(map[string]int)
+	sessionsByMode := make(map[string]int)
+	sessionsByTheme := make(map[string]int)
+	playersByMode := make(map[string]int)
+	playersByTheme := make(map[string]int)
+	sessions, players := 0, 0
+
+	for _, status := range unfinishedStatuses {
+		found, err := r.gameSessionRepo.GetActiveSessionsByStatus(ctx, status)
+		if err != nil {
+			return fmt.Errorf("failed to list %s sessions: %w", status, err)
+		}
+
+		byStatus[string(status)] = len(found)
+		for _, session := range found {
+			mode, theme := string(session.Mode), themeLabel(session)
+			active := activeHumans(session)
+
+			sessions++
+			players += active
+			sessionsByMode[mode]++
+			sessionsByTheme[theme]++
+			playersByMode[mode] += active
+			playersByTheme[theme] += active
+		}
+	}
+
+	r.collector.SetActiveGameSessions(sessions)
+	r.collector.SetActivePlayers(players)
+	r.setBreakdown("game_sessions_by_status", "Unfinished game sessions by status", "status", byStatus)
+	r.setBreakdown("game_sessions_by_mode", "Unfinished game sessions by game mode", "mode", sessionsByMode)
+	r.setBreakdown("game_sessions_by_theme", "Unfinished game sessions by theme", "theme", sessionsByTheme)
+	r.setBreakdown("players_by_mode", "Active players by game mode", "mode", playersByMode)
+	r.setBreakdown("players_by_theme", "Active players by theme", "theme", playersByTheme)
+
+	if r.wsManager != nil {
+		connections := 0
+		for _, sessionID := range r.wsManager.LocalSessionIDs() {
+			connections += len(r.wsManager.GetActiveConnections(sessionID)) + r.wsManager.GetSpectatorCount(sessionID)
+		}
+		r.collector.SetActiveConnections(connections)
+	}
+	return nil
+}
+
+// setBreakdown sets one gauge series per label value, zeroing values reported before that
+// have no sessions now
+func (r *SessionMetricsReporterImpl) setBreakdown(name, help, label string, counts map[string]int) {
+	seen := r.reported[name]
+	if seen == nil {
+		seen = make(map[string]bool)
+		r.reported[name] = seen
+	}
+	for value := range seen {
+		if _, ok := counts[value]; !ok {
+			counts[value] = 0
+		}
+	}
+
+	for value, count := range counts {
+		r.collector.NewGauge(name, help, map[string]string{label: value}).Set(float64(count))
+		seen[value] = true
+	}
+}
+
+// themeLabel returns the session's theme for the per-theme series
+func themeLabel(session *models.GameSession) string {
+	if session.Theme == nil || *session.Theme == "" {
+		return noThemeLabel
+	}
+	return *session.Theme
+}
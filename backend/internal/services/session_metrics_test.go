@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"testing"
+)
+
+// gaugeValue returns the value of the series with the given label, or -1 if there is none
+func gaugeValue(collector *monitoring.MetricsCollector, name, label, value string) float64 {
+	for _, family := range collector.GetMetricFamilies() {
+		if family.Name != name {
+			continue
+		}
+		for _, series := range family.Series {
+			if series.Labels[label] == value {
+				return series.Value
+			}
+		}
+	}
+	return -1
+}
+
+func TestSessionMetricsReporter_ReportsBreakdowns(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	spooky := "spooky"
+	gameSessionRepo.sessions["lobby"] = &models.GameSession{
+		SessionID: "lobby", Mode: models.GameModeMultiplayer, Status: models.GameStatusWaiting, Theme: &spooky,
+		Players: []models.PlayerInfo{{PlayerID: "p1", IsActive: true}, {PlayerID: "p2", IsActive: true}},
+	}
+	gameSessionRepo.sessions["solo"] = &models.GameSession{
+		SessionID: "solo", Mode: models.GameModeSinglePlayer, Status: models.GameStatusActive,
+		Players: []models.PlayerInfo{{PlayerID: "p3", IsActive: true}, {PlayerID: "bot-1", IsActive: true, IsBot: true}},
+	}
+	gameSessionRepo.sessions["done"] = &models.GameSession{
+		SessionID: "done", Mode: models.GameModeMultiplayer, Status: models.GameStatusCompleted,
+		Players: []models.PlayerInfo{{PlayerID: "p4", IsActive: true}},
+	}
+
+	collector := monitoring.NewMetricsCollector()
+	reporter := NewSessionMetricsReporter(gameSessionRepo, NewMockWebSocketManager(), collector, 0)
+	if err := reporter.Report(ctx); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	if sessions, players := collector.Total("game_sessions_active"), collector.Total("players_active"); sessions != 2 || players != 3 {
+		t.Errorf("Expected 2 unfinished sessions with 3 players, got %v sessions and %v players", sessions, players)
+	}
+	expected := []struct {
+		name, label, value string
+		count              float64
+	}{
+		{"game_sessions_by_status", "status", "waiting", 1},
+		{"game_sessions_by_status", "status", "completed", -1},
+		{"game_sessions_by_mode", "mode", "single-player", 1},
+		{"game_sessions_by_theme", "theme", "spooky", 1},
+		{"game_sessions_by_theme", "theme", noThemeLabel, 1},
+		{"players_by_mode", "mode", "multiplayer", 2},
+		{"players_by_theme", "theme", noThemeLabel, 1},
+	}
+	for _, want := range expected {
+		if got := gaugeValue(collector, want.name, want.label, want.value); got != want.count {
+			t.Errorf("%s{%s=%q}: expected %v, got %v", want.name, want.label, want.value, want.count, got)
+		}
+	}
+
+	// A theme nobody is playing any more drops to zero rather than keeping its last value
+	delete(gameSessionRepo.sessions, "lobby")
+	if err := reporter.Report(ctx); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if got := gaugeValue(collector, "game_sessions_by_theme", "theme", "spooky"); got != 0 {
+		t.Errorf("Expected the emptied theme to report 0, got %v", got)
+	}
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Session quota errors
+var (
+	ErrSessionQuotaExceeded = errors.New("session quota exceeded")
+	ErrInvalidSessionLimits = errors.New("invalid session limits")
+)
+
+// Session limit defaults
+const (
+	DefaultMaxActiveSessionsPerSubreddit = 200
+	DefaultMaxActiveSessionsPerPlayer    = 3
+)
+
+// SessionQuotaService caps how many unfinished sessions a subreddit's app install and a single
+// player may have at once, so a spike on one install cannot starve the others
+type SessionQuotaService interface {
+	Acquire(ctx context.Context, sessionID, playerID string, post *models.PostContext) error
+	Release(ctx context.Context, sessionID string)
+	Limits(ctx context.Context) (*models.SessionLimits, error)
+	SetLimits(ctx context.Context, limits models.SessionLimits) (*models.SessionLimits, error)
+	Usage(ctx context.Context, scope, id string) (*models.SessionQuota, error)
+}
+
+// SessionQuotaServiceImpl implements the SessionQuotaService interface
+type SessionQuotaServiceImpl struct {
+	store     repositories.SessionQuotaStore
+	defaults  models.SessionLimits
+	collector *monitoring.MetricsCollector
+}
+
+// NewSessionQuotaService creates a new session quota service; defaults apply until an admin sets
+// other limits
+func NewSessionQuotaService(store repositories.SessionQuotaStore, defaults models.SessionLimits) SessionQuotaService {
+	return &SessionQuotaServiceImpl{
+		store:     store,
+		defaults:  defaults,
+		collector: monitoring.GetGlobalMetricsCollector(),
+	}
+}
+
+// Acquire counts a new session against its creator's quota and, for sessions started from a
+// Reddit post, its subreddit's quota. It returns ErrSessionQuotaExceeded, saying which limit was
+// reached, if either is full. Sessions are counted even where the limit is unlimited, so a limit
+// set later sees them. If Redis is unavailable the session is let through.
+func (s *SessionQuotaServiceImpl) Acquire(ctx context.Context, sessionID, playerID string, post *models.PostContext) error {
+	limits, err := s.Limits(ctx)
+	if err != nil {
+		fmt.Printf("Warning: %v, using the default session limits\n", err)
+		limits = &s.defaults
+	}
+
+	quotas := []models.SessionQuota{{Scope: models.QuotaScopePlayer, ID: playerID, Limit: limits.MaxActivePerPlayer}}
+	if subreddit := postSubreddit(post); subreddit != "" {
+		quotas = append(quotas, models.SessionQuota{Scope: models.QuotaScopeSubreddit, ID: subreddit, Limit: limits.SubredditLimit(subreddit)})
+	}
+
+	full, err := s.store.Acquire(ctx, sessionID, quotas)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return nil
+	}
+	if full == nil {
+		return nil
+	}
+
+	s.collector.NewCounter("session_quota_rejections_total", "Sessions refused because a session limit was reached", map[string]string{
+		"scope": full.Scope,
+	}).Inc()
+	if full.Scope == models.QuotaScopeSubreddit {
+		return fmt.Errorf("%w: r/%s already has %d active games, try again once one finishes", ErrSessionQuotaExceeded, full.ID, full.Limit)
+	}
+	return fmt.Errorf("%w: you already have %d unfinished games, finish or leave one before starting another", ErrSessionQuotaExceeded, full.Limit)
+}
+
+// Release stops a finished or abandoned session counting against its quotas
+func (s *SessionQuotaServiceImpl) Release(ctx context.Context, sessionID string) {
+	if err := s.store.Release(ctx, sessionID); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// Limits returns the limits in force: those set by an admin, or else the defaults
+func (s *SessionQuotaServiceImpl) Limits(ctx context.Context) (*models.SessionLimits, error) {
+	limits, err := s.store.GetLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if limits == nil {
+		defaults := s.defaults
+		return &defaults, nil
+	}
+	return limits, nil
+}
+
+// SetLimits replaces the limits in force. Sessions already running keep counting, so lowering
+// a limit only turns away new sessions.
+func (s *SessionQuotaServiceImpl) SetLimits(ctx context.Context, limits models.SessionLimits) (*models.SessionLimits, error) {
+	if limits.MaxActivePerSubreddit < 0 || limits.MaxActivePerPlayer < 0 {
+		return nil, fmt.Errorf("%w: limits cannot be negative", ErrInvalidSessionLimits)
+	}
+
+	overrides := make(map[string]int, len(limits.Subreddits))
+	for name, limit := range limits.Subreddits {
+		subreddit := normalizeSubreddit(name)
+		if subreddit == "" || limit < 0 {
+			return nil, fmt.Errorf("%w: subreddit override %q: %d", ErrInvalidSessionLimits, name, limit)
+		}
+		overrides[subreddit] = limit
+	}
+	limits.Subreddits = overrides
+
+	if err := s.store.SetLimits(ctx, limits); err != nil {
+		return nil, err
+	}
+	return &limits, nil
+}
+
+// Usage returns a quota's limit and how many unfinished sessions count against it
+func (s *SessionQuotaServiceImpl) Usage(ctx context.Context, scope, id string) (*models.SessionQuota, error) {
+	limits, err := s.Limits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &models.SessionQuota{Scope: scope, ID: id}
+	switch scope {
+	case models.QuotaScopeSubreddit:
+		quota.ID = normalizeSubreddit(id)
+		quota.Limit = limits.SubredditLimit(quota.ID)
+	case models.QuotaScopePlayer:
+		quota.Limit = limits.MaxActivePerPlayer
+	default:
+		return nil, fmt.Errorf("%w: unknown quota scope %q", ErrInvalidSessionLimits, scope)
+	}
+
+	active, err := s.store.Active(ctx, quota.Scope, quota.ID)
+	if err != nil {
+		return nil, err
+	}
+	quota.Active = active
+	return quota, nil
+}
+
+// postSubreddit returns the normalized subreddit a session was started from, if any
+func postSubreddit(post *models.PostContext) string {
+	if post == nil {
+		return ""
+	}
+	return normalizeSubreddit(post.SubredditName)
+}
+
+// normalizeSubreddit lower-cases a subreddit name and strips an "r/" prefix, as Reddit treats
+// names case-insensitively
+func normalizeSubreddit(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.TrimPrefix(name, "r/")
+}
+
+// WithSessionQuota enforces session limits when sessions are created and releases them when
+// sessions end
+func WithSessionQuota(quota SessionQuotaService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.quota = quota
+	}
+}
+
+// releaseQuota stops a session counting against its quotas once it has ended
+func (s *GameServiceImpl) releaseQuota(ctx context.Context, session *models.GameSession) {
+	if s.quota == nil {
+		return
+	}
+	if session.Status == models.GameStatusCompleted || session.Status == models.GameStatusAbandoned {
+		s.quota.Release(ctx, session.SessionID)
+	}
+}
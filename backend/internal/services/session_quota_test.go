@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockSessionQuotaStore counts sessions per quota in memory
+type MockSessionQuotaStore struct {
+	active map[string]map[string]bool // quota key -> session IDs
+	held   map[string][]string        // session ID -> quota keys
+	limits *models.SessionLimits
+}
+
+func NewMockSessionQuotaStore() *MockSessionQuotaStore {
+	return &MockSessionQuotaStore{
+		active: make(map[string]map[string]bool),
+		held:   make(map[string][]string),
+	}
+}
+
+func (m *MockSessionQuotaStore) Acquire(ctx context.Context, sessionID string, quotas []models.SessionQuota) (*models.SessionQuota, error) {
+	for i, quota := range quotas {
+		key := quota.Scope + ":" + quota.ID
+		if quota.Limit > 0 && !m.active[key][sessionID] && len(m.active[key]) >= quota.Limit {
+			return &quotas[i], nil
+		}
+	}
+	for _, quota := range quotas {
+		key := quota.Scope + ":" + quota.ID
+		if m.active[key] == nil {
+			m.active[key] = make(map[string]bool)
+		}
+		m.active[key][sessionID] = true
+		m.held[sessionID] = append(m.held[sessionID], key)
+	}
+	return nil, nil
+}
+
+func (m *MockSessionQuotaStore) Release(ctx context.Context, sessionID string) error {
+	for _, key := range m.held[sessionID] {
+		delete(m.active[key], sessionID)
+	}
+	delete(m.held, sessionID)
+	return nil
+}
+
+func (m *MockSessionQuotaStore) Active(ctx context.Context, scope, id string) (int, error) {
+	return len(m.active[scope+":"+id]), nil
+}
+
+func (m *MockSessionQuotaStore) GetLimits(ctx context.Context) (*models.SessionLimits, error) {
+	return m.limits, nil
+}
+
+func (m *MockSessionQuotaStore) SetLimits(ctx context.Context, limits models.SessionLimits) error {
+	m.limits = &limits
+	return nil
+}
+
+func TestSessionQuota_EnforcesPlayerAndSubredditLimits(t *testing.T) {
+	ctx := context.Background()
+	quota := NewSessionQuotaService(NewMockSessionQuotaStore(), models.SessionLimits{MaxActivePerSubreddit: 2, MaxActivePerPlayer: 1})
+	post := &models.PostContext{PostID: "t3_abc", SubredditName: "DumDoors"}
+
+	if err := quota.Acquire(ctx, "s1", "p1", post); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	err := quota.Acquire(ctx, "s2", "p1", nil)
+	if !errors.Is(err, ErrSessionQuotaExceeded) || !strings.Contains(err.Error(), "unfinished games") {
+		t.Fatalf("Expected the player's second session to be refused, got %v", err)
+	}
+
+	if err := quota.Acquire(ctx, "s3", "p2", &models.PostContext{SubredditName: "r/dumdoors"}); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	err = quota.Acquire(ctx, "s4", "p3", post)
+	if !errors.Is(err, ErrSessionQuotaExceeded) || !strings.Contains(err.Error(), "r/dumdoors") {
+		t.Fatalf("Expected the subreddit's third session to be refused, got %v", err)
+	}
+
+	quota.Release(ctx, "s1")
+	if err := quota.Acquire(ctx, "s4", "p3", post); err != nil {
+		t.Errorf("Expected a released session to free its subreddit's quota, got %v", err)
+	}
+	usage, err := quota.Usage(ctx, models.QuotaScopeSubreddit, "DumDoors")
+	if err != nil {
+		t.Fatalf("Usage failed: %v", err)
+	}
+	if usage.Active != 2 || usage.Limit != 2 {
+		t.Errorf("Expected 2 of 2 sessions in use, got %+v", usage)
+	}
+}
+
+func TestSessionQuota_SetLimitsAppliesOverrides(t *testing.T) {
+	ctx := context.Background()
+	quota := NewSessionQuotaService(NewMockSessionQuotaStore(), models.SessionLimits{MaxActivePerSubreddit: 1})
+
+	if _, err := quota.SetLimits(ctx, models.SessionLimits{MaxActivePerPlayer: -1}); !errors.Is(err, ErrInvalidSessionLimits) {
+		t.Fatalf("Expected negative limits to be refused, got %v", err)
+	}
+	limits, err := quota.SetLimits(ctx, models.SessionLimits{MaxActivePerSubreddit: 1, Subreddits: map[string]int{"r/BigSub": 0}})
+	if err != nil {
+		t.Fatalf("SetLimits failed: %v", err)
+	}
+	if limit, ok := limits.Subreddits["bigsub"]; !ok || limit != 0 {
+		t.Fatalf("Expected the override to be stored under the normalized name, got %v", limits.Subreddits)
+	}
+
+	for _, sessionID := range []string{"s1", "s2", "s3"} {
+		if err := quota.Acquire(ctx, sessionID, "p-"+sessionID, &models.PostContext{SubredditName: "BigSub"}); err != nil {
+			t.Fatalf("Expected the unlimited override to let every session through, got %v", err)
+		}
+	}
+	if err := quota.Acquire(ctx, "s4", "p4", &models.PostContext{SubredditName: "small"}); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if err := quota.Acquire(ctx, "s5", "p5", &models.PostContext{SubredditName: "small"}); !errors.Is(err, ErrSessionQuotaExceeded) {
+		t.Errorf("Expected other subreddits to keep the default limit, got %v", err)
+	}
+}
+
+func TestCreateSession_RefusedOverQuotaAndReleasedWhenAbandoned(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	store := NewMockSessionQuotaStore()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithSessionQuota(NewSessionQuotaService(store, models.SessionLimits{MaxActivePerPlayer: 1})),
+	)
+
+	session, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", models.SessionSettings{})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", models.SessionSettings{}); !errors.Is(err, ErrSessionQuotaExceeded) {
+		t.Fatalf("Expected a second unfinished session to be refused, got %v", err)
+	}
+
+	if _, err := gameService.LeaveSession(ctx, session.SessionID, "p1"); err != nil {
+		t.Fatalf("LeaveSession failed: %v", err)
+	}
+	if active, _ := store.Active(ctx, models.QuotaScopePlayer, "p1"); active != 0 {
+		t.Fatalf("Expected the abandoned session to be released, %d still active", active)
+	}
+	if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", models.SessionSettings{}); err != nil {
+		t.Errorf("Expected a new session once the first was abandoned, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSessionSettings is returned when a session is created with unusable settings
+var ErrInvalidSessionSettings = errors.New("invalid session settings")
+
+// validateSessionSettings checks the settings chosen when a session is created
+func validateSessionSettings(mode models.GameMode, settings models.SessionSettings) error {
+	switch settings.ScoringMode {
+	case "", models.ScoringModeAI:
+	case models.ScoringModePeerVote:
+		if mode != models.GameModeMultiplayer {
+			return fmt.Errorf("%w: peer-vote scoring requires a multiplayer session", ErrInvalidSessionSettings)
+		}
+	default:
+		return fmt.Errorf("%w: unknown scoring mode %q", ErrInvalidSessionSettings, settings.ScoringMode)
+	}
+
+	switch settings.RevealMode {
+	case "", models.RevealAnonymous, models.RevealAttributed, models.RevealOff:
+	default:
+		return fmt.Errorf("%w: unknown reveal mode %q", ErrInvalidSessionSettings, settings.RevealMode)
+	}
+
+	if settings.ScoringStrategy != "" {
+		if settings.PeerVoting() {
+			return fmt.Errorf("%w: peer-vote sessions don't use a scoring strategy", ErrInvalidSessionSettings)
+		}
+		if _, err := NewScoringStrategy(settings.ScoringStrategy); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSessionSettings, err)
+		}
+	}
+
+	if settings.ReadyPercent < 0 || settings.ReadyPercent > 100 {
+		return fmt.Errorf("%w: ready percent must be between 0 and 100", ErrInvalidSessionSettings)
+	}
+	if settings.ReadyPercent > 0 && mode != models.GameModeMultiplayer {
+		return fmt.Errorf("%w: the ready check requires a multiplayer session", ErrInvalidSessionSettings)
+	}
+	if settings.Password != "" && !settings.IsPrivate {
+		return fmt.Errorf("%w: only private sessions can have a password", ErrInvalidSessionSettings)
+	}
+	if len(settings.Password) > maxPasswordLength {
+		return fmt.Errorf("%w: password must be at most %d bytes", ErrInvalidSessionSettings, maxPasswordLength)
+	}
+	if settings.BotOpponents < 0 || settings.BotOpponents > MaxBotOpponents {
+		return fmt.Errorf("%w: bot opponents must be between 0 and %d", ErrInvalidSessionSettings, MaxBotOpponents)
+	}
+	if settings.BotOpponents > 0 && mode != models.GameModeSinglePlayer {
+		return fmt.Errorf("%w: bot opponents require a single-player session", ErrInvalidSessionSettings)
+	}
+
+	switch settings.Format {
+	case "", models.MatchFormatPath:
+		if settings.Rounds != 0 {
+			return fmt.Errorf("%w: rounds can only be set for best-of matches", ErrInvalidSessionSettings)
+		}
+	case models.MatchFormatBestOf:
+		if settings.Rounds < 0 || settings.Rounds > MaxMatchRounds {
+			return fmt.Errorf("%w: best-of matches must have between 1 and %d rounds", ErrInvalidSessionSettings, MaxMatchRounds)
+		}
+	default:
+		return fmt.Errorf("%w: unknown match format %q", ErrInvalidSessionSettings, settings.Format)
+	}
+
+	if settings.MaxLength != 0 && (settings.MaxLength < MinSessionResponseLength || settings.MaxLength > MaxSessionResponseLength) {
+		return fmt.Errorf("%w: character limit must be between %d and %d", ErrInvalidSessionSettings, MinSessionResponseLength, MaxSessionResponseLength)
+	}
+	switch settings.Profanity {
+	case "", models.ProfanityMask, models.ProfanityReject, models.ProfanityAllow:
+	default:
+		return fmt.Errorf("%w: unknown profanity filter %q", ErrInvalidSessionSettings, settings.Profanity)
+	}
+
+	if settings.TeamSize != 0 {
+		if !validTeamSize(settings.TeamSize) {
+			return fmt.Errorf("%w: teams must have %v players", ErrInvalidSessionSettings, teamSizes)
+		}
+		if mode != models.GameModeMultiplayer {
+			return fmt.Errorf("%w: teams require a multiplayer session", ErrInvalidSessionSettings)
+		}
+	}
+	return nil
+}
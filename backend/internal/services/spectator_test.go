@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestJoinAsSpectator_AddsSpectatorOnce(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newDraftSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := gameService.JoinAsSpectator(ctx, "s1", "viewer", "Viewer"); err != nil {
+			t.Fatalf("JoinAsSpectator failed: %v", err)
+		}
+	}
+
+	session := gameSessionRepo.sessions["s1"]
+	if len(session.Spectators) != 1 || session.Spectators[0].SpectatorID != "viewer" {
+		t.Errorf("Expected one spectator, got %+v", session.Spectators)
+	}
+	if len(session.Players) != 2 {
+		t.Errorf("Expected spectators not to count as players, got %d players", len(session.Players))
+	}
+}
+
+func TestJoinAsSpectator_RejectsPlayersAndCompletedSessions(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newDraftSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+
+	if _, err := gameService.JoinAsSpectator(ctx, "s1", "p1", "Player 1"); err == nil {
+		t.Error("Expected a player to be refused as a spectator of their own session")
+	}
+
+	gameSessionRepo.sessions["s1"].Status = models.GameStatusCompleted
+	if _, err := gameService.JoinAsSpectator(ctx, "s1", "viewer", "Viewer"); err == nil {
+		t.Error("Expected spectating a completed session to fail")
+	}
+}
+
+func TestSubmitResponse_RejectsSpectators(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newDraftSession()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+
+	if _, err := gameService.JoinAsSpectator(ctx, "s1", "viewer", "Viewer"); err != nil {
+		t.Fatalf("JoinAsSpectator failed: %v", err)
+	}
+
+	_, err := gameService.SubmitResponse(ctx, "s1", "viewer", "I would open the door.")
+	if !errors.Is(err, ErrSpectatorReadOnly) {
+		t.Errorf("Expected ErrSpectatorReadOnly, got %v", err)
+	}
+}
+
+func TestIsSpectatorEvent_FiltersPrivateEvents(t *testing.T) {
+	for _, eventType := range []string{"door-presented", "scores-updated", "leaderboard-update", "game-completed"} {
+		if !isSpectatorEvent(eventType) {
+			t.Errorf("Expected spectators to receive %s", eventType)
+		}
+	}
+	for _, eventType := range []string{"draft-saved", "player-typing", "message", "connection-established"} {
+		if isSpectatorEvent(eventType) {
+			t.Errorf("Expected %s to stay private to players", eventType)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Session state machine errors
+var (
+	ErrInvalidTransition = errors.New("invalid session state transition")
+	ErrIllegalOperation  = errors.New("operation not allowed in the current session state")
+)
+
+// SessionOperation names a game operation whose legality depends on the session state
+type SessionOperation string
+
+// Session operations
+const (
+	OpJoinSession     SessionOperation = "join session"
+	OpStartGame       SessionOperation = "start game"
+	OpPresentDoor     SessionOperation = "present door"
+	OpSubmitResponse  SessionOperation = "submit response"
+	OpResponseTimeout SessionOperation = "expire response window"
+	OpCastVote        SessionOperation = "cast vote"
+	OpReadyCheck      SessionOperation = "ready check"
+	OpKickPlayer      SessionOperation = "kick player"
+	OpTransferHost    SessionOperation = "transfer host"
+	OpLockLobby       SessionOperation = "lock lobby"
+	OpAssignTeams     SessionOperation = "assign teams"
+	OpSetOptions      SessionOperation = "set player options"
+	OpPauseGame       SessionOperation = "pause game"
+	OpResumeGame      SessionOperation = "resume game"
+	OpLeaveSession    SessionOperation = "leave session"
+	OpForfeitPlayer   SessionOperation = "forfeit disconnected player"
+)
+
+// EventSessionStateChanged announces every session state change to the session's clients
+const EventSessionStateChanged = "session-state-changed"
+
+// sessionTransitions lists the states each state may move to. A session starts
+// waiting -> starting (ready countdown, first door set up) -> active; a countdown that loses its
+// ready players falls back to waiting. A round runs active (collecting responses) -> scoring ->
+// revealing (between doors, scores shown) -> active (next door). The host may pause an open round,
+// which resumes where it left off.
+// A finished game is completing while its results are recorded, then completed. Peer-vote
+// sessions collect votes while scoring. Any unfinished session may be abandoned once it has been
+// inactive for too long.
+var sessionTransitions = map[models.GameStatus][]models.GameStatus{
+	models.GameStatusWaiting:    {models.GameStatusStarting, models.GameStatusAbandoned},
+	models.GameStatusStarting:   {models.GameStatusActive, models.GameStatusWaiting, models.GameStatusAbandoned},
+	models.GameStatusActive:     {models.GameStatusScoring, models.GameStatusPaused, models.GameStatusCompleting, models.GameStatusAbandoned},
+	models.GameStatusScoring:    {models.GameStatusRevealing, models.GameStatusCompleting, models.GameStatusAbandoned},
+	models.GameStatusRevealing:  {models.GameStatusActive, models.GameStatusCompleting, models.GameStatusAbandoned},
+	models.GameStatusPaused:     {models.GameStatusActive, models.GameStatusCompleting, models.GameStatusAbandoned},
+	models.GameStatusCompleting: {models.GameStatusCompleted, models.GameStatusAbandoned},
+}
+
+// sessionOperations lists the states in which each operation is allowed
+var sessionOperations = map[SessionOperation][]models.GameStatus{
+	OpJoinSession:     {models.GameStatusWaiting},
+	OpStartGame:       {models.GameStatusWaiting, models.GameStatusStarting},
+	OpPresentDoor:     {models.GameStatusActive, models.GameStatusRevealing},
+	OpSubmitResponse:  {models.GameStatusActive},
+	OpResponseTimeout: {models.GameStatusActive},
+	OpCastVote:        {models.GameStatusScoring},
+	OpReadyCheck:      {models.GameStatusWaiting, models.GameStatusStarting},
+	OpKickPlayer:      {models.GameStatusWaiting, models.GameStatusStarting},
+	OpTransferHost:    {models.GameStatusWaiting, models.GameStatusStarting, models.GameStatusActive, models.GameStatusScoring, models.GameStatusRevealing, models.GameStatusPaused},
+	OpLockLobby:       {models.GameStatusWaiting, models.GameStatusStarting},
+	OpAssignTeams:     {models.GameStatusWaiting, models.GameStatusStarting},
+	OpSetOptions:      {models.GameStatusWaiting, models.GameStatusStarting},
+	OpPauseGame:       {models.GameStatusActive},
+	OpResumeGame:      {models.GameStatusPaused},
+	OpLeaveSession:    {models.GameStatusWaiting, models.GameStatusStarting, models.GameStatusActive, models.GameStatusScoring, models.GameStatusRevealing, models.GameStatusPaused},
+	OpForfeitPlayer:   {models.GameStatusActive, models.GameStatusScoring, models.GameStatusRevealing, models.GameStatusPaused},
+}
+
+// SessionTransition records a single state change
+type SessionTransition struct {
+	SessionID string            `json:"sessionId"`
+	From      models.GameStatus `json:"from"`
+	To        models.GameStatus `json:"to"`
+	At        time.Time         `json:"at"`
+}
+
+// SessionStateMachine validates session state changes and the operations allowed in each state
+type SessionStateMachine interface {
+	CanTransition(from, to models.GameStatus) bool
+	Transition(session *models.GameSession, to models.GameStatus) (*SessionTransition, error)
+	Require(session *models.GameSession, operation SessionOperation) error
+}
+
+// SessionStateMachineImpl implements SessionStateMachine from the static transition tables
+type SessionStateMachineImpl struct{}
+
+// NewSessionStateMachine creates a new session state machine
+func NewSessionStateMachine() SessionStateMachine {
+	return &SessionStateMachineImpl{}
+}
+
+// CanTransition reports whether a session may move from one state to another
+func (m *SessionStateMachineImpl) CanTransition(from, to models.GameStatus) bool {
+	return containsStatus(sessionTransitions[from], to)
+}
+
+// Transition moves the session to a new state, appending it to the session's status history and
+// stamping start, completion and abandonment times. The game ends when it starts completing.
+// The caller persists the session and announces the returned transition.
+func (m *SessionStateMachineImpl) Transition(session *models.GameSession, to models.GameStatus) (*SessionTransition, error) {
+	from := session.Status
+	if !m.CanTransition(from, to) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+
+	now := time.Now()
+	session.Status = to
+	session.StatusHistory = append(session.StatusHistory, models.StatusChange{From: from, To: to, At: now})
+
+	if to == models.GameStatusActive && session.StartedAt == nil {
+		session.StartedAt = &now
+	}
+	if (to == models.GameStatusCompleting || to == models.GameStatusCompleted) && session.CompletedAt == nil {
+		session.CompletedAt = &now
+	}
+	if to == models.GameStatusAbandoned {
+		session.AbandonedAt = &now
+	}
+
+	return &SessionTransition{
+		SessionID: session.SessionID,
+		From:      from,
+		To:        to,
+		At:        now,
+	}, nil
+}
+
+// Require returns ErrIllegalOperation, naming the operation and state, unless the
+// operation is allowed in the session's current state
+func (m *SessionStateMachineImpl) Require(session *models.GameSession, operation SessionOperation) error {
+	if containsStatus(sessionOperations[operation], session.Status) {
+		return nil
+	}
+	return fmt.Errorf("%w: cannot %s while session is %s", ErrIllegalOperation, operation, session.Status)
+}
+
+// announceTransition broadcasts a session state change to the session's clients
+func announceTransition(wsManager WebSocketManager, change *SessionTransition) {
+	if wsManager == nil {
+		return
+	}
+
+	event := WebSocketEvent{
+		Type:      EventSessionStateChanged,
+		SessionID: change.SessionID,
+		Data:      change,
+		Timestamp: change.At,
+	}
+	if err := wsManager.BroadcastToSession(change.SessionID, event); err != nil {
+		fmt.Printf("Warning: failed to broadcast state change: %v\n", err)
+	}
+}
+
+// containsStatus reports whether status is in the list
+func containsStatus(statuses []models.GameStatus, status models.GameStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestSessionStateMachine_RoundCycle(t *testing.T) {
+	machine := NewSessionStateMachine()
+	session := &models.GameSession{SessionID: "s1", Status: models.GameStatusWaiting}
+
+	steps := []models.GameStatus{
+		models.GameStatusStarting,
+		models.GameStatusActive,
+		models.GameStatusScoring,
+		models.GameStatusRevealing,
+		models.GameStatusActive,
+		models.GameStatusPaused,
+		models.GameStatusActive,
+		models.GameStatusCompleting,
+		models.GameStatusCompleted,
+	}
+
+	for _, to := range steps {
+		from := session.Status
+		change, err := machine.Transition(session, to)
+		if err != nil {
+			t.Fatalf("Transition %s -> %s failed: %v", from, to, err)
+		}
+		if change.From != from || change.To != to || session.Status != to {
+			t.Errorf("Unexpected transition record %+v for %s -> %s", change, from, to)
+		}
+	}
+
+	if session.StartedAt == nil || session.CompletedAt == nil {
+		t.Error("Expected start and completion times to be stamped")
+	}
+	if len(session.StatusHistory) != len(steps) {
+		t.Fatalf("Expected %d recorded transitions, got %d", len(steps), len(session.StatusHistory))
+	}
+	for i, change := range session.StatusHistory {
+		if change.To != steps[i] || change.At.IsZero() {
+			t.Errorf("History entry %d: expected a timestamped move to %s, got %+v", i, steps[i], change)
+		}
+	}
+}
+
+func TestSessionStateMachine_RejectsIllegalTransitions(t *testing.T) {
+	machine := NewSessionStateMachine()
+
+	illegal := [][2]models.GameStatus{
+		{models.GameStatusWaiting, models.GameStatusScoring},
+		{models.GameStatusWaiting, models.GameStatusActive},
+		{models.GameStatusActive, models.GameStatusCompleted},
+		{models.GameStatusActive, models.GameStatusRevealing},
+		{models.GameStatusScoring, models.GameStatusActive},
+		{models.GameStatusCompleted, models.GameStatusActive},
+	}
+
+	for _, pair := range illegal {
+		session := &models.GameSession{Status: pair[0]}
+		if _, err := machine.Transition(session, pair[1]); !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("%s -> %s: expected ErrInvalidTransition, got %v", pair[0], pair[1], err)
+		}
+		if session.Status != pair[0] || len(session.StatusHistory) != 0 {
+			t.Errorf("%s -> %s: rejected transition changed the status to %s", pair[0], pair[1], session.Status)
+		}
+	}
+}
+
+func TestSubmitResponse_IllegalOperationWhileScoring(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = &models.GameSession{
+		SessionID:   "s1",
+		Status:      models.GameStatusScoring,
+		CurrentDoor: &models.Door{DoorID: "door-1"},
+		Players:     []models.PlayerInfo{{PlayerID: "p1"}},
+	}
+
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+
+	_, err := gameService.SubmitResponse(context.Background(), "s1", "p1", "too late")
+	if !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("Expected ErrIllegalOperation, got %v", err)
+	}
+}
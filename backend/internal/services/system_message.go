@@ -0,0 +1,18 @@
+package services
+
+import (
+	"dumdoors-backend/internal/i18n"
+)
+
+// systemMessage adds a system message to event data: the message rendered in the session's
+// locale, plus its code and params so clients can render it in their own language
+func systemMessage(data map[string]interface{}, locale string, key i18n.MessageKey, params ...interface{}) map[string]interface{} {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	data["message"] = i18n.Message(locale, key, params...)
+	data["messageCode"] = key
+	data["messageParams"] = params
+	return data
+}
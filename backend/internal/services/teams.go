@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Team sizes a team session may be played with: 2v2 or 4v4
+var teamSizes = []int{2, 4}
+
+// defaultTeamRating is the rating assumed for players without a finished game, when teams are balanced
+const defaultTeamRating = 50.0
+
+// EventTeamsUpdated announces a lobby's new team rosters
+const EventTeamsUpdated = "teams-updated"
+
+// Team errors
+var (
+	ErrTeamFull        = errors.New("team is full")
+	ErrTeamsIncomplete = errors.New("teams are not full")
+	ErrNotTeamSession  = errors.New("session is not played in teams")
+)
+
+// validTeamSize reports whether a team session can be played with size players per team
+func validTeamSize(size int) bool {
+	for _, valid := range teamSizes {
+		if size == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// openTeam returns the team a new player joins: the one with fewer players, or "" if both are full
+func openTeam(session *models.GameSession) string {
+	open := ""
+	fewest := session.Settings.TeamSize
+	for _, teamID := range models.TeamIDs {
+		if count := len(session.TeamPlayers(teamID)); count < fewest {
+			open, fewest = teamID, count
+		}
+	}
+	return open
+}
+
+// checkTeamsFull reports whether both teams have a full roster of active players
+func checkTeamsFull(session *models.GameSession) error {
+	for _, teamID := range models.TeamIDs {
+		active := 0
+		for _, player := range session.TeamPlayers(teamID) {
+			if player.IsActive {
+				active++
+			}
+		}
+		if active != session.Settings.TeamSize {
+			return fmt.Errorf("%w: team %s has %d of %d players", ErrTeamsIncomplete, teamID, active, session.Settings.TeamSize)
+		}
+	}
+	return nil
+}
+
+// AssignTeam moves a player in the lobby to another team at the host's request
+func (s *GameServiceImpl) AssignTeam(ctx context.Context, sessionID, hostID, playerID, teamID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.AssignTeam", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(hostID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.hostedSession(ctx, sessionID, hostID, OpAssignTeams)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Settings.TeamPlay() {
+		return nil, ErrNotTeamSession
+	}
+	if !models.ValidTeamID(teamID) {
+		return nil, fmt.Errorf("unknown team %q", teamID)
+	}
+
+	player := sessionPlayer(session, playerID)
+	if player == nil {
+		return nil, ErrPlayerNotInSession
+	}
+	if player.TeamID == teamID {
+		return session, nil
+	}
+	if len(session.TeamPlayers(teamID)) >= session.Settings.TeamSize {
+		return nil, fmt.Errorf("%w: team %s already has %d players", ErrTeamFull, teamID, session.Settings.TeamSize)
+	}
+
+	player.TeamID = teamID
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to assign team: %w", err)
+	}
+	s.announceTeams(ctx, session, hostID)
+
+	return session, nil
+}
+
+// BalanceTeams splits the lobby into two teams of even size and as close a total rating as a
+// greedy draft gets: the strongest players are placed first, each on the weaker team with room.
+// A player's rating is their career average score.
+func (s *GameServiceImpl) BalanceTeams(ctx context.Context, sessionID, hostID string) (*models.GameSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "GameService.BalanceTeams", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(hostID))
+	defer span.End()
+
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.hostedSession(ctx, sessionID, hostID, OpAssignTeams)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Settings.TeamPlay() {
+		return nil, ErrNotTeamSession
+	}
+
+	ratings := make(map[string]float64, len(session.Players))
+	order := make([]int, len(session.Players))
+	for i, player := range session.Players {
+		ratings[player.PlayerID] = s.playerRating(ctx, player)
+		order[i] = i
+	}
+	// Players who joined first win ties
+	sort.SliceStable(order, func(a, b int) bool {
+		return ratings[session.Players[order[a]].PlayerID] > ratings[session.Players[order[b]].PlayerID]
+	})
+
+	capacity := (len(session.Players) + 1) / 2
+	counts := make(map[string]int, len(models.TeamIDs))
+	totals := make(map[string]float64, len(models.TeamIDs))
+	for _, i := range order {
+		player := &session.Players[i]
+		teamID := ""
+		for _, candidate := range models.TeamIDs {
+			if counts[candidate] >= capacity {
+				continue
+			}
+			if teamID == "" || totals[candidate] < totals[teamID] {
+				teamID = candidate
+			}
+		}
+		player.TeamID = teamID
+		counts[teamID]++
+		totals[teamID] += ratings[player.PlayerID]
+	}
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to balance teams: %w", err)
+	}
+	s.announceTeams(ctx, session, hostID)
+
+	return session, nil
+}
+
+// playerRating is how strong a player is for balancing teams: their career average score, or
+// the default for players without a finished game
+func (s *GameServiceImpl) playerRating(ctx context.Context, player models.PlayerInfo) float64 {
+	if s.profileService == nil || player.IsBot {
+		return defaultTeamRating
+	}
+	profile, err := s.profileService.GetProfile(ctx, player.PlayerID)
+	if err != nil || profile == nil || profile.GamesPlayed == 0 {
+		return defaultTeamRating
+	}
+	return profile.AverageScore
+}
+
+// announceTeams broadcasts the session's team rosters
+func (s *GameServiceImpl) announceTeams(ctx context.Context, session *models.GameSession, hostID string) {
+	if s.wsManager == nil {
+		return
+	}
+
+	event := WebSocketEvent{
+		Type:      EventTeamsUpdated,
+		SessionID: session.SessionID,
+		PlayerID:  hostID,
+		Data: map[string]interface{}{
+			"teams":   teamRosters(session),
+			"players": playerDeltas(session),
+			"hostId":  hostID,
+		},
+		Timestamp: time.Now(),
+	}
+
+	s.runInBackground(ctx, session.SessionID, "broadcast-teams-updated", func(ctx context.Context) {
+		if err := s.wsManager.BroadcastToSession(session.SessionID, event); err != nil {
+			fmt.Printf("Warning: failed to broadcast teams update: %v\n", err)
+		}
+	})
+}
+
+// sessionPlayer returns the session's player with the given ID, or nil
+func sessionPlayer(session *models.GameSession, playerID string) *models.PlayerInfo {
+	for i := range session.Players {
+		if session.Players[i].PlayerID == playerID {
+			return &session.Players[i]
+		}
+	}
+	return nil
+}
+
+// teamRosters returns the player IDs on each team, keyed by team ID
+func teamRosters(session *models.GameSession) map[string][]string {
+	rosters := make(map[string][]string, len(models.TeamIDs))
+	for _, teamID := range models.TeamIDs {
+		rosters[teamID] = []string{}
+	}
+	for _, player := range session.Players {
+		if player.TeamID != "" {
+			rosters[player.TeamID] = append(rosters[player.TeamID], player.PlayerID)
+		}
+	}
+	return rosters
+}
+
+// teamScores returns each team's players' total scores added together, keyed by team ID
+func teamScores(session *models.GameSession) map[string]int {
+	scores := make(map[string]int, len(models.TeamIDs))
+	for _, teamID := range models.TeamIDs {
+		scores[teamID] = 0
+	}
+	for _, player := range session.Players {
+		if player.TeamID != "" {
+			scores[player.TeamID] += player.TotalScore
+		}
+	}
+	return scores
+}
+
+// leadingTeam returns the team with the higher score; ties go to the first team
+func leadingTeam(session *models.GameSession) string {
+	scores := teamScores(session)
+	leader := models.TeamIDs[0]
+	for _, teamID := range models.TeamIDs[1:] {
+		if scores[teamID] > scores[leader] {
+			leader = teamID
+		}
+	}
+	return leader
+}
+
+// pathScore is the score a player's path moves by for a door. In team sessions that is the
+// average score of the player's team on the door, counting the teammates scored so far.
+func pathScore(session *models.GameSession, playerID, doorID string, score int) int {
+	player := sessionPlayer(session, playerID)
+	if !session.Settings.TeamPlay() || player == nil || player.TeamID == "" {
+		return score
+	}
+
+	total, count := 0, 0
+	for _, teammate := range session.TeamPlayers(player.TeamID) {
+		if teammate.PlayerID == playerID {
+			continue
+		}
+		for _, response := range teammate.Responses {
+			if response.DoorID == doorID && !response.ScoringPending {
+				total += response.AIScore
+				count++
+				break
+			}
+		}
+	}
+	return (total + score) / (count + 1)
+}
+
+// teamWinner returns the team that has finished its path, with the team's top scorer. A team
+// has finished once its players' average position reaches their average path length; if both
+// have, the team further along wins, then the higher scoring team.
+func (s *GameServiceImpl) teamWinner(ctx context.Context, session *models.GameSession) (string, string) {
+	winner, best := "", 0.0
+	scores := teamScores(session)
+	for _, teamID := range models.TeamIDs {
+		players := session.TeamPlayers(teamID)
+		if len(players) == 0 {
+			continue
+		}
+
+		position, length := 0, 0
+		for _, player := range players {
+			path, err := s.playerPathRepo.GetPlayerPath(ctx, player.PlayerID)
+			if err != nil {
+				fmt.Printf("Warning: failed to get path for player %s: %v\n", player.PlayerID, err)
+			}
+			if path == nil {
+				length += defaultPathLength
+				continue
+			}
+			position += path.CurrentPosition
+			length += path.TotalDoors
+		}
+		if position < length {
+			continue
+		}
+
+		progress := float64(position) / float64(length)
+		if winner == "" || progress > best || (progress == best && scores[teamID] > scores[winner]) {
+			winner, best = teamID, progress
+		}
+	}
+	if winner == "" {
+		return "", ""
+	}
+	return winner, topScorer(session, winner).PlayerID
+}
+
+// rankTeams ranks the teams of a finished team session from its players' rankings, the
+// winning team first, then by completion rate and total score
+func rankTeams(session *models.GameSession, rankings []models.PlayerRanking) []models.TeamRanking {
+	teams := make([]models.TeamRanking, 0, len(models.TeamIDs))
+	for _, teamID := range models.TeamIDs {
+		team := models.TeamRanking{
+			TeamID:    teamID,
+			PlayerIDs: []string{},
+			IsWinner:  teamID == session.WinnerTeamID,
+		}
+		for _, ranking := range rankings {
+			player := sessionPlayer(session, ranking.PlayerID)
+			if player == nil || player.TeamID != teamID {
+				continue
+			}
+			team.PlayerIDs = append(team.PlayerIDs, ranking.PlayerID)
+			team.TotalScore += ranking.TotalScore
+			team.CompletionRate += ranking.CompletionRate
+		}
+		if len(team.PlayerIDs) > 0 {
+			team.AverageScore = float64(team.TotalScore) / float64(len(team.PlayerIDs))
+			team.CompletionRate /= float64(len(team.PlayerIDs))
+		}
+		teams = append(teams, team)
+	}
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		if teams[i].IsWinner != teams[j].IsWinner {
+			return teams[i].IsWinner
+		}
+		if teams[i].CompletionRate != teams[j].CompletionRate {
+			return teams[i].CompletionRate > teams[j].CompletionRate
+		}
+		return teams[i].TotalScore > teams[j].TotalScore
+	})
+	for i := range teams {
+		teams[i].Rank = i + 1
+	}
+	return teams
+}
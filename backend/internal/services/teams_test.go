@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTeams_JoinsFillTheSmallerTeamAndStartNeedsFullTeams(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil)
+
+	if _, err := gameService.CreateSession(ctx, models.GameModeSinglePlayer, "p1", "Alice", nil, "", models.SessionSettings{TeamSize: 2}); !errors.Is(err, ErrInvalidSessionSettings) {
+		t.Fatalf("Expected teams to require a multiplayer session, got %v", err)
+	}
+	if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", models.SessionSettings{TeamSize: 3}); !errors.Is(err, ErrInvalidSessionSettings) {
+		t.Fatalf("Expected a team size of 3 to be refused, got %v", err)
+	}
+
+	session, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Alice", nil, "", models.SessionSettings{TeamSize: 2})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if _, err := gameService.JoinSession(ctx, session.SessionID, "p2", "Bob", ""); err != nil {
+		t.Fatalf("JoinSession failed: %v", err)
+	}
+	if err := gameService.StartGame(ctx, session.SessionID); !errors.Is(err, ErrTeamsIncomplete) {
+		t.Fatalf("Expected a 1v1 start to be refused, got %v", err)
+	}
+
+	for i := 3; i <= 4; i++ {
+		if _, err := gameService.JoinSession(ctx, session.SessionID, fmt.Sprintf("p%d", i), "Player", ""); err != nil {
+			t.Fatalf("JoinSession failed: %v", err)
+		}
+	}
+	if _, err := gameService.JoinSession(ctx, session.SessionID, "p5", "Eve", ""); !errors.Is(err, ErrSessionFull) {
+		t.Fatalf("Expected a fifth player to be refused, got %v", err)
+	}
+
+	rosters := teamRosters(gameSessionRepo.sessions[session.SessionID])
+	if len(rosters[models.TeamRed]) != 2 || len(rosters[models.TeamBlue]) != 2 || rosters[models.TeamRed][0] != "p1" || rosters[models.TeamBlue][0] != "p2" {
+		t.Fatalf("Expected players to alternate between teams, got %v", rosters)
+	}
+	if err := gameService.StartGame(ctx, session.SessionID); err != nil {
+		t.Errorf("Expected full teams to start, got %v", err)
+	}
+}
+
+func TestTeams_HostAssignsAndBalancesByRating(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	profiles := NewMockPlayerProfileRepository()
+	profiles.profiles["p1"] = &models.PlayerProfile{PlayerID: "p1", GamesPlayed: 4, AverageScore: 90}
+	profiles.profiles["p2"] = &models.PlayerProfile{PlayerID: "p2", GamesPlayed: 4, AverageScore: 80}
+	profiles.profiles["p3"] = &models.PlayerProfile{PlayerID: "p3", GamesPlayed: 4, AverageScore: 20}
+	gameSessionRepo.sessions["s1"] = &models.GameSession{
+		SessionID: "s1",
+		Mode:      models.GameModeMultiplayer,
+		HostID:    "p1",
+		Status:    models.GameStatusWaiting,
+		Settings:  models.SessionSettings{TeamSize: 2},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", TeamID: models.TeamRed, IsActive: true},
+			{PlayerID: "p2", TeamID: models.TeamRed, IsActive: true},
+			{PlayerID: "p3", TeamID: models.TeamBlue, IsActive: true},
+			{PlayerID: "p4", TeamID: models.TeamBlue, IsActive: true},
+		},
+	}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, &MockAIClient{}, nil, nil,
+		WithProfileService(NewProfileService(profiles)),
+	)
+
+	if _, err := gameService.AssignTeam(ctx, "s1", "p2", "p3", models.TeamRed); !errors.Is(err, ErrNotHost) {
+		t.Fatalf("Expected only the host to assign teams, got %v", err)
+	}
+	if _, err := gameService.AssignTeam(ctx, "s1", "p1", "p3", models.TeamRed); !errors.Is(err, ErrTeamFull) {
+		t.Fatalf("Expected a move onto a full team to be refused, got %v", err)
+	}
+
+	// 90 and 80 start on the same team; balancing pairs each strong player with a weaker one
+	session, err := gameService.BalanceTeams(ctx, "s1", "p1")
+	if err != nil {
+		t.Fatalf("BalanceTeams failed: %v", err)
+	}
+	rosters := teamRosters(session)
+	if len(rosters[models.TeamRed]) != 2 || len(rosters[models.TeamBlue]) != 2 {
+		t.Fatalf("Expected even teams, got %v", rosters)
+	}
+	if sessionPlayer(session, "p1").TeamID == sessionPlayer(session, "p2").TeamID {
+		t.Errorf("Expected the two strongest players on different teams, got %v", rosters)
+	}
+	if sessionPlayer(session, "p2").TeamID != sessionPlayer(session, "p4").TeamID {
+		t.Errorf("Expected the unrated player to join the weaker pair, got %v", rosters)
+	}
+}
+
+func TestTeams_WinByAveragePositionAndRankTeams(t *testing.T) {
+	ctx := context.Background()
+	playerPathRepo := NewMockPlayerPathRepository()
+	playerPathRepo.paths["p1"] = &models.PlayerPath{PlayerID: "p1", CurrentPosition: 10, TotalDoors: 8}
+	playerPathRepo.paths["p2"] = &models.PlayerPath{PlayerID: "p2", CurrentPosition: 6, TotalDoors: 8}
+	playerPathRepo.paths["p3"] = &models.PlayerPath{PlayerID: "p3", CurrentPosition: 9, TotalDoors: 8}
+	playerPathRepo.paths["p4"] = &models.PlayerPath{PlayerID: "p4", CurrentPosition: 2, TotalDoors: 10}
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, playerPathRepo, nil, &MockAIClient{}, nil, nil).(*GameServiceImpl)
+
+	session := &models.GameSession{
+		SessionID: "s1",
+		Settings:  models.SessionSettings{TeamSize: 2},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", TeamID: models.TeamRed, TotalScore: 300, IsActive: true},
+			{PlayerID: "p2", TeamID: models.TeamRed, TotalScore: 400, IsActive: true},
+			{PlayerID: "p3", TeamID: models.TeamBlue, TotalScore: 500, IsActive: true},
+			{PlayerID: "p4", TeamID: models.TeamBlue, TotalScore: 100, IsActive: true},
+		},
+	}
+
+	// Red has covered 16 of 16 doors between them; blue's p3 finished alone but blue has not
+	teamID, mvp := gameService.teamWinner(ctx, session)
+	if teamID != models.TeamRed || mvp != "p2" {
+		t.Fatalf("Expected red to win with p2 as its top scorer, got %q and %q", teamID, mvp)
+	}
+
+	session.WinnerTeamID = teamID
+	rankings, _ := gameService.rankingEngine.Rankings(ctx, session)
+	teams := rankTeams(session, rankings)
+	if len(teams) != 2 || teams[0].TeamID != models.TeamRed || !teams[0].IsWinner || teams[0].Rank != 1 {
+		t.Fatalf("Expected red ranked first, got %+v", teams)
+	}
+	if teams[0].TotalScore != 700 || teams[0].AverageScore != 350 || teams[1].TotalScore != 600 {
+		t.Errorf("Expected team scores of 700 and 600, got %+v", teams)
+	}
+}
+
+func TestPathScore_UsesTeamAverage(t *testing.T) {
+	session := &models.GameSession{
+		Settings: models.SessionSettings{TeamSize: 2},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", TeamID: models.TeamRed, Responses: []models.PlayerResponse{{DoorID: "door-1", AIScore: 80}}},
+			{PlayerID: "p2", TeamID: models.TeamRed, Responses: []models.PlayerResponse{{DoorID: "door-1", AIScore: 20}}},
+			{PlayerID: "p3", TeamID: models.TeamBlue, Responses: []models.PlayerResponse{{DoorID: "door-1", AIScore: 100}}},
+		},
+	}
+
+	if score := pathScore(session, "p2", "door-1", 20); score != 50 {
+		t.Errorf("Expected the red team's average of 50, got %d", score)
+	}
+	session.Settings.TeamSize = 0
+	if score := pathScore(session, "p2", "door-1", 20); score != 20 {
+		t.Errorf("Expected the player's own score outside team play, got %d", score)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Theme catalog errors
+var (
+	ErrThemeNotFound    = errors.New("theme not found")
+	ErrThemeUnavailable = errors.New("theme is not available")
+	ErrInvalidTheme     = errors.New("invalid theme")
+)
+
+// themeIDPattern is the shape of a theme ID: lowercase words joined by dashes
+var themeIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// DefaultThemes are the themes the game shipped with; they seed an empty catalog
+var DefaultThemes = []models.Theme{
+	{ThemeID: "general", Name: "General", Description: "A bit of everything.", Icon: "🚪", MaturityRating: models.MaturityEveryone},
+	{ThemeID: "workplace", Name: "Workplace", Description: "Office politics, awkward meetings and the shared fridge.", Icon: "💼", MaturityRating: models.MaturityEveryone},
+	{ThemeID: "social", Name: "Social", Description: "Parties, group chats and social faux pas.", Icon: "🎉", MaturityRating: models.MaturityTeen},
+	{ThemeID: "technology", Name: "Technology", Description: "Gadgets and software with minds of their own.", Icon: "💻", MaturityRating: models.MaturityEveryone},
+	{ThemeID: "adventure", Name: "Adventure", Description: "Jungles, caves and ill-advised expeditions.", Icon: "🗺️", MaturityRating: models.MaturityEveryone},
+	{ThemeID: "mystery", Name: "Mystery", Description: "Locked rooms, strange notes and missing briefcases.", Icon: "🔍", MaturityRating: models.MaturityEveryone},
+	{ThemeID: "comedy", Name: "Comedy", Description: "Situations too absurd to take seriously.", Icon: "🤡", MaturityRating: models.MaturityEveryone},
+	{ThemeID: "survival", Name: "Survival", Description: "Stranded, outnumbered and out of snacks.", Icon: "🏕️", MaturityRating: models.MaturityTeen},
+}
+
+// ThemeUpdate holds the theme fields an admin may change; nil fields are left as they are
+type ThemeUpdate struct {
+	Name           *string                `json:"name,omitempty"`
+	Description    *string                `json:"description,omitempty"`
+	Icon           *string                `json:"icon,omitempty"`
+	MaturityRating *models.MaturityRating `json:"maturityRating,omitempty"`
+	Enabled        *bool                  `json:"enabled,omitempty"`
+}
+
+// ThemeService manages the catalog of themes sessions can be played in
+type ThemeService interface {
+	ListThemes(ctx context.Context, includeDisabled bool) ([]*models.Theme, error)
+	GetTheme(ctx context.Context, themeID string) (*models.Theme, error)
+	CreateTheme(ctx context.Context, theme *models.Theme) (*models.Theme, error)
+	UpdateTheme(ctx context.Context, themeID string, update ThemeUpdate) (*models.Theme, error)
+	DeleteTheme(ctx context.Context, themeID string) error
+	RequireEnabled(ctx context.Context, themeID string) error
+	SeedDefaults(ctx context.Context) error
+}
+
+// ThemeServiceImpl implements the ThemeService interface
+type ThemeServiceImpl struct {
+	themeRepo repositories.ThemeRepository
+}
+
+// NewThemeService creates a new theme service
+func NewThemeService(themeRepo repositories.ThemeRepository) ThemeService {
+	return &ThemeServiceImpl{themeRepo: themeRepo}
+}
+
+// ListThemes returns the catalog; players only see enabled themes
+func (s *ThemeServiceImpl) ListThemes(ctx context.Context, includeDisabled bool) ([]*models.Theme, error) {
+	themes, err := s.themeRepo.List(ctx, !includeDisabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list themes: %w", err)
+	}
+	return themes, nil
+}
+
+// GetTheme retrieves a theme whether or not it is enabled
+func (s *ThemeServiceImpl) GetTheme(ctx context.Context, themeID string) (*models.Theme, error) {
+	theme, err := s.themeRepo.GetByID(ctx, themeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get theme: %w", err)
+	}
+	if theme == nil {
+		return nil, ErrThemeNotFound
+	}
+	return theme, nil
+}
+
+// CreateTheme adds a theme to the catalog. New themes are rated for everyone unless rated otherwise.
+func (s *ThemeServiceImpl) CreateTheme(ctx context.Context, theme *models.Theme) (*models.Theme, error) {
+	theme.ThemeID = strings.TrimSpace(theme.ThemeID)
+	if theme.MaturityRating == "" {
+		theme.MaturityRating = models.MaturityEveryone
+	}
+	if err := validateTheme(theme); err != nil {
+		return nil, err
+	}
+
+	theme.CreatedAt = time.Now()
+	theme.UpdatedAt = nil
+	if err := s.themeRepo.Create(ctx, theme); err != nil {
+		if errors.Is(err, repositories.ErrThemeExists) {
+			return nil, fmt.Errorf("%w: theme %q already exists", ErrInvalidTheme, theme.ThemeID)
+		}
+		return nil, fmt.Errorf("failed to create theme: %w", err)
+	}
+	return theme, nil
+}
+
+// UpdateTheme edits a theme's metadata or enables and disables it
+func (s *ThemeServiceImpl) UpdateTheme(ctx context.Context, themeID string, update ThemeUpdate) (*models.Theme, error) {
+	theme, err := s.GetTheme(ctx, themeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		theme.Name = *update.Name
+	}
+	if update.Description != nil {
+		theme.Description = *update.Description
+	}
+	if update.Icon != nil {
+		theme.Icon = *update.Icon
+	}
+	if update.MaturityRating != nil {
+		theme.MaturityRating = *update.MaturityRating
+	}
+	if update.Enabled != nil {
+		theme.Enabled = *update.Enabled
+	}
+	if err := validateTheme(theme); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	theme.UpdatedAt = &now
+	if err := s.themeRepo.Update(ctx, theme); err != nil {
+		return nil, fmt.Errorf("failed to update theme: %w", err)
+	}
+	return theme, nil
+}
+
+// DeleteTheme removes a theme from the catalog; its doors are kept
+func (s *ThemeServiceImpl) DeleteTheme(ctx context.Context, themeID string) error {
+	if _, err := s.GetTheme(ctx, themeID); err != nil {
+		return err
+	}
+	if err := s.themeRepo.Delete(ctx, themeID); err != nil {
+		return fmt.Errorf("failed to delete theme: %w", err)
+	}
+	return nil
+}
+
+// RequireEnabled checks that new sessions may be played in the theme
+func (s *ThemeServiceImpl) RequireEnabled(ctx context.Context, themeID string) error {
+	theme, err := s.themeRepo.GetByID(ctx, themeID)
+	if err != nil {
+		return fmt.Errorf("failed to get theme: %w", err)
+	}
+	if theme == nil || !theme.Enabled {
+		return fmt.Errorf("%w: %q", ErrThemeUnavailable, themeID)
+	}
+	return nil
+}
+
+// SeedDefaults adds any default theme missing from the catalog, enabled. Themes already in
+// the catalog are left as admins configured them.
+func (s *ThemeServiceImpl) SeedDefaults(ctx context.Context) error {
+	for _, theme := range DefaultThemes {
+		seeded := theme
+		seeded.Enabled = true
+		seeded.CreatedAt = time.Now()
+		if err := s.themeRepo.Create(ctx, &seeded); err != nil && !errors.Is(err, repositories.ErrThemeExists) {
+			return fmt.Errorf("failed to seed theme %s: %w", theme.ThemeID, err)
+		}
+	}
+	return nil
+}
+
+// validateTheme checks the fields every theme needs
+func validateTheme(theme *models.Theme) error {
+	theme.Name = strings.TrimSpace(theme.Name)
+
+	switch {
+	case !themeIDPattern.MatchString(theme.ThemeID):
+		return fmt.Errorf("%w: theme ID must be lowercase letters, digits and dashes", ErrInvalidTheme)
+	case theme.Name == "":
+		return fmt.Errorf("%w: theme name is required", ErrInvalidTheme)
+	case !theme.MaturityRating.Valid():
+		return fmt.Errorf("%w: unknown maturity rating %q", ErrInvalidTheme, theme.MaturityRating)
+	}
+	return nil
+}
+
+// WithThemeCatalog restricts new sessions to the catalog's enabled themes
+func WithThemeCatalog(themes ThemeService) GameServiceOption {
+	return func(s *GameServiceImpl) {
+		s.themes = themes
+	}
+}
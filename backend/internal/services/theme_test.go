@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"sort"
+	"testing"
+)
+
+// MockThemeRepository keeps the theme catalog in memory
+type MockThemeRepository struct {
+	themes map[string]*models.Theme
+}
+
+func NewMockThemeRepository() *MockThemeRepository {
+	return &MockThemeRepository{themes: make(map[string]*models.Theme)}
+}
+
+func (m *MockThemeRepository) Create(ctx context.Context, theme *models.Theme) error {
+	if _, exists := m.themes[theme.ThemeID]; exists {
+		return repositories.ErrThemeExists
+	}
+	m.themes[theme.ThemeID] = theme
+	return nil
+}
+
+func (m *MockThemeRepository) GetByID(ctx context.Context, themeID string) (*models.Theme, error) {
+	return m.themes[themeID], nil
+}
+
+func (m *MockThemeRepository) List(ctx context.Context, enabledOnly bool) ([]*models.Theme, error) {
+	themes := []*models.Theme{}
+	for _, theme := range m.themes {
+		if theme.Enabled || !enabledOnly {
+			themes = append(themes, theme)
+		}
+	}
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return themes, nil
+}
+
+func (m *MockThemeRepository) Update(ctx context.Context, theme *models.Theme) error {
+	m.themes[theme.ThemeID] = theme
+	return nil
+}
+
+func (m *MockThemeRepository) Delete(ctx context.Context, themeID string) error {
+	delete(m.themes, themeID)
+	return nil
+}
+
+func TestThemeService_SeedDefaultsKeepsAdminChanges(t *testing.T) {
+	ctx := context.Background()
+	themes := NewThemeService(NewMockThemeRepository())
+
+	if err := themes.SeedDefaults(ctx); err != nil {
+		t.Fatalf("SeedDefaults failed: %v", err)
+	}
+	catalog, _ := themes.ListThemes(ctx, false)
+	if len(catalog) != len(DefaultThemes) {
+		t.Fatalf("Expected every default theme enabled, got %d", len(catalog))
+	}
+
+	disabled := false
+	if _, err := themes.UpdateTheme(ctx, "survival", ThemeUpdate{Enabled: &disabled}); err != nil {
+		t.Fatalf("UpdateTheme failed: %v", err)
+	}
+	if err := themes.SeedDefaults(ctx); err != nil {
+		t.Fatalf("SeedDefaults failed: %v", err)
+	}
+
+	// Seeding again doesn't bring a disabled theme back, but admins still see it
+	if catalog, _ = themes.ListThemes(ctx, false); len(catalog) != len(DefaultThemes)-1 {
+		t.Errorf("Expected the disabled theme to stay hidden, got %d themes", len(catalog))
+	}
+	if catalog, _ = themes.ListThemes(ctx, true); len(catalog) != len(DefaultThemes) {
+		t.Errorf("Expected admins to see every theme, got %d", len(catalog))
+	}
+}
+
+func TestThemeService_ValidatesThemes(t *testing.T) {
+	ctx := context.Background()
+	themes := NewThemeService(NewMockThemeRepository())
+
+	theme, err := themes.CreateTheme(ctx, &models.Theme{ThemeID: "space-station", Name: " Space Station "})
+	if err != nil {
+		t.Fatalf("CreateTheme failed: %v", err)
+	}
+	if theme.Name != "Space Station" || theme.MaturityRating != models.MaturityEveryone || theme.Enabled {
+		t.Errorf("Expected a trimmed, disabled theme rated for everyone, got %+v", theme)
+	}
+
+	invalid := []*models.Theme{
+		{ThemeID: "space-station", Name: "Again"},
+		{ThemeID: "Space Station", Name: "Spaces"},
+		{ThemeID: "horror", Name: ""},
+		{ThemeID: "horror", Name: "Horror", MaturityRating: "adults-only"},
+	}
+	for _, theme := range invalid {
+		if _, err := themes.CreateTheme(ctx, theme); !errors.Is(err, ErrInvalidTheme) {
+			t.Errorf("Expected %+v to be rejected, got %v", theme, err)
+		}
+	}
+
+	if _, err := themes.GetTheme(ctx, "horror"); !errors.Is(err, ErrThemeNotFound) {
+		t.Errorf("Expected ErrThemeNotFound, got %v", err)
+	}
+}
+
+func TestCreateSession_RequiresEnabledTheme(t *testing.T) {
+	ctx := context.Background()
+	themes := NewThemeService(NewMockThemeRepository())
+	themes.SeedDefaults(ctx)
+	disabled := false
+	themes.UpdateTheme(ctx, "survival", ThemeUpdate{Enabled: &disabled})
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil, WithThemeCatalog(themes))
+
+	for _, theme := range []string{"survival", "underwater-basket-weaving"} {
+		if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Player 1", &theme, "en", models.SessionSettings{}); !errors.Is(err, ErrThemeUnavailable) {
+			t.Errorf("Expected theme %q to be unavailable, got %v", theme, err)
+		}
+	}
+
+	theme := "workplace"
+	if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Player 1", &theme, "en", models.SessionSettings{}); err != nil {
+		t.Errorf("Expected an enabled theme to be accepted, got %v", err)
+	}
+	if _, err := gameService.CreateSession(ctx, models.GameModeMultiplayer, "p1", "Player 1", nil, "en", models.SessionSettings{}); err != nil {
+		t.Errorf("Expected sessions without a theme to be accepted, got %v", err)
+	}
+
+	// Players can't queue for a theme no session could be created in
+	matchmaking, _, _ := newTestMatchmaking(WithMatchmakingThemeCatalog(themes))
+	if _, err := matchmaking.Enqueue(ctx, "p1", "Player 1", "survival", 1); !errors.Is(err, ErrThemeUnavailable) {
+		t.Errorf("Expected matchmaking to reject a disabled theme, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"time"
+)
+
+// Timer event types
+const (
+	// EventTimerTick is sent to every connected player of a round every tick interval
+	EventTimerTick = "timer-tick"
+	// EventTimerSync is sent to a player as their connection opens
+	EventTimerSync = "timer-sync"
+)
+
+// DefaultTimerTickInterval is how often connected players are sent their round deadlines
+const DefaultTimerTickInterval = 10 * time.Second
+
+// PlayerTimer is the response deadline of the door a player is answering. ServerTime lets
+// clients correct for their clock being off, so every client counts down to the same instant.
+type PlayerTimer struct {
+	PlayerID    string    `json:"playerId"`
+	DoorID      string    `json:"doorId"`
+	Deadline    time.Time `json:"deadline"`
+	RemainingMs int64     `json:"remainingMs"`
+}
+
+// TimerSyncService broadcasts the authoritative round deadlines, read from the deadline
+// scheduler that will fire them, so clients don't have to guess at the countdown
+type TimerSyncService interface {
+	Start(ctx context.Context)
+	Tick(ctx context.Context) error
+	SyncEvent(ctx context.Context, session *models.GameSession, playerID string) *WebSocketEvent
+}
+
+// TimerSyncServiceImpl implements the TimerSyncService interface
+type TimerSyncServiceImpl struct {
+	gameSessionRepo repositories.GameSessionRepository
+	scheduler       DeadlineScheduler
+	wsManager       WebSocketManager
+	interval        time.Duration
+}
+
+// NewTimerSyncService creates a service that sends timer ticks to this instance's connected
+// sessions every interval
+func NewTimerSyncService(gameSessionRepo repositories.GameSessionRepository, scheduler DeadlineScheduler, wsManager WebSocketManager, interval time.Duration) TimerSyncService {
+	if interval <= 0 {
+		interval = DefaultTimerTickInterval
+	}
+	return &TimerSyncServiceImpl{
+		gameSessionRepo: gameSessionRepo,
+		scheduler:       scheduler,
+		wsManager:       wsManager,
+		interval:        interval,
+	}
+}
+
+// Start sends timer ticks every interval until the context is cancelled
+func (t *TimerSyncServiceImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.Tick(ctx); err != nil {
+				fmt.Printf("Warning: failed to send timer ticks: %v\n", err)
+			}
+		}
+	}
+}
+
+// Tick sends each session with players connected to this instance the deadlines of its open
+// round. Every instance ticks only its own connections, so players aren't sent duplicates.
+// Sessions that are paused or between rounds have no countdown and are skipped.
+func (t *TimerSyncServiceImpl) Tick(ctx context.Context) error {
+	var lastErr error
+	for _, sessionID := range t.wsManager.LocalSessionIDs() {
+		session, err := t.gameSessionRepo.GetByID(ctx, sessionID)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to get session %s: %w", sessionID, err)
+			continue
+		}
+		if session == nil || session.Status != models.GameStatusActive {
+			continue
+		}
+
+		now := time.Now()
+		timers, err := t.playerTimers(ctx, session, now)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(timers) == 0 {
+			continue
+		}
+
+		event := WebSocketEvent{
+			Type:      EventTimerTick,
+			SessionID: sessionID,
+			Data: map[string]interface{}{
+				"serverTime": now,
+				"timers":     timers,
+			},
+			Timestamp: now,
+		}
+		if err := t.wsManager.SendToLocalSession(sessionID, event); err != nil {
+			lastErr = fmt.Errorf("failed to send timer tick to session %s: %w", sessionID, err)
+		}
+	}
+	return lastErr
+}
+
+// SyncEvent returns the timer-sync event for a player who has just connected, or nil if the
+// session has no round counting down. While the game is paused it carries the time left
+// instead of a deadline.
+func (t *TimerSyncServiceImpl) SyncEvent(ctx context.Context, session *models.GameSession, playerID string) *WebSocketEvent {
+	now := time.Now()
+	data := map[string]interface{}{"serverTime": now}
+
+	switch session.Status {
+	case models.GameStatusPaused:
+		if session.RoundDeadline == nil {
+			return nil
+		}
+		data["paused"] = true
+		data["remainingMs"] = session.TimeRemaining(now).Milliseconds()
+		if session.PausedAt != nil {
+			// Counted from the pause, which keeps any extra time the player has
+			data["remainingMs"] = remainingMs(*session.PlayerDeadline(playerID), *session.PausedAt)
+		}
+	case models.GameStatusActive:
+		door := session.DoorForPlayer(playerID)
+		if door == nil {
+			return nil
+		}
+		deadline, pending, err := t.scheduler.Deadline(ctx, session.SessionID, door.DoorID)
+		if err != nil {
+			fmt.Printf("Warning: failed to get deadline for door %s: %v\n", door.DoorID, err)
+			return nil
+		}
+		if !pending {
+			return nil
+		}
+		deadline = ownDeadline(session, playerID, deadline)
+		data["doorId"] = door.DoorID
+		data["deadline"] = deadline
+		data["remainingMs"] = remainingMs(deadline, now)
+	default:
+		return nil
+	}
+
+	return &WebSocketEvent{
+		Type:      EventTimerSync,
+		SessionID: session.SessionID,
+		PlayerID:  playerID,
+		Data:      data,
+		Timestamp: now,
+	}
+}
+
+// playerTimers returns the pending deadline of each active player's door. Players on the same
+// door share one scheduler lookup.
+func (t *TimerSyncServiceImpl) playerTimers(ctx context.Context, session *models.GameSession, now time.Time) ([]PlayerTimer, error) {
+	deadlines := make(map[string]*time.Time)
+	var timers []PlayerTimer
+
+	for _, player := range session.Players {
+		door := session.DoorForPlayer(player.PlayerID)
+		if !player.IsActive || player.IsBot || door == nil {
+			continue
+		}
+
+		deadline, looked := deadlines[door.DoorID]
+		if !looked {
+			at, pending, err := t.scheduler.Deadline(ctx, session.SessionID, door.DoorID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deadline for door %s: %w", door.DoorID, err)
+			}
+			if pending {
+				deadline = &at
+			}
+			deadlines[door.DoorID] = deadline
+		}
+		if deadline == nil {
+			continue
+		}
+
+		own := ownDeadline(session, player.PlayerID, *deadline)
+		timers = append(timers, PlayerTimer{
+			PlayerID:    player.PlayerID,
+			DoorID:      door.DoorID,
+			Deadline:    own,
+			RemainingMs: remainingMs(own, now),
+		})
+	}
+	return timers, nil
+}
+
+// ownDeadline returns the player's deadline for a door scheduled to close at scheduled. The
+// door waits for the players with the most extra time, so everyone else's time runs out sooner.
+func ownDeadline(session *models.GameSession, playerID string, scheduled time.Time) time.Time {
+	if deadline := session.PlayerDeadline(playerID); deadline != nil && deadline.Before(scheduled) {
+		return *deadline
+	}
+	return scheduled
+}
+
+// remainingMs returns the milliseconds left until a deadline, or zero once it has passed
+func remainingMs(deadline, now time.Time) int64 {
+	if remaining := deadline.Sub(now); remaining > 0 {
+		return remaining.Milliseconds()
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// localSessionRecordingWebSocketManager reports connected sessions and records events sent to them
+type localSessionRecordingWebSocketManager struct {
+	*MockWebSocketManager
+	local []string
+	sent  []WebSocketEvent
+}
+
+func (m *localSessionRecordingWebSocketManager) LocalSessionIDs() []string { return m.local }
+
+func (m *localSessionRecordingWebSocketManager) SendToLocalSession(sessionID string, event WebSocketEvent) error {
+	m.sent = append(m.sent, event)
+	return nil
+}
+
+func TestTimerSync_TickSendsSchedulerDeadlines(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Players[0].IsActive, session.Players[1].IsActive = true, true
+	session.Players[1].CurrentDoor = &models.Door{DoorID: "door-2"}
+	gameSessionRepo.sessions["s1"] = session
+	paused := newDraftSession()
+	paused.SessionID, paused.Status = "s2", models.GameStatusPaused
+	gameSessionRepo.sessions["s2"] = paused
+
+	scheduler := NewPersistentScheduler(NewMockDeadlineStore(), time.Hour)
+	shared := time.Now().Add(45 * time.Second).Truncate(time.Millisecond)
+	scheduler.Schedule(ctx, "s1", "door-1", shared)
+	scheduler.Schedule(ctx, "s1", "door-2", shared.Add(5*time.Second))
+	scheduler.Schedule(ctx, "s2", "door-1", shared)
+
+	wsManager := &localSessionRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager(), local: []string{"s1", "s2"}}
+	timerSync := NewTimerSyncService(gameSessionRepo, scheduler, wsManager, 0)
+	if err := timerSync.Tick(ctx); err != nil {
+		t.Fatalf("Tick failed: %v", err)
+	}
+
+	if len(wsManager.sent) != 1 || wsManager.sent[0].Type != EventTimerTick || wsManager.sent[0].SessionID != "s1" {
+		t.Fatalf("Expected one timer tick for the active session only, got %+v", wsManager.sent)
+	}
+	timers := wsManager.sent[0].Data.(map[string]interface{})["timers"].([]PlayerTimer)
+	if len(timers) != 2 {
+		t.Fatalf("Expected a timer per player, got %+v", timers)
+	}
+	if timers[0].DoorID != "door-1" || !timers[0].Deadline.Equal(shared) {
+		t.Errorf("Expected p1 to count down to the shared door's deadline, got %+v", timers[0])
+	}
+	if timers[1].DoorID != "door-2" || !timers[1].Deadline.Equal(shared.Add(5*time.Second)) {
+		t.Errorf("Expected p2 to count down to their own door's deadline, got %+v", timers[1])
+	}
+	if timers[0].RemainingMs <= 0 || timers[0].RemainingMs > 45000 {
+		t.Errorf("Expected up to 45s remaining, got %dms", timers[0].RemainingMs)
+	}
+}
+
+func TestTimerSync_SyncEventOnReconnect(t *testing.T) {
+	ctx := context.Background()
+	scheduler := NewInProcessScheduler()
+	timerSync := NewTimerSyncService(NewMockGameSessionRepository(), scheduler, NewMockWebSocketManager(), 0)
+	session := newDraftSession()
+
+	if event := timerSync.SyncEvent(ctx, session, "p1"); event != nil {
+		t.Fatalf("Expected no sync without a pending deadline, got %+v", event)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	scheduler.Schedule(ctx, "s1", "door-1", deadline)
+	defer scheduler.Cancel(ctx, "s1", "door-1")
+
+	event := timerSync.SyncEvent(ctx, session, "p1")
+	if event == nil || event.Type != EventTimerSync || event.PlayerID != "p1" {
+		t.Fatalf("Expected a timer sync for p1, got %+v", event)
+	}
+	data := event.Data.(map[string]interface{})
+	if at, _ := data["deadline"].(time.Time); !at.Equal(deadline) || data["doorId"] != "door-1" {
+		t.Errorf("Expected the scheduled deadline for door-1, got %+v", data)
+	}
+
+	pausedAt := time.Now()
+	roundDeadline := pausedAt.Add(20 * time.Second)
+	session.Status, session.PausedAt, session.RoundDeadline = models.GameStatusPaused, &pausedAt, &roundDeadline
+	data = timerSync.SyncEvent(ctx, session, "p1").Data.(map[string]interface{})
+	if data["paused"] != true || data["remainingMs"] != int64(20000) {
+		t.Errorf("Expected a paused sync with 20s left, got %+v", data)
+	}
+}
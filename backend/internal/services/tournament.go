@@ -0,0 +1,452 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/random"
+	"dumdoors-backend/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// DefaultTournamentPollInterval is how often running tournaments check their match sessions
+const DefaultTournamentPollInterval = 5 * time.Second
+
+// TournamentService runs single-elimination tournaments made of multiplayer sessions
+type TournamentService interface {
+	Start(ctx context.Context)
+	CreateTournament(ctx context.Context, creatorID, username, name string, size int, theme *string) (*models.Tournament, error)
+	JoinTournament(ctx context.Context, tournamentID, playerID, username string) (*models.Tournament, error)
+	GetTournament(ctx context.Context, tournamentID string) (*models.Tournament, error)
+	AdvanceTournaments(ctx context.Context) error
+}
+
+// TournamentServiceImpl implements the TournamentService interface
+type TournamentServiceImpl struct {
+	tournamentRepo  repositories.TournamentRepository
+	gameSessionRepo repositories.GameSessionRepository
+	gameService     GameService
+	wsManager       WebSocketManager
+	pollInterval    time.Duration
+}
+
+// TournamentOption configures optional tournament settings
+type TournamentOption func(*TournamentServiceImpl)
+
+// WithTournamentPollInterval sets how often match sessions are checked for results
+func WithTournamentPollInterval(interval time.Duration) TournamentOption {
+	return func(t *TournamentServiceImpl) {
+		if interval > 0 {
+			t.pollInterval = interval
+		}
+	}
+}
+
+// NewTournamentService creates a new tournament service
+func NewTournamentService(tournamentRepo repositories.TournamentRepository, gameSessionRepo repositories.GameSessionRepository, gameService GameService, wsManager WebSocketManager, opts ...TournamentOption) TournamentService {
+	service := &TournamentServiceImpl{
+		tournamentRepo:  tournamentRepo,
+		gameSessionRepo: gameSessionRepo,
+		gameService:     gameService,
+		wsManager:       wsManager,
+		pollInterval:    DefaultTournamentPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// Start advances running tournaments every poll interval until the context is cancelled
+func (t *TournamentServiceImpl) Start(ctx context.Context) {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.AdvanceTournaments(ctx); err != nil {
+				fmt.Printf("Warning: failed to advance tournaments: %v\n", err)
+			}
+		}
+	}
+}
+
+// CreateTournament opens registration for a bracket of 8 or 16 players, with the creator
+// registered first
+func (t *TournamentServiceImpl) CreateTournament(ctx context.Context, creatorID, username, name string, size int, theme *string) (*models.Tournament, error) {
+	if creatorID == "" {
+		return nil, fmt.Errorf("creator ID is required")
+	}
+	if !models.ValidTournamentSize(size) {
+		return nil, fmt.Errorf("tournament size must be 8 or 16 players")
+	}
+	if name == "" {
+		name = fmt.Sprintf("%s's tournament", username)
+	}
+
+	tournament := &models.Tournament{
+		TournamentID: random.ID(),
+		Name:         name,
+		Theme:        theme,
+		Size:         size,
+		Status:       models.TournamentStatusRegistering,
+		CreatedBy:    creatorID,
+		Players: []models.TournamentPlayer{{
+			PlayerID: creatorID,
+			Username: username,
+			JoinedAt: time.Now(),
+		}},
+		Rounds: []models.TournamentRound{},
+	}
+
+	if err := t.tournamentRepo.Create(ctx, tournament); err != nil {
+		return nil, fmt.Errorf("failed to create tournament: %w", err)
+	}
+
+	return tournament, nil
+}
+
+// JoinTournament registers a player; the player who fills the bracket starts the first round
+func (t *TournamentServiceImpl) JoinTournament(ctx context.Context, tournamentID, playerID, username string) (*models.Tournament, error) {
+	tournament, err := t.GetTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case tournament.HasPlayer(playerID):
+		return nil, fmt.Errorf("player already registered for tournament")
+	case tournament.Status != models.TournamentStatusRegistering:
+		return nil, fmt.Errorf("tournament has already started")
+	case len(tournament.Players) >= tournament.Size:
+		return nil, fmt.Errorf("tournament is full")
+	}
+
+	player := models.TournamentPlayer{
+		PlayerID: playerID,
+		Username: username,
+		JoinedAt: time.Now(),
+	}
+	if err := t.tournamentRepo.AddPlayer(ctx, tournamentID, player); err != nil {
+		return nil, fmt.Errorf("failed to join tournament: %w", err)
+	}
+
+	tournament, err = t.GetTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.broadcastBracket(tournament, "tournament-player-joined")
+
+	if tournament.Status == models.TournamentStatusRegistering && len(tournament.Players) == tournament.Size {
+		if err := t.startBracket(ctx, tournament); err != nil {
+			return nil, fmt.Errorf("failed to start tournament: %w", err)
+		}
+	}
+
+	return tournament, nil
+}
+
+// GetTournament retrieves a tournament by ID
+func (t *TournamentServiceImpl) GetTournament(ctx context.Context, tournamentID string) (*models.Tournament, error) {
+	tournament, err := t.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	if tournament == nil {
+		return nil, fmt.Errorf("tournament not found")
+	}
+	return tournament, nil
+}
+
+// AdvanceTournaments records finished matches, moves winners to the next round and crowns
+// the champion of every running tournament
+func (t *TournamentServiceImpl) AdvanceTournaments(ctx context.Context) error {
+	tournaments, err := t.tournamentRepo.GetByStatus(ctx, models.TournamentStatusInProgress)
+	if err != nil {
+		return fmt.Errorf("failed to list running tournaments: %w", err)
+	}
+
+	for _, tournament := range tournaments {
+		if err := t.advance(ctx, tournament); err != nil && !errors.Is(err, repositories.ErrTournamentConflict) {
+			fmt.Printf("Warning: failed to advance tournament %s: %v\n", tournament.TournamentID, err)
+		}
+	}
+
+	return nil
+}
+
+// startBracket seeds players in join order and pairs them for the first round
+func (t *TournamentServiceImpl) startBracket(ctx context.Context, tournament *models.Tournament) error {
+	now := time.Now()
+	tournament.Status = models.TournamentStatusInProgress
+	tournament.StartedAt = &now
+
+	seeded := make([]string, len(tournament.Players))
+	for i := range tournament.Players {
+		tournament.Players[i].Seed = i + 1
+		seeded[i] = tournament.Players[i].PlayerID
+	}
+
+	// Standard bracket order keeps the top seeds apart until the late rounds
+	var pairs [][]string
+	order := bracketOrder(len(seeded))
+	for i := 0; i+1 < len(order); i += 2 {
+		pairs = append(pairs, []string{seeded[order[i]-1], seeded[order[i+1]-1]})
+	}
+	addRound(tournament, pairs)
+
+	// Claiming the bracket before creating sessions keeps instances from starting it twice
+	if err := t.tournamentRepo.Update(ctx, tournament); err != nil {
+		return err
+	}
+
+	t.broadcastBracket(tournament, "tournament-started")
+	return t.startPendingMatches(ctx, tournament)
+}
+
+// advance decides any finished matches in the current round and, once all are decided,
+// starts the next round or completes the tournament
+func (t *TournamentServiceImpl) advance(ctx context.Context, tournament *models.Tournament) error {
+	round := tournament.Round()
+	if round == nil {
+		return nil
+	}
+
+	changed := false
+	for i := range round.Matches {
+		match := &round.Matches[i]
+		if match.Completed() || match.SessionID == "" {
+			continue
+		}
+
+		session, err := t.gameSessionRepo.GetByID(ctx, match.SessionID)
+		if err != nil {
+			fmt.Printf("Warning: failed to check tournament match %s: %v\n", match.MatchID, err)
+			continue
+		}
+		if session == nil || session.Status != models.GameStatusCompleted {
+			continue
+		}
+
+		completedAt := time.Now()
+		match.WinnerID = matchWinner(match, session)
+		match.CompletedAt = &completedAt
+		for _, playerID := range match.PlayerIDs {
+			if player := tournament.Player(playerID); player != nil && playerID != match.WinnerID {
+				player.EliminatedInRound = round.Number
+			}
+		}
+		changed = true
+	}
+
+	roundDecided := true
+	var winners []string
+	for _, match := range round.Matches {
+		if !match.Completed() {
+			roundDecided = false
+			break
+		}
+		winners = append(winners, match.WinnerID)
+	}
+
+	event := "bracket-updated"
+	switch {
+	case roundDecided && len(winners) == 1:
+		now := time.Now()
+		tournament.Status = models.TournamentStatusCompleted
+		tournament.WinnerID = winners[0]
+		tournament.CompletedAt = &now
+		event = "tournament-completed"
+		changed = true
+	case roundDecided:
+		// Winners of adjacent matches meet in the next round
+		var pairs [][]string
+		for i := 0; i+1 < len(winners); i += 2 {
+			pairs = append(pairs, []string{winners[i], winners[i+1]})
+		}
+		addRound(tournament, pairs)
+		event = "tournament-round-started"
+		changed = true
+	}
+
+	if changed {
+		if err := t.tournamentRepo.Update(ctx, tournament); err != nil {
+			return err
+		}
+		t.broadcastBracket(tournament, event)
+	}
+
+	// Retries matches whose sessions could not be created on an earlier pass
+	return t.startPendingMatches(ctx, tournament)
+}
+
+// startPendingMatches creates and starts a session for every current-round match without one
+func (t *TournamentServiceImpl) startPendingMatches(ctx context.Context, tournament *models.Tournament) error {
+	round := tournament.Round()
+	if round == nil || tournament.Status != models.TournamentStatusInProgress {
+		return nil
+	}
+
+	var started []*models.TournamentMatch
+	for i := range round.Matches {
+		match := &round.Matches[i]
+		if match.SessionID != "" || match.Completed() {
+			continue
+		}
+
+		sessionID, err := t.startMatch(ctx, tournament, match)
+		if err != nil {
+			fmt.Printf("Warning: failed to start tournament match %s: %v\n", match.MatchID, err)
+			continue
+		}
+		match.SessionID = sessionID
+		started = append(started, match)
+	}
+
+	if len(started) == 0 {
+		return nil
+	}
+
+	if err := t.tournamentRepo.Update(ctx, tournament); err != nil {
+		return fmt.Errorf("failed to record tournament match sessions: %w", err)
+	}
+
+	for _, match := range started {
+		t.notifyMatchReady(tournament, match)
+	}
+	return nil
+}
+
+// startMatch creates a multiplayer session for the two players of a match and presents its first door
+func (t *TournamentServiceImpl) startMatch(ctx context.Context, tournament *models.Tournament, match *models.TournamentMatch) (string, error) {
+	host := tournament.Player(match.PlayerIDs[0])
+	opponent := tournament.Player(match.PlayerIDs[1])
+	if host == nil || opponent == nil {
+		return "", fmt.Errorf("match players are not registered")
+	}
+
+	session, err := t.gameService.CreateSession(ctx, models.GameModeMultiplayer, host.PlayerID, host.Username, tournament.Theme, i18n.DefaultLocale, models.SessionSettings{})
+	if err != nil {
+		return "", fmt.Errorf("failed to create match session: %w", err)
+	}
+
+	if _, err := t.gameService.JoinSession(ctx, session.SessionID, opponent.PlayerID, opponent.Username, ""); err != nil {
+		return "", fmt.Errorf("failed to add opponent to match session: %w", err)
+	}
+
+	// Matches start without waiting for players; response deadlines keep no-shows from stalling the bracket
+	if err := t.gameService.StartGameWithFirstDoor(ctx, session.SessionID); err != nil {
+		return "", fmt.Errorf("failed to start match session: %w", err)
+	}
+
+	return session.SessionID, nil
+}
+
+// bracketOrder returns seeds 1..size in bracket position order, e.g. 1 8 4 5 2 7 3 6 for
+// eight players, so each first-round pair faces seeds that sum to size+1
+func bracketOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// addRound appends a round with the given pairings and makes it current
+func addRound(tournament *models.Tournament, pairs [][]string) {
+	number := len(tournament.Rounds) + 1
+	round := models.TournamentRound{Number: number}
+	for i, pair := range pairs {
+		round.Matches = append(round.Matches, models.TournamentMatch{
+			MatchID:   fmt.Sprintf("r%d-m%d", number, i+1),
+			PlayerIDs: pair,
+		})
+	}
+	tournament.Rounds = append(tournament.Rounds, round)
+	tournament.CurrentRound = number
+}
+
+// matchWinner returns the session's winner, falling back to the higher total score and then
+// to the better seed when the session ended without one
+func matchWinner(match *models.TournamentMatch, session *models.GameSession) string {
+	for _, playerID := range match.PlayerIDs {
+		if playerID == session.WinnerID {
+			return playerID
+		}
+	}
+
+	winner := match.PlayerIDs[0]
+	best := -1
+	for _, playerID := range match.PlayerIDs {
+		for _, player := range session.Players {
+			if player.PlayerID == playerID && player.TotalScore > best {
+				winner = playerID
+				best = player.TotalScore
+			}
+		}
+	}
+	return winner
+}
+
+// broadcastBracket sends the bracket to every registered player
+func (t *TournamentServiceImpl) broadcastBracket(tournament *models.Tournament, eventType string) {
+	if t.wsManager == nil {
+		return
+	}
+
+	for _, player := range tournament.Players {
+		event := WebSocketEvent{
+			Type:     eventType,
+			PlayerID: player.PlayerID,
+			Data: map[string]interface{}{
+				"tournament": tournament,
+			},
+			Timestamp: time.Now(),
+		}
+
+		if err := t.wsManager.SendToPlayer(player.PlayerID, event); err != nil {
+			log.Printf("Tournament %s update not delivered to player %s: %v", tournament.TournamentID, player.PlayerID, err)
+		}
+	}
+}
+
+// notifyMatchReady tells both players of a match which session to join
+func (t *TournamentServiceImpl) notifyMatchReady(tournament *models.Tournament, match *models.TournamentMatch) {
+	if t.wsManager == nil {
+		return
+	}
+
+	for _, playerID := range match.PlayerIDs {
+		event := WebSocketEvent{
+			Type:      "tournament-match-ready",
+			SessionID: match.SessionID,
+			PlayerID:  playerID,
+			Data: map[string]interface{}{
+				"tournamentId": tournament.TournamentID,
+				"round":        tournament.CurrentRound,
+				"matchId":      match.MatchID,
+				"sessionId":    match.SessionID,
+				"players":      match.PlayerIDs,
+			},
+			Timestamp: time.Now(),
+		}
+
+		// Players who are not connected find their match in the bracket instead
+		if err := t.wsManager.SendToPlayer(playerID, event); err != nil {
+			log.Printf("Tournament match ready for player %s, who is not connected: %v", playerID, err)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/repositories"
+	"fmt"
+	"testing"
+)
+
+// MockTournamentRepository is an in-memory TournamentRepository for testing
+type MockTournamentRepository struct {
+	tournaments map[string]*models.Tournament
+}
+
+func NewMockTournamentRepository() *MockTournamentRepository {
+	return &MockTournamentRepository{tournaments: make(map[string]*models.Tournament)}
+}
+
+func (m *MockTournamentRepository) Create(ctx context.Context, tournament *models.Tournament) error {
+	tournament.Version = 1
+	m.tournaments[tournament.TournamentID] = cloneTournament(tournament)
+	return nil
+}
+
+func (m *MockTournamentRepository) GetByID(ctx context.Context, tournamentID string) (*models.Tournament, error) {
+	tournament, exists := m.tournaments[tournamentID]
+	if !exists {
+		return nil, nil
+	}
+	return cloneTournament(tournament), nil
+}
+
+func (m *MockTournamentRepository) AddPlayer(ctx context.Context, tournamentID string, player models.TournamentPlayer) error {
+	tournament, exists := m.tournaments[tournamentID]
+	if !exists || tournament.Status != models.TournamentStatusRegistering || tournament.HasPlayer(player.PlayerID) || len(tournament.Players) >= tournament.Size {
+		return repositories.ErrTournamentClosed
+	}
+	tournament.Players = append(tournament.Players, player)
+	tournament.Version++
+	return nil
+}
+
+func (m *MockTournamentRepository) Update(ctx context.Context, tournament *models.Tournament) error {
+	stored, exists := m.tournaments[tournament.TournamentID]
+	if !exists || stored.Version != tournament.Version {
+		return repositories.ErrTournamentConflict
+	}
+	tournament.Version++
+	m.tournaments[tournament.TournamentID] = cloneTournament(tournament)
+	return nil
+}
+
+func (m *MockTournamentRepository) GetByStatus(ctx context.Context, status models.TournamentStatus) ([]*models.Tournament, error) {
+	var tournaments []*models.Tournament
+	for _, tournament := range m.tournaments {
+		if tournament.Status == status {
+			tournaments = append(tournaments, cloneTournament(tournament))
+		}
+	}
+	return tournaments, nil
+}
+
+// cloneTournament copies the bracket so callers cannot mutate stored state
+func cloneTournament(tournament *models.Tournament) *models.Tournament {
+	clone := *tournament
+	clone.Players = append([]models.TournamentPlayer(nil), tournament.Players...)
+	clone.Rounds = make([]models.TournamentRound, len(tournament.Rounds))
+	for i, round := range tournament.Rounds {
+		clone.Rounds[i] = round
+		clone.Rounds[i].Matches = append([]models.TournamentMatch(nil), round.Matches...)
+	}
+	return &clone
+}
+
+// unstartedGameService leaves match sessions waiting instead of presenting doors
+type unstartedGameService struct {
+	GameService
+}
+
+func (s *unstartedGameService) StartGameWithFirstDoor(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func newTestTournaments() (*TournamentServiceImpl, *MockTournamentRepository, *MockGameSessionRepository) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameService := &unstartedGameService{NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)}
+	tournamentRepo := NewMockTournamentRepository()
+	service := NewTournamentService(tournamentRepo, gameSessionRepo, gameService, nil).(*TournamentServiceImpl)
+	return service, tournamentRepo, gameSessionRepo
+}
+
+// fillTournament creates a tournament and registers players p1..pN in order
+func fillTournament(t *testing.T, service *TournamentServiceImpl, size int) *models.Tournament {
+	t.Helper()
+	ctx := context.Background()
+
+	tournament, err := service.CreateTournament(ctx, "p1", "Player 1", "Cup", size, nil)
+	if err != nil {
+		t.Fatalf("CreateTournament failed: %v", err)
+	}
+	for i := 2; i <= size; i++ {
+		playerID := fmt.Sprintf("p%d", i)
+		if tournament, err = service.JoinTournament(ctx, tournament.TournamentID, playerID, playerID); err != nil {
+			t.Fatalf("JoinTournament failed for %s: %v", playerID, err)
+		}
+	}
+
+	tournament, err = service.GetTournament(ctx, tournament.TournamentID)
+	if err != nil {
+		t.Fatalf("GetTournament failed: %v", err)
+	}
+	return tournament
+}
+
+// finishMatches completes every current-round session, letting the lower-numbered seed win
+func finishMatches(t *testing.T, tournament *models.Tournament, gameSessionRepo *MockGameSessionRepository) {
+	t.Helper()
+	for _, match := range tournament.Round().Matches {
+		session := gameSessionRepo.sessions[match.SessionID]
+		if session == nil {
+			t.Fatalf("match %s has no session", match.MatchID)
+		}
+		session.Status = models.GameStatusCompleted
+		session.WinnerID = match.PlayerIDs[0]
+	}
+}
+
+func TestCreateTournament_ValidatesSize(t *testing.T) {
+	service, _, _ := newTestTournaments()
+
+	if _, err := service.CreateTournament(context.Background(), "p1", "Player 1", "", 6, nil); err == nil {
+		t.Error("Expected a six-player bracket to be rejected")
+	}
+}
+
+func TestJoinTournament_FullBracketStartsFirstRound(t *testing.T) {
+	service, _, gameSessionRepo := newTestTournaments()
+	tournament := fillTournament(t, service, 8)
+
+	if tournament.Status != models.TournamentStatusInProgress || tournament.CurrentRound != 1 {
+		t.Fatalf("Expected round 1 to be in progress, got %s round %d", tournament.Status, tournament.CurrentRound)
+	}
+
+	matches := tournament.Round().Matches
+	if len(matches) != 4 {
+		t.Fatalf("Expected 4 first-round matches, got %d", len(matches))
+	}
+	if matches[0].PlayerIDs[0] != "p1" || matches[0].PlayerIDs[1] != "p8" {
+		t.Errorf("Expected the top seed to face the bottom seed, got %v", matches[0].PlayerIDs)
+	}
+
+	for _, match := range matches {
+		session := gameSessionRepo.sessions[match.SessionID]
+		if session == nil || len(session.Players) != 2 || session.Mode != models.GameModeMultiplayer {
+			t.Errorf("Expected match %s to have a two-player multiplayer session", match.MatchID)
+		}
+	}
+
+	if _, err := service.JoinTournament(context.Background(), tournament.TournamentID, "p9", "p9"); err == nil {
+		t.Error("Expected joining a started tournament to fail")
+	}
+}
+
+func TestAdvanceTournaments_WinnersAdvanceToChampion(t *testing.T) {
+	service, _, gameSessionRepo := newTestTournaments()
+	ctx := context.Background()
+	tournament := fillTournament(t, service, 8)
+
+	for round := 1; round <= 3; round++ {
+		finishMatches(t, tournament, gameSessionRepo)
+		if err := service.AdvanceTournaments(ctx); err != nil {
+			t.Fatalf("AdvanceTournaments failed: %v", err)
+		}
+		tournament, _ = service.GetTournament(ctx, tournament.TournamentID)
+	}
+
+	if tournament.Status != models.TournamentStatusCompleted || tournament.WinnerID != "p1" {
+		t.Fatalf("Expected p1 to win the completed tournament, got %s won by %q", tournament.Status, tournament.WinnerID)
+	}
+	if len(tournament.Rounds) != 3 {
+		t.Errorf("Expected 3 rounds for 8 players, got %d", len(tournament.Rounds))
+	}
+	if semifinal := tournament.Rounds[1].Matches[0].PlayerIDs; semifinal[0] != "p1" || semifinal[1] != "p4" {
+		t.Errorf("Expected adjacent first-round winners to meet, got %v", semifinal)
+	}
+	if player := tournament.Player("p8"); player.EliminatedInRound != 1 {
+		t.Errorf("Expected p8 to be eliminated in round 1, got %d", player.EliminatedInRound)
+	}
+}
+
+func TestAdvanceTournaments_WaitsForUnfinishedMatches(t *testing.T) {
+	service, _, gameSessionRepo := newTestTournaments()
+	ctx := context.Background()
+	tournament := fillTournament(t, service, 8)
+
+	first := tournament.Round().Matches[0]
+	session := gameSessionRepo.sessions[first.SessionID]
+	session.Status = models.GameStatusCompleted
+	session.Players[1].TotalScore = 90 // no recorded winner, so the higher score advances
+
+	if err := service.AdvanceTournaments(ctx); err != nil {
+		t.Fatalf("AdvanceTournaments failed: %v", err)
+	}
+
+	tournament, _ = service.GetTournament(ctx, tournament.TournamentID)
+	if tournament.CurrentRound != 1 {
+		t.Errorf("Expected round 1 to continue until every match finishes, got round %d", tournament.CurrentRound)
+	}
+	if winner := tournament.Round().Matches[0].WinnerID; winner != first.PlayerIDs[1] {
+		t.Errorf("Expected the higher scorer %s to win, got %q", first.PlayerIDs[1], winner)
+	}
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/i18n"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VotingTimeLimit is how long players have to vote on a peer-vote round
+const VotingTimeLimit = 30 * time.Second
+
+// Star ratings players may give a response
+const (
+	MinVoteStars = 1
+	MaxVoteStars = 5
+)
+
+// ErrInvalidVote is returned when a vote cannot be counted
+var ErrInvalidVote = errors.New("invalid vote")
+
+// votingDeadlinePrefix marks scheduler deadlines that close voting rather than responses
+const votingDeadlinePrefix = "vote:"
+
+// votingDeadlineDoor returns the door a scheduler key closes voting for, if it is a voting deadline
+func votingDeadlineDoor(key string) (string, bool) {
+	if !strings.HasPrefix(key, votingDeadlinePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, votingDeadlinePrefix), true
+}
+
+// CastVote records a player's star rating of another player's response in a peer-vote round.
+// Once every active player has rated every other response, the round is scored and revealed.
+func (s *GameServiceImpl) CastVote(ctx context.Context, sessionID, voterID, responseID string, stars int) error {
+	ctx, span := tracing.StartSpan(ctx, "GameService.CastVote", tracing.SessionIDKey.String(sessionID), tracing.PlayerIDKey.String(voterID))
+	defer span.End()
+
+	if stars < MinVoteStars || stars > MaxVoteStars {
+		return fmt.Errorf("%w: stars must be between %d and %d", ErrInvalidVote, MinVoteStars, MaxVoteStars)
+	}
+
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	if !session.Settings.PeerVoting() {
+		return fmt.Errorf("%w: session is not using peer-vote scoring", ErrInvalidVote)
+	}
+	if err := s.stateMachine.Require(session, OpCastVote); err != nil {
+		return err
+	}
+	if session.VoteDeadline == nil {
+		return fmt.Errorf("%w: voting is closed", ErrInvalidVote)
+	}
+
+	if !isActivePlayer(session, voterID) {
+		if isSpectator(session, voterID) {
+			return ErrSpectatorReadOnly
+		}
+		return ErrPlayerNotInSession
+	}
+
+	var response *models.PlayerResponse
+	for _, candidate := range roundResponses(session) {
+		if candidate.ResponseID == responseID {
+			response = candidate
+			break
+		}
+	}
+	switch {
+	case response == nil:
+		return fmt.Errorf("%w: response is not part of this round", ErrInvalidVote)
+	case response.PlayerID == voterID:
+		return fmt.Errorf("%w: players cannot vote on their own response", ErrInvalidVote)
+	case hasVoted(response, voterID):
+		return fmt.Errorf("%w: already voted on this response", ErrInvalidVote)
+	}
+
+	response.Votes = append(response.Votes, models.ResponseVote{
+		VoterID: voterID,
+		Stars:   stars,
+		CastAt:  time.Now(),
+	})
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to record vote: %w", err)
+	}
+
+	// Ratings stay secret until the round is revealed; only the fact that a vote was cast is shared
+	if s.wsManager != nil {
+		event := WebSocketEvent{
+			Type:      "vote-cast",
+			SessionID: sessionID,
+			PlayerID:  voterID,
+			Data: map[string]interface{}{
+				"voterId":    voterID,
+				"responseId": responseID,
+			},
+			Timestamp: time.Now(),
+		}
+
+		s.runInBackground(ctx, sessionID, "broadcast-vote-cast", func(ctx context.Context) {
+			if err := s.wsManager.BroadcastToSession(sessionID, event); err != nil {
+				fmt.Printf("Warning: failed to broadcast vote: %v\n", err)
+			}
+		})
+	}
+
+	if allVotesIn(session) {
+		s.closeVoting(ctx, sessionID, session)
+	}
+	return nil
+}
+
+// closeVoting ends a round's voting once every active player has voted, without waiting for
+// its deadline
+func (s *GameServiceImpl) closeVoting(ctx context.Context, sessionID string, session *models.GameSession) {
+	if err := s.scheduler.Cancel(ctx, sessionID, votingDeadlineKey(session)); err != nil {
+		fmt.Printf("Warning: failed to cancel voting deadline: %v\n", err)
+	}
+
+	// Revealing pauses between rounds, so it runs on a worker; dropping it would stall the session
+	traced := tracing.Carry(tracing.WithSessionID(ctx, sessionID))
+	finishVoting := s.detachedTask(traced, "finish-voting", func(ctx context.Context) {
+		if err := s.finishVoting(ctx, sessionID); err != nil {
+			fmt.Printf("Error finishing voting: %v\n", err)
+		}
+	})
+	if err := s.workerPool.Submit("finish-voting", finishVoting); err != nil {
+		fmt.Printf("Warning: %v, finishing voting inline\n", err)
+		finishVoting()
+	}
+}
+
+// handleVoteMessage casts a vote sent over the player's socket
+func (s *GameServiceImpl) handleVoteMessage(ctx context.Context, sessionID, playerID string, msg map[string]interface{}) error {
+	responseID, _ := msg["responseId"].(string)
+	stars, _ := msg["stars"].(float64)
+	return s.CastVote(ctx, sessionID, playerID, responseID, int(stars))
+}
+
+// startVoting opens a closed round to peer voting, sending each player the responses they may rate
+func (s *GameServiceImpl) startVoting(ctx context.Context, session *models.GameSession) error {
+	responses := roundResponses(session)
+	if len(responses) == 0 {
+		return s.revealRound(ctx, session)
+	}
+
+	deadline := time.Now().Add(VotingTimeLimit)
+	session.VoteDeadline = &deadline
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to open voting: %w", err)
+	}
+
+	if s.wsManager != nil {
+		for _, player := range session.Players {
+			if !player.IsActive {
+				continue
+			}
+
+			ballot := make([]map[string]interface{}, 0, len(responses))
+			for _, response := range responses {
+				if response.PlayerID == player.PlayerID {
+					continue
+				}
+				ballot = append(ballot, map[string]interface{}{
+					"responseId": response.ResponseID,
+					"doorId":     response.DoorID,
+					"content":    response.Content,
+				})
+			}
+
+			event := WebSocketEvent{
+				Type:      "voting-started",
+				SessionID: session.SessionID,
+				PlayerID:  player.PlayerID,
+				Data: systemMessage(map[string]interface{}{
+					"ballot":    ballot,
+					"minStars":  MinVoteStars,
+					"maxStars":  MaxVoteStars,
+					"timeLimit": int(VotingTimeLimit.Seconds()),
+					"deadline":  deadline,
+				}, session.Locale, i18n.MsgVotingStarted, int(VotingTimeLimit.Seconds())),
+				Timestamp: time.Now(),
+			}
+
+			if err := s.wsManager.SendToPlayer(player.PlayerID, event); err != nil {
+				fmt.Printf("Warning: failed to send ballot to player %s: %v\n", player.PlayerID, err)
+			}
+		}
+	}
+
+	if err := s.scheduler.Schedule(ctx, session.SessionID, votingDeadlineKey(session), deadline); err != nil {
+		fmt.Printf("Warning: failed to schedule voting deadline: %v\n", err)
+	}
+
+	return nil
+}
+
+// handleVotingTimeout closes voting when its deadline passes, scoring the votes that were cast
+func (s *GameServiceImpl) handleVotingTimeout(ctx context.Context, sessionID, doorID string) {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		fmt.Printf("Error getting session for voting timeout: %v\n", err)
+		return
+	}
+
+	if session == nil || session.VoteDeadline == nil || !session.IsCurrentDoor(doorID) {
+		return // Voting already closed
+	}
+
+	if err := s.finishVoting(ctx, sessionID); err != nil {
+		fmt.Printf("Error finishing voting after timeout: %v\n", err)
+	}
+}
+
+// finishVoting turns a round's votes into scores and reveals the round
+func (s *GameServiceImpl) finishVoting(ctx context.Context, sessionID string) error {
+	session, err := s.gameSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	if session.VoteDeadline == nil || s.stateMachine.Require(session, OpCastVote) != nil {
+		return nil // Another trigger already closed voting
+	}
+
+	responses := roundResponses(session)
+	for _, response := range responses {
+		score := voteScore(response.Votes)
+		response.ScoringMetrics = models.ScoringMetrics{
+			Creativity:  score,
+			Feasibility: score,
+			Humor:       score,
+			Originality: score,
+		}
+		score = cheatPenalty(score, response.CheatSuspicion)
+		response.AIScore = score
+
+		for i := range session.Players {
+			if session.Players[i].PlayerID == response.PlayerID {
+				session.Players[i].TotalScore += score
+				break
+			}
+		}
+	}
+	session.VoteDeadline = nil
+
+	if err := s.gameSessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to save voted scores: %w", err)
+	}
+
+	// Paths and progress move now that the scores are known, before win conditions are checked
+	for _, response := range responses {
+		if err := s.updatePlayerPath(ctx, response.PlayerID, pathScore(session, response.PlayerID, response.DoorID, response.AIScore), response.DoorID, s.shortcutThreshold(session)); err != nil {
+			fmt.Printf("Warning: failed to update player path: %v\n", err)
+		}
+		s.recordDoorScore(ctx, sessionID, response.DoorID, response.AIScore)
+		s.observeExperimentScore(session, response.AIScore)
+		if s.progressService != nil {
+			if err := s.progressService.TrackPlayerResponse(ctx, sessionID, response.PlayerID, response.AIScore); err != nil {
+				fmt.Printf("Warning: failed to track player response: %v\n", err)
+			}
+		}
+	}
+
+	return s.revealRound(ctx, session)
+}
+
+// roundResponses returns pointers to each player's response to their current door
+func roundResponses(session *models.GameSession) []*models.PlayerResponse {
+	var responses []*models.PlayerResponse
+	for i := range session.Players {
+		door := session.DoorForPlayer(session.Players[i].PlayerID)
+		if door == nil {
+			continue
+		}
+
+		for j := range session.Players[i].Responses {
+			if session.Players[i].Responses[j].DoorID == door.DoorID {
+				responses = append(responses, &session.Players[i].Responses[j])
+				break
+			}
+		}
+	}
+	return responses
+}
+
+// allVotesIn reports whether every active player has rated every response but their own
+func allVotesIn(session *models.GameSession) bool {
+	for _, response := range roundResponses(session) {
+		if response.Forfeited {
+			continue
+		}
+		for _, player := range session.Players {
+			if player.AwaitedInRound() && player.PlayerID != response.PlayerID && !hasVoted(response, player.PlayerID) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasVoted reports whether the voter has already rated the response
+func hasVoted(response *models.PlayerResponse, voterID string) bool {
+	for _, vote := range response.Votes {
+		if vote.VoterID == voterID {
+			return true
+		}
+	}
+	return false
+}
+
+// isActivePlayer reports whether the player is still playing in the session
+func isActivePlayer(session *models.GameSession, playerID string) bool {
+	for _, player := range session.Players {
+		if player.PlayerID == playerID {
+			return player.IsActive
+		}
+	}
+	return false
+}
+
+// voteScore maps the average star rating onto the 0-100 score scale, so one star scores 0
+// and five stars 100. A response nobody rated gets the neutral fallback score of 50.
+func voteScore(votes []models.ResponseVote) int {
+	if len(votes) == 0 {
+		return 50
+	}
+
+	total := 0
+	for _, vote := range votes {
+		total += vote.Stars - MinVoteStars
+	}
+	return total * 100 / (len(votes) * (MaxVoteStars - MinVoteStars))
+}
+
+// votingDeadlineKey is the scheduler key for a round's voting deadline
+func votingDeadlineKey(session *models.GameSession) string {
+	doorID := ""
+	if doors := session.CurrentDoors(); len(doors) > 0 {
+		doorID = doors[0].DoorID
+	}
+	return votingDeadlinePrefix + doorID
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"errors"
+	"testing"
+)
+
+func newPeerVoteSession() *models.GameSession {
+	return &models.GameSession{
+		SessionID:   "s1",
+		Mode:        models.GameModeMultiplayer,
+		Status:      models.GameStatusActive,
+		Settings:    models.SessionSettings{ScoringMode: models.ScoringModePeerVote},
+		CurrentDoor: &models.Door{DoorID: "door-1"},
+		Players: []models.PlayerInfo{
+			{PlayerID: "p1", IsActive: true},
+			{PlayerID: "p2", IsActive: true},
+			{PlayerID: "p3", IsActive: true},
+		},
+	}
+}
+
+func TestCreateSession_PeerVoteRequiresMultiplayer(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil)
+	settings := models.SessionSettings{ScoringMode: models.ScoringModePeerVote}
+
+	_, err := gameService.CreateSession(context.Background(), models.GameModeSinglePlayer, "p1", "Player 1", nil, "en", settings)
+	if !errors.Is(err, ErrInvalidSessionSettings) {
+		t.Errorf("Expected ErrInvalidSessionSettings, got %v", err)
+	}
+
+	session, err := gameService.CreateSession(context.Background(), models.GameModeMultiplayer, "p1", "Player 1", nil, "en", settings)
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if !session.Settings.PeerVoting() {
+		t.Errorf("Expected the session to use peer voting, got %q", session.Settings.ScoringMode)
+	}
+}
+
+func TestPeerVote_RoundWaitsForVotes(t *testing.T) {
+	ctx := context.Background()
+	gameSessionRepo := NewMockGameSessionRepository()
+	gameSessionRepo.sessions["s1"] = newPeerVoteSession()
+	aiClient := &MockAIClient{}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), nil, aiClient, nil, nil,
+		WithWorkerPool(discardWorkerPool{}),
+	)
+
+	for _, playerID := range []string{"p1", "p2", "p3"} {
+		if _, err := gameService.SubmitResponse(ctx, "s1", playerID, "I would ask it nicely."); err != nil {
+			t.Fatalf("SubmitResponse failed: %v", err)
+		}
+	}
+	if aiClient.scoreCalls != 0 {
+		t.Errorf("Expected peer-vote responses not to be AI scored, got %d calls", aiClient.scoreCalls)
+	}
+
+	if err := gameService.(*GameServiceImpl).processAllResponses(ctx, "s1"); err != nil {
+		t.Fatalf("processAllResponses failed: %v", err)
+	}
+	session := gameSessionRepo.sessions["s1"]
+	if session.Status != models.GameStatusScoring || session.VoteDeadline == nil {
+		t.Fatalf("Expected the round to wait for votes, got status %s", session.Status)
+	}
+
+	responseOf := func(playerID string) string {
+		for _, response := range roundResponses(gameSessionRepo.sessions["s1"]) {
+			if response.PlayerID == playerID {
+				return response.ResponseID
+			}
+		}
+		return ""
+	}
+
+	if err := gameService.CastVote(ctx, "s1", "p1", responseOf("p1"), 5); !errors.Is(err, ErrInvalidVote) {
+		t.Errorf("Expected voting on your own response to be rejected, got %v", err)
+	}
+	if err := gameService.CastVote(ctx, "s1", "p1", responseOf("p2"), 6); !errors.Is(err, ErrInvalidVote) {
+		t.Errorf("Expected an out-of-range rating to be rejected, got %v", err)
+	}
+	if err := gameService.CastVote(ctx, "s1", "p1", responseOf("p2"), 4); err != nil {
+		t.Fatalf("CastVote failed: %v", err)
+	}
+	if err := gameService.CastVote(ctx, "s1", "p1", responseOf("p2"), 2); !errors.Is(err, ErrInvalidVote) {
+		t.Errorf("Expected a second vote on the same response to be rejected, got %v", err)
+	}
+
+	if allVotesIn(gameSessionRepo.sessions["s1"]) {
+		t.Error("Expected voting to stay open until every player has voted")
+	}
+	for _, vote := range []struct{ voter, author string }{{"p1", "p3"}, {"p2", "p1"}, {"p2", "p3"}, {"p3", "p1"}, {"p3", "p2"}} {
+		if err := gameService.CastVote(ctx, "s1", vote.voter, responseOf(vote.author), 3); err != nil {
+			t.Fatalf("CastVote failed: %v", err)
+		}
+	}
+	if !allVotesIn(gameSessionRepo.sessions["s1"]) {
+		t.Error("Expected every vote to be counted")
+	}
+}
+
+func TestVoteScore_MapsStarsOntoScoreScale(t *testing.T) {
+	votes := func(stars ...int) []models.ResponseVote {
+		var result []models.ResponseVote
+		for _, s := range stars {
+			result = append(result, models.ResponseVote{Stars: s})
+		}
+		return result
+	}
+
+	cases := []struct {
+		votes []models.ResponseVote
+		want  int
+	}{
+		{nil, 50},
+		{votes(1), 0},
+		{votes(5), 100},
+		{votes(3), 50},
+		{votes(4, 5), 87},
+	}
+	for _, c := range cases {
+		if got := voteScore(c.votes); got != c.want {
+			t.Errorf("voteScore(%v) = %d, want %d", c.votes, got, c.want)
+		}
+	}
+}
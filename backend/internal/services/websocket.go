@@ -1,7 +1,12 @@
 package services
 
 import (
+	"context"
+	"dumdoors-backend/internal/i18n"
 	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/repositories"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -17,6 +22,7 @@ type WebSocketEvent struct {
 	PlayerID  string      `json:"playerId,omitempty"`
 	Data      interface{} `json:"data"`
 	Timestamp time.Time   `json:"timestamp"`
+	Sequence  int64       `json:"seq,omitempty"` // per-session order of broadcast events, for resync
 }
 
 // PlayerProgress represents a player's current progress in the game
@@ -44,14 +50,23 @@ type SessionProgress struct {
 
 // WebSocketConnection represents a WebSocket connection with metadata
 type WebSocketConnection struct {
-	Conn      *websocket.Conn
-	PlayerID  string
-	SessionID string
-	LastSeen  time.Time
-	IsActive  bool
-	mu        sync.RWMutex
+	Conn        *websocket.Conn
+	PlayerID    string
+	SessionID   string
+	LastSeen    time.Time
+	IsActive    bool
+	IsSpectator bool // read-only connection that only receives spectator-visible events
+	mu          sync.RWMutex
+	queue       *outboundQueue
+	heartbeat   heartbeat
 }
 
+// WebSocketMessageHandler processes one typed message sent by a player over their socket
+type WebSocketMessageHandler func(ctx context.Context, sessionID, playerID string, msg map[string]interface{}) error
+
+// PresenceHandler is told when a player's connection to a session drops or comes back
+type PresenceHandler func(ctx context.Context, sessionID, playerID string, connected bool)
+
 // WebSocketManager interface defines the contract for WebSocket operations
 type WebSocketManager interface {
 	RegisterConnection(sessionID, playerID string, conn *websocket.Conn) error
@@ -62,7 +77,20 @@ type WebSocketManager interface {
 	RestorePlayerConnection(playerID string, conn *websocket.Conn) error
 	GetActiveConnections(sessionID string) []*WebSocketConnection
 	CleanupInactiveConnections()
+	CloseSession(sessionID string) error
+	LocalSessionIDs() []string
+	SendToLocalSession(sessionID string, event WebSocketEvent) error
+	CloseLocalSession(sessionID string)
+	DisconnectPlayer(playerID string) error
 	HandleWebSocketConnection(c *websocket.Conn, sessionID, playerID string)
+	RegisterStream(sessionID, playerID string) (*EventStream, error)
+	RegisterSpectator(sessionID, spectatorID string, conn *websocket.Conn) error
+	UnregisterSpectator(spectatorID string) error
+	GetSpectatorCount(sessionID string) int
+	HandleSpectatorConnection(c *websocket.Conn, sessionID, spectatorID string)
+	StartFanout(ctx context.Context)
+	OnMessage(msgType string, handler WebSocketMessageHandler)
+	OnPresence(handler PresenceHandler)
 	BroadcastProgressUpdate(sessionID string, progress SessionProgress) error
 	BroadcastPlayerPositionUpdate(sessionID, playerID string, position int, totalDoors int) error
 	BroadcastScoreUpdate(sessionID, playerID string, newScore int, totalScore int) error
@@ -78,23 +106,131 @@ type WebSocketManagerImpl struct {
 	sessions    map[string][]string             // sessionID -> []playerID
 	mu          sync.RWMutex
 	
+	// Read-only connections, kept apart so spectator IDs never collide with players
+	spectators        map[string]*WebSocketConnection // spectatorID -> connection
+	sessionSpectators map[string][]string             // sessionID -> []spectatorID
+	
 	// Configuration
 	disconnectTimeout time.Duration
 	pingInterval      time.Duration
+	maxMissedPongs    int
+	sendQueueSize     int
+	overflowPolicy    OverflowPolicy
+	writeTimeout      time.Duration
+	
+	// Payloads at least this large are compressed for clients that negotiated it; zero never compresses
+	compressionThreshold int
+	
+	// Backpressure metrics
+	droppedEvents      *monitoring.Counter
+	slowDisconnections *monitoring.Counter
+	heartbeatMetrics   heartbeatMetrics
+	
+	// Autosaves drafts sent over the socket
+	draftService DraftService
+	
+	// Throttles typing indicators broadcast to the session
+	typing *typingThrottle
+	
+	// Handlers for typed player messages, keyed by message type
+	messageHandlers map[string]WebSocketMessageHandler
+	
+	// Told when players disconnect and reconnect
+	presenceHandler PresenceHandler
+	
+	// Events players could not be sent, kept until they reconnect
+	deadLetters *deadLetterBuffer
+	
+	// Adds session events to the replay log
+	replayRecorder ReplayRecorder
+	
+	// Fans events out to connections held by other backend instances
+	eventBus   repositories.EventBus
+	instanceID string
+	
+	// Numbers session broadcasts and keeps the recent ones for clients that resync
+	eventLog repositories.SessionEventLog
+}
+
+// WebSocketManagerOption configures optional WebSocket manager settings
+type WebSocketManagerOption func(*WebSocketManagerImpl)
+
+// WithSendQueueSize caps the number of events buffered for each connection
+func WithSendQueueSize(size int) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		if size > 0 {
+			w.sendQueueSize = size
+		}
+	}
+}
+
+// WithOverflowPolicy sets what happens to clients whose send queue overflows; unknown policies are ignored
+func WithOverflowPolicy(policy OverflowPolicy) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		if policy == OverflowDisconnect || policy == OverflowDropOldest {
+			w.overflowPolicy = policy
+		}
+	}
+}
+
+// WithWriteTimeout sets how long a single write may block before the client is dropped
+func WithWriteTimeout(timeout time.Duration) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		if timeout > 0 {
+			w.writeTimeout = timeout
+		}
+	}
+}
+
+// WithDraftService lets clients autosave their in-progress responses with "save-draft" messages
+func WithDraftService(draftService DraftService) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		w.draftService = draftService
+	}
+}
+
+// WithReplayRecorder records the session events this instance broadcasts for later replay
+func WithReplayRecorder(recorder ReplayRecorder) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		w.replayRecorder = recorder
+	}
 }
 
 // NewWebSocketManager creates a new WebSocket manager instance
-func NewWebSocketManager() WebSocketManager {
+func NewWebSocketManager(opts ...WebSocketManagerOption) WebSocketManager {
+	collector := monitoring.GetGlobalMetricsCollector()
 	manager := &WebSocketManagerImpl{
-		connections:       make(map[string]*WebSocketConnection),
-		sessions:          make(map[string][]string),
-		disconnectTimeout: 5 * time.Minute, // 5-minute timeout as per requirements
-		pingInterval:      30 * time.Second,
+		connections:        make(map[string]*WebSocketConnection),
+		sessions:           make(map[string][]string),
+		spectators:         make(map[string]*WebSocketConnection),
+		sessionSpectators:  make(map[string][]string),
+		messageHandlers:    make(map[string]WebSocketMessageHandler),
+		disconnectTimeout:  DisconnectGracePeriod, // 5-minute timeout as per requirements
+		pingInterval:       DefaultPingInterval,
+		maxMissedPongs:     DefaultMaxMissedPongs,
+		sendQueueSize:      DefaultSendQueueSize,
+		overflowPolicy:     OverflowDisconnect,
+		writeTimeout:       DefaultWriteTimeout,
+		droppedEvents:      collector.NewCounter("websocket_events_dropped_total", "Low-priority events shed for slow clients", nil),
+		slowDisconnections: collector.NewCounter("websocket_slow_client_disconnects_total", "Clients disconnected for exceeding their send queue", nil),
+		heartbeatMetrics:   newHeartbeatMetrics(),
+		typing:             newTypingThrottle(DefaultTypingThrottle),
+		deadLetters:        newDeadLetterBuffer(DefaultDeadLetterSize, DefaultDeadLetterTTL),
+	}
+	
+	for _, opt := range opts {
+		opt(manager)
 	}
 	
 	// Start cleanup routine
 	go manager.startCleanupRoutine()
 	
+	// Ping connections so dead ones are noticed without waiting for a failed write
+	go manager.startHeartbeat()
+	
+	// Sample send queue depths so slow sessions show up in metrics
+	go manager.startQueueDepthReporting(DefaultQueueDepthInterval)
+	
 	return manager
 }
 
@@ -111,6 +247,25 @@ func (w *WebSocketManagerImpl) RegisterConnection(sessionID, playerID string, co
 		LastSeen:  time.Now(),
 		IsActive:  true,
 	}
+	w.startWriter(wsConn)
+	w.trackPongs(wsConn)
+	
+	w.addConnection(wsConn)
+	log.Printf("WebSocket connection registered for player %s in session %s", playerID, sessionID)
+	
+	return nil
+}
+
+// addConnection stores a player's connection in place of any previous one and announces it to
+// the rest of the session. The caller must hold w.mu.
+func (w *WebSocketManagerImpl) addConnection(wsConn *WebSocketConnection) {
+	sessionID := wsConn.SessionID
+	playerID := wsConn.PlayerID
+	
+	// Stop writing to any connection this one replaces
+	if existing, exists := w.connections[playerID]; exists {
+		existing.closeQueue()
+	}
 	
 	// Store connection
 	w.connections[playerID] = wsConn
@@ -133,24 +288,21 @@ func (w *WebSocketManagerImpl) RegisterConnection(sessionID, playerID string, co
 		w.sessions[sessionID] = append(w.sessions[sessionID], playerID)
 	}
 	
-	log.Printf("WebSocket connection registered for player %s in session %s", playerID, sessionID)
-	
 	// Notify other players in session about new connection
 	event := WebSocketEvent{
 		Type:      "player-connected",
 		SessionID: sessionID,
 		PlayerID:  playerID,
-		Data: map[string]interface{}{
+		Data: systemMessage(map[string]interface{}{
 			"playerId": playerID,
-			"message":  "Player connected",
-		},
+		}, i18n.DefaultLocale, i18n.MsgPlayerConnected),
 		Timestamp: time.Now(),
 	}
 	
 	// Broadcast to other players (not the connecting player)
 	go w.broadcastToOthers(sessionID, playerID, event)
-	
-	return nil
+	go w.notifyPresence(sessionID, playerID, true)
+	go w.redeliverDeadLetters(sessionID, playerID)
 }
 
 // UnregisterConnection removes a WebSocket connection
@@ -163,12 +315,22 @@ func (w *WebSocketManagerImpl) UnregisterConnection(playerID string) error {
 		return fmt.Errorf("connection not found for player %s", playerID)
 	}
 	
+	w.markDisconnected(conn)
+	return nil
+}
+
+// markDisconnected deactivates a connection, keeping it for reconnection, and tells the rest of
+// the session the player left. The caller must hold w.mu.
+func (w *WebSocketManagerImpl) markDisconnected(conn *WebSocketConnection) {
+	playerID := conn.PlayerID
 	sessionID := conn.SessionID
 	
 	// Mark as inactive but don't remove immediately (for reconnection)
 	conn.mu.Lock()
 	conn.IsActive = false
 	conn.mu.Unlock()
+	conn.closeQueue()
+	w.typing.forget(playerID)
 	
 	log.Printf("WebSocket connection unregistered for player %s in session %s", playerID, sessionID)
 	
@@ -177,38 +339,68 @@ func (w *WebSocketManagerImpl) UnregisterConnection(playerID string) error {
 		Type:      "player-disconnected",
 		SessionID: sessionID,
 		PlayerID:  playerID,
-		Data: map[string]interface{}{
+		Data: systemMessage(map[string]interface{}{
 			"playerId": playerID,
-			"message":  "Player disconnected",
-		},
+		}, i18n.DefaultLocale, i18n.MsgPlayerLeft),
 		Timestamp: time.Now(),
 	}
 	
 	// Broadcast to other players
 	go w.broadcastToOthers(sessionID, playerID, event)
-	
-	return nil
+	go w.notifyPresence(sessionID, playerID, false)
 }
 
-// BroadcastToSession sends an event to all active connections in a session
+// BroadcastToSession sends an event to all active connections in a session, including
+// spectators when the event is visible to them. With an event bus, connections held by
+// other instances receive it too.
 func (w *WebSocketManagerImpl) BroadcastToSession(sessionID string, event WebSocketEvent) error {
+	w.sequence(sessionID, &event)
+	w.record(event)
+	
+	if w.eventBus == nil {
+		return w.deliverToSession(sessionID, event)
+	}
+	
+	w.publish(fanoutMessage{SessionID: sessionID, Event: event})
+	
+	// Other instances may hold every connection of the session
+	if !w.hasLocalSession(sessionID) {
+		return nil
+	}
+	return w.deliverToSession(sessionID, event)
+}
+
+// record passes an event to the replay recorder, if one is configured. Events are recorded
+// where they are first sent, so fanned-out copies are not recorded twice.
+func (w *WebSocketManagerImpl) record(event WebSocketEvent) {
+	if w.replayRecorder != nil {
+		w.replayRecorder.Record(event)
+	}
+}
+
+// deliverToSession sends an event to the session's connections on this instance
+func (w *WebSocketManagerImpl) deliverToSession(sessionID string, event WebSocketEvent) error {
 	w.mu.RLock()
 	playerIDs, exists := w.sessions[sessionID]
+	_, watched := w.sessionSpectators[sessionID]
 	w.mu.RUnlock()
 	
-	if !exists {
+	if !exists && !watched {
 		return fmt.Errorf("session %s not found", sessionID)
 	}
 	
-	var errors []error
+	w.broadcastToSpectators(sessionID, event)
+	
+	failures := make(map[string]error)
 	for _, playerID := range playerIDs {
-		if err := w.SendToPlayer(playerID, event); err != nil {
-			errors = append(errors, fmt.Errorf("failed to send to player %s: %w", playerID, err))
+		if err := w.sendToLocalPlayer(playerID, event); err != nil {
+			failures[playerID] = err
+			w.deliveryFailed(sessionID, playerID, event, err)
 		}
 	}
 	
-	if len(errors) > 0 {
-		return fmt.Errorf("broadcast errors: %v", errors)
+	if len(failures) > 0 {
+		return &BroadcastError{SessionID: sessionID, EventType: event.Type, Failures: failures}
 	}
 	
 	return nil
@@ -216,20 +408,42 @@ func (w *WebSocketManagerImpl) BroadcastToSession(sessionID string, event WebSoc
 
 // SendToPlayer sends an event to a specific player
 func (w *WebSocketManagerImpl) SendToPlayer(playerID string, event WebSocketEvent) error {
+	w.record(event)
+	
+	err := w.sendToLocalPlayer(playerID, event)
+	if errors.Is(err, errConnectionNotFound) && w.eventBus != nil {
+		// The player may be connected to another instance
+		return w.publish(fanoutMessage{PlayerID: playerID, Event: event})
+	}
+	if err != nil {
+		w.deliveryFailed(event.SessionID, playerID, event, err)
+	}
+	return err
+}
+
+// sendToLocalPlayer sends an event to a player connected to this instance
+func (w *WebSocketManagerImpl) sendToLocalPlayer(playerID string, event WebSocketEvent) error {
 	w.mu.RLock()
 	conn, exists := w.connections[playerID]
 	w.mu.RUnlock()
 	
 	if !exists {
-		return fmt.Errorf("connection not found for player %s", playerID)
+		return fmt.Errorf("%w for player %s", errConnectionNotFound, playerID)
 	}
 	
+	return w.sendToConnection(conn, event)
+}
+
+// sendToConnection queues an event on a player or spectator connection
+func (w *WebSocketManagerImpl) sendToConnection(conn *WebSocketConnection, event WebSocketEvent) error {
+	playerID := conn.PlayerID
+	
 	conn.mu.RLock()
 	isActive := conn.IsActive
-	wsConn := conn.Conn
+	queue := conn.queue
 	conn.mu.RUnlock()
 	
-	if !isActive {
+	if !isActive || queue == nil {
 		return fmt.Errorf("connection inactive for player %s", playerID)
 	}
 	
@@ -238,12 +452,15 @@ func (w *WebSocketManagerImpl) SendToPlayer(playerID string, event WebSocketEven
 	conn.LastSeen = time.Now()
 	conn.mu.Unlock()
 	
-	// Send message
-	if err := wsConn.WriteJSON(event); err != nil {
-		// Mark connection as inactive on write error
-		conn.mu.Lock()
-		conn.IsActive = false
-		conn.mu.Unlock()
+	// Queue message for the connection's writer
+	dropped, err := queue.push(event)
+	if dropped {
+		w.droppedEvents.Inc()
+	}
+	if err != nil {
+		if errors.Is(err, ErrSendQueueOverflow) {
+			w.disconnectSlowClient(conn)
+		}
 		return fmt.Errorf("failed to send message to player %s: %w", playerID, err)
 	}
 	
@@ -274,11 +491,14 @@ func (w *WebSocketManagerImpl) RestorePlayerConnection(playerID string, conn *we
 	}
 	
 	// Update connection
+	existingConn.closeQueue()
 	existingConn.mu.Lock()
 	existingConn.Conn = conn
 	existingConn.IsActive = true
 	existingConn.LastSeen = time.Now()
 	existingConn.mu.Unlock()
+	w.startWriter(existingConn)
+	w.trackPongs(existingConn)
 	
 	log.Printf("WebSocket connection restored for player %s in session %s", playerID, existingConn.SessionID)
 	
@@ -287,14 +507,15 @@ func (w *WebSocketManagerImpl) RestorePlayerConnection(playerID string, conn *we
 		Type:      "player-reconnected",
 		SessionID: existingConn.SessionID,
 		PlayerID:  playerID,
-		Data: map[string]interface{}{
+		Data: systemMessage(map[string]interface{}{
 			"playerId": playerID,
-			"message":  "Player reconnected",
-		},
+		}, i18n.DefaultLocale, i18n.MsgPlayerBack),
 		Timestamp: time.Now(),
 	}
 	
 	go w.broadcastToOthers(existingConn.SessionID, playerID, event)
+	go w.notifyPresence(existingConn.SessionID, playerID, true)
+	go w.redeliverDeadLetters(existingConn.SessionID, playerID)
 	
 	return nil
 }
@@ -347,11 +568,89 @@ func (w *WebSocketManagerImpl) CleanupInactiveConnections() {
 	
 	for _, playerID := range toRemove {
 		delete(w.connections, playerID)
+		w.deadLetters.drop(playerID)
+	}
+}
+
+// CloseSession disconnects every player and spectator of a session that has ended for good.
+// With an event bus, other instances close their connections to the session too.
+func (w *WebSocketManagerImpl) CloseSession(sessionID string) error {
+	if w.eventBus != nil {
+		w.publish(fanoutMessage{SessionID: sessionID, CloseSession: true})
+	}
+	w.closeLocalSession(sessionID)
+	return nil
+}
+
+// closeLocalSession drops this instance's connections to a session, closing each socket once
+// the events already queued for it are written
+func (w *WebSocketManagerImpl) closeLocalSession(sessionID string) {
+	w.mu.Lock()
+	var closing []*WebSocketConnection
+	for _, playerID := range w.sessions[sessionID] {
+		if conn, exists := w.connections[playerID]; exists && conn.SessionID == sessionID {
+			closing = append(closing, conn)
+			delete(w.connections, playerID)
+		}
+	}
+	for _, spectatorID := range w.sessionSpectators[sessionID] {
+		if conn, exists := w.spectators[spectatorID]; exists {
+			closing = append(closing, conn)
+			delete(w.spectators, spectatorID)
+		}
+	}
+	delete(w.sessions, sessionID)
+	delete(w.sessionSpectators, sessionID)
+	w.mu.Unlock()
+	queueDepthGauge(sessionID).Set(0)
+	
+	for _, conn := range closing {
+		conn.hangUp()
+	}
+	
+	if len(closing) > 0 {
+		log.Printf("Closed %d WebSocket connections for session %s", len(closing), sessionID)
+	}
+}
+
+// DisconnectPlayer closes a player's connection for good, once the events already queued for
+// it are written. With an event bus, the instance holding the connection closes it.
+func (w *WebSocketManagerImpl) DisconnectPlayer(playerID string) error {
+	if w.eventBus != nil {
+		w.publish(fanoutMessage{PlayerID: playerID, Disconnect: true})
+	}
+	w.disconnectLocalPlayer(playerID)
+	return nil
+}
+
+// disconnectLocalPlayer drops this instance's connection for a player without announcing a
+// disconnect, so the player is not offered a reconnection
+func (w *WebSocketManagerImpl) disconnectLocalPlayer(playerID string) {
+	w.mu.Lock()
+	conn, exists := w.connections[playerID]
+	if exists {
+		delete(w.connections, playerID)
+		w.removePlayerFromSession(conn.SessionID, playerID)
+	}
+	w.mu.Unlock()
+	w.deadLetters.drop(playerID)
+	
+	if exists {
+		conn.hangUp()
+		log.Printf("Closed WebSocket connection for player %s in session %s", playerID, conn.SessionID)
 	}
 }
 
 // broadcastToOthers sends an event to all players in a session except the specified player
 func (w *WebSocketManagerImpl) broadcastToOthers(sessionID, excludePlayerID string, event WebSocketEvent) {
+	if w.eventBus != nil {
+		w.publish(fanoutMessage{SessionID: sessionID, ExcludePlayerID: excludePlayerID, Event: event})
+	}
+	w.deliverToOthers(sessionID, excludePlayerID, event)
+}
+
+// deliverToOthers sends an event to this instance's players in a session except one
+func (w *WebSocketManagerImpl) deliverToOthers(sessionID, excludePlayerID string, event WebSocketEvent) {
 	w.mu.RLock()
 	playerIDs, exists := w.sessions[sessionID]
 	w.mu.RUnlock()
@@ -362,7 +661,7 @@ func (w *WebSocketManagerImpl) broadcastToOthers(sessionID, excludePlayerID stri
 	
 	for _, playerID := range playerIDs {
 		if playerID != excludePlayerID {
-			if err := w.SendToPlayer(playerID, event); err != nil {
+			if err := w.sendToLocalPlayer(playerID, event); err != nil {
 				log.Printf("Failed to send event to player %s: %v", playerID, err)
 			}
 		}
@@ -412,27 +711,75 @@ func (w *WebSocketManagerImpl) HandleWebSocketConnection(c *websocket.Conn, sess
 	
 	// Handle incoming messages
 	for {
-		var msg map[string]interface{}
-		if err := c.ReadJSON(&msg); err != nil {
+		_, data, err := c.ReadMessage()
+		if err != nil {
 			log.Printf("WebSocket read error for player %s: %v", playerID, err)
 			break
 		}
 		
-		// Process message (placeholder for future message handling)
-		log.Printf("Received WebSocket message from player %s: %v", playerID, msg)
-		
-		// Echo message back to session (for testing)
-		event := WebSocketEvent{
-			Type:      "message",
-			SessionID: sessionID,
-			PlayerID:  playerID,
-			Data:      msg,
-			Timestamp: time.Now(),
-		}
-		
-		if err := w.BroadcastToSession(sessionID, event); err != nil {
-			log.Printf("Failed to broadcast message: %v", err)
-		}
+		w.routeMessage(sessionID, playerID, data)
+	}
+}
+
+// OnMessage registers the handler for player messages of the given type
+func (w *WebSocketManagerImpl) OnMessage(msgType string, handler WebSocketMessageHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messageHandlers[msgType] = handler
+}
+
+// OnPresence registers the handler told when players disconnect and reconnect
+func (w *WebSocketManagerImpl) OnPresence(handler PresenceHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.presenceHandler = handler
+}
+
+// notifyPresence tells the presence handler, if any, that a player's connection dropped or came back
+func (w *WebSocketManagerImpl) notifyPresence(sessionID, playerID string, connected bool) {
+	w.mu.RLock()
+	handler := w.presenceHandler
+	w.mu.RUnlock()
+	
+	if handler != nil {
+		handler(context.Background(), sessionID, playerID, connected)
+	}
+}
+
+// messageHandler returns the handler registered for a message type, if any
+func (w *WebSocketManagerImpl) messageHandler(msgType string) WebSocketMessageHandler {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.messageHandlers[msgType]
+}
+
+// handleSaveDraft stores a draft sent over the socket and acknowledges it to the player
+func (w *WebSocketManagerImpl) handleSaveDraft(sessionID, playerID string, msg map[string]interface{}) {
+	if w.draftService == nil {
+		return
+	}
+	
+	content, _ := msg["response"].(string)
+	draft, err := w.draftService.SaveDraft(context.Background(), sessionID, playerID, content)
+	
+	data := map[string]interface{}{"saved": err == nil}
+	if err != nil {
+		data["message"] = err.Error()
+	} else if draft != nil {
+		data["doorId"] = draft.DoorID
+		data["updatedAt"] = draft.UpdatedAt
+	}
+	
+	event := WebSocketEvent{
+		Type:      "draft-saved",
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	
+	if err := w.SendToPlayer(playerID, event); err != nil {
+		log.Printf("Failed to acknowledge draft for player %s: %v", playerID, err)
 	}
 }
 
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"dumdoors-backend/internal/logging"
+	"dumdoors-backend/internal/monitoring"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dead-letter defaults
+const (
+	DefaultDeadLetterSize = 50
+	DefaultDeadLetterTTL  = DisconnectGracePeriod
+)
+
+// EventDeadLetters wraps the events redelivered to a reconnecting player
+const EventDeadLetters = "dead-letters"
+
+// BroadcastError reports the players of a session an event could not be delivered to
+type BroadcastError struct {
+	SessionID string
+	EventType string
+	Failures  map[string]error // player ID -> delivery error
+}
+
+// Error lists the failed players in a stable order
+func (e *BroadcastError) Error() string {
+	playerIDs := make([]string, 0, len(e.Failures))
+	for playerID := range e.Failures {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	failures := make([]string, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		failures = append(failures, fmt.Sprintf("%s: %v", playerID, e.Failures[playerID]))
+	}
+	return fmt.Sprintf("failed to broadcast %s to %d players of session %s: %s", e.EventType, len(failures), e.SessionID, strings.Join(failures, "; "))
+}
+
+// Unwrap returns the delivery errors, so errors.Is sees through the broadcast error
+func (e *BroadcastError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, err := range e.Failures {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// deadLetter is an event that could not be delivered to a player
+type deadLetter struct {
+	event    WebSocketEvent
+	failedAt time.Time
+}
+
+// deadLetterBuffer keeps the most recent undeliverable events of each player for a while, so
+// they can be sent once the player reconnects
+type deadLetterBuffer struct {
+	mu      sync.Mutex
+	letters map[string][]deadLetter // playerID -> oldest first
+	size    int
+	ttl     time.Duration
+}
+
+// newDeadLetterBuffer creates a buffer keeping up to size events per player for ttl
+func newDeadLetterBuffer(size int, ttl time.Duration) *deadLetterBuffer {
+	return &deadLetterBuffer{
+		letters: make(map[string][]deadLetter),
+		size:    size,
+		ttl:     ttl,
+	}
+}
+
+// add keeps an event for the player, dropping their oldest one when the buffer is full
+func (b *deadLetterBuffer) add(playerID string, event WebSocketEvent, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	letters := append(b.fresh(playerID, now), deadLetter{event: event, failedAt: now})
+	if len(letters) > b.size {
+		letters = letters[len(letters)-b.size:]
+	}
+	b.letters[playerID] = letters
+}
+
+// take removes and returns the player's events that have not expired, oldest first
+func (b *deadLetterBuffer) take(playerID string, now time.Time) []WebSocketEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	letters := b.fresh(playerID, now)
+	delete(b.letters, playerID)
+
+	events := make([]WebSocketEvent, 0, len(letters))
+	for _, letter := range letters {
+		events = append(events, letter.event)
+	}
+	return events
+}
+
+// drop forgets the player's events, e.g. once they have left for good
+func (b *deadLetterBuffer) drop(playerID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.letters, playerID)
+}
+
+// fresh returns the player's events that have not expired. The caller must hold b.mu.
+func (b *deadLetterBuffer) fresh(playerID string, now time.Time) []deadLetter {
+	letters := b.letters[playerID]
+	for len(letters) > 0 && now.Sub(letters[0].failedAt) > b.ttl {
+		letters = letters[1:]
+	}
+	return letters
+}
+
+// WithDeadLetters sets how many undeliverable events are kept per player, and for how long
+func WithDeadLetters(size int, ttl time.Duration) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		if size > 0 && ttl > 0 {
+			w.deadLetters = newDeadLetterBuffer(size, ttl)
+		}
+	}
+}
+
+// deliveryFailed records an event that could not be delivered to a player: it is counted per
+// event type and session, logged with its context, and kept for the player's reconnection
+// unless the session's event log already holds it for a resync or a newer update supersedes it
+func (w *WebSocketManagerImpl) deliveryFailed(sessionID, playerID string, event WebSocketEvent, err error) {
+	monitoring.GetGlobalMetricsCollector().NewCounter("websocket_broadcast_failures_total", "Events that could not be delivered to a player", map[string]string{
+		"event_type": event.Type,
+		"session_id": sessionID,
+	}).Inc()
+
+	superseded := priorityForEvent(event.Type) == PriorityLow
+	keep := event.Sequence == 0 && !superseded && !errors.Is(err, errConnectionNotFound)
+	logger := logging.GetLogger().WithComponent("websocket").WithSession(sessionID).WithPlayer(playerID).WithFields(map[string]interface{}{
+		"eventType":  event.Type,
+		"sequence":   event.Sequence,
+		"deadLetter": keep,
+		"error":      err.Error(),
+	})
+	// Missed frequent updates are expected while a player is away and not worth a warning
+	if superseded {
+		logger.Debug("failed to deliver event")
+	} else {
+		logger.Warn("failed to deliver event")
+	}
+
+	if keep {
+		w.deadLetters.add(playerID, event, time.Now())
+	}
+}
+
+// redeliverDeadLetters sends a reconnected player the events they could not be sent while
+// away, in one frame so the client can apply them in order
+func (w *WebSocketManagerImpl) redeliverDeadLetters(sessionID, playerID string) {
+	events := w.deadLetters.take(playerID, time.Now())
+	if len(events) == 0 {
+		return
+	}
+
+	event := WebSocketEvent{
+		Type:      EventDeadLetters,
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data: map[string]interface{}{
+			"events": events,
+		},
+		Timestamp: time.Now(),
+	}
+	if err := w.sendToLocalPlayer(playerID, event); err != nil {
+		logging.GetLogger().WithComponent("websocket").WithSession(sessionID).WithPlayer(playerID).WithFields(map[string]interface{}{
+			"events": len(events),
+			"error":  err.Error(),
+		}).Warn("failed to redeliver dead letters")
+
+		// Keep them for the next reconnection
+		now := time.Now()
+		for _, event := range events {
+			w.deadLetters.add(playerID, event, now)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"dumdoors-backend/internal/monitoring"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBroadcastToSession_ReportsFailuresAndKeepsDeadLetters(t *testing.T) {
+	w := NewWebSocketManager().(*WebSocketManagerImpl)
+	addLocalConnection(w, "dl-1", "p1")
+	away := addLocalConnection(w, "dl-1", "p2")
+	away.IsActive = false
+
+	err := w.BroadcastToSession("dl-1", WebSocketEvent{Type: "door-presented", SessionID: "dl-1"})
+	var broadcastErr *BroadcastError
+	if !errors.As(err, &broadcastErr) || len(broadcastErr.Failures) != 1 || broadcastErr.Failures["p2"] == nil || broadcastErr.EventType != "door-presented" {
+		t.Fatalf("Expected a broadcast error for p2 only, got %v", err)
+	}
+	w.BroadcastToSession("dl-1", WebSocketEvent{Type: "progress-update", SessionID: "dl-1"})
+
+	failures := monitoring.GetGlobalMetricsCollector().NewCounter("websocket_broadcast_failures_total", "", map[string]string{
+		"event_type": "door-presented",
+		"session_id": "dl-1",
+	})
+	if failures.Get() != 1 {
+		t.Errorf("Expected one failure counted for the door, got %v", failures.Get())
+	}
+
+	// The superseded progress update is not kept
+	away.mu.Lock()
+	away.IsActive = true
+	away.queue = newOutboundQueue(8)
+	away.mu.Unlock()
+	w.redeliverDeadLetters("dl-1", "p2")
+
+	event, ok := away.queue.pop()
+	if !ok || event.Type != EventDeadLetters {
+		t.Fatalf("Expected the dead letters redelivered, got %+v", event)
+	}
+	events := event.Data.(map[string]interface{})["events"].([]WebSocketEvent)
+	if len(events) != 1 || events[0].Type != "door-presented" {
+		t.Errorf("Expected only the door to be redelivered, got %+v", events)
+	}
+	if left := w.deadLetters.take("p2", time.Now()); len(left) != 0 {
+		t.Errorf("Expected redelivered events to leave the buffer, got %+v", left)
+	}
+}
+
+func TestDeadLetterBuffer_BoundedAndExpires(t *testing.T) {
+	buffer := newDeadLetterBuffer(2, time.Minute)
+	now := time.Now()
+	for _, eventType := range []string{"first", "second", "third"} {
+		buffer.add("p1", WebSocketEvent{Type: eventType}, now)
+	}
+	buffer.add("p2", WebSocketEvent{Type: "stale"}, now.Add(-2*time.Minute))
+
+	events := buffer.take("p1", now)
+	if len(events) != 2 || events[0].Type != "second" || events[1].Type != "third" {
+		t.Errorf("Expected the two newest events, oldest first, got %+v", events)
+	}
+	if events := buffer.take("p2", now); len(events) != 0 {
+		t.Errorf("Expected expired events to be dropped, got %+v", events)
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/repositories"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fan-out settings
+const (
+	fanoutPublishTimeout = 2 * time.Second
+	fanoutRetryDelay     = time.Second
+)
+
+// errConnectionNotFound reports that a player has no connection on this instance
+var errConnectionNotFound = errors.New("connection not found")
+
+// fanoutMessage carries an event to the other backend instances. Session messages reach every
+// connection in the session except ExcludePlayerID; player messages reach a single player.
+// CloseSession messages carry no event and disconnect the whole session; Disconnect messages
+// carry no event and close a single player's connection.
+type fanoutMessage struct {
+	Origin          string         `json:"origin"`
+	SessionID       string         `json:"sessionId,omitempty"`
+	PlayerID        string         `json:"playerId,omitempty"`
+	ExcludePlayerID string         `json:"excludePlayerId,omitempty"`
+	CloseSession    bool           `json:"closeSession,omitempty"`
+	Disconnect      bool           `json:"disconnect,omitempty"`
+	Event           WebSocketEvent `json:"event"`
+}
+
+// WithEventBus delivers broadcasts to connections held by other backend instances
+func WithEventBus(bus repositories.EventBus) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		w.eventBus = bus
+		if w.instanceID == "" {
+			w.instanceID = uuid.New().String()
+		}
+	}
+}
+
+// WithInstanceID names this instance in fan-out messages, so it matches the instance sticky
+// routing sends clients to; an empty ID keeps a generated one
+func WithInstanceID(instanceID string) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		if instanceID != "" {
+			w.instanceID = instanceID
+		}
+	}
+}
+
+// StartFanout delivers events published by other instances until the context is cancelled,
+// resubscribing after failures
+func (w *WebSocketManagerImpl) StartFanout(ctx context.Context) {
+	if w.eventBus == nil {
+		return
+	}
+
+	for {
+		if err := w.eventBus.Subscribe(ctx, w.handleFanout); err != nil {
+			log.Printf("WebSocket fan-out subscription failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(fanoutRetryDelay):
+		}
+	}
+}
+
+// publish sends a message to the other instances
+func (w *WebSocketManagerImpl) publish(message fanoutMessage) error {
+	message.Origin = w.instanceID
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode fan-out message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fanoutPublishTimeout)
+	defer cancel()
+
+	if err := w.eventBus.Publish(ctx, payload); err != nil {
+		log.Printf("Failed to fan out %s event: %v", message.Event.Type, err)
+		return err
+	}
+	return nil
+}
+
+// handleFanout delivers a message published by another instance to local connections
+func (w *WebSocketManagerImpl) handleFanout(payload []byte) {
+	var message fanoutMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		log.Printf("Failed to decode fan-out message: %v", err)
+		return
+	}
+
+	if message.Origin == w.instanceID {
+		return
+	}
+
+	switch {
+	case message.CloseSession:
+		w.closeLocalSession(message.SessionID)
+	case message.Disconnect:
+		w.disconnectLocalPlayer(message.PlayerID)
+	case message.PlayerID != "":
+		if err := w.sendToLocalPlayer(message.PlayerID, message.Event); err != nil && !errors.Is(err, errConnectionNotFound) {
+			log.Printf("Failed to deliver fanned-out event to player %s: %v", message.PlayerID, err)
+		}
+	case message.ExcludePlayerID != "":
+		w.deliverToOthers(message.SessionID, message.ExcludePlayerID, message.Event)
+	case w.hasLocalSession(message.SessionID):
+		if err := w.deliverToSession(message.SessionID, message.Event); err != nil {
+			log.Printf("Failed to deliver fanned-out event to session %s: %v", message.SessionID, err)
+		}
+	}
+}
+
+// hasLocalSession reports whether this instance holds any connection for the session
+func (w *WebSocketManagerImpl) hasLocalSession(sessionID string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	_, players := w.sessions[sessionID]
+	_, watched := w.sessionSpectators[sessionID]
+	return players || watched
+}
+
+// LocalSessionIDs lists the sessions this instance holds a player or spectator connection for
+func (w *WebSocketManagerImpl) LocalSessionIDs() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	sessionIDs := make([]string, 0, len(w.sessions))
+	for sessionID := range w.sessions {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	for sessionID := range w.sessionSpectators {
+		if _, players := w.sessions[sessionID]; !players {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	return sessionIDs
+}
+
+// SendToLocalSession sends an event to the session's connections on this instance only. It
+// is for news about the instance itself, such as it draining, which other instances must not
+// pass on to their connections.
+func (w *WebSocketManagerImpl) SendToLocalSession(sessionID string, event WebSocketEvent) error {
+	return w.deliverToSession(sessionID, event)
+}
+
+// CloseLocalSession closes this instance's connections to a session, leaving any other
+// instance's connections open
+func (w *WebSocketManagerImpl) CloseLocalSession(sessionID string) {
+	w.closeLocalSession(sessionID)
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MockEventBus is an in-memory EventBus shared by several managers
+type MockEventBus struct {
+	mu       sync.Mutex
+	handlers []func(payload []byte)
+}
+
+func (b *MockEventBus) Publish(ctx context.Context, payload []byte) error {
+	b.mu.Lock()
+	handlers := append([]func(payload []byte){}, b.handlers...)
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(payload)
+	}
+	return nil
+}
+
+func (b *MockEventBus) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handler)
+	b.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (b *MockEventBus) subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.handlers)
+}
+
+// newFanoutManagers starts two managers sharing one bus, as if they were separate instances
+func newFanoutManagers(t *testing.T) (*WebSocketManagerImpl, *WebSocketManagerImpl) {
+	t.Helper()
+
+	bus := &MockEventBus{}
+	first := NewWebSocketManager(WithEventBus(bus)).(*WebSocketManagerImpl)
+	second := NewWebSocketManager(WithEventBus(bus)).(*WebSocketManagerImpl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go first.StartFanout(ctx)
+	go second.StartFanout(ctx)
+
+	deadline := time.Now().Add(time.Second)
+	for bus.subscribers() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("managers did not subscribe to the event bus")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return first, second
+}
+
+// addLocalConnection registers a queue-only connection, bypassing the socket
+func addLocalConnection(w *WebSocketManagerImpl, sessionID, playerID string) *WebSocketConnection {
+	conn := &WebSocketConnection{
+		PlayerID:  playerID,
+		SessionID: sessionID,
+		IsActive:  true,
+		queue:     newOutboundQueue(8),
+	}
+
+	w.mu.Lock()
+	w.connections[playerID] = conn
+	w.sessions[sessionID] = append(w.sessions[sessionID], playerID)
+	w.mu.Unlock()
+	return conn
+}
+
+func queueDepth(conn *WebSocketConnection) int {
+	depth, _ := conn.SendQueueStats()
+	return depth
+}
+
+func TestFanout_BroadcastReachesOtherInstances(t *testing.T) {
+	first, second := newFanoutManagers(t)
+	local := addLocalConnection(first, "s1", "p1")
+	remote := addLocalConnection(second, "s1", "p2")
+
+	if err := first.BroadcastToSession("s1", WebSocketEvent{Type: "door-presented", SessionID: "s1"}); err != nil {
+		t.Fatalf("BroadcastToSession failed: %v", err)
+	}
+
+	if queueDepth(local) != 1 {
+		t.Errorf("expected the local player to receive the event once, got %d", queueDepth(local))
+	}
+	if queueDepth(remote) != 1 {
+		t.Errorf("expected the remote player to receive the event once, got %d", queueDepth(remote))
+	}
+}
+
+func TestFanout_BroadcastForRemoteOnlySession(t *testing.T) {
+	first, second := newFanoutManagers(t)
+	remote := addLocalConnection(second, "s1", "p2")
+
+	if err := first.BroadcastToSession("s1", WebSocketEvent{Type: "scores-updated", SessionID: "s1"}); err != nil {
+		t.Fatalf("expected no error for a session held by another instance, got %v", err)
+	}
+	if queueDepth(remote) != 1 {
+		t.Errorf("expected the remote player to receive the event, got %d", queueDepth(remote))
+	}
+}
+
+func TestFanout_SendToPlayerOnOtherInstance(t *testing.T) {
+	first, second := newFanoutManagers(t)
+	remote := addLocalConnection(second, "s1", "p2")
+	bystander := addLocalConnection(second, "s1", "p3")
+
+	if err := first.SendToPlayer("p2", WebSocketEvent{Type: "match-found"}); err != nil {
+		t.Fatalf("SendToPlayer failed: %v", err)
+	}
+
+	if queueDepth(remote) != 1 {
+		t.Errorf("expected the target player to receive the event, got %d", queueDepth(remote))
+	}
+	if queueDepth(bystander) != 0 {
+		t.Errorf("expected other players not to receive a direct event, got %d", queueDepth(bystander))
+	}
+}
+
+func TestFanout_BroadcastToOthersExcludesSender(t *testing.T) {
+	first, second := newFanoutManagers(t)
+	sender := addLocalConnection(second, "s1", "p1")
+	other := addLocalConnection(second, "s1", "p2")
+
+	first.broadcastToOthers("s1", "p1", WebSocketEvent{Type: "player-joined", SessionID: "s1"})
+
+	if queueDepth(sender) != 0 {
+		t.Errorf("expected the excluded player not to receive the event, got %d", queueDepth(sender))
+	}
+	if queueDepth(other) != 1 {
+		t.Errorf("expected the other player to receive the event, got %d", queueDepth(other))
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"dumdoors-backend/internal/monitoring"
+	"encoding/binary"
+	"log"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+// Heartbeat defaults
+const (
+	DefaultPingInterval   = 30 * time.Second
+	DefaultMaxMissedPongs = 3
+)
+
+// HeartbeatStats describes the liveness of a single connection
+type HeartbeatStats struct {
+	LatencyMs   float64    `json:"latencyMs"`
+	MissedPongs int        `json:"missedPongs"`
+	LastPongAt  *time.Time `json:"lastPongAt,omitempty"`
+}
+
+// heartbeat tracks the pings sent to a connection and the pongs it answered with
+type heartbeat struct {
+	awaitingPong bool
+	missedPongs  int
+	latency      time.Duration
+	lastPongAt   time.Time
+}
+
+// heartbeatMetrics are shared by every manager, since they register under fixed names
+type heartbeatMetrics struct {
+	pongLatency     *monitoring.Histogram
+	deadConnections *monitoring.Counter
+}
+
+func newHeartbeatMetrics() heartbeatMetrics {
+	collector := monitoring.GetGlobalMetricsCollector()
+	return heartbeatMetrics{
+		pongLatency:     collector.NewHistogram("websocket_pong_latency_seconds", "Round trip time of WebSocket ping frames", nil),
+		deadConnections: collector.NewCounter("websocket_dead_connections_total", "Connections closed after missing too many pongs", nil),
+	}
+}
+
+// WithHeartbeat sets how often connections are pinged and how many pongs in a row a
+// connection may miss before it is considered dead and closed
+func WithHeartbeat(interval time.Duration, maxMissedPongs int) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		if interval > 0 {
+			w.pingInterval = interval
+		}
+		if maxMissedPongs > 0 {
+			w.maxMissedPongs = maxMissedPongs
+		}
+	}
+}
+
+// startHeartbeat pings every connection each ping interval
+func (w *WebSocketManagerImpl) startHeartbeat() {
+	ticker := time.NewTicker(w.pingInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.pingConnections()
+	}
+}
+
+// pingConnections pings every active connection, closing those that missed too many pongs
+func (w *WebSocketManagerImpl) pingConnections() {
+	w.mu.RLock()
+	connections := make([]*WebSocketConnection, 0, len(w.connections)+len(w.spectators))
+	for _, conn := range w.connections {
+		connections = append(connections, conn)
+	}
+	for _, conn := range w.spectators {
+		connections = append(connections, conn)
+	}
+	w.mu.RUnlock()
+
+	for _, conn := range connections {
+		w.ping(conn)
+	}
+}
+
+// ping sends a ping frame carrying the send time, or closes the connection when the previous
+// pings went unanswered. Closing the socket ends its read loop, which unregisters it as usual.
+func (w *WebSocketManagerImpl) ping(conn *WebSocketConnection) {
+	conn.mu.Lock()
+	if !conn.IsActive || conn.Conn == nil {
+		conn.mu.Unlock()
+		return
+	}
+	if conn.heartbeat.awaitingPong {
+		conn.heartbeat.missedPongs++
+	}
+	if conn.heartbeat.missedPongs >= w.maxMissedPongs {
+		conn.IsActive = false
+		wsConn := conn.Conn
+		conn.mu.Unlock()
+
+		w.heartbeatMetrics.deadConnections.Inc()
+		log.Printf("Closing dead WebSocket connection for player %s in session %s after %d missed pongs", conn.PlayerID, conn.SessionID, w.maxMissedPongs)
+		conn.closeQueue()
+		wsConn.Close()
+		return
+	}
+	conn.heartbeat.awaitingPong = true
+	wsConn := conn.Conn
+	conn.mu.Unlock()
+
+	payload := make([]byte, 8)
+	binary.BigEndian.PutUint64(payload, uint64(time.Now().UnixNano()))
+
+	// Control frames may be written concurrently with the connection's writer
+	if err := wsConn.WriteControl(websocket.PingMessage, payload, time.Now().Add(w.writeTimeout)); err != nil {
+		log.Printf("Failed to ping WebSocket connection for player %s: %v", conn.PlayerID, err)
+	}
+}
+
+// trackPongs resets the connection's heartbeat and records the latency of each pong its
+// socket answers with. It must be called before the socket's read loop starts.
+func (w *WebSocketManagerImpl) trackPongs(conn *WebSocketConnection) {
+	conn.mu.Lock()
+	conn.heartbeat = heartbeat{}
+	wsConn := conn.Conn
+	conn.mu.Unlock()
+
+	if wsConn == nil {
+		return
+	}
+
+	wsConn.SetPongHandler(func(appData string) error {
+		now := time.Now()
+
+		conn.mu.Lock()
+		conn.heartbeat.awaitingPong = false
+		conn.heartbeat.missedPongs = 0
+		conn.heartbeat.lastPongAt = now
+		conn.LastSeen = now
+		if len(appData) == 8 {
+			sentAt := time.Unix(0, int64(binary.BigEndian.Uint64([]byte(appData))))
+			conn.heartbeat.latency = now.Sub(sentAt)
+		}
+		latency := conn.heartbeat.latency
+		conn.mu.Unlock()
+
+		w.heartbeatMetrics.pongLatency.Observe(latency.Seconds())
+		return nil
+	})
+}
+
+// HeartbeatStats reports the connection's last measured latency and how many pongs it has missed
+func (c *WebSocketConnection) HeartbeatStats() HeartbeatStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := HeartbeatStats{
+		LatencyMs:   float64(c.heartbeat.latency.Microseconds()) / 1000,
+		MissedPongs: c.heartbeat.missedPongs,
+	}
+	if !c.heartbeat.lastPongAt.IsZero() {
+		lastPongAt := c.heartbeat.lastPongAt
+		stats.LastPongAt = &lastPongAt
+	}
+	return stats
+}
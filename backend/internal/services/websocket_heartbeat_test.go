@@ -0,0 +1,115 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	fiberws "github.com/gofiber/contrib/websocket"
+)
+
+// dialTestSocket connects to a server that runs serve on its end of each socket and returns
+// the client end, wrapped the way the manager receives sockets
+func dialTestSocket(t *testing.T, serve func(conn *websocket.Conn)) *fiberws.Conn {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		serve(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial test socket: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return &fiberws.Conn{Conn: client}
+}
+
+// registerSocket registers a player connection on a socket and starts reading it, as the
+// connection handler would
+func registerSocket(t *testing.T, w *WebSocketManagerImpl, conn *fiberws.Conn) *WebSocketConnection {
+	t.Helper()
+
+	if err := w.RegisterConnection("s1", "p1", conn); err != nil {
+		t.Fatalf("RegisterConnection failed: %v", err)
+	}
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.connections["p1"]
+}
+
+func TestHeartbeat_RecordsPongLatency(t *testing.T) {
+	w := NewWebSocketManager().(*WebSocketManagerImpl)
+
+	// The server answers pings with pongs while it reads
+	socket := dialTestSocket(t, func(conn *websocket.Conn) {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	conn := registerSocket(t, w, socket)
+
+	w.pingConnections()
+
+	deadline := time.Now().Add(time.Second)
+	for conn.HeartbeatStats().LastPongAt == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a pong to be recorded")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	stats := conn.HeartbeatStats()
+	if stats.MissedPongs != 0 {
+		t.Errorf("expected no missed pongs, got %d", stats.MissedPongs)
+	}
+	if stats.LatencyMs <= 0 {
+		t.Errorf("expected a positive latency, got %f", stats.LatencyMs)
+	}
+}
+
+func TestHeartbeat_ClosesConnectionAfterMissedPongs(t *testing.T) {
+	w := NewWebSocketManager(WithHeartbeat(time.Hour, 2)).(*WebSocketManagerImpl)
+
+	// The server never reads, so pings go unanswered
+	hold := make(chan struct{})
+	t.Cleanup(func() { close(hold) })
+	socket := dialTestSocket(t, func(conn *websocket.Conn) {
+		<-hold
+	})
+	conn := registerSocket(t, w, socket)
+
+	w.pingConnections()
+	w.pingConnections()
+	if stats := conn.HeartbeatStats(); stats.MissedPongs != 1 {
+		t.Fatalf("expected 1 missed pong, got %d", stats.MissedPongs)
+	}
+	if len(w.GetActiveConnections("s1")) != 1 {
+		t.Fatal("connection should stay active until it misses the limit")
+	}
+
+	w.pingConnections()
+	if len(w.GetActiveConnections("s1")) != 0 {
+		t.Error("expected the dead connection to be marked inactive")
+	}
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"dumdoors-backend/internal/monitoring"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+// Outbound queue defaults
+const (
+	DefaultSendQueueSize      = 256
+	DefaultWriteTimeout       = 10 * time.Second
+	DefaultQueueDepthInterval = 5 * time.Second
+)
+
+// Outbound queue errors
+var (
+	ErrSendQueueOverflow = errors.New("send queue overflow")
+	ErrSendQueueClosed   = errors.New("send queue closed")
+)
+
+// OverflowPolicy decides what happens when a client's queue is full of events that cannot be shed
+type OverflowPolicy string
+
+// Overflow policies
+const (
+	OverflowDisconnect OverflowPolicy = "disconnect"  // the client is dropped and reconnects to resync; the default
+	OverflowDropOldest OverflowPolicy = "drop-oldest" // the oldest queued event makes room, so the client stays connected
+)
+
+// EventPriority controls which queued events are shed first when a client falls behind
+type EventPriority int
+
+// Event priorities, lowest first
+const (
+	PriorityLow EventPriority = iota
+	PriorityNormal
+	PriorityHigh
+)
+
+// eventPriorities maps event types to their delivery priority; unlisted types are normal, but
+// every Event constant is listed so its priority is a deliberate choice
+var eventPriorities = map[string]EventPriority{
+	// Frequent, superseded by the next update
+	"player-typing":          PriorityLow,
+	"draft-saved":            PriorityLow,
+	"progress-update":        PriorityLow,
+	"player-progress-update": PriorityLow,
+	"player-position-update": PriorityLow,
+	"player-status-update":   PriorityLow,
+	"leaderboard-update":     PriorityLow,
+	"real-time-score-update": PriorityLow,
+	"scoring-progress":       PriorityLow,
+	EventAnswerProgress:      PriorityLow,
+	EventTimerTick:           PriorityLow,
+	"message":                PriorityLow,
+
+	// Game flow the client cannot recover from missing
+	"game-started":     PriorityHigh,
+	"door-presented":   PriorityHigh,
+	"scores-updated":   PriorityHigh,
+	"game-completed":   PriorityHigh,
+	"final-rankings":   PriorityHigh,
+	"response-timeout": PriorityHigh,
+	"match-found":      PriorityHigh,
+	"ready-countdown":  PriorityHigh,
+	"player-kicked":    PriorityHigh,
+	"host-transferred": PriorityHigh,
+	EventDeadLetters:   PriorityHigh,
+
+	// Tournament progression
+	"tournament-started":       PriorityHigh,
+	"tournament-round-started": PriorityHigh,
+	"tournament-match-ready":   PriorityHigh,
+	"tournament-completed":     PriorityHigh,
+
+	// Session lifecycle
+	EventSessionStateChanged: PriorityHigh,
+	EventGamePaused:          PriorityHigh,
+	EventGameResumed:         PriorityHigh,
+	EventRoundResults:        PriorityHigh,
+	EventResponsesRevealed:   PriorityHigh,
+	EventPlayerTimedOut:      PriorityHigh,
+	EventServerDraining:      PriorityHigh,
+	EventResyncComplete:      PriorityHigh,
+
+	// Recoverable by fetching the session
+	EventResponseFeedback:     PriorityNormal,
+	EventTeamsUpdated:         PriorityNormal,
+	EventPlayerOptionsUpdated: PriorityNormal,
+	EventTimerSync:            PriorityNormal,
+}
+
+// priorityForEvent returns the delivery priority for an event type
+func priorityForEvent(eventType string) EventPriority {
+	if priority, exists := eventPriorities[eventType]; exists {
+		return priority
+	}
+	return PriorityNormal
+}
+
+// outboundQueue is a bounded per-connection buffer of events waiting to be written
+type outboundQueue struct {
+	mu      sync.Mutex
+	events  []WebSocketEvent
+	maxSize int
+	policy  OverflowPolicy
+	dropped uint64
+	closed  bool
+	hungUp  bool // closed by hangUp; the writer closes the socket once drained
+	ready   chan struct{}
+}
+
+// newOutboundQueue creates a queue that holds at most maxSize events
+func newOutboundQueue(maxSize int) *outboundQueue {
+	if maxSize <= 0 {
+		maxSize = DefaultSendQueueSize
+	}
+	return &outboundQueue{
+		events:  make([]WebSocketEvent, 0, maxSize),
+		maxSize: maxSize,
+		ready:   make(chan struct{}, 1),
+	}
+}
+
+// push enqueues an event. When the queue is full the oldest event with a lower priority is
+// dropped to make room; a full queue of equal or higher priority events drops an incoming
+// low-priority event. Anything more important drops the oldest queued event under the
+// drop-oldest policy and reports ErrSendQueueOverflow otherwise.
+func (q *outboundQueue) push(event WebSocketEvent) (dropped bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return false, ErrSendQueueClosed
+	}
+
+	if len(q.events) >= q.maxSize {
+		priority := priorityForEvent(event.Type)
+		victim := q.sheddableIndex(priority)
+
+		switch {
+		case victim >= 0:
+			q.events = append(q.events[:victim], q.events[victim+1:]...)
+			q.dropped++
+			dropped = true
+		case priority == PriorityLow:
+			q.dropped++
+			return true, nil
+		case q.policy == OverflowDropOldest:
+			q.events[0] = WebSocketEvent{}
+			q.events = q.events[1:]
+			q.dropped++
+			dropped = true
+		default:
+			return false, ErrSendQueueOverflow
+		}
+	}
+
+	q.events = append(q.events, event)
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+
+	return dropped, nil
+}
+
+// sheddableIndex returns the oldest queued event with the lowest priority below the given one, or -1
+func (q *outboundQueue) sheddableIndex(below EventPriority) int {
+	victim := -1
+	for i, queued := range q.events {
+		priority := priorityForEvent(queued.Type)
+		if priority >= below {
+			continue
+		}
+		if victim < 0 || priority < priorityForEvent(q.events[victim].Type) {
+			victim = i
+		}
+	}
+	return victim
+}
+
+// pop blocks until an event is available or the queue is closed
+func (q *outboundQueue) pop() (WebSocketEvent, bool) {
+	event, ok, _ := q.popBefore(nil)
+	return event, ok
+}
+
+// popBefore is pop that gives up once timeout fires, reporting the queue still open. A nil
+// timeout never fires.
+func (q *outboundQueue) popBefore(timeout <-chan time.Time) (event WebSocketEvent, ok bool, open bool) {
+	for {
+		q.mu.Lock()
+		if len(q.events) > 0 {
+			event := q.events[0]
+			q.events[0] = WebSocketEvent{}
+			q.events = q.events[1:]
+			q.mu.Unlock()
+			return event, true, true
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return WebSocketEvent{}, false, false
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-timeout:
+			return WebSocketEvent{}, false, true
+		}
+	}
+}
+
+// close stops the queue; pending events are discarded
+func (q *outboundQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.events = nil
+	close(q.ready)
+}
+
+// hangUp stops the queue from accepting events; the pending ones are still written
+func (q *outboundQueue) hangUp() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.hungUp = true
+	close(q.ready)
+}
+
+// isHungUp reports whether the queue was stopped by hangUp
+func (q *outboundQueue) isHungUp() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.hungUp
+}
+
+// stats returns the current depth and the number of events dropped so far
+func (q *outboundQueue) stats() (depth int, dropped uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.events), q.dropped
+}
+
+// startWriter gives a connection a fresh outbound queue and a goroutine that drains it
+func (w *WebSocketManagerImpl) startWriter(conn *WebSocketConnection) {
+	queue := newOutboundQueue(w.sendQueueSize)
+	queue.policy = w.overflowPolicy
+
+	conn.mu.Lock()
+	conn.queue = queue
+	wsConn := conn.Conn
+	conn.mu.Unlock()
+
+	go w.writeLoop(conn, wsConn, queue)
+}
+
+// writeLoop writes queued events to the socket one at a time until the queue closes or a write fails
+func (w *WebSocketManagerImpl) writeLoop(conn *WebSocketConnection, wsConn *websocket.Conn, queue *outboundQueue) {
+	for {
+		event, ok := queue.pop()
+		if !ok {
+			if queue.isHungUp() {
+				wsConn.Close()
+			}
+			return
+		}
+
+		if w.writeTimeout > 0 {
+			wsConn.SetWriteDeadline(time.Now().Add(w.writeTimeout))
+		}
+
+		data, err := w.encodeEvent(event)
+		if err != nil {
+			log.Printf("Failed to write WebSocket event to player %s: %v", conn.PlayerID, err)
+			continue
+		}
+		if err := w.writeEvent(wsConn, data); err != nil {
+			log.Printf("Failed to write WebSocket event to player %s: %v", conn.PlayerID, err)
+			// Mark connection as inactive on write error
+			conn.mu.Lock()
+			if conn.queue == queue {
+				conn.IsActive = false
+			}
+			conn.mu.Unlock()
+			queue.close()
+			return
+		}
+	}
+}
+
+// disconnectSlowClient drops a client whose queue is full of events that cannot be shed.
+// Closing the socket ends its read loop, which unregisters the connection as usual.
+func (w *WebSocketManagerImpl) disconnectSlowClient(conn *WebSocketConnection) {
+	conn.mu.Lock()
+	conn.IsActive = false
+	wsConn := conn.Conn
+	conn.mu.Unlock()
+	conn.closeQueue()
+
+	w.slowDisconnections.Inc()
+	log.Printf("Disconnecting slow WebSocket client for player %s in session %s", conn.PlayerID, conn.SessionID)
+
+	if wsConn != nil {
+		wsConn.Close()
+	}
+}
+
+// startQueueDepthReporting reports the send queue depth of every session each interval
+func (w *WebSocketManagerImpl) startQueueDepthReporting(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.reportQueueDepths()
+	}
+}
+
+// reportQueueDepths sets each session's queue depth gauge to the events waiting for its players'
+// connections on this instance
+func (w *WebSocketManagerImpl) reportQueueDepths() {
+	w.mu.RLock()
+	depths := make(map[string]int, len(w.sessions))
+	for sessionID, playerIDs := range w.sessions {
+		depths[sessionID] = 0
+		for _, playerID := range playerIDs {
+			if conn, exists := w.connections[playerID]; exists && conn.SessionID == sessionID {
+				depth, _ := conn.SendQueueStats()
+				depths[sessionID] += depth
+			}
+		}
+	}
+	w.mu.RUnlock()
+
+	for sessionID, depth := range depths {
+		queueDepthGauge(sessionID).Set(float64(depth))
+	}
+}
+
+// queueDepthGauge returns the gauge of events waiting to be written to a session's players
+func queueDepthGauge(sessionID string) *monitoring.Gauge {
+	return monitoring.GetGlobalMetricsCollector().NewGauge("websocket_send_queue_depth", "Events waiting to be written to a session's players on this instance", map[string]string{
+		"session_id": sessionID,
+	})
+}
+
+// closeQueue stops the connection's writer
+func (c *WebSocketConnection) closeQueue() {
+	c.mu.RLock()
+	queue := c.queue
+	c.mu.RUnlock()
+
+	if queue != nil {
+		queue.close()
+	}
+}
+
+// hangUp closes the connection once the events already queued for it are written
+func (c *WebSocketConnection) hangUp() {
+	c.mu.Lock()
+	c.IsActive = false
+	queue := c.queue
+	wsConn := c.Conn
+	c.mu.Unlock()
+
+	if queue != nil {
+		queue.hangUp()
+		return
+	}
+	if wsConn != nil {
+		wsConn.Close()
+	}
+}
+
+// SendQueueStats reports how many events are waiting for this connection and how many were shed
+func (c *WebSocketConnection) SendQueueStats() (depth int, dropped uint64) {
+	c.mu.RLock()
+	queue := c.queue
+	c.mu.RUnlock()
+
+	if queue == nil {
+		return 0, 0
+	}
+	return queue.stats()
+}
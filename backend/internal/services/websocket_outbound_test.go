@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutboundQueue_ShedsLowPriorityFirst(t *testing.T) {
+	queue := newOutboundQueue(3)
+
+	queue.push(WebSocketEvent{Type: "progress-update"})
+	queue.push(WebSocketEvent{Type: "player-joined"})
+	queue.push(WebSocketEvent{Type: "leaderboard-update"})
+
+	// A full queue makes room for a high-priority event by dropping the oldest low-priority one
+	dropped, err := queue.push(WebSocketEvent{Type: "door-presented"})
+	if err != nil {
+		t.Fatalf("Unexpected push error: %v", err)
+	}
+	if !dropped {
+		t.Error("Expected a low-priority event to be dropped")
+	}
+
+	var types []string
+	for i := 0; i < 3; i++ {
+		event, _ := queue.pop()
+		types = append(types, event.Type)
+	}
+
+	expected := []string{"player-joined", "leaderboard-update", "door-presented"}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf("Expected %s at position %d, got %s", expected[i], i, types[i])
+		}
+	}
+}
+
+func TestOutboundQueue_DropsIncomingLowPriorityWhenFull(t *testing.T) {
+	queue := newOutboundQueue(2)
+
+	queue.push(WebSocketEvent{Type: "door-presented"})
+	queue.push(WebSocketEvent{Type: "scores-updated"})
+
+	dropped, err := queue.push(WebSocketEvent{Type: "player-typing"})
+	if err != nil {
+		t.Fatalf("Low-priority events should be dropped, not overflow: %v", err)
+	}
+	if !dropped {
+		t.Error("Expected the incoming low-priority event to be dropped")
+	}
+
+	if depth, droppedCount := queue.stats(); depth != 2 || droppedCount != 1 {
+		t.Errorf("Expected depth 2 and 1 dropped, got depth %d and %d dropped", depth, droppedCount)
+	}
+}
+
+func TestOutboundQueue_OverflowsOnUnsheddableEvents(t *testing.T) {
+	queue := newOutboundQueue(2)
+
+	queue.push(WebSocketEvent{Type: "door-presented"})
+	queue.push(WebSocketEvent{Type: "game-started"})
+
+	if _, err := queue.push(WebSocketEvent{Type: "final-rankings"}); !errors.Is(err, ErrSendQueueOverflow) {
+		t.Errorf("Expected ErrSendQueueOverflow, got %v", err)
+	}
+}
+
+func TestOutboundQueue_DropOldestKeepsClientConnected(t *testing.T) {
+	queue := newOutboundQueue(2)
+	queue.policy = OverflowDropOldest
+
+	queue.push(WebSocketEvent{Type: "door-presented"})
+	queue.push(WebSocketEvent{Type: "game-started"})
+
+	dropped, err := queue.push(WebSocketEvent{Type: "final-rankings"})
+	if err != nil || !dropped {
+		t.Fatalf("Expected the oldest event to make room, got dropped=%v, %v", dropped, err)
+	}
+	for _, want := range []string{"game-started", "final-rankings"} {
+		if event, _ := queue.pop(); event.Type != want {
+			t.Errorf("Expected %s, got %s", want, event.Type)
+		}
+	}
+}
+
+func TestReportQueueDepths_SumsEachSessionsConnections(t *testing.T) {
+	w := NewWebSocketManager().(*WebSocketManagerImpl)
+	first := addLocalConnection(w, "depth-1", "p1")
+	second := addLocalConnection(w, "depth-1", "p2")
+	addLocalConnection(w, "depth-2", "p3")
+	first.queue.push(WebSocketEvent{Type: "door-presented"})
+	second.queue.push(WebSocketEvent{Type: "door-presented"})
+	second.queue.push(WebSocketEvent{Type: "scores-updated"})
+
+	w.reportQueueDepths()
+	if depth := queueDepthGauge("depth-1").Get(); depth != 3 {
+		t.Errorf("Expected 3 events waiting in depth-1, got %v", depth)
+	}
+	if depth := queueDepthGauge("depth-2").Get(); depth != 0 {
+		t.Errorf("Expected an empty queue in depth-2, got %v", depth)
+	}
+
+	w.CloseSession("depth-1")
+	if depth := queueDepthGauge("depth-1").Get(); depth != 0 {
+		t.Errorf("Expected a closed session to report no depth, got %v", depth)
+	}
+}
+
+func TestOutboundQueue_PopUnblocksOnClose(t *testing.T) {
+	queue := newOutboundQueue(2)
+
+	done := make(chan bool)
+	go func() {
+		_, ok := queue.pop()
+		done <- ok
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	queue.close()
+
+	select {
+	case ok := <-done:
+		if ok {
+			t.Error("Expected pop to report a closed queue")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pop did not return after close")
+	}
+
+	if _, err := queue.push(WebSocketEvent{Type: "door-presented"}); !errors.Is(err, ErrSendQueueClosed) {
+		t.Errorf("Expected ErrSendQueueClosed after close, got %v", err)
+	}
+}
+
+func TestOutboundQueue_HangUpDeliversPendingEvents(t *testing.T) {
+	queue := newOutboundQueue(4)
+	queue.push(WebSocketEvent{Type: EventSessionStateChanged})
+	queue.hangUp()
+
+	if _, err := queue.push(WebSocketEvent{Type: "door-presented"}); !errors.Is(err, ErrSendQueueClosed) {
+		t.Errorf("Expected ErrSendQueueClosed after hanging up, got %v", err)
+	}
+	if event, ok := queue.pop(); !ok || event.Type != EventSessionStateChanged {
+		t.Errorf("Expected the pending event to still be written, got %+v", event)
+	}
+	if _, ok := queue.pop(); ok {
+		t.Error("Expected the queue to stop once drained")
+	}
+	if !queue.isHungUp() {
+		t.Error("Expected the writer to be told to close the socket")
+	}
+}
+
+func TestEventPriorities_CoverEveryEventConstant(t *testing.T) {
+	packages, err := parser.ParseDir(token.NewFileSet(), ".", func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("failed to parse package: %v", err)
+	}
+
+	for _, file := range packages["services"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				for i, name := range spec.(*ast.ValueSpec).Names {
+					values := spec.(*ast.ValueSpec).Values
+					if !strings.HasPrefix(name.Name, "Event") || i >= len(values) {
+						continue
+					}
+					literal, ok := values[i].(*ast.BasicLit)
+					if !ok || literal.Kind != token.STRING {
+						continue
+					}
+					eventType := strings.Trim(literal.Value, "\"`")
+					if _, exists := eventPriorities[eventType]; !exists {
+						t.Errorf("Expected %s (%q) to have a delivery priority", name.Name, eventType)
+					}
+				}
+			}
+		}
+	}
+}
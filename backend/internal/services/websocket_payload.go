@@ -0,0 +1,86 @@
+package services
+
+import (
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+// DefaultCompressionThreshold is the smallest payload worth compressing; below it the deflate
+// framing costs about as much as it saves
+const DefaultCompressionThreshold = 1024
+
+// payloadSizeBuckets are the histogram bucket upper bounds, in bytes, for written payloads
+var payloadSizeBuckets = []float64{256, 1024, 4096, 16384, 65536, 262144}
+
+// PlayerDelta is the part of a player that changes during a game. Events carry these in place
+// of the whole session, whose responses grow with every door; clients that need everything
+// fetch the session or get it when their socket connects.
+type PlayerDelta struct {
+	PlayerID        string `json:"playerId"`
+	Username        string `json:"username"`
+	TotalScore      int    `json:"totalScore"`
+	CurrentPosition int    `json:"currentPosition"`
+	IsActive        bool   `json:"isActive"`
+	IsBot           bool   `json:"isBot,omitempty"`
+}
+
+// playerDeltas returns the changing state of every player in the session
+func playerDeltas(session *models.GameSession) []PlayerDelta {
+	deltas := make([]PlayerDelta, 0, len(session.Players))
+	for _, player := range session.Players {
+		deltas = append(deltas, PlayerDelta{
+			PlayerID:        player.PlayerID,
+			Username:        player.Username,
+			TotalScore:      player.TotalScore,
+			CurrentPosition: player.CurrentPosition,
+			IsActive:        player.IsActive,
+			IsBot:           player.IsBot,
+		})
+	}
+	return deltas
+}
+
+// totalScores returns each player's running total, keyed by player ID
+func totalScores(session *models.GameSession) map[string]int {
+	totals := make(map[string]int, len(session.Players))
+	for _, player := range session.Players {
+		totals[player.PlayerID] = player.TotalScore
+	}
+	return totals
+}
+
+// WithCompression compresses payloads of at least threshold bytes for clients that negotiated
+// permessage-deflate when their socket was upgraded
+func WithCompression(threshold int) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		if threshold <= 0 {
+			threshold = DefaultCompressionThreshold
+		}
+		w.compressionThreshold = threshold
+	}
+}
+
+// encodeEvent marshals an event for the socket, recording its size by event type
+func (w *WebSocketManagerImpl) encodeEvent(event WebSocketEvent) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	monitoring.GetGlobalMetricsCollector().NewHistogramWithBuckets("websocket_payload_bytes", "Size of event payloads written to WebSockets", map[string]string{
+		"type": event.Type,
+	}, payloadSizeBuckets).Observe(float64(len(data)))
+	return data, nil
+}
+
+// writeEvent writes an encoded event, compressing it if compression is on and it is large enough
+func (w *WebSocketManagerImpl) writeEvent(wsConn *websocket.Conn, data []byte) error {
+	if w.compressionThreshold > 0 {
+		wsConn.EnableWriteCompression(len(data) >= w.compressionThreshold)
+	}
+	return wsConn.WriteMessage(websocket.TextMessage, data)
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/models"
+	"dumdoors-backend/internal/monitoring"
+	"testing"
+)
+
+func TestJoinSession_BroadcastsPlayerInsteadOfSession(t *testing.T) {
+	gameSessionRepo := NewMockGameSessionRepository()
+	session := newDraftSession()
+	session.Status = models.GameStatusWaiting
+	session.Players[0].Responses = []models.PlayerResponse{{DoorID: "door-0", Content: "A long answer nobody else needs again"}}
+	gameSessionRepo.sessions["s1"] = session
+	wsManager := &broadcastRecordingWebSocketManager{MockWebSocketManager: NewMockWebSocketManager()}
+	gameService := NewGameService(gameSessionRepo, nil, NewMockPlayerPathRepository(), wsManager, &MockAIClient{}, nil, nil,
+		WithWorkerPool(inlineWorkerPool{}),
+	)
+
+	if _, err := gameService.JoinSession(context.Background(), "s1", "p3", "Carol", ""); err != nil {
+		t.Fatalf("JoinSession failed: %v", err)
+	}
+	if len(wsManager.broadcasts) != 1 || wsManager.broadcasts[0].Type != "player-joined" {
+		t.Fatalf("Expected a player-joined broadcast, got %+v", wsManager.broadcasts)
+	}
+	data := wsManager.broadcasts[0].Data.(map[string]interface{})
+	if _, embedded := data["session"]; embedded {
+		t.Error("Expected the whole session not to be embedded")
+	}
+	if player, ok := data["playerInfo"].(models.PlayerInfo); !ok || player.PlayerID != "p3" || data["playerCount"] != 3 {
+		t.Errorf("Expected the new player and the player count, got %+v", data)
+	}
+}
+
+func TestEncodeEvent_RecordsPayloadSize(t *testing.T) {
+	collector := monitoring.GetGlobalMetricsCollector()
+	before := collector.Total("websocket_payload_bytes")
+	manager := &WebSocketManagerImpl{}
+
+	data, err := manager.encodeEvent(WebSocketEvent{Type: "scores-updated", SessionID: "s1", Data: map[string]int{"p1": 80}})
+	if err != nil {
+		t.Fatalf("encodeEvent failed: %v", err)
+	}
+	if len(data) == 0 || collector.Total("websocket_payload_bytes") != before+1 {
+		t.Errorf("Expected the payload size to be observed once, got %d bytes", len(data))
+	}
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// WebSocketProtocolVersion is the newest version of the inbound message schema. Messages
+// without a version are read as this version.
+const WebSocketProtocolVersion = 1
+
+// Inbound message types
+const (
+	MessageTypeSubmitResponse  = "submit-response"
+	MessageTypeTypingIndicator = "typing-indicator"
+	MessageTypeReadyCheck      = "ready-check"
+	MessageTypePing            = "ping"
+	MessageTypeSaveDraft       = "save-draft"
+	MessageTypeChat            = "chat-message"
+	MessageTypeCastVote        = "cast-vote"
+	MessageTypeResync          = "resync"
+)
+
+// Codes carried by error frames
+const (
+	ErrorCodeMalformedMessage   = "malformed-message"
+	ErrorCodeUnsupportedVersion = "unsupported-version"
+	ErrorCodeUnknownMessageType = "unknown-message-type"
+	ErrorCodeInvalidMessage     = "invalid-message"
+	ErrorCodeUnavailable        = "unavailable"
+)
+
+// ProtocolError explains why an inbound message was refused before reaching its handler
+type ProtocolError struct {
+	Code    string
+	Message string
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// InboundMessage is a message a player sent over their socket. Every message is a JSON object
+// with a "type", an optional protocol version "v" and an optional "id" that replies echo back;
+// the remaining fields depend on the type.
+type InboundMessage struct {
+	Version int
+	Type    string
+	ID      string
+	Fields  map[string]interface{}
+}
+
+// fieldKind is the JSON type a message field must have
+type fieldKind string
+
+const (
+	fieldString fieldKind = "string"
+	fieldBool   fieldKind = "boolean"
+	fieldNumber fieldKind = "number"
+)
+
+// messageField describes one field of an inbound message
+type messageField struct {
+	name     string
+	kind     fieldKind
+	required bool
+}
+
+// messageSchemas lists the inbound message types and the fields each one carries
+var messageSchemas = map[string][]messageField{
+	MessageTypeSubmitResponse:  {{name: "response", kind: fieldString, required: true}},
+	MessageTypeTypingIndicator: {{name: "typing", kind: fieldBool, required: true}},
+	MessageTypeReadyCheck:      {{name: "ready", kind: fieldBool, required: true}},
+	MessageTypePing:            nil,
+	MessageTypeSaveDraft:       {{name: "response", kind: fieldString}},
+	MessageTypeChat:            {{name: "text", kind: fieldString, required: true}},
+	MessageTypeCastVote: {
+		{name: "responseId", kind: fieldString, required: true},
+		{name: "stars", kind: fieldNumber, required: true},
+	},
+	MessageTypeResync: {{name: "lastSeq", kind: fieldNumber, required: true}},
+}
+
+// ParseInboundMessage decodes and validates a raw socket message against its schema
+func ParseInboundMessage(data []byte) (*InboundMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil || fields == nil {
+		return nil, &ProtocolError{Code: ErrorCodeMalformedMessage, Message: "message must be a JSON object"}
+	}
+
+	msg := &InboundMessage{Version: WebSocketProtocolVersion, Fields: fields}
+	if raw, exists := fields["v"]; exists {
+		version, err := numberField(raw)
+		if err != nil || version < 1 || version != float64(int(version)) {
+			return nil, &ProtocolError{Code: ErrorCodeMalformedMessage, Message: "v must be a positive integer"}
+		}
+		msg.Version = int(version)
+	}
+	if raw, exists := fields["id"]; exists {
+		id, ok := raw.(string)
+		if !ok {
+			return nil, &ProtocolError{Code: ErrorCodeMalformedMessage, Message: "id must be a string"}
+		}
+		msg.ID = id
+	}
+
+	msgType, ok := fields["type"].(string)
+	if !ok || msgType == "" {
+		return msg, &ProtocolError{Code: ErrorCodeMalformedMessage, Message: "type is required"}
+	}
+	msg.Type = msgType
+
+	if msg.Version > WebSocketProtocolVersion {
+		return msg, &ProtocolError{Code: ErrorCodeUnsupportedVersion, Message: fmt.Sprintf("protocol version %d is not supported, the newest is %d", msg.Version, WebSocketProtocolVersion)}
+	}
+
+	schema, known := messageSchemas[msgType]
+	if !known {
+		return msg, &ProtocolError{Code: ErrorCodeUnknownMessageType, Message: fmt.Sprintf("unknown message type %q", msgType)}
+	}
+
+	for _, field := range schema {
+		value, exists := fields[field.name]
+		if !exists || value == nil {
+			if field.required {
+				return msg, &ProtocolError{Code: ErrorCodeInvalidMessage, Message: fmt.Sprintf("%s is required", field.name)}
+			}
+			continue
+		}
+		if !hasKind(value, field.kind) {
+			return msg, &ProtocolError{Code: ErrorCodeInvalidMessage, Message: fmt.Sprintf("%s must be a %s", field.name, field.kind)}
+		}
+		if field.kind == fieldNumber {
+			// Handlers read numbers the way encoding/json decodes them by default
+			fields[field.name], _ = numberField(value)
+		}
+	}
+
+	return msg, nil
+}
+
+// hasKind reports whether a decoded JSON value has the expected type
+func hasKind(value interface{}, kind fieldKind) bool {
+	switch kind {
+	case fieldString:
+		_, ok := value.(string)
+		return ok
+	case fieldBool:
+		_, ok := value.(bool)
+		return ok
+	case fieldNumber:
+		_, ok := value.(json.Number)
+		return ok
+	}
+	return false
+}
+
+// numberField converts a decoded JSON number to a float64
+func numberField(value interface{}) (float64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("not a number")
+	}
+	return number.Float64()
+}
+
+// routeMessage validates a raw message from a player and dispatches it: pings, typing
+// indicators, drafts and resyncs are handled by the manager, other types go to the handler
+// registered for them.
+// Messages that cannot be handled are answered with an error frame.
+func (w *WebSocketManagerImpl) routeMessage(sessionID, playerID string, data []byte) {
+	msg, err := ParseInboundMessage(data)
+	if err != nil {
+		w.sendErrorFrame(sessionID, playerID, msg, err.(*ProtocolError))
+		return
+	}
+
+	switch msg.Type {
+	case MessageTypePing:
+		w.sendPong(sessionID, playerID, msg)
+	case MessageTypeTypingIndicator:
+		w.broadcastTyping(sessionID, playerID, msg.Fields["typing"].(bool))
+	case MessageTypeSaveDraft:
+		// Drafts are private to the player, so they are saved rather than echoed
+		w.handleSaveDraft(sessionID, playerID, msg.Fields)
+	case MessageTypeResync:
+		w.handleResync(sessionID, playerID, msg)
+	default:
+		handler := w.messageHandler(msg.Type)
+		if handler == nil {
+			w.sendErrorFrame(sessionID, playerID, msg, &ProtocolError{Code: ErrorCodeUnavailable, Message: fmt.Sprintf("%s messages are not handled by this server", msg.Type)})
+			return
+		}
+		w.dispatchMessage(handler, sessionID, playerID, msg)
+	}
+}
+
+// sendErrorFrame tells a player why their message was refused
+func (w *WebSocketManagerImpl) sendErrorFrame(sessionID, playerID string, msg *InboundMessage, protoErr *ProtocolError) {
+	data := map[string]interface{}{
+		"code":    protoErr.Code,
+		"message": protoErr.Message,
+		"version": WebSocketProtocolVersion,
+	}
+	if msg != nil {
+		if msg.Type != "" {
+			data["type"] = msg.Type
+		}
+		if msg.ID != "" {
+			data["id"] = msg.ID
+		}
+	}
+
+	event := WebSocketEvent{
+		Type:      "error",
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	if err := w.SendToPlayer(playerID, event); err != nil {
+		log.Printf("Failed to send error frame to player %s: %v", playerID, err)
+	}
+}
+
+// sendPong answers an application-level ping so clients can measure latency without control frames
+func (w *WebSocketManagerImpl) sendPong(sessionID, playerID string, msg *InboundMessage) {
+	data := map[string]interface{}{"serverTime": time.Now()}
+	if msg.ID != "" {
+		data["id"] = msg.ID
+	}
+
+	event := WebSocketEvent{
+		Type:      "pong",
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	if err := w.SendToPlayer(playerID, event); err != nil {
+		log.Printf("Failed to send pong to player %s: %v", playerID, err)
+	}
+}
+
+// broadcastTyping tells the other players in a session that a player started or stopped typing,
+// dropping indicators that would repeat what the session already knows
+func (w *WebSocketManagerImpl) broadcastTyping(sessionID, playerID string, typing bool) {
+	if !w.typing.allow(playerID, typing, time.Now()) {
+		return
+	}
+
+	event := WebSocketEvent{
+		Type:      "player-typing",
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data: map[string]interface{}{
+			"playerId": playerID,
+			"typing":   typing,
+		},
+		Timestamp: time.Now(),
+	}
+	w.broadcastToOthers(sessionID, playerID, event)
+}
+
+// dispatchMessage runs a message handler and tells the player if their message was refused
+func (w *WebSocketManagerImpl) dispatchMessage(handler WebSocketMessageHandler, sessionID, playerID string, msg *InboundMessage) {
+	err := handler(context.Background(), sessionID, playerID, msg.Fields)
+	if err == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"type":    msg.Type,
+		"message": err.Error(),
+	}
+	if msg.ID != "" {
+		data["id"] = msg.ID
+	}
+
+	event := WebSocketEvent{
+		Type:      "message-rejected",
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	if err := w.SendToPlayer(playerID, event); err != nil {
+		log.Printf("Failed to reject %s message for player %s: %v", msg.Type, playerID, err)
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseInboundMessage_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		code string
+	}{
+		{"not json", `{"type":`, ErrorCodeMalformedMessage},
+		{"not an object", `["ping"]`, ErrorCodeMalformedMessage},
+		{"missing type", `{"v":1}`, ErrorCodeMalformedMessage},
+		{"fractional version", `{"v":1.5,"type":"ping"}`, ErrorCodeMalformedMessage},
+		{"future version", `{"v":2,"type":"ping"}`, ErrorCodeUnsupportedVersion},
+		{"unknown type", `{"type":"teleport"}`, ErrorCodeUnknownMessageType},
+		{"missing field", `{"type":"submit-response"}`, ErrorCodeInvalidMessage},
+		{"wrong field type", `{"type":"typing-indicator","typing":"yes"}`, ErrorCodeInvalidMessage},
+		{"valid", `{"v":1,"type":"cast-vote","id":"m1","responseId":"r1","stars":4}`, ""},
+		{"valid without version", `{"type":"ping"}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInboundMessage([]byte(tt.data))
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("expected a valid message, got %v", err)
+				}
+				return
+			}
+
+			var protoErr *ProtocolError
+			if !errors.As(err, &protoErr) {
+				t.Fatalf("expected a protocol error, got %v", err)
+			}
+			if protoErr.Code != tt.code {
+				t.Errorf("expected code %s, got %s", tt.code, protoErr.Code)
+			}
+		})
+	}
+}
+
+func TestParseInboundMessage_NumbersDecodeAsFloat(t *testing.T) {
+	msg, err := ParseInboundMessage([]byte(`{"type":"cast-vote","responseId":"r1","stars":4}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stars, ok := msg.Fields["stars"].(float64); !ok || stars != 4 {
+		t.Errorf("expected stars to decode as float64 4, got %#v", msg.Fields["stars"])
+	}
+	if msg.Version != WebSocketProtocolVersion {
+		t.Errorf("expected version %d, got %d", WebSocketProtocolVersion, msg.Version)
+	}
+}
+
+func TestRouteMessage_RepliesWithErrorFrame(t *testing.T) {
+	w := NewWebSocketManager().(*WebSocketManagerImpl)
+	conn := addLocalConnection(w, "s1", "p1")
+
+	w.routeMessage("s1", "p1", []byte(`{"type":"submit-response","id":"m7"}`))
+
+	event, _ := conn.queue.pop()
+	if event.Type != "error" {
+		t.Fatalf("expected an error frame, got %s", event.Type)
+	}
+	data := event.Data.(map[string]interface{})
+	if data["code"] != ErrorCodeInvalidMessage || data["id"] != "m7" {
+		t.Errorf("expected invalid-message for m7, got %v", data)
+	}
+}
+
+func TestRouteMessage_DispatchesToHandler(t *testing.T) {
+	w := NewWebSocketManager().(*WebSocketManagerImpl)
+	conn := addLocalConnection(w, "s1", "p1")
+
+	var submitted string
+	w.OnMessage(MessageTypeSubmitResponse, func(ctx context.Context, sessionID, playerID string, msg map[string]interface{}) error {
+		submitted = msg["response"].(string)
+		return nil
+	})
+
+	w.routeMessage("s1", "p1", []byte(`{"v":1,"type":"submit-response","response":"jump"}`))
+	if submitted != "jump" {
+		t.Errorf("expected the handler to receive the response, got %q", submitted)
+	}
+	if queueDepth(conn) != 0 {
+		t.Errorf("expected no reply for a handled message, got %d events", queueDepth(conn))
+	}
+
+	// Known types nobody handles are refused rather than dropped
+	w.routeMessage("s1", "p1", []byte(`{"type":"ready-check","ready":true}`))
+	event, _ := conn.queue.pop()
+	if data := event.Data.(map[string]interface{}); event.Type != "error" || data["code"] != ErrorCodeUnavailable {
+		t.Errorf("expected an unavailable error frame, got %s %v", event.Type, event.Data)
+	}
+}
+
+func TestRouteMessage_AnswersPingAndRelaysTyping(t *testing.T) {
+	w := NewWebSocketManager().(*WebSocketManagerImpl)
+	sender := addLocalConnection(w, "s1", "p1")
+	other := addLocalConnection(w, "s1", "p2")
+
+	w.routeMessage("s1", "p1", []byte(`{"type":"ping","id":"m1"}`))
+	pong, _ := sender.queue.pop()
+	if pong.Type != "pong" || pong.Data.(map[string]interface{})["id"] != "m1" {
+		t.Errorf("expected a pong for m1, got %s %v", pong.Type, pong.Data)
+	}
+
+	w.routeMessage("s1", "p1", []byte(`{"type":"typing-indicator","typing":true}`))
+	typing, _ := other.queue.pop()
+	if typing.Type != "player-typing" || typing.PlayerID != "p1" {
+		t.Errorf("expected p1's typing indicator, got %s from %s", typing.Type, typing.PlayerID)
+	}
+	if queueDepth(sender) != 0 {
+		t.Error("the typing player should not receive their own indicator")
+	}
+}
+
+func TestRouteMessage_ThrottlesTyping(t *testing.T) {
+	w := NewWebSocketManager(WithTypingThrottle(time.Hour)).(*WebSocketManagerImpl)
+	addLocalConnection(w, "s1", "p1")
+	other := addLocalConnection(w, "s1", "p2")
+
+	for _, typing := range []string{"true", "true", "true", "false", "false"} {
+		w.routeMessage("s1", "p1", []byte(`{"type":"typing-indicator","typing":`+typing+`}`))
+	}
+
+	var relayed []interface{}
+	for queueDepth(other) > 0 {
+		event, _ := other.queue.pop()
+		relayed = append(relayed, event.Data.(map[string]interface{})["typing"])
+	}
+	if len(relayed) != 2 || relayed[0] != true || relayed[1] != false {
+		t.Errorf("expected one start and one stop to be relayed, got %v", relayed)
+	}
+}
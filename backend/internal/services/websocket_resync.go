@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/repositories"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// EventResyncComplete follows the events replayed for a resync. When it reports the replay
+// incomplete, some missed events had already left the log and the client should reload the session.
+const EventResyncComplete = "resync-complete"
+
+// eventLogTimeout bounds each event log call, so a slow Redis delays broadcasts only briefly
+const eventLogTimeout = 2 * time.Second
+
+// WithEventLog numbers every session broadcast and keeps the recent ones, so clients can send a
+// "resync" message with the last sequence number they saw and receive what they missed
+func WithEventLog(eventLog repositories.SessionEventLog) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		w.eventLog = eventLog
+	}
+}
+
+// sequence gives a session broadcast its sequence number and logs it. Low-priority events are
+// superseded by the next update anyway, so they are neither numbered nor kept. If the log is
+// unavailable the event goes out unnumbered.
+func (w *WebSocketManagerImpl) sequence(sessionID string, event *WebSocketEvent) {
+	if w.eventLog == nil || priorityForEvent(event.Type) == PriorityLow {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode %s event for the event log: %v", event.Type, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), eventLogTimeout)
+	defer cancel()
+
+	seq, err := w.eventLog.Append(ctx, sessionID, payload)
+	if err != nil {
+		log.Printf("Failed to log %s event for session %s: %v", event.Type, sessionID, err)
+		return
+	}
+	event.Sequence = seq
+}
+
+// handleResync replays, in order, the session events a reconnecting player missed after the
+// last sequence number they saw, then reports how far the replay got. Live events may arrive
+// while the replay is sent, so clients ignore sequence numbers they have already seen.
+func (w *WebSocketManagerImpl) handleResync(sessionID, playerID string, msg *InboundMessage) {
+	if w.eventLog == nil {
+		w.sendErrorFrame(sessionID, playerID, msg, &ProtocolError{Code: ErrorCodeUnavailable, Message: "resync is not supported by this server"})
+		return
+	}
+	lastSeq := int64(msg.Fields["lastSeq"].(float64))
+
+	ctx, cancel := context.WithTimeout(context.Background(), eventLogTimeout)
+	defer cancel()
+
+	logged, latest, err := w.eventLog.Since(ctx, sessionID, lastSeq)
+	if err != nil {
+		log.Printf("Failed to resync player %s in session %s: %v", playerID, sessionID, err)
+		w.sendErrorFrame(sessionID, playerID, msg, &ProtocolError{Code: ErrorCodeUnavailable, Message: "missed events could not be loaded"})
+		return
+	}
+
+	// The replay is complete if it starts right after the client's last event. A client ahead
+	// of the log saw a sequence that has since expired and restarted.
+	complete := lastSeq == latest
+	if len(logged) > 0 {
+		complete = logged[0].Sequence == lastSeq+1
+	}
+
+	replayed := 0
+	for _, entry := range logged {
+		var event WebSocketEvent
+		if err := json.Unmarshal(entry.Payload, &event); err != nil {
+			log.Printf("Failed to decode logged event %d of session %s: %v", entry.Sequence, sessionID, err)
+			complete = false
+			continue
+		}
+		event.Sequence = entry.Sequence
+		if err := w.sendToLocalPlayer(playerID, event); err != nil {
+			log.Printf("Failed to replay event %d to player %s: %v", entry.Sequence, playerID, err)
+			return
+		}
+		replayed++
+	}
+
+	data := map[string]interface{}{
+		"lastSeq":  lastSeq,
+		"latest":   latest,
+		"replayed": replayed,
+		"complete": complete,
+	}
+	if msg.ID != "" {
+		data["id"] = msg.ID
+	}
+	event := WebSocketEvent{
+		Type:      EventResyncComplete,
+		SessionID: sessionID,
+		PlayerID:  playerID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	if err := w.sendToLocalPlayer(playerID, event); err != nil {
+		log.Printf("Failed to complete resync for player %s: %v", playerID, err)
+	}
+}
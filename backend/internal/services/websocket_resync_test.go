@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/repositories"
+	"testing"
+	"time"
+)
+
+// MockSessionEventLog keeps the last size events of each session in memory
+type MockSessionEventLog struct {
+	size   int
+	latest map[string]int64
+	events map[string][]repositories.SequencedEvent
+}
+
+func NewMockSessionEventLog(size int) *MockSessionEventLog {
+	return &MockSessionEventLog{
+		size:   size,
+		latest: make(map[string]int64),
+		events: make(map[string][]repositories.SequencedEvent),
+	}
+}
+
+func (m *MockSessionEventLog) Append(ctx context.Context, sessionID string, payload []byte) (int64, error) {
+	m.latest[sessionID]++
+	events := append(m.events[sessionID], repositories.SequencedEvent{Sequence: m.latest[sessionID], Payload: payload})
+	if len(events) > m.size {
+		events = events[len(events)-m.size:]
+	}
+	m.events[sessionID] = events
+	return m.latest[sessionID], nil
+}
+
+func (m *MockSessionEventLog) Since(ctx context.Context, sessionID string, after int64) ([]repositories.SequencedEvent, int64, error) {
+	var since []repositories.SequencedEvent
+	for _, event := range m.events[sessionID] {
+		if event.Sequence > after {
+			since = append(since, event)
+		}
+	}
+	return since, m.latest[sessionID], nil
+}
+
+// broadcastDoors broadcasts a door-presented event for each door ID
+func broadcastDoors(w *WebSocketManagerImpl, doorIDs ...string) {
+	for _, doorID := range doorIDs {
+		w.BroadcastToSession("s1", WebSocketEvent{Type: "door-presented", SessionID: "s1", Data: map[string]interface{}{"doorId": doorID}, Timestamp: time.Now()})
+	}
+}
+
+func TestBroadcastToSession_NumbersEventsAndSkipsLowPriority(t *testing.T) {
+	w := NewWebSocketManager(WithEventLog(NewMockSessionEventLog(10))).(*WebSocketManagerImpl)
+	conn := addLocalConnection(w, "s1", "p1")
+
+	broadcastDoors(w, "door-1")
+	w.BroadcastToSession("s1", WebSocketEvent{Type: "progress-update", SessionID: "s1"})
+	broadcastDoors(w, "door-2")
+
+	for _, want := range []int64{1, 0, 2} {
+		event, _ := conn.queue.pop()
+		if event.Sequence != want {
+			t.Errorf("Expected %s to be numbered %d, got %d", event.Type, want, event.Sequence)
+		}
+	}
+}
+
+func TestRouteMessage_ResyncReplaysMissedEvents(t *testing.T) {
+	w := NewWebSocketManager(WithEventLog(NewMockSessionEventLog(10))).(*WebSocketManagerImpl)
+	broadcastDoors(w, "door-1", "door-2", "door-3")
+	conn := addLocalConnection(w, "s1", "p1")
+
+	w.routeMessage("s1", "p1", []byte(`{"type":"resync","id":"r1","lastSeq":1}`))
+
+	for _, want := range []string{"door-2", "door-3"} {
+		event, _ := conn.queue.pop()
+		if event.Type != "door-presented" || event.Data.(map[string]interface{})["doorId"] != want {
+			t.Fatalf("Expected %s to be replayed, got %s %v", want, event.Type, event.Data)
+		}
+	}
+	done, _ := conn.queue.pop()
+	data := done.Data.(map[string]interface{})
+	if done.Type != EventResyncComplete || data["complete"] != true || data["replayed"] != 2 || data["id"] != "r1" {
+		t.Errorf("Expected a complete resync of 2 events, got %s %v", done.Type, data)
+	}
+}
+
+func TestRouteMessage_ResyncReportsEventsLostFromLog(t *testing.T) {
+	w := NewWebSocketManager(WithEventLog(NewMockSessionEventLog(2))).(*WebSocketManagerImpl)
+	broadcastDoors(w, "door-1", "door-2", "door-3", "door-4")
+	conn := addLocalConnection(w, "s1", "p1")
+
+	w.routeMessage("s1", "p1", []byte(`{"type":"resync","lastSeq":1}`))
+
+	if depth := queueDepth(conn); depth != 3 {
+		t.Fatalf("Expected the 2 logged events and the completion, got %d events", depth)
+	}
+	conn.queue.pop()
+	conn.queue.pop()
+	done, _ := conn.queue.pop()
+	if data := done.Data.(map[string]interface{}); data["complete"] != false {
+		t.Errorf("Expected the resync to be reported incomplete, got %v", data)
+	}
+}
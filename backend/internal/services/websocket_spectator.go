@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+// spectatorEvents lists the event types forwarded to spectators. Private events such as
+// drafts, typing indicators and player chat stay with the players.
+var spectatorEvents = map[string]bool{
+	"game-started":           true,
+	EventSessionStateChanged: true,
+	"door-presented":         true,
+	"response-submitted":     true,
+	EventAnswerProgress:      true,
+	"response-timeout":       true,
+	"scores-updated":         true,
+	EventResponsesRevealed:   true,
+	"player-score-update":    true,
+	"real-time-score-update": true,
+	"progress-update":        true,
+	"player-progress-update": true,
+	"player-position-update": true,
+	"player-status-update":   true,
+	"leaderboard-update":     true,
+	"player-joined":          true,
+	"player-kicked":          true,
+	"player-left":            true,
+	"achievement-unlocked":   true,
+	"host-transferred":       true,
+	"game-paused":            true,
+	"game-resumed":           true,
+	"player-ready":           true,
+	"ready-countdown":        true,
+	"spectator-joined":       true,
+	"game-completed":         true,
+	"final-rankings":         true,
+	"performance-statistics": true,
+	"server-draining":        true,
+}
+
+// isSpectatorEvent reports whether spectators should receive an event type
+func isSpectatorEvent(eventType string) bool {
+	return spectatorEvents[eventType]
+}
+
+// RegisterSpectator registers a read-only WebSocket connection watching a session
+func (w *WebSocketManagerImpl) RegisterSpectator(sessionID, spectatorID string, conn *websocket.Conn) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Stop writing to any connection this one replaces
+	if existing, exists := w.spectators[spectatorID]; exists {
+		existing.closeQueue()
+		w.removeSpectatorFromSession(existing.SessionID, spectatorID)
+	}
+
+	wsConn := &WebSocketConnection{
+		Conn:        conn,
+		PlayerID:    spectatorID,
+		SessionID:   sessionID,
+		LastSeen:    time.Now(),
+		IsActive:    true,
+		IsSpectator: true,
+	}
+	w.startWriter(wsConn)
+	w.trackPongs(wsConn)
+
+	w.spectators[spectatorID] = wsConn
+	w.sessionSpectators[sessionID] = append(w.sessionSpectators[sessionID], spectatorID)
+
+	log.Printf("WebSocket spectator %s registered for session %s", spectatorID, sessionID)
+
+	return nil
+}
+
+// UnregisterSpectator removes a spectator connection. Spectators have no game state to
+// restore, so they are dropped immediately rather than held for reconnection.
+func (w *WebSocketManagerImpl) UnregisterSpectator(spectatorID string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	conn, exists := w.spectators[spectatorID]
+	if !exists {
+		return fmt.Errorf("connection not found for spectator %s", spectatorID)
+	}
+
+	conn.mu.Lock()
+	conn.IsActive = false
+	conn.mu.Unlock()
+	conn.closeQueue()
+
+	delete(w.spectators, spectatorID)
+	w.removeSpectatorFromSession(conn.SessionID, spectatorID)
+
+	log.Printf("WebSocket spectator %s unregistered from session %s", spectatorID, conn.SessionID)
+
+	return nil
+}
+
+// GetSpectatorCount returns the number of spectators connected to a session
+func (w *WebSocketManagerImpl) GetSpectatorCount(sessionID string) int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return len(w.sessionSpectators[sessionID])
+}
+
+// HandleSpectatorConnection serves a spectator's socket until it closes. Anything the
+// spectator sends is ignored.
+func (w *WebSocketManagerImpl) HandleSpectatorConnection(c *websocket.Conn, sessionID, spectatorID string) {
+	if err := w.RegisterSpectator(sessionID, spectatorID, c); err != nil {
+		log.Printf("Failed to register WebSocket spectator: %v", err)
+		c.Close()
+		return
+	}
+
+	defer func() {
+		w.UnregisterSpectator(spectatorID)
+		c.Close()
+	}()
+
+	for {
+		if _, _, err := c.ReadMessage(); err != nil {
+			log.Printf("WebSocket read error for spectator %s: %v", spectatorID, err)
+			break
+		}
+	}
+}
+
+// broadcastToSpectators forwards a spectator-visible event to everyone watching a session.
+// Failures are logged so a spectator's connection never affects the players' broadcast.
+func (w *WebSocketManagerImpl) broadcastToSpectators(sessionID string, event WebSocketEvent) {
+	if !isSpectatorEvent(event.Type) {
+		return
+	}
+
+	w.mu.RLock()
+	spectatorIDs := append([]string(nil), w.sessionSpectators[sessionID]...)
+	w.mu.RUnlock()
+
+	for _, spectatorID := range spectatorIDs {
+		w.mu.RLock()
+		conn, exists := w.spectators[spectatorID]
+		w.mu.RUnlock()
+
+		if !exists {
+			continue
+		}
+		if err := w.sendToConnection(conn, event); err != nil {
+			log.Printf("Failed to send event to spectator %s: %v", spectatorID, err)
+		}
+	}
+}
+
+// removeSpectatorFromSession removes a spectator from a session's spectator list
+func (w *WebSocketManagerImpl) removeSpectatorFromSession(sessionID, spectatorID string) {
+	spectatorIDs := w.sessionSpectators[sessionID]
+	for i, id := range spectatorIDs {
+		if id == spectatorID {
+			w.sessionSpectators[sessionID] = append(spectatorIDs[:i], spectatorIDs[i+1:]...)
+			break
+		}
+	}
+
+	if len(w.sessionSpectators[sessionID]) == 0 {
+		delete(w.sessionSpectators, sessionID)
+	}
+}
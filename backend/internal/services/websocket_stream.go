@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+)
+
+// DefaultStreamKeepAlive is how often an idle event stream sends a comment, so proxies keep it open
+// and a client that has gone away is noticed
+const DefaultStreamKeepAlive = 15 * time.Second
+
+// ErrStreamClosed is returned by EventStream.Next once the stream has been closed or replaced
+var ErrStreamClosed = errors.New("event stream closed")
+
+// EventStream is a player's Server-Sent Events connection, for clients that can't hold a
+// WebSocket open. It joins the session exactly like a socket, so broadcasts and direct messages
+// reach it through the same send queue; the HTTP handler writes out whatever Next returns.
+type EventStream struct {
+	manager *WebSocketManagerImpl
+	conn    *WebSocketConnection
+	queue   *outboundQueue
+}
+
+// RegisterStream connects a player over Server-Sent Events, replacing any connection they held
+func (w *WebSocketManagerImpl) RegisterStream(sessionID, playerID string) (*EventStream, error) {
+	queue := newOutboundQueue(w.sendQueueSize)
+	conn := &WebSocketConnection{
+		PlayerID:  playerID,
+		SessionID: sessionID,
+		LastSeen:  time.Now(),
+		IsActive:  true,
+		queue:     queue,
+	}
+
+	w.mu.Lock()
+	w.addConnection(conn)
+	w.mu.Unlock()
+
+	log.Printf("Event stream registered for player %s in session %s", playerID, sessionID)
+	return &EventStream{manager: w, conn: conn, queue: queue}, nil
+}
+
+// Next waits up to timeout for the stream's next event. It returns nil if none arrived in time,
+// and ErrStreamClosed once the stream is closed, so the handler should end the response.
+func (s *EventStream) Next(timeout time.Duration) (*WebSocketEvent, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	event, ok, open := s.queue.popBefore(timer.C)
+	if ok {
+		return &event, nil
+	}
+	if !open {
+		return nil, ErrStreamClosed
+	}
+	return nil, nil
+}
+
+// Close disconnects the stream after its client has gone, unless another connection has
+// already replaced it
+func (s *EventStream) Close() {
+	w := s.manager
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if current, exists := w.connections[s.conn.PlayerID]; exists && current == s.conn {
+		w.markDisconnected(s.conn)
+		return
+	}
+	s.queue.close()
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// nextSessionEvent returns the stream's next event, skipping the player-connected announcements
+// sent in the background as streams register
+func nextSessionEvent(stream *EventStream) (*WebSocketEvent, error) {
+	for {
+		event, err := stream.Next(time.Second)
+		if err != nil || event == nil || event.Type != "player-connected" {
+			return event, err
+		}
+	}
+}
+
+func TestEventStream_ReceivesBroadcastsAndDirectMessages(t *testing.T) {
+	manager := NewWebSocketManager().(*WebSocketManagerImpl)
+	p1, _ := manager.RegisterStream("s1", "p1")
+	p2, _ := manager.RegisterStream("s1", "p2")
+
+	// p1 hears p2 connect, like a socket would
+	if event, err := p1.Next(time.Second); err != nil || event == nil || event.Type != "player-connected" || event.PlayerID != "p2" {
+		t.Fatalf("Expected p2's player-connected event, got %+v (err %v)", event, err)
+	}
+
+	broadcast := WebSocketEvent{Type: "door-presented", SessionID: "s1", Data: map[string]interface{}{"doorId": "door-1"}}
+	if err := manager.BroadcastToSession("s1", broadcast); err != nil {
+		t.Fatalf("BroadcastToSession failed: %v", err)
+	}
+	for _, stream := range []*EventStream{p1, p2} {
+		event, err := nextSessionEvent(stream)
+		if err != nil || event == nil || event.Type != "door-presented" || event.Data.(map[string]interface{})["doorId"] != "door-1" {
+			t.Errorf("Expected the broadcast event unchanged, got %+v (err %v)", event, err)
+		}
+	}
+
+	if err := manager.SendToPlayer("p2", WebSocketEvent{Type: "response-feedback", SessionID: "s1"}); err != nil {
+		t.Fatalf("SendToPlayer failed: %v", err)
+	}
+	if event, _ := nextSessionEvent(p2); event == nil || event.Type != "response-feedback" {
+		t.Errorf("Expected p2's direct message, got %+v", event)
+	}
+
+	// Idle streams time out without closing, so the handler can send a keep-alive
+	if event, err := p1.Next(10 * time.Millisecond); event != nil || err != nil {
+		t.Errorf("Expected an idle stream to time out, got %+v (err %v)", event, err)
+	}
+}
+
+func TestEventStream_ClosesWhenSessionEnds(t *testing.T) {
+	manager := NewWebSocketManager().(*WebSocketManagerImpl)
+	stream, _ := manager.RegisterStream("s1", "p1")
+
+	manager.BroadcastToSession("s1", WebSocketEvent{Type: "game-completed", SessionID: "s1"})
+	manager.CloseSession("s1")
+
+	// Events queued before the session closed are still delivered
+	if event, err := stream.Next(time.Second); err != nil || event == nil || event.Type != "game-completed" {
+		t.Fatalf("Expected the queued game-completed event, got %+v (err %v)", event, err)
+	}
+	if _, err := stream.Next(time.Second); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("Expected ErrStreamClosed after the session closed, got %v", err)
+	}
+}
+
+func TestEventStream_CloseKeepsReplacementConnection(t *testing.T) {
+	manager := NewWebSocketManager().(*WebSocketManagerImpl)
+	first, _ := manager.RegisterStream("s1", "p1")
+	second, _ := manager.RegisterStream("s1", "p1")
+
+	// The replaced stream ends, and closing it leaves the new one connected
+	if _, err := first.Next(time.Second); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("Expected the replaced stream to close, got %v", err)
+	}
+	first.Close()
+
+	if connections := manager.GetActiveConnections("s1"); len(connections) != 1 {
+		t.Fatalf("Expected the new stream to stay connected, got %d connections", len(connections))
+	}
+	if err := manager.SendToPlayer("p1", WebSocketEvent{Type: "scores-updated", SessionID: "s1"}); err != nil {
+		t.Fatalf("SendToPlayer failed: %v", err)
+	}
+	if event, _ := nextSessionEvent(second); event == nil || event.Type != "scores-updated" {
+		t.Errorf("Expected the new stream to receive events, got %+v", event)
+	}
+
+	second.Close()
+	if connections := manager.GetActiveConnections("s1"); len(connections) != 0 {
+		t.Errorf("Expected no active connections after the stream closed, got %d", len(connections))
+	}
+}
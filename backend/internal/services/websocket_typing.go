@@ -0,0 +1,68 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultTypingThrottle is how often a player who keeps typing is re-announced to the session
+const DefaultTypingThrottle = 3 * time.Second
+
+// typingState is the last typing indicator forwarded for a player
+type typingState struct {
+	typing bool
+	sentAt time.Time
+}
+
+// typingThrottle limits how often typing indicators are broadcast. Clients report every
+// keystroke burst; the session only hears when a player starts or stops typing, plus a
+// refresh every interval while they keep going so stale indicators can expire client-side.
+type typingThrottle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     map[string]typingState // playerID -> last forwarded state
+}
+
+// newTypingThrottle creates a throttle that re-announces ongoing typing once per interval
+func newTypingThrottle(interval time.Duration) *typingThrottle {
+	return &typingThrottle{
+		interval: interval,
+		last:     make(map[string]typingState),
+	}
+}
+
+// allow reports whether a typing indicator should be broadcast, recording it if so
+func (t *typingThrottle) allow(playerID string, typing bool, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last, exists := t.last[playerID]
+	if typing {
+		if exists && last.typing && now.Sub(last.sentAt) < t.interval {
+			return false
+		}
+		t.last[playerID] = typingState{typing: true, sentAt: now}
+		return true
+	}
+
+	// Only announce a stop if the session was told the player started
+	if !exists || !last.typing {
+		return false
+	}
+	delete(t.last, playerID)
+	return true
+}
+
+// forget drops a player's typing state, e.g. when they disconnect
+func (t *typingThrottle) forget(playerID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.last, playerID)
+}
+
+// WithTypingThrottle sets how often a player who keeps typing is re-announced to the session
+func WithTypingThrottle(interval time.Duration) WebSocketManagerOption {
+	return func(w *WebSocketManagerImpl) {
+		w.typing = newTypingThrottle(interval)
+	}
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/monitoring"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrWorkerPoolFull is returned when a task is rejected because the queue is at capacity
+var ErrWorkerPoolFull = errors.New("worker pool queue is full")
+
+// ErrWorkerPoolClosed is returned when a task is submitted after shutdown
+var ErrWorkerPoolClosed = errors.New("worker pool is shut down")
+
+// Default sizing for background game work
+const (
+	DefaultWorkerPoolSize      = 16
+	DefaultWorkerPoolQueueSize = 1024
+)
+
+// WorkerPool runs background tasks on a fixed number of goroutines
+type WorkerPool interface {
+	Submit(name string, task func()) error
+	Stats() WorkerPoolStats
+	Shutdown(ctx context.Context) error
+}
+
+// WorkerPoolStats is a snapshot of worker pool activity
+type WorkerPoolStats struct {
+	Name       string `json:"name"`
+	Workers    int    `json:"workers"`
+	QueueSize  int    `json:"queueSize"`
+	QueueDepth int    `json:"queueDepth"`
+	Submitted  uint64 `json:"submitted"`
+	Completed  uint64 `json:"completed"`
+	Rejected   uint64 `json:"rejected"`
+	Panics     uint64 `json:"panics"`
+}
+
+// workerTask is a unit of work queued on the pool
+type workerTask struct {
+	name string
+	fn   func()
+}
+
+// WorkerPoolImpl implements the WorkerPool interface
+type WorkerPoolImpl struct {
+	name    string
+	workers int
+	queue   chan workerTask
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+
+	statsMu   sync.Mutex
+	submitted uint64
+	completed uint64
+	rejected  uint64
+	panics    uint64
+
+	queueDepth    *monitoring.Gauge
+	rejectedCount *monitoring.Counter
+	panicCount    *monitoring.Counter
+}
+
+// NewWorkerPool creates a worker pool with the given number of workers and queue capacity
+func NewWorkerPool(name string, workers, queueSize int) WorkerPool {
+	if workers <= 0 {
+		workers = DefaultWorkerPoolSize
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultWorkerPoolQueueSize
+	}
+
+	collector := monitoring.GetGlobalMetricsCollector()
+	labels := map[string]string{"pool": name}
+
+	pool := &WorkerPoolImpl{
+		name:          name,
+		workers:       workers,
+		queue:         make(chan workerTask, queueSize),
+		queueDepth:    collector.NewGauge("worker_pool_queue_depth", "Number of tasks waiting in the worker pool queue", labels),
+		rejectedCount: collector.NewCounter("worker_pool_rejected_total", "Total number of tasks rejected by the worker pool", labels),
+		panicCount:    collector.NewCounter("worker_pool_panics_total", "Total number of worker pool tasks that panicked", labels),
+	}
+
+	for i := 0; i < workers; i++ {
+		pool.wg.Add(1)
+		go pool.worker()
+	}
+
+	return pool
+}
+
+// Submit queues a task without blocking, returning ErrWorkerPoolFull when the queue is at capacity
+func (p *WorkerPoolImpl) Submit(name string, task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrWorkerPoolClosed
+	}
+
+	select {
+	case p.queue <- workerTask{name: name, fn: task}:
+		p.statsMu.Lock()
+		p.submitted++
+		p.statsMu.Unlock()
+		p.queueDepth.Set(float64(len(p.queue)))
+		return nil
+	default:
+		p.statsMu.Lock()
+		p.rejected++
+		p.statsMu.Unlock()
+		p.rejectedCount.Inc()
+		return fmt.Errorf("%w: dropped task %s", ErrWorkerPoolFull, name)
+	}
+}
+
+// Stats returns a snapshot of the pool counters
+func (p *WorkerPoolImpl) Stats() WorkerPoolStats {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+
+	return WorkerPoolStats{
+		Name:       p.name,
+		Workers:    p.workers,
+		QueueSize:  cap(p.queue),
+		QueueDepth: len(p.queue),
+		Submitted:  p.submitted,
+		Completed:  p.completed,
+		Rejected:   p.rejected,
+		Panics:     p.panics,
+	}
+}
+
+// Shutdown stops accepting tasks and waits for queued tasks to drain
+func (p *WorkerPoolImpl) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("worker pool %s did not drain: %w", p.name, ctx.Err())
+	}
+}
+
+// worker executes tasks from the queue until it is closed
+func (p *WorkerPoolImpl) worker() {
+	defer p.wg.Done()
+
+	for task := range p.queue {
+		p.queueDepth.Set(float64(len(p.queue)))
+		p.run(task)
+	}
+}
+
+// run executes a single task, recovering from panics so one bad task cannot kill a worker
+func (p *WorkerPoolImpl) run(task workerTask) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Error: worker pool %s task %s panicked: %v\n", p.name, task.name, r)
+			p.statsMu.Lock()
+			p.panics++
+			p.statsMu.Unlock()
+			p.panicCount.Inc()
+		}
+		p.statsMu.Lock()
+		p.completed++
+		p.statsMu.Unlock()
+	}()
+
+	task.fn()
+}
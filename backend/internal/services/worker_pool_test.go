@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"dumdoors-backend/internal/tracing"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_RunsSubmittedTasks(t *testing.T) {
+	pool := NewWorkerPool("test-run", 4, 16)
+
+	var wg sync.WaitGroup
+	var ran int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		if err := pool.Submit("task", func() {
+			defer wg.Done()
+			atomic.AddInt32(&ran, 1)
+		}); err != nil {
+			t.Fatalf("Unexpected submit error: %v", err)
+		}
+	}
+	wg.Wait()
+
+	if ran != 10 {
+		t.Errorf("Expected 10 tasks to run, got %d", ran)
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+
+	stats := pool.Stats()
+	if stats.Submitted != 10 || stats.Completed != 10 {
+		t.Errorf("Expected 10 submitted and completed, got %d and %d", stats.Submitted, stats.Completed)
+	}
+}
+
+func TestWorkerPool_RejectsWhenQueueFull(t *testing.T) {
+	pool := NewWorkerPool("test-reject", 1, 1)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	// Occupy the only worker
+	pool.Submit("blocker", func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	// Fill the queue
+	if err := pool.Submit("queued", func() {}); err != nil {
+		t.Fatalf("Expected queued task to be accepted, got %v", err)
+	}
+
+	// Next submission should be rejected
+	err := pool.Submit("overflow", func() {})
+	if !errors.Is(err, ErrWorkerPoolFull) {
+		t.Fatalf("Expected ErrWorkerPoolFull, got %v", err)
+	}
+
+	stats := pool.Stats()
+	if stats.Rejected != 1 {
+		t.Errorf("Expected 1 rejected task, got %d", stats.Rejected)
+	}
+	if stats.QueueDepth != 1 {
+		t.Errorf("Expected queue depth 1, got %d", stats.QueueDepth)
+	}
+
+	close(release)
+	pool.Shutdown(context.Background())
+}
+
+func TestWorkerPool_RecoversFromPanics(t *testing.T) {
+	pool := NewWorkerPool("test-panic", 1, 4)
+
+	pool.Submit("panics", func() {
+		panic("boom")
+	})
+
+	done := make(chan struct{})
+	pool.Submit("after-panic", func() {
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Worker did not survive a panicking task")
+	}
+
+	pool.Shutdown(context.Background())
+
+	if pool.Stats().Panics != 1 {
+		t.Errorf("Expected 1 recorded panic, got %d", pool.Stats().Panics)
+	}
+}
+
+func TestWorkerPool_RejectsAfterShutdown(t *testing.T) {
+	pool := NewWorkerPool("test-closed", 1, 1)
+	pool.Shutdown(context.Background())
+
+	if err := pool.Submit("late", func() {}); !errors.Is(err, ErrWorkerPoolClosed) {
+		t.Errorf("Expected ErrWorkerPoolClosed, got %v", err)
+	}
+}
+
+func TestRunInBackground_DetachesFromRequestContext(t *testing.T) {
+	gameService := NewGameService(NewMockGameSessionRepository(), nil, NewMockPlayerPathRepository(), nil, nil, nil, nil,
+		WithWorkerPool(NewWorkerPool("test-detached", 1, 1)),
+		WithBackgroundTimeout(time.Second),
+	).(*GameServiceImpl)
+
+	requestCtx, cancel := context.WithCancel(tracing.WithRequestID(context.Background(), "req-123"))
+	cancel() // the request has already finished
+
+	done := make(chan struct{})
+	var taskErr error
+	var trace tracing.Trace
+	var deadline time.Time
+	gameService.runInBackground(requestCtx, "session-1", "test-task", func(ctx context.Context) {
+		taskErr = ctx.Err()
+		trace = tracing.FromContext(ctx)
+		deadline, _ = ctx.Deadline()
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Background task did not run")
+	}
+
+	if taskErr != nil {
+		t.Errorf("Expected background context to outlive the request, got %v", taskErr)
+	}
+	if trace.RequestID != "req-123" || trace.SessionID != "session-1" || trace.Operation != "test-task" {
+		t.Errorf("Expected trace to carry request, session and operation, got %+v", trace)
+	}
+	if deadline.IsZero() || time.Until(deadline) > time.Second {
+		t.Errorf("Expected background context to have its own timeout, got deadline %v", deadline)
+	}
+}
@@ -0,0 +1,104 @@
+// Package tracing carries request, session and player identifiers through contexts so
+// background work can be correlated with the request that started it.
+package tracing
+
+import (
+	"context"
+	"dumdoors-backend/internal/logging"
+	"time"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// DefaultDetachedTimeout bounds background work started from a request
+const DefaultDetachedTimeout = 30 * time.Second
+
+// Trace identifies the request and game entities a unit of work belongs to
+type Trace struct {
+	RequestID string `json:"requestId,omitempty"`
+	SessionID string `json:"sessionId,omitempty"`
+	PlayerID  string `json:"playerId,omitempty"`
+	Operation string `json:"operation,omitempty"`
+}
+
+type traceKey struct{}
+
+// With returns a context carrying trace, keeping any identifiers trace leaves empty
+func With(ctx context.Context, trace Trace) context.Context {
+	current := FromContext(ctx)
+	if trace.RequestID != "" {
+		current.RequestID = trace.RequestID
+	}
+	if trace.SessionID != "" {
+		current.SessionID = trace.SessionID
+	}
+	if trace.PlayerID != "" {
+		current.PlayerID = trace.PlayerID
+	}
+	if trace.Operation != "" {
+		current.Operation = trace.Operation
+	}
+	return context.WithValue(ctx, traceKey{}, current)
+}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return With(ctx, Trace{RequestID: requestID})
+}
+
+// WithSessionID returns a context carrying the game session ID
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return With(ctx, Trace{SessionID: sessionID})
+}
+
+// WithPlayerID returns a context carrying the player ID
+func WithPlayerID(ctx context.Context, playerID string) context.Context {
+	return With(ctx, Trace{PlayerID: playerID})
+}
+
+// FromContext returns the trace carried by ctx, or an empty trace
+func FromContext(ctx context.Context) Trace {
+	if ctx == nil {
+		return Trace{}
+	}
+	if trace, ok := ctx.Value(traceKey{}).(Trace); ok {
+		return trace
+	}
+	return Trace{}
+}
+
+// Carry copies the trace from parent into a fresh background context. The result keeps
+// the identifiers and the current span, so background spans join the request's trace, but
+// none of parent's cancellation, deadline or other values, so it is safe to hold after the
+// originating request has finished and its context is recycled.
+func Carry(parent context.Context) context.Context {
+	ctx := context.WithValue(context.Background(), traceKey{}, FromContext(parent))
+	return oteltrace.ContextWithSpanContext(ctx, oteltrace.SpanContextFromContext(parent))
+}
+
+// Detach starts a context for background work spawned from parent. It carries parent's
+// trace, names the operation, and is bounded by its own timeout instead of parent's
+// lifetime. Callers must call the returned cancel function when the work is done.
+func Detach(parent context.Context, operation string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = DefaultDetachedTimeout
+	}
+	ctx := With(Carry(parent), Trace{Operation: operation})
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Logger returns a structured logger annotated with the trace carried by ctx
+func Logger(ctx context.Context) *logging.ContextLogger {
+	trace := FromContext(ctx)
+	logger := logging.GetLogger().WithRequestID(trace.RequestID)
+	if trace.SessionID != "" {
+		logger = logger.WithSession(trace.SessionID)
+	}
+	if trace.PlayerID != "" {
+		logger = logger.WithPlayer(trace.PlayerID)
+	}
+	if trace.Operation != "" {
+		logger = logger.WithOperation(trace.Operation)
+	}
+	return logger
+}
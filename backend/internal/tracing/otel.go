@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"dumdoors-backend/internal/config"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies the spans created by this service
+const TracerName = "dumdoors-backend"
+
+// Span attribute keys for the game entities a span works on
+const (
+	RequestIDKey = attribute.Key("dumdoors.request_id")
+	SessionIDKey = attribute.Key("dumdoors.session_id")
+	PlayerIDKey  = attribute.Key("dumdoors.player_id")
+)
+
+// Setup installs the global tracer provider and W3C trace context propagation. Spans are
+// exported over OTLP/HTTP when an endpoint is configured and are otherwise not recorded.
+// The returned function flushes pending spans and stops the exporter.
+func Setup(ctx context.Context, cfg config.TracingConfig, environment string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+		attribute.String("deployment.environment", environment),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the service's tracer from the global provider
+func Tracer() oteltrace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan starts a span as a child of any span in ctx, tagged with the request, session
+// and player the context carries
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return Tracer().Start(ctx, name, oteltrace.WithAttributes(append(Attributes(ctx), attrs...)...))
+}
+
+// Attributes returns span attributes for the identifiers carried by ctx
+func Attributes(ctx context.Context) []attribute.KeyValue {
+	trace := FromContext(ctx)
+
+	var attrs []attribute.KeyValue
+	if trace.RequestID != "" {
+		attrs = append(attrs, RequestIDKey.String(trace.RequestID))
+	}
+	if trace.SessionID != "" {
+		attrs = append(attrs, SessionIDKey.String(trace.SessionID))
+	}
+	if trace.PlayerID != "" {
+		attrs = append(attrs, PlayerIDKey.String(trace.PlayerID))
+	}
+	return attrs
+}
+
+// EndSpan records err on the span, if there is one, and ends it
+func EndSpan(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -13,9 +14,12 @@ import (
 	"dumdoors-backend/internal/handlers"
 	"dumdoors-backend/internal/logging"
 	"dumdoors-backend/internal/middleware"
+	"dumdoors-backend/internal/models"
 	"dumdoors-backend/internal/monitoring"
+	"dumdoors-backend/internal/random"
 	"dumdoors-backend/internal/repositories"
 	"dumdoors-backend/internal/services"
+	"dumdoors-backend/internal/tracing"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -28,18 +32,37 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	// Load configuration
+	// Load configuration, refusing to start on missing or invalid values
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+	// The mock provider stands in for the AI service in deterministic mode
+	if !cfg.Deterministic() {
+		if err := cfg.CheckAIServiceReachable(context.Background(), 5*time.Second); err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	// Initialize structured logging
-	logLevel := logging.LevelInfo
-	if cfg.Environment == "development" {
-		logLevel = logging.LevelDebug
-	}
-	logging.InitializeLogger("dumdoors-backend", "1.0.0", logLevel)
+	logging.InitializeLogger("dumdoors-backend", "1.0.0", logging.LogLevel(cfg.LogLevel))
 	logger := logging.GetLogger()
 
 	logger.Info("Starting DumDoors backend service")
+	
+	// Initialize OpenTelemetry tracing; spans are exported only when an OTLP endpoint is set
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.Environment)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	// Seeded mode makes IDs, jitter and AI results reproducible for end-to-end tests and demos
+	var aiClientOpts []services.AIClientOption
+	if cfg.Deterministic() {
+		random.Seed(cfg.DeterministicSeed)
+		aiClientOpts = append(aiClientOpts, services.WithMockProvider())
+		logger.Info(fmt.Sprintf("Deterministic mode enabled with seed %d; AI calls use the mock provider", cfg.DeterministicSeed))
+	}
 
 	// Initialize metrics collection
 	metricsCollector := monitoring.GetGlobalMetricsCollector()
@@ -56,28 +79,364 @@ func main() {
 		log.Fatalf("Failed to initialize database manager: %v", err)
 	}
 	defer dbManager.Close()
+	
+	// Export connection pool utilization
+	go dbManager.StartPoolMetricsCollection(ctx, 15*time.Second)
 
 	// Initialize repositories
-	gameSessionRepo := repositories.NewGameSessionRepository(dbManager.MongoDB, dbManager.Redis)
+	// MongoDB and Neo4j calls go through named circuit breakers so an outage fails fast, with
+	// sessions served from the Redis cache and players given the default path in the meantime
+	mongoBreaker := middleware.GetCircuitBreaker(repositories.MongoDBBreaker)
+	neo4jBreaker := middleware.GetCircuitBreaker(repositories.Neo4jBreaker)
+	gameSessionRepo := repositories.NewCircuitBreakingSessionRepository(
+		repositories.NewGameSessionRepository(dbManager.MongoDB, dbManager.Redis),
+		mongoBreaker,
+		repositories.NewSessionCache(repositories.NewRedisSessionCacheStore(dbManager.Redis), repositories.DefaultSessionCacheTTL),
+	)
+	// Single-instance deployments can serve active sessions from an in-memory snapshot to cut
+	// MongoDB reads during rounds. It never hears of writes made by other instances, so it is
+	// off unless SESSION_SNAPSHOT_CACHE is set.
+	if cfg.SessionSnapshotCache {
+		gameSessionRepo = repositories.NewSessionSnapshotRepository(ctx, gameSessionRepo, repositories.DefaultSnapshotIdleTTL)
+	}
 	doorRepo := repositories.NewDoorRepository(dbManager.MongoDB, dbManager.Redis)
-	playerPathRepo := repositories.NewPlayerPathRepository(dbManager.Neo4j)
+	playerPathRepo := repositories.NewCircuitBreakingPlayerPathRepository(repositories.NewPlayerPathRepository(dbManager.Neo4j), neo4jBreaker)
 	leaderboardRepo := repositories.NewLeaderboardRepository(dbManager.MongoDB, dbManager.Redis)
 
 	// Initialize services
-	wsManager := services.NewWebSocketManager()
-	aiClient := services.NewAIClient(cfg.AIServiceURL, dbManager.Redis) // Use basic AI client
-	progressService := services.NewProgressService(gameSessionRepo, playerPathRepo, wsManager)
-	leaderboardService := services.NewLeaderboardService(leaderboardRepo, gameSessionRepo)
-	gameService := services.NewGameService(gameSessionRepo, doorRepo, playerPathRepo, wsManager, aiClient, progressService, leaderboardService)
-	devvitService := services.NewDevvitIntegration()
+	draftService := services.NewDraftService(gameSessionRepo, repositories.NewResponseDraftStore(dbManager.Redis, repositories.DefaultDraftTTL))
+	// Public session events are recorded so finished games can be replayed
+	replayService := services.NewReplayService(repositories.NewReplayRepository(dbManager.MongoDB), gameSessionRepo, cfg.ReplayBufferSize)
+	go replayService.Start(ctx)
+	// Door, response and score timings are logged per session so disputes can be settled
+	historyService := services.NewSessionHistoryService(repositories.NewSessionEventRepository(dbManager.MongoDB), gameSessionRepo)
+	// Sticky routing tokens and fan-out messages name the instance they come from
+	instanceID := cfg.InstanceID
+	if instanceID == "" {
+		instanceID = random.ID()
+	}
+	// Sessions are pinned to the instance they were created on; load balancers and clients
+	// follow connection tokens there while games move to multiple instances. Every instance
+	// checks the tokens, so they are signed with the shared ROUTING_SECRET.
+	routingSecret := []byte(cfg.RoutingSecret)
+	if len(routingSecret) == 0 {
+		if routingSecret, err = services.GenerateTokenSecret(); err != nil {
+			log.Fatalf("Failed to generate routing secret: %v", err)
+		}
+		logger.Warn("ROUTING_SECRET not set; connection tokens are only valid on this instance")
+	}
+	routingService := services.NewRoutingService(instanceID, repositories.NewSessionRouteStore(dbManager.Redis, repositories.DefaultSessionRouteTTL), routingSecret, cfg.WSStickyRouting)
+	// Held routes are renewed while this instance is alive, so those of a dead instance expire
+	go routingService.Start(ctx)
+	wsOpts := []services.WebSocketManagerOption{
+		services.WithSendQueueSize(cfg.WSSendQueueSize),
+		services.WithOverflowPolicy(services.OverflowPolicy(cfg.WSOverflowPolicy)),
+		services.WithWriteTimeout(cfg.WSWriteTimeout),
+		services.WithHeartbeat(cfg.WSPingInterval, cfg.WSMaxMissedPongs),
+		services.WithDraftService(draftService),
+		// Broadcasts reach players connected to any backend instance
+		services.WithEventBus(repositories.NewEventBus(dbManager.Redis)),
+		services.WithInstanceID(instanceID),
+		services.WithReplayRecorder(replayService),
+	}
+	// Large payloads are deflated for clients that negotiate permessage-deflate on upgrade
+	if cfg.WSCompression {
+		wsOpts = append(wsOpts, services.WithCompression(cfg.WSCompressionThreshold))
+	}
+	// Reconnecting clients catch up on the session events they missed; zero turns this off
+	if cfg.WSResyncBufferSize > 0 {
+		wsOpts = append(wsOpts, services.WithEventLog(repositories.NewSessionEventLog(dbManager.Redis, cfg.WSResyncBufferSize, repositories.DefaultSessionEventLogTTL)))
+	}
+	wsManager := services.NewWebSocketManager(wsOpts...)
+	go wsManager.StartFanout(ctx)
+	// With AI_TRANSPORT=grpc doors and scores go over gRPC, falling back to HTTP when it is unavailable
+	if cfg.AITransport == "grpc" {
+		aiTransport, err := services.NewAIGRPCTransport(cfg.AIGRPCAddr,
+			services.WithAIGRPCPoolSize(cfg.AIGRPCPoolSize),
+			services.WithAIGRPCDeadline(cfg.AIGRPCDeadline),
+		)
+		if err != nil {
+			log.Fatalf("Failed to initialize AI service gRPC transport: %v", err)
+		}
+		defer aiTransport.Close()
+		aiClientOpts = append(aiClientOpts, services.WithGRPCTransport(aiTransport))
+	}
+	aiClient := services.NewAIClient(cfg.AIServiceURL, dbManager.Redis, aiClientOpts...) // Use basic AI client
+	// Games fall back to mock doors and scores without the AI service, so losing it only degrades the instance
+	dbManager.AddHealthProbe(database.DependencyProbe{
+		Name:     "ai_service",
+		Optional: true,
+		Check: func(ctx context.Context) error {
+			_, err := aiClient.HealthCheck(ctx)
+			return err
+		},
+	})
+	progressService := services.NewProgressService(gameSessionRepo, playerPathRepo, wsManager, services.WithProgressBroadcastInterval(cfg.ProgressBroadcastInterval))
+	// Global leaderboards are materialized into Redis so reads never hit MongoDB
+	leaderboardMaterializer := services.NewLeaderboardMaterializer(
+		leaderboardRepo,
+		repositories.NewLeaderboardSnapshotStore(dbManager.Redis, 3*cfg.LeaderboardRefreshInterval),
+		cfg.LeaderboardRefreshInterval,
+	)
+	go leaderboardMaterializer.Start(ctx)
+	// Career statistics are updated as each game completes
+	profileService := services.NewProfileService(repositories.NewPlayerProfileRepository(dbManager.MongoDB))
+	achievementService := services.NewAchievementService(repositories.NewAchievementRepository(dbManager.MongoDB), wsManager)
+	friendService := services.NewFriendService(repositories.NewFriendRepository(dbManager.MongoDB))
+	// Door graphs are seeded into Neo4j as games start so players walk a real short and long path
+	doorGraphRepo := repositories.NewCircuitBreakingDoorGraphRepository(repositories.NewDoorGraphRepository(dbManager.Neo4j), neo4jBreaker)
+	pathGraphService := services.NewPathGraphService(doorRepo, doorGraphRepo)
+	// Sessions are played in themes from the catalog, seeded with the built-in themes
+	themeService := services.NewThemeService(repositories.NewThemeRepository(dbManager.MongoDB))
+	if err := themeService.SeedDefaults(ctx); err != nil {
+		log.Printf("Warning: failed to seed default themes: %v", err)
+	}
+	// Door difficulty is recalibrated from the scores each door receives
+	doorScoreRepo := repositories.NewDoorScoreRepository(dbManager.MongoDB)
+	doorCalibrator := services.NewDoorCalibrator(doorRepo, doorScoreRepo, cfg.DoorCalibrationInterval, cfg.DoorCalibrationMinScores)
+	go doorCalibrator.Start(ctx)
+	// Players whose answers arrive faster than anyone could read the door are flagged for review
+	// and shadow-excluded from the global leaderboards until an admin clears them
+	playerActivityRepo := repositories.NewPlayerActivityRepository(dbManager.MongoDB)
+	leaderboardService := services.NewLeaderboardService(leaderboardRepo, gameSessionRepo,
+		services.WithLeaderboardMaterializer(leaderboardMaterializer),
+		services.WithFriendService(friendService),
+		services.WithShadowedPlayers(playerActivityRepo),
+	)
+	anomalyService := services.NewAnomalyService(services.AnomalyConfig{
+		FastThreshold: cfg.AnomalyFastThreshold,
+		FastLimit:     cfg.AnomalyFastLimit,
+		Window:        cfg.AnomalyWindow,
+	}, playerActivityRepo, leaderboardService)
+	workerPool := services.NewWorkerPool("game", cfg.WorkerPoolSize, cfg.WorkerPoolQueueSize)
+	var scoringQueueOpts []services.ScoringQueueOption
+	if cfg.AIScoringStreaming {
+		scoringQueueOpts = append(scoringQueueOpts, services.WithStreamingScores())
+	}
+	scoringQueue := services.NewScoringQueue(aiClient, wsManager, cfg.AIScoringConcurrency, cfg.AIScoringRatePerSec, scoringQueueOpts...)
+	// Responses are scored in batches off the request path; the queue is the fallback when the backlog is full.
+	// Streamed scores are relayed metric by metric, so with AI_SCORING_STREAMING every response goes through the queue.
+	var scoringBatcher services.ScoringBatcher
+	if !cfg.AIScoringStreaming {
+		scoringBatcher = services.NewScoringBatcher(aiClient, cfg.AIScoringBatchSize, cfg.AIScoringConcurrency,
+			services.WithScoringBatchWindow(cfg.AIScoringBatchWindow),
+		)
+		go scoringBatcher.Start(ctx)
+	}
+	// Door deadlines live in Redis so they survive restarts and fire once across instances
+	deadlineScheduler := services.NewPersistentScheduler(repositories.NewDeadlineStore(dbManager.Redis), cfg.SchedulerPollInterval)
+	// Responses are screened for offensive content before scoring; interventions are kept for admin review
+	moderationConfig := services.ModerationConfig{
+		Denylist:         cfg.ModerationDenylist,
+		RejectPatterns:   cfg.ModerationRejectPatterns,
+		RejectDenylisted: cfg.ModerationMode == "reject",
+		UseAI:            cfg.ModerationAIEnabled,
+	}
+	if len(moderationConfig.Denylist) == 0 {
+		moderationConfig.Denylist = services.DefaultDenylist
+	}
+	moderationService := services.NewModerationService(moderationConfig, aiClient, repositories.NewModerationEventRepository(dbManager.MongoDB))
+	// Finished games are posted back to their Reddit post; updates Devvit never accepts are kept for replay.
+	// Requests vouching for a Reddit user must be signed by the Devvit app with DEVVIT_REQUEST_SECRET.
+	devvitOptions := []services.DevvitOption{
+		services.WithPostCallback(cfg.DevvitCallbackURL, cfg.DevvitCallbackSecret, repositories.NewPostUpdateDeadLetterRepository(dbManager.MongoDB)),
+		services.WithRequestSigning(cfg.DevvitRequestSecret),
+	}
+	if cfg.DevvitRequestSecret == "" {
+		if cfg.Environment != "development" {
+			log.Fatalf("DEVVIT_REQUEST_SECRET must be set outside development")
+		}
+		logger.Warn("DEVVIT_REQUEST_SECRET not set; accepting unsigned Devvit requests")
+		devvitOptions = append(devvitOptions, services.WithUnsignedRequests())
+	}
+	devvitService := services.NewDevvitIntegration(devvitOptions...)
+	// SCORING_STRATEGY picks how AI metrics become a score for sessions that don't choose their own
+	scoringStrategy, err := services.NewScoringStrategy(models.ScoringStrategy(cfg.ScoringStrategy))
+	if err != nil {
+		log.Fatalf("Invalid scoring strategy: %v", err)
+	}
+	// EXPERIMENTS lists the A/B experiments new sessions are bucketed into
+	experiments, err := services.SelectExperiments(cfg.Experiments)
+	if err != nil {
+		log.Fatalf("Invalid experiments: %v", err)
+	}
+	// Doors are pre-generated into per-theme pools so moving to a new door never waits on the AI
+	// service; the bank's client skips the response cache, which would hand back the same door
+	var doorBank services.DoorBankService
+	if cfg.DoorBankSize > 0 {
+		doorBank = services.NewDoorBankService(
+			repositories.NewDoorBank(dbManager.Redis),
+			services.NewAIClient(cfg.AIServiceURL, nil, aiClientOpts...),
+			themeService,
+			cfg.DoorBankSize,
+			cfg.DoorBankRefillInterval,
+			services.WithDoorBankLocales(cfg.DoorBankLocales...),
+			services.WithDoorBankDeduplicator(services.NewDoorDeduplicator(doorRepo, cfg.DoorSimilarityThreshold)),
+		)
+		go doorBank.Start(ctx)
+	}
+	auditService := services.NewAuditService(repositories.NewAuditEventRepository(dbManager.MongoDB))
+	// Responses repeating a player's earlier answers or copying another player's are penalized or
+	// rejected; each player's recent answers are fingerprinted in Redis to catch reuse across games
+	var antiCheat services.AntiCheatService
+	if cfg.AntiCheatMode != "off" {
+		antiCheat = services.NewAntiCheatService(services.AntiCheatConfig{
+			Reject:    cfg.AntiCheatMode == "reject",
+			Penalty:   cfg.AntiCheatPenalty,
+			Threshold: cfg.AntiCheatThreshold,
+		}, repositories.NewFingerprintStore(dbManager.Redis, repositories.DefaultFingerprintHistoryTTL), auditService)
+	}
+	// Unfinished sessions are counted per subreddit and per player in Redis; admins can change
+	// the limits at runtime, these are only the defaults
+	sessionQuota := services.NewSessionQuotaService(repositories.NewSessionQuotaStore(dbManager.Redis, repositories.DefaultSessionQuotaTTL), models.SessionLimits{
+		MaxActivePerSubreddit: cfg.SessionLimitPerSubreddit,
+		MaxActivePerPlayer:    cfg.SessionLimitPerPlayer,
+	})
+	gameService := services.NewGameService(gameSessionRepo, doorRepo, playerPathRepo, wsManager, aiClient, progressService, leaderboardService,
+		services.WithWorkerPool(workerPool),
+		services.WithScoringQueue(scoringQueue),
+		services.WithScoringBatcher(scoringBatcher),
+		services.WithBackgroundTimeout(cfg.BackgroundTaskTimeout),
+		services.WithDeadlineScheduler(deadlineScheduler),
+		services.WithModerationService(moderationService),
+		services.WithJoinCodes(repositories.NewJoinCodeStore(dbManager.Redis, repositories.DefaultJoinCodeTTL)),
+		services.WithOpenSessionCache(repositories.NewOpenSessionCache(dbManager.Redis, repositories.DefaultOpenSessionsTTL)),
+		services.WithProfileService(profileService),
+		services.WithAchievementService(achievementService),
+		services.WithPathGraphService(pathGraphService),
+		services.WithDoorScoreRepository(doorScoreRepo),
+		// Generated doors that nearly repeat a stored door are not saved again
+		services.WithDoorDeduplicator(services.NewDoorDeduplicator(doorRepo, cfg.DoorSimilarityThreshold)),
+		services.WithThemeCatalog(themeService),
+		services.WithSessionHistory(historyService),
+		// Privacy-sensitive deployments can keep every session's answers hidden
+		services.WithResponseReveal(cfg.ResponseRevealEnabled),
+		services.WithPostUpdates(devvitService),
+		services.WithScoringStrategy(scoringStrategy),
+		services.WithExperiments(services.NewExperimentService(experiments)),
+		services.WithDoorBank(doorBank),
+		services.WithAntiCheat(antiCheat),
+		services.WithAnomalyDetection(anomalyService),
+		services.WithSessionQuota(sessionQuota),
+		services.WithSessionRoutes(routingService),
+	)
+	go deadlineScheduler.Start(ctx)
+	// Rounds in play are snapshotted to Redis, so another instance rebuilds them after a crash or deploy
+	roundSnapshots := services.NewRoundSnapshotService(repositories.NewRoundSnapshotStore(dbManager.Redis, repositories.DefaultRoundSnapshotTTL), gameService, wsManager, instanceID, cfg.RoundSnapshotInterval)
+	go roundSnapshots.Start(ctx)
+	// Connected players are sent their round deadline every WS_TIMER_TICK_INTERVAL and when they reconnect
+	timerSync := services.NewTimerSyncService(gameSessionRepo, deadlineScheduler, wsManager, cfg.WSTimerTickInterval)
+	go timerSync.Start(ctx)
+	// Draining refuses new games and lets the doors in play finish before shutdown
+	drainService := services.NewDrainService(gameSessionRepo, wsManager, deadlineScheduler, cfg.DrainWindow)
+	// Matchmaking and tournaments start new games, so they stop as soon as the instance drains
+	gamesCtx, stopStartingGames := context.WithCancel(ctx)
+	// Lobby chat arrives over the game socket; recent history is kept in Redis for reconnects
+	chatService := services.NewChatService(gameSessionRepo, repositories.NewChatStore(dbManager.Redis, repositories.DefaultChatHistoryTTL), wsManager,
+		services.WithChatModeration(moderationService),
+		services.WithChatRateLimit(cfg.ChatRateLimit, cfg.ChatRateWindow),
+	)
+	matchmakingService := services.NewMatchmakingService(
+		repositories.NewMatchmakingQueue(dbManager.Redis, repositories.DefaultTicketTTL),
+		gameService,
+		wsManager,
+		services.WithMatchmakingInterval(cfg.MatchmakingInterval),
+		services.WithMatchmakingMaxWait(cfg.MatchmakingMaxWait),
+		services.WithMatchmakingThemeCatalog(themeService),
+	)
+	go matchmakingService.Start(gamesCtx)
+	tournamentService := services.NewTournamentService(
+		repositories.NewTournamentRepository(dbManager.MongoDB),
+		gameSessionRepo,
+		gameService,
+		wsManager,
+		services.WithTournamentPollInterval(cfg.TournamentPollInterval),
+	)
+	go tournamentService.Start(gamesCtx)
+	// Sessions nobody has touched for SESSION_INACTIVITY_TIMEOUT are marked abandoned
+	sessionJanitor := services.NewSessionJanitor(gameSessionRepo, playerPathRepo, wsManager, cfg.SessionInactivityTimeout, cfg.SessionJanitorInterval,
+		services.WithJanitorSessionQuota(sessionQuota),
+		services.WithJanitorSessionRoutes(routingService),
+	)
+	go sessionJanitor.Start(ctx)
+	// Session, player and connection gauges read by autoscalers from /metrics
+	sessionMetrics := services.NewSessionMetricsReporter(gameSessionRepo, wsManager, metricsCollector, cfg.SessionMetricsInterval)
+	go sessionMetrics.Start(ctx)
+	// Player access tokens; without a configured secret they only stay valid until restart
+	tokenSecret := []byte(cfg.JWTSecret)
+	if len(tokenSecret) == 0 {
+		if cfg.Environment != "development" {
+			log.Fatalf("JWT_SECRET must be set outside development")
+		}
+		if tokenSecret, err = services.GenerateTokenSecret(); err != nil {
+			log.Fatalf("Failed to generate token secret: %v", err)
+		}
+		logger.Warn("JWT_SECRET not set; using a random secret for this process")
+	}
+	authService := services.NewAuthService(tokenSecret, cfg.JWTTTL)
+	authenticate := middleware.Authenticate(authService)
+
+	// Rate limit buckets live in Redis so limits hold across instances
+	rateLimitStore := repositories.NewRateLimitStore(dbManager.Redis)
+	limitSubmits := middleware.RateLimit(rateLimitStore, middleware.RateLimitConfig{
+		Name:   "submit",
+		Limit:  cfg.RateLimit.SubmitLimit,
+		Window: cfg.RateLimit.SubmitWindow,
+		Key:    middleware.PerPlayer,
+	})
+	limitLeaderboard := middleware.RateLimit(rateLimitStore, middleware.RateLimitConfig{
+		Name:   "leaderboard",
+		Limit:  cfg.RateLimit.LeaderboardLimit,
+		Window: cfg.RateLimit.LeaderboardWindow,
+		Key:    middleware.PerIP,
+	})
+
+	// SIGHUP reloads the log level, rate limits and timers without a restart
+	reloader := config.NewReloader(cfg, ".env")
+	reloader.OnReload(func(cfg *config.Config) {
+		logger.SetLevel(logging.LogLevel(cfg.LogLevel))
+		middleware.SetRateLimit("submit", cfg.RateLimit.SubmitLimit, cfg.RateLimit.SubmitWindow)
+		middleware.SetRateLimit("leaderboard", cfg.RateLimit.LeaderboardLimit, cfg.RateLimit.LeaderboardWindow)
+		chatService.SetRateLimit(cfg.ChatRateLimit, cfg.ChatRateWindow)
+		sessionJanitor.SetInactivityTimeout(cfg.SessionInactivityTimeout)
+		logger.Info("Configuration reloaded")
+	})
+	go reloader.Watch(ctx)
+
+	// Retried session mutations replay their first response instead of executing twice
+	idempotent := middleware.Idempotent(repositories.NewIdempotencyStore(dbManager.Redis), cfg.IdempotencyTTL)
+
+	// A draining instance sends players looking for a new game elsewhere
+	rejectWhileDraining := middleware.RejectWhileDraining(drainService)
 
 	// Initialize handlers
-	healthHandler := handlers.NewHealthHandler()
-	gameHandler := handlers.NewGameHandler(gameService, progressService, leaderboardService)
+	healthHandler := handlers.NewHealthHandler(drainService, dbManager)
+	pathMapHandler := handlers.NewPathMapHandler(services.NewPathMapService(gameSessionRepo, doorGraphRepo))
+	gameHandler := handlers.NewGameHandler(gameService, progressService, leaderboardService, draftService, auditService, routingService)
 	devvitHandler := handlers.NewDevvitHandler(devvitService)
-	wsHandler := handlers.NewWebSocketHandler(wsManager, gameService)
+	authHandler := handlers.NewAuthHandler(authService, devvitService, cfg.Environment == "development")
+	matchmakingHandler := handlers.NewMatchmakingHandler(matchmakingService)
+	tournamentHandler := handlers.NewTournamentHandler(tournamentService)
+	adminDoorHandler := handlers.NewAdminDoorHandler(services.NewDoorAdminService(doorRepo,
+		services.WithImportSimilarityThreshold(cfg.DoorSimilarityThreshold)), auditService)
+	adminModerationHandler := handlers.NewAdminModerationHandler(moderationService)
+	adminAnomalyHandler := handlers.NewAdminAnomalyHandler(anomalyService, auditService)
+	adminSessionHandler := handlers.NewAdminSessionHandler(sessionJanitor, auditService)
+	adminSessionLimitsHandler := handlers.NewAdminSessionLimitsHandler(sessionQuota, auditService)
+	adminExportHandler := handlers.NewAdminExportHandler(services.NewExportService(repositories.NewExportRepository(dbManager.MongoDB), services.DefaultExportChunkSize), auditService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	themeHandler := handlers.NewThemeHandler(themeService, auditService)
+	replayHandler := handlers.NewReplayHandler(replayService)
+	chatHandler := handlers.NewChatHandler(chatService)
+	historyHandler := handlers.NewSessionHistoryHandler(historyService)
+	resultCardHandler := handlers.NewResultCardHandler(services.NewResultCardService(gameSessionRepo, doorRepo, cfg.ResponseRevealEnabled))
+	profileHandler := handlers.NewProfileHandler(profileService)
+	achievementHandler := handlers.NewAchievementHandler(achievementService)
+	friendHandler := handlers.NewFriendHandler(friendService)
+	graphqlHandler := handlers.NewGraphQLHandler(gameService, progressService, leaderboardService, profileService)
+	wsHandler := handlers.NewWebSocketHandler(wsManager, gameService, draftService, auditService, timerSync, routingService, cfg.WSCompression)
 	errorReportingHandler := handlers.NewErrorReportingHandler()
-	monitoringHandler := handlers.NewMonitoringHandler()
+	monitoringHandler := handlers.NewMonitoringHandler(auditService)
 
 	// Create Fiber app with enhanced error handling
 	app := fiber.New(fiber.Config{
@@ -87,12 +446,14 @@ func main() {
 
 	// Enhanced middleware stack
 	app.Use(middleware.RequestID())
+	app.Use(middleware.Tracing())
 	app.Use(middleware.RecoverPanic())
 	app.Use(middleware.MetricsMiddleware())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "*",
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Request-ID",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Request-ID,Idempotency-Key,traceparent,tracestate",
+		ExposeHeaders:    "Retry-After,X-RateLimit-Limit,X-RateLimit-Remaining,Idempotent-Replayed",
 		AllowCredentials: false,
 	}))
 
@@ -128,19 +489,19 @@ func main() {
 	// Monitoring and metrics endpoints
 	app.Get("/metrics", monitoringHandler.GetMetrics)
 	app.Get("/metrics/prometheus", monitoringHandler.GetPrometheusMetrics)
-	app.Get("/metrics/system", monitoringHandler.GetSystemInfo)
-	app.Get("/metrics/performance", monitoringHandler.GetPerformanceStats)
-	app.Post("/metrics/reset", monitoringHandler.ResetMetrics)
 	
 	// Database health check endpoint
 	app.Get("/health/db", func(c *fiber.Ctx) error {
-		ctx := context.Background()
-		if err := dbManager.HealthCheck(ctx); err != nil {
-			return middleware.ServiceUnavailableError("Database health check failed").WithCause(err)
+		report := dbManager.HealthCheck(c.UserContext())
+		status := fiber.StatusOK
+		if !report.Ready() {
+			status = fiber.StatusServiceUnavailable
 		}
-		return c.JSON(fiber.Map{
-			"status":    "healthy",
-			"databases": []string{"mongodb", "neo4j", "redis"},
+		return c.Status(status).JSON(fiber.Map{
+			"status":       report.Status,
+			"checked_at":   report.CheckedAt,
+			"dependencies": report.Dependencies,
+			"pools":        dbManager.GetPoolStats(),
 		})
 	})
 
@@ -150,20 +511,39 @@ func main() {
 	
 	// Error reporting endpoint
 	api.Post("/errors", errorReportingHandler.ReportError)
-	api.Get("/errors/stats", errorReportingHandler.GetErrorStats)
 	
 	// Devvit integration routes (migrated from Express server)
 	api.Get("/init", devvitHandler.InitGame)
 
-	// Game routes
-	game := api.Group("/game")
-	game.Post("/create", gameHandler.CreateSession)
-	game.Post("/join/:sessionId", gameHandler.JoinSession)
+	// Auth routes exchange a Devvit identity for a player access token
+	api.Post("/auth/token", authHandler.IssueToken)
+
+	// Game routes act on behalf of the authenticated player
+	game := api.Group("/game", authenticate)
+	game.Post("/create", rejectWhileDraining, idempotent, gameHandler.CreateSession)
+	game.Post("/join/:sessionId", rejectWhileDraining, idempotent, gameHandler.JoinSession)
+	game.Get("/lookup/:code", gameHandler.LookupJoinCode)
+	game.Get("/sessions/open", gameHandler.ListOpenSessions)
+	game.Post("/spectate/:sessionId", gameHandler.Spectate)
 	game.Get("/status/:sessionId", gameHandler.GetSessionStatus)
+	game.Get("/resume/:sessionId/:playerId", gameHandler.ResumeSession)
+	game.Post("/ready/:sessionId", gameHandler.SetReady)
+	game.Post("/options/:sessionId", gameHandler.SetPlayerOptions)
+	game.Post("/leave/:sessionId", gameHandler.LeaveSession)
+	game.Post("/host/:sessionId/kick", gameHandler.KickPlayer)
+	game.Post("/host/:sessionId/transfer", gameHandler.TransferHost)
+	game.Post("/host/:sessionId/lock", gameHandler.LockLobby)
+	game.Post("/host/:sessionId/teams", gameHandler.AssignTeam)
+	game.Post("/host/:sessionId/teams/balance", gameHandler.BalanceTeams)
+	game.Post("/pause/:sessionId", gameHandler.PauseGame)
+	game.Post("/resume/:sessionId", gameHandler.ResumeGame)
 	game.Post("/start/:sessionId", gameHandler.StartGame)
 	game.Post("/start-with-door/:sessionId", gameHandler.StartGameWithDoor)
 	game.Get("/next-door", gameHandler.GetNextDoor)
-	game.Post("/submit-response", gameHandler.SubmitResponse)
+	game.Get("/path/:sessionId/:playerId/graph", pathMapHandler.GetPlayerGraph)
+	game.Post("/submit-response", limitSubmits, idempotent, gameHandler.SubmitResponse)
+	game.Post("/vote", gameHandler.CastVote)
+	game.Put("/draft", gameHandler.SaveDraft)
 	
 	// Progress tracking routes
 	game.Get("/progress/:sessionId", gameHandler.GetSessionProgress)
@@ -171,19 +551,99 @@ func main() {
 	game.Get("/progress/:sessionId/realtime", gameHandler.GetRealTimeProgress)
 	game.Post("/progress/:sessionId/broadcast", gameHandler.BroadcastProgressUpdate)
 	game.Get("/leaderboard/:sessionId", gameHandler.GetLeaderboard)
+	game.Get("/replay/:sessionId", replayHandler.GetReplay)
+	game.Get("/replay/:sessionId/stream", replayHandler.StreamReplay)
+	game.Get("/chat/:sessionId", chatHandler.GetHistory)
+	game.Get("/history/:sessionId", historyHandler.GetHistory)
+	game.Get("/summary/:sessionId", resultCardHandler.GetSummary)
+	// Server-Sent Events fallback for clients that can't hold a WebSocket open
+	game.Get("/events/:sessionId/:playerId", wsHandler.StreamEvents)
 	
-	// Global leaderboard routes
-	api.Get("/leaderboard", gameHandler.GetGlobalLeaderboard)
-	api.Get("/leaderboard/stats", gameHandler.GetLeaderboardStats)
-	api.Get("/leaderboard/fastest", gameHandler.GetFastestCompletions)
-	api.Get("/leaderboard/highest-averages", gameHandler.GetHighestAverageScores)
-	api.Get("/leaderboard/player/:playerId/rank/:category", gameHandler.GetPlayerRank)
+	// Matchmaking routes
+	matchmaking := api.Group("/matchmaking", authenticate)
+	matchmaking.Post("/enqueue", rejectWhileDraining, matchmakingHandler.Enqueue)
+	matchmaking.Get("/status/:playerId", matchmakingHandler.GetStatus)
+	matchmaking.Delete("/:playerId", matchmakingHandler.Cancel)
+
+	// Tournament routes
+	tournament := api.Group("/tournament", authenticate)
+	tournament.Post("/create", rejectWhileDraining, tournamentHandler.CreateTournament)
+	tournament.Post("/join", rejectWhileDraining, tournamentHandler.JoinTournament)
+	tournament.Get("/status/:id", tournamentHandler.GetTournamentStatus)
+
+	// Admin routes are limited to staff: the players listed in ADMIN_PLAYER_IDS and
+	// MODERATOR_PLAYER_IDS presenting one of the STAFF_API_KEYS, and services presenting one of
+	// the SERVICE_API_KEYS. Admins may call every route; moderators curate content and services
+	// run diagnostics and broadcasts.
+	admin := api.Group("/admin", middleware.Authorize(authService, middleware.RoleConfig{
+		AdminPlayerIDs:     cfg.AdminPlayerIDs,
+		ModeratorPlayerIDs: cfg.ModeratorPlayerIDs,
+		ServiceKeys:        cfg.ServiceAPIKeys,
+		StaffKeys:          cfg.StaffAPIKeys,
+	}))
+	adminOnly := middleware.RequireRole(middleware.RoleAdmin)
+	moderators := middleware.RequireRole(middleware.RoleModerator)
+	automation := middleware.RequireRole(middleware.RoleService)
+	admin.Get("/doors", moderators, adminDoorHandler.ListDoors)
+	admin.Post("/doors", moderators, adminDoorHandler.CreateDoor)
+	admin.Post("/doors/import", moderators, adminDoorHandler.ImportDoors)
+	admin.Get("/doors/:doorId", moderators, adminDoorHandler.GetDoor)
+	admin.Put("/doors/:doorId", moderators, adminDoorHandler.UpdateDoor)
+	admin.Put("/doors/:doorId/status", moderators, adminDoorHandler.UpdateDoorStatus)
+	admin.Delete("/doors/:doorId", moderators, adminDoorHandler.DeleteDoor)
+	admin.Get("/moderation/events", moderators, adminModerationHandler.ListEvents)
+	admin.Get("/anomalies", moderators, adminAnomalyHandler.ListFlaggedPlayers)
+	admin.Post("/anomalies/:playerId/clear", moderators, adminAnomalyHandler.ClearPlayer)
+	admin.Get("/themes", moderators, themeHandler.ListAllThemes)
+	admin.Post("/themes", moderators, themeHandler.CreateTheme)
+	admin.Get("/themes/:themeId", moderators, themeHandler.GetTheme)
+	admin.Put("/themes/:themeId", moderators, themeHandler.UpdateTheme)
+	admin.Delete("/themes/:themeId", moderators, themeHandler.DeleteTheme)
+	admin.Get("/sessions", automation, adminSessionHandler.ListSessions)
+	admin.Post("/sessions/expire", automation, adminSessionHandler.ExpireInactiveSessions)
+	admin.Post("/sessions/:sessionId/expire", adminOnly, adminSessionHandler.ExpireSession)
+	admin.Post("/sessions/:sessionId/broadcast", automation, wsHandler.BroadcastMessage)
+	admin.Get("/session-limits", automation, adminSessionLimitsHandler.GetLimits)
+	admin.Put("/session-limits", adminOnly, adminSessionLimitsHandler.UpdateLimits)
+	admin.Get("/export/leaderboard", moderators, adminExportHandler.ExportLeaderboard)
+	admin.Get("/export/sessions", moderators, adminExportHandler.ExportSessions)
+	admin.Get("/metrics/system", automation, monitoringHandler.GetSystemInfo)
+	admin.Get("/metrics/performance", automation, monitoringHandler.GetPerformanceStats)
+	admin.Get("/metrics/rate-limits", automation, monitoringHandler.GetRateLimitStats)
+	admin.Get("/errors/stats", automation, errorReportingHandler.GetErrorStats)
+	admin.Post("/metrics/reset", adminOnly, monitoringHandler.ResetMetrics)
+	admin.Get("/audit", adminOnly, auditHandler.ListEvents)
+	
+	// The theme catalog is public so players can pick a theme before signing in
+	api.Get("/themes", limitLeaderboard, themeHandler.ListThemes)
+	
+	// Global leaderboard routes are public, so they are limited per IP
+	api.Get("/leaderboard", limitLeaderboard, gameHandler.GetGlobalLeaderboard)
+	api.Get("/leaderboard/stats", limitLeaderboard, gameHandler.GetLeaderboardStats)
+	api.Get("/leaderboard/fastest", limitLeaderboard, gameHandler.GetFastestCompletions)
+	api.Get("/leaderboard/highest-averages", limitLeaderboard, gameHandler.GetHighestAverageScores)
+	api.Get("/leaderboard/player/:playerId/rank/:category", limitLeaderboard, gameHandler.GetPlayerRank)
+	
+	// Player profiles are public too
+	players := api.Group("/players")
+	players.Get("/:playerId/profile", limitLeaderboard, profileHandler.GetProfile)
+	players.Get("/:playerId/achievements", limitLeaderboard, achievementHandler.GetAchievements)
+	
+	// Friend lists belong to the authenticated player
+	friends := api.Group("/friends", authenticate)
+	friends.Get("/:playerId", friendHandler.ListFriends)
+	friends.Post("/:playerId", friendHandler.AddFriend)
+	friends.Delete("/:playerId/:friendId", friendHandler.RemoveFriend)
+
+	// GraphQL answers read-only queries that combine sessions, progress, leaderboards and profiles
+	api.Get("/graphql", authenticate, graphqlHandler.Query)
+	api.Post("/graphql", authenticate, graphqlHandler.Query)
 
 	// WebSocket routes
-	ws := api.Group("/ws")
+	ws := api.Group("/ws", authenticate)
 	ws.Get("/connect", wsHandler.UpgradeConnection)
 	ws.Get("/status/:sessionId", wsHandler.GetConnectionStatus)
-	ws.Post("/broadcast/:sessionId", wsHandler.BroadcastMessage)
+	ws.Get("/route/:sessionId", wsHandler.GetSessionRoute)
 
 	// Internal Devvit routes
 	internal := app.Group("/internal")
@@ -217,7 +677,19 @@ func main() {
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	<-sigChan
 
-	logger.Info("Shutdown signal received, starting graceful shutdown")
+	logger.Info("Shutdown signal received, draining")
+	
+	// Stop taking new games and give the doors in play up to DRAIN_WINDOW to finish
+	stopStartingGames()
+	if err := drainService.Drain(context.Background()); err != nil {
+		logger.Error("Drain failed", err)
+	}
+	// Players reconnecting are no longer routed to this instance
+	if err := routingService.ReleaseAll(context.Background()); err != nil {
+		logger.Error("Failed to release session routes", err)
+	}
+	
+	logger.Info("Drain complete, starting graceful shutdown")
 	
 	// Cancel context to stop background tasks
 	cancel()
@@ -231,6 +703,16 @@ func main() {
 	} else {
 		logger.Info("Server shutdown completed successfully")
 	}
+	
+	// Let queued background work finish before closing database connections
+	if err := workerPool.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Worker pool shutdown failed", err)
+	}
+	
+	// Flush spans still buffered for export
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Tracing shutdown failed", err)
+	}
 }
 
 // Note: Custom error handler removed - now using middleware.ErrorHandler()
\ No newline at end of file
@@ -121,7 +121,11 @@ export const GameBoard = ({ session, currentUser, playerId, onResponseSubmitted,
 
       const res = await fetch('/api/game/submit-response', {
         method: 'POST',
-        headers: { 'Content-Type': 'application/json' },
+        headers: {
+          'Content-Type': 'application/json',
+          // One key per door, so a double-tap replays the first submission instead of failing
+          'Idempotency-Key': `${session.sessionId}:${session.currentDoor?.doorId ?? 'none'}`,
+        },
         body: JSON.stringify(request)
       });
 
@@ -20,7 +20,13 @@ export type DecrementResponse = {
 // Game-related types
 export type GameMode = 'multiplayer' | 'single-player';
 
-export type GameStatus = 'waiting' | 'active' | 'completed';
+export type ScoringMode = 'ai' | 'peer-vote';
+
+export interface SessionSettings {
+  scoringMode?: ScoringMode;
+}
+
+export type GameStatus = 'waiting' | 'active' | 'scoring' | 'revealing' | 'paused' | 'completed' | 'abandoned';
 
 export interface PlayerInfo {
   playerId: string;
@@ -31,6 +37,13 @@ export interface PlayerInfo {
   totalScore: number;
   responses: PlayerResponse[];
   isActive: boolean;
+  currentDoor?: Door;
+}
+
+export interface SpectatorInfo {
+  spectatorId: string;
+  username: string;
+  joinedAt: string;
 }
 
 export interface Door {
@@ -38,7 +51,18 @@ export interface Door {
   content: string;
   theme: string;
   difficulty: number;
+  locale?: string;
   expectedSolutionTypes: string[];
+  accessibility?: DoorAccessibility;
+  status?: DoorStatus;
+}
+
+export type DoorStatus = 'draft' | 'approved' | 'published';
+
+export interface DoorAccessibility {
+  altText: string;
+  readingLevel?: number;
+  contentWarnings: string[];
 }
 
 export interface PlayerResponse {
@@ -48,6 +72,9 @@ export interface PlayerResponse {
   content: string;
   aiScore: number;
   submittedAt: string;
+  votes?: ResponseVote[];
+  scoringPending?: boolean;
+  feedback?: string;
   scoringMetrics: {
     creativity: number;
     feasibility: number;
@@ -56,13 +83,24 @@ export interface PlayerResponse {
   };
 }
 
+export interface ResponseVote {
+  voterId: string;
+  stars: number;
+  castAt: string;
+}
+
 export interface GameSession {
   sessionId: string;
   mode: GameMode;
   theme?: string;
   players: PlayerInfo[];
+  spectators?: SpectatorInfo[];
   status: GameStatus;
   currentDoor?: Door;
+  roundDeadline?: string;
+  settings?: SessionSettings;
+  voteDeadline?: string;
+  winnerId?: string;
   createdAt: string;
   startedAt?: string;
   completedAt?: string;
@@ -71,6 +109,7 @@ export interface GameSession {
 export interface CreateSessionRequest {
   mode: GameMode;
   theme?: string;
+  scoringMode?: ScoringMode;
 }
 
 export interface CreateSessionResponse {
@@ -97,6 +136,80 @@ export interface SubmitResponseResponse {
   success: boolean;
 }
 
+export interface CastVoteRequest {
+  sessionId: string;
+  responseId: string;
+  stars: number;
+}
+
+// Replay types
+export interface ReplayEvent {
+  sessionId: string;
+  sequence: number;
+  type: string;
+  playerId?: string;
+  data: unknown;
+  at: string;
+}
+
+export interface ReplayPage {
+  sessionId: string;
+  events: ReplayEvent[];
+  total: number;
+  page: number;
+  pageSize: number;
+}
+
+// Chat types
+export interface ChatMessage {
+  messageId: string;
+  sessionId: string;
+  playerId: string;
+  username: string;
+  text: string;
+  sentAt: string;
+}
+
+// Tournament types
+export type TournamentStatus = 'registering' | 'in_progress' | 'completed';
+
+export interface TournamentPlayer {
+  playerId: string;
+  username: string;
+  seed: number;
+  joinedAt: string;
+  eliminatedInRound?: number;
+}
+
+export interface TournamentMatch {
+  matchId: string;
+  playerIds: string[];
+  sessionId?: string;
+  winnerId?: string;
+  completedAt?: string;
+}
+
+export interface TournamentRound {
+  number: number;
+  matches: TournamentMatch[];
+}
+
+export interface Tournament {
+  tournamentId: string;
+  name: string;
+  theme?: string;
+  size: 8 | 16;
+  status: TournamentStatus;
+  createdBy: string;
+  players: TournamentPlayer[];
+  rounds: TournamentRound[];
+  currentRound: number;
+  winnerId?: string;
+  createdAt: string;
+  startedAt?: string;
+  completedAt?: string;
+}
+
 // Leaderboard and Results types
 export interface LeaderboardEntry {
   id: string;
@@ -0,0 +1,37 @@
+#!/bin/bash
+
+# DumDoors Hot Path Benchmark Script
+#
+# Runs the game hot path benchmarks (8 players x 15 doors) and stores the results
+# under backend/benchmarks/ named by date and commit, so runs can be compared over
+# time. When benchstat is installed the new run is compared against the previous one.
+
+set -e
+
+# Configuration
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+BACKEND_DIR="$SCRIPT_DIR/../backend"
+RESULTS_DIR="$BACKEND_DIR/benchmarks"
+BENCH_PATTERN=${BENCH_PATTERN:-"SubmitResponse|CalculateSessionProgress|CalculateFinalRankings|CalculatePerformanceStatistics|SortPlayerRankings"}
+BENCH_COUNT=${BENCH_COUNT:-6}
+
+mkdir -p "$RESULTS_DIR"
+
+COMMIT=$(git -C "$BACKEND_DIR" rev-parse --short HEAD 2>/dev/null || echo "unknown")
+RESULT_FILE="$RESULTS_DIR/$(date +"%Y%m%d_%H%M%S")_$COMMIT.txt"
+PREVIOUS_FILE=$(ls -1 "$RESULTS_DIR"/*.txt 2>/dev/null | tail -n 1 || true)
+
+echo "⏱️  Running hot path benchmarks at commit $COMMIT..."
+
+(cd "$BACKEND_DIR" && go test ./internal/services/ -run '^$' -bench "$BENCH_PATTERN" -benchmem -count "$BENCH_COUNT") | tee "$RESULT_FILE"
+
+echo "📄 Results saved to $RESULT_FILE"
+
+if [ -n "$PREVIOUS_FILE" ]; then
+  if command -v benchstat &> /dev/null; then
+    echo "📊 Comparing with $(basename "$PREVIOUS_FILE")..."
+    benchstat "$PREVIOUS_FILE" "$RESULT_FILE"
+  else
+    echo "💡 Install benchstat to compare runs: go install golang.org/x/perf/cmd/benchstat@latest"
+  fi
+fi